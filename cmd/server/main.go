@@ -1,21 +1,44 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/slamchillz/getinstashop-ecommerce-api/config"
 	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/events"
 	"github.com/slamchillz/getinstashop-ecommerce-api/internal/handlers"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/jobs"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/mailer"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/orderstream"
 	"github.com/slamchillz/getinstashop-ecommerce-api/internal/routers"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/storage"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/thumbnail"
 	"github.com/slamchillz/getinstashop-ecommerce-api/pkg/token"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 )
 
 // Server struct
 type Server struct {
-	config  config.Config
-	token   *token.JWT
-	router  *gin.Engine
-	store   db.Store
-	handler *handlers.AllHandler
+	config     config.Config
+	token      *token.JWT
+	router     *gin.Engine
+	store      db.Store
+	blobs      storage.BlobStore
+	thumbnails *thumbnail.Generator
+	workers    *jobs.Pool
+	bus        *events.Bus
+	dispatcher *events.Dispatcher
+	orders     *orderstream.Broker
+	handler    *handlers.AllHandler
 }
 
 // NewServer Create a new server instance
@@ -24,14 +47,142 @@ func NewServer(config config.Config, store db.Store) (*Server, error) {
 	if err != nil {
 		return nil, err
 	}
-	server := &Server{config: config, token: jwt, store: store}
+	blobs, err := newBlobStore(config)
+	if err != nil {
+		return nil, err
+	}
+	sizes, err := thumbnail.ParseSizes(config.ThumbnailSizes)
+	if err != nil {
+		return nil, err
+	}
+	thumbnails := thumbnail.NewGenerator(blobs, thumbnail.Config{Sizes: sizes, OnUpload: config.ThumbnailOnUpload})
+	// The workers only start with the server, so that tests can build one freely
+	workers := jobs.NewPool(store, jobs.Config{Workers: config.JobWorkers, PollInterval: config.JobPollInterval})
+	mail, err := newMailer(config)
+	if err != nil {
+		return nil, err
+	}
+	services.RegisterJobHandlers(workers, store, blobs, thumbnails, mail, jwt)
+	bus := events.NewBus()
+	services.RegisterEventHandlers(bus, store, splitList(config.LowStockEmails))
+	sinks, err := newEventSinks(config, store)
+	if err != nil {
+		return nil, err
+	}
+	dispatcher := events.NewDispatcher(store, events.Config{PollInterval: config.EventPollInterval}, append([]events.Sink{bus}, sinks...)...)
+	server := &Server{
+		config:     config,
+		token:      jwt,
+		store:      store,
+		blobs:      blobs,
+		thumbnails: thumbnails,
+		workers:    workers,
+		bus:        bus,
+		dispatcher: dispatcher,
+		orders:     orderstream.NewBroker(store, orderstream.Config{Heartbeat: config.OrderStreamHeartbeat}),
+	}
 	server.setupHandler().setupRouter()
 	return server, nil
 }
 
+// Create the blob store uploaded files are kept in
+func newBlobStore(config config.Config) (storage.BlobStore, error) {
+	switch config.BlobStore {
+	case "", "local":
+		dir, publicURL := config.BlobLocalDir, config.BlobPublicURL
+		if dir == "" {
+			dir = "uploads"
+		}
+		if publicURL == "" {
+			publicURL = "/api/v1/media"
+		}
+		return storage.NewLocalStore(dir, publicURL), nil
+	case "s3":
+		return storage.NewS3Store(storage.S3Config{
+			Endpoint:        config.S3Endpoint,
+			Region:          config.S3Region,
+			Bucket:          config.S3Bucket,
+			AccessKeyID:     config.S3AccessKeyID,
+			SecretAccessKey: config.S3SecretAccessKey,
+			PathStyle:       config.S3UsePathStyle,
+			PublicURL:       config.BlobPublicURL,
+		})
+	}
+	return nil, fmt.Errorf("unknown blob store %q", config.BlobStore)
+}
+
+// splitList splits a comma separated list, dropping blank items
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Create the mailer the notification emails are sent with
+func newMailer(config config.Config) (mailer.Mailer, error) {
+	from := config.MailFrom
+	if from == "" {
+		from = "InstaShop <no-reply@instashop.local>"
+	}
+	switch config.Mailer {
+	case "", "file":
+		dir := config.MailFileDir
+		if dir == "" {
+			dir = "mail"
+		}
+		return mailer.NewFileMailer(dir, from), nil
+	case "smtp":
+		if config.SMTPHost == "" {
+			return nil, errors.New("SMTP_HOST is required by the smtp mailer")
+		}
+		return mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     config.SMTPHost,
+			Port:     config.SMTPPort,
+			Username: config.SMTPUsername,
+			Password: config.SMTPPassword,
+			From:     from,
+		}), nil
+	}
+	return nil, fmt.Errorf("unknown mailer %q", config.Mailer)
+}
+
+// Create the sinks the outbox events are relayed to. A Kafka sink needs a
+// client for the brokers, so it is added in code with events.NewKafkaSink.
+func newEventSinks(config config.Config, store db.Store) ([]events.Sink, error) {
+	names := config.EventSinks
+	if names == "" {
+		names = "webhook"
+	}
+	var sinks []events.Sink
+	for _, name := range strings.Split(names, ",") {
+		switch strings.TrimSpace(name) {
+		case "webhook":
+			sinks = append(sinks, events.NewWebhookSink(store))
+		case "log":
+			sinks = append(sinks, events.LogSink{})
+		case "nats":
+			if config.NatsURL == "" {
+				return nil, errors.New("NATS_URL is required by the nats event sink")
+			}
+			sinks = append(sinks, events.NewNATSSink(events.NATSConfig{
+				URL:           config.NatsURL,
+				SubjectPrefix: config.NatsSubjectPrefix,
+			}))
+		case "", "none":
+		default:
+			return nil, fmt.Errorf("unknown event sink %q", name)
+		}
+	}
+	return sinks, nil
+}
+
 // Instantiate all handlers
 func (server *Server) setupHandler() *Server {
-	server.handler = handlers.RegisterHandlers(server.store, server.token)
+	server.handler = handlers.RegisterHandlers(server.store, server.token, server.blobs, server.thumbnails, server.orders)
 	return server
 }
 
@@ -49,7 +200,44 @@ func (server *Server) TokenCreator() *token.JWT {
 	return server.token
 }
 
-// Start server on the given address
+// Events returns the in-process bus the committed domain events are published on.
+func (server *Server) Events() *events.Bus {
+	return server.bus
+}
+
+// Start server on the given address along with the job workers and the event dispatcher. On SIGINT or
+// SIGTERM it stops taking requests, jobs and events, and waits for the ones in
+// flight to finish before returning.
 func (server *Server) Start() error {
-	return server.router.Run(server.config.HTTPServerAddress)
+	httpServer := &http.Server{Addr: server.config.HTTPServerAddress, Handler: server.router}
+	// Order streams never go idle, so they are ended for the shutdown to complete
+	httpServer.RegisterOnShutdown(server.orders.Close)
+	server.workers.Start()
+	server.dispatcher.Start()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("Listening on %s", httpServer.Addr)
+		serveErr <- httpServer.ListenAndServe()
+	}()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+	select {
+	case err = <-serveErr:
+	case <-ctx.Done():
+		log.Println("Shutting down")
+	}
+	timeout := server.config.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err == nil {
+		err = httpServer.Shutdown(shutdownCtx)
+	}
+	server.orders.Close()
+	return errors.Join(err, server.workers.Shutdown(shutdownCtx), server.dispatcher.Shutdown(shutdownCtx))
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"github.com/spf13/viper"
+	"time"
 )
 
 // Config stores all configuration of the application.
@@ -12,6 +13,42 @@ type Config struct {
 	DatabaseURL       string `mapstructure:"DATABASE_URL"`
 	MigrationURL      string `mapstructure:"MIGRATION_URL"`
 	JwtSecret         string `mapstructure:"JWT_SECRET"`
+	// BlobStore selects where uploaded files are kept: "local" (default) or "s3"
+	BlobStore         string `mapstructure:"BLOB_STORE"`
+	BlobLocalDir      string `mapstructure:"BLOB_LOCAL_DIR"`
+	BlobPublicURL     string `mapstructure:"BLOB_PUBLIC_URL"`
+	S3Endpoint        string `mapstructure:"S3_ENDPOINT"`
+	S3Region          string `mapstructure:"S3_REGION"`
+	S3Bucket          string `mapstructure:"S3_BUCKET"`
+	S3AccessKeyID     string `mapstructure:"S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey string `mapstructure:"S3_SECRET_ACCESS_KEY"`
+	S3UsePathStyle    bool   `mapstructure:"S3_USE_PATH_STYLE"`
+	// ThumbnailSizes lists the thumbnail sizes as name:maxDimension pairs, e.g. small:160,medium:480
+	ThumbnailSizes    string `mapstructure:"THUMBNAIL_SIZES"`
+	ThumbnailOnUpload bool   `mapstructure:"THUMBNAIL_ON_UPLOAD"`
+	// JobWorkers is the number of background jobs run at the same time
+	JobWorkers      int           `mapstructure:"JOB_WORKERS"`
+	JobPollInterval time.Duration `mapstructure:"JOB_POLL_INTERVAL"`
+	// EventSinks lists the sinks the outbox events are relayed to besides the in-process bus:
+	// webhook, log or nats, e.g. webhook,log. Defaults to webhook.
+	EventSinks        string        `mapstructure:"EVENT_SINKS"`
+	EventPollInterval time.Duration `mapstructure:"EVENT_POLL_INTERVAL"`
+	NatsURL           string        `mapstructure:"NATS_URL"`
+	NatsSubjectPrefix string        `mapstructure:"NATS_SUBJECT_PREFIX"`
+	// Mailer selects how emails are sent: "file" (default) writes them to MailFileDir, "smtp" sends them
+	Mailer       string `mapstructure:"MAILER"`
+	MailFrom     string `mapstructure:"MAIL_FROM"`
+	MailFileDir  string `mapstructure:"MAIL_FILE_DIR"`
+	SMTPHost     string `mapstructure:"SMTP_HOST"`
+	SMTPPort     int    `mapstructure:"SMTP_PORT"`
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+	// LowStockEmails lists, comma separated, who is emailed when a product runs low on stock. Nobody is when empty
+	LowStockEmails string `mapstructure:"LOW_STOCK_EMAILS"`
+	// OrderStreamHeartbeat is how often idle order streams send a heartbeat
+	OrderStreamHeartbeat time.Duration `mapstructure:"ORDER_STREAM_HEARTBEAT"`
+	// ShutdownTimeout bounds how long requests and running jobs get to finish on shutdown
+	ShutdownTimeout time.Duration `mapstructure:"SHUTDOWN_TIMEOUT"`
 }
 
 // LoadConfig reads configuration from file or environment variables.
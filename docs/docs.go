@@ -24,14 +24,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/orders/{orderId}": {
-            "patch": {
+        "/admin/coupons": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the status of any order. Requires admin privilege",
+                "description": "List all coupons. Requires admin privilege",
                 "consumes": [
                     "application/json"
                 ],
@@ -39,44 +39,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
-                ],
-                "summary": "Updates the status of any order. Requires admin privilege",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Unique uuid of the order whose status is to be updated",
-                        "name": "orderId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "The new status of the order",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.UpdateOrderStatusInput"
-                        }
-                    }
+                    "coupon"
                 ],
+                "summary": "List all coupons. Requires admin privilege",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.Order"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/types.OrderCancelError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/types.OrderCancelError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.Coupon"
+                            }
                         }
                     },
                     "500": {
@@ -86,16 +59,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/products": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new product. Requires admin privilege",
+                "description": "Create a new percentage or fixed amount coupon. Requires admin privilege",
                 "consumes": [
                     "application/json"
                 ],
@@ -103,31 +74,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "coupon"
                 ],
-                "summary": "Create a new product. Requires admin privilege",
+                "summary": "Create a new coupon. Requires admin privilege",
                 "parameters": [
                     {
-                        "description": "Create Product request body",
+                        "description": "Create Coupon request body",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.CreateProductInput"
+                            "$ref": "#/definitions/types.CreateCouponInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/types.Product"
+                            "$ref": "#/definitions/types.Coupon"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ProductError"
+                            "$ref": "#/definitions/types.CouponError"
                         }
                     },
                     "500": {
@@ -139,14 +110,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/products/{productId}": {
+        "/admin/coupons/{couponId}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Fetch One Product. Requires admin privilege",
+                "description": "Fetch One Coupon. Requires admin privilege",
                 "consumes": [
                     "application/json"
                 ],
@@ -154,14 +125,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "coupon"
                 ],
-                "summary": "Fetch One Product. Requires admin privilege",
+                "summary": "Fetch One Coupon. Requires admin privilege",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Unique product id",
-                        "name": "productId",
+                        "description": "Unique coupon id",
+                        "name": "couponId",
                         "in": "path",
                         "required": true
                     }
@@ -170,13 +141,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.Product"
+                            "$ref": "#/definitions/types.Coupon"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/types.ProductError"
+                            "$ref": "#/definitions/types.CouponError"
                         }
                     },
                     "500": {
@@ -193,7 +164,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a single Product. Requires admin privilege",
+                "description": "Update a single Coupon. Only the fields present in the body are changed. Requires admin privilege",
                 "consumes": [
                     "application/json"
                 ],
@@ -201,24 +172,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "coupon"
                 ],
-                "summary": "Update a single Product. Requires admin privilege",
+                "summary": "Update a single Coupon. Requires admin privilege",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Unique product id",
-                        "name": "productId",
+                        "description": "Unique coupon id",
+                        "name": "couponId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update Product request body",
+                        "description": "Update Coupon request body",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.CreateProductInput"
+                            "$ref": "#/definitions/types.CouponUpdateInput"
                         }
                     }
                 ],
@@ -226,13 +197,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.Product"
+                            "$ref": "#/definitions/types.Coupon"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ProductError"
+                            "$ref": "#/definitions/types.CouponError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.CouponError"
                         }
                     },
                     "500": {
@@ -249,7 +226,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete One Product. Requires admin privilege",
+                "description": "Delete One Coupon. A coupon that has been redeemed cannot be deleted, deactivate it instead. Requires admin privilege",
                 "consumes": [
                     "application/json"
                 ],
@@ -257,14 +234,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "coupon"
                 ],
-                "summary": "Delete One Product. Requires admin privilege",
+                "summary": "Delete One Coupon. Requires admin privilege",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Unique product id",
-                        "name": "productId",
+                        "description": "Unique coupon id",
+                        "name": "couponId",
                         "in": "path",
                         "required": true
                     }
@@ -276,7 +253,13 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ProductError"
+                            "$ref": "#/definitions/types.CouponError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.CouponError"
                         }
                     },
                     "500": {
@@ -288,9 +271,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "User Login. Generates an access token for a valid user.",
+        "/admin/inventory/low-stock": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch the products whose stock is at or below their reorder threshold, those furthest below it first, with the alert opened when they ran low. Requires admin privilege",
                 "consumes": [
                     "application/json"
                 ],
@@ -298,37 +286,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "inventory"
                 ],
-                "summary": "User Login. Generates an access token for a valid user.",
+                "summary": "Fetch the products low on stock. Requires admin privilege",
                 "parameters": [
                     {
-                        "description": "Login request body",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.LoginUserInput"
-                        }
+                        "type": "string",
+                        "description": "Only the products of this category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of products to return, 100 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.LoginUserOutput"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.LowStockProduct"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.LoginUserError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/types.LoginUserError"
+                            "$ref": "#/definitions/types.InventoryError"
                         }
                     },
                     "500": {
@@ -340,9 +328,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "New user signup. Register a new user",
+        "/admin/inventory/reconciliation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the products whose stock is not the sum of their stock movements, or not the sum of what the warehouses hold. The list is empty when they all agree. Requires admin privilege",
                 "consumes": [
                     "application/json"
                 ],
@@ -350,31 +343,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
-                ],
-                "summary": "New user signup. Create a new user",
-                "parameters": [
-                    {
-                        "description": "Register request body",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.RegisterUserInput"
-                        }
-                    }
+                    "inventory"
                 ],
+                "summary": "Check the stock of the products against their stock ledger. Requires admin privilege",
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/types.CreateUserOk"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.CreateUserError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.StockDiscrepancy"
+                            }
                         }
                     },
                     "500": {
@@ -386,14 +365,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders": {
+        "/admin/jobs": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Fetch all orders placed by a user",
+                "description": "Fetch the latest background jobs, newest first, optionally only those in a given status. DEAD jobs failed every attempt and wait to be retried. Requires admin privilege",
                 "consumes": [
                     "application/json"
                 ],
@@ -401,23 +380,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "job"
+                ],
+                "summary": "Fetch background jobs. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "PENDING, RUNNING, SUCCEEDED or DEAD",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of jobs to return, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Fetch all orders placed by a user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/types.Order"
+                                "$ref": "#/definitions/types.Job"
                             }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.OrderError"
+                            "$ref": "#/definitions/types.JobError"
                         }
                     },
                     "500": {
@@ -427,14 +420,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/jobs/stats": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Place an order for one or more Product",
+                "description": "Count the background jobs in each status, e.g. to alert on DEAD jobs. Requires admin privilege",
                 "consumes": [
                     "application/json"
                 ],
@@ -442,31 +437,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
-                ],
-                "summary": "Place an order for one or more Product",
-                "parameters": [
-                    {
-                        "description": "Create Order request body",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.CreateOrderInput"
-                        }
-                    }
+                    "job"
                 ],
+                "summary": "Count background jobs by status. Requires admin privilege",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.Order"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/types.OrderError"
+                            "$ref": "#/definitions/types.JobStats"
                         }
                     },
                     "500": {
@@ -478,14 +456,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{orderId}": {
-            "patch": {
+        "/admin/jobs/{jobId}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Cancels an order only if it is in PENDING state",
+                "description": "Fetch a background job, e.g. to follow an async product import. Requires admin privilege",
                 "consumes": [
                     "application/json"
                 ],
@@ -493,14 +471,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "job"
                 ],
-                "summary": "Cancels an order only if it is in PENDING state",
+                "summary": "Fetch a background job. Requires admin privilege",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Unique uuid of the order whose status is to be cancelled",
-                        "name": "orderId",
+                        "description": "Unique job id",
+                        "name": "jobId",
                         "in": "path",
                         "required": true
                     }
@@ -509,13 +487,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.Order"
+                            "$ref": "#/definitions/types.Job"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/types.OrderCancelError"
+                            "$ref": "#/definitions/types.JobError"
                         }
                     },
                     "500": {
@@ -527,14 +505,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/products": {
-            "get": {
+        "/admin/jobs/{jobId}/retry": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "List all products. None admin users should be able to see products before placing an order.",
+                "description": "Queue a DEAD job again with a fresh set of attempts, e.g. once what made it fail is fixed. Requires admin privilege",
                 "consumes": [
                     "application/json"
                 ],
@@ -542,23 +520,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "job"
+                ],
+                "summary": "Retry a dead background job. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique job id",
+                        "name": "jobId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List all products. None admin users should be able to see products before placing an order.",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/types.Product"
-                            }
+                            "$ref": "#/definitions/types.Job"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/types.ProductError"
+                            "$ref": "#/definitions/types.JobError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.JobError"
                         }
                     },
                     "500": {
@@ -569,81 +559,7895 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "db.OrderStatus": {
-            "type": "string",
-            "enum": [
-                "PENDING",
-                "COMPLETED",
-                "CANCELLED"
-            ],
-            "x-enum-varnames": [
-                "OrderStatusPENDING",
-                "OrderStatusCOMPLETED",
-                "OrderStatusCANCELLED"
-            ]
         },
-        "types.CreateOrderInput": {
-            "type": "object",
-            "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.Item"
-                    }
-                }
-            }
+        "/admin/orders/{orderId}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the status of any order. Requires admin privilege. PENDING orders can be moved to COMPLETED, and orders that have not shipped can be cancelled",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Updates the status of any order. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique uuid of the order whose status is to be updated",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The new status of the order",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateOrderStatusInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.OrderCancelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.OrderCancelError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.OrderCancelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/orders/{orderId}/allocations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch how many units of each product of an order are shipped from each warehouse. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Fetch the warehouses an order is shipped from. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique order id",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.OrderAllocation"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WarehouseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/orders/{orderId}/shipments": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ship the given quantity of each product of a COMPLETED or PARTIALLY_SHIPPED order, or everything left to ship when no items are given. The order moves to PARTIALLY_SHIPPED or SHIPPED automatically. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipment"
+                ],
+                "summary": "Ship all or part of an order. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique uuid of the order being shipped",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Shipment request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateShipmentInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.Shipment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShipmentError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShipmentError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/products": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new product. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Create a new product. Requires admin privilege",
+                "parameters": [
+                    {
+                        "description": "Create Product request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateProductInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/products/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download every product, streamed as it is read so that large catalogs export quickly. The file has the columns accepted by the import. Requires admin privilege",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Export the catalog as a CSV or JSON Lines file. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (default) or jsonl",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductImportError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/products/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create or update products in bulk. Send the file as the request body with a text/csv or application/x-ndjson content type, or as the \"file\" field of a multipart form. CSV files start with a header naming their columns among id, sku, name, description, price, stock, category, taxClass and weight; name, description and price are required. A product with the same sku, or else the same name, is updated, any other is created. Every row is validated and the import is saved in a single transaction, so an invalid row saves nothing. Set dryRun to check a file without saving it, or async to check it and leave saving it to a background job whose id is returned. The files written by the export can be imported as they are. Requires admin privilege",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Import products from a CSV or JSON Lines file. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv or jsonl, detected from the content type or file name when omitted",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the file and report what would change without saving anything",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the file, then save it in the background and return the id of the job",
+                        "name": "async",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Catalog file, when sent as a multipart form",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductImportOutput"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductImportOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductImportError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/products/{productId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch One Product. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Fetch One Product. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a single Product. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Update a single Product. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the product as fetched, or * to overwrite any version",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Product request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateProductInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductError"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Archive One Product. It is hidden from the catalog but kept for the orders it is in, and can be restored. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Delete One Product. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/products/{productId}/images": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a JPEG, PNG, GIF or WebP image of at most 5 MB as the \"image\" field of a multipart form. The image is added after the existing images of the product; set \"primary\" to true to make it the main image. The first image of a product is always its main image. Requires admin privilege",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Upload an image of a product. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Make the image the main image of the product",
+                        "name": "primary",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductImage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductImageError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductImageError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Put the images of a product in the order of imageIds, which must list every image of the product, and/or change its main image. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Reorder the images of a product. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Image order request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductImageOrderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.ProductImage"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductImageError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductImageError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/products/{productId}/images/{imageId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an image of a product. When the main image is deleted the next image becomes the main image. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Delete an image of a product. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique image id",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductImageError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/products/{productId}/prices": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch the prices of a product, the latest to come into effect first, including scheduled and cancelled prices. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Fetch the price history of a product. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of prices to return, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.ProductPrice"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductPriceError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductPriceError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Price a product from effectiveFrom, now by default, until effectiveTo, for good by default. The latest started of the prices in effect is the price of the product, so a sale ending falls back to the price before it. compareAtPrice is shown struck through while the price is in effect. Scheduled prices are applied when they come into and go out of effect. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Set or schedule a price of a product. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product Price request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductPriceInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.ScheduledProductPrice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductPriceError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductPriceError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/products/{productId}/prices/{priceId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a scheduled price, or end one in effect early. The product goes back to the price in effect without it. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Cancel a price of a product. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique price id",
+                        "name": "priceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ScheduledProductPrice"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductPriceError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/products/{productId}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Put a deleted product back in the catalog. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Restore an archived Product. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Product"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/products/{productId}/stock-movements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch the movements of the stock of a product, newest first: orders placed and cancelled, manual adjustments, imports, returns and transfers between warehouses. Pass the id of the last movement of a page as before to get the next one. ledgerStock is the sum of every movement and equals stock unless the stock was changed outside of the ledger. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Fetch the stock ledger of a product. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only the movements before the movement with this id",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of movements to return, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.StockMovements"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.InventoryError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.InventoryError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/products/{productId}/warehouse-stock": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch how many units of a product each warehouse holds, in the order orders are shipped from them. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Fetch the stock of a product in each warehouse. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.ProductWarehouseStock"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WarehouseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/promotions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all promotions, the highest priority first. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotion"
+                ],
+                "summary": "List all promotions. Requires admin privilege",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.Promotion"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a promotion applied automatically at checkout. BUY_X_GET_Y takes percent, 100 by default, off getQuantity of every buyQuantity + getQuantity units, the cheapest first. BUNDLE sells one of each of productIds for bundlePrice. QUANTITY_TIER takes the percent of the highest tier reached off each line. CATEGORY_SALE takes percent off every product in categories. Promotions are applied from the highest priority down and one that is not stackable only discounts lines no other promotion has discounted. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotion"
+                ],
+                "summary": "Create a new promotion. Requires admin privilege",
+                "parameters": [
+                    {
+                        "description": "Create Promotion request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreatePromotionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.PromotionError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/promotions/{promotionId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch One Promotion. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotion"
+                ],
+                "summary": "Fetch One Promotion. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique promotion id",
+                        "name": "promotionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Promotion"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.PromotionError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a single Promotion. Only the fields present in the body are changed and the promotion is validated as a whole against its kind. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotion"
+                ],
+                "summary": "Update a single Promotion. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique promotion id",
+                        "name": "promotionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Promotion request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.PromotionUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.PromotionError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.PromotionError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete One Promotion. Orders the promotion was applied to keep their discount. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotion"
+                ],
+                "summary": "Delete One Promotion. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique promotion id",
+                        "name": "promotionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.PromotionError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/returns": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch all returns, newest first, together with their items and state history. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "return"
+                ],
+                "summary": "Fetch all returns. Requires admin privilege",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.Return"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/returns/{returnId}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve a REQUESTED return so the customer can send the products back. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "return"
+                ],
+                "summary": "Approve a requested return. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique return id",
+                        "name": "returnId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional note recorded in the return history",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReturnActionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Return"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReturnError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReturnError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/returns/{returnId}/receive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark an APPROVED return as RECEIVED, putting the returned products back in stock and refunding the customer. When the refund fails the return stays RECEIVED and can be refunded later. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "return"
+                ],
+                "summary": "Mark the products of an approved return as received. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique return id",
+                        "name": "returnId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional note recorded in the return history",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReturnActionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Return"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReturnError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReturnError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/returns/{returnId}/refund": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retry the refund of a RECEIVED return. A return being refunded concurrently is not refunded again. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "return"
+                ],
+                "summary": "Refund a received return. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique return id",
+                        "name": "returnId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional note recorded in the return history",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReturnActionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Return"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReturnError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReturnError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReturnError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/returns/{returnId}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reject a REQUESTED or APPROVED return. Rejected returns are final. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "return"
+                ],
+                "summary": "Reject a return. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique return id",
+                        "name": "returnId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional note recorded in the return history",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReturnActionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Return"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReturnError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReturnError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the latest reviews of all products, newest first. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "List the reviews to moderate. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only the reviews in this state: PENDING, APPROVED or REJECTED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of reviews to return, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.Review"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReviewError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reviews/{reviewId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a review. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Delete a review. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique review id",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReviewError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reviews/{reviewId}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Show a review on the product and count it in the rating of the product. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Approve a review. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique review id",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Review"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReviewError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reviews/{reviewId}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hide a review from the product and leave it out of the rating of the product. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Reject a review. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique review id",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Review"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReviewError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/shipments/{shipmentId}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the carrier and tracking number of a shipment or mark it as delivered. A SHIPPED order becomes DELIVERED once all its shipments are delivered. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipment"
+                ],
+                "summary": "Update the tracking details or delivery of a shipment. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique shipment id",
+                        "name": "shipmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Shipment request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ShipmentUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Shipment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShipmentError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShipmentError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/shipping-methods": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all shipping methods. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "List all shipping methods. Requires admin privilege",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.ShippingMethod"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a FLAT, WEIGHT or FREE_OVER shipping method for a zone. WEIGHT methods cost rate plus ratePerKg for every kilogram, FREE_OVER methods cost rate until the basket subtotal reaches freeOver. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Create a new shipping method. Requires admin privilege",
+                "parameters": [
+                    {
+                        "description": "Create Shipping Method request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateShippingMethodInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShippingMethod"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShippingMethodError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/shipping-methods/{shippingMethodId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a single shipping method. Orders already placed keep the shipping cost they were charged. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Update a single shipping method. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique shipping method id",
+                        "name": "shippingMethodId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Shipping Method request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ShippingMethodUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShippingMethod"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShippingMethodError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShippingMethodError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a single shipping method. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Delete a single shipping method. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique shipping method id",
+                        "name": "shippingMethodId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/shipping-zones": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all shipping zones. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "List all shipping zones. Requires admin privilege",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.ShippingZone"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a zone covering one or more regions, e.g. GB or US-CA. A zone without regions covers every region not covered by another zone. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Create a new shipping zone. Requires admin privilege",
+                "parameters": [
+                    {
+                        "description": "Create Shipping Zone request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateShippingZoneInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShippingZone"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShippingZoneError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/shipping-zones/{shippingZoneId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a single shipping zone. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Update a single shipping zone. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique shipping zone id",
+                        "name": "shippingZoneId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Shipping Zone request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ShippingZoneUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShippingZone"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShippingZoneError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShippingZoneError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a single shipping zone together with its shipping methods. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Delete a single shipping zone. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique shipping zone id",
+                        "name": "shippingZoneId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/stock-transfers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the latest stock transfers between warehouses, newest first. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "List the stock transfers. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only the transfers of this product",
+                        "name": "productId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the transfers from or to this warehouse",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of transfers to return, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.StockTransfer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.WarehouseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move units of a product from a warehouse to another. The stock of the product does not change, both sides of the transfer are recorded in its stock ledger. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Transfer stock between warehouses. Requires admin privilege",
+                "parameters": [
+                    {
+                        "description": "Stock Transfer request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.StockTransferInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.WarehouseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WarehouseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tax-rules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all tax rules. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "List all tax rules. Requires admin privilege",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.TaxRule"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a tax rate for a product tax class in a shipping region. An empty region applies to every region. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Create a new tax rule. Requires admin privilege",
+                "parameters": [
+                    {
+                        "description": "Create Tax Rule request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateTaxRuleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.TaxRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.TaxRuleError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tax-rules/{taxRuleId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a single tax rule. Orders already placed keep the rate they were charged. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Update a single tax rule. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique tax rule id",
+                        "name": "taxRuleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Tax Rule request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.TaxRuleUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.TaxRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.TaxRuleError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.TaxRuleError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a single tax rule. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Delete a single tax rule. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique tax rule id",
+                        "name": "taxRuleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/warehouses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all warehouses in the order orders are shipped from them. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "List all warehouses. Requires admin privilege",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.Warehouse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a warehouse. Orders are shipped from the active warehouses with the lowest priority first. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Create a new warehouse. Requires admin privilege",
+                "parameters": [
+                    {
+                        "description": "Create Warehouse request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateWarehouseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.Warehouse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.WarehouseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/warehouses/{warehouseId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch a single warehouse. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Fetch a single warehouse. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique warehouse id",
+                        "name": "warehouseId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Warehouse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WarehouseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a single warehouse. Inactive warehouses keep their stock but no order is shipped from them. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Update a single warehouse. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique warehouse id",
+                        "name": "warehouseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Warehouse request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.WarehouseUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Warehouse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.WarehouseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WarehouseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/warehouses/{warehouseId}/stock": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch how many units of each product a warehouse holds. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Fetch the stock of a warehouse. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique warehouse id",
+                        "name": "warehouseId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.WarehouseStock"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WarehouseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/warehouses/{warehouseId}/stock/{productId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set how many units of a product a warehouse holds. The stock of the product goes up or down by the difference, which is recorded in its stock ledger as an adjustment. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Set the stock of a product in a warehouse. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique warehouse id",
+                        "name": "warehouseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Warehouse Stock request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.WarehouseStockInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.WarehouseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WarehouseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhook-deliveries/{deliveryId}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send the event of a delivery to its webhook again, e.g. once the receiver is fixed. The event is sent as a new delivery with the same event id. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Send a webhook delivery again. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique webhook delivery id",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.WebhookDelivery"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WebhookError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all webhooks. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "List all webhooks. Requires admin privilege",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.Webhook"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribe a URL to order.created, order.status_changed, product.created, product.updated, product.deleted or product.stock_low events. Every delivery is signed with the secret in the X-Webhook-Signature header, sha256= followed by the hex HMAC-SHA256 of the X-Webhook-Timestamp header, a dot and the body. A secret is generated when none is given. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Create a new webhook. Requires admin privilege",
+                "parameters": [
+                    {
+                        "description": "Create Webhook request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateWebhookInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.WebhookError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{webhookId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch One Webhook. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Fetch One Webhook. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique webhook id",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Webhook"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WebhookError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a single Webhook. Only the fields present in the body are changed. Deliveries of an inactive webhook fail without being sent. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Update a single Webhook. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique webhook id",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Webhook request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.WebhookUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.WebhookError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WebhookError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete One Webhook along with its delivery log. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Delete One Webhook. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique webhook id",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WebhookError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{webhookId}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch the latest deliveries to a webhook, newest first, with the attempts made and the last response. Requires admin privilege",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Fetch the delivery log of a webhook. Requires admin privilege",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique webhook id",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of deliveries to return, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.WebhookError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WebhookError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/claim": {
+            "post": {
+                "description": "Turn the email a guest ordered with into an account with a password, proving it with the account claim token emailed to the guest. Order lookup tokens are not accepted. Every order placed as a guest with the email is kept. Generates an access token for the account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Claim the account of a guest",
+                "parameters": [
+                    {
+                        "description": "Claim Account request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ClaimAccountInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.LoginUserOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ClaimAccountError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.ClaimAccountError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ClaimAccountError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/claim/request": {
+            "post": {
+                "description": "Email a guest a token to claim their account with, valid for an hour and for a single claim. The response is the same whether or not the email has guest orders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Request a token to claim the account of a guest",
+                "parameters": [
+                    {
+                        "description": "Account Claim request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AccountClaimRequestInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ClaimAccountError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "User Login. Generates an access token for a valid user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "User Login. Generates an access token for a valid user.",
+                "parameters": [
+                    {
+                        "description": "Login request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.LoginUserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.LoginUserOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.LoginUserError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.LoginUserError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "New user signup. Register a new user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "New user signup. Create a new user",
+                "parameters": [
+                    {
+                        "description": "Register request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RegisterUserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateUserOk"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateUserError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/guest/orders": {
+            "post": {
+                "description": "Place an order without an account, shipped to the address given. A token to look up the order is emailed to the guest. Emails belonging to an account have to log in instead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guest"
+                ],
+                "summary": "Place an order as a guest",
+                "parameters": [
+                    {
+                        "description": "Guest Order request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.GuestOrderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.GuestOrderError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.GuestOrderError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/guest/orders/lookup": {
+            "post": {
+                "description": "Get an order placed as a guest, along with its shipping address, with the token emailed to the guest. The token is sent in the body to keep it out of URLs. No sign in is needed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guest"
+                ],
+                "summary": "Look up a guest order",
+                "parameters": [
+                    {
+                        "description": "Guest Order Lookup request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.GuestOrderLookupInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.GuestOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.GuestOrderError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.GuestOrderError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.GuestOrderError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/images/{imageId}/{variant}": {
+            "get": {
+                "description": "Download a thumbnail of a product image, e.g. medium.webp. The sizes and urls of the thumbnails are listed with each image. Thumbnails are made on the first request for them, revalidate them with If-None-Match",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/webp"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Download a thumbnail of a product image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique image id",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Size and format of the thumbnail, e.g. small.jpg or small.webp",
+                        "name": "variant",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductImageError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/notification-preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch which order emails the user gets. Users who never changed them get every email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Fetch the notification preferences of the user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.NotificationPreference"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Turn the order placed, cancelled, completed and shipped emails on or off. Only the fields present in the body are changed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Update the notification preferences of the user",
+                "parameters": [
+                    {
+                        "description": "Notification preferences request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.NotificationPreferenceInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.NotificationPreference"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/wishlists": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the wishlists of the signed in customer along with their products, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlist"
+                ],
+                "summary": "List my wishlists",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.Wishlist"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a named wishlist to save products to. A customer can have many wishlists, each with a different name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlist"
+                ],
+                "summary": "Create a wishlist",
+                "parameters": [
+                    {
+                        "description": "Create Wishlist request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.Wishlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/wishlists/{wishlistId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a wishlist of the signed in customer along with its products",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlist"
+                ],
+                "summary": "Get one of my wishlists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique wishlist id",
+                        "name": "wishlistId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Wishlist"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename a wishlist of the signed in customer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlist"
+                ],
+                "summary": "Rename a wishlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique wishlist id",
+                        "name": "wishlistId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Wishlist request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Wishlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a wishlist of the signed in customer along with its products",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlist"
+                ],
+                "summary": "Delete a wishlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique wishlist id",
+                        "name": "wishlistId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Wishlist"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/wishlists/{wishlistId}/items": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Save a product to a wishlist, 1 unit by default. Saving a product already on the wishlist sets its quantity",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlist"
+                ],
+                "summary": "Save a product to a wishlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique wishlist id",
+                        "name": "wishlistId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Wishlist Item request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistItemInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/wishlists/{wishlistId}/items/{productId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a product from a wishlist of the signed in customer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlist"
+                ],
+                "summary": "Remove a product from a wishlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique wishlist id",
+                        "name": "wishlistId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistItem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/wishlists/{wishlistId}/order": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Place an order for the products of a wishlist, or only those in productIds, in the quantities saved on it. The products ordered are removed from the wishlist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlist"
+                ],
+                "summary": "Order the products of a wishlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique wishlist id",
+                        "name": "wishlistId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Order Wishlist request body",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/types.OrderWishlistInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/wishlists/{wishlistId}/share": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Give a wishlist a share token, which lets anyone see the wishlist at /wishlists/shared/{token} without signing in. Sharing a wishlist again replaces its token, so the old link stops working",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlist"
+                ],
+                "summary": "Share a wishlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique wishlist id",
+                        "name": "wishlistId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Wishlist"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the share token of a wishlist, so its link stops working",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlist"
+                ],
+                "summary": "Stop sharing a wishlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique wishlist id",
+                        "name": "wishlistId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Wishlist"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/{key}": {
+            "get": {
+                "description": "Download an uploaded file such as a product image. Files never change once uploaded, so they can be cached for as long as needed",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif",
+                    "image/webp"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Download an uploaded file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key of the file, as found at the end of its url",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch all orders placed by a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Fetch all orders placed by a user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.Order"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.OrderError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Place an order for one or more Product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Place an order for one or more Product",
+                "parameters": [
+                    {
+                        "description": "Create Order request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateOrderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.OrderError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/preview": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Run the checks and pricing of placing an order without placing it: stock in the warehouses, line totals, promotion and coupon discounts, taxes and shipping cost. Nothing is written, no stock is taken and the coupon is not redeemed. The status is BACKORDERED when some units would wait for a restock. Problems with the order are returned keyed by product id or field name, the same as when placing it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Preview an order for one or more Product",
+                "parameters": [
+                    {
+                        "description": "Preview Order request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateOrderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.OrderPreview"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.OrderError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-sent events stream of the status changes of the orders of the user. Every change is an order.status_changed event whose id can be sent back in the Last-Event-ID header (or lastEventId query parameter) to resume after the changes already received. As changes can commit out of order, the changes just before the resumed one are sent again, and clients should ignore the ids they already have. A heartbeat comment is sent while the stream is idle",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Stream the status changes of the user's orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of the last event received, for clients that cannot set headers",
+                        "name": "lastEventId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.OrderStatusEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.OrderStreamError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{orderId}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels an order only if it is in PENDING or BACKORDERED state and has not shipped. Its units go back in stock and its coupon can be redeemed again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Cancels an order only if it is in PENDING state",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique uuid of the order whose status is to be cancelled",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.OrderCancelError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.OrderCancelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{orderId}/returns": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch the returns of an order placed by the user together with their items and state history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "return"
+                ],
+                "summary": "Fetch the returns of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique uuid of the order",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.Return"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReturnError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Request a return for shipped products of an order placed by the user. Each product can be returned up to the quantity shipped and not yet returned, and every item needs a reason",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "return"
+                ],
+                "summary": "Request a return for products of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique uuid of the order",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Return request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateReturnInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.Return"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReturnError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReturnError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{orderId}/shipments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch the shipments of an order placed by the user together with the items in each shipment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipment"
+                ],
+                "summary": "Fetch the shipments of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique uuid of the order",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.Shipment"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShipmentError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all products. None admin users should be able to see products before placing an order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "List all products. None admin users should be able to see products before placing an order.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "rating to list the best rated products first",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.Product"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ProductError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{productId}/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the approved reviews of a product, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "List the reviews of a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of reviews to return, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.Review"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReviewError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rate a product from 1 to 5 stars with a title and an optional body. Only customers with a completed order of the product can review it, once. The review is shown once an admin approves it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Review a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Review request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateReviewInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReviewError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReviewError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReviewError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReviewError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/quote": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the active shipping methods of the zone covering the address together with their cost for the basket. Free shipping thresholds are checked against the basket subtotal before any discount",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Price the shipping of a basket to an address",
+                "parameters": [
+                    {
+                        "description": "Shipping Quote request body",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ShippingQuoteInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShippingQuote"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ShippingQuoteError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/wishlists/shared/{token}": {
+            "get": {
+                "description": "Get the name and products of a wishlist shared with a link. No sign in is needed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlist"
+                ],
+                "summary": "Get a shared wishlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share token of the wishlist",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.SharedWishlist"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.WishlistError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.InterServerError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "db.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "COMPLETED",
+                "CANCELLED",
+                "PARTIALLY_SHIPPED",
+                "SHIPPED",
+                "DELIVERED",
+                "BACKORDERED"
+            ],
+            "x-enum-varnames": [
+                "OrderStatusPENDING",
+                "OrderStatusCOMPLETED",
+                "OrderStatusCANCELLED",
+                "OrderStatusPARTIALLYSHIPPED",
+                "OrderStatusSHIPPED",
+                "OrderStatusDELIVERED",
+                "OrderStatusBACKORDERED"
+            ]
+        },
+        "promotion.Tier": {
+            "type": "object",
+            "properties": {
+                "minQuantity": {
+                    "type": "integer"
+                },
+                "percent": {
+                    "type": "number"
+                }
+            }
+        },
+        "types.AccountClaimRequestInput": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.Address": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.AppliedPromotion": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "number"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "promotionId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ClaimAccountErrMessage": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ClaimAccountError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.ClaimAccountErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ClaimAccountInput": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.Coupon": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "discountType": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "minOrderValue": {
+                    "type": "number"
+                },
+                "perUserLimit": {
+                    "type": "integer"
+                },
+                "productIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "startsAt": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "usageCount": {
+                    "type": "integer"
+                },
+                "usageLimit": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "types.CouponErrMessage": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "discountType": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "minOrderValue": {
+                    "type": "string"
+                },
+                "perUserLimit": {
+                    "type": "string"
+                },
+                "usageLimit": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.CouponError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.CouponErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.CouponUpdateInput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "discountType": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "minOrderValue": {
+                    "type": "number"
+                },
+                "perUserLimit": {
+                    "type": "integer"
+                },
+                "productIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "startsAt": {
+                    "type": "string"
+                },
+                "usageLimit": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "types.CreateCouponInput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "discountType": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "minOrderValue": {
+                    "type": "number"
+                },
+                "perUserLimit": {
+                    "type": "integer"
+                },
+                "productIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "startsAt": {
+                    "type": "string"
+                },
+                "usageLimit": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "types.CreateOrderInput": {
+            "type": "object",
+            "properties": {
+                "couponCode": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.Item"
+                    }
+                },
+                "shippingMethodId": {
+                    "type": "string"
+                },
+                "shippingRegion": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.CreateProductInput": {
+            "type": "object",
+            "properties": {
+                "availableAt": {
+                    "type": "string"
+                },
+                "backorderPolicy": {
+                    "description": "BackorderPolicy is NONE (default), BACKORDER or PREORDER",
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "reorderThreshold": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "taxClass": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "types.CreatePromotionInput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "bundlePrice": {
+                    "type": "number"
+                },
+                "buyQuantity": {
+                    "type": "integer"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "getQuantity": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percent": {
+                    "type": "number"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "productIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "stackable": {
+                    "type": "boolean"
+                },
+                "startsAt": {
+                    "type": "string"
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/promotion.Tier"
+                    }
+                }
+            }
+        },
+        "types.CreateReturnInput": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ReturnItemInput"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.CreateReviewInput": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.CreateShipmentInput": {
+            "type": "object",
+            "properties": {
+                "carrier": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.Item"
+                    }
+                },
+                "trackingNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.CreateShippingMethodInput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "freeOver": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "ratePerKg": {
+                    "type": "number"
+                },
+                "rateType": {
+                    "type": "string"
+                },
+                "zoneId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.CreateShippingZoneInput": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "regions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "types.CreateTaxRuleInput": {
+            "type": "object",
+            "properties": {
+                "inclusive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "taxClass": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.CreateUserError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.RegisterUserErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.CreateUserOk": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/types.RegisterUserOutput"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.CreateWarehouseInput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.CreateWebhookInput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.GuestAddress": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "line1": {
+                    "type": "string"
+                },
+                "line2": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "postalCode": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.GuestOrder": {
+            "type": "object",
+            "properties": {
+                "couponId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.OrderPromotion"
+                    }
+                },
+                "shippingAddress": {
+                    "$ref": "#/definitions/types.GuestOrderAddress"
+                },
+                "shippingCost": {
+                    "type": "number"
+                },
+                "shippingMethod": {
+                    "type": "string"
+                },
+                "shippingMethodId": {
+                    "type": "string"
+                },
+                "shippingRegion": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/types.OrderStatus"
+                },
+                "subtotal": {
+                    "type": "number"
+                },
+                "tax": {
+                    "type": "number"
+                },
+                "taxes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.OrderTax"
+                    }
+                },
+                "total": {
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.GuestOrderAddress": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "line1": {
+                    "type": "string"
+                },
+                "line2": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "postalCode": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.GuestOrderErrMessage": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.GuestOrderError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.GuestOrderErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.GuestOrderInput": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/types.GuestAddress"
+                },
+                "couponCode": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.Item"
+                    }
+                },
+                "shippingMethodId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.GuestOrderLookupInput": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.InterServerError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.InventoryErrMessage": {
+            "type": "object",
+            "properties": {
+                "before": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.InventoryError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.InventoryErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.Item": {
+            "type": "object",
+            "properties": {
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.ItemError": {
+            "type": "object",
+            "properties": {
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.Job": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "completedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "lockedBy": {
+                    "type": "string"
+                },
+                "maxAttempts": {
+                    "type": "integer"
+                },
+                "payload": {},
+                "runAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.JobErrMessage": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.JobError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.JobErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.JobStats": {
+            "type": "object",
+            "properties": {
+                "DEAD": {
+                    "type": "integer"
+                },
+                "PENDING": {
+                    "type": "integer"
+                },
+                "RUNNING": {
+                    "type": "integer"
+                },
+                "SUCCEEDED": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.LoginUserError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.RegisterUserErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.LoginUserInput": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.LoginUserOutput": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.LowStockProduct": {
+            "type": "object",
+            "properties": {
+                "alertId": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lowSince": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notifiedAt": {
+                    "type": "string"
+                },
+                "reorderThreshold": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.NotificationPreference": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "orderCancelled": {
+                    "type": "boolean"
+                },
+                "orderCompleted": {
+                    "type": "boolean"
+                },
+                "orderPlaced": {
+                    "type": "boolean"
+                },
+                "orderShipped": {
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.NotificationPreferenceInput": {
+            "type": "object",
+            "properties": {
+                "orderCancelled": {
+                    "type": "boolean"
+                },
+                "orderCompleted": {
+                    "type": "boolean"
+                },
+                "orderPlaced": {
+                    "type": "boolean"
+                },
+                "orderShipped": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "types.Order": {
+            "type": "object",
+            "properties": {
+                "couponId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.OrderPromotion"
+                    }
+                },
+                "shippingCost": {
+                    "type": "number"
+                },
+                "shippingMethod": {
+                    "type": "string"
+                },
+                "shippingMethodId": {
+                    "type": "string"
+                },
+                "shippingRegion": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/types.OrderStatus"
+                },
+                "subtotal": {
+                    "type": "number"
+                },
+                "tax": {
+                    "type": "number"
+                },
+                "taxes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.OrderTax"
+                    }
+                },
+                "total": {
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.OrderAllocation": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "orderItemId": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "warehouseId": {
+                    "type": "string"
+                },
+                "warehouseName": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.OrderCancelError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.OrderErrMessage": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "lastEventId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.OrderError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ItemError"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.OrderPreview": {
+            "type": "object",
+            "properties": {
+                "couponDiscount": {
+                    "type": "number"
+                },
+                "couponId": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "number"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.OrderPreviewItem"
+                    }
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AppliedPromotion"
+                    }
+                },
+                "shippingCost": {
+                    "type": "number"
+                },
+                "shippingMethod": {
+                    "type": "string"
+                },
+                "shippingMethodId": {
+                    "type": "string"
+                },
+                "shippingRegion": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/types.OrderStatus"
+                },
+                "subtotal": {
+                    "type": "number"
+                },
+                "tax": {
+                    "type": "number"
+                },
+                "taxes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.OrderPreviewTax"
+                    }
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "types.OrderPreviewItem": {
+            "type": "object",
+            "properties": {
+                "backordered": {
+                    "type": "integer"
+                },
+                "discount": {
+                    "type": "number"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "tax": {
+                    "type": "number"
+                }
+            }
+        },
+        "types.OrderPreviewTax": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "inclusive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "taxRuleId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.OrderPromotion": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "promotionId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "COMPLETED",
+                "CANCELLED",
+                "PARTIALLY_SHIPPED",
+                "SHIPPED",
+                "DELIVERED",
+                "BACKORDERED"
+            ],
+            "x-enum-varnames": [
+                "OrderStatusPENDING",
+                "OrderStatusCOMPLETED",
+                "OrderStatusCANCELLED",
+                "OrderStatusPARTIALLYSHIPPED",
+                "OrderStatusSHIPPED",
+                "OrderStatusDELIVERED",
+                "OrderStatusBACKORDERED"
+            ]
+        },
+        "types.OrderStatusEvent": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "previousStatus": {
+                    "$ref": "#/definitions/db.OrderStatus"
+                },
+                "status": {
+                    "$ref": "#/definitions/db.OrderStatus"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.OrderStreamError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.OrderErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.OrderTax": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "inclusive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "taxRuleId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.OrderWishlistInput": {
+            "type": "object",
+            "properties": {
+                "couponCode": {
+                    "type": "string"
+                },
+                "productIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "shippingMethodId": {
+                    "type": "string"
+                },
+                "shippingRegion": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.Product": {
+            "type": "object",
+            "properties": {
+                "availableAt": {
+                    "type": "string"
+                },
+                "backorderPolicy": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "compareAtPrice": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ProductImage"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "ratingAverage": {
+                    "type": "number"
+                },
+                "ratingCount": {
+                    "type": "integer"
+                },
+                "reorderThreshold": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "taxClass": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "types.ProductErrMessage": {
+            "type": "object",
+            "properties": {
+                "backorderPolicy": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "reorderThreshold": {
+                    "type": "string"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "sort": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "string"
+                },
+                "stockNote": {
+                    "type": "string"
+                },
+                "taxClass": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ProductError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.ProductErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ProductImage": {
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isPrimary": {
+                    "type": "boolean"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "thumbnails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ThumbnailOutput"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ProductImageErrMessage": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "imageIds": {
+                    "type": "string"
+                },
+                "primaryImageId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ProductImageError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.ProductImageErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ProductImageOrderInput": {
+            "type": "object",
+            "properties": {
+                "imageIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "primaryImageId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ProductImportErrMessage": {
+            "type": "object",
+            "properties": {
+                "file": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ProductImportRowError"
+                    }
+                }
+            }
+        },
+        "types.ProductImportError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.ProductImportErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ProductImportOutput": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "jobId": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ProductImportResult"
+                    }
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.ProductImportResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ProductImportRowError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.ProductErrMessage"
+                },
+                "line": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.ProductPrice": {
+            "type": "object",
+            "properties": {
+                "cancelledAt": {
+                    "type": "string"
+                },
+                "compareAtPrice": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "effectiveFrom": {
+                    "type": "string"
+                },
+                "effectiveTo": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "productId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ProductPriceErrMessage": {
+            "type": "object",
+            "properties": {
+                "compareAtPrice": {
+                    "type": "string"
+                },
+                "effectiveFrom": {
+                    "type": "string"
+                },
+                "effectiveTo": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ProductPriceError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.ProductPriceErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ProductPriceInput": {
+            "type": "object",
+            "properties": {
+                "compareAtPrice": {
+                    "type": "number"
+                },
+                "effectiveFrom": {
+                    "type": "string"
+                },
+                "effectiveTo": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "types.ProductWarehouseStock": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "warehouseId": {
+                    "type": "string"
+                },
+                "warehouseName": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.Promotion": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "bundlePrice": {
+                    "type": "number"
+                },
+                "buyQuantity": {
+                    "type": "integer"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "getQuantity": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percent": {
+                    "type": "number"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "productIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "stackable": {
+                    "type": "boolean"
+                },
+                "startsAt": {
+                    "type": "string"
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/promotion.Tier"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.PromotionErrMessage": {
+            "type": "object",
+            "properties": {
+                "bundlePrice": {
+                    "type": "string"
+                },
+                "buyQuantity": {
+                    "type": "string"
+                },
+                "categories": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "getQuantity": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percent": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "string"
+                },
+                "productIds": {
+                    "type": "string"
+                },
+                "tiers": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.PromotionError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.PromotionErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.PromotionUpdateInput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "bundlePrice": {
+                    "type": "number"
+                },
+                "buyQuantity": {
+                    "type": "integer"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "getQuantity": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percent": {
+                    "type": "number"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "productIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "stackable": {
+                    "type": "boolean"
+                },
+                "startsAt": {
+                    "type": "string"
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/promotion.Tier"
+                    }
+                }
+            }
+        },
+        "types.RegisterUserErrMessage": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.RegisterUserInput": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.RegisterUserOutput": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "description": "Password  string    ` + "`" + `json:\"password\"` + "`" + `",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.Return": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ReturnEvent"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ReturnItem"
+                    }
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "refundAmount": {
+                    "type": "number"
+                },
+                "refundReference": {
+                    "type": "string"
+                },
+                "refundedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ReturnActionInput": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ReturnErrMessage": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                },
+                "refund": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ReturnError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.ReturnErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ReturnEvent": {
+            "type": "object",
+            "properties": {
+                "actorId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "fromStatus": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "returnId": {
+                    "type": "string"
+                },
+                "toStatus": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ReturnItem": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "orderItemId": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "returnId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ReturnItemInput": {
+            "type": "object",
+            "properties": {
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.Review": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "moderatedAt": {
+                    "type": "string"
+                },
+                "moderatedBy": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ReviewErrMessage": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ReviewError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.ReviewErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ScheduledProductPrice": {
+            "type": "object",
+            "properties": {
+                "price": {
+                    "$ref": "#/definitions/types.ProductPrice"
+                },
+                "product": {
+                    "$ref": "#/definitions/types.Product"
+                }
+            }
+        },
+        "types.SharedWishlist": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.WishlistItem"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.Shipment": {
+            "type": "object",
+            "properties": {
+                "carrier": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ShipmentItem"
+                    }
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "shippedAt": {
+                    "type": "string"
+                },
+                "trackingNumber": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ShipmentErrMessage": {
+            "type": "object",
+            "properties": {
+                "carrier": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "trackingNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ShipmentError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.ShipmentErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ShipmentItem": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "orderItemId": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "shipmentId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ShipmentUpdateInput": {
+            "type": "object",
+            "properties": {
+                "carrier": {
+                    "type": "string"
+                },
+                "delivered": {
+                    "type": "boolean"
+                },
+                "trackingNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ShippingMethod": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "freeOver": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "ratePerKg": {
+                    "type": "number"
+                },
+                "rateType": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "zoneId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ShippingMethodErrMessage": {
+            "type": "object",
+            "properties": {
+                "freeOver": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "string"
+                },
+                "ratePerKg": {
+                    "type": "string"
+                },
+                "rateType": {
+                    "type": "string"
+                },
+                "zoneId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ShippingMethodError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.ShippingMethodErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ShippingMethodUpdateInput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "freeOver": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "ratePerKg": {
+                    "type": "number"
+                },
+                "rateType": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ShippingQuote": {
+            "type": "object",
+            "properties": {
+                "rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ShippingRate"
+                    }
+                },
+                "region": {
+                    "type": "string"
+                },
+                "subtotal": {
+                    "type": "number"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "zone": {
+                    "$ref": "#/definitions/types.ShippingZone"
+                }
+            }
+        },
+        "types.ShippingQuoteErrMessage": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "types.ShippingQuoteError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.ShippingQuoteErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ShippingQuoteInput": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/types.Address"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.Item"
+                    }
+                }
+            }
+        },
+        "types.ShippingRate": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "cost": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "freeOver": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "ratePerKg": {
+                    "type": "number"
+                },
+                "rateType": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "zoneId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ShippingZone": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "regions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ShippingZoneErrMessage": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "regions": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ShippingZoneError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.ShippingZoneErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ShippingZoneUpdateInput": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "regions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "types.StockDiscrepancy": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "ledgerStock": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "warehouseStock": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.StockMovement": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "returnId": {
+                    "type": "string"
+                },
+                "stockAfter": {
+                    "type": "integer"
+                },
+                "warehouseId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.StockMovements": {
+            "type": "object",
+            "properties": {
+                "ledgerStock": {
+                    "type": "integer"
+                },
+                "movements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.StockMovement"
+                    }
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.StockTransfer": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "fromWarehouseId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "toWarehouseId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.StockTransferInput": {
+            "type": "object",
+            "properties": {
+                "fromWarehouseId": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "toWarehouseId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.TaxRule": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "inclusive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "taxClass": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.TaxRuleErrMessage": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "taxClass": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.TaxRuleError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.TaxRuleErrMessage"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.TaxRuleUpdateInput": {
+            "type": "object",
+            "properties": {
+                "inclusive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "taxClass": {
+                    "type": "string"
+                }
+            }
         },
-        "types.CreateProductInput": {
+        "types.ThumbnailOutput": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
+                "maxDimension": {
+                    "type": "integer"
                 },
-                "name": {
+                "size": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "url": {
+                    "type": "string"
                 },
-                "stock": {
-                    "type": "integer"
+                "webpUrl": {
+                    "type": "string"
                 }
             }
         },
-        "types.CreateUserError": {
+        "types.UpdateOrderStatusInput": {
             "type": "object",
             "properties": {
-                "error": {
-                    "$ref": "#/definitions/types.RegisterUserErrMessage"
+                "status": {
+                    "$ref": "#/definitions/db.OrderStatus"
+                }
+            }
+        },
+        "types.Warehouse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
                 },
-                "message": {
+                "createdAt": {
                     "type": "string"
                 },
-                "status": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "types.CreateUserOk": {
+        "types.WarehouseErrMessage": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/types.RegisterUserOutput"
+                "fromWarehouseId": {
+                    "type": "string"
                 },
-                "message": {
+                "id": {
                     "type": "string"
                 },
-                "status": {
+                "limit": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "string"
+                },
+                "toWarehouseId": {
+                    "type": "string"
+                },
+                "warehouseId": {
                     "type": "string"
                 }
             }
         },
-        "types.InterServerError": {
+        "types.WarehouseError": {
             "type": "object",
             "properties": {
+                "error": {
+                    "$ref": "#/definitions/types.WarehouseErrMessage"
+                },
                 "message": {
                     "type": "string"
                 },
@@ -652,89 +8456,149 @@ const docTemplate = `{
                 }
             }
         },
-        "types.Item": {
+        "types.WarehouseStock": {
             "type": "object",
             "properties": {
+                "name": {
+                    "type": "string"
+                },
                 "productId": {
                     "type": "string"
                 },
-                "quantity": {
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
                     "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
                 }
             }
         },
-        "types.ItemError": {
+        "types.WarehouseStockInput": {
             "type": "object",
             "properties": {
-                "productId": {
+                "note": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "string"
+                "stock": {
+                    "type": "integer"
                 }
             }
         },
-        "types.LoginUserError": {
+        "types.WarehouseUpdateInput": {
             "type": "object",
             "properties": {
-                "error": {
-                    "$ref": "#/definitions/types.RegisterUserErrMessage"
+                "active": {
+                    "type": "boolean"
                 },
-                "message": {
+                "name": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "string"
+                "priority": {
+                    "type": "integer"
                 }
             }
         },
-        "types.LoginUserInput": {
+        "types.Webhook": {
             "type": "object",
             "properties": {
-                "email": {
+                "active": {
+                    "type": "boolean"
+                },
+                "createdAt": {
                     "type": "string"
                 },
-                "password": {
+                "createdBy": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "types.LoginUserOutput": {
+        "types.WebhookDelivery": {
             "type": "object",
             "properties": {
-                "token": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "eventId": {
+                    "type": "string"
+                },
+                "eventType": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "responseBody": {
+                    "type": "string"
+                },
+                "responseStatus": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "webhookId": {
                     "type": "string"
                 }
             }
         },
-        "types.Order": {
+        "types.WebhookErrMessage": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "events": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/types.OrderStatus"
-                },
-                "total": {
-                    "type": "number"
+                "limit": {
+                    "type": "string"
                 },
-                "updatedAt": {
+                "secret": {
                     "type": "string"
                 },
-                "userId": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "types.OrderCancelError": {
+        "types.WebhookError": {
             "type": "object",
             "properties": {
                 "error": {
-                    "type": "string"
+                    "$ref": "#/definitions/types.WebhookErrMessage"
                 },
                 "message": {
                     "type": "string"
@@ -744,147 +8608,146 @@ const docTemplate = `{
                 }
             }
         },
-        "types.OrderError": {
+        "types.WebhookUpdateInput": {
             "type": "object",
             "properties": {
-                "error": {
+                "active": {
+                    "type": "boolean"
+                },
+                "events": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/types.ItemError"
+                        "type": "string"
                     }
                 },
-                "message": {
+                "secret": {
                     "type": "string"
                 },
-                "status": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "types.OrderStatus": {
-            "type": "string",
-            "enum": [
-                "PENDING",
-                "COMPLETED",
-                "CANCELLED"
-            ],
-            "x-enum-varnames": [
-                "OrderStatusPENDING",
-                "OrderStatusCOMPLETED",
-                "OrderStatusCANCELLED"
-            ]
-        },
-        "types.Product": {
+        "types.Wishlist": {
             "type": "object",
             "properties": {
                 "createdAt": {
                     "type": "string"
                 },
-                "createdBy": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "string"
                 },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.WishlistItem"
+                    }
+                },
                 "name": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
-                },
-                "stock": {
-                    "type": "integer"
+                "shareToken": {
+                    "type": "string"
                 },
                 "updatedAt": {
                     "type": "string"
+                },
+                "userId": {
+                    "type": "string"
                 }
             }
         },
-        "types.ProductErrMessage": {
+        "types.WishlistErrMessage": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "string"
                 },
+                "items": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "name": {
                     "type": "string"
                 },
-                "price": {
+                "productId": {
                     "type": "string"
                 },
-                "stock": {
+                "productIds": {
                     "type": "string"
-                }
-            }
-        },
-        "types.ProductError": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "$ref": "#/definitions/types.ProductErrMessage"
                 },
-                "message": {
+                "quantity": {
                     "type": "string"
                 },
-                "status": {
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "types.RegisterUserErrMessage": {
+        "types.WishlistError": {
             "type": "object",
             "properties": {
-                "email": {
+                "error": {
+                    "$ref": "#/definitions/types.WishlistErrMessage"
+                },
+                "message": {
                     "type": "string"
                 },
-                "password": {
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "types.RegisterUserInput": {
+        "types.WishlistInput": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "types.RegisterUserOutput": {
+        "types.WishlistItem": {
             "type": "object",
             "properties": {
-                "admin": {
+                "archived": {
                     "type": "boolean"
                 },
                 "createdAt": {
-                    "description": "Password  string    ` + "`" + `json:\"password\"` + "`" + `",
                     "type": "string"
                 },
-                "email": {
+                "id": {
                     "type": "string"
                 },
-                "id": {
+                "name": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "price": {
+                    "type": "number"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "wishlistId": {
                     "type": "string"
                 }
             }
         },
-        "types.UpdateOrderStatusInput": {
+        "types.WishlistItemInput": {
             "type": "object",
             "properties": {
-                "status": {
-                    "$ref": "#/definitions/db.OrderStatus"
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
                 }
             }
         }
@@ -0,0 +1,265 @@
+// Package catalog reads and writes product catalogs as CSV or JSON Lines
+// files, the formats the catalog team edits products in.
+package catalog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	CSV   = "csv"
+	JSONL = "jsonl"
+)
+
+var (
+	ErrUnknownFormat = errors.New("format must be csv or jsonl")
+	ErrTooManyRows   = errors.New("file has too many products")
+)
+
+// Columns are the fields of a catalog file in the order they are exported.
+// The id is only informative, imports match products by sku or name.
+var Columns = []string{"id", "sku", "name", "description", "price", "stock", "category", "taxClass", "weight"}
+
+// requiredColumns must be present in the header of a CSV file.
+var requiredColumns = []string{"name", "description", "price"}
+
+// Row is a product read from a catalog file. Errors holds the fields whose
+// value could not be read, the product itself is not validated.
+type Row struct {
+	Line    int
+	Product types.CreateProductInput
+	Errors  types.ProductErrMessage
+}
+
+// ValidFormat reports whether format is a supported catalog format.
+func ValidFormat(format string) bool {
+	return format == CSV || format == JSONL
+}
+
+// ContentType is the MIME type of catalog files in format.
+func ContentType(format string) string {
+	if format == JSONL {
+		return "application/x-ndjson"
+	}
+	return "text/csv"
+}
+
+// Read reads at most maxRows products from r.
+func Read(r io.Reader, format string, maxRows int) ([]Row, error) {
+	switch format {
+	case CSV:
+		return readCSV(r, maxRows)
+	case JSONL:
+		return readJSONL(r, maxRows)
+	}
+	return nil, ErrUnknownFormat
+}
+
+func readCSV(r io.Reader, maxRows int) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int)
+	for i, column := range header {
+		column = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		if !slices.Contains(Columns, column) {
+			return nil, fmt.Errorf("unknown column %q, columns are %s", column, strings.Join(Columns, ", "))
+		}
+		if _, ok := columns[column]; ok {
+			return nil, fmt.Errorf("column %q appears more than once", column)
+		}
+		columns[column] = i
+	}
+	for _, column := range requiredColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("missing column %q", column)
+		}
+	}
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == maxRows {
+			return nil, ErrTooManyRows
+		}
+		line, _ := reader.FieldPos(0)
+		value := func(column string) string {
+			if i, ok := columns[column]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row := Row{Line: line}
+		row.Product = types.CreateProductInput{
+			Name:        value("name"),
+			Description: value("description"),
+			Category:    value("category"),
+			TaxClass:    value("taxClass"),
+			Sku:         value("sku"),
+		}
+		if row.Product.Price, err = parseFloat(value("price")); err != nil {
+			row.Errors.Price = "price must be a number"
+		}
+		if row.Product.Stock, err = parseInt(value("stock")); err != nil {
+			row.Errors.Stock = "stock must be a whole number"
+		}
+		if row.Product.Weight, err = parseFloat(value("weight")); err != nil {
+			row.Errors.Weight = "weight must be a number"
+		}
+		rows = append(rows, row)
+	}
+}
+
+// jsonRow is a line of a JSON Lines file. The id exported with each product
+// is accepted and ignored.
+type jsonRow struct {
+	ID string `json:"id"`
+	types.CreateProductInput
+}
+
+func readJSONL(r io.Reader, maxRows int) ([]Row, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	var rows []Row
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		if len(rows) == maxRows {
+			return nil, ErrTooManyRows
+		}
+		var value jsonRow
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		row := Row{Line: line}
+		if err := decoder.Decode(&value); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &typeErr) {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			// Only the first field of the wrong type is reported
+			switch typeErr.Field {
+			case "price":
+				row.Errors.Price = "price must be a number"
+			case "stock":
+				row.Errors.Stock = "stock must be a whole number"
+			case "weight":
+				row.Errors.Weight = "weight must be a number"
+			default:
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+		row.Product = value.CreateProductInput
+		rows = append(rows, row)
+	}
+	return rows, scanner.Err()
+}
+
+func parseFloat(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+func parseInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// Writer writes products to a catalog file.
+type Writer struct {
+	format  string
+	csv     *csv.Writer
+	json    *json.Encoder
+	started bool
+}
+
+// NewWriter creates a Writer writing format to w.
+func NewWriter(w io.Writer, format string) (*Writer, error) {
+	switch format {
+	case CSV:
+		return &Writer{format: format, csv: csv.NewWriter(w)}, nil
+	case JSONL:
+		return &Writer{format: format, json: json.NewEncoder(w)}, nil
+	}
+	return nil, ErrUnknownFormat
+}
+
+// Write writes a product, preceded by the header for the first product of a
+// CSV file.
+func (w *Writer) Write(product db.GetAllProductRow) error {
+	if w.format == JSONL {
+		return w.json.Encode(jsonRow{
+			ID: product.ID.String(),
+			CreateProductInput: types.CreateProductInput{
+				Name:        product.Name,
+				Description: product.Description,
+				Price:       product.Price,
+				Stock:       int(product.Stock),
+				Category:    product.Category,
+				TaxClass:    product.TaxClass,
+				Weight:      product.Weight,
+				Sku:         product.Sku,
+			},
+		})
+	}
+	if !w.started {
+		w.started = true
+		if err := w.csv.Write(Columns); err != nil {
+			return err
+		}
+	}
+	return w.csv.Write([]string{
+		product.ID.String(),
+		product.Sku,
+		product.Name,
+		product.Description,
+		strconv.FormatFloat(product.Price, 'f', -1, 64),
+		strconv.Itoa(int(product.Stock)),
+		product.Category,
+		product.TaxClass,
+		strconv.FormatFloat(product.Weight, 'f', -1, 64),
+	})
+}
+
+// Flush writes any buffered data. A CSV file without products still gets its
+// header, so that it can be filled in and imported.
+func (w *Writer) Flush() error {
+	if w.format != CSV {
+		return nil
+	}
+	if !w.started {
+		w.started = true
+		if err := w.csv.Write(Columns); err != nil {
+			return err
+		}
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
@@ -0,0 +1,139 @@
+package coupon
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"math"
+	"time"
+)
+
+const (
+	Percentage = "PERCENTAGE"
+	Fixed      = "FIXED"
+)
+
+var (
+	ErrInactive          = errors.New("coupon is not active")
+	ErrNotStarted        = errors.New("coupon is not yet valid")
+	ErrExpired           = errors.New("coupon has expired")
+	ErrUsageLimitReached = errors.New("coupon usage limit reached")
+	ErrUserLimitReached  = errors.New("coupon already used the maximum number of times")
+	ErrMinOrderValue     = errors.New("order subtotal is below the coupon minimum order value")
+	ErrNotApplicable     = errors.New("coupon does not apply to any product in the order")
+)
+
+// Rule describes the constraints and discount of a single coupon.
+// A zero StartsAt or ExpiresAt means the window is open on that side and a
+// zero UsageLimit or PerUserLimit means unlimited.
+type Rule struct {
+	DiscountType  string
+	Value         float64
+	MinOrderValue float64
+	ProductIds    []uuid.UUID
+	Categories    []string
+	UsageLimit    int32
+	UsageCount    int32
+	PerUserLimit  int32
+	StartsAt      time.Time
+	ExpiresAt     time.Time
+	Active        bool
+}
+
+// Line is a single priced order line the coupon is evaluated against.
+type Line struct {
+	ProductId uuid.UUID
+	Category  string
+	Amount    float64
+}
+
+// Discount validates the rule against the basket and returns the amount to
+// take off the order. userRedemptions is the number of times the current user
+// has already redeemed the coupon.
+func Discount(rule Rule, lines []Line, userRedemptions int64, now time.Time) (float64, error) {
+	if !rule.Active {
+		return 0, ErrInactive
+	}
+	if !rule.StartsAt.IsZero() && now.Before(rule.StartsAt) {
+		return 0, ErrNotStarted
+	}
+	if !rule.ExpiresAt.IsZero() && !now.Before(rule.ExpiresAt) {
+		return 0, ErrExpired
+	}
+	if rule.UsageLimit > 0 && rule.UsageCount >= rule.UsageLimit {
+		return 0, ErrUsageLimitReached
+	}
+	if rule.PerUserLimit > 0 && userRedemptions >= int64(rule.PerUserLimit) {
+		return 0, ErrUserLimitReached
+	}
+	var subtotal, eligible float64
+	for _, line := range lines {
+		subtotal += line.Amount
+		if rule.applies(line) {
+			eligible += line.Amount
+		}
+	}
+	if subtotal < rule.MinOrderValue {
+		return 0, ErrMinOrderValue
+	}
+	if eligible <= 0 {
+		return 0, ErrNotApplicable
+	}
+	var discount float64
+	if rule.DiscountType == Percentage {
+		discount = eligible * rule.Value / 100
+	} else {
+		discount = rule.Value
+	}
+	discount = math.Min(discount, eligible)
+	return math.Round(discount*100) / 100, nil
+}
+
+// applies reports whether the line falls within the product and category
+// restrictions of the rule. A rule without restrictions applies to every line.
+func (rule Rule) applies(line Line) bool {
+	if len(rule.ProductIds) == 0 && len(rule.Categories) == 0 {
+		return true
+	}
+	for _, id := range rule.ProductIds {
+		if id == line.ProductId {
+			return true
+		}
+	}
+	for _, category := range rule.Categories {
+		if category == line.Category {
+			return true
+		}
+	}
+	return false
+}
+
+// Allocate spreads the discount across the lines the rule applies to in
+// proportion to their amount, so that taxes can be charged on the discounted
+// price of each line. Any rounding remainder goes to the last eligible line.
+func Allocate(rule Rule, lines []Line, discount float64) []float64 {
+	shares := make([]float64, len(lines))
+	var eligible float64
+	last := -1
+	for i, line := range lines {
+		if rule.applies(line) {
+			eligible += line.Amount
+			last = i
+		}
+	}
+	if eligible <= 0 || discount <= 0 {
+		return shares
+	}
+	var allocated float64
+	for i, line := range lines {
+		if !rule.applies(line) {
+			continue
+		}
+		if i == last {
+			shares[i] = math.Round((discount-allocated)*100) / 100
+			break
+		}
+		shares[i] = math.Round(discount*line.Amount/eligible*100) / 100
+		allocated += shares[i]
+	}
+	return shares
+}
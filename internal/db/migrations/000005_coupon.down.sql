@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "couponRedemption";
+ALTER TABLE "order" DROP CONSTRAINT IF EXISTS "fk_coupon";
+ALTER TABLE "order" DROP COLUMN IF EXISTS "couponId";
+ALTER TABLE "order" DROP COLUMN IF EXISTS "discount";
+ALTER TABLE "order" DROP COLUMN IF EXISTS "subtotal";
+DROP TABLE IF EXISTS "coupon";
+DROP TYPE IF EXISTS "discount_type";
+ALTER TABLE "product" DROP COLUMN IF EXISTS "category";
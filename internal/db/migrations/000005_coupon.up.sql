@@ -0,0 +1,50 @@
+ALTER TABLE "product" ADD COLUMN "category" VARCHAR(100) NOT NULL DEFAULT '';  -- Product category, used for coupon restrictions
+
+CREATE TYPE "discount_type" AS ENUM ('PERCENTAGE', 'FIXED');
+
+CREATE TABLE "coupon" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the coupon
+    "code" VARCHAR(50) UNIQUE NOT NULL,  -- Code entered by the customer at checkout, unique and cannot be null
+    "discountType" "discount_type" NOT NULL,  -- Whether the value is a percentage or a fixed amount
+    "value" FLOAT NOT NULL,  -- Percentage (0-100] or fixed amount taken off the eligible subtotal
+    "minOrderValue" FLOAT NOT NULL DEFAULT 0,  -- Minimum order subtotal required to use the coupon
+    "productIds" UUID[] NOT NULL DEFAULT '{}',  -- Products the coupon is restricted to, empty means all products
+    "categories" TEXT[] NOT NULL DEFAULT '{}',  -- Categories the coupon is restricted to, empty means all categories
+    "usageLimit" INT NOT NULL DEFAULT 0,  -- Total number of redemptions allowed, 0 means unlimited
+    "perUserLimit" INT NOT NULL DEFAULT 0,  -- Number of redemptions allowed per user, 0 means unlimited
+    "usageCount" INT NOT NULL DEFAULT 0,  -- Number of times the coupon has been redeemed
+    "startsAt" TIMESTAMP,  -- Start of the validity window, NULL means immediately
+    "expiresAt" TIMESTAMP,  -- End of the validity window, NULL means never
+    "active" BOOLEAN NOT NULL DEFAULT TRUE,  -- Whether the coupon can currently be redeemed
+    "createdBy" UUID NOT NULL,  -- UUID of the admin who created the coupon
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the coupon was created
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the coupon was last updated
+    CONSTRAINT "fk_user" FOREIGN KEY ("createdBy") REFERENCES "user"("id")
+        ON DELETE RESTRICT,
+    CONSTRAINT "check_value_positive" CHECK ("value" > 0),
+    CONSTRAINT "check_usage_count" CHECK ("usageLimit" = 0 OR "usageCount" <= "usageLimit")
+);
+
+ALTER TABLE "order" ADD COLUMN "subtotal" FLOAT NOT NULL DEFAULT 0;  -- Sum of all order item prices before discount
+ALTER TABLE "order" ADD COLUMN "discount" FLOAT NOT NULL DEFAULT 0;  -- Amount taken off the subtotal by a coupon
+ALTER TABLE "order" ADD COLUMN "couponId" UUID;  -- Coupon redeemed on the order, if any
+ALTER TABLE "order" ADD CONSTRAINT "fk_coupon" FOREIGN KEY ("couponId") REFERENCES "coupon"("id")
+    ON DELETE SET NULL;  -- Keeps past orders intact if a coupon is removed
+UPDATE "order" SET "subtotal" = "total";
+
+CREATE TABLE "couponRedemption" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the redemption
+    "couponId" UUID NOT NULL,  -- UUID of the redeemed coupon
+    "userId" UUID NOT NULL,  -- UUID of the user who redeemed the coupon
+    "orderId" UUID NOT NULL,  -- UUID of the order the coupon was applied to
+    "discount" FLOAT NOT NULL,  -- Amount taken off the order
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the coupon was redeemed
+    CONSTRAINT "fk_coupon" FOREIGN KEY ("couponId") REFERENCES "coupon"("id")
+        ON DELETE CASCADE,
+    CONSTRAINT "fk_user" FOREIGN KEY ("userId") REFERENCES "user"("id")
+        ON DELETE CASCADE,
+    CONSTRAINT "fk_order" FOREIGN KEY ("orderId") REFERENCES "order"("id")
+        ON DELETE CASCADE
+);
+
+CREATE INDEX "idx_coupon_redemption_user" ON "couponRedemption" ("couponId", "userId");
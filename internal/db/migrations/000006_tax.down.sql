@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "orderTax";
+ALTER TABLE "orderItem" DROP COLUMN IF EXISTS "tax";
+ALTER TABLE "order" DROP COLUMN IF EXISTS "shippingRegion";
+ALTER TABLE "order" DROP COLUMN IF EXISTS "tax";
+DROP TABLE IF EXISTS "taxRule";
+ALTER TABLE "product" DROP COLUMN IF EXISTS "taxClass";
@@ -0,0 +1,34 @@
+ALTER TABLE "product" ADD COLUMN "taxClass" VARCHAR(50) NOT NULL DEFAULT 'standard';  -- Tax class used to pick the tax rules that apply to the product
+
+CREATE TABLE "taxRule" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the tax rule
+    "name" VARCHAR(100) NOT NULL,  -- Name shown in the order tax breakdown, e.g. VAT or State Sales Tax
+    "taxClass" VARCHAR(50) NOT NULL,  -- Product tax class the rule applies to
+    "region" VARCHAR(50) NOT NULL DEFAULT '',  -- Shipping region the rule applies to, e.g. GB or US-CA. Empty means every region
+    "rate" FLOAT NOT NULL,  -- Tax rate as a percentage
+    "inclusive" BOOLEAN NOT NULL DEFAULT FALSE,  -- Whether product prices already include the tax
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the tax rule was created
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the tax rule was last updated
+    CONSTRAINT "check_rate_range" CHECK ("rate" >= 0 AND "rate" <= 100)
+);
+
+CREATE INDEX "idx_tax_rule_tax_class" ON "taxRule" ("taxClass");
+
+ALTER TABLE "order" ADD COLUMN "tax" FLOAT NOT NULL DEFAULT 0;  -- Total tax on the order, inclusive and exclusive
+ALTER TABLE "order" ADD COLUMN "shippingRegion" VARCHAR(50) NOT NULL DEFAULT '';  -- Region the order ships to, used to pick tax rules
+ALTER TABLE "orderItem" ADD COLUMN "tax" FLOAT NOT NULL DEFAULT 0;  -- Tax on the order item
+
+CREATE TABLE "orderTax" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the order tax line
+    "orderId" UUID NOT NULL,  -- UUID of the order the tax was charged on
+    "taxRuleId" UUID,  -- UUID of the rule the tax was computed from, NULL once the rule is deleted
+    "name" VARCHAR(100) NOT NULL,  -- Name of the tax rule at the time of the order
+    "rate" FLOAT NOT NULL,  -- Rate of the tax rule at the time of the order
+    "inclusive" BOOLEAN NOT NULL,  -- Whether the tax was included in the product prices
+    "amount" FLOAT NOT NULL,  -- Total tax charged at this rate across the order
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the order tax line was created
+    CONSTRAINT "fk_order" FOREIGN KEY ("orderId") REFERENCES "order"("id")
+        ON DELETE CASCADE,
+    CONSTRAINT "fk_tax_rule" FOREIGN KEY ("taxRuleId") REFERENCES "taxRule"("id")
+        ON DELETE SET NULL
+);
@@ -0,0 +1,9 @@
+ALTER TABLE "order" DROP CONSTRAINT IF EXISTS "fk_shipping_method";
+ALTER TABLE "order" DROP COLUMN IF EXISTS "shippingCost";
+ALTER TABLE "order" DROP COLUMN IF EXISTS "shippingMethod";
+ALTER TABLE "order" DROP COLUMN IF EXISTS "shippingMethodId";
+DROP TABLE IF EXISTS "shippingMethod";
+DROP TABLE IF EXISTS "shippingZone";
+DROP TYPE IF EXISTS "shipping_rate_type";
+ALTER TABLE "product" DROP CONSTRAINT IF EXISTS "check_weight_non_negative";
+ALTER TABLE "product" DROP COLUMN IF EXISTS "weight";
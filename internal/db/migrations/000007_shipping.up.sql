@@ -0,0 +1,36 @@
+ALTER TABLE "product" ADD COLUMN "weight" FLOAT NOT NULL DEFAULT 0;  -- Shipping weight of a single unit of the product in kilograms
+ALTER TABLE "product" ADD CONSTRAINT "check_weight_non_negative" CHECK ("weight" >= 0);
+
+CREATE TYPE "shipping_rate_type" AS ENUM ('FLAT', 'WEIGHT', 'FREE_OVER');
+
+CREATE TABLE "shippingZone" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the shipping zone
+    "name" VARCHAR(100) UNIQUE NOT NULL,  -- Name of the zone, e.g. Domestic or Europe
+    "regions" VARCHAR(50)[] NOT NULL DEFAULT '{}',  -- Regions covered by the zone, e.g. GB or US-CA. Empty means every region
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the zone was created
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW()  -- Timestamp of when the zone was last updated
+);
+
+CREATE TABLE "shippingMethod" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the shipping method
+    "zoneId" UUID NOT NULL,  -- UUID of the zone the method ships to
+    "name" VARCHAR(100) NOT NULL,  -- Name shown to the customer, e.g. Standard or Express
+    "rateType" "shipping_rate_type" NOT NULL,  -- How the cost of the method is calculated
+    "rate" FLOAT NOT NULL DEFAULT 0,  -- Flat cost, or base cost of weight based and free over threshold methods
+    "ratePerKg" FLOAT NOT NULL DEFAULT 0,  -- Cost added per kilogram for weight based methods
+    "freeOver" FLOAT NOT NULL DEFAULT 0,  -- Order subtotal from which free over threshold methods cost nothing
+    "active" BOOLEAN NOT NULL DEFAULT TRUE,  -- Whether the method can currently be selected
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the method was created
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the method was last updated
+    CONSTRAINT "fk_shipping_zone" FOREIGN KEY ("zoneId") REFERENCES "shippingZone"("id")
+        ON DELETE CASCADE,
+    CONSTRAINT "check_rates_non_negative" CHECK ("rate" >= 0 AND "ratePerKg" >= 0 AND "freeOver" >= 0)
+);
+
+CREATE INDEX "idx_shipping_method_zone_id" ON "shippingMethod" ("zoneId");
+
+ALTER TABLE "order" ADD COLUMN "shippingMethodId" UUID;  -- UUID of the shipping method selected for the order
+ALTER TABLE "order" ADD COLUMN "shippingMethod" VARCHAR(100) NOT NULL DEFAULT '';  -- Name of the shipping method at the time of the order
+ALTER TABLE "order" ADD COLUMN "shippingCost" FLOAT NOT NULL DEFAULT 0;  -- Shipping cost included in the order total
+ALTER TABLE "order" ADD CONSTRAINT "fk_shipping_method" FOREIGN KEY ("shippingMethodId") REFERENCES "shippingMethod"("id")
+    ON DELETE SET NULL;
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS "shipmentItem";
+DROP TABLE IF EXISTS "shipment";
+
+-- Enum values cannot be dropped, so the type is recreated without the shipping statuses.
+UPDATE "order" SET "status" = 'COMPLETED' WHERE "status" IN ('PARTIALLY_SHIPPED', 'SHIPPED', 'DELIVERED');
+ALTER TABLE "order" ALTER COLUMN "status" DROP DEFAULT;
+ALTER TYPE "order_status" RENAME TO "order_status_old";
+CREATE TYPE "order_status" AS ENUM ('PENDING', 'COMPLETED', 'CANCELLED');
+ALTER TABLE "order" ALTER COLUMN "status" TYPE "order_status" USING "status"::TEXT::"order_status";
+ALTER TABLE "order" ALTER COLUMN "status" SET DEFAULT 'PENDING';
+DROP TYPE "order_status_old";
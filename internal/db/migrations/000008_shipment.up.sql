@@ -0,0 +1,37 @@
+ALTER TYPE "order_status" ADD VALUE IF NOT EXISTS 'PARTIALLY_SHIPPED';
+ALTER TYPE "order_status" ADD VALUE IF NOT EXISTS 'SHIPPED';
+ALTER TYPE "order_status" ADD VALUE IF NOT EXISTS 'DELIVERED';
+
+CREATE TABLE "shipment" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the shipment
+    "orderId" UUID NOT NULL,  -- UUID of the order the shipment fulfils
+    "carrier" VARCHAR(100) NOT NULL,  -- Carrier handling the shipment, e.g. DHL or UPS
+    "trackingNumber" VARCHAR(100) NOT NULL DEFAULT '',  -- Tracking number given by the carrier
+    "shippedAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the shipment left the warehouse
+    "deliveredAt" TIMESTAMP,  -- Timestamp of when the shipment was delivered, NULL while in transit
+    "createdBy" UUID NOT NULL,  -- UUID of the admin who created the shipment
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the shipment was created
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the shipment was last updated
+    CONSTRAINT "fk_order" FOREIGN KEY ("orderId") REFERENCES "order"("id")
+        ON DELETE CASCADE,
+    CONSTRAINT "fk_user" FOREIGN KEY ("createdBy") REFERENCES "user"("id")
+        ON DELETE RESTRICT
+);
+
+CREATE INDEX "idx_shipment_order_id" ON "shipment" ("orderId");
+
+CREATE TABLE "shipmentItem" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the shipment item
+    "shipmentId" UUID NOT NULL,  -- UUID of the shipment the item was shipped in
+    "orderItemId" UUID NOT NULL,  -- UUID of the order item being shipped
+    "productId" UUID NOT NULL,  -- UUID of the product being shipped
+    "quantity" INT NOT NULL,  -- Number of units of the order item in the shipment
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the shipment item was created
+    CONSTRAINT "fk_shipment" FOREIGN KEY ("shipmentId") REFERENCES "shipment"("id")
+        ON DELETE CASCADE,
+    CONSTRAINT "fk_order_item" FOREIGN KEY ("orderItemId") REFERENCES "orderItem"("id")
+        ON DELETE CASCADE,
+    CONSTRAINT "check_quantity_positive" CHECK ("quantity" > 0)
+);
+
+CREATE INDEX "idx_shipment_item_order_item_id" ON "shipmentItem" ("orderItemId");
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "returnEvent";
+DROP TABLE IF EXISTS "returnItem";
+DROP TABLE IF EXISTS "returnRequest";
+DROP TYPE IF EXISTS "return_status";
@@ -0,0 +1,48 @@
+CREATE TYPE "return_status" AS ENUM ('REQUESTED', 'APPROVED', 'REJECTED', 'RECEIVED', 'REFUNDED');
+
+CREATE TABLE "returnRequest" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the return request (RMA)
+    "orderId" UUID NOT NULL,  -- UUID of the order the products are returned from
+    "userId" UUID NOT NULL,  -- UUID of the customer returning the products
+    "status" "return_status" NOT NULL DEFAULT 'REQUESTED',  -- Current state of the return
+    "refundAmount" FLOAT NOT NULL DEFAULT 0,  -- Amount refunded once the returned products are received
+    "refundReference" VARCHAR(255) NOT NULL DEFAULT '',  -- Reference of the refund given by the refund provider
+    "refundedAt" TIMESTAMP,  -- Timestamp of when the refund was issued, NULL until refunded
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the return was requested
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the return was last updated
+    CONSTRAINT "fk_order" FOREIGN KEY ("orderId") REFERENCES "order"("id")
+        ON DELETE CASCADE,
+    CONSTRAINT "fk_user" FOREIGN KEY ("userId") REFERENCES "user"("id")
+        ON DELETE CASCADE
+);
+
+CREATE INDEX "idx_return_request_order_id" ON "returnRequest" ("orderId");
+
+CREATE TABLE "returnItem" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the return item
+    "returnId" UUID NOT NULL,  -- UUID of the return request the item belongs to
+    "orderItemId" UUID NOT NULL,  -- UUID of the order item being returned
+    "productId" UUID NOT NULL,  -- UUID of the product being returned
+    "quantity" INT NOT NULL,  -- Number of units returned
+    "reason" TEXT NOT NULL,  -- Why the customer is returning the product
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the return item was created
+    CONSTRAINT "fk_return_request" FOREIGN KEY ("returnId") REFERENCES "returnRequest"("id")
+        ON DELETE CASCADE,
+    CONSTRAINT "fk_order_item" FOREIGN KEY ("orderItemId") REFERENCES "orderItem"("id")
+        ON DELETE CASCADE,
+    CONSTRAINT "check_quantity_positive" CHECK ("quantity" > 0)
+);
+
+CREATE INDEX "idx_return_item_order_item_id" ON "returnItem" ("orderItemId");
+
+CREATE TABLE "returnEvent" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the history entry
+    "returnId" UUID NOT NULL,  -- UUID of the return request the entry belongs to
+    "fromStatus" "return_status",  -- State before the transition, NULL when the return was requested
+    "toStatus" "return_status" NOT NULL,  -- State after the transition
+    "note" TEXT NOT NULL DEFAULT '',  -- Note left by the customer or admin
+    "actorId" UUID NOT NULL,  -- UUID of the user who made the transition
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of the transition
+    CONSTRAINT "fk_return_request" FOREIGN KEY ("returnId") REFERENCES "returnRequest"("id")
+        ON DELETE CASCADE
+);
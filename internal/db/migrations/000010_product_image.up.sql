@@ -0,0 +1,19 @@
+CREATE TABLE "productImage" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the product image
+    "productId" UUID NOT NULL,  -- UUID of the product the image belongs to
+    "key" VARCHAR(255) NOT NULL UNIQUE,  -- Key of the image file in the blob store
+    "contentType" VARCHAR(50) NOT NULL,  -- MIME type of the image file
+    "size" BIGINT NOT NULL CHECK ("size" > 0),  -- Size of the image file in bytes
+    "position" INT NOT NULL DEFAULT 0,  -- Display order of the image among the product images, lowest first
+    "isPrimary" BOOLEAN NOT NULL DEFAULT FALSE,  -- Whether the image is the main image of the product
+    "createdBy" UUID NOT NULL,  -- UUID of the admin who uploaded the image
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the image was uploaded
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the image was last updated
+    CONSTRAINT "fk_product" FOREIGN KEY ("productId") REFERENCES "product"("id")
+        ON DELETE CASCADE
+);
+
+CREATE INDEX "idx_product_image_product_id" ON "productImage" ("productId");
+
+-- A product has at most one primary image
+CREATE UNIQUE INDEX "idx_product_image_primary" ON "productImage" ("productId") WHERE "isPrimary";
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_product_sku";
+
+ALTER TABLE "product" DROP COLUMN IF EXISTS "sku";
@@ -0,0 +1,3 @@
+ALTER TABLE "product" ADD COLUMN "sku" VARCHAR(64) NOT NULL DEFAULT '';  -- Stock keeping unit of the product, empty when it has none
+
+CREATE UNIQUE INDEX "idx_product_sku" ON "product" ("sku") WHERE "sku" <> '';
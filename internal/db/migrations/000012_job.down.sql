@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "job";
+DROP TYPE IF EXISTS "job_status";
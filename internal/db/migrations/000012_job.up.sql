@@ -0,0 +1,22 @@
+CREATE TYPE "job_status" AS ENUM ('PENDING', 'RUNNING', 'SUCCEEDED', 'DEAD');
+
+CREATE TABLE "job" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the job
+    "kind" VARCHAR(100) NOT NULL,  -- Name of the handler that runs the job, e.g. product.import
+    "payload" JSONB NOT NULL DEFAULT '{}',  -- Arguments of the job, passed to its handler
+    "status" "job_status" NOT NULL DEFAULT 'PENDING',  -- Current state of the job, DEAD once it failed too many times
+    "attempts" INT NOT NULL DEFAULT 0,  -- Number of times the job was started
+    "maxAttempts" INT NOT NULL DEFAULT 5,  -- Number of attempts after which a failing job is dead
+    "runAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp from which the job can run, pushed back after each failure
+    "lockedAt" TIMESTAMP,  -- Timestamp of when a worker claimed the job, NULL unless running
+    "lockedBy" VARCHAR(100) NOT NULL DEFAULT '',  -- Worker running the job
+    "lastError" TEXT NOT NULL DEFAULT '',  -- Error of the last failed attempt
+    "completedAt" TIMESTAMP,  -- Timestamp of when the job succeeded or died
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the job was enqueued
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the job was last updated
+    CONSTRAINT "check_attempts" CHECK ("attempts" >= 0 AND "maxAttempts" > 0)
+);
+
+-- Workers only ever look for pending jobs that are due
+CREATE INDEX "idx_job_pending_run_at" ON "job" ("runAt") WHERE "status" = 'PENDING';
+CREATE INDEX "idx_job_status_created_at" ON "job" ("status", "createdAt");
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "webhookDelivery";
+DROP TYPE IF EXISTS "webhook_delivery_status";
+DROP TABLE IF EXISTS "webhook";
+DROP TABLE IF EXISTS "outboxEvent";
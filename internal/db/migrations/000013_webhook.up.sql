@@ -0,0 +1,43 @@
+CREATE TABLE "outboxEvent" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the event, sent to webhooks so they can drop duplicates
+    "type" VARCHAR(100) NOT NULL,  -- Type of the event, e.g. order.created
+    "payload" JSONB NOT NULL DEFAULT '{}',  -- Data of the event, as sent to webhooks
+    "dispatchedAt" TIMESTAMP,  -- Timestamp of when deliveries were created for the event, NULL until then
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW()  -- Timestamp of when the event happened
+);
+
+CREATE INDEX "idx_outbox_event_pending" ON "outboxEvent" ("createdAt") WHERE "dispatchedAt" IS NULL;
+
+CREATE TABLE "webhook" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the webhook
+    "url" TEXT NOT NULL,  -- URL the events are posted to
+    "secret" VARCHAR(255) NOT NULL,  -- Key the deliveries are signed with
+    "events" TEXT[] NOT NULL DEFAULT '{}',  -- Types of the events the webhook subscribes to
+    "active" BOOLEAN NOT NULL DEFAULT TRUE,  -- Whether events are delivered to the webhook
+    "createdBy" UUID NOT NULL,  -- UUID of the admin who created the webhook
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the webhook was created
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW()  -- Timestamp of when the webhook was last updated
+);
+
+CREATE TYPE "webhook_delivery_status" AS ENUM ('PENDING', 'SUCCEEDED', 'FAILED');
+
+CREATE TABLE "webhookDelivery" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the delivery, sent along with it
+    "webhookId" UUID NOT NULL,  -- UUID of the webhook the event is delivered to
+    "eventId" UUID NOT NULL,  -- UUID of the event delivered
+    "eventType" VARCHAR(100) NOT NULL,  -- Type of the event delivered
+    "status" "webhook_delivery_status" NOT NULL DEFAULT 'PENDING',  -- PENDING until the webhook answers with a 2xx status or every attempt failed
+    "attempts" INT NOT NULL DEFAULT 0,  -- Number of times the event was posted
+    "responseStatus" INT NOT NULL DEFAULT 0,  -- HTTP status of the last response, 0 when there was none
+    "responseBody" TEXT NOT NULL DEFAULT '',  -- Start of the body of the last response
+    "lastError" TEXT NOT NULL DEFAULT '',  -- Why the last attempt failed
+    "deliveredAt" TIMESTAMP,  -- Timestamp of when the webhook accepted the event
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the delivery was created
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of the last attempt
+    CONSTRAINT "fk_webhook" FOREIGN KEY ("webhookId") REFERENCES "webhook"("id")
+        ON DELETE CASCADE,
+    CONSTRAINT "fk_outbox_event" FOREIGN KEY ("eventId") REFERENCES "outboxEvent"("id")
+        ON DELETE CASCADE
+);
+
+CREATE INDEX "idx_webhook_delivery_webhook_id" ON "webhookDelivery" ("webhookId", "createdAt");
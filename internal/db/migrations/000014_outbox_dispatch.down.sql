@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "idx_outbox_event_pending";
+CREATE INDEX "idx_outbox_event_pending" ON "outboxEvent" ("createdAt") WHERE "dispatchedAt" IS NULL;
+
+ALTER TABLE "outboxEvent"
+    DROP COLUMN IF EXISTS "lastError",
+    DROP COLUMN IF EXISTS "nextAttemptAt",
+    DROP COLUMN IF EXISTS "attempts";
@@ -0,0 +1,9 @@
+-- Events are relayed to their sinks by a dispatcher polling the outbox,
+-- which retries the events a sink failed to take
+ALTER TABLE "outboxEvent"
+    ADD COLUMN "attempts" INT NOT NULL DEFAULT 0,  -- Number of times the event was relayed to the sinks
+    ADD COLUMN "nextAttemptAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp from which the event can be relayed, pushed back while it is being relayed and after a failure
+    ADD COLUMN "lastError" TEXT NOT NULL DEFAULT '';  -- Why the sinks failed to take the event the last time
+
+DROP INDEX IF EXISTS "idx_outbox_event_pending";
+CREATE INDEX "idx_outbox_event_pending" ON "outboxEvent" ("nextAttemptAt") WHERE "dispatchedAt" IS NULL;
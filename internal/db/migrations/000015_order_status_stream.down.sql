@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS notify_order_status_after_update ON "order";
+DROP FUNCTION IF EXISTS notify_order_status();
+DROP TABLE IF EXISTS "orderStatusEvent";
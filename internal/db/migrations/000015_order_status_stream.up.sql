@@ -0,0 +1,32 @@
+-- Every status change of an order is kept, so that live streams can resume
+-- from the last change a client saw
+CREATE TABLE IF NOT EXISTS "orderStatusEvent" (
+    "id" BIGSERIAL PRIMARY KEY,  -- Increasing id of the change, sent as the SSE event id
+    "orderId" UUID NOT NULL REFERENCES "order"("id") ON DELETE CASCADE,  -- Order whose status changed
+    "userId" UUID NOT NULL,  -- Owner of the order
+    "status" "order_status" NOT NULL,  -- Status the order moved to
+    "previousStatus" "order_status" NOT NULL,  -- Status the order moved from
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW()  -- Timestamp of the change
+);
+
+CREATE INDEX IF NOT EXISTS "idx_order_status_event_user" ON "orderStatusEvent" ("userId", "id");
+
+-- Record the change and notify the listeners on the order_status channel.
+-- The notification is only sent once the transaction commits.
+CREATE OR REPLACE FUNCTION notify_order_status() RETURNS TRIGGER AS $$
+DECLARE
+    event "orderStatusEvent";
+BEGIN
+    INSERT INTO "orderStatusEvent" ("orderId", "userId", "status", "previousStatus")
+    VALUES (NEW."id", NEW."userId", NEW."status", OLD."status")
+    RETURNING * INTO event;
+    PERFORM pg_notify('order_status', row_to_json(event)::TEXT);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notify_order_status_after_update
+AFTER UPDATE OF "status" ON "order"
+FOR EACH ROW
+WHEN (OLD."status" IS DISTINCT FROM NEW."status")
+EXECUTE FUNCTION notify_order_status();
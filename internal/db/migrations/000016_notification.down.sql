@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "notification";
+DROP TABLE IF EXISTS "notificationPreference";
+DROP TYPE IF EXISTS "notification_status";
+DROP TYPE IF EXISTS "notification_kind";
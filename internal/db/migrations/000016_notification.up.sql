@@ -0,0 +1,33 @@
+CREATE TYPE "notification_kind" AS ENUM ('ORDER_PLACED', 'ORDER_CANCELLED', 'ORDER_COMPLETED', 'ORDER_SHIPPED');
+CREATE TYPE "notification_status" AS ENUM ('PENDING', 'SENT', 'FAILED');
+
+-- Which emails a user wants. Users without a row get every email.
+CREATE TABLE IF NOT EXISTS "notificationPreference" (
+    "userId" UUID PRIMARY KEY REFERENCES "user"("id") ON DELETE CASCADE,  -- User the preferences belong to
+    "orderPlaced" BOOLEAN NOT NULL DEFAULT TRUE,  -- Email when an order is placed
+    "orderCancelled" BOOLEAN NOT NULL DEFAULT TRUE,  -- Email when an order is cancelled
+    "orderCompleted" BOOLEAN NOT NULL DEFAULT TRUE,  -- Email when an order is completed
+    "orderShipped" BOOLEAN NOT NULL DEFAULT TRUE,  -- Email when an order is shipped
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of creation
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW()  -- Timestamp of last update
+);
+
+-- Every email sent to a user, sent by the job queue
+CREATE TABLE IF NOT EXISTS "notification" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the notification
+    "userId" UUID NOT NULL REFERENCES "user"("id") ON DELETE CASCADE,  -- User the email is sent to
+    "orderId" UUID NOT NULL REFERENCES "order"("id") ON DELETE CASCADE,  -- Order the email is about
+    "eventId" UUID NOT NULL,  -- Outbox event the email was raised by
+    "kind" "notification_kind" NOT NULL,  -- Template of the email
+    "email" VARCHAR(255) NOT NULL,  -- Address the email is sent to
+    "status" "notification_status" NOT NULL DEFAULT 'PENDING',  -- Delivery status (PENDING, SENT, FAILED)
+    "attempts" INT NOT NULL DEFAULT 0,  -- Number of attempts at sending the email
+    "lastError" TEXT NOT NULL DEFAULT '',  -- Why the last attempt failed
+    "sentAt" TIMESTAMP,  -- Timestamp the email was accepted by the mail server
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of creation
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of last update
+    -- An event raises each email once, however often it is relayed
+    UNIQUE ("eventId", "kind")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_notification_user" ON "notification" ("userId", "createdAt");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "lowStockAlert";
+
+ALTER TABLE "product" DROP COLUMN IF EXISTS "reorderThreshold";
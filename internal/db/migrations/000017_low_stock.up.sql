@@ -0,0 +1,22 @@
+ALTER TABLE "product" ADD COLUMN "reorderThreshold" INT NOT NULL DEFAULT 5 CHECK ("reorderThreshold" >= 0);  -- Stock at or below which the product is low on stock
+
+-- A product running low on stock. The alert stays open until the product is
+-- restocked above its reorder threshold.
+CREATE TABLE IF NOT EXISTS "lowStockAlert" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the alert
+    "productId" UUID NOT NULL REFERENCES "product"("id") ON DELETE CASCADE,  -- Product low on stock
+    "stock" INT NOT NULL,  -- Stock of the product when the alert was raised
+    "threshold" INT NOT NULL,  -- Reorder threshold of the product when the alert was raised
+    "notifiedAt" TIMESTAMP,  -- Timestamp the alert was emailed to the staff
+    "resolvedAt" TIMESTAMP,  -- Timestamp the product was restocked
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW()  -- Timestamp of creation
+);
+
+-- A product has at most one open alert
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_low_stock_alert_open" ON "lowStockAlert" ("productId") WHERE "resolvedAt" IS NULL;
+
+-- Products already low on stock start with an open alert
+INSERT INTO "lowStockAlert" ("id", "productId", "stock", "threshold")
+SELECT gen_random_uuid(), "id", "stock", "reorderThreshold"
+FROM "product"
+WHERE "stock" <= "reorderThreshold";
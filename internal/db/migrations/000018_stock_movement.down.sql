@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "stockMovement";
+DROP FUNCTION IF EXISTS forbid_stock_movement_change();
+DROP TYPE IF EXISTS "stock_movement_kind";
@@ -0,0 +1,41 @@
+CREATE TYPE "stock_movement_kind" AS ENUM ('ORDER_PLACED', 'ORDER_CANCELLED', 'ADJUSTMENT', 'IMPORT', 'RETURN');
+
+-- Ledger of every change to the stock of the products, written in the same
+-- transaction as the change. The stock of a product is the sum of its
+-- movements.
+CREATE TABLE IF NOT EXISTS "stockMovement" (
+    "id" BIGSERIAL PRIMARY KEY,  -- Increasing id of the movement
+    "productId" UUID NOT NULL REFERENCES "product"("id") ON DELETE CASCADE,  -- Product whose stock changed
+    "quantity" INT NOT NULL,  -- Units added to the stock, negative when units were taken off
+    "stockAfter" INT NOT NULL,  -- Stock of the product after the movement
+    "kind" "stock_movement_kind" NOT NULL,  -- Why the stock changed
+    "orderId" UUID,  -- Order placed or cancelled, kept after the order is gone
+    "returnId" UUID,  -- Return whose products were received back, kept after the return is gone
+    "createdBy" UUID,  -- User who made the change, NULL for the opening balances
+    "note" TEXT NOT NULL DEFAULT '',  -- Reason given for a manual adjustment
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW()  -- Timestamp of the movement
+);
+
+CREATE INDEX IF NOT EXISTS "idx_stock_movement_product" ON "stockMovement" ("productId", "id");
+
+-- Movements are never changed nor removed, except along with their product
+CREATE OR REPLACE FUNCTION forbid_stock_movement_change() RETURNS TRIGGER AS $$
+BEGIN
+    -- Deletes cascading from a product run one trigger deeper
+    IF TG_OP = 'DELETE' AND pg_trigger_depth() > 1 THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'stock movements are append-only';
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER forbid_stock_movement_change
+BEFORE UPDATE OR DELETE ON "stockMovement"
+FOR EACH ROW
+EXECUTE FUNCTION forbid_stock_movement_change();
+
+-- The stock of the existing products is their opening balance
+INSERT INTO "stockMovement" ("productId", "quantity", "stockAfter", "kind", "note")
+SELECT "id", "stock", "stock", 'ADJUSTMENT', 'Opening balance'
+FROM "product"
+WHERE "stock" <> 0;
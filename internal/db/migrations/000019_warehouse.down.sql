@@ -0,0 +1,10 @@
+-- Enum values cannot be dropped, TRANSFER stays in stock_movement_kind
+ALTER TABLE "stockMovement" DISABLE TRIGGER forbid_stock_movement_change;
+DELETE FROM "stockMovement" WHERE "kind" = 'TRANSFER';
+ALTER TABLE "stockMovement" ENABLE TRIGGER forbid_stock_movement_change;
+ALTER TABLE "stockMovement" DROP COLUMN IF EXISTS "warehouseId";
+
+DROP TABLE IF EXISTS "stockTransfer";
+DROP TABLE IF EXISTS "orderAllocation";
+DROP TABLE IF EXISTS "warehouseStock";
+DROP TABLE IF EXISTS "warehouse";
@@ -0,0 +1,69 @@
+-- Places the products are stocked in and shipped from
+CREATE TABLE IF NOT EXISTS "warehouse" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the warehouse
+    "name" VARCHAR(100) UNIQUE NOT NULL,  -- Name of the warehouse
+    "priority" INT NOT NULL DEFAULT 0,  -- Orders are shipped from the warehouses with the lowest priority first
+    "active" BOOLEAN NOT NULL DEFAULT TRUE,  -- Inactive warehouses ship no orders and receive no stock
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of creation
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW()  -- Timestamp of last update
+);
+
+-- Units of each product held by each warehouse. The stock of a product is
+-- the sum of what the warehouses hold.
+CREATE TABLE IF NOT EXISTS "warehouseStock" (
+    "warehouseId" UUID NOT NULL REFERENCES "warehouse"("id") ON DELETE RESTRICT,  -- Warehouse holding the units
+    "productId" UUID NOT NULL REFERENCES "product"("id") ON DELETE CASCADE,  -- Product held
+    "stock" INT NOT NULL DEFAULT 0 CHECK ("stock" >= 0),  -- Units held
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of last update
+    PRIMARY KEY ("warehouseId", "productId")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_warehouse_stock_product" ON "warehouseStock" ("productId");
+
+-- Warehouses each item of an order is shipped from. An item split across
+-- warehouses has one allocation per warehouse.
+CREATE TABLE IF NOT EXISTS "orderAllocation" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the allocation
+    "orderId" UUID NOT NULL REFERENCES "order"("id") ON DELETE CASCADE,  -- Order the item belongs to
+    "orderItemId" UUID NOT NULL REFERENCES "orderItem"("id") ON DELETE CASCADE,  -- Item shipped
+    "productId" UUID NOT NULL,  -- Product of the item
+    "warehouseId" UUID NOT NULL REFERENCES "warehouse"("id") ON DELETE RESTRICT,  -- Warehouse shipping the units
+    "quantity" INT NOT NULL CHECK ("quantity" > 0),  -- Units shipped from the warehouse
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW()  -- Timestamp of creation
+);
+
+CREATE INDEX IF NOT EXISTS "idx_order_allocation_order" ON "orderAllocation" ("orderId");
+
+-- Stock moved between warehouses by an admin
+CREATE TABLE IF NOT EXISTS "stockTransfer" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the transfer
+    "productId" UUID NOT NULL REFERENCES "product"("id") ON DELETE CASCADE,  -- Product moved
+    "fromWarehouseId" UUID NOT NULL REFERENCES "warehouse"("id") ON DELETE RESTRICT,  -- Warehouse the units left
+    "toWarehouseId" UUID NOT NULL REFERENCES "warehouse"("id") ON DELETE RESTRICT,  -- Warehouse the units went to
+    "quantity" INT NOT NULL CHECK ("quantity" > 0),  -- Units moved
+    "note" TEXT NOT NULL DEFAULT '',  -- Reason for the transfer
+    "createdBy" UUID NOT NULL,  -- Admin who made the transfer
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW()  -- Timestamp of the transfer
+);
+
+CREATE INDEX IF NOT EXISTS "idx_stock_transfer_product" ON "stockTransfer" ("productId", "createdAt");
+
+-- Movements now happen in a warehouse, or between two of them
+ALTER TYPE "stock_movement_kind" ADD VALUE IF NOT EXISTS 'TRANSFER';
+ALTER TABLE "stockMovement" ADD COLUMN "warehouseId" UUID;  -- Warehouse whose stock changed, NULL for the movements made before warehouses
+
+-- Everything in stock so far is held by a single warehouse, and ships the
+-- orders placed so far
+INSERT INTO "warehouse" ("id", "name") VALUES (gen_random_uuid(), 'Main');
+
+INSERT INTO "warehouseStock" ("warehouseId", "productId", "stock")
+SELECT "warehouse"."id", "product"."id", "product"."stock"
+FROM "product", "warehouse"
+WHERE "warehouse"."name" = 'Main' AND "product"."stock" > 0;
+
+INSERT INTO "orderAllocation" ("id", "orderId", "orderItemId", "productId", "warehouseId", "quantity")
+SELECT gen_random_uuid(), "orderItem"."orderId", "orderItem"."id", "orderItem"."productId", "warehouse"."id", "orderItem"."quantity"
+FROM "orderItem"
+JOIN "order" ON "order"."id" = "orderItem"."orderId"
+CROSS JOIN "warehouse"
+WHERE "warehouse"."name" = 'Main' AND "order"."status" <> 'CANCELLED' AND "orderItem"."quantity" > 0;
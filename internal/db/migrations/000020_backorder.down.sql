@@ -0,0 +1,21 @@
+DROP INDEX IF EXISTS "idx_order_item_backordered";
+ALTER TABLE "orderItem" DROP CONSTRAINT IF EXISTS "orderItem_backordered_check";
+ALTER TABLE "orderItem" DROP COLUMN IF EXISTS "backordered";
+
+-- Enum values cannot be dropped, so the type is recreated without BACKORDERED.
+UPDATE "order" SET "status" = 'PENDING' WHERE "status" = 'BACKORDERED';
+UPDATE "orderStatusEvent" SET "status" = 'PENDING' WHERE "status" = 'BACKORDERED';
+UPDATE "orderStatusEvent" SET "previousStatus" = 'PENDING' WHERE "previousStatus" = 'BACKORDERED';
+ALTER TABLE "order" ALTER COLUMN "status" DROP DEFAULT;
+ALTER TYPE "order_status" RENAME TO "order_status_old";
+CREATE TYPE "order_status" AS ENUM ('PENDING', 'COMPLETED', 'CANCELLED', 'PARTIALLY_SHIPPED', 'SHIPPED', 'DELIVERED');
+ALTER TABLE "order" ALTER COLUMN "status" TYPE "order_status" USING "status"::TEXT::"order_status";
+ALTER TABLE "orderStatusEvent" ALTER COLUMN "status" TYPE "order_status" USING "status"::TEXT::"order_status";
+ALTER TABLE "orderStatusEvent" ALTER COLUMN "previousStatus" TYPE "order_status" USING "previousStatus"::TEXT::"order_status";
+ALTER TABLE "order" ALTER COLUMN "status" SET DEFAULT 'PENDING';
+DROP TYPE "order_status_old";
+
+ALTER TABLE "product"
+    DROP COLUMN IF EXISTS "availableAt",
+    DROP COLUMN IF EXISTS "backorderPolicy";
+DROP TYPE IF EXISTS "backorder_policy";
@@ -0,0 +1,17 @@
+-- NONE rejects orders for more than the stock, BACKORDER accepts them and
+-- ships the missing units once restocked, PREORDER does the same for a
+-- product not released yet
+CREATE TYPE "backorder_policy" AS ENUM ('NONE', 'BACKORDER', 'PREORDER');
+
+ALTER TABLE "product"
+    ADD COLUMN "backorderPolicy" "backorder_policy" NOT NULL DEFAULT 'NONE',  -- Whether orders for more than the stock are accepted
+    ADD COLUMN "availableAt" TIMESTAMP;  -- When backordered or pre-ordered units are expected to ship, NULL when unknown
+
+-- Orders waiting for some of their units to be restocked
+ALTER TYPE "order_status" ADD VALUE IF NOT EXISTS 'BACKORDERED';
+
+ALTER TABLE "orderItem"
+    ADD COLUMN "backordered" INT NOT NULL DEFAULT 0,  -- Units of the item waiting for stock
+    ADD CONSTRAINT "orderItem_backordered_check" CHECK ("backordered" >= 0 AND "backordered" <= "quantity");
+
+CREATE INDEX IF NOT EXISTS "idx_order_item_backordered" ON "orderItem" ("productId") WHERE "backordered" > 0;
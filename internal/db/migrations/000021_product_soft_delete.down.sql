@@ -0,0 +1,9 @@
+ALTER TABLE "orderItem"
+    DROP CONSTRAINT "fk_product",
+    ADD CONSTRAINT "fk_product" FOREIGN KEY ("productId") REFERENCES "product"("id")
+        ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS "idx_product_active";
+
+-- Archived products are kept, they go back to the catalog
+ALTER TABLE "product" DROP COLUMN IF EXISTS "deletedAt";
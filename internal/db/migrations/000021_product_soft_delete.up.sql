@@ -0,0 +1,10 @@
+-- Deleted products are archived instead so that past orders keep their lines
+ALTER TABLE "product" ADD COLUMN "deletedAt" TIMESTAMP;  -- Timestamp the product was archived, NULL while it is in the catalog
+
+CREATE INDEX IF NOT EXISTS "idx_product_active" ON "product" ("id") WHERE "deletedAt" IS NULL;
+
+-- A product ordered before can no longer be removed along with the order lines
+ALTER TABLE "orderItem"
+    DROP CONSTRAINT "fk_product",
+    ADD CONSTRAINT "fk_product" FOREIGN KEY ("productId") REFERENCES "product"("id")  -- Foreign key referencing the product table
+        ON DELETE RESTRICT;  -- Ensures that products in orders cannot be deleted
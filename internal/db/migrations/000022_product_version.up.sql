@@ -0,0 +1,3 @@
+-- Bumped on every update of a product, admins send back the version they
+-- edited so that concurrent edits are not lost
+ALTER TABLE "product" ADD COLUMN "version" INT NOT NULL DEFAULT 1;  -- Version of the product, starting at 1
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "productReview";
+DROP TYPE IF EXISTS "review_status";
@@ -0,0 +1,20 @@
+-- Reviews are hidden until an admin approves them
+CREATE TYPE "review_status" AS ENUM ('PENDING', 'APPROVED', 'REJECTED');
+
+CREATE TABLE IF NOT EXISTS "productReview" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the review
+    "productId" UUID NOT NULL REFERENCES "product"("id") ON DELETE CASCADE,  -- Product reviewed
+    "userId" UUID NOT NULL REFERENCES "user"("id") ON DELETE CASCADE,  -- Customer who wrote the review
+    "rating" INT NOT NULL CHECK ("rating" BETWEEN 1 AND 5),  -- Stars given to the product, 1 to 5
+    "title" VARCHAR(150) NOT NULL,  -- Headline of the review
+    "body" TEXT NOT NULL DEFAULT '',  -- Text of the review
+    "status" "review_status" NOT NULL DEFAULT 'PENDING',  -- Moderation state of the review
+    "moderatedBy" UUID REFERENCES "user"("id") ON DELETE SET NULL,  -- Admin who approved or rejected the review
+    "moderatedAt" TIMESTAMP,  -- Timestamp the review was approved or rejected
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of creation
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of the last update
+    UNIQUE ("productId", "userId")  -- A customer reviews a product once
+);
+
+CREATE INDEX IF NOT EXISTS "idx_product_review_approved" ON "productReview" ("productId", "createdAt" DESC) WHERE "status" = 'APPROVED';
+CREATE INDEX IF NOT EXISTS "idx_product_review_status" ON "productReview" ("status", "createdAt" DESC);
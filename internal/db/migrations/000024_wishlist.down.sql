@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "wishlistItem";
+DROP TABLE IF EXISTS "wishlist";
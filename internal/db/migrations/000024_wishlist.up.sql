@@ -0,0 +1,22 @@
+-- Named lists of products a customer saves for later
+CREATE TABLE IF NOT EXISTS "wishlist" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the wishlist
+    "userId" UUID NOT NULL REFERENCES "user"("id") ON DELETE CASCADE,  -- Customer owning the wishlist
+    "name" VARCHAR(100) NOT NULL,  -- Name of the wishlist, unique per customer
+    "shareToken" VARCHAR(64) UNIQUE,  -- Token of the public read-only link to the wishlist, NULL when not shared
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of creation
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of the last update
+    UNIQUE ("userId", "name")
+);
+
+CREATE TABLE IF NOT EXISTS "wishlistItem" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the item
+    "wishlistId" UUID NOT NULL REFERENCES "wishlist"("id") ON DELETE CASCADE,  -- Wishlist the item is on
+    "productId" UUID NOT NULL REFERENCES "product"("id") ON DELETE CASCADE,  -- Product saved
+    "quantity" INT NOT NULL DEFAULT 1 CHECK ("quantity" > 0),  -- Units the customer wants
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp the product was saved
+    UNIQUE ("wishlistId", "productId")  -- A product is on a wishlist once
+);
+
+-- Customers are told when a product on their wishlists is back in stock
+CREATE INDEX IF NOT EXISTS "idx_wishlist_item_product_id" ON "wishlistItem" ("productId");
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "productPrice";
+
+ALTER TABLE "product"
+    DROP COLUMN IF EXISTS "compareAtPrice";
@@ -0,0 +1,24 @@
+ALTER TABLE "product"
+    ADD COLUMN IF NOT EXISTS "compareAtPrice" FLOAT;  -- Price the product is shown reduced from, struck through, NULL when not on sale
+
+-- Prices of the products over time, the latest started of those in effect
+-- being the price of the product
+CREATE TABLE IF NOT EXISTS "productPrice" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the price
+    "productId" UUID NOT NULL REFERENCES "product"("id") ON DELETE CASCADE,  -- Product priced
+    "price" FLOAT NOT NULL CHECK ("price" > 0),  -- Price of the product while the price is in effect
+    "compareAtPrice" FLOAT CHECK ("compareAtPrice" > "price"),  -- Price shown struck through while the price is in effect, if any
+    "effectiveFrom" TIMESTAMP NOT NULL,  -- Time the price comes into effect
+    "effectiveTo" TIMESTAMP CHECK ("effectiveTo" > "effectiveFrom"),  -- Time the price stops being in effect, NULL when open-ended
+    "createdBy" UUID REFERENCES "user"("id") ON DELETE SET NULL,  -- Admin who set the price
+    "cancelledAt" TIMESTAMP,  -- Time a scheduled price was cancelled, NULL when it was not
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW()  -- Timestamp of creation
+);
+
+CREATE INDEX IF NOT EXISTS "idx_product_price_product_id" ON "productPrice" ("productId", "effectiveFrom" DESC);
+
+-- The current prices start the history of the products
+INSERT INTO "productPrice" ("id", "productId", "price", "effectiveFrom", "createdBy", "createdAt")
+SELECT gen_random_uuid(), "id", "price", "createdAt", "createdBy", "createdAt"
+FROM "product"
+WHERE "price" > 0;
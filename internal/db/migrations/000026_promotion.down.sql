@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "orderPromotion";
+DROP TABLE IF EXISTS "promotion";
+DROP TYPE IF EXISTS "promotion_kind";
@@ -0,0 +1,44 @@
+CREATE TYPE "promotion_kind" AS ENUM ('BUY_X_GET_Y', 'BUNDLE', 'QUANTITY_TIER', 'CATEGORY_SALE');
+
+CREATE TABLE "promotion" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the promotion
+    "name" VARCHAR(100) NOT NULL,  -- Name shown in the order promotion breakdown
+    "kind" "promotion_kind" NOT NULL,  -- How the promotion discounts the basket
+    "priority" INT NOT NULL DEFAULT 0,  -- Promotions with a higher priority are applied first
+    "stackable" BOOLEAN NOT NULL DEFAULT FALSE,  -- Whether the promotion combines with other promotions on the same lines
+    "productIds" UUID[] NOT NULL DEFAULT '{}',  -- Products the promotion applies to, the products of the bundle for BUNDLE
+    "categories" TEXT[] NOT NULL DEFAULT '{}',  -- Categories the promotion applies to, empty with no products means all products
+    "buyQuantity" INT NOT NULL DEFAULT 0,  -- Units to buy for BUY_X_GET_Y
+    "getQuantity" INT NOT NULL DEFAULT 0,  -- Units discounted for every buyQuantity units bought for BUY_X_GET_Y
+    "percent" FLOAT NOT NULL DEFAULT 0,  -- Percentage taken off for BUY_X_GET_Y and CATEGORY_SALE
+    "bundlePrice" FLOAT NOT NULL DEFAULT 0,  -- Price of one of each of the products for BUNDLE
+    "tiers" JSONB NOT NULL DEFAULT '[]',  -- Minimum quantities and the percentage taken off at each for QUANTITY_TIER
+    "startsAt" TIMESTAMP,  -- Start of the validity window, NULL means immediately
+    "expiresAt" TIMESTAMP,  -- End of the validity window, NULL means never
+    "active" BOOLEAN NOT NULL DEFAULT TRUE,  -- Whether the promotion is currently applied at checkout
+    "createdBy" UUID NOT NULL,  -- UUID of the admin who created the promotion
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the promotion was created
+    "updatedAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the promotion was last updated
+    CONSTRAINT "fk_user" FOREIGN KEY ("createdBy") REFERENCES "user"("id")
+        ON DELETE RESTRICT,
+    CONSTRAINT "check_percent_range" CHECK ("percent" >= 0 AND "percent" <= 100),
+    CONSTRAINT "check_bundle_price" CHECK ("bundlePrice" >= 0)
+);
+
+CREATE INDEX "idx_promotion_active" ON "promotion" ("active", "priority" DESC);
+
+CREATE TABLE "orderPromotion" (
+    "id" UUID PRIMARY KEY,  -- Unique identifier for the order promotion line
+    "orderId" UUID NOT NULL,  -- UUID of the order the promotion was applied to
+    "promotionId" UUID,  -- UUID of the applied promotion, NULL once the promotion is deleted
+    "name" VARCHAR(100) NOT NULL,  -- Name of the promotion at the time of the order
+    "kind" "promotion_kind" NOT NULL,  -- Kind of the promotion at the time of the order
+    "discount" FLOAT NOT NULL,  -- Amount the promotion took off the order
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW(),  -- Timestamp of when the promotion was applied
+    CONSTRAINT "fk_order" FOREIGN KEY ("orderId") REFERENCES "order"("id")
+        ON DELETE CASCADE,
+    CONSTRAINT "fk_promotion" FOREIGN KEY ("promotionId") REFERENCES "promotion"("id")
+        ON DELETE SET NULL
+);
+
+CREATE INDEX "idx_order_promotion_order" ON "orderPromotion" ("orderId");
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "guestOrder";
+ALTER TABLE "user" DROP COLUMN IF EXISTS "guest";
@@ -0,0 +1,16 @@
+-- Guests check out without an account. Their orders are placed under a shadow
+-- user holding their email, which they claim to turn into a regular account.
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "guest" BOOLEAN NOT NULL DEFAULT FALSE;  -- Whether the user is the shadow user of a guest, who cannot log in until the account is claimed
+
+CREATE TABLE IF NOT EXISTS "guestOrder" (
+    "orderId" UUID PRIMARY KEY REFERENCES "order"("id") ON DELETE CASCADE,  -- Order placed as a guest
+    "email" VARCHAR(255) NOT NULL,  -- Email the guest checked out with, where the order lookup token is sent
+    "name" VARCHAR(255) NOT NULL,  -- Name of the recipient
+    "line1" VARCHAR(255) NOT NULL,  -- First line of the street address
+    "line2" VARCHAR(255) NOT NULL DEFAULT '',  -- Optional second line of the street address
+    "city" VARCHAR(100) NOT NULL,  -- City or town
+    "postalCode" VARCHAR(20) NOT NULL DEFAULT '',  -- Postal code, empty where there is none
+    "country" VARCHAR(2) NOT NULL,  -- ISO 3166 country code
+    "state" VARCHAR(3) NOT NULL DEFAULT '',  -- Optional subdivision code
+    "createdAt" TIMESTAMP NOT NULL DEFAULT NOW()  -- Timestamp the order was placed
+);
@@ -0,0 +1,12 @@
+-- Enum values cannot be dropped, so the type is recreated without REFUNDING.
+UPDATE "returnRequest" SET "status" = 'RECEIVED' WHERE "status" = 'REFUNDING';
+DELETE FROM "returnEvent" WHERE "toStatus" = 'REFUNDING';
+UPDATE "returnEvent" SET "fromStatus" = 'RECEIVED' WHERE "fromStatus" = 'REFUNDING';
+ALTER TABLE "returnRequest" ALTER COLUMN "status" DROP DEFAULT;
+ALTER TYPE "return_status" RENAME TO "return_status_old";
+CREATE TYPE "return_status" AS ENUM ('REQUESTED', 'APPROVED', 'REJECTED', 'RECEIVED', 'REFUNDED');
+ALTER TABLE "returnRequest" ALTER COLUMN "status" TYPE "return_status" USING "status"::TEXT::"return_status";
+ALTER TABLE "returnEvent" ALTER COLUMN "fromStatus" TYPE "return_status" USING "fromStatus"::TEXT::"return_status";
+ALTER TABLE "returnEvent" ALTER COLUMN "toStatus" TYPE "return_status" USING "toStatus"::TEXT::"return_status";
+ALTER TABLE "returnRequest" ALTER COLUMN "status" SET DEFAULT 'REQUESTED';
+DROP TYPE "return_status_old";
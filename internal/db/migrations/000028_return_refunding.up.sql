@@ -0,0 +1,3 @@
+-- Returns being refunded. A return is claimed for its refund before the
+-- payment provider is called, so it is only ever refunded once.
+ALTER TYPE "return_status" ADD VALUE IF NOT EXISTS 'REFUNDING' AFTER 'RECEIVED';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "idx_outbox_event_pending";
+CREATE INDEX "idx_outbox_event_pending" ON "outboxEvent" ("nextAttemptAt") WHERE "dispatchedAt" IS NULL;
+
+ALTER TABLE "outboxEvent"
+    DROP COLUMN IF EXISTS "failedAt",
+    DROP COLUMN IF EXISTS "deliveredSinks";
@@ -0,0 +1,8 @@
+-- The dispatcher records which sinks took an event so that only the sinks
+-- that failed are retried, and gives up on an event after too many attempts
+ALTER TABLE "outboxEvent"
+    ADD COLUMN "deliveredSinks" TEXT[] NOT NULL DEFAULT '{}',  -- Names of the sinks that took the event
+    ADD COLUMN "failedAt" TIMESTAMP;  -- Timestamp at which the dispatcher gave up on the event, NULL while it is relayed
+
+DROP INDEX IF EXISTS "idx_outbox_event_pending";
+CREATE INDEX "idx_outbox_event_pending" ON "outboxEvent" ("nextAttemptAt") WHERE "dispatchedAt" IS NULL AND "failedAt" IS NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_job_idempotency_key";
+
+ALTER TABLE "job"
+    DROP COLUMN IF EXISTS "idempotencyKey";
@@ -0,0 +1,6 @@
+-- Jobs enqueued by event handlers carry a key made of the event and what the
+-- job is for, so that relaying the event again does not enqueue them twice
+ALTER TABLE "job"
+    ADD COLUMN "idempotencyKey" VARCHAR(255) NOT NULL DEFAULT '';  -- Key a job is enqueued at most once with, empty when it can be enqueued any number of times
+
+CREATE UNIQUE INDEX "idx_job_idempotency_key" ON "job" ("idempotencyKey") WHERE "idempotencyKey" <> '';
@@ -0,0 +1,3 @@
+ALTER TABLE "couponRedemption" DROP CONSTRAINT IF EXISTS "fk_coupon";
+ALTER TABLE "couponRedemption" ADD CONSTRAINT "fk_coupon" FOREIGN KEY ("couponId") REFERENCES "coupon"("id")
+    ON DELETE CASCADE;
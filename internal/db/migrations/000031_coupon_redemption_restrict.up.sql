@@ -0,0 +1,5 @@
+-- Redemptions are the history of who used a coupon, so a coupon that was
+-- redeemed can no longer be deleted, only deactivated
+ALTER TABLE "couponRedemption" DROP CONSTRAINT IF EXISTS "fk_coupon";
+ALTER TABLE "couponRedemption" ADD CONSTRAINT "fk_coupon" FOREIGN KEY ("couponId") REFERENCES "coupon"("id")
+    ON DELETE RESTRICT;
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	uuid "github.com/google/uuid"
+	pgtype "github.com/jackc/pgx/v5/pgtype"
 	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,245 +43,2919 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AddWarehouseStock mocks base method.
+func (m *MockStore) AddWarehouseStock(ctx context.Context, arg db.AddWarehouseStockParams) (db.WarehouseStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWarehouseStock", ctx, arg)
+	ret0, _ := ret[0].(db.WarehouseStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWarehouseStock indicates an expected call of AddWarehouseStock.
+func (mr *MockStoreMockRecorder) AddWarehouseStock(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWarehouseStock", reflect.TypeOf((*MockStore)(nil).AddWarehouseStock), ctx, arg)
+}
+
+// AddWishlistItem mocks base method.
+func (m *MockStore) AddWishlistItem(ctx context.Context, arg db.AddWishlistItemParams) (db.WishlistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWishlistItem", ctx, arg)
+	ret0, _ := ret[0].(db.WishlistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWishlistItem indicates an expected call of AddWishlistItem.
+func (mr *MockStoreMockRecorder) AddWishlistItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWishlistItem", reflect.TypeOf((*MockStore)(nil).AddWishlistItem), ctx, arg)
+}
+
+// ApplyProductPriceTx mocks base method.
+func (m *MockStore) ApplyProductPriceTx(ctx context.Context, productId uuid.UUID) (db.Product, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyProductPriceTx", ctx, productId)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ApplyProductPriceTx indicates an expected call of ApplyProductPriceTx.
+func (mr *MockStoreMockRecorder) ApplyProductPriceTx(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyProductPriceTx", reflect.TypeOf((*MockStore)(nil).ApplyProductPriceTx), ctx, productId)
+}
+
+// ArchiveProduct mocks base method.
+func (m *MockStore) ArchiveProduct(ctx context.Context, id uuid.UUID) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveProduct", ctx, id)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveProduct indicates an expected call of ArchiveProduct.
+func (mr *MockStoreMockRecorder) ArchiveProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveProduct", reflect.TypeOf((*MockStore)(nil).ArchiveProduct), ctx, id)
+}
+
 // CancelOrder mocks base method.
 func (m *MockStore) CancelOrder(ctx context.Context, arg db.CancelOrderParams) (db.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CancelOrder", ctx, arg)
-	ret0, _ := ret[0].(db.Order)
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, arg)
+	ret0, _ := ret[0].(db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockStoreMockRecorder) CancelOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockStore)(nil).CancelOrder), ctx, arg)
+}
+
+// CancelProductPrice mocks base method.
+func (m *MockStore) CancelProductPrice(ctx context.Context, arg db.CancelProductPriceParams) (db.ProductPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelProductPrice", ctx, arg)
+	ret0, _ := ret[0].(db.ProductPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelProductPrice indicates an expected call of CancelProductPrice.
+func (mr *MockStoreMockRecorder) CancelProductPrice(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelProductPrice", reflect.TypeOf((*MockStore)(nil).CancelProductPrice), ctx, arg)
+}
+
+// CancelProductPriceTx mocks base method.
+func (m *MockStore) CancelProductPriceTx(ctx context.Context, arg db.CancelProductPriceParams) (db.ScheduleProductPriceTxResult, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelProductPriceTx", ctx, arg)
+	ret0, _ := ret[0].(db.ScheduleProductPriceTxResult)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CancelProductPriceTx indicates an expected call of CancelProductPriceTx.
+func (mr *MockStoreMockRecorder) CancelProductPriceTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelProductPriceTx", reflect.TypeOf((*MockStore)(nil).CancelProductPriceTx), ctx, arg)
+}
+
+// ClaimGuestUser mocks base method.
+func (m *MockStore) ClaimGuestUser(ctx context.Context, arg db.ClaimGuestUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimGuestUser", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimGuestUser indicates an expected call of ClaimGuestUser.
+func (mr *MockStoreMockRecorder) ClaimGuestUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimGuestUser", reflect.TypeOf((*MockStore)(nil).ClaimGuestUser), ctx, arg)
+}
+
+// ClaimJobs mocks base method.
+func (m *MockStore) ClaimJobs(ctx context.Context, arg db.ClaimJobsParams) ([]db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimJobs", ctx, arg)
+	ret0, _ := ret[0].([]db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimJobs indicates an expected call of ClaimJobs.
+func (mr *MockStoreMockRecorder) ClaimJobs(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimJobs", reflect.TypeOf((*MockStore)(nil).ClaimJobs), ctx, arg)
+}
+
+// ClaimOutboxEvents mocks base method.
+func (m *MockStore) ClaimOutboxEvents(ctx context.Context, arg db.ClaimOutboxEventsParams) ([]db.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimOutboxEvents", ctx, arg)
+	ret0, _ := ret[0].([]db.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimOutboxEvents indicates an expected call of ClaimOutboxEvents.
+func (mr *MockStoreMockRecorder) ClaimOutboxEvents(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimOutboxEvents", reflect.TypeOf((*MockStore)(nil).ClaimOutboxEvents), ctx, arg)
+}
+
+// ClearPrimaryProductImage mocks base method.
+func (m *MockStore) ClearPrimaryProductImage(ctx context.Context, productId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearPrimaryProductImage", ctx, productId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearPrimaryProductImage indicates an expected call of ClearPrimaryProductImage.
+func (mr *MockStoreMockRecorder) ClearPrimaryProductImage(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearPrimaryProductImage", reflect.TypeOf((*MockStore)(nil).ClearPrimaryProductImage), ctx, productId)
+}
+
+// CompleteJob mocks base method.
+func (m *MockStore) CompleteJob(ctx context.Context, id uuid.UUID) (db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteJob", ctx, id)
+	ret0, _ := ret[0].(db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteJob indicates an expected call of CompleteJob.
+func (mr *MockStoreMockRecorder) CompleteJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteJob", reflect.TypeOf((*MockStore)(nil).CompleteJob), ctx, id)
+}
+
+// CountBackorderedItems mocks base method.
+func (m *MockStore) CountBackorderedItems(ctx context.Context, orderId uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBackorderedItems", ctx, orderId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBackorderedItems indicates an expected call of CountBackorderedItems.
+func (mr *MockStoreMockRecorder) CountBackorderedItems(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBackorderedItems", reflect.TypeOf((*MockStore)(nil).CountBackorderedItems), ctx, orderId)
+}
+
+// CountUndeliveredShipment mocks base method.
+func (m *MockStore) CountUndeliveredShipment(ctx context.Context, orderid uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUndeliveredShipment", ctx, orderid)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUndeliveredShipment indicates an expected call of CountUndeliveredShipment.
+func (mr *MockStoreMockRecorder) CountUndeliveredShipment(ctx, orderid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUndeliveredShipment", reflect.TypeOf((*MockStore)(nil).CountUndeliveredShipment), ctx, orderid)
+}
+
+// CountUserCouponRedemption mocks base method.
+func (m *MockStore) CountUserCouponRedemption(ctx context.Context, arg db.CountUserCouponRedemptionParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserCouponRedemption", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserCouponRedemption indicates an expected call of CountUserCouponRedemption.
+func (mr *MockStoreMockRecorder) CountUserCouponRedemption(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserCouponRedemption", reflect.TypeOf((*MockStore)(nil).CountUserCouponRedemption), ctx, arg)
+}
+
+// CountWebhookDeliveriesForEvent mocks base method.
+func (m *MockStore) CountWebhookDeliveriesForEvent(ctx context.Context, eventId uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountWebhookDeliveriesForEvent", ctx, eventId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountWebhookDeliveriesForEvent indicates an expected call of CountWebhookDeliveriesForEvent.
+func (mr *MockStoreMockRecorder) CountWebhookDeliveriesForEvent(ctx, eventId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountWebhookDeliveriesForEvent", reflect.TypeOf((*MockStore)(nil).CountWebhookDeliveriesForEvent), ctx, eventId)
+}
+
+// CreateAdminUser mocks base method.
+func (m *MockStore) CreateAdminUser(ctx context.Context, arg db.CreateAdminUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdminUser", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAdminUser indicates an expected call of CreateAdminUser.
+func (mr *MockStoreMockRecorder) CreateAdminUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdminUser", reflect.TypeOf((*MockStore)(nil).CreateAdminUser), ctx, arg)
+}
+
+// CreateCoupon mocks base method.
+func (m *MockStore) CreateCoupon(ctx context.Context, arg db.CreateCouponParams) (db.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCoupon", ctx, arg)
+	ret0, _ := ret[0].(db.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCoupon indicates an expected call of CreateCoupon.
+func (mr *MockStoreMockRecorder) CreateCoupon(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCoupon", reflect.TypeOf((*MockStore)(nil).CreateCoupon), ctx, arg)
+}
+
+// CreateCouponRedemption mocks base method.
+func (m *MockStore) CreateCouponRedemption(ctx context.Context, arg db.CreateCouponRedemptionParams) (db.CouponRedemption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCouponRedemption", ctx, arg)
+	ret0, _ := ret[0].(db.CouponRedemption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCouponRedemption indicates an expected call of CreateCouponRedemption.
+func (mr *MockStoreMockRecorder) CreateCouponRedemption(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCouponRedemption", reflect.TypeOf((*MockStore)(nil).CreateCouponRedemption), ctx, arg)
+}
+
+// CreateGuestOrder mocks base method.
+func (m *MockStore) CreateGuestOrder(ctx context.Context, arg db.CreateGuestOrderParams) (db.GuestOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGuestOrder", ctx, arg)
+	ret0, _ := ret[0].(db.GuestOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGuestOrder indicates an expected call of CreateGuestOrder.
+func (mr *MockStoreMockRecorder) CreateGuestOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGuestOrder", reflect.TypeOf((*MockStore)(nil).CreateGuestOrder), ctx, arg)
+}
+
+// CreateGuestUser mocks base method.
+func (m *MockStore) CreateGuestUser(ctx context.Context, arg db.CreateGuestUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGuestUser", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGuestUser indicates an expected call of CreateGuestUser.
+func (mr *MockStoreMockRecorder) CreateGuestUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGuestUser", reflect.TypeOf((*MockStore)(nil).CreateGuestUser), ctx, arg)
+}
+
+// CreateJob mocks base method.
+func (m *MockStore) CreateJob(ctx context.Context, arg db.CreateJobParams) (db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJob", ctx, arg)
+	ret0, _ := ret[0].(db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJob indicates an expected call of CreateJob.
+func (mr *MockStoreMockRecorder) CreateJob(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockStore)(nil).CreateJob), ctx, arg)
+}
+
+// CreateLowStockAlert mocks base method.
+func (m *MockStore) CreateLowStockAlert(ctx context.Context, arg db.CreateLowStockAlertParams) (db.LowStockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLowStockAlert", ctx, arg)
+	ret0, _ := ret[0].(db.LowStockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLowStockAlert indicates an expected call of CreateLowStockAlert.
+func (mr *MockStoreMockRecorder) CreateLowStockAlert(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLowStockAlert", reflect.TypeOf((*MockStore)(nil).CreateLowStockAlert), ctx, arg)
+}
+
+// CreateNotification mocks base method.
+func (m *MockStore) CreateNotification(ctx context.Context, arg db.CreateNotificationParams) (db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", ctx, arg)
+	ret0, _ := ret[0].(db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockStoreMockRecorder) CreateNotification(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockStore)(nil).CreateNotification), ctx, arg)
+}
+
+// CreateNotificationTx mocks base method.
+func (m *MockStore) CreateNotificationTx(ctx context.Context, arg db.CreateNotificationTxParams) (db.Notification, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotificationTx", ctx, arg)
+	ret0, _ := ret[0].(db.Notification)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateNotificationTx indicates an expected call of CreateNotificationTx.
+func (mr *MockStoreMockRecorder) CreateNotificationTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotificationTx", reflect.TypeOf((*MockStore)(nil).CreateNotificationTx), ctx, arg)
+}
+
+// CreateOrder mocks base method.
+func (m *MockStore) CreateOrder(ctx context.Context, arg db.CreateOrderParams) (db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, arg)
+	ret0, _ := ret[0].(db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockStoreMockRecorder) CreateOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockStore)(nil).CreateOrder), ctx, arg)
+}
+
+// CreateOrderAllocation mocks base method.
+func (m *MockStore) CreateOrderAllocation(ctx context.Context, arg db.CreateOrderAllocationParams) (db.OrderAllocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderAllocation", ctx, arg)
+	ret0, _ := ret[0].(db.OrderAllocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderAllocation indicates an expected call of CreateOrderAllocation.
+func (mr *MockStoreMockRecorder) CreateOrderAllocation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderAllocation", reflect.TypeOf((*MockStore)(nil).CreateOrderAllocation), ctx, arg)
+}
+
+// CreateOrderPromotion mocks base method.
+func (m *MockStore) CreateOrderPromotion(ctx context.Context, arg db.CreateOrderPromotionParams) (db.OrderPromotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderPromotion", ctx, arg)
+	ret0, _ := ret[0].(db.OrderPromotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderPromotion indicates an expected call of CreateOrderPromotion.
+func (mr *MockStoreMockRecorder) CreateOrderPromotion(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderPromotion", reflect.TypeOf((*MockStore)(nil).CreateOrderPromotion), ctx, arg)
+}
+
+// CreateOrderTax mocks base method.
+func (m *MockStore) CreateOrderTax(ctx context.Context, arg db.CreateOrderTaxParams) (db.OrderTax, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderTax", ctx, arg)
+	ret0, _ := ret[0].(db.OrderTax)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderTax indicates an expected call of CreateOrderTax.
+func (mr *MockStoreMockRecorder) CreateOrderTax(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderTax", reflect.TypeOf((*MockStore)(nil).CreateOrderTax), ctx, arg)
+}
+
+// CreateOrderTx mocks base method.
+func (m *MockStore) CreateOrderTx(ctx context.Context, arg db.CreateOrderTxParams) (db.Order, map[string]string, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderTx", ctx, arg)
+	ret0, _ := ret[0].(db.Order)
+	ret1, _ := ret[1].(map[string]string)
+	ret2, _ := ret[2].(error)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// CreateOrderTx indicates an expected call of CreateOrderTx.
+func (mr *MockStoreMockRecorder) CreateOrderTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderTx", reflect.TypeOf((*MockStore)(nil).CreateOrderTx), ctx, arg)
+}
+
+// CreateOutboxEvent mocks base method.
+func (m *MockStore) CreateOutboxEvent(ctx context.Context, arg db.CreateOutboxEventParams) (db.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOutboxEvent", ctx, arg)
+	ret0, _ := ret[0].(db.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOutboxEvent indicates an expected call of CreateOutboxEvent.
+func (mr *MockStoreMockRecorder) CreateOutboxEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOutboxEvent", reflect.TypeOf((*MockStore)(nil).CreateOutboxEvent), ctx, arg)
+}
+
+// CreateProduct mocks base method.
+func (m *MockStore) CreateProduct(ctx context.Context, arg db.CreateProductParams) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProduct", ctx, arg)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProduct indicates an expected call of CreateProduct.
+func (mr *MockStoreMockRecorder) CreateProduct(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockStore)(nil).CreateProduct), ctx, arg)
+}
+
+// CreateProductImage mocks base method.
+func (m *MockStore) CreateProductImage(ctx context.Context, arg db.CreateProductImageParams) (db.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductImage", ctx, arg)
+	ret0, _ := ret[0].(db.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductImage indicates an expected call of CreateProductImage.
+func (mr *MockStoreMockRecorder) CreateProductImage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductImage", reflect.TypeOf((*MockStore)(nil).CreateProductImage), ctx, arg)
+}
+
+// CreateProductImageTx mocks base method.
+func (m *MockStore) CreateProductImageTx(ctx context.Context, arg db.CreateProductImageTxParams) (db.ProductImage, map[string]string, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductImageTx", ctx, arg)
+	ret0, _ := ret[0].(db.ProductImage)
+	ret1, _ := ret[1].(map[string]string)
+	ret2, _ := ret[2].(error)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// CreateProductImageTx indicates an expected call of CreateProductImageTx.
+func (mr *MockStoreMockRecorder) CreateProductImageTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductImageTx", reflect.TypeOf((*MockStore)(nil).CreateProductImageTx), ctx, arg)
+}
+
+// CreateProductPrice mocks base method.
+func (m *MockStore) CreateProductPrice(ctx context.Context, arg db.CreateProductPriceParams) (db.ProductPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductPrice", ctx, arg)
+	ret0, _ := ret[0].(db.ProductPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductPrice indicates an expected call of CreateProductPrice.
+func (mr *MockStoreMockRecorder) CreateProductPrice(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductPrice", reflect.TypeOf((*MockStore)(nil).CreateProductPrice), ctx, arg)
+}
+
+// CreateProductReview mocks base method.
+func (m *MockStore) CreateProductReview(ctx context.Context, arg db.CreateProductReviewParams) (db.ProductReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductReview", ctx, arg)
+	ret0, _ := ret[0].(db.ProductReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductReview indicates an expected call of CreateProductReview.
+func (mr *MockStoreMockRecorder) CreateProductReview(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductReview", reflect.TypeOf((*MockStore)(nil).CreateProductReview), ctx, arg)
+}
+
+// CreateProductTx mocks base method.
+func (m *MockStore) CreateProductTx(ctx context.Context, arg db.CreateProductParams) (db.Product, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductTx", ctx, arg)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateProductTx indicates an expected call of CreateProductTx.
+func (mr *MockStoreMockRecorder) CreateProductTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductTx", reflect.TypeOf((*MockStore)(nil).CreateProductTx), ctx, arg)
+}
+
+// CreatePromotion mocks base method.
+func (m *MockStore) CreatePromotion(ctx context.Context, arg db.CreatePromotionParams) (db.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromotion", ctx, arg)
+	ret0, _ := ret[0].(db.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromotion indicates an expected call of CreatePromotion.
+func (mr *MockStoreMockRecorder) CreatePromotion(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotion", reflect.TypeOf((*MockStore)(nil).CreatePromotion), ctx, arg)
+}
+
+// CreateReturnEvent mocks base method.
+func (m *MockStore) CreateReturnEvent(ctx context.Context, arg db.CreateReturnEventParams) (db.ReturnEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReturnEvent", ctx, arg)
+	ret0, _ := ret[0].(db.ReturnEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReturnEvent indicates an expected call of CreateReturnEvent.
+func (mr *MockStoreMockRecorder) CreateReturnEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReturnEvent", reflect.TypeOf((*MockStore)(nil).CreateReturnEvent), ctx, arg)
+}
+
+// CreateReturnItem mocks base method.
+func (m *MockStore) CreateReturnItem(ctx context.Context, arg db.CreateReturnItemParams) (db.ReturnItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReturnItem", ctx, arg)
+	ret0, _ := ret[0].(db.ReturnItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReturnItem indicates an expected call of CreateReturnItem.
+func (mr *MockStoreMockRecorder) CreateReturnItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReturnItem", reflect.TypeOf((*MockStore)(nil).CreateReturnItem), ctx, arg)
+}
+
+// CreateReturnRequest mocks base method.
+func (m *MockStore) CreateReturnRequest(ctx context.Context, arg db.CreateReturnRequestParams) (db.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReturnRequest", ctx, arg)
+	ret0, _ := ret[0].(db.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReturnRequest indicates an expected call of CreateReturnRequest.
+func (mr *MockStoreMockRecorder) CreateReturnRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReturnRequest", reflect.TypeOf((*MockStore)(nil).CreateReturnRequest), ctx, arg)
+}
+
+// CreateReturnTx mocks base method.
+func (m *MockStore) CreateReturnTx(ctx context.Context, arg db.CreateReturnTxParams) (db.ReturnTxResult, map[string]string, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReturnTx", ctx, arg)
+	ret0, _ := ret[0].(db.ReturnTxResult)
+	ret1, _ := ret[1].(map[string]string)
+	ret2, _ := ret[2].(error)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// CreateReturnTx indicates an expected call of CreateReturnTx.
+func (mr *MockStoreMockRecorder) CreateReturnTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReturnTx", reflect.TypeOf((*MockStore)(nil).CreateReturnTx), ctx, arg)
+}
+
+// CreateShipment mocks base method.
+func (m *MockStore) CreateShipment(ctx context.Context, arg db.CreateShipmentParams) (db.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShipment", ctx, arg)
+	ret0, _ := ret[0].(db.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShipment indicates an expected call of CreateShipment.
+func (mr *MockStoreMockRecorder) CreateShipment(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipment", reflect.TypeOf((*MockStore)(nil).CreateShipment), ctx, arg)
+}
+
+// CreateShipmentItem mocks base method.
+func (m *MockStore) CreateShipmentItem(ctx context.Context, arg db.CreateShipmentItemParams) (db.ShipmentItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShipmentItem", ctx, arg)
+	ret0, _ := ret[0].(db.ShipmentItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShipmentItem indicates an expected call of CreateShipmentItem.
+func (mr *MockStoreMockRecorder) CreateShipmentItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipmentItem", reflect.TypeOf((*MockStore)(nil).CreateShipmentItem), ctx, arg)
+}
+
+// CreateShipmentTx mocks base method.
+func (m *MockStore) CreateShipmentTx(ctx context.Context, arg db.CreateShipmentTxParams) (db.ShipmentTxResult, map[string]string, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShipmentTx", ctx, arg)
+	ret0, _ := ret[0].(db.ShipmentTxResult)
+	ret1, _ := ret[1].(map[string]string)
+	ret2, _ := ret[2].(error)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// CreateShipmentTx indicates an expected call of CreateShipmentTx.
+func (mr *MockStoreMockRecorder) CreateShipmentTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipmentTx", reflect.TypeOf((*MockStore)(nil).CreateShipmentTx), ctx, arg)
+}
+
+// CreateShippingMethod mocks base method.
+func (m *MockStore) CreateShippingMethod(ctx context.Context, arg db.CreateShippingMethodParams) (db.ShippingMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShippingMethod", ctx, arg)
+	ret0, _ := ret[0].(db.ShippingMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShippingMethod indicates an expected call of CreateShippingMethod.
+func (mr *MockStoreMockRecorder) CreateShippingMethod(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShippingMethod", reflect.TypeOf((*MockStore)(nil).CreateShippingMethod), ctx, arg)
+}
+
+// CreateShippingZone mocks base method.
+func (m *MockStore) CreateShippingZone(ctx context.Context, arg db.CreateShippingZoneParams) (db.ShippingZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShippingZone", ctx, arg)
+	ret0, _ := ret[0].(db.ShippingZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShippingZone indicates an expected call of CreateShippingZone.
+func (mr *MockStoreMockRecorder) CreateShippingZone(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShippingZone", reflect.TypeOf((*MockStore)(nil).CreateShippingZone), ctx, arg)
+}
+
+// CreateStockMovement mocks base method.
+func (m *MockStore) CreateStockMovement(ctx context.Context, arg db.CreateStockMovementParams) (db.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStockMovement", ctx, arg)
+	ret0, _ := ret[0].(db.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStockMovement indicates an expected call of CreateStockMovement.
+func (mr *MockStoreMockRecorder) CreateStockMovement(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStockMovement", reflect.TypeOf((*MockStore)(nil).CreateStockMovement), ctx, arg)
+}
+
+// CreateStockTransfer mocks base method.
+func (m *MockStore) CreateStockTransfer(ctx context.Context, arg db.CreateStockTransferParams) (db.StockTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStockTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.StockTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStockTransfer indicates an expected call of CreateStockTransfer.
+func (mr *MockStoreMockRecorder) CreateStockTransfer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStockTransfer", reflect.TypeOf((*MockStore)(nil).CreateStockTransfer), ctx, arg)
+}
+
+// CreateTaxRule mocks base method.
+func (m *MockStore) CreateTaxRule(ctx context.Context, arg db.CreateTaxRuleParams) (db.TaxRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaxRule", ctx, arg)
+	ret0, _ := ret[0].(db.TaxRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaxRule indicates an expected call of CreateTaxRule.
+func (mr *MockStoreMockRecorder) CreateTaxRule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaxRule", reflect.TypeOf((*MockStore)(nil).CreateTaxRule), ctx, arg)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
+}
+
+// CreateWarehouse mocks base method.
+func (m *MockStore) CreateWarehouse(ctx context.Context, arg db.CreateWarehouseParams) (db.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWarehouse", ctx, arg)
+	ret0, _ := ret[0].(db.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWarehouse indicates an expected call of CreateWarehouse.
+func (mr *MockStoreMockRecorder) CreateWarehouse(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWarehouse", reflect.TypeOf((*MockStore)(nil).CreateWarehouse), ctx, arg)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockStore) CreateWebhook(ctx context.Context, arg db.CreateWebhookParams) (db.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, arg)
+	ret0, _ := ret[0].(db.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockStoreMockRecorder) CreateWebhook(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockStore)(nil).CreateWebhook), ctx, arg)
+}
+
+// CreateWebhookDeliveriesTx mocks base method.
+func (m *MockStore) CreateWebhookDeliveriesTx(ctx context.Context, eventId uuid.UUID) ([]db.WebhookDelivery, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDeliveriesTx", ctx, eventId)
+	ret0, _ := ret[0].([]db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateWebhookDeliveriesTx indicates an expected call of CreateWebhookDeliveriesTx.
+func (mr *MockStoreMockRecorder) CreateWebhookDeliveriesTx(ctx, eventId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDeliveriesTx", reflect.TypeOf((*MockStore)(nil).CreateWebhookDeliveriesTx), ctx, eventId)
+}
+
+// CreateWebhookDelivery mocks base method.
+func (m *MockStore) CreateWebhookDelivery(ctx context.Context, arg db.CreateWebhookDeliveryParams) (db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDelivery", ctx, arg)
+	ret0, _ := ret[0].(db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookDelivery indicates an expected call of CreateWebhookDelivery.
+func (mr *MockStoreMockRecorder) CreateWebhookDelivery(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDelivery", reflect.TypeOf((*MockStore)(nil).CreateWebhookDelivery), ctx, arg)
+}
+
+// CreateWishlist mocks base method.
+func (m *MockStore) CreateWishlist(ctx context.Context, arg db.CreateWishlistParams) (db.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWishlist", ctx, arg)
+	ret0, _ := ret[0].(db.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWishlist indicates an expected call of CreateWishlist.
+func (mr *MockStoreMockRecorder) CreateWishlist(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWishlist", reflect.TypeOf((*MockStore)(nil).CreateWishlist), ctx, arg)
+}
+
+// DecrementCouponUsage mocks base method.
+func (m *MockStore) DecrementCouponUsage(ctx context.Context, id uuid.UUID) (db.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrementCouponUsage", ctx, id)
+	ret0, _ := ret[0].(db.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecrementCouponUsage indicates an expected call of DecrementCouponUsage.
+func (mr *MockStoreMockRecorder) DecrementCouponUsage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementCouponUsage", reflect.TypeOf((*MockStore)(nil).DecrementCouponUsage), ctx, id)
+}
+
+// DeleteCouponRedemptionByOrderId mocks base method.
+func (m *MockStore) DeleteCouponRedemptionByOrderId(ctx context.Context, orderId uuid.UUID) ([]db.CouponRedemption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCouponRedemptionByOrderId", ctx, orderId)
+	ret0, _ := ret[0].([]db.CouponRedemption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCouponRedemptionByOrderId indicates an expected call of DeleteCouponRedemptionByOrderId.
+func (mr *MockStoreMockRecorder) DeleteCouponRedemptionByOrderId(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCouponRedemptionByOrderId", reflect.TypeOf((*MockStore)(nil).DeleteCouponRedemptionByOrderId), ctx, orderId)
+}
+
+// DeleteOneCoupon mocks base method.
+func (m *MockStore) DeleteOneCoupon(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOneCoupon", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOneCoupon indicates an expected call of DeleteOneCoupon.
+func (mr *MockStoreMockRecorder) DeleteOneCoupon(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneCoupon", reflect.TypeOf((*MockStore)(nil).DeleteOneCoupon), ctx, id)
+}
+
+// DeleteOneProductImage mocks base method.
+func (m *MockStore) DeleteOneProductImage(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOneProductImage", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOneProductImage indicates an expected call of DeleteOneProductImage.
+func (mr *MockStoreMockRecorder) DeleteOneProductImage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneProductImage", reflect.TypeOf((*MockStore)(nil).DeleteOneProductImage), ctx, id)
+}
+
+// DeleteOnePromotion mocks base method.
+func (m *MockStore) DeleteOnePromotion(ctx context.Context, id uuid.UUID) (db.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOnePromotion", ctx, id)
+	ret0, _ := ret[0].(db.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOnePromotion indicates an expected call of DeleteOnePromotion.
+func (mr *MockStoreMockRecorder) DeleteOnePromotion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOnePromotion", reflect.TypeOf((*MockStore)(nil).DeleteOnePromotion), ctx, id)
+}
+
+// DeleteOneShippingMethod mocks base method.
+func (m *MockStore) DeleteOneShippingMethod(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOneShippingMethod", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOneShippingMethod indicates an expected call of DeleteOneShippingMethod.
+func (mr *MockStoreMockRecorder) DeleteOneShippingMethod(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneShippingMethod", reflect.TypeOf((*MockStore)(nil).DeleteOneShippingMethod), ctx, id)
+}
+
+// DeleteOneShippingZone mocks base method.
+func (m *MockStore) DeleteOneShippingZone(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOneShippingZone", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOneShippingZone indicates an expected call of DeleteOneShippingZone.
+func (mr *MockStoreMockRecorder) DeleteOneShippingZone(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneShippingZone", reflect.TypeOf((*MockStore)(nil).DeleteOneShippingZone), ctx, id)
+}
+
+// DeleteOneTaxRule mocks base method.
+func (m *MockStore) DeleteOneTaxRule(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOneTaxRule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOneTaxRule indicates an expected call of DeleteOneTaxRule.
+func (mr *MockStoreMockRecorder) DeleteOneTaxRule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneTaxRule", reflect.TypeOf((*MockStore)(nil).DeleteOneTaxRule), ctx, id)
+}
+
+// DeleteOneWebhook mocks base method.
+func (m *MockStore) DeleteOneWebhook(ctx context.Context, id uuid.UUID) (db.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOneWebhook", ctx, id)
+	ret0, _ := ret[0].(db.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOneWebhook indicates an expected call of DeleteOneWebhook.
+func (mr *MockStoreMockRecorder) DeleteOneWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneWebhook", reflect.TypeOf((*MockStore)(nil).DeleteOneWebhook), ctx, id)
+}
+
+// DeleteProductImageTx mocks base method.
+func (m *MockStore) DeleteProductImageTx(ctx context.Context, productId, imageId uuid.UUID) (db.ProductImage, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductImageTx", ctx, productId, imageId)
+	ret0, _ := ret[0].(db.ProductImage)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteProductImageTx indicates an expected call of DeleteProductImageTx.
+func (mr *MockStoreMockRecorder) DeleteProductImageTx(ctx, productId, imageId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductImageTx", reflect.TypeOf((*MockStore)(nil).DeleteProductImageTx), ctx, productId, imageId)
+}
+
+// DeleteProductReview mocks base method.
+func (m *MockStore) DeleteProductReview(ctx context.Context, id uuid.UUID) (db.ProductReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductReview", ctx, id)
+	ret0, _ := ret[0].(db.ProductReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProductReview indicates an expected call of DeleteProductReview.
+func (mr *MockStoreMockRecorder) DeleteProductReview(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductReview", reflect.TypeOf((*MockStore)(nil).DeleteProductReview), ctx, id)
+}
+
+// DeleteProductTx mocks base method.
+func (m *MockStore) DeleteProductTx(ctx context.Context, productId uuid.UUID) (db.Product, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductTx", ctx, productId)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteProductTx indicates an expected call of DeleteProductTx.
+func (mr *MockStoreMockRecorder) DeleteProductTx(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductTx", reflect.TypeOf((*MockStore)(nil).DeleteProductTx), ctx, productId)
+}
+
+// DeleteWishlist mocks base method.
+func (m *MockStore) DeleteWishlist(ctx context.Context, arg db.DeleteWishlistParams) (db.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWishlist", ctx, arg)
+	ret0, _ := ret[0].(db.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWishlist indicates an expected call of DeleteWishlist.
+func (mr *MockStoreMockRecorder) DeleteWishlist(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWishlist", reflect.TypeOf((*MockStore)(nil).DeleteWishlist), ctx, arg)
+}
+
+// DeleteWishlistItem mocks base method.
+func (m *MockStore) DeleteWishlistItem(ctx context.Context, arg db.DeleteWishlistItemParams) (db.WishlistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWishlistItem", ctx, arg)
+	ret0, _ := ret[0].(db.WishlistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWishlistItem indicates an expected call of DeleteWishlistItem.
+func (mr *MockStoreMockRecorder) DeleteWishlistItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWishlistItem", reflect.TypeOf((*MockStore)(nil).DeleteWishlistItem), ctx, arg)
+}
+
+// DeleteWishlistItems mocks base method.
+func (m *MockStore) DeleteWishlistItems(ctx context.Context, arg db.DeleteWishlistItemsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWishlistItems", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWishlistItems indicates an expected call of DeleteWishlistItems.
+func (mr *MockStoreMockRecorder) DeleteWishlistItems(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWishlistItems", reflect.TypeOf((*MockStore)(nil).DeleteWishlistItems), ctx, arg)
+}
+
+// FailOutboxEvent mocks base method.
+func (m *MockStore) FailOutboxEvent(ctx context.Context, arg db.FailOutboxEventParams) (db.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailOutboxEvent", ctx, arg)
+	ret0, _ := ret[0].(db.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailOutboxEvent indicates an expected call of FailOutboxEvent.
+func (mr *MockStoreMockRecorder) FailOutboxEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailOutboxEvent", reflect.TypeOf((*MockStore)(nil).FailOutboxEvent), ctx, arg)
+}
+
+// GetActivePromotions mocks base method.
+func (m *MockStore) GetActivePromotions(ctx context.Context) ([]db.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivePromotions", ctx)
+	ret0, _ := ret[0].([]db.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivePromotions indicates an expected call of GetActivePromotions.
+func (mr *MockStoreMockRecorder) GetActivePromotions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivePromotions", reflect.TypeOf((*MockStore)(nil).GetActivePromotions), ctx)
+}
+
+// GetActiveShippingMethodByZoneId mocks base method.
+func (m *MockStore) GetActiveShippingMethodByZoneId(ctx context.Context, zoneid uuid.UUID) ([]db.ShippingMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveShippingMethodByZoneId", ctx, zoneid)
+	ret0, _ := ret[0].([]db.ShippingMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveShippingMethodByZoneId indicates an expected call of GetActiveShippingMethodByZoneId.
+func (mr *MockStoreMockRecorder) GetActiveShippingMethodByZoneId(ctx, zoneid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveShippingMethodByZoneId", reflect.TypeOf((*MockStore)(nil).GetActiveShippingMethodByZoneId), ctx, zoneid)
+}
+
+// GetAllCoupon mocks base method.
+func (m *MockStore) GetAllCoupon(ctx context.Context) ([]db.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCoupon", ctx)
+	ret0, _ := ret[0].([]db.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCoupon indicates an expected call of GetAllCoupon.
+func (mr *MockStoreMockRecorder) GetAllCoupon(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCoupon", reflect.TypeOf((*MockStore)(nil).GetAllCoupon), ctx)
+}
+
+// GetAllJob mocks base method.
+func (m *MockStore) GetAllJob(ctx context.Context, arg db.GetAllJobParams) ([]db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllJob", ctx, arg)
+	ret0, _ := ret[0].([]db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllJob indicates an expected call of GetAllJob.
+func (mr *MockStoreMockRecorder) GetAllJob(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllJob", reflect.TypeOf((*MockStore)(nil).GetAllJob), ctx, arg)
+}
+
+// GetAllOrderByUserId mocks base method.
+func (m *MockStore) GetAllOrderByUserId(ctx context.Context, userid uuid.UUID) ([]db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllOrderByUserId", ctx, userid)
+	ret0, _ := ret[0].([]db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllOrderByUserId indicates an expected call of GetAllOrderByUserId.
+func (mr *MockStoreMockRecorder) GetAllOrderByUserId(ctx, userid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrderByUserId", reflect.TypeOf((*MockStore)(nil).GetAllOrderByUserId), ctx, userid)
+}
+
+// GetAllOrderItem mocks base method.
+func (m *MockStore) GetAllOrderItem(ctx context.Context, orderid uuid.UUID) ([]db.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllOrderItem", ctx, orderid)
+	ret0, _ := ret[0].([]db.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllOrderItem indicates an expected call of GetAllOrderItem.
+func (mr *MockStoreMockRecorder) GetAllOrderItem(ctx, orderid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrderItem", reflect.TypeOf((*MockStore)(nil).GetAllOrderItem), ctx, orderid)
+}
+
+// GetAllProduct mocks base method.
+func (m *MockStore) GetAllProduct(ctx context.Context, sort string) ([]db.GetAllProductRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllProduct", ctx, sort)
+	ret0, _ := ret[0].([]db.GetAllProductRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllProduct indicates an expected call of GetAllProduct.
+func (mr *MockStoreMockRecorder) GetAllProduct(ctx, sort any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProduct", reflect.TypeOf((*MockStore)(nil).GetAllProduct), ctx, sort)
+}
+
+// GetAllProductInOrder mocks base method.
+func (m *MockStore) GetAllProductInOrder(ctx context.Context, orderid uuid.UUID) ([]db.GetAllProductInOrderRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllProductInOrder", ctx, orderid)
+	ret0, _ := ret[0].([]db.GetAllProductInOrderRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllProductInOrder indicates an expected call of GetAllProductInOrder.
+func (mr *MockStoreMockRecorder) GetAllProductInOrder(ctx, orderid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProductInOrder", reflect.TypeOf((*MockStore)(nil).GetAllProductInOrder), ctx, orderid)
+}
+
+// GetAllProductReview mocks base method.
+func (m *MockStore) GetAllProductReview(ctx context.Context, arg db.GetAllProductReviewParams) ([]db.ProductReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllProductReview", ctx, arg)
+	ret0, _ := ret[0].([]db.ProductReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllProductReview indicates an expected call of GetAllProductReview.
+func (mr *MockStoreMockRecorder) GetAllProductReview(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProductReview", reflect.TypeOf((*MockStore)(nil).GetAllProductReview), ctx, arg)
+}
+
+// GetAllPromotion mocks base method.
+func (m *MockStore) GetAllPromotion(ctx context.Context) ([]db.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPromotion", ctx)
+	ret0, _ := ret[0].([]db.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllPromotion indicates an expected call of GetAllPromotion.
+func (mr *MockStoreMockRecorder) GetAllPromotion(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPromotion", reflect.TypeOf((*MockStore)(nil).GetAllPromotion), ctx)
+}
+
+// GetAllReturnRequest mocks base method.
+func (m *MockStore) GetAllReturnRequest(ctx context.Context) ([]db.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllReturnRequest", ctx)
+	ret0, _ := ret[0].([]db.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllReturnRequest indicates an expected call of GetAllReturnRequest.
+func (mr *MockStoreMockRecorder) GetAllReturnRequest(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllReturnRequest", reflect.TypeOf((*MockStore)(nil).GetAllReturnRequest), ctx)
+}
+
+// GetAllShippingMethod mocks base method.
+func (m *MockStore) GetAllShippingMethod(ctx context.Context) ([]db.ShippingMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllShippingMethod", ctx)
+	ret0, _ := ret[0].([]db.ShippingMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllShippingMethod indicates an expected call of GetAllShippingMethod.
+func (mr *MockStoreMockRecorder) GetAllShippingMethod(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllShippingMethod", reflect.TypeOf((*MockStore)(nil).GetAllShippingMethod), ctx)
+}
+
+// GetAllShippingZone mocks base method.
+func (m *MockStore) GetAllShippingZone(ctx context.Context) ([]db.ShippingZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllShippingZone", ctx)
+	ret0, _ := ret[0].([]db.ShippingZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllShippingZone indicates an expected call of GetAllShippingZone.
+func (mr *MockStoreMockRecorder) GetAllShippingZone(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllShippingZone", reflect.TypeOf((*MockStore)(nil).GetAllShippingZone), ctx)
+}
+
+// GetAllTaxRule mocks base method.
+func (m *MockStore) GetAllTaxRule(ctx context.Context) ([]db.TaxRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTaxRule", ctx)
+	ret0, _ := ret[0].([]db.TaxRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTaxRule indicates an expected call of GetAllTaxRule.
+func (mr *MockStoreMockRecorder) GetAllTaxRule(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTaxRule", reflect.TypeOf((*MockStore)(nil).GetAllTaxRule), ctx)
+}
+
+// GetAllWarehouse mocks base method.
+func (m *MockStore) GetAllWarehouse(ctx context.Context) ([]db.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllWarehouse", ctx)
+	ret0, _ := ret[0].([]db.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllWarehouse indicates an expected call of GetAllWarehouse.
+func (mr *MockStoreMockRecorder) GetAllWarehouse(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllWarehouse", reflect.TypeOf((*MockStore)(nil).GetAllWarehouse), ctx)
+}
+
+// GetAllWebhook mocks base method.
+func (m *MockStore) GetAllWebhook(ctx context.Context) ([]db.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllWebhook", ctx)
+	ret0, _ := ret[0].([]db.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllWebhook indicates an expected call of GetAllWebhook.
+func (mr *MockStoreMockRecorder) GetAllWebhook(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllWebhook", reflect.TypeOf((*MockStore)(nil).GetAllWebhook), ctx)
+}
+
+// GetAllocatableStock mocks base method.
+func (m *MockStore) GetAllocatableStock(ctx context.Context, productIds []uuid.UUID) ([]db.GetAllocatableStockRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllocatableStock", ctx, productIds)
+	ret0, _ := ret[0].([]db.GetAllocatableStockRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllocatableStock indicates an expected call of GetAllocatableStock.
+func (mr *MockStoreMockRecorder) GetAllocatableStock(ctx, productIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllocatableStock", reflect.TypeOf((*MockStore)(nil).GetAllocatableStock), ctx, productIds)
+}
+
+// GetAvailableStock mocks base method.
+func (m *MockStore) GetAvailableStock(ctx context.Context, productIds []uuid.UUID) ([]db.GetAvailableStockRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailableStock", ctx, productIds)
+	ret0, _ := ret[0].([]db.GetAvailableStockRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailableStock indicates an expected call of GetAvailableStock.
+func (mr *MockStoreMockRecorder) GetAvailableStock(ctx, productIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableStock", reflect.TypeOf((*MockStore)(nil).GetAvailableStock), ctx, productIds)
+}
+
+// GetBackorderedItemsForUpdate mocks base method.
+func (m *MockStore) GetBackorderedItemsForUpdate(ctx context.Context, productId uuid.UUID) ([]db.GetBackorderedItemsForUpdateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBackorderedItemsForUpdate", ctx, productId)
+	ret0, _ := ret[0].([]db.GetBackorderedItemsForUpdateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBackorderedItemsForUpdate indicates an expected call of GetBackorderedItemsForUpdate.
+func (mr *MockStoreMockRecorder) GetBackorderedItemsForUpdate(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBackorderedItemsForUpdate", reflect.TypeOf((*MockStore)(nil).GetBackorderedItemsForUpdate), ctx, productId)
+}
+
+// GetCouponByCode mocks base method.
+func (m *MockStore) GetCouponByCode(ctx context.Context, code string) (db.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCouponByCode", ctx, code)
+	ret0, _ := ret[0].(db.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCouponByCode indicates an expected call of GetCouponByCode.
+func (mr *MockStoreMockRecorder) GetCouponByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCouponByCode", reflect.TypeOf((*MockStore)(nil).GetCouponByCode), ctx, code)
+}
+
+// GetEffectiveProductPrice mocks base method.
+func (m *MockStore) GetEffectiveProductPrice(ctx context.Context, arg db.GetEffectiveProductPriceParams) (db.ProductPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectiveProductPrice", ctx, arg)
+	ret0, _ := ret[0].(db.ProductPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEffectiveProductPrice indicates an expected call of GetEffectiveProductPrice.
+func (mr *MockStoreMockRecorder) GetEffectiveProductPrice(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectiveProductPrice", reflect.TypeOf((*MockStore)(nil).GetEffectiveProductPrice), ctx, arg)
+}
+
+// GetGuestOrder mocks base method.
+func (m *MockStore) GetGuestOrder(ctx context.Context, orderId uuid.UUID) (db.GuestOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuestOrder", ctx, orderId)
+	ret0, _ := ret[0].(db.GuestOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuestOrder indicates an expected call of GetGuestOrder.
+func (mr *MockStoreMockRecorder) GetGuestOrder(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuestOrder", reflect.TypeOf((*MockStore)(nil).GetGuestOrder), ctx, orderId)
+}
+
+// GetJobStats mocks base method.
+func (m *MockStore) GetJobStats(ctx context.Context) ([]db.GetJobStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobStats", ctx)
+	ret0, _ := ret[0].([]db.GetJobStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobStats indicates an expected call of GetJobStats.
+func (mr *MockStoreMockRecorder) GetJobStats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobStats", reflect.TypeOf((*MockStore)(nil).GetJobStats), ctx)
+}
+
+// GetLastOrderStatusEventId mocks base method.
+func (m *MockStore) GetLastOrderStatusEventId(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastOrderStatusEventId", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastOrderStatusEventId indicates an expected call of GetLastOrderStatusEventId.
+func (mr *MockStoreMockRecorder) GetLastOrderStatusEventId(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastOrderStatusEventId", reflect.TypeOf((*MockStore)(nil).GetLastOrderStatusEventId), ctx)
+}
+
+// GetLedgerStock mocks base method.
+func (m *MockStore) GetLedgerStock(ctx context.Context, productId uuid.UUID) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLedgerStock", ctx, productId)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLedgerStock indicates an expected call of GetLedgerStock.
+func (mr *MockStoreMockRecorder) GetLedgerStock(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLedgerStock", reflect.TypeOf((*MockStore)(nil).GetLedgerStock), ctx, productId)
+}
+
+// GetLowStockProducts mocks base method.
+func (m *MockStore) GetLowStockProducts(ctx context.Context, arg db.GetLowStockProductsParams) ([]db.GetLowStockProductsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLowStockProducts", ctx, arg)
+	ret0, _ := ret[0].([]db.GetLowStockProductsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLowStockProducts indicates an expected call of GetLowStockProducts.
+func (mr *MockStoreMockRecorder) GetLowStockProducts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLowStockProducts", reflect.TypeOf((*MockStore)(nil).GetLowStockProducts), ctx, arg)
+}
+
+// GetMultipleProductById mocks base method.
+func (m *MockStore) GetMultipleProductById(ctx context.Context, dollar_1 []uuid.UUID) ([]db.GetMultipleProductByIdRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultipleProductById", ctx, dollar_1)
+	ret0, _ := ret[0].([]db.GetMultipleProductByIdRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultipleProductById indicates an expected call of GetMultipleProductById.
+func (mr *MockStoreMockRecorder) GetMultipleProductById(ctx, dollar_1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultipleProductById", reflect.TypeOf((*MockStore)(nil).GetMultipleProductById), ctx, dollar_1)
+}
+
+// GetNotificationPreference mocks base method.
+func (m *MockStore) GetNotificationPreference(ctx context.Context, userId uuid.UUID) (db.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreference", ctx, userId)
+	ret0, _ := ret[0].(db.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreference indicates an expected call of GetNotificationPreference.
+func (mr *MockStoreMockRecorder) GetNotificationPreference(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreference", reflect.TypeOf((*MockStore)(nil).GetNotificationPreference), ctx, userId)
+}
+
+// GetOneCoupon mocks base method.
+func (m *MockStore) GetOneCoupon(ctx context.Context, id uuid.UUID) (db.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneCoupon", ctx, id)
+	ret0, _ := ret[0].(db.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneCoupon indicates an expected call of GetOneCoupon.
+func (mr *MockStoreMockRecorder) GetOneCoupon(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneCoupon", reflect.TypeOf((*MockStore)(nil).GetOneCoupon), ctx, id)
+}
+
+// GetOneJob mocks base method.
+func (m *MockStore) GetOneJob(ctx context.Context, id uuid.UUID) (db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneJob", ctx, id)
+	ret0, _ := ret[0].(db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneJob indicates an expected call of GetOneJob.
+func (mr *MockStoreMockRecorder) GetOneJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneJob", reflect.TypeOf((*MockStore)(nil).GetOneJob), ctx, id)
+}
+
+// GetOneLowStockAlert mocks base method.
+func (m *MockStore) GetOneLowStockAlert(ctx context.Context, id uuid.UUID) (db.LowStockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneLowStockAlert", ctx, id)
+	ret0, _ := ret[0].(db.LowStockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneLowStockAlert indicates an expected call of GetOneLowStockAlert.
+func (mr *MockStoreMockRecorder) GetOneLowStockAlert(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneLowStockAlert", reflect.TypeOf((*MockStore)(nil).GetOneLowStockAlert), ctx, id)
+}
+
+// GetOneNotification mocks base method.
+func (m *MockStore) GetOneNotification(ctx context.Context, id uuid.UUID) (db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneNotification", ctx, id)
+	ret0, _ := ret[0].(db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneNotification indicates an expected call of GetOneNotification.
+func (mr *MockStoreMockRecorder) GetOneNotification(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneNotification", reflect.TypeOf((*MockStore)(nil).GetOneNotification), ctx, id)
+}
+
+// GetOneProduct mocks base method.
+func (m *MockStore) GetOneProduct(ctx context.Context, id uuid.UUID) (db.GetOneProductRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneProduct", ctx, id)
+	ret0, _ := ret[0].(db.GetOneProductRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneProduct indicates an expected call of GetOneProduct.
+func (mr *MockStoreMockRecorder) GetOneProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneProduct", reflect.TypeOf((*MockStore)(nil).GetOneProduct), ctx, id)
+}
+
+// GetOneProductImage mocks base method.
+func (m *MockStore) GetOneProductImage(ctx context.Context, id uuid.UUID) (db.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneProductImage", ctx, id)
+	ret0, _ := ret[0].(db.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneProductImage indicates an expected call of GetOneProductImage.
+func (mr *MockStoreMockRecorder) GetOneProductImage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneProductImage", reflect.TypeOf((*MockStore)(nil).GetOneProductImage), ctx, id)
+}
+
+// GetOneProductReview mocks base method.
+func (m *MockStore) GetOneProductReview(ctx context.Context, id uuid.UUID) (db.ProductReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneProductReview", ctx, id)
+	ret0, _ := ret[0].(db.ProductReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneProductReview indicates an expected call of GetOneProductReview.
+func (mr *MockStoreMockRecorder) GetOneProductReview(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneProductReview", reflect.TypeOf((*MockStore)(nil).GetOneProductReview), ctx, id)
+}
+
+// GetOnePromotion mocks base method.
+func (m *MockStore) GetOnePromotion(ctx context.Context, id uuid.UUID) (db.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOnePromotion", ctx, id)
+	ret0, _ := ret[0].(db.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOnePromotion indicates an expected call of GetOnePromotion.
+func (mr *MockStoreMockRecorder) GetOnePromotion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOnePromotion", reflect.TypeOf((*MockStore)(nil).GetOnePromotion), ctx, id)
+}
+
+// GetOneReturnRequest mocks base method.
+func (m *MockStore) GetOneReturnRequest(ctx context.Context, id uuid.UUID) (db.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneReturnRequest", ctx, id)
+	ret0, _ := ret[0].(db.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneReturnRequest indicates an expected call of GetOneReturnRequest.
+func (mr *MockStoreMockRecorder) GetOneReturnRequest(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneReturnRequest", reflect.TypeOf((*MockStore)(nil).GetOneReturnRequest), ctx, id)
+}
+
+// GetOneShipment mocks base method.
+func (m *MockStore) GetOneShipment(ctx context.Context, id uuid.UUID) (db.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneShipment", ctx, id)
+	ret0, _ := ret[0].(db.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneShipment indicates an expected call of GetOneShipment.
+func (mr *MockStoreMockRecorder) GetOneShipment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneShipment", reflect.TypeOf((*MockStore)(nil).GetOneShipment), ctx, id)
+}
+
+// GetOneShippingMethod mocks base method.
+func (m *MockStore) GetOneShippingMethod(ctx context.Context, id uuid.UUID) (db.ShippingMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneShippingMethod", ctx, id)
+	ret0, _ := ret[0].(db.ShippingMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneShippingMethod indicates an expected call of GetOneShippingMethod.
+func (mr *MockStoreMockRecorder) GetOneShippingMethod(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneShippingMethod", reflect.TypeOf((*MockStore)(nil).GetOneShippingMethod), ctx, id)
+}
+
+// GetOneShippingZone mocks base method.
+func (m *MockStore) GetOneShippingZone(ctx context.Context, id uuid.UUID) (db.ShippingZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneShippingZone", ctx, id)
+	ret0, _ := ret[0].(db.ShippingZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneShippingZone indicates an expected call of GetOneShippingZone.
+func (mr *MockStoreMockRecorder) GetOneShippingZone(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneShippingZone", reflect.TypeOf((*MockStore)(nil).GetOneShippingZone), ctx, id)
+}
+
+// GetOneTaxRule mocks base method.
+func (m *MockStore) GetOneTaxRule(ctx context.Context, id uuid.UUID) (db.TaxRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneTaxRule", ctx, id)
+	ret0, _ := ret[0].(db.TaxRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneTaxRule indicates an expected call of GetOneTaxRule.
+func (mr *MockStoreMockRecorder) GetOneTaxRule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneTaxRule", reflect.TypeOf((*MockStore)(nil).GetOneTaxRule), ctx, id)
+}
+
+// GetOneWarehouse mocks base method.
+func (m *MockStore) GetOneWarehouse(ctx context.Context, id uuid.UUID) (db.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneWarehouse", ctx, id)
+	ret0, _ := ret[0].(db.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneWarehouse indicates an expected call of GetOneWarehouse.
+func (mr *MockStoreMockRecorder) GetOneWarehouse(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneWarehouse", reflect.TypeOf((*MockStore)(nil).GetOneWarehouse), ctx, id)
+}
+
+// GetOneWebhook mocks base method.
+func (m *MockStore) GetOneWebhook(ctx context.Context, id uuid.UUID) (db.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneWebhook", ctx, id)
+	ret0, _ := ret[0].(db.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneWebhook indicates an expected call of GetOneWebhook.
+func (mr *MockStoreMockRecorder) GetOneWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneWebhook", reflect.TypeOf((*MockStore)(nil).GetOneWebhook), ctx, id)
+}
+
+// GetOneWebhookDelivery mocks base method.
+func (m *MockStore) GetOneWebhookDelivery(ctx context.Context, id uuid.UUID) (db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneWebhookDelivery", ctx, id)
+	ret0, _ := ret[0].(db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneWebhookDelivery indicates an expected call of GetOneWebhookDelivery.
+func (mr *MockStoreMockRecorder) GetOneWebhookDelivery(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneWebhookDelivery", reflect.TypeOf((*MockStore)(nil).GetOneWebhookDelivery), ctx, id)
+}
+
+// GetOrderAllocations mocks base method.
+func (m *MockStore) GetOrderAllocations(ctx context.Context, orderId uuid.UUID) ([]db.GetOrderAllocationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderAllocations", ctx, orderId)
+	ret0, _ := ret[0].([]db.GetOrderAllocationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderAllocations indicates an expected call of GetOrderAllocations.
+func (mr *MockStoreMockRecorder) GetOrderAllocations(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderAllocations", reflect.TypeOf((*MockStore)(nil).GetOrderAllocations), ctx, orderId)
+}
+
+// GetOrderById mocks base method.
+func (m *MockStore) GetOrderById(ctx context.Context, id uuid.UUID) (db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderById", ctx, id)
+	ret0, _ := ret[0].(db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderById indicates an expected call of GetOrderById.
+func (mr *MockStoreMockRecorder) GetOrderById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderById", reflect.TypeOf((*MockStore)(nil).GetOrderById), ctx, id)
+}
+
+// GetOrderForUpdate mocks base method.
+func (m *MockStore) GetOrderForUpdate(ctx context.Context, id uuid.UUID) (db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderForUpdate indicates an expected call of GetOrderForUpdate.
+func (mr *MockStoreMockRecorder) GetOrderForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderForUpdate", reflect.TypeOf((*MockStore)(nil).GetOrderForUpdate), ctx, id)
+}
+
+// GetOrderItemDetails mocks base method.
+func (m *MockStore) GetOrderItemDetails(ctx context.Context, orderId uuid.UUID) ([]db.GetOrderItemDetailsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderItemDetails", ctx, orderId)
+	ret0, _ := ret[0].([]db.GetOrderItemDetailsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderItemDetails indicates an expected call of GetOrderItemDetails.
+func (mr *MockStoreMockRecorder) GetOrderItemDetails(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItemDetails", reflect.TypeOf((*MockStore)(nil).GetOrderItemDetails), ctx, orderId)
+}
+
+// GetOrderItemReturnableQuantity mocks base method.
+func (m *MockStore) GetOrderItemReturnableQuantity(ctx context.Context, orderid uuid.UUID) ([]db.GetOrderItemReturnableQuantityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderItemReturnableQuantity", ctx, orderid)
+	ret0, _ := ret[0].([]db.GetOrderItemReturnableQuantityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderItemReturnableQuantity indicates an expected call of GetOrderItemReturnableQuantity.
+func (mr *MockStoreMockRecorder) GetOrderItemReturnableQuantity(ctx, orderid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItemReturnableQuantity", reflect.TypeOf((*MockStore)(nil).GetOrderItemReturnableQuantity), ctx, orderid)
+}
+
+// GetOrderItemShippedQuantity mocks base method.
+func (m *MockStore) GetOrderItemShippedQuantity(ctx context.Context, orderid uuid.UUID) ([]db.GetOrderItemShippedQuantityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderItemShippedQuantity", ctx, orderid)
+	ret0, _ := ret[0].([]db.GetOrderItemShippedQuantityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderItemShippedQuantity indicates an expected call of GetOrderItemShippedQuantity.
+func (mr *MockStoreMockRecorder) GetOrderItemShippedQuantity(ctx, orderid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItemShippedQuantity", reflect.TypeOf((*MockStore)(nil).GetOrderItemShippedQuantity), ctx, orderid)
+}
+
+// GetOrderPromotionsByOrderIds mocks base method.
+func (m *MockStore) GetOrderPromotionsByOrderIds(ctx context.Context, orderids []uuid.UUID) ([]db.OrderPromotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderPromotionsByOrderIds", ctx, orderids)
+	ret0, _ := ret[0].([]db.OrderPromotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderPromotionsByOrderIds indicates an expected call of GetOrderPromotionsByOrderIds.
+func (mr *MockStoreMockRecorder) GetOrderPromotionsByOrderIds(ctx, orderids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderPromotionsByOrderIds", reflect.TypeOf((*MockStore)(nil).GetOrderPromotionsByOrderIds), ctx, orderids)
+}
+
+// GetOrderStatusEventsAfter mocks base method.
+func (m *MockStore) GetOrderStatusEventsAfter(ctx context.Context, arg db.GetOrderStatusEventsAfterParams) ([]db.OrderStatusEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderStatusEventsAfter", ctx, arg)
+	ret0, _ := ret[0].([]db.OrderStatusEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderStatusEventsAfter indicates an expected call of GetOrderStatusEventsAfter.
+func (mr *MockStoreMockRecorder) GetOrderStatusEventsAfter(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatusEventsAfter", reflect.TypeOf((*MockStore)(nil).GetOrderStatusEventsAfter), ctx, arg)
+}
+
+// GetOrderStatusEventsSince mocks base method.
+func (m *MockStore) GetOrderStatusEventsSince(ctx context.Context, arg db.GetOrderStatusEventsSinceParams) ([]db.OrderStatusEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderStatusEventsSince", ctx, arg)
+	ret0, _ := ret[0].([]db.OrderStatusEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderStatusEventsSince indicates an expected call of GetOrderStatusEventsSince.
+func (mr *MockStoreMockRecorder) GetOrderStatusEventsSince(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatusEventsSince", reflect.TypeOf((*MockStore)(nil).GetOrderStatusEventsSince), ctx, arg)
+}
+
+// GetOrderTaxByOrderIds mocks base method.
+func (m *MockStore) GetOrderTaxByOrderIds(ctx context.Context, orderids []uuid.UUID) ([]db.OrderTax, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderTaxByOrderIds", ctx, orderids)
+	ret0, _ := ret[0].([]db.OrderTax)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderTaxByOrderIds indicates an expected call of GetOrderTaxByOrderIds.
+func (mr *MockStoreMockRecorder) GetOrderTaxByOrderIds(ctx, orderids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderTaxByOrderIds", reflect.TypeOf((*MockStore)(nil).GetOrderTaxByOrderIds), ctx, orderids)
+}
+
+// GetOutboxEventForUpdate mocks base method.
+func (m *MockStore) GetOutboxEventForUpdate(ctx context.Context, id uuid.UUID) (db.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxEventForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutboxEventForUpdate indicates an expected call of GetOutboxEventForUpdate.
+func (mr *MockStoreMockRecorder) GetOutboxEventForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxEventForUpdate", reflect.TypeOf((*MockStore)(nil).GetOutboxEventForUpdate), ctx, id)
+}
+
+// GetPrimaryWarehouse mocks base method.
+func (m *MockStore) GetPrimaryWarehouse(ctx context.Context) (db.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrimaryWarehouse", ctx)
+	ret0, _ := ret[0].(db.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrimaryWarehouse indicates an expected call of GetPrimaryWarehouse.
+func (mr *MockStoreMockRecorder) GetPrimaryWarehouse(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrimaryWarehouse", reflect.TypeOf((*MockStore)(nil).GetPrimaryWarehouse), ctx)
+}
+
+// GetProductByNameForUpdate mocks base method.
+func (m *MockStore) GetProductByNameForUpdate(ctx context.Context, name string) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductByNameForUpdate", ctx, name)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductByNameForUpdate indicates an expected call of GetProductByNameForUpdate.
+func (mr *MockStoreMockRecorder) GetProductByNameForUpdate(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByNameForUpdate", reflect.TypeOf((*MockStore)(nil).GetProductByNameForUpdate), ctx, name)
+}
+
+// GetProductBySkuForUpdate mocks base method.
+func (m *MockStore) GetProductBySkuForUpdate(ctx context.Context, sku string) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductBySkuForUpdate", ctx, sku)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductBySkuForUpdate indicates an expected call of GetProductBySkuForUpdate.
+func (mr *MockStoreMockRecorder) GetProductBySkuForUpdate(ctx, sku any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductBySkuForUpdate", reflect.TypeOf((*MockStore)(nil).GetProductBySkuForUpdate), ctx, sku)
+}
+
+// GetProductForUpdate mocks base method.
+func (m *MockStore) GetProductForUpdate(ctx context.Context, id uuid.UUID) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductForUpdate indicates an expected call of GetProductForUpdate.
+func (mr *MockStoreMockRecorder) GetProductForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductForUpdate", reflect.TypeOf((*MockStore)(nil).GetProductForUpdate), ctx, id)
+}
+
+// GetProductIdsForUpdate mocks base method.
+func (m *MockStore) GetProductIdsForUpdate(ctx context.Context, productIds []uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductIdsForUpdate", ctx, productIds)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductIdsForUpdate indicates an expected call of GetProductIdsForUpdate.
+func (mr *MockStoreMockRecorder) GetProductIdsForUpdate(ctx, productIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductIdsForUpdate", reflect.TypeOf((*MockStore)(nil).GetProductIdsForUpdate), ctx, productIds)
+}
+
+// GetProductImageByProductIds mocks base method.
+func (m *MockStore) GetProductImageByProductIds(ctx context.Context, productids []uuid.UUID) ([]db.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductImageByProductIds", ctx, productids)
+	ret0, _ := ret[0].([]db.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductImageByProductIds indicates an expected call of GetProductImageByProductIds.
+func (mr *MockStoreMockRecorder) GetProductImageByProductIds(ctx, productids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductImageByProductIds", reflect.TypeOf((*MockStore)(nil).GetProductImageByProductIds), ctx, productids)
+}
+
+// GetProductPage mocks base method.
+func (m *MockStore) GetProductPage(ctx context.Context, arg db.GetProductPageParams) ([]db.GetProductPageRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductPage", ctx, arg)
+	ret0, _ := ret[0].([]db.GetProductPageRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductPage indicates an expected call of GetProductPage.
+func (mr *MockStoreMockRecorder) GetProductPage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductPage", reflect.TypeOf((*MockStore)(nil).GetProductPage), ctx, arg)
+}
+
+// GetProductPrices mocks base method.
+func (m *MockStore) GetProductPrices(ctx context.Context, arg db.GetProductPricesParams) ([]db.ProductPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductPrices", ctx, arg)
+	ret0, _ := ret[0].([]db.ProductPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductPrices indicates an expected call of GetProductPrices.
+func (mr *MockStoreMockRecorder) GetProductPrices(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductPrices", reflect.TypeOf((*MockStore)(nil).GetProductPrices), ctx, arg)
+}
+
+// GetProductReviews mocks base method.
+func (m *MockStore) GetProductReviews(ctx context.Context, arg db.GetProductReviewsParams) ([]db.ProductReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductReviews", ctx, arg)
+	ret0, _ := ret[0].([]db.ProductReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductReviews indicates an expected call of GetProductReviews.
+func (mr *MockStoreMockRecorder) GetProductReviews(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductReviews", reflect.TypeOf((*MockStore)(nil).GetProductReviews), ctx, arg)
+}
+
+// GetProductWarehouseStock mocks base method.
+func (m *MockStore) GetProductWarehouseStock(ctx context.Context, productId uuid.UUID) ([]db.GetProductWarehouseStockRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductWarehouseStock", ctx, productId)
+	ret0, _ := ret[0].([]db.GetProductWarehouseStockRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductWarehouseStock indicates an expected call of GetProductWarehouseStock.
+func (mr *MockStoreMockRecorder) GetProductWarehouseStock(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductWarehouseStock", reflect.TypeOf((*MockStore)(nil).GetProductWarehouseStock), ctx, productId)
+}
+
+// GetProductWatchers mocks base method.
+func (m *MockStore) GetProductWatchers(ctx context.Context, productId uuid.UUID) ([]db.GetProductWatchersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductWatchers", ctx, productId)
+	ret0, _ := ret[0].([]db.GetProductWatchersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductWatchers indicates an expected call of GetProductWatchers.
+func (mr *MockStoreMockRecorder) GetProductWatchers(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductWatchers", reflect.TypeOf((*MockStore)(nil).GetProductWatchers), ctx, productId)
+}
+
+// GetReturnEventByReturnIds mocks base method.
+func (m *MockStore) GetReturnEventByReturnIds(ctx context.Context, returnids []uuid.UUID) ([]db.ReturnEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturnEventByReturnIds", ctx, returnids)
+	ret0, _ := ret[0].([]db.ReturnEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturnEventByReturnIds indicates an expected call of GetReturnEventByReturnIds.
+func (mr *MockStoreMockRecorder) GetReturnEventByReturnIds(ctx, returnids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturnEventByReturnIds", reflect.TypeOf((*MockStore)(nil).GetReturnEventByReturnIds), ctx, returnids)
+}
+
+// GetReturnItemByReturnIds mocks base method.
+func (m *MockStore) GetReturnItemByReturnIds(ctx context.Context, returnids []uuid.UUID) ([]db.ReturnItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturnItemByReturnIds", ctx, returnids)
+	ret0, _ := ret[0].([]db.ReturnItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturnItemByReturnIds indicates an expected call of GetReturnItemByReturnIds.
+func (mr *MockStoreMockRecorder) GetReturnItemByReturnIds(ctx, returnids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturnItemByReturnIds", reflect.TypeOf((*MockStore)(nil).GetReturnItemByReturnIds), ctx, returnids)
+}
+
+// GetReturnRequestByOrderId mocks base method.
+func (m *MockStore) GetReturnRequestByOrderId(ctx context.Context, orderid uuid.UUID) ([]db.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturnRequestByOrderId", ctx, orderid)
+	ret0, _ := ret[0].([]db.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturnRequestByOrderId indicates an expected call of GetReturnRequestByOrderId.
+func (mr *MockStoreMockRecorder) GetReturnRequestByOrderId(ctx, orderid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturnRequestByOrderId", reflect.TypeOf((*MockStore)(nil).GetReturnRequestByOrderId), ctx, orderid)
+}
+
+// GetReturnRequestForUpdate mocks base method.
+func (m *MockStore) GetReturnRequestForUpdate(ctx context.Context, id uuid.UUID) (db.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturnRequestForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturnRequestForUpdate indicates an expected call of GetReturnRequestForUpdate.
+func (mr *MockStoreMockRecorder) GetReturnRequestForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturnRequestForUpdate", reflect.TypeOf((*MockStore)(nil).GetReturnRequestForUpdate), ctx, id)
+}
+
+// GetShipmentByOrderId mocks base method.
+func (m *MockStore) GetShipmentByOrderId(ctx context.Context, orderid uuid.UUID) ([]db.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShipmentByOrderId", ctx, orderid)
+	ret0, _ := ret[0].([]db.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShipmentByOrderId indicates an expected call of GetShipmentByOrderId.
+func (mr *MockStoreMockRecorder) GetShipmentByOrderId(ctx, orderid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipmentByOrderId", reflect.TypeOf((*MockStore)(nil).GetShipmentByOrderId), ctx, orderid)
+}
+
+// GetShipmentItemByShipmentIds mocks base method.
+func (m *MockStore) GetShipmentItemByShipmentIds(ctx context.Context, shipmentids []uuid.UUID) ([]db.ShipmentItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShipmentItemByShipmentIds", ctx, shipmentids)
+	ret0, _ := ret[0].([]db.ShipmentItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShipmentItemByShipmentIds indicates an expected call of GetShipmentItemByShipmentIds.
+func (mr *MockStoreMockRecorder) GetShipmentItemByShipmentIds(ctx, shipmentids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipmentItemByShipmentIds", reflect.TypeOf((*MockStore)(nil).GetShipmentItemByShipmentIds), ctx, shipmentids)
+}
+
+// GetStockDiscrepancies mocks base method.
+func (m *MockStore) GetStockDiscrepancies(ctx context.Context) ([]db.GetStockDiscrepanciesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockDiscrepancies", ctx)
+	ret0, _ := ret[0].([]db.GetStockDiscrepanciesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockDiscrepancies indicates an expected call of GetStockDiscrepancies.
+func (mr *MockStoreMockRecorder) GetStockDiscrepancies(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockDiscrepancies", reflect.TypeOf((*MockStore)(nil).GetStockDiscrepancies), ctx)
+}
+
+// GetStockMovements mocks base method.
+func (m *MockStore) GetStockMovements(ctx context.Context, arg db.GetStockMovementsParams) ([]db.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockMovements", ctx, arg)
+	ret0, _ := ret[0].([]db.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockMovements indicates an expected call of GetStockMovements.
+func (mr *MockStoreMockRecorder) GetStockMovements(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockMovements", reflect.TypeOf((*MockStore)(nil).GetStockMovements), ctx, arg)
+}
+
+// GetStockTransfers mocks base method.
+func (m *MockStore) GetStockTransfers(ctx context.Context, arg db.GetStockTransfersParams) ([]db.StockTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockTransfers", ctx, arg)
+	ret0, _ := ret[0].([]db.StockTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockTransfers indicates an expected call of GetStockTransfers.
+func (mr *MockStoreMockRecorder) GetStockTransfers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockTransfers", reflect.TypeOf((*MockStore)(nil).GetStockTransfers), ctx, arg)
+}
+
+// GetTaxRuleByTaxClass mocks base method.
+func (m *MockStore) GetTaxRuleByTaxClass(ctx context.Context, taxclasses []string) ([]db.TaxRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRuleByTaxClass", ctx, taxclasses)
+	ret0, _ := ret[0].([]db.TaxRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxRuleByTaxClass indicates an expected call of GetTaxRuleByTaxClass.
+func (mr *MockStoreMockRecorder) GetTaxRuleByTaxClass(ctx, taxclasses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRuleByTaxClass", reflect.TypeOf((*MockStore)(nil).GetTaxRuleByTaxClass), ctx, taxclasses)
+}
+
+// GetUndeliveredWebhookDelivery mocks base method.
+func (m *MockStore) GetUndeliveredWebhookDelivery(ctx context.Context, id uuid.UUID) (db.GetUndeliveredWebhookDeliveryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUndeliveredWebhookDelivery", ctx, id)
+	ret0, _ := ret[0].(db.GetUndeliveredWebhookDeliveryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUndeliveredWebhookDelivery indicates an expected call of GetUndeliveredWebhookDelivery.
+func (mr *MockStoreMockRecorder) GetUndeliveredWebhookDelivery(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUndeliveredWebhookDelivery", reflect.TypeOf((*MockStore)(nil).GetUndeliveredWebhookDelivery), ctx, id)
+}
+
+// GetUserById mocks base method.
+func (m *MockStore) GetUserById(ctx context.Context, email string) (db.GetUserByIdRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", ctx, email)
+	ret0, _ := ret[0].(db.GetUserByIdRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockStoreMockRecorder) GetUserById(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockStore)(nil).GetUserById), ctx, email)
+}
+
+// GetUserEmail mocks base method.
+func (m *MockStore) GetUserEmail(ctx context.Context, id uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserEmail", ctx, id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserEmail indicates an expected call of GetUserEmail.
+func (mr *MockStoreMockRecorder) GetUserEmail(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEmail", reflect.TypeOf((*MockStore)(nil).GetUserEmail), ctx, id)
+}
+
+// GetUserWishlist mocks base method.
+func (m *MockStore) GetUserWishlist(ctx context.Context, arg db.GetUserWishlistParams) (db.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserWishlist", ctx, arg)
+	ret0, _ := ret[0].(db.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserWishlist indicates an expected call of GetUserWishlist.
+func (mr *MockStoreMockRecorder) GetUserWishlist(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserWishlist", reflect.TypeOf((*MockStore)(nil).GetUserWishlist), ctx, arg)
+}
+
+// GetUserWishlists mocks base method.
+func (m *MockStore) GetUserWishlists(ctx context.Context, userId uuid.UUID) ([]db.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserWishlists", ctx, userId)
+	ret0, _ := ret[0].([]db.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserWishlists indicates an expected call of GetUserWishlists.
+func (mr *MockStoreMockRecorder) GetUserWishlists(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserWishlists", reflect.TypeOf((*MockStore)(nil).GetUserWishlists), ctx, userId)
+}
+
+// GetWarehouseStock mocks base method.
+func (m *MockStore) GetWarehouseStock(ctx context.Context, warehouseId uuid.UUID) ([]db.GetWarehouseStockRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWarehouseStock", ctx, warehouseId)
+	ret0, _ := ret[0].([]db.GetWarehouseStockRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWarehouseStock indicates an expected call of GetWarehouseStock.
+func (mr *MockStoreMockRecorder) GetWarehouseStock(ctx, warehouseId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWarehouseStock", reflect.TypeOf((*MockStore)(nil).GetWarehouseStock), ctx, warehouseId)
+}
+
+// GetWarehouseStockForUpdate mocks base method.
+func (m *MockStore) GetWarehouseStockForUpdate(ctx context.Context, arg db.GetWarehouseStockForUpdateParams) (db.WarehouseStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWarehouseStockForUpdate", ctx, arg)
+	ret0, _ := ret[0].(db.WarehouseStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWarehouseStockForUpdate indicates an expected call of GetWarehouseStockForUpdate.
+func (mr *MockStoreMockRecorder) GetWarehouseStockForUpdate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWarehouseStockForUpdate", reflect.TypeOf((*MockStore)(nil).GetWarehouseStockForUpdate), ctx, arg)
+}
+
+// GetWebhookDeliveries mocks base method.
+func (m *MockStore) GetWebhookDeliveries(ctx context.Context, arg db.GetWebhookDeliveriesParams) ([]db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].([]db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveries indicates an expected call of GetWebhookDeliveries.
+func (mr *MockStoreMockRecorder) GetWebhookDeliveries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).GetWebhookDeliveries), ctx, arg)
+}
+
+// GetWebhooksForEvent mocks base method.
+func (m *MockStore) GetWebhooksForEvent(ctx context.Context, eventType string) ([]db.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooksForEvent", ctx, eventType)
+	ret0, _ := ret[0].([]db.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooksForEvent indicates an expected call of GetWebhooksForEvent.
+func (mr *MockStoreMockRecorder) GetWebhooksForEvent(ctx, eventType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooksForEvent", reflect.TypeOf((*MockStore)(nil).GetWebhooksForEvent), ctx, eventType)
+}
+
+// GetWishlistByShareToken mocks base method.
+func (m *MockStore) GetWishlistByShareToken(ctx context.Context, shareToken pgtype.Text) (db.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlistByShareToken", ctx, shareToken)
+	ret0, _ := ret[0].(db.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlistByShareToken indicates an expected call of GetWishlistByShareToken.
+func (mr *MockStoreMockRecorder) GetWishlistByShareToken(ctx, shareToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlistByShareToken", reflect.TypeOf((*MockStore)(nil).GetWishlistByShareToken), ctx, shareToken)
+}
+
+// GetWishlistItems mocks base method.
+func (m *MockStore) GetWishlistItems(ctx context.Context, wishlistIds []uuid.UUID) ([]db.GetWishlistItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlistItems", ctx, wishlistIds)
+	ret0, _ := ret[0].([]db.GetWishlistItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlistItems indicates an expected call of GetWishlistItems.
+func (mr *MockStoreMockRecorder) GetWishlistItems(ctx, wishlistIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlistItems", reflect.TypeOf((*MockStore)(nil).GetWishlistItems), ctx, wishlistIds)
+}
+
+// HasPurchasedProduct mocks base method.
+func (m *MockStore) HasPurchasedProduct(ctx context.Context, arg db.HasPurchasedProductParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPurchasedProduct", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPurchasedProduct indicates an expected call of HasPurchasedProduct.
+func (mr *MockStoreMockRecorder) HasPurchasedProduct(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPurchasedProduct", reflect.TypeOf((*MockStore)(nil).HasPurchasedProduct), ctx, arg)
+}
+
+// ImportProductTx mocks base method.
+func (m *MockStore) ImportProductTx(ctx context.Context, arg db.ImportProductTxParams) ([]db.ImportedProduct, map[int]map[string]string, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportProductTx", ctx, arg)
+	ret0, _ := ret[0].([]db.ImportedProduct)
+	ret1, _ := ret[1].(map[int]map[string]string)
+	ret2, _ := ret[2].(error)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// ImportProductTx indicates an expected call of ImportProductTx.
+func (mr *MockStoreMockRecorder) ImportProductTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportProductTx", reflect.TypeOf((*MockStore)(nil).ImportProductTx), ctx, arg)
+}
+
+// IncrementCouponUsage mocks base method.
+func (m *MockStore) IncrementCouponUsage(ctx context.Context, id uuid.UUID) (db.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementCouponUsage", ctx, id)
+	ret0, _ := ret[0].(db.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementCouponUsage indicates an expected call of IncrementCouponUsage.
+func (mr *MockStoreMockRecorder) IncrementCouponUsage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementCouponUsage", reflect.TypeOf((*MockStore)(nil).IncrementCouponUsage), ctx, id)
+}
+
+// KillJob mocks base method.
+func (m *MockStore) KillJob(ctx context.Context, arg db.KillJobParams) (db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KillJob", ctx, arg)
+	ret0, _ := ret[0].(db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KillJob indicates an expected call of KillJob.
+func (mr *MockStoreMockRecorder) KillJob(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KillJob", reflect.TypeOf((*MockStore)(nil).KillJob), ctx, arg)
+}
+
+// Listen mocks base method.
+func (m *MockStore) Listen(ctx context.Context, channel string, listening func(), notify func(string)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", ctx, channel, listening, notify)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockStoreMockRecorder) Listen(ctx, channel, listening, notify any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockStore)(nil).Listen), ctx, channel, listening, notify)
+}
+
+// MarkLowStockAlertNotified mocks base method.
+func (m *MockStore) MarkLowStockAlertNotified(ctx context.Context, arg db.MarkLowStockAlertNotifiedParams) (db.LowStockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkLowStockAlertNotified", ctx, arg)
+	ret0, _ := ret[0].(db.LowStockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkLowStockAlertNotified indicates an expected call of MarkLowStockAlertNotified.
+func (mr *MockStoreMockRecorder) MarkLowStockAlertNotified(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkLowStockAlertNotified", reflect.TypeOf((*MockStore)(nil).MarkLowStockAlertNotified), ctx, arg)
+}
+
+// MarkOutboxEventDispatched mocks base method.
+func (m *MockStore) MarkOutboxEventDispatched(ctx context.Context, id uuid.UUID) (db.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOutboxEventDispatched", ctx, id)
+	ret0, _ := ret[0].(db.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOutboxEventDispatched indicates an expected call of MarkOutboxEventDispatched.
+func (mr *MockStoreMockRecorder) MarkOutboxEventDispatched(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxEventDispatched", reflect.TypeOf((*MockStore)(nil).MarkOutboxEventDispatched), ctx, id)
+}
+
+// PreviewOrder mocks base method.
+func (m *MockStore) PreviewOrder(ctx context.Context, arg db.CreateOrderTxParams) (db.OrderPreview, map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewOrder", ctx, arg)
+	ret0, _ := ret[0].(db.OrderPreview)
+	ret1, _ := ret[1].(map[string]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PreviewOrder indicates an expected call of PreviewOrder.
+func (mr *MockStoreMockRecorder) PreviewOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewOrder", reflect.TypeOf((*MockStore)(nil).PreviewOrder), ctx, arg)
+}
+
+// QuoteShipping mocks base method.
+func (m *MockStore) QuoteShipping(ctx context.Context, arg db.QuoteShippingParams) (db.ShippingQuote, map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuoteShipping", ctx, arg)
+	ret0, _ := ret[0].(db.ShippingQuote)
+	ret1, _ := ret[1].(map[string]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// QuoteShipping indicates an expected call of QuoteShipping.
+func (mr *MockStoreMockRecorder) QuoteShipping(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteShipping", reflect.TypeOf((*MockStore)(nil).QuoteShipping), ctx, arg)
+}
+
+// RecordNotificationAttempt mocks base method.
+func (m *MockStore) RecordNotificationAttempt(ctx context.Context, arg db.RecordNotificationAttemptParams) (db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordNotificationAttempt", ctx, arg)
+	ret0, _ := ret[0].(db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordNotificationAttempt indicates an expected call of RecordNotificationAttempt.
+func (mr *MockStoreMockRecorder) RecordNotificationAttempt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordNotificationAttempt", reflect.TypeOf((*MockStore)(nil).RecordNotificationAttempt), ctx, arg)
+}
+
+// RecordWebhookDeliveryAttempt mocks base method.
+func (m *MockStore) RecordWebhookDeliveryAttempt(ctx context.Context, arg db.RecordWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordWebhookDeliveryAttempt", ctx, arg)
+	ret0, _ := ret[0].(db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordWebhookDeliveryAttempt indicates an expected call of RecordWebhookDeliveryAttempt.
+func (mr *MockStoreMockRecorder) RecordWebhookDeliveryAttempt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWebhookDeliveryAttempt", reflect.TypeOf((*MockStore)(nil).RecordWebhookDeliveryAttempt), ctx, arg)
+}
+
+// RedeliverWebhookTx mocks base method.
+func (m *MockStore) RedeliverWebhookTx(ctx context.Context, deliveryId uuid.UUID) (db.WebhookDelivery, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeliverWebhookTx", ctx, deliveryId)
+	ret0, _ := ret[0].(db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RedeliverWebhookTx indicates an expected call of RedeliverWebhookTx.
+func (mr *MockStoreMockRecorder) RedeliverWebhookTx(ctx, deliveryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeliverWebhookTx", reflect.TypeOf((*MockStore)(nil).RedeliverWebhookTx), ctx, deliveryId)
+}
+
+// ReorderProductImageTx mocks base method.
+func (m *MockStore) ReorderProductImageTx(ctx context.Context, arg db.ReorderProductImageTxParams) ([]db.ProductImage, map[string]string, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderProductImageTx", ctx, arg)
+	ret0, _ := ret[0].([]db.ProductImage)
+	ret1, _ := ret[1].(map[string]string)
+	ret2, _ := ret[2].(error)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// ReorderProductImageTx indicates an expected call of ReorderProductImageTx.
+func (mr *MockStoreMockRecorder) ReorderProductImageTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderProductImageTx", reflect.TypeOf((*MockStore)(nil).ReorderProductImageTx), ctx, arg)
+}
+
+// RequeueStaleJobs mocks base method.
+func (m *MockStore) RequeueStaleJobs(ctx context.Context, lockedBefore pgtype.Timestamp) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueStaleJobs", ctx, lockedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueStaleJobs indicates an expected call of RequeueStaleJobs.
+func (mr *MockStoreMockRecorder) RequeueStaleJobs(ctx, lockedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueStaleJobs", reflect.TypeOf((*MockStore)(nil).RequeueStaleJobs), ctx, lockedBefore)
+}
+
+// ResolveLowStockAlerts mocks base method.
+func (m *MockStore) ResolveLowStockAlerts(ctx context.Context, productId uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveLowStockAlerts", ctx, productId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveLowStockAlerts indicates an expected call of ResolveLowStockAlerts.
+func (mr *MockStoreMockRecorder) ResolveLowStockAlerts(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveLowStockAlerts", reflect.TypeOf((*MockStore)(nil).ResolveLowStockAlerts), ctx, productId)
+}
+
+// RestoreProduct mocks base method.
+func (m *MockStore) RestoreProduct(ctx context.Context, id uuid.UUID) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProduct", ctx, id)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreProduct indicates an expected call of RestoreProduct.
+func (mr *MockStoreMockRecorder) RestoreProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProduct", reflect.TypeOf((*MockStore)(nil).RestoreProduct), ctx, id)
+}
+
+// RestoreProductTx mocks base method.
+func (m *MockStore) RestoreProductTx(ctx context.Context, productId uuid.UUID) (db.Product, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProductTx", ctx, productId)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RestoreProductTx indicates an expected call of RestoreProductTx.
+func (mr *MockStoreMockRecorder) RestoreProductTx(ctx, productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProductTx", reflect.TypeOf((*MockStore)(nil).RestoreProductTx), ctx, productId)
+}
+
+// ResurrectJob mocks base method.
+func (m *MockStore) ResurrectJob(ctx context.Context, arg db.ResurrectJobParams) (db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResurrectJob", ctx, arg)
+	ret0, _ := ret[0].(db.Job)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CancelOrder indicates an expected call of CancelOrder.
-func (mr *MockStoreMockRecorder) CancelOrder(ctx, arg any) *gomock.Call {
+// ResurrectJob indicates an expected call of ResurrectJob.
+func (mr *MockStoreMockRecorder) ResurrectJob(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockStore)(nil).CancelOrder), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResurrectJob", reflect.TypeOf((*MockStore)(nil).ResurrectJob), ctx, arg)
 }
 
-// CreateAdminUser mocks base method.
-func (m *MockStore) CreateAdminUser(ctx context.Context, arg db.CreateAdminUserParams) (db.User, error) {
+// RetryJob mocks base method.
+func (m *MockStore) RetryJob(ctx context.Context, arg db.RetryJobParams) (db.Job, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAdminUser", ctx, arg)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "RetryJob", ctx, arg)
+	ret0, _ := ret[0].(db.Job)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateAdminUser indicates an expected call of CreateAdminUser.
-func (mr *MockStoreMockRecorder) CreateAdminUser(ctx, arg any) *gomock.Call {
+// RetryJob indicates an expected call of RetryJob.
+func (mr *MockStoreMockRecorder) RetryJob(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdminUser", reflect.TypeOf((*MockStore)(nil).CreateAdminUser), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryJob", reflect.TypeOf((*MockStore)(nil).RetryJob), ctx, arg)
 }
 
-// CreateOrder mocks base method.
-func (m *MockStore) CreateOrder(ctx context.Context, arg db.CreateOrderParams) (db.Order, error) {
+// RetryOutboxEvent mocks base method.
+func (m *MockStore) RetryOutboxEvent(ctx context.Context, arg db.RetryOutboxEventParams) (db.OutboxEvent, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrder", ctx, arg)
-	ret0, _ := ret[0].(db.Order)
+	ret := m.ctrl.Call(m, "RetryOutboxEvent", ctx, arg)
+	ret0, _ := ret[0].(db.OutboxEvent)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateOrder indicates an expected call of CreateOrder.
-func (mr *MockStoreMockRecorder) CreateOrder(ctx, arg any) *gomock.Call {
+// RetryOutboxEvent indicates an expected call of RetryOutboxEvent.
+func (mr *MockStoreMockRecorder) RetryOutboxEvent(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockStore)(nil).CreateOrder), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryOutboxEvent", reflect.TypeOf((*MockStore)(nil).RetryOutboxEvent), ctx, arg)
 }
 
-// CreateOrderTx mocks base method.
-func (m *MockStore) CreateOrderTx(ctx context.Context, arg db.CreateOrderTxParams) (db.Order, map[string]string, error, error) {
+// ScheduleProductPriceTx mocks base method.
+func (m *MockStore) ScheduleProductPriceTx(ctx context.Context, arg db.CreateProductPriceParams) (db.ScheduleProductPriceTxResult, error, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrderTx", ctx, arg)
-	ret0, _ := ret[0].(db.Order)
-	ret1, _ := ret[1].(map[string]string)
+	ret := m.ctrl.Call(m, "ScheduleProductPriceTx", ctx, arg)
+	ret0, _ := ret[0].(db.ScheduleProductPriceTxResult)
+	ret1, _ := ret[1].(error)
 	ret2, _ := ret[2].(error)
-	ret3, _ := ret[3].(error)
-	return ret0, ret1, ret2, ret3
+	return ret0, ret1, ret2
 }
 
-// CreateOrderTx indicates an expected call of CreateOrderTx.
-func (mr *MockStoreMockRecorder) CreateOrderTx(ctx, arg any) *gomock.Call {
+// ScheduleProductPriceTx indicates an expected call of ScheduleProductPriceTx.
+func (mr *MockStoreMockRecorder) ScheduleProductPriceTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderTx", reflect.TypeOf((*MockStore)(nil).CreateOrderTx), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleProductPriceTx", reflect.TypeOf((*MockStore)(nil).ScheduleProductPriceTx), ctx, arg)
 }
 
-// CreateProduct mocks base method.
-func (m *MockStore) CreateProduct(ctx context.Context, arg db.CreateProductParams) (db.Product, error) {
+// SetWarehouseStockTx mocks base method.
+func (m *MockStore) SetWarehouseStockTx(ctx context.Context, arg db.SetWarehouseStockTxParams) (db.Product, error, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProduct", ctx, arg)
+	ret := m.ctrl.Call(m, "SetWarehouseStockTx", ctx, arg)
 	ret0, _ := ret[0].(db.Product)
 	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// CreateProduct indicates an expected call of CreateProduct.
-func (mr *MockStoreMockRecorder) CreateProduct(ctx, arg any) *gomock.Call {
+// SetWarehouseStockTx indicates an expected call of SetWarehouseStockTx.
+func (mr *MockStoreMockRecorder) SetWarehouseStockTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockStore)(nil).CreateProduct), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWarehouseStockTx", reflect.TypeOf((*MockStore)(nil).SetWarehouseStockTx), ctx, arg)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
+// SetWishlistShareToken mocks base method.
+func (m *MockStore) SetWishlistShareToken(ctx context.Context, arg db.SetWishlistShareTokenParams) (db.Wishlist, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "SetWishlistShareToken", ctx, arg)
+	ret0, _ := ret[0].(db.Wishlist)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
+// SetWishlistShareToken indicates an expected call of SetWishlistShareToken.
+func (mr *MockStoreMockRecorder) SetWishlistShareToken(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWishlistShareToken", reflect.TypeOf((*MockStore)(nil).SetWishlistShareToken), ctx, arg)
 }
 
-// DeleteOneProduct mocks base method.
-func (m *MockStore) DeleteOneProduct(ctx context.Context, id uuid.UUID) error {
+// TransferStockTx mocks base method.
+func (m *MockStore) TransferStockTx(ctx context.Context, arg db.TransferStockTxParams) (db.StockTransfer, error, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteOneProduct", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "TransferStockTx", ctx, arg)
+	ret0, _ := ret[0].(db.StockTransfer)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// DeleteOneProduct indicates an expected call of DeleteOneProduct.
-func (mr *MockStoreMockRecorder) DeleteOneProduct(ctx, id any) *gomock.Call {
+// TransferStockTx indicates an expected call of TransferStockTx.
+func (mr *MockStoreMockRecorder) TransferStockTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneProduct", reflect.TypeOf((*MockStore)(nil).DeleteOneProduct), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferStockTx", reflect.TypeOf((*MockStore)(nil).TransferStockTx), ctx, arg)
 }
 
-// GetAllOrderByUserId mocks base method.
-func (m *MockStore) GetAllOrderByUserId(ctx context.Context, userid uuid.UUID) ([]db.Order, error) {
+// UpdateCouponTx mocks base method.
+func (m *MockStore) UpdateCouponTx(ctx context.Context, arg db.UpdateCouponTxParams) (db.Coupon, error, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllOrderByUserId", ctx, userid)
-	ret0, _ := ret[0].([]db.Order)
+	ret := m.ctrl.Call(m, "UpdateCouponTx", ctx, arg)
+	ret0, _ := ret[0].(db.Coupon)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateCouponTx indicates an expected call of UpdateCouponTx.
+func (mr *MockStoreMockRecorder) UpdateCouponTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCouponTx", reflect.TypeOf((*MockStore)(nil).UpdateCouponTx), ctx, arg)
+}
+
+// UpdateOneCoupon mocks base method.
+func (m *MockStore) UpdateOneCoupon(ctx context.Context, arg db.UpdateOneCouponParams) (db.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOneCoupon", ctx, arg)
+	ret0, _ := ret[0].(db.Coupon)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllOrderByUserId indicates an expected call of GetAllOrderByUserId.
-func (mr *MockStoreMockRecorder) GetAllOrderByUserId(ctx, userid any) *gomock.Call {
+// UpdateOneCoupon indicates an expected call of UpdateOneCoupon.
+func (mr *MockStoreMockRecorder) UpdateOneCoupon(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrderByUserId", reflect.TypeOf((*MockStore)(nil).GetAllOrderByUserId), ctx, userid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneCoupon", reflect.TypeOf((*MockStore)(nil).UpdateOneCoupon), ctx, arg)
 }
 
-// GetAllOrderItem mocks base method.
-func (m *MockStore) GetAllOrderItem(ctx context.Context, orderid uuid.UUID) ([]db.OrderItem, error) {
+// UpdateOneProduct mocks base method.
+func (m *MockStore) UpdateOneProduct(ctx context.Context, arg db.UpdateOneProductParams) (db.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllOrderItem", ctx, orderid)
-	ret0, _ := ret[0].([]db.OrderItem)
+	ret := m.ctrl.Call(m, "UpdateOneProduct", ctx, arg)
+	ret0, _ := ret[0].(db.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllOrderItem indicates an expected call of GetAllOrderItem.
-func (mr *MockStoreMockRecorder) GetAllOrderItem(ctx, orderid any) *gomock.Call {
+// UpdateOneProduct indicates an expected call of UpdateOneProduct.
+func (mr *MockStoreMockRecorder) UpdateOneProduct(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrderItem", reflect.TypeOf((*MockStore)(nil).GetAllOrderItem), ctx, orderid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneProduct", reflect.TypeOf((*MockStore)(nil).UpdateOneProduct), ctx, arg)
 }
 
-// GetAllProduct mocks base method.
-func (m *MockStore) GetAllProduct(ctx context.Context) ([]db.GetAllProductRow, error) {
+// UpdateOnePromotion mocks base method.
+func (m *MockStore) UpdateOnePromotion(ctx context.Context, arg db.UpdateOnePromotionParams) (db.Promotion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllProduct", ctx)
-	ret0, _ := ret[0].([]db.GetAllProductRow)
+	ret := m.ctrl.Call(m, "UpdateOnePromotion", ctx, arg)
+	ret0, _ := ret[0].(db.Promotion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllProduct indicates an expected call of GetAllProduct.
-func (mr *MockStoreMockRecorder) GetAllProduct(ctx any) *gomock.Call {
+// UpdateOnePromotion indicates an expected call of UpdateOnePromotion.
+func (mr *MockStoreMockRecorder) UpdateOnePromotion(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProduct", reflect.TypeOf((*MockStore)(nil).GetAllProduct), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOnePromotion", reflect.TypeOf((*MockStore)(nil).UpdateOnePromotion), ctx, arg)
 }
 
-// GetAllProductInOrder mocks base method.
-func (m *MockStore) GetAllProductInOrder(ctx context.Context, orderid uuid.UUID) ([]db.GetAllProductInOrderRow, error) {
+// UpdateOneShipment mocks base method.
+func (m *MockStore) UpdateOneShipment(ctx context.Context, arg db.UpdateOneShipmentParams) (db.Shipment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllProductInOrder", ctx, orderid)
-	ret0, _ := ret[0].([]db.GetAllProductInOrderRow)
+	ret := m.ctrl.Call(m, "UpdateOneShipment", ctx, arg)
+	ret0, _ := ret[0].(db.Shipment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllProductInOrder indicates an expected call of GetAllProductInOrder.
-func (mr *MockStoreMockRecorder) GetAllProductInOrder(ctx, orderid any) *gomock.Call {
+// UpdateOneShipment indicates an expected call of UpdateOneShipment.
+func (mr *MockStoreMockRecorder) UpdateOneShipment(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProductInOrder", reflect.TypeOf((*MockStore)(nil).GetAllProductInOrder), ctx, orderid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneShipment", reflect.TypeOf((*MockStore)(nil).UpdateOneShipment), ctx, arg)
 }
 
-// GetMultipleProductById mocks base method.
-func (m *MockStore) GetMultipleProductById(ctx context.Context, dollar_1 []uuid.UUID) ([]db.GetMultipleProductByIdRow, error) {
+// UpdateOneShippingMethod mocks base method.
+func (m *MockStore) UpdateOneShippingMethod(ctx context.Context, arg db.UpdateOneShippingMethodParams) (db.ShippingMethod, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMultipleProductById", ctx, dollar_1)
-	ret0, _ := ret[0].([]db.GetMultipleProductByIdRow)
+	ret := m.ctrl.Call(m, "UpdateOneShippingMethod", ctx, arg)
+	ret0, _ := ret[0].(db.ShippingMethod)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMultipleProductById indicates an expected call of GetMultipleProductById.
-func (mr *MockStoreMockRecorder) GetMultipleProductById(ctx, dollar_1 any) *gomock.Call {
+// UpdateOneShippingMethod indicates an expected call of UpdateOneShippingMethod.
+func (mr *MockStoreMockRecorder) UpdateOneShippingMethod(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultipleProductById", reflect.TypeOf((*MockStore)(nil).GetMultipleProductById), ctx, dollar_1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneShippingMethod", reflect.TypeOf((*MockStore)(nil).UpdateOneShippingMethod), ctx, arg)
 }
 
-// GetOneProduct mocks base method.
-func (m *MockStore) GetOneProduct(ctx context.Context, id uuid.UUID) (db.GetOneProductRow, error) {
+// UpdateOneShippingZone mocks base method.
+func (m *MockStore) UpdateOneShippingZone(ctx context.Context, arg db.UpdateOneShippingZoneParams) (db.ShippingZone, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOneProduct", ctx, id)
-	ret0, _ := ret[0].(db.GetOneProductRow)
+	ret := m.ctrl.Call(m, "UpdateOneShippingZone", ctx, arg)
+	ret0, _ := ret[0].(db.ShippingZone)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOneProduct indicates an expected call of GetOneProduct.
-func (mr *MockStoreMockRecorder) GetOneProduct(ctx, id any) *gomock.Call {
+// UpdateOneShippingZone indicates an expected call of UpdateOneShippingZone.
+func (mr *MockStoreMockRecorder) UpdateOneShippingZone(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneProduct", reflect.TypeOf((*MockStore)(nil).GetOneProduct), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneShippingZone", reflect.TypeOf((*MockStore)(nil).UpdateOneShippingZone), ctx, arg)
 }
 
-// GetOrderById mocks base method.
-func (m *MockStore) GetOrderById(ctx context.Context, id uuid.UUID) (db.Order, error) {
+// UpdateOneTaxRule mocks base method.
+func (m *MockStore) UpdateOneTaxRule(ctx context.Context, arg db.UpdateOneTaxRuleParams) (db.TaxRule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrderById", ctx, id)
-	ret0, _ := ret[0].(db.Order)
+	ret := m.ctrl.Call(m, "UpdateOneTaxRule", ctx, arg)
+	ret0, _ := ret[0].(db.TaxRule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOrderById indicates an expected call of GetOrderById.
-func (mr *MockStoreMockRecorder) GetOrderById(ctx, id any) *gomock.Call {
+// UpdateOneTaxRule indicates an expected call of UpdateOneTaxRule.
+func (mr *MockStoreMockRecorder) UpdateOneTaxRule(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderById", reflect.TypeOf((*MockStore)(nil).GetOrderById), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneTaxRule", reflect.TypeOf((*MockStore)(nil).UpdateOneTaxRule), ctx, arg)
 }
 
-// GetUserById mocks base method.
-func (m *MockStore) GetUserById(ctx context.Context, email string) (db.GetUserByIdRow, error) {
+// UpdateOneWebhook mocks base method.
+func (m *MockStore) UpdateOneWebhook(ctx context.Context, arg db.UpdateOneWebhookParams) (db.Webhook, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserById", ctx, email)
-	ret0, _ := ret[0].(db.GetUserByIdRow)
+	ret := m.ctrl.Call(m, "UpdateOneWebhook", ctx, arg)
+	ret0, _ := ret[0].(db.Webhook)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserById indicates an expected call of GetUserById.
-func (mr *MockStoreMockRecorder) GetUserById(ctx, email any) *gomock.Call {
+// UpdateOneWebhook indicates an expected call of UpdateOneWebhook.
+func (mr *MockStoreMockRecorder) UpdateOneWebhook(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockStore)(nil).GetUserById), ctx, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneWebhook", reflect.TypeOf((*MockStore)(nil).UpdateOneWebhook), ctx, arg)
 }
 
-// UpdateOneProduct mocks base method.
-func (m *MockStore) UpdateOneProduct(ctx context.Context, arg db.UpdateOneProductParams) (db.Product, error) {
+// UpdateOrderItemBackordered mocks base method.
+func (m *MockStore) UpdateOrderItemBackordered(ctx context.Context, arg db.UpdateOrderItemBackorderedParams) (db.OrderItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOneProduct", ctx, arg)
-	ret0, _ := ret[0].(db.Product)
+	ret := m.ctrl.Call(m, "UpdateOrderItemBackordered", ctx, arg)
+	ret0, _ := ret[0].(db.OrderItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateOneProduct indicates an expected call of UpdateOneProduct.
-func (mr *MockStoreMockRecorder) UpdateOneProduct(ctx, arg any) *gomock.Call {
+// UpdateOrderItemBackordered indicates an expected call of UpdateOrderItemBackordered.
+func (mr *MockStoreMockRecorder) UpdateOrderItemBackordered(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneProduct", reflect.TypeOf((*MockStore)(nil).UpdateOneProduct), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderItemBackordered", reflect.TypeOf((*MockStore)(nil).UpdateOrderItemBackordered), ctx, arg)
 }
 
 // UpdateOrderStatus mocks base method.
@@ -313,6 +2988,51 @@ func (mr *MockStoreMockRecorder) UpdateOrderTx(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderTx", reflect.TypeOf((*MockStore)(nil).UpdateOrderTx), ctx, arg)
 }
 
+// UpdateProductImagePosition mocks base method.
+func (m *MockStore) UpdateProductImagePosition(ctx context.Context, arg db.UpdateProductImagePositionParams) (db.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductImagePosition", ctx, arg)
+	ret0, _ := ret[0].(db.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProductImagePosition indicates an expected call of UpdateProductImagePosition.
+func (mr *MockStoreMockRecorder) UpdateProductImagePosition(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductImagePosition", reflect.TypeOf((*MockStore)(nil).UpdateProductImagePosition), ctx, arg)
+}
+
+// UpdateProductPrice mocks base method.
+func (m *MockStore) UpdateProductPrice(ctx context.Context, arg db.UpdateProductPriceParams) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductPrice", ctx, arg)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProductPrice indicates an expected call of UpdateProductPrice.
+func (mr *MockStoreMockRecorder) UpdateProductPrice(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductPrice", reflect.TypeOf((*MockStore)(nil).UpdateProductPrice), ctx, arg)
+}
+
+// UpdateProductReviewStatus mocks base method.
+func (m *MockStore) UpdateProductReviewStatus(ctx context.Context, arg db.UpdateProductReviewStatusParams) (db.ProductReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductReviewStatus", ctx, arg)
+	ret0, _ := ret[0].(db.ProductReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProductReviewStatus indicates an expected call of UpdateProductReviewStatus.
+func (mr *MockStoreMockRecorder) UpdateProductReviewStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductReviewStatus", reflect.TypeOf((*MockStore)(nil).UpdateProductReviewStatus), ctx, arg)
+}
+
 // UpdateProductStock mocks base method.
 func (m *MockStore) UpdateProductStock(ctx context.Context, arg db.UpdateProductStockParams) (db.Product, error) {
 	m.ctrl.T.Helper()
@@ -343,3 +3063,175 @@ func (mr *MockStoreMockRecorder) UpdateProductTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductTx", reflect.TypeOf((*MockStore)(nil).UpdateProductTx), ctx, arg)
 }
+
+// UpdateReturnRequestStatus mocks base method.
+func (m *MockStore) UpdateReturnRequestStatus(ctx context.Context, arg db.UpdateReturnRequestStatusParams) (db.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReturnRequestStatus", ctx, arg)
+	ret0, _ := ret[0].(db.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateReturnRequestStatus indicates an expected call of UpdateReturnRequestStatus.
+func (mr *MockStoreMockRecorder) UpdateReturnRequestStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReturnRequestStatus", reflect.TypeOf((*MockStore)(nil).UpdateReturnRequestStatus), ctx, arg)
+}
+
+// UpdateReturnStatusTx mocks base method.
+func (m *MockStore) UpdateReturnStatusTx(ctx context.Context, arg db.UpdateReturnStatusTxParams) (db.ReturnTxResult, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReturnStatusTx", ctx, arg)
+	ret0, _ := ret[0].(db.ReturnTxResult)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateReturnStatusTx indicates an expected call of UpdateReturnStatusTx.
+func (mr *MockStoreMockRecorder) UpdateReturnStatusTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReturnStatusTx", reflect.TypeOf((*MockStore)(nil).UpdateReturnStatusTx), ctx, arg)
+}
+
+// UpdateShipmentTx mocks base method.
+func (m *MockStore) UpdateShipmentTx(ctx context.Context, arg db.UpdateShipmentTxParams) (db.ShipmentTxResult, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShipmentTx", ctx, arg)
+	ret0, _ := ret[0].(db.ShipmentTxResult)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateShipmentTx indicates an expected call of UpdateShipmentTx.
+func (mr *MockStoreMockRecorder) UpdateShipmentTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShipmentTx", reflect.TypeOf((*MockStore)(nil).UpdateShipmentTx), ctx, arg)
+}
+
+// UpdateShippingMethodTx mocks base method.
+func (m *MockStore) UpdateShippingMethodTx(ctx context.Context, arg db.UpdateShippingMethodTxParams) (db.ShippingMethod, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShippingMethodTx", ctx, arg)
+	ret0, _ := ret[0].(db.ShippingMethod)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateShippingMethodTx indicates an expected call of UpdateShippingMethodTx.
+func (mr *MockStoreMockRecorder) UpdateShippingMethodTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShippingMethodTx", reflect.TypeOf((*MockStore)(nil).UpdateShippingMethodTx), ctx, arg)
+}
+
+// UpdateShippingZoneTx mocks base method.
+func (m *MockStore) UpdateShippingZoneTx(ctx context.Context, arg db.UpdateShippingZoneTxParams) (db.ShippingZone, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShippingZoneTx", ctx, arg)
+	ret0, _ := ret[0].(db.ShippingZone)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateShippingZoneTx indicates an expected call of UpdateShippingZoneTx.
+func (mr *MockStoreMockRecorder) UpdateShippingZoneTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShippingZoneTx", reflect.TypeOf((*MockStore)(nil).UpdateShippingZoneTx), ctx, arg)
+}
+
+// UpdateTaxRuleTx mocks base method.
+func (m *MockStore) UpdateTaxRuleTx(ctx context.Context, arg db.UpdateTaxRuleTxParams) (db.TaxRule, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaxRuleTx", ctx, arg)
+	ret0, _ := ret[0].(db.TaxRule)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateTaxRuleTx indicates an expected call of UpdateTaxRuleTx.
+func (mr *MockStoreMockRecorder) UpdateTaxRuleTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaxRuleTx", reflect.TypeOf((*MockStore)(nil).UpdateTaxRuleTx), ctx, arg)
+}
+
+// UpdateWarehouse mocks base method.
+func (m *MockStore) UpdateWarehouse(ctx context.Context, arg db.UpdateWarehouseParams) (db.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWarehouse", ctx, arg)
+	ret0, _ := ret[0].(db.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWarehouse indicates an expected call of UpdateWarehouse.
+func (mr *MockStoreMockRecorder) UpdateWarehouse(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWarehouse", reflect.TypeOf((*MockStore)(nil).UpdateWarehouse), ctx, arg)
+}
+
+// UpdateWarehouseTx mocks base method.
+func (m *MockStore) UpdateWarehouseTx(ctx context.Context, arg db.UpdateWarehouseTxParams) (db.Warehouse, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWarehouseTx", ctx, arg)
+	ret0, _ := ret[0].(db.Warehouse)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateWarehouseTx indicates an expected call of UpdateWarehouseTx.
+func (mr *MockStoreMockRecorder) UpdateWarehouseTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWarehouseTx", reflect.TypeOf((*MockStore)(nil).UpdateWarehouseTx), ctx, arg)
+}
+
+// UpdateWebhookTx mocks base method.
+func (m *MockStore) UpdateWebhookTx(ctx context.Context, arg db.UpdateWebhookTxParams) (db.Webhook, error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookTx", ctx, arg)
+	ret0, _ := ret[0].(db.Webhook)
+	ret1, _ := ret[1].(error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateWebhookTx indicates an expected call of UpdateWebhookTx.
+func (mr *MockStoreMockRecorder) UpdateWebhookTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookTx", reflect.TypeOf((*MockStore)(nil).UpdateWebhookTx), ctx, arg)
+}
+
+// UpdateWishlistName mocks base method.
+func (m *MockStore) UpdateWishlistName(ctx context.Context, arg db.UpdateWishlistNameParams) (db.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWishlistName", ctx, arg)
+	ret0, _ := ret[0].(db.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWishlistName indicates an expected call of UpdateWishlistName.
+func (mr *MockStoreMockRecorder) UpdateWishlistName(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWishlistName", reflect.TypeOf((*MockStore)(nil).UpdateWishlistName), ctx, arg)
+}
+
+// UpsertNotificationPreference mocks base method.
+func (m *MockStore) UpsertNotificationPreference(ctx context.Context, arg db.UpsertNotificationPreferenceParams) (db.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertNotificationPreference", ctx, arg)
+	ret0, _ := ret[0].(db.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertNotificationPreference indicates an expected call of UpsertNotificationPreference.
+func (mr *MockStoreMockRecorder) UpsertNotificationPreference(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertNotificationPreference", reflect.TypeOf((*MockStore)(nil).UpsertNotificationPreference), ctx, arg)
+}
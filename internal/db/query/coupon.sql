@@ -0,0 +1,90 @@
+-- name: CreateCoupon :one
+INSERT INTO "coupon" (
+    id,
+    code,
+    "discountType",
+    value,
+    "minOrderValue",
+    "productIds",
+    categories,
+    "usageLimit",
+    "perUserLimit",
+    "startsAt",
+    "expiresAt",
+    active,
+    "createdBy"
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+) RETURNING *;
+
+-- name: GetAllCoupon :many
+SELECT * FROM "coupon"
+ORDER BY "createdAt" DESC;
+
+-- name: GetOneCoupon :one
+SELECT * FROM "coupon"
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetCouponByCode :one
+SELECT * FROM "coupon"
+WHERE code = $1
+LIMIT 1;
+
+-- name: UpdateOneCoupon :one
+UPDATE "coupon"
+SET
+    code = sqlc.arg('code'),
+    "discountType" = sqlc.arg('discountType'),
+    value = sqlc.arg('value'),
+    "minOrderValue" = sqlc.arg('minOrderValue'),
+    "productIds" = sqlc.arg('productIds'),
+    categories = sqlc.arg('categories'),
+    "usageLimit" = sqlc.arg('usageLimit'),
+    "perUserLimit" = sqlc.arg('perUserLimit'),
+    "startsAt" = sqlc.arg('startsAt'),
+    "expiresAt" = sqlc.arg('expiresAt'),
+    active = sqlc.arg('active'),
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: DeleteOneCoupon :exec
+DELETE FROM "coupon"
+WHERE id = $1;
+
+-- name: IncrementCouponUsage :one
+UPDATE "coupon"
+SET
+    "usageCount" = "usageCount" + 1,
+    "updatedAt" = NOW()
+WHERE id = $1 AND ("usageLimit" = 0 OR "usageCount" < "usageLimit")
+RETURNING *;
+
+-- name: CountUserCouponRedemption :one
+SELECT COUNT(*) FROM "couponRedemption"
+WHERE "couponId" = $1 AND "userId" = $2;
+
+-- name: CreateCouponRedemption :one
+INSERT INTO "couponRedemption" (
+    id,
+    "couponId",
+    "userId",
+    "orderId",
+    discount
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: DecrementCouponUsage :one
+UPDATE "coupon"
+SET
+    "usageCount" = GREATEST("usageCount" - 1, 0),
+    "updatedAt" = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteCouponRedemptionByOrderId :many
+DELETE FROM "couponRedemption"
+WHERE "orderId" = $1
+RETURNING *;
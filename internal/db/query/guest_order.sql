@@ -0,0 +1,19 @@
+-- name: CreateGuestOrder :one
+INSERT INTO "guestOrder" (
+    "orderId",
+    email,
+    name,
+    line1,
+    line2,
+    city,
+    "postalCode",
+    country,
+    state
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetGuestOrder :one
+SELECT * FROM "guestOrder"
+WHERE "orderId" = $1
+LIMIT 1;
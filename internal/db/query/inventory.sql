@@ -0,0 +1,46 @@
+-- name: CreateLowStockAlert :one
+INSERT INTO "lowStockAlert" (
+    id,
+    "productId",
+    stock,
+    threshold
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT ("productId") WHERE "resolvedAt" IS NULL DO NOTHING
+RETURNING *;
+
+-- name: ResolveLowStockAlerts :execrows
+UPDATE "lowStockAlert"
+SET "resolvedAt" = NOW()
+WHERE "productId" = $1 AND "resolvedAt" IS NULL;
+
+-- name: GetOneLowStockAlert :one
+SELECT * FROM "lowStockAlert"
+WHERE id = $1
+LIMIT 1;
+
+-- name: MarkLowStockAlertNotified :one
+UPDATE "lowStockAlert"
+SET "notifiedAt" = sqlc.arg('notifiedAt')
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: GetLowStockProducts :many
+SELECT
+    "product".id,
+    "product".name,
+    "product".sku,
+    "product".category,
+    "product".stock,
+    "product"."reorderThreshold",
+    "lowStockAlert".id AS "alertId",
+    "lowStockAlert"."createdAt" AS "lowSince",
+    "lowStockAlert"."notifiedAt"
+FROM "product"
+LEFT JOIN "lowStockAlert" ON "lowStockAlert"."productId" = "product".id AND "lowStockAlert"."resolvedAt" IS NULL
+WHERE "product".stock <= "product"."reorderThreshold"
+    AND "product"."deletedAt" IS NULL
+    AND (sqlc.arg('category')::VARCHAR = '' OR "product".category = sqlc.arg('category'))
+ORDER BY "product".stock - "product"."reorderThreshold", "product".name
+LIMIT sqlc.arg('pageSize');
@@ -0,0 +1,101 @@
+-- name: CreateJob :one
+INSERT INTO "job" (
+    id,
+    kind,
+    payload,
+    "maxAttempts",
+    "runAt",
+    "idempotencyKey"
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT ("idempotencyKey") WHERE "idempotencyKey" <> '' DO NOTHING
+RETURNING *;
+
+-- name: ClaimJobs :many
+UPDATE "job"
+SET
+    status = 'RUNNING',
+    attempts = attempts + 1,
+    "lockedAt" = sqlc.arg('now'),
+    "lockedBy" = sqlc.arg('lockedBy'),
+    "updatedAt" = NOW()
+WHERE id IN (
+    SELECT id FROM "job"
+    WHERE status = 'PENDING' AND "runAt" <= sqlc.arg('now')
+    ORDER BY "runAt"
+    LIMIT sqlc.arg('batchSize')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteJob :one
+UPDATE "job"
+SET
+    status = 'SUCCEEDED',
+    "lockedAt" = NULL,
+    "completedAt" = NOW(),
+    "updatedAt" = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: RetryJob :one
+UPDATE "job"
+SET
+    status = 'PENDING',
+    "runAt" = sqlc.arg('runAt'),
+    "lastError" = sqlc.arg('lastError'),
+    "lockedAt" = NULL,
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: KillJob :one
+UPDATE "job"
+SET
+    status = 'DEAD',
+    "lastError" = sqlc.arg('lastError'),
+    "lockedAt" = NULL,
+    "completedAt" = NOW(),
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: RequeueStaleJobs :execrows
+UPDATE "job"
+SET
+    status = 'PENDING',
+    "lockedAt" = NULL,
+    "lastError" = 'worker stopped while running the job',
+    "updatedAt" = NOW()
+WHERE status = 'RUNNING' AND "lockedAt" < sqlc.arg('lockedBefore');
+
+-- name: ResurrectJob :one
+UPDATE "job"
+SET
+    status = 'PENDING',
+    attempts = 0,
+    "runAt" = sqlc.arg('runAt'),
+    "completedAt" = NULL,
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id') AND status = 'DEAD'
+RETURNING *;
+
+-- name: GetOneJob :one
+SELECT * FROM "job"
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetAllJob :many
+SELECT * FROM "job"
+WHERE sqlc.arg('status')::text = '' OR status::text = sqlc.arg('status')::text
+ORDER BY "createdAt" DESC
+LIMIT sqlc.arg('pageSize');
+
+-- name: GetJobStats :many
+SELECT
+    status,
+    COUNT(*) AS count
+FROM "job"
+GROUP BY status
+ORDER BY status;
@@ -0,0 +1,67 @@
+-- name: GetNotificationPreference :one
+SELECT * FROM "notificationPreference"
+WHERE "userId" = $1;
+
+-- name: UpsertNotificationPreference :one
+INSERT INTO "notificationPreference" (
+    "userId",
+    "orderPlaced",
+    "orderCancelled",
+    "orderCompleted",
+    "orderShipped"
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT ("userId") DO UPDATE
+SET
+    "orderPlaced" = EXCLUDED."orderPlaced",
+    "orderCancelled" = EXCLUDED."orderCancelled",
+    "orderCompleted" = EXCLUDED."orderCompleted",
+    "orderShipped" = EXCLUDED."orderShipped",
+    "updatedAt" = NOW()
+RETURNING *;
+
+-- name: CreateNotification :one
+INSERT INTO "notification" (
+    id,
+    "userId",
+    "orderId",
+    "eventId",
+    kind,
+    email
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT ("eventId", kind) DO NOTHING
+RETURNING *;
+
+-- name: GetOneNotification :one
+SELECT * FROM "notification"
+WHERE id = $1;
+
+-- name: RecordNotificationAttempt :one
+UPDATE "notification"
+SET
+    status = sqlc.arg('status'),
+    attempts = attempts + 1,
+    "lastError" = sqlc.arg('lastError'),
+    "sentAt" = sqlc.arg('sentAt'),
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: GetUserEmail :one
+SELECT email FROM "user"
+WHERE id = $1;
+
+-- name: GetOrderItemDetails :many
+SELECT
+    "orderItem"."productId",
+    COALESCE("product".name, '')::VARCHAR AS "productName",
+    "orderItem".quantity,
+    "orderItem".price,
+    "orderItem".tax
+FROM "orderItem"
+LEFT JOIN "product" ON "product".id = "orderItem"."productId"
+WHERE "orderItem"."orderId" = $1
+ORDER BY "orderItem"."createdAt";
@@ -2,9 +2,18 @@
 INSERT INTO "order" (
     id,
     "userId",
-    total
+    subtotal,
+    discount,
+    tax,
+    total,
+    "couponId",
+    "shippingRegion",
+    "shippingMethodId",
+    "shippingMethod",
+    "shippingCost",
+    status
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 ) RETURNING *;
 
 -- name: GetOrderById :one
@@ -23,17 +32,43 @@ WHERE "orderId" = $1;
 UPDATE "order"
 SET
     status = 'CANCELLED',
-    updated_at = NOW()
-WHERE id = $1 AND "userId" = $2 AND status = 'PENDING'
+    "updatedAt" = NOW()
+WHERE id = $1 AND "userId" = $2 AND status IN ('PENDING', 'BACKORDERED')
 RETURNING *;
 
 -- name: UpdateOrderStatus :one
 UPDATE "order"
 SET
     status = sqlc.arg('status'),
-    updated_at = NOW()
+    "updatedAt" = NOW()
 WHERE id = sqlc.arg('id')
 RETURNING *;
 
 -- name: GetAllProductInOrder :many
-SELECT "orderItem"."productId", "orderItem"."quantity" FROM "orderItem" WHERE "orderId" = $1;
+SELECT "orderItem"."productId", ("orderItem"."quantity" - "orderItem"."backordered")::INT AS "quantity" FROM "orderItem" WHERE "orderId" = $1;
+
+-- name: GetBackorderedItemsForUpdate :many
+SELECT
+    "orderItem".id,
+    "orderItem"."orderId",
+    "order"."userId",
+    "orderItem".backordered
+FROM "orderItem"
+JOIN "order" ON "order".id = "orderItem"."orderId"
+WHERE "orderItem"."productId" = $1
+    AND "orderItem".backordered > 0
+    AND "order".status = 'BACKORDERED'
+ORDER BY "order"."createdAt", "orderItem".id
+FOR UPDATE OF "orderItem";
+
+-- name: UpdateOrderItemBackordered :one
+UPDATE "orderItem"
+SET
+    backordered = sqlc.arg('backordered'),
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: CountBackorderedItems :one
+SELECT COUNT(*) FROM "orderItem"
+WHERE "orderId" = $1 AND backordered > 0;
@@ -0,0 +1,14 @@
+-- name: GetOrderStatusEventsAfter :many
+SELECT * FROM "orderStatusEvent"
+WHERE "userId" = sqlc.arg('userId') AND id > sqlc.arg('afterId')
+ORDER BY id
+LIMIT sqlc.arg('pageSize');
+
+-- name: GetOrderStatusEventsSince :many
+SELECT * FROM "orderStatusEvent"
+WHERE id > sqlc.arg('afterId')
+ORDER BY id
+LIMIT sqlc.arg('pageSize');
+
+-- name: GetLastOrderStatusEventId :one
+SELECT COALESCE(MAX(id), 0)::BIGINT AS id FROM "orderStatusEvent";
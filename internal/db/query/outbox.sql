@@ -0,0 +1,48 @@
+-- name: CreateOutboxEvent :one
+INSERT INTO "outboxEvent" (
+    id,
+    type,
+    payload
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: ClaimOutboxEvents :many
+UPDATE "outboxEvent"
+SET
+    attempts = attempts + 1,
+    "nextAttemptAt" = sqlc.arg('leaseUntil')
+WHERE id IN (
+    SELECT id FROM "outboxEvent"
+    WHERE "dispatchedAt" IS NULL AND "failedAt" IS NULL AND "nextAttemptAt" <= sqlc.arg('now')
+    ORDER BY "createdAt"
+    LIMIT sqlc.arg('batchSize')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkOutboxEventDispatched :one
+UPDATE "outboxEvent"
+SET
+    "dispatchedAt" = NOW(),
+    "lastError" = ''
+WHERE id = $1
+RETURNING *;
+
+-- name: RetryOutboxEvent :one
+UPDATE "outboxEvent"
+SET
+    "nextAttemptAt" = sqlc.arg('nextAttemptAt'),
+    "lastError" = sqlc.arg('lastError'),
+    "deliveredSinks" = sqlc.arg('deliveredSinks')
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: FailOutboxEvent :one
+UPDATE "outboxEvent"
+SET
+    "failedAt" = NOW(),
+    "lastError" = sqlc.arg('lastError'),
+    "deliveredSinks" = sqlc.arg('deliveredSinks')
+WHERE id = sqlc.arg('id')
+RETURNING *;
@@ -5,9 +5,16 @@ INSERT INTO "product" (
     description,
     price,
     stock,
-    "createdBy"
+    category,
+    "taxClass",
+    weight,
+    "createdBy",
+    sku,
+    "reorderThreshold",
+    "backorderPolicy",
+    "availableAt"
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
 ) RETURNING *;
 
 -- name: GetAllProduct :many
@@ -19,8 +26,33 @@ SELECT
     stock,
     "createdBy",
     "createdAt",
-    "updatedAt"
-FROM "product";
+    "updatedAt",
+    category,
+    "taxClass",
+    weight,
+    sku,
+    "reorderThreshold",
+    "backorderPolicy",
+    "availableAt",
+    "deletedAt",
+    version,
+    "compareAtPrice",
+    COALESCE("rating"."ratingAverage", 0)::FLOAT AS "ratingAverage",
+    COALESCE("rating"."ratingCount", 0)::INT AS "ratingCount"
+FROM "product"
+LEFT JOIN (
+    SELECT
+        "productId",
+        ROUND(AVG(rating)::NUMERIC, 2)::FLOAT AS "ratingAverage",
+        COUNT(*)::INT AS "ratingCount"
+    FROM "productReview"
+    WHERE status = 'APPROVED'
+    GROUP BY "productId"
+) AS "rating" ON "rating"."productId" = "product".id
+WHERE "deletedAt" IS NULL
+ORDER BY
+    CASE WHEN sqlc.arg('sort')::TEXT = 'rating' THEN COALESCE("rating"."ratingAverage", 0) END DESC,
+    CASE WHEN sqlc.arg('sort')::TEXT = 'rating' THEN COALESCE("rating"."ratingCount", 0) END DESC;
 
 -- name: GetOneProduct :one
 SELECT
@@ -31,14 +63,47 @@ SELECT
     stock,
     "createdBy",
     "createdAt",
-    "updatedAt"
+    "updatedAt",
+    category,
+    "taxClass",
+    weight,
+    sku,
+    "reorderThreshold",
+    "backorderPolicy",
+    "availableAt",
+    "deletedAt",
+    version,
+    "compareAtPrice",
+    COALESCE("rating"."ratingAverage", 0)::FLOAT AS "ratingAverage",
+    COALESCE("rating"."ratingCount", 0)::INT AS "ratingCount"
 FROM "product"
+LEFT JOIN (
+    SELECT
+        "productId",
+        ROUND(AVG(rating)::NUMERIC, 2)::FLOAT AS "ratingAverage",
+        COUNT(*)::INT AS "ratingCount"
+    FROM "productReview"
+    WHERE status = 'APPROVED'
+    GROUP BY "productId"
+) AS "rating" ON "rating"."productId" = "product".id
 WHERE id = $1
 LIMIT 1;
 
--- name: DeleteOneProduct :exec
-DELETE FROM product
-WHERE id = $1;
+-- name: ArchiveProduct :one
+UPDATE product
+SET
+    "deletedAt" = NOW(),
+    "updatedAt" = NOW()
+WHERE id = $1 AND "deletedAt" IS NULL
+RETURNING *;
+
+-- name: RestoreProduct :one
+UPDATE product
+SET
+    "deletedAt" = NULL,
+    "updatedAt" = NOW()
+WHERE id = $1 AND "deletedAt" IS NOT NULL
+RETURNING *;
 
 -- name: UpdateOneProduct :one
 UPDATE product
@@ -47,7 +112,16 @@ SET
     description = sqlc.arg('description'),
     price = sqlc.arg('price'),
     stock = sqlc.arg('stock'),
-    updated_at = NOW()
+    category = sqlc.arg('category'),
+    "taxClass" = sqlc.arg('taxClass'),
+    weight = sqlc.arg('weight'),
+    sku = sqlc.arg('sku'),
+    "reorderThreshold" = sqlc.arg('reorderThreshold'),
+    "backorderPolicy" = sqlc.arg('backorderPolicy'),
+    "availableAt" = sqlc.arg('availableAt'),
+    "compareAtPrice" = sqlc.narg('compareAtPrice'),
+    version = version + 1,
+    "updatedAt" = NOW()
 WHERE id = sqlc.arg('id')
 RETURNING *;
 
@@ -55,7 +129,7 @@ RETURNING *;
 UPDATE product
 SET
     stock = stock - $2,
-    updated_at = NOW()
+    "updatedAt" = NOW()
 WHERE id = $1
 RETURNING *;
 
@@ -63,6 +137,64 @@ RETURNING *;
 SELECT
     id,
     price,
-    stock
+    stock,
+    category,
+    "taxClass",
+    weight,
+    "backorderPolicy"
 FROM product
-WHERE id = ANY($1::UUID[]);
+WHERE id = ANY($1::UUID[]) AND "deletedAt" IS NULL;
+
+-- name: GetProductByNameForUpdate :one
+SELECT * FROM "product"
+WHERE name = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: GetProductBySkuForUpdate :one
+SELECT * FROM "product"
+WHERE sku = $1 AND sku <> ''
+LIMIT 1
+FOR UPDATE;
+
+-- name: GetProductIdsForUpdate :many
+SELECT id FROM "product"
+WHERE id = ANY(sqlc.arg('productIds')::UUID[])
+ORDER BY id
+FOR UPDATE;
+
+-- name: GetProductPage :many
+SELECT
+    id,
+    name,
+    description,
+    price,
+    stock,
+    "createdBy",
+    "createdAt",
+    "updatedAt",
+    category,
+    "taxClass",
+    weight,
+    sku,
+    "reorderThreshold",
+    "backorderPolicy",
+    "availableAt",
+    "deletedAt",
+    version,
+    "compareAtPrice",
+    COALESCE("rating"."ratingAverage", 0)::FLOAT AS "ratingAverage",
+    COALESCE("rating"."ratingCount", 0)::INT AS "ratingCount"
+FROM "product"
+LEFT JOIN (
+    SELECT
+        "productId",
+        ROUND(AVG(rating)::NUMERIC, 2)::FLOAT AS "ratingAverage",
+        COUNT(*)::INT AS "ratingCount"
+    FROM "productReview"
+    WHERE status = 'APPROVED'
+    GROUP BY "productId"
+) AS "rating" ON "rating"."productId" = "product".id
+WHERE id > sqlc.arg('afterId') AND "deletedAt" IS NULL
+ORDER BY id
+LIMIT sqlc.arg('pageSize');
\ No newline at end of file
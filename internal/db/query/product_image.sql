@@ -0,0 +1,49 @@
+-- name: CreateProductImage :one
+INSERT INTO "productImage" (
+    id,
+    "productId",
+    key,
+    "contentType",
+    size,
+    position,
+    "isPrimary",
+    "createdBy"
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetOneProductImage :one
+SELECT * FROM "productImage"
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetProductImageByProductIds :many
+SELECT * FROM "productImage"
+WHERE "productId" = ANY(sqlc.arg('productIds')::UUID[])
+ORDER BY "productId", position, "createdAt";
+
+-- name: GetProductForUpdate :one
+SELECT * FROM "product"
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: UpdateProductImagePosition :one
+UPDATE "productImage"
+SET
+    position = $2,
+    "isPrimary" = $3,
+    "updatedAt" = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ClearPrimaryProductImage :exec
+UPDATE "productImage"
+SET
+    "isPrimary" = FALSE,
+    "updatedAt" = NOW()
+WHERE "productId" = $1 AND "isPrimary";
+
+-- name: DeleteOneProductImage :exec
+DELETE FROM "productImage"
+WHERE id = $1;
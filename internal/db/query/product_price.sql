@@ -0,0 +1,46 @@
+-- name: CreateProductPrice :one
+INSERT INTO "productPrice" (
+    id,
+    "productId",
+    price,
+    "compareAtPrice",
+    "effectiveFrom",
+    "effectiveTo",
+    "createdBy"
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetProductPrices :many
+SELECT * FROM "productPrice"
+WHERE "productId" = sqlc.arg('productId')
+ORDER BY "effectiveFrom" DESC, "createdAt" DESC
+LIMIT sqlc.arg('pageSize');
+
+-- name: GetEffectiveProductPrice :one
+SELECT * FROM "productPrice"
+WHERE "productId" = sqlc.arg('productId')
+    AND "cancelledAt" IS NULL
+    AND "effectiveFrom" <= sqlc.arg('at')
+    AND ("effectiveTo" IS NULL OR "effectiveTo" > sqlc.arg('at'))
+ORDER BY "effectiveFrom" DESC, "createdAt" DESC
+LIMIT 1;
+
+-- name: CancelProductPrice :one
+UPDATE "productPrice"
+SET "cancelledAt" = NOW()
+WHERE id = sqlc.arg('id')
+    AND "productId" = sqlc.arg('productId')
+    AND "cancelledAt" IS NULL
+    AND ("effectiveTo" IS NULL OR "effectiveTo" > NOW())
+RETURNING *;
+
+-- name: UpdateProductPrice :one
+UPDATE product
+SET
+    price = sqlc.arg('price'),
+    "compareAtPrice" = sqlc.narg('compareAtPrice'),
+    version = version + 1,
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
@@ -0,0 +1,80 @@
+-- name: CreatePromotion :one
+INSERT INTO "promotion" (
+    id,
+    name,
+    kind,
+    priority,
+    stackable,
+    "productIds",
+    categories,
+    "buyQuantity",
+    "getQuantity",
+    percent,
+    "bundlePrice",
+    tiers,
+    "startsAt",
+    "expiresAt",
+    active,
+    "createdBy"
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+) RETURNING *;
+
+-- name: GetAllPromotion :many
+SELECT * FROM "promotion"
+ORDER BY priority DESC, "createdAt" DESC;
+
+-- name: GetOnePromotion :one
+SELECT * FROM "promotion"
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetActivePromotions :many
+SELECT * FROM "promotion"
+WHERE active = TRUE
+    AND ("startsAt" IS NULL OR "startsAt" <= NOW())
+    AND ("expiresAt" IS NULL OR "expiresAt" > NOW())
+ORDER BY priority DESC, "createdAt";
+
+-- name: UpdateOnePromotion :one
+UPDATE "promotion"
+SET
+    name = sqlc.arg('name'),
+    kind = sqlc.arg('kind'),
+    priority = sqlc.arg('priority'),
+    stackable = sqlc.arg('stackable'),
+    "productIds" = sqlc.arg('productIds'),
+    categories = sqlc.arg('categories'),
+    "buyQuantity" = sqlc.arg('buyQuantity'),
+    "getQuantity" = sqlc.arg('getQuantity'),
+    percent = sqlc.arg('percent'),
+    "bundlePrice" = sqlc.arg('bundlePrice'),
+    tiers = sqlc.arg('tiers'),
+    "startsAt" = sqlc.arg('startsAt'),
+    "expiresAt" = sqlc.arg('expiresAt'),
+    active = sqlc.arg('active'),
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: DeleteOnePromotion :one
+DELETE FROM "promotion"
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateOrderPromotion :one
+INSERT INTO "orderPromotion" (
+    id,
+    "orderId",
+    "promotionId",
+    name,
+    kind,
+    discount
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetOrderPromotionsByOrderIds :many
+SELECT * FROM "orderPromotion"
+WHERE "orderId" = ANY(sqlc.arg('orderIds')::UUID[])
+ORDER BY "createdAt";
@@ -0,0 +1,92 @@
+-- name: CreateReturnRequest :one
+INSERT INTO "returnRequest" (
+    id,
+    "orderId",
+    "userId",
+    "refundAmount"
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: CreateReturnItem :one
+INSERT INTO "returnItem" (
+    id,
+    "returnId",
+    "orderItemId",
+    "productId",
+    quantity,
+    reason
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: CreateReturnEvent :one
+INSERT INTO "returnEvent" (
+    id,
+    "returnId",
+    "fromStatus",
+    "toStatus",
+    note,
+    "actorId"
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetAllReturnRequest :many
+SELECT * FROM "returnRequest"
+ORDER BY "createdAt" DESC;
+
+-- name: GetReturnRequestByOrderId :many
+SELECT * FROM "returnRequest"
+WHERE "orderId" = $1
+ORDER BY "createdAt";
+
+-- name: GetOneReturnRequest :one
+SELECT * FROM "returnRequest"
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetReturnRequestForUpdate :one
+SELECT * FROM "returnRequest"
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetReturnItemByReturnIds :many
+SELECT * FROM "returnItem"
+WHERE "returnId" = ANY(sqlc.arg('returnIds')::UUID[])
+ORDER BY "createdAt";
+
+-- name: GetReturnEventByReturnIds :many
+SELECT * FROM "returnEvent"
+WHERE "returnId" = ANY(sqlc.arg('returnIds')::UUID[])
+ORDER BY "createdAt";
+
+-- name: UpdateReturnRequestStatus :one
+UPDATE "returnRequest"
+SET
+    status = sqlc.arg('status'),
+    "refundReference" = sqlc.arg('refundReference'),
+    "refundedAt" = sqlc.arg('refundedAt'),
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: GetOrderItemReturnableQuantity :many
+SELECT
+    "orderItem".id,
+    "orderItem"."productId",
+    "orderItem".quantity,
+    "orderItem".price,
+    COALESCE((
+        SELECT SUM("shipmentItem".quantity)
+        FROM "shipmentItem"
+        WHERE "shipmentItem"."orderItemId" = "orderItem".id
+    ), 0)::INT AS shipped,
+    COALESCE((
+        SELECT SUM("returnItem".quantity)
+        FROM "returnItem"
+        JOIN "returnRequest" ON "returnRequest".id = "returnItem"."returnId"
+        WHERE "returnItem"."orderItemId" = "orderItem".id AND "returnRequest".status <> 'REJECTED'
+    ), 0)::INT AS returned
+FROM "orderItem"
+WHERE "orderItem"."orderId" = $1;
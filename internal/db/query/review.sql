@@ -0,0 +1,53 @@
+-- name: CreateProductReview :one
+INSERT INTO "productReview" (
+    id,
+    "productId",
+    "userId",
+    rating,
+    title,
+    body
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: HasPurchasedProduct :one
+SELECT EXISTS (
+    SELECT 1
+    FROM "orderItem"
+    JOIN "order" ON "order".id = "orderItem"."orderId"
+    WHERE "order"."userId" = sqlc.arg('userId')
+        AND "orderItem"."productId" = sqlc.arg('productId')
+        AND "order".status IN ('COMPLETED', 'PARTIALLY_SHIPPED', 'SHIPPED', 'DELIVERED')
+);
+
+-- name: GetProductReviews :many
+SELECT * FROM "productReview"
+WHERE "productId" = sqlc.arg('productId') AND status = 'APPROVED'
+ORDER BY "createdAt" DESC
+LIMIT sqlc.arg('pageSize');
+
+-- name: GetAllProductReview :many
+SELECT * FROM "productReview"
+WHERE sqlc.narg('status')::review_status IS NULL OR status = sqlc.narg('status')
+ORDER BY "createdAt" DESC
+LIMIT sqlc.arg('pageSize');
+
+-- name: GetOneProductReview :one
+SELECT * FROM "productReview"
+WHERE id = $1
+LIMIT 1;
+
+-- name: UpdateProductReviewStatus :one
+UPDATE "productReview"
+SET
+    status = sqlc.arg('status'),
+    "moderatedBy" = sqlc.arg('moderatedBy'),
+    "moderatedAt" = NOW(),
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: DeleteProductReview :one
+DELETE FROM "productReview"
+WHERE id = $1
+RETURNING *;
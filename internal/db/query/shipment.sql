@@ -0,0 +1,66 @@
+-- name: CreateShipment :one
+INSERT INTO "shipment" (
+    id,
+    "orderId",
+    carrier,
+    "trackingNumber",
+    "createdBy"
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: CreateShipmentItem :one
+INSERT INTO "shipmentItem" (
+    id,
+    "shipmentId",
+    "orderItemId",
+    "productId",
+    quantity
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetOneShipment :one
+SELECT * FROM "shipment"
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetShipmentByOrderId :many
+SELECT * FROM "shipment"
+WHERE "orderId" = $1
+ORDER BY "shippedAt";
+
+-- name: GetShipmentItemByShipmentIds :many
+SELECT * FROM "shipmentItem"
+WHERE "shipmentId" = ANY(sqlc.arg('shipmentIds')::UUID[])
+ORDER BY "createdAt";
+
+-- name: UpdateOneShipment :one
+UPDATE "shipment"
+SET
+    carrier = sqlc.arg('carrier'),
+    "trackingNumber" = sqlc.arg('trackingNumber'),
+    "deliveredAt" = sqlc.arg('deliveredAt'),
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: CountUndeliveredShipment :one
+SELECT COUNT(*) FROM "shipment"
+WHERE "orderId" = $1 AND "deliveredAt" IS NULL;
+
+-- name: GetOrderForUpdate :one
+SELECT * FROM "order"
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetOrderItemShippedQuantity :many
+SELECT
+    "orderItem".id,
+    "orderItem"."productId",
+    "orderItem".quantity,
+    COALESCE(SUM("shipmentItem".quantity), 0)::INT AS shipped
+FROM "orderItem"
+LEFT JOIN "shipmentItem" ON "shipmentItem"."orderItemId" = "orderItem".id
+WHERE "orderItem"."orderId" = $1
+GROUP BY "orderItem".id;
@@ -0,0 +1,75 @@
+-- name: CreateShippingZone :one
+INSERT INTO "shippingZone" (
+    id,
+    name,
+    regions
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetAllShippingZone :many
+SELECT * FROM "shippingZone"
+ORDER BY name;
+
+-- name: GetOneShippingZone :one
+SELECT * FROM "shippingZone"
+WHERE id = $1
+LIMIT 1;
+
+-- name: UpdateOneShippingZone :one
+UPDATE "shippingZone"
+SET
+    name = sqlc.arg('name'),
+    regions = sqlc.arg('regions'),
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: DeleteOneShippingZone :exec
+DELETE FROM "shippingZone"
+WHERE id = $1;
+
+-- name: CreateShippingMethod :one
+INSERT INTO "shippingMethod" (
+    id,
+    "zoneId",
+    name,
+    "rateType",
+    rate,
+    "ratePerKg",
+    "freeOver",
+    active
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetAllShippingMethod :many
+SELECT * FROM "shippingMethod"
+ORDER BY "zoneId", name;
+
+-- name: GetOneShippingMethod :one
+SELECT * FROM "shippingMethod"
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetActiveShippingMethodByZoneId :many
+SELECT * FROM "shippingMethod"
+WHERE "zoneId" = $1 AND active = TRUE
+ORDER BY name;
+
+-- name: UpdateOneShippingMethod :one
+UPDATE "shippingMethod"
+SET
+    name = sqlc.arg('name'),
+    "rateType" = sqlc.arg('rateType'),
+    rate = sqlc.arg('rate'),
+    "ratePerKg" = sqlc.arg('ratePerKg'),
+    "freeOver" = sqlc.arg('freeOver'),
+    active = sqlc.arg('active'),
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: DeleteOneShippingMethod :exec
+DELETE FROM "shippingMethod"
+WHERE id = $1;
@@ -0,0 +1,49 @@
+-- name: CreateStockMovement :one
+INSERT INTO "stockMovement" (
+    "productId",
+    quantity,
+    "stockAfter",
+    kind,
+    "orderId",
+    "returnId",
+    "createdBy",
+    note,
+    "warehouseId"
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetStockMovements :many
+SELECT * FROM "stockMovement"
+WHERE "productId" = sqlc.arg('productId')
+    AND (sqlc.arg('beforeId')::BIGINT = 0 OR id < sqlc.arg('beforeId'))
+ORDER BY id DESC
+LIMIT sqlc.arg('pageSize');
+
+-- name: GetLedgerStock :one
+SELECT COALESCE(SUM(quantity), 0)::INT AS "ledgerStock"
+FROM "stockMovement"
+WHERE "productId" = $1;
+
+-- name: GetStockDiscrepancies :many
+SELECT
+    "product".id,
+    "product".name,
+    "product".sku,
+    "product".stock,
+    COALESCE("ledger"."ledgerStock", 0)::INT AS "ledgerStock",
+    COALESCE("warehouses"."warehouseStock", 0)::INT AS "warehouseStock"
+FROM "product"
+LEFT JOIN (
+    SELECT "productId", SUM(quantity) AS "ledgerStock"
+    FROM "stockMovement"
+    GROUP BY "productId"
+) AS "ledger" ON "ledger"."productId" = "product".id
+LEFT JOIN (
+    SELECT "productId", SUM(stock) AS "warehouseStock"
+    FROM "warehouseStock"
+    GROUP BY "productId"
+) AS "warehouses" ON "warehouses"."productId" = "product".id
+WHERE "product".stock <> COALESCE("ledger"."ledgerStock", 0)
+    OR "product".stock <> COALESCE("warehouses"."warehouseStock", 0)
+ORDER BY "product".name;
@@ -0,0 +1,58 @@
+-- name: CreateTaxRule :one
+INSERT INTO "taxRule" (
+    id,
+    name,
+    "taxClass",
+    region,
+    rate,
+    inclusive
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetAllTaxRule :many
+SELECT * FROM "taxRule"
+ORDER BY "taxClass", region, name;
+
+-- name: GetOneTaxRule :one
+SELECT * FROM "taxRule"
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetTaxRuleByTaxClass :many
+SELECT * FROM "taxRule"
+WHERE "taxClass" = ANY(sqlc.arg('taxClasses')::VARCHAR[]);
+
+-- name: UpdateOneTaxRule :one
+UPDATE "taxRule"
+SET
+    name = sqlc.arg('name'),
+    "taxClass" = sqlc.arg('taxClass'),
+    region = sqlc.arg('region'),
+    rate = sqlc.arg('rate'),
+    inclusive = sqlc.arg('inclusive'),
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: DeleteOneTaxRule :exec
+DELETE FROM "taxRule"
+WHERE id = $1;
+
+-- name: CreateOrderTax :one
+INSERT INTO "orderTax" (
+    id,
+    "orderId",
+    "taxRuleId",
+    name,
+    rate,
+    inclusive,
+    amount
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetOrderTaxByOrderIds :many
+SELECT * FROM "orderTax"
+WHERE "orderId" = ANY(sqlc.arg('orderIds')::UUID[])
+ORDER BY "createdAt";
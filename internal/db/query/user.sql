@@ -17,7 +17,25 @@ INSERT INTO "user" (
     $1, $2, $3, true
 ) RETURNING *;
 
+-- name: CreateGuestUser :one
+INSERT INTO "user" (
+    id,
+    email,
+    password,
+    guest
+) VALUES (
+    $1, $2, '', true
+) RETURNING *;
+
 -- name: GetUserById :one
-SELECT id, email, password, admin
+SELECT id, email, password, admin, guest
 FROM "user"
 WHERE email = $1 LIMIT 1;
+
+-- name: ClaimGuestUser :one
+UPDATE "user"
+SET password = $2,
+    guest = false,
+    "updatedAt" = NOW()
+WHERE id = $1 AND guest
+RETURNING *;
@@ -0,0 +1,148 @@
+-- name: CreateWarehouse :one
+INSERT INTO "warehouse" (
+    id,
+    name,
+    priority,
+    active
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetAllWarehouse :many
+SELECT * FROM "warehouse"
+ORDER BY priority, name;
+
+-- name: GetOneWarehouse :one
+SELECT * FROM "warehouse"
+WHERE id = $1
+LIMIT 1;
+
+-- name: UpdateWarehouse :one
+UPDATE "warehouse"
+SET
+    name = sqlc.arg('name'),
+    priority = sqlc.arg('priority'),
+    active = sqlc.arg('active'),
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: GetPrimaryWarehouse :one
+SELECT * FROM "warehouse"
+WHERE active
+ORDER BY priority, name
+LIMIT 1;
+
+-- name: GetWarehouseStockForUpdate :one
+SELECT * FROM "warehouseStock"
+WHERE "warehouseId" = $1 AND "productId" = $2
+LIMIT 1
+FOR UPDATE;
+
+-- name: AddWarehouseStock :one
+INSERT INTO "warehouseStock" (
+    "warehouseId",
+    "productId",
+    stock
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT ("warehouseId", "productId") DO UPDATE
+SET
+    stock = "warehouseStock".stock + EXCLUDED.stock,
+    "updatedAt" = NOW()
+RETURNING *;
+
+-- name: GetWarehouseStock :many
+SELECT
+    "product".id AS "productId",
+    "product".name,
+    "product".sku,
+    "warehouseStock".stock,
+    "warehouseStock"."updatedAt"
+FROM "warehouseStock"
+JOIN "product" ON "product".id = "warehouseStock"."productId"
+WHERE "warehouseStock"."warehouseId" = $1
+ORDER BY "product".name;
+
+-- name: GetProductWarehouseStock :many
+SELECT
+    "warehouse".id AS "warehouseId",
+    "warehouse".name AS "warehouseName",
+    "warehouse".priority,
+    "warehouse".active,
+    "warehouseStock".stock
+FROM "warehouseStock"
+JOIN "warehouse" ON "warehouse".id = "warehouseStock"."warehouseId"
+WHERE "warehouseStock"."productId" = $1
+ORDER BY "warehouse".priority, "warehouse".name;
+
+-- name: GetAllocatableStock :many
+SELECT
+    "warehouseStock"."warehouseId",
+    "warehouseStock"."productId",
+    "warehouseStock".stock
+FROM "warehouseStock"
+JOIN "warehouse" ON "warehouse".id = "warehouseStock"."warehouseId"
+WHERE "warehouse".active
+    AND "warehouseStock"."productId" = ANY(sqlc.arg('productIds')::UUID[])
+    AND "warehouseStock".stock > 0
+ORDER BY "warehouse".priority, "warehouse".name, "warehouseStock"."productId"
+FOR UPDATE OF "warehouseStock";
+
+-- name: GetAvailableStock :many
+SELECT
+    "warehouseStock"."warehouseId",
+    "warehouseStock"."productId",
+    "warehouseStock".stock
+FROM "warehouseStock"
+JOIN "warehouse" ON "warehouse".id = "warehouseStock"."warehouseId"
+WHERE "warehouse".active
+    AND "warehouseStock"."productId" = ANY(sqlc.arg('productIds')::UUID[])
+    AND "warehouseStock".stock > 0
+ORDER BY "warehouse".priority, "warehouse".name, "warehouseStock"."productId";
+
+-- name: CreateOrderAllocation :one
+INSERT INTO "orderAllocation" (
+    id,
+    "orderId",
+    "orderItemId",
+    "productId",
+    "warehouseId",
+    quantity
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetOrderAllocations :many
+SELECT
+    "orderAllocation".id,
+    "orderAllocation"."orderItemId",
+    "orderAllocation"."productId",
+    "orderAllocation"."warehouseId",
+    "warehouse".name AS "warehouseName",
+    "orderAllocation".quantity
+FROM "orderAllocation"
+JOIN "warehouse" ON "warehouse".id = "orderAllocation"."warehouseId"
+WHERE "orderAllocation"."orderId" = $1
+ORDER BY "warehouse".priority, "warehouse".name, "orderAllocation"."productId";
+
+-- name: CreateStockTransfer :one
+INSERT INTO "stockTransfer" (
+    id,
+    "productId",
+    "fromWarehouseId",
+    "toWarehouseId",
+    quantity,
+    note,
+    "createdBy"
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetStockTransfers :many
+SELECT * FROM "stockTransfer"
+WHERE (sqlc.narg('productId')::UUID IS NULL OR "productId" = sqlc.narg('productId'))
+    AND (sqlc.narg('warehouseId')::UUID IS NULL OR "fromWarehouseId" = sqlc.narg('warehouseId') OR "toWarehouseId" = sqlc.narg('warehouseId'))
+ORDER BY "createdAt" DESC
+LIMIT sqlc.arg('pageSize');
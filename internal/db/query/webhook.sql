@@ -0,0 +1,99 @@
+-- name: GetOutboxEventForUpdate :one
+SELECT * FROM "outboxEvent"
+WHERE id = $1
+FOR UPDATE;
+
+-- name: CountWebhookDeliveriesForEvent :one
+SELECT COUNT(*) FROM "webhookDelivery"
+WHERE "eventId" = $1;
+
+-- name: CreateWebhook :one
+INSERT INTO "webhook" (
+    id,
+    url,
+    secret,
+    events,
+    active,
+    "createdBy"
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetAllWebhook :many
+SELECT * FROM "webhook"
+ORDER BY "createdAt" DESC;
+
+-- name: GetOneWebhook :one
+SELECT * FROM "webhook"
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetWebhooksForEvent :many
+SELECT * FROM "webhook"
+WHERE active AND sqlc.arg('eventType')::text = ANY(events);
+
+-- name: UpdateOneWebhook :one
+UPDATE "webhook"
+SET
+    url = sqlc.arg('url'),
+    secret = sqlc.arg('secret'),
+    events = sqlc.arg('events'),
+    active = sqlc.arg('active'),
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: DeleteOneWebhook :one
+DELETE FROM "webhook"
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO "webhookDelivery" (
+    id,
+    "webhookId",
+    "eventId",
+    "eventType"
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetOneWebhookDelivery :one
+SELECT * FROM "webhookDelivery"
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetWebhookDeliveries :many
+SELECT * FROM "webhookDelivery"
+WHERE "webhookId" = sqlc.arg('webhookId')
+ORDER BY "createdAt" DESC
+LIMIT sqlc.arg('pageSize');
+
+-- name: GetUndeliveredWebhookDelivery :one
+SELECT
+    "webhookDelivery".id,
+    "webhookDelivery"."eventId",
+    "webhookDelivery".attempts,
+    "webhookDelivery"."eventType",
+    "webhook".url,
+    "webhook".secret,
+    "webhook".active,
+    "outboxEvent".payload,
+    "outboxEvent"."createdAt" AS "eventCreatedAt"
+FROM "webhookDelivery"
+JOIN "webhook" ON "webhook".id = "webhookDelivery"."webhookId"
+JOIN "outboxEvent" ON "outboxEvent".id = "webhookDelivery"."eventId"
+WHERE "webhookDelivery".id = $1 AND "webhookDelivery".status <> 'SUCCEEDED';
+
+-- name: RecordWebhookDeliveryAttempt :one
+UPDATE "webhookDelivery"
+SET
+    status = sqlc.arg('status'),
+    attempts = attempts + 1,
+    "responseStatus" = sqlc.arg('responseStatus'),
+    "responseBody" = sqlc.arg('responseBody'),
+    "lastError" = sqlc.arg('lastError'),
+    "deliveredAt" = sqlc.arg('deliveredAt'),
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
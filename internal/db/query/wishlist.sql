@@ -0,0 +1,89 @@
+-- name: CreateWishlist :one
+INSERT INTO "wishlist" (
+    id,
+    "userId",
+    name
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetUserWishlists :many
+SELECT * FROM "wishlist"
+WHERE "userId" = $1
+ORDER BY "createdAt";
+
+-- name: GetUserWishlist :one
+SELECT * FROM "wishlist"
+WHERE id = $1 AND "userId" = $2
+LIMIT 1;
+
+-- name: GetWishlistByShareToken :one
+SELECT * FROM "wishlist"
+WHERE "shareToken" = $1
+LIMIT 1;
+
+-- name: UpdateWishlistName :one
+UPDATE "wishlist"
+SET
+    name = sqlc.arg('name'),
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id') AND "userId" = sqlc.arg('userId')
+RETURNING *;
+
+-- name: SetWishlistShareToken :one
+UPDATE "wishlist"
+SET
+    "shareToken" = sqlc.narg('shareToken'),
+    "updatedAt" = NOW()
+WHERE id = sqlc.arg('id') AND "userId" = sqlc.arg('userId')
+RETURNING *;
+
+-- name: DeleteWishlist :one
+DELETE FROM "wishlist"
+WHERE id = $1 AND "userId" = $2
+RETURNING *;
+
+-- name: AddWishlistItem :one
+INSERT INTO "wishlistItem" (
+    id,
+    "wishlistId",
+    "productId",
+    quantity
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT ("wishlistId", "productId") DO UPDATE
+SET quantity = EXCLUDED.quantity
+RETURNING *;
+
+-- name: DeleteWishlistItem :one
+DELETE FROM "wishlistItem"
+WHERE "wishlistId" = $1 AND "productId" = $2
+RETURNING *;
+
+-- name: DeleteWishlistItems :execrows
+DELETE FROM "wishlistItem"
+WHERE "wishlistId" = sqlc.arg('wishlistId') AND "productId" = ANY(sqlc.arg('productIds')::UUID[]);
+
+-- name: GetWishlistItems :many
+SELECT
+    "wishlistItem".id,
+    "wishlistItem"."wishlistId",
+    "wishlistItem"."productId",
+    "wishlistItem".quantity,
+    "wishlistItem"."createdAt",
+    "product".name,
+    "product".price,
+    "product".stock,
+    "product"."deletedAt" IS NOT NULL AS archived
+FROM "wishlistItem"
+JOIN "product" ON "product".id = "wishlistItem"."productId"
+WHERE "wishlistItem"."wishlistId" = ANY(sqlc.arg('wishlistIds')::UUID[])
+ORDER BY "wishlistItem"."createdAt";
+
+-- name: GetProductWatchers :many
+SELECT DISTINCT "user".id, "user".email
+FROM "wishlistItem"
+JOIN "wishlist" ON "wishlist".id = "wishlistItem"."wishlistId"
+JOIN "user" ON "user".id = "wishlist"."userId"
+WHERE "wishlistItem"."productId" = $1;
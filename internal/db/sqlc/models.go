@@ -6,18 +6,239 @@ package db
 
 import (
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type BackorderPolicy string
+
+const (
+	BackorderPolicyNONE      BackorderPolicy = "NONE"
+	BackorderPolicyBACKORDER BackorderPolicy = "BACKORDER"
+	BackorderPolicyPREORDER  BackorderPolicy = "PREORDER"
+)
+
+func (e *BackorderPolicy) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BackorderPolicy(s)
+	case string:
+		*e = BackorderPolicy(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BackorderPolicy: %T", src)
+	}
+	return nil
+}
+
+type NullBackorderPolicy struct {
+	BackorderPolicy BackorderPolicy `json:"backorder_policy"`
+	Valid           bool            `json:"valid"` // Valid is true if BackorderPolicy is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBackorderPolicy) Scan(value interface{}) error {
+	if value == nil {
+		ns.BackorderPolicy, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BackorderPolicy.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBackorderPolicy) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BackorderPolicy), nil
+}
+
+type DiscountType string
+
+const (
+	DiscountTypePERCENTAGE DiscountType = "PERCENTAGE"
+	DiscountTypeFIXED      DiscountType = "FIXED"
+)
+
+func (e *DiscountType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DiscountType(s)
+	case string:
+		*e = DiscountType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DiscountType: %T", src)
+	}
+	return nil
+}
+
+type NullDiscountType struct {
+	DiscountType DiscountType `json:"discount_type"`
+	Valid        bool         `json:"valid"` // Valid is true if DiscountType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDiscountType) Scan(value interface{}) error {
+	if value == nil {
+		ns.DiscountType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DiscountType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDiscountType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DiscountType), nil
+}
+
+type JobStatus string
+
+const (
+	JobStatusPENDING   JobStatus = "PENDING"
+	JobStatusRUNNING   JobStatus = "RUNNING"
+	JobStatusSUCCEEDED JobStatus = "SUCCEEDED"
+	JobStatusDEAD      JobStatus = "DEAD"
+)
+
+func (e *JobStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = JobStatus(s)
+	case string:
+		*e = JobStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for JobStatus: %T", src)
+	}
+	return nil
+}
+
+type NullJobStatus struct {
+	JobStatus JobStatus `json:"job_status"`
+	Valid     bool      `json:"valid"` // Valid is true if JobStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullJobStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.JobStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.JobStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullJobStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.JobStatus), nil
+}
+
+type NotificationKind string
+
+const (
+	NotificationKindORDERPLACED    NotificationKind = "ORDER_PLACED"
+	NotificationKindORDERCANCELLED NotificationKind = "ORDER_CANCELLED"
+	NotificationKindORDERCOMPLETED NotificationKind = "ORDER_COMPLETED"
+	NotificationKindORDERSHIPPED   NotificationKind = "ORDER_SHIPPED"
+)
+
+func (e *NotificationKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = NotificationKind(s)
+	case string:
+		*e = NotificationKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for NotificationKind: %T", src)
+	}
+	return nil
+}
+
+type NullNotificationKind struct {
+	NotificationKind NotificationKind `json:"notification_kind"`
+	Valid            bool             `json:"valid"` // Valid is true if NotificationKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullNotificationKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.NotificationKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.NotificationKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullNotificationKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.NotificationKind), nil
+}
+
+type NotificationStatus string
+
+const (
+	NotificationStatusPENDING NotificationStatus = "PENDING"
+	NotificationStatusSENT    NotificationStatus = "SENT"
+	NotificationStatusFAILED  NotificationStatus = "FAILED"
+)
+
+func (e *NotificationStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = NotificationStatus(s)
+	case string:
+		*e = NotificationStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for NotificationStatus: %T", src)
+	}
+	return nil
+}
+
+type NullNotificationStatus struct {
+	NotificationStatus NotificationStatus `json:"notification_status"`
+	Valid              bool               `json:"valid"` // Valid is true if NotificationStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullNotificationStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.NotificationStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.NotificationStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullNotificationStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.NotificationStatus), nil
+}
+
 type OrderStatus string
 
 const (
-	OrderStatusPENDING   OrderStatus = "PENDING"
-	OrderStatusCOMPLETED OrderStatus = "COMPLETED"
-	OrderStatusCANCELLED OrderStatus = "CANCELLED"
+	OrderStatusPENDING          OrderStatus = "PENDING"
+	OrderStatusCOMPLETED        OrderStatus = "COMPLETED"
+	OrderStatusCANCELLED        OrderStatus = "CANCELLED"
+	OrderStatusPARTIALLYSHIPPED OrderStatus = "PARTIALLY_SHIPPED"
+	OrderStatusSHIPPED          OrderStatus = "SHIPPED"
+	OrderStatusDELIVERED        OrderStatus = "DELIVERED"
+	OrderStatusBACKORDERED      OrderStatus = "BACKORDERED"
 )
 
 func (e *OrderStatus) Scan(src interface{}) error {
@@ -55,34 +276,635 @@ func (ns NullOrderStatus) Value() (driver.Value, error) {
 	return string(ns.OrderStatus), nil
 }
 
-type Order struct {
+type PromotionKind string
+
+const (
+	PromotionKindBUYXGETY     PromotionKind = "BUY_X_GET_Y"
+	PromotionKindBUNDLE       PromotionKind = "BUNDLE"
+	PromotionKindQUANTITYTIER PromotionKind = "QUANTITY_TIER"
+	PromotionKindCATEGORYSALE PromotionKind = "CATEGORY_SALE"
+)
+
+func (e *PromotionKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PromotionKind(s)
+	case string:
+		*e = PromotionKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PromotionKind: %T", src)
+	}
+	return nil
+}
+
+type NullPromotionKind struct {
+	PromotionKind PromotionKind `json:"promotion_kind"`
+	Valid         bool          `json:"valid"` // Valid is true if PromotionKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPromotionKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.PromotionKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PromotionKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPromotionKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PromotionKind), nil
+}
+
+type ReturnStatus string
+
+const (
+	ReturnStatusREQUESTED ReturnStatus = "REQUESTED"
+	ReturnStatusAPPROVED  ReturnStatus = "APPROVED"
+	ReturnStatusREJECTED  ReturnStatus = "REJECTED"
+	ReturnStatusRECEIVED  ReturnStatus = "RECEIVED"
+	ReturnStatusREFUNDING ReturnStatus = "REFUNDING"
+	ReturnStatusREFUNDED  ReturnStatus = "REFUNDED"
+)
+
+func (e *ReturnStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReturnStatus(s)
+	case string:
+		*e = ReturnStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReturnStatus: %T", src)
+	}
+	return nil
+}
+
+type NullReturnStatus struct {
+	ReturnStatus ReturnStatus `json:"return_status"`
+	Valid        bool         `json:"valid"` // Valid is true if ReturnStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullReturnStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ReturnStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ReturnStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullReturnStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ReturnStatus), nil
+}
+
+type ReviewStatus string
+
+const (
+	ReviewStatusPENDING  ReviewStatus = "PENDING"
+	ReviewStatusAPPROVED ReviewStatus = "APPROVED"
+	ReviewStatusREJECTED ReviewStatus = "REJECTED"
+)
+
+func (e *ReviewStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReviewStatus(s)
+	case string:
+		*e = ReviewStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReviewStatus: %T", src)
+	}
+	return nil
+}
+
+type NullReviewStatus struct {
+	ReviewStatus ReviewStatus `json:"review_status"`
+	Valid        bool         `json:"valid"` // Valid is true if ReviewStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullReviewStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ReviewStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ReviewStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullReviewStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ReviewStatus), nil
+}
+
+type ShippingRateType string
+
+const (
+	ShippingRateTypeFLAT     ShippingRateType = "FLAT"
+	ShippingRateTypeWEIGHT   ShippingRateType = "WEIGHT"
+	ShippingRateTypeFREEOVER ShippingRateType = "FREE_OVER"
+)
+
+func (e *ShippingRateType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ShippingRateType(s)
+	case string:
+		*e = ShippingRateType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ShippingRateType: %T", src)
+	}
+	return nil
+}
+
+type NullShippingRateType struct {
+	ShippingRateType ShippingRateType `json:"shipping_rate_type"`
+	Valid            bool             `json:"valid"` // Valid is true if ShippingRateType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullShippingRateType) Scan(value interface{}) error {
+	if value == nil {
+		ns.ShippingRateType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ShippingRateType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullShippingRateType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ShippingRateType), nil
+}
+
+type StockMovementKind string
+
+const (
+	StockMovementKindORDERPLACED    StockMovementKind = "ORDER_PLACED"
+	StockMovementKindORDERCANCELLED StockMovementKind = "ORDER_CANCELLED"
+	StockMovementKindADJUSTMENT     StockMovementKind = "ADJUSTMENT"
+	StockMovementKindIMPORT         StockMovementKind = "IMPORT"
+	StockMovementKindRETURN         StockMovementKind = "RETURN"
+	StockMovementKindTRANSFER       StockMovementKind = "TRANSFER"
+)
+
+func (e *StockMovementKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = StockMovementKind(s)
+	case string:
+		*e = StockMovementKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for StockMovementKind: %T", src)
+	}
+	return nil
+}
+
+type NullStockMovementKind struct {
+	StockMovementKind StockMovementKind `json:"stock_movement_kind"`
+	Valid             bool              `json:"valid"` // Valid is true if StockMovementKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullStockMovementKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.StockMovementKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.StockMovementKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullStockMovementKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.StockMovementKind), nil
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPENDING   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusSUCCEEDED WebhookDeliveryStatus = "SUCCEEDED"
+	WebhookDeliveryStatusFAILED    WebhookDeliveryStatus = "FAILED"
+)
+
+func (e *WebhookDeliveryStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebhookDeliveryStatus(s)
+	case string:
+		*e = WebhookDeliveryStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebhookDeliveryStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWebhookDeliveryStatus struct {
+	WebhookDeliveryStatus WebhookDeliveryStatus `json:"webhook_delivery_status"`
+	Valid                 bool                  `json:"valid"` // Valid is true if WebhookDeliveryStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebhookDeliveryStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebhookDeliveryStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebhookDeliveryStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebhookDeliveryStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebhookDeliveryStatus), nil
+}
+
+type Coupon struct {
+	ID            uuid.UUID        `json:"id"`
+	Code          string           `json:"code"`
+	DiscountType  DiscountType     `json:"discountType"`
+	Value         float64          `json:"value"`
+	MinOrderValue float64          `json:"minOrderValue"`
+	ProductIds    []uuid.UUID      `json:"productIds"`
+	Categories    []string         `json:"categories"`
+	UsageLimit    int32            `json:"usageLimit"`
+	PerUserLimit  int32            `json:"perUserLimit"`
+	UsageCount    int32            `json:"usageCount"`
+	StartsAt      pgtype.Timestamp `json:"startsAt"`
+	ExpiresAt     pgtype.Timestamp `json:"expiresAt"`
+	Active        bool             `json:"active"`
+	CreatedBy     uuid.UUID        `json:"createdBy"`
+	CreatedAt     pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt     pgtype.Timestamp `json:"updatedAt"`
+}
+
+type CouponRedemption struct {
 	ID        uuid.UUID        `json:"id"`
+	CouponId  uuid.UUID        `json:"couponId"`
 	UserId    uuid.UUID        `json:"userId"`
-	Total     float64          `json:"total"`
-	Status    OrderStatus      `json:"status"`
+	OrderId   uuid.UUID        `json:"orderId"`
+	Discount  float64          `json:"discount"`
 	CreatedAt pgtype.Timestamp `json:"createdAt"`
-	UpdatedAt pgtype.Timestamp `json:"updatedAt"`
+}
+
+type GuestOrder struct {
+	OrderId    uuid.UUID        `json:"orderId"`
+	Email      string           `json:"email"`
+	Name       string           `json:"name"`
+	Line1      string           `json:"line1"`
+	Line2      string           `json:"line2"`
+	City       string           `json:"city"`
+	PostalCode string           `json:"postalCode"`
+	Country    string           `json:"country"`
+	State      string           `json:"state"`
+	CreatedAt  pgtype.Timestamp `json:"createdAt"`
+}
+
+type Job struct {
+	ID             uuid.UUID        `json:"id"`
+	Kind           string           `json:"kind"`
+	Payload        []byte           `json:"payload"`
+	Status         JobStatus        `json:"status"`
+	Attempts       int32            `json:"attempts"`
+	MaxAttempts    int32            `json:"maxAttempts"`
+	RunAt          pgtype.Timestamp `json:"runAt"`
+	LockedAt       pgtype.Timestamp `json:"lockedAt"`
+	LockedBy       string           `json:"lockedBy"`
+	LastError      string           `json:"lastError"`
+	CompletedAt    pgtype.Timestamp `json:"completedAt"`
+	CreatedAt      pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt      pgtype.Timestamp `json:"updatedAt"`
+	IdempotencyKey string           `json:"idempotencyKey"`
+}
+
+type LowStockAlert struct {
+	ID         uuid.UUID        `json:"id"`
+	ProductId  uuid.UUID        `json:"productId"`
+	Stock      int32            `json:"stock"`
+	Threshold  int32            `json:"threshold"`
+	NotifiedAt pgtype.Timestamp `json:"notifiedAt"`
+	ResolvedAt pgtype.Timestamp `json:"resolvedAt"`
+	CreatedAt  pgtype.Timestamp `json:"createdAt"`
+}
+
+type Notification struct {
+	ID        uuid.UUID          `json:"id"`
+	UserId    uuid.UUID          `json:"userId"`
+	OrderId   uuid.UUID          `json:"orderId"`
+	EventId   uuid.UUID          `json:"eventId"`
+	Kind      NotificationKind   `json:"kind"`
+	Email     string             `json:"email"`
+	Status    NotificationStatus `json:"status"`
+	Attempts  int32              `json:"attempts"`
+	LastError string             `json:"lastError"`
+	SentAt    pgtype.Timestamp   `json:"sentAt"`
+	CreatedAt pgtype.Timestamp   `json:"createdAt"`
+	UpdatedAt pgtype.Timestamp   `json:"updatedAt"`
+}
+
+type NotificationPreference struct {
+	UserId         uuid.UUID        `json:"userId"`
+	OrderPlaced    bool             `json:"orderPlaced"`
+	OrderCancelled bool             `json:"orderCancelled"`
+	OrderCompleted bool             `json:"orderCompleted"`
+	OrderShipped   bool             `json:"orderShipped"`
+	CreatedAt      pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt      pgtype.Timestamp `json:"updatedAt"`
+}
+
+type Order struct {
+	ID               uuid.UUID        `json:"id"`
+	UserId           uuid.UUID        `json:"userId"`
+	Total            float64          `json:"total"`
+	Status           OrderStatus      `json:"status"`
+	CreatedAt        pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt        pgtype.Timestamp `json:"updatedAt"`
+	Subtotal         float64          `json:"subtotal"`
+	Discount         float64          `json:"discount"`
+	CouponId         pgtype.UUID      `json:"couponId"`
+	Tax              float64          `json:"tax"`
+	ShippingRegion   string           `json:"shippingRegion"`
+	ShippingMethodId pgtype.UUID      `json:"shippingMethodId"`
+	ShippingMethod   string           `json:"shippingMethod"`
+	ShippingCost     float64          `json:"shippingCost"`
+}
+
+type OrderAllocation struct {
+	ID          uuid.UUID        `json:"id"`
+	OrderId     uuid.UUID        `json:"orderId"`
+	OrderItemId uuid.UUID        `json:"orderItemId"`
+	ProductId   uuid.UUID        `json:"productId"`
+	WarehouseId uuid.UUID        `json:"warehouseId"`
+	Quantity    int32            `json:"quantity"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
 }
 
 type OrderItem struct {
+	ID          uuid.UUID        `json:"id"`
+	OrderId     uuid.UUID        `json:"orderId"`
+	ProductId   uuid.UUID        `json:"productId"`
+	Quantity    int32            `json:"quantity"`
+	Price       float64          `json:"price"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+	Tax         float64          `json:"tax"`
+	Backordered int32            `json:"backordered"`
+}
+
+type OrderPromotion struct {
+	ID          uuid.UUID        `json:"id"`
+	OrderId     uuid.UUID        `json:"orderId"`
+	PromotionId pgtype.UUID      `json:"promotionId"`
+	Name        string           `json:"name"`
+	Kind        PromotionKind    `json:"kind"`
+	Discount    float64          `json:"discount"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
+}
+
+type OrderStatusEvent struct {
+	ID             int64            `json:"id"`
+	OrderId        uuid.UUID        `json:"orderId"`
+	UserId         uuid.UUID        `json:"userId"`
+	Status         OrderStatus      `json:"status"`
+	PreviousStatus OrderStatus      `json:"previousStatus"`
+	CreatedAt      pgtype.Timestamp `json:"createdAt"`
+}
+
+type OrderTax struct {
 	ID        uuid.UUID        `json:"id"`
 	OrderId   uuid.UUID        `json:"orderId"`
-	ProductId uuid.UUID        `json:"productId"`
-	Quantity  int32            `json:"quantity"`
-	Price     float64          `json:"price"`
+	TaxRuleId pgtype.UUID      `json:"taxRuleId"`
+	Name      string           `json:"name"`
+	Rate      float64          `json:"rate"`
+	Inclusive bool             `json:"inclusive"`
+	Amount    float64          `json:"amount"`
 	CreatedAt pgtype.Timestamp `json:"createdAt"`
-	UpdatedAt pgtype.Timestamp `json:"updatedAt"`
+}
+
+type OutboxEvent struct {
+	ID             uuid.UUID        `json:"id"`
+	Type           string           `json:"type"`
+	Payload        []byte           `json:"payload"`
+	DispatchedAt   pgtype.Timestamp `json:"dispatchedAt"`
+	CreatedAt      pgtype.Timestamp `json:"createdAt"`
+	Attempts       int32            `json:"attempts"`
+	NextAttemptAt  pgtype.Timestamp `json:"nextAttemptAt"`
+	LastError      string           `json:"lastError"`
+	DeliveredSinks []string         `json:"deliveredSinks"`
+	FailedAt       pgtype.Timestamp `json:"failedAt"`
 }
 
 type Product struct {
+	ID               uuid.UUID        `json:"id"`
+	Name             string           `json:"name"`
+	Description      string           `json:"description"`
+	Price            float64          `json:"price"`
+	Stock            int32            `json:"stock"`
+	CreatedAt        pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt        pgtype.Timestamp `json:"updatedAt"`
+	CreatedBy        uuid.UUID        `json:"createdBy"`
+	Category         string           `json:"category"`
+	TaxClass         string           `json:"taxClass"`
+	Weight           float64          `json:"weight"`
+	Sku              string           `json:"sku"`
+	ReorderThreshold int32            `json:"reorderThreshold"`
+	BackorderPolicy  BackorderPolicy  `json:"backorderPolicy"`
+	AvailableAt      pgtype.Timestamp `json:"availableAt"`
+	DeletedAt        pgtype.Timestamp `json:"deletedAt"`
+	Version          int32            `json:"version"`
+	CompareAtPrice   pgtype.Float8    `json:"compareAtPrice"`
+}
+
+type ProductImage struct {
 	ID          uuid.UUID        `json:"id"`
-	Name        string           `json:"name"`
-	Description string           `json:"description"`
-	Price       float64          `json:"price"`
-	Stock       int32            `json:"stock"`
+	ProductId   uuid.UUID        `json:"productId"`
+	Key         string           `json:"key"`
+	ContentType string           `json:"contentType"`
+	Size        int64            `json:"size"`
+	Position    int32            `json:"position"`
+	IsPrimary   bool             `json:"isPrimary"`
+	CreatedBy   uuid.UUID        `json:"createdBy"`
 	CreatedAt   pgtype.Timestamp `json:"createdAt"`
 	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+}
+
+type ProductPrice struct {
+	ID             uuid.UUID        `json:"id"`
+	ProductId      uuid.UUID        `json:"productId"`
+	Price          float64          `json:"price"`
+	CompareAtPrice pgtype.Float8    `json:"compareAtPrice"`
+	EffectiveFrom  pgtype.Timestamp `json:"effectiveFrom"`
+	EffectiveTo    pgtype.Timestamp `json:"effectiveTo"`
+	CreatedBy      pgtype.UUID      `json:"createdBy"`
+	CancelledAt    pgtype.Timestamp `json:"cancelledAt"`
+	CreatedAt      pgtype.Timestamp `json:"createdAt"`
+}
+
+type ProductReview struct {
+	ID          uuid.UUID        `json:"id"`
+	ProductId   uuid.UUID        `json:"productId"`
+	UserId      uuid.UUID        `json:"userId"`
+	Rating      int32            `json:"rating"`
+	Title       string           `json:"title"`
+	Body        string           `json:"body"`
+	Status      ReviewStatus     `json:"status"`
+	ModeratedBy pgtype.UUID      `json:"moderatedBy"`
+	ModeratedAt pgtype.Timestamp `json:"moderatedAt"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+}
+
+type Promotion struct {
+	ID          uuid.UUID        `json:"id"`
+	Name        string           `json:"name"`
+	Kind        PromotionKind    `json:"kind"`
+	Priority    int32            `json:"priority"`
+	Stackable   bool             `json:"stackable"`
+	ProductIds  []uuid.UUID      `json:"productIds"`
+	Categories  []string         `json:"categories"`
+	BuyQuantity int32            `json:"buyQuantity"`
+	GetQuantity int32            `json:"getQuantity"`
+	Percent     float64          `json:"percent"`
+	BundlePrice float64          `json:"bundlePrice"`
+	Tiers       json.RawMessage  `json:"tiers"`
+	StartsAt    pgtype.Timestamp `json:"startsAt"`
+	ExpiresAt   pgtype.Timestamp `json:"expiresAt"`
+	Active      bool             `json:"active"`
 	CreatedBy   uuid.UUID        `json:"createdBy"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+}
+
+type ReturnEvent struct {
+	ID         uuid.UUID        `json:"id"`
+	ReturnId   uuid.UUID        `json:"returnId"`
+	FromStatus NullReturnStatus `json:"fromStatus"`
+	ToStatus   ReturnStatus     `json:"toStatus"`
+	Note       string           `json:"note"`
+	ActorId    uuid.UUID        `json:"actorId"`
+	CreatedAt  pgtype.Timestamp `json:"createdAt"`
+}
+
+type ReturnItem struct {
+	ID          uuid.UUID        `json:"id"`
+	ReturnId    uuid.UUID        `json:"returnId"`
+	OrderItemId uuid.UUID        `json:"orderItemId"`
+	ProductId   uuid.UUID        `json:"productId"`
+	Quantity    int32            `json:"quantity"`
+	Reason      string           `json:"reason"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
+}
+
+type ReturnRequest struct {
+	ID              uuid.UUID        `json:"id"`
+	OrderId         uuid.UUID        `json:"orderId"`
+	UserId          uuid.UUID        `json:"userId"`
+	Status          ReturnStatus     `json:"status"`
+	RefundAmount    float64          `json:"refundAmount"`
+	RefundReference string           `json:"refundReference"`
+	RefundedAt      pgtype.Timestamp `json:"refundedAt"`
+	CreatedAt       pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt       pgtype.Timestamp `json:"updatedAt"`
+}
+
+type Shipment struct {
+	ID             uuid.UUID        `json:"id"`
+	OrderId        uuid.UUID        `json:"orderId"`
+	Carrier        string           `json:"carrier"`
+	TrackingNumber string           `json:"trackingNumber"`
+	ShippedAt      pgtype.Timestamp `json:"shippedAt"`
+	DeliveredAt    pgtype.Timestamp `json:"deliveredAt"`
+	CreatedBy      uuid.UUID        `json:"createdBy"`
+	CreatedAt      pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt      pgtype.Timestamp `json:"updatedAt"`
+}
+
+type ShipmentItem struct {
+	ID          uuid.UUID        `json:"id"`
+	ShipmentId  uuid.UUID        `json:"shipmentId"`
+	OrderItemId uuid.UUID        `json:"orderItemId"`
+	ProductId   uuid.UUID        `json:"productId"`
+	Quantity    int32            `json:"quantity"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
+}
+
+type ShippingMethod struct {
+	ID        uuid.UUID        `json:"id"`
+	ZoneId    uuid.UUID        `json:"zoneId"`
+	Name      string           `json:"name"`
+	RateType  ShippingRateType `json:"rateType"`
+	Rate      float64          `json:"rate"`
+	RatePerKg float64          `json:"ratePerKg"`
+	FreeOver  float64          `json:"freeOver"`
+	Active    bool             `json:"active"`
+	CreatedAt pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt pgtype.Timestamp `json:"updatedAt"`
+}
+
+type ShippingZone struct {
+	ID        uuid.UUID        `json:"id"`
+	Name      string           `json:"name"`
+	Regions   []string         `json:"regions"`
+	CreatedAt pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt pgtype.Timestamp `json:"updatedAt"`
+}
+
+type StockMovement struct {
+	ID          int64             `json:"id"`
+	ProductId   uuid.UUID         `json:"productId"`
+	Quantity    int32             `json:"quantity"`
+	StockAfter  int32             `json:"stockAfter"`
+	Kind        StockMovementKind `json:"kind"`
+	OrderId     pgtype.UUID       `json:"orderId"`
+	ReturnId    pgtype.UUID       `json:"returnId"`
+	CreatedBy   pgtype.UUID       `json:"createdBy"`
+	Note        string            `json:"note"`
+	CreatedAt   pgtype.Timestamp  `json:"createdAt"`
+	WarehouseId pgtype.UUID       `json:"warehouseId"`
+}
+
+type StockTransfer struct {
+	ID              uuid.UUID        `json:"id"`
+	ProductId       uuid.UUID        `json:"productId"`
+	FromWarehouseId uuid.UUID        `json:"fromWarehouseId"`
+	ToWarehouseId   uuid.UUID        `json:"toWarehouseId"`
+	Quantity        int32            `json:"quantity"`
+	Note            string           `json:"note"`
+	CreatedBy       uuid.UUID        `json:"createdBy"`
+	CreatedAt       pgtype.Timestamp `json:"createdAt"`
+}
+
+type TaxRule struct {
+	ID        uuid.UUID        `json:"id"`
+	Name      string           `json:"name"`
+	TaxClass  string           `json:"taxClass"`
+	Region    string           `json:"region"`
+	Rate      float64          `json:"rate"`
+	Inclusive bool             `json:"inclusive"`
+	CreatedAt pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt pgtype.Timestamp `json:"updatedAt"`
 }
 
 type User struct {
@@ -92,4 +914,64 @@ type User struct {
 	Admin     bool             `json:"admin"`
 	CreatedAt pgtype.Timestamp `json:"createdAt"`
 	UpdatedAt pgtype.Timestamp `json:"updatedAt"`
+	Guest     bool             `json:"guest"`
+}
+
+type Warehouse struct {
+	ID        uuid.UUID        `json:"id"`
+	Name      string           `json:"name"`
+	Priority  int32            `json:"priority"`
+	Active    bool             `json:"active"`
+	CreatedAt pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt pgtype.Timestamp `json:"updatedAt"`
+}
+
+type WarehouseStock struct {
+	WarehouseId uuid.UUID        `json:"warehouseId"`
+	ProductId   uuid.UUID        `json:"productId"`
+	Stock       int32            `json:"stock"`
+	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+}
+
+type Webhook struct {
+	ID        uuid.UUID        `json:"id"`
+	Url       string           `json:"url"`
+	Secret    string           `json:"secret"`
+	Events    []string         `json:"events"`
+	Active    bool             `json:"active"`
+	CreatedBy uuid.UUID        `json:"createdBy"`
+	CreatedAt pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt pgtype.Timestamp `json:"updatedAt"`
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	WebhookId      uuid.UUID             `json:"webhookId"`
+	EventId        uuid.UUID             `json:"eventId"`
+	EventType      string                `json:"eventType"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int32                 `json:"attempts"`
+	ResponseStatus int32                 `json:"responseStatus"`
+	ResponseBody   string                `json:"responseBody"`
+	LastError      string                `json:"lastError"`
+	DeliveredAt    pgtype.Timestamp      `json:"deliveredAt"`
+	CreatedAt      pgtype.Timestamp      `json:"createdAt"`
+	UpdatedAt      pgtype.Timestamp      `json:"updatedAt"`
+}
+
+type Wishlist struct {
+	ID         uuid.UUID        `json:"id"`
+	UserId     uuid.UUID        `json:"userId"`
+	Name       string           `json:"name"`
+	ShareToken pgtype.Text      `json:"shareToken"`
+	CreatedAt  pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt  pgtype.Timestamp `json:"updatedAt"`
+}
+
+type WishlistItem struct {
+	ID         uuid.UUID        `json:"id"`
+	WishlistId uuid.UUID        `json:"wishlistId"`
+	ProductId  uuid.UUID        `json:"productId"`
+	Quantity   int32            `json:"quantity"`
+	CreatedAt  pgtype.Timestamp `json:"createdAt"`
 }
@@ -9,15 +9,16 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const cancelOrder = `-- name: CancelOrder :one
 UPDATE "order"
 SET
     status = 'CANCELLED',
-    updated_at = NOW()
-WHERE id = $1 AND "userId" = $2 AND status = 'PENDING'
-RETURNING id, "userId", total, status, "createdAt", "updatedAt"
+    "updatedAt" = NOW()
+WHERE id = $1 AND "userId" = $2 AND status IN ('PENDING', 'BACKORDERED')
+RETURNING id, "userId", total, status, "createdAt", "updatedAt", subtotal, discount, "couponId", tax, "shippingRegion", "shippingMethodId", "shippingMethod", "shippingCost"
 `
 
 type CancelOrderParams struct {
@@ -35,28 +36,79 @@ func (q *Queries) CancelOrder(ctx context.Context, arg CancelOrderParams) (Order
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Subtotal,
+		&i.Discount,
+		&i.CouponId,
+		&i.Tax,
+		&i.ShippingRegion,
+		&i.ShippingMethodId,
+		&i.ShippingMethod,
+		&i.ShippingCost,
 	)
 	return i, err
 }
 
+const countBackorderedItems = `-- name: CountBackorderedItems :one
+SELECT COUNT(*) FROM "orderItem"
+WHERE "orderId" = $1 AND backordered > 0
+`
+
+func (q *Queries) CountBackorderedItems(ctx context.Context, orderId uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countBackorderedItems, orderId)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createOrder = `-- name: CreateOrder :one
 INSERT INTO "order" (
     id,
     "userId",
-    total
+    subtotal,
+    discount,
+    tax,
+    total,
+    "couponId",
+    "shippingRegion",
+    "shippingMethodId",
+    "shippingMethod",
+    "shippingCost",
+    status
 ) VALUES (
-    $1, $2, $3
-) RETURNING id, "userId", total, status, "createdAt", "updatedAt"
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING id, "userId", total, status, "createdAt", "updatedAt", subtotal, discount, "couponId", tax, "shippingRegion", "shippingMethodId", "shippingMethod", "shippingCost"
 `
 
 type CreateOrderParams struct {
-	ID     uuid.UUID `json:"id"`
-	UserId uuid.UUID `json:"userId"`
-	Total  float64   `json:"total"`
+	ID               uuid.UUID   `json:"id"`
+	UserId           uuid.UUID   `json:"userId"`
+	Subtotal         float64     `json:"subtotal"`
+	Discount         float64     `json:"discount"`
+	Tax              float64     `json:"tax"`
+	Total            float64     `json:"total"`
+	CouponId         pgtype.UUID `json:"couponId"`
+	ShippingRegion   string      `json:"shippingRegion"`
+	ShippingMethodId pgtype.UUID `json:"shippingMethodId"`
+	ShippingMethod   string      `json:"shippingMethod"`
+	ShippingCost     float64     `json:"shippingCost"`
+	Status           OrderStatus `json:"status"`
 }
 
 func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error) {
-	row := q.db.QueryRow(ctx, createOrder, arg.ID, arg.UserId, arg.Total)
+	row := q.db.QueryRow(ctx, createOrder,
+		arg.ID,
+		arg.UserId,
+		arg.Subtotal,
+		arg.Discount,
+		arg.Tax,
+		arg.Total,
+		arg.CouponId,
+		arg.ShippingRegion,
+		arg.ShippingMethodId,
+		arg.ShippingMethod,
+		arg.ShippingCost,
+		arg.Status,
+	)
 	var i Order
 	err := row.Scan(
 		&i.ID,
@@ -65,12 +117,20 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Subtotal,
+		&i.Discount,
+		&i.CouponId,
+		&i.Tax,
+		&i.ShippingRegion,
+		&i.ShippingMethodId,
+		&i.ShippingMethod,
+		&i.ShippingCost,
 	)
 	return i, err
 }
 
 const getAllOrderByUserId = `-- name: GetAllOrderByUserId :many
-SELECT id, "userId", total, status, "createdAt", "updatedAt" FROM "order"
+SELECT id, "userId", total, status, "createdAt", "updatedAt", subtotal, discount, "couponId", tax, "shippingRegion", "shippingMethodId", "shippingMethod", "shippingCost" FROM "order"
 WHERE "userId" = $1
 `
 
@@ -90,6 +150,14 @@ func (q *Queries) GetAllOrderByUserId(ctx context.Context, userid uuid.UUID) ([]
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Subtotal,
+			&i.Discount,
+			&i.CouponId,
+			&i.Tax,
+			&i.ShippingRegion,
+			&i.ShippingMethodId,
+			&i.ShippingMethod,
+			&i.ShippingCost,
 		); err != nil {
 			return nil, err
 		}
@@ -102,7 +170,7 @@ func (q *Queries) GetAllOrderByUserId(ctx context.Context, userid uuid.UUID) ([]
 }
 
 const getAllOrderItem = `-- name: GetAllOrderItem :many
-SELECT id, "orderId", "productId", quantity, price, "createdAt", "updatedAt" FROM "orderItem"
+SELECT id, "orderId", "productId", quantity, price, "createdAt", "updatedAt", tax, backordered FROM "orderItem"
 WHERE "orderId" = $1
 `
 
@@ -123,6 +191,8 @@ func (q *Queries) GetAllOrderItem(ctx context.Context, orderid uuid.UUID) ([]Ord
 			&i.Price,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Tax,
+			&i.Backordered,
 		); err != nil {
 			return nil, err
 		}
@@ -135,7 +205,7 @@ func (q *Queries) GetAllOrderItem(ctx context.Context, orderid uuid.UUID) ([]Ord
 }
 
 const getAllProductInOrder = `-- name: GetAllProductInOrder :many
-SELECT "orderItem"."productId", "orderItem"."quantity" FROM "orderItem" WHERE "orderId" = $1
+SELECT "orderItem"."productId", ("orderItem"."quantity" - "orderItem"."backordered")::INT AS "quantity" FROM "orderItem" WHERE "orderId" = $1
 `
 
 type GetAllProductInOrderRow struct {
@@ -163,8 +233,55 @@ func (q *Queries) GetAllProductInOrder(ctx context.Context, orderid uuid.UUID) (
 	return items, nil
 }
 
+const getBackorderedItemsForUpdate = `-- name: GetBackorderedItemsForUpdate :many
+SELECT
+    "orderItem".id,
+    "orderItem"."orderId",
+    "order"."userId",
+    "orderItem".backordered
+FROM "orderItem"
+JOIN "order" ON "order".id = "orderItem"."orderId"
+WHERE "orderItem"."productId" = $1
+    AND "orderItem".backordered > 0
+    AND "order".status = 'BACKORDERED'
+ORDER BY "order"."createdAt", "orderItem".id
+FOR UPDATE OF "orderItem"
+`
+
+type GetBackorderedItemsForUpdateRow struct {
+	ID          uuid.UUID `json:"id"`
+	OrderId     uuid.UUID `json:"orderId"`
+	UserId      uuid.UUID `json:"userId"`
+	Backordered int32     `json:"backordered"`
+}
+
+func (q *Queries) GetBackorderedItemsForUpdate(ctx context.Context, productId uuid.UUID) ([]GetBackorderedItemsForUpdateRow, error) {
+	rows, err := q.db.Query(ctx, getBackorderedItemsForUpdate, productId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetBackorderedItemsForUpdateRow{}
+	for rows.Next() {
+		var i GetBackorderedItemsForUpdateRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderId,
+			&i.UserId,
+			&i.Backordered,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getOrderById = `-- name: GetOrderById :one
-SELECT id, "userId", total, status, "createdAt", "updatedAt" FROM "order"
+SELECT id, "userId", total, status, "createdAt", "updatedAt", subtotal, discount, "couponId", tax, "shippingRegion", "shippingMethodId", "shippingMethod", "shippingCost" FROM "order"
 WHERE id = $1
 `
 
@@ -178,6 +295,45 @@ func (q *Queries) GetOrderById(ctx context.Context, id uuid.UUID) (Order, error)
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Subtotal,
+		&i.Discount,
+		&i.CouponId,
+		&i.Tax,
+		&i.ShippingRegion,
+		&i.ShippingMethodId,
+		&i.ShippingMethod,
+		&i.ShippingCost,
+	)
+	return i, err
+}
+
+const updateOrderItemBackordered = `-- name: UpdateOrderItemBackordered :one
+UPDATE "orderItem"
+SET
+    backordered = $1,
+    "updatedAt" = NOW()
+WHERE id = $2
+RETURNING id, "orderId", "productId", quantity, price, "createdAt", "updatedAt", tax, backordered
+`
+
+type UpdateOrderItemBackorderedParams struct {
+	Backordered int32     `json:"backordered"`
+	ID          uuid.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateOrderItemBackordered(ctx context.Context, arg UpdateOrderItemBackorderedParams) (OrderItem, error) {
+	row := q.db.QueryRow(ctx, updateOrderItemBackordered, arg.Backordered, arg.ID)
+	var i OrderItem
+	err := row.Scan(
+		&i.ID,
+		&i.OrderId,
+		&i.ProductId,
+		&i.Quantity,
+		&i.Price,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Tax,
+		&i.Backordered,
 	)
 	return i, err
 }
@@ -186,9 +342,9 @@ const updateOrderStatus = `-- name: UpdateOrderStatus :one
 UPDATE "order"
 SET
     status = $1,
-    updated_at = NOW()
+    "updatedAt" = NOW()
 WHERE id = $2
-RETURNING id, "userId", total, status, "createdAt", "updatedAt"
+RETURNING id, "userId", total, status, "createdAt", "updatedAt", subtotal, discount, "couponId", tax, "shippingRegion", "shippingMethodId", "shippingMethod", "shippingCost"
 `
 
 type UpdateOrderStatusParams struct {
@@ -206,6 +362,14 @@ func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusPa
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Subtotal,
+		&i.Discount,
+		&i.CouponId,
+		&i.Tax,
+		&i.ShippingRegion,
+		&i.ShippingMethodId,
+		&i.ShippingMethod,
+		&i.ShippingCost,
 	)
 	return i, err
 }
@@ -12,6 +12,41 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const archiveProduct = `-- name: ArchiveProduct :one
+UPDATE product
+SET
+    "deletedAt" = NOW(),
+    "updatedAt" = NOW()
+WHERE id = $1 AND "deletedAt" IS NULL
+RETURNING id, name, description, price, stock, "createdAt", "updatedAt", "createdBy", category, "taxClass", weight, sku, "reorderThreshold", "backorderPolicy", "availableAt", "deletedAt", version, "compareAtPrice"
+`
+
+func (q *Queries) ArchiveProduct(ctx context.Context, id uuid.UUID) (Product, error) {
+	row := q.db.QueryRow(ctx, archiveProduct, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Stock,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.Category,
+		&i.TaxClass,
+		&i.Weight,
+		&i.Sku,
+		&i.ReorderThreshold,
+		&i.BackorderPolicy,
+		&i.AvailableAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CompareAtPrice,
+	)
+	return i, err
+}
+
 const createProduct = `-- name: CreateProduct :one
 INSERT INTO "product" (
     id,
@@ -19,19 +54,33 @@ INSERT INTO "product" (
     description,
     price,
     stock,
-    "createdBy"
+    category,
+    "taxClass",
+    weight,
+    "createdBy",
+    sku,
+    "reorderThreshold",
+    "backorderPolicy",
+    "availableAt"
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
-) RETURNING id, name, description, price, stock, "createdAt", "updatedAt", "createdBy"
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+) RETURNING id, name, description, price, stock, "createdAt", "updatedAt", "createdBy", category, "taxClass", weight, sku, "reorderThreshold", "backorderPolicy", "availableAt", "deletedAt", version, "compareAtPrice"
 `
 
 type CreateProductParams struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	Stock       int32     `json:"stock"`
-	CreatedBy   uuid.UUID `json:"createdBy"`
+	ID               uuid.UUID        `json:"id"`
+	Name             string           `json:"name"`
+	Description      string           `json:"description"`
+	Price            float64          `json:"price"`
+	Stock            int32            `json:"stock"`
+	Category         string           `json:"category"`
+	TaxClass         string           `json:"taxClass"`
+	Weight           float64          `json:"weight"`
+	CreatedBy        uuid.UUID        `json:"createdBy"`
+	Sku              string           `json:"sku"`
+	ReorderThreshold int32            `json:"reorderThreshold"`
+	BackorderPolicy  BackorderPolicy  `json:"backorderPolicy"`
+	AvailableAt      pgtype.Timestamp `json:"availableAt"`
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error) {
@@ -41,7 +90,14 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		arg.Description,
 		arg.Price,
 		arg.Stock,
+		arg.Category,
+		arg.TaxClass,
+		arg.Weight,
 		arg.CreatedBy,
+		arg.Sku,
+		arg.ReorderThreshold,
+		arg.BackorderPolicy,
+		arg.AvailableAt,
 	)
 	var i Product
 	err := row.Scan(
@@ -53,20 +109,20 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.CreatedBy,
+		&i.Category,
+		&i.TaxClass,
+		&i.Weight,
+		&i.Sku,
+		&i.ReorderThreshold,
+		&i.BackorderPolicy,
+		&i.AvailableAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CompareAtPrice,
 	)
 	return i, err
 }
 
-const deleteOneProduct = `-- name: DeleteOneProduct :exec
-DELETE FROM product
-WHERE id = $1
-`
-
-func (q *Queries) DeleteOneProduct(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteOneProduct, id)
-	return err
-}
-
 const getAllProduct = `-- name: GetAllProduct :many
 SELECT
     id,
@@ -76,23 +132,60 @@ SELECT
     stock,
     "createdBy",
     "createdAt",
-    "updatedAt"
+    "updatedAt",
+    category,
+    "taxClass",
+    weight,
+    sku,
+    "reorderThreshold",
+    "backorderPolicy",
+    "availableAt",
+    "deletedAt",
+    version,
+    "compareAtPrice",
+    COALESCE("rating"."ratingAverage", 0)::FLOAT AS "ratingAverage",
+    COALESCE("rating"."ratingCount", 0)::INT AS "ratingCount"
 FROM "product"
+LEFT JOIN (
+    SELECT
+        "productId",
+        ROUND(AVG(rating)::NUMERIC, 2)::FLOAT AS "ratingAverage",
+        COUNT(*)::INT AS "ratingCount"
+    FROM "productReview"
+    WHERE status = 'APPROVED'
+    GROUP BY "productId"
+) AS "rating" ON "rating"."productId" = "product".id
+WHERE "deletedAt" IS NULL
+ORDER BY
+    CASE WHEN $1::TEXT = 'rating' THEN COALESCE("rating"."ratingAverage", 0) END DESC,
+    CASE WHEN $1::TEXT = 'rating' THEN COALESCE("rating"."ratingCount", 0) END DESC
 `
 
 type GetAllProductRow struct {
-	ID          uuid.UUID        `json:"id"`
-	Name        string           `json:"name"`
-	Description string           `json:"description"`
-	Price       float64          `json:"price"`
-	Stock       int32            `json:"stock"`
-	CreatedBy   uuid.UUID        `json:"createdBy"`
-	CreatedAt   pgtype.Timestamp `json:"createdAt"`
-	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+	ID               uuid.UUID        `json:"id"`
+	Name             string           `json:"name"`
+	Description      string           `json:"description"`
+	Price            float64          `json:"price"`
+	Stock            int32            `json:"stock"`
+	CreatedBy        uuid.UUID        `json:"createdBy"`
+	CreatedAt        pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt        pgtype.Timestamp `json:"updatedAt"`
+	Category         string           `json:"category"`
+	TaxClass         string           `json:"taxClass"`
+	Weight           float64          `json:"weight"`
+	Sku              string           `json:"sku"`
+	ReorderThreshold int32            `json:"reorderThreshold"`
+	BackorderPolicy  BackorderPolicy  `json:"backorderPolicy"`
+	AvailableAt      pgtype.Timestamp `json:"availableAt"`
+	DeletedAt        pgtype.Timestamp `json:"deletedAt"`
+	Version          int32            `json:"version"`
+	CompareAtPrice   pgtype.Float8    `json:"compareAtPrice"`
+	RatingAverage    float64          `json:"ratingAverage"`
+	RatingCount      int32            `json:"ratingCount"`
 }
 
-func (q *Queries) GetAllProduct(ctx context.Context) ([]GetAllProductRow, error) {
-	rows, err := q.db.Query(ctx, getAllProduct)
+func (q *Queries) GetAllProduct(ctx context.Context, sort string) ([]GetAllProductRow, error) {
+	rows, err := q.db.Query(ctx, getAllProduct, sort)
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +202,18 @@ func (q *Queries) GetAllProduct(ctx context.Context) ([]GetAllProductRow, error)
 			&i.CreatedBy,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Category,
+			&i.TaxClass,
+			&i.Weight,
+			&i.Sku,
+			&i.ReorderThreshold,
+			&i.BackorderPolicy,
+			&i.AvailableAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.CompareAtPrice,
+			&i.RatingAverage,
+			&i.RatingCount,
 		); err != nil {
 			return nil, err
 		}
@@ -124,15 +229,23 @@ const getMultipleProductById = `-- name: GetMultipleProductById :many
 SELECT
     id,
     price,
-    stock
+    stock,
+    category,
+    "taxClass",
+    weight,
+    "backorderPolicy"
 FROM product
-WHERE id = ANY($1::UUID[])
+WHERE id = ANY($1::UUID[]) AND "deletedAt" IS NULL
 `
 
 type GetMultipleProductByIdRow struct {
-	ID    uuid.UUID `json:"id"`
-	Price float64   `json:"price"`
-	Stock int32     `json:"stock"`
+	ID              uuid.UUID       `json:"id"`
+	Price           float64         `json:"price"`
+	Stock           int32           `json:"stock"`
+	Category        string          `json:"category"`
+	TaxClass        string          `json:"taxClass"`
+	Weight          float64         `json:"weight"`
+	BackorderPolicy BackorderPolicy `json:"backorderPolicy"`
 }
 
 func (q *Queries) GetMultipleProductById(ctx context.Context, dollar_1 []uuid.UUID) ([]GetMultipleProductByIdRow, error) {
@@ -144,7 +257,15 @@ func (q *Queries) GetMultipleProductById(ctx context.Context, dollar_1 []uuid.UU
 	items := []GetMultipleProductByIdRow{}
 	for rows.Next() {
 		var i GetMultipleProductByIdRow
-		if err := rows.Scan(&i.ID, &i.Price, &i.Stock); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Price,
+			&i.Stock,
+			&i.Category,
+			&i.TaxClass,
+			&i.Weight,
+			&i.BackorderPolicy,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -164,21 +285,54 @@ SELECT
     stock,
     "createdBy",
     "createdAt",
-    "updatedAt"
+    "updatedAt",
+    category,
+    "taxClass",
+    weight,
+    sku,
+    "reorderThreshold",
+    "backorderPolicy",
+    "availableAt",
+    "deletedAt",
+    version,
+    "compareAtPrice",
+    COALESCE("rating"."ratingAverage", 0)::FLOAT AS "ratingAverage",
+    COALESCE("rating"."ratingCount", 0)::INT AS "ratingCount"
 FROM "product"
+LEFT JOIN (
+    SELECT
+        "productId",
+        ROUND(AVG(rating)::NUMERIC, 2)::FLOAT AS "ratingAverage",
+        COUNT(*)::INT AS "ratingCount"
+    FROM "productReview"
+    WHERE status = 'APPROVED'
+    GROUP BY "productId"
+) AS "rating" ON "rating"."productId" = "product".id
 WHERE id = $1
 LIMIT 1
 `
 
 type GetOneProductRow struct {
-	ID          uuid.UUID        `json:"id"`
-	Name        string           `json:"name"`
-	Description string           `json:"description"`
-	Price       float64          `json:"price"`
-	Stock       int32            `json:"stock"`
-	CreatedBy   uuid.UUID        `json:"createdBy"`
-	CreatedAt   pgtype.Timestamp `json:"createdAt"`
-	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+	ID               uuid.UUID        `json:"id"`
+	Name             string           `json:"name"`
+	Description      string           `json:"description"`
+	Price            float64          `json:"price"`
+	Stock            int32            `json:"stock"`
+	CreatedBy        uuid.UUID        `json:"createdBy"`
+	CreatedAt        pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt        pgtype.Timestamp `json:"updatedAt"`
+	Category         string           `json:"category"`
+	TaxClass         string           `json:"taxClass"`
+	Weight           float64          `json:"weight"`
+	Sku              string           `json:"sku"`
+	ReorderThreshold int32            `json:"reorderThreshold"`
+	BackorderPolicy  BackorderPolicy  `json:"backorderPolicy"`
+	AvailableAt      pgtype.Timestamp `json:"availableAt"`
+	DeletedAt        pgtype.Timestamp `json:"deletedAt"`
+	Version          int32            `json:"version"`
+	CompareAtPrice   pgtype.Float8    `json:"compareAtPrice"`
+	RatingAverage    float64          `json:"ratingAverage"`
+	RatingCount      int32            `json:"ratingCount"`
 }
 
 func (q *Queries) GetOneProduct(ctx context.Context, id uuid.UUID) (GetOneProductRow, error) {
@@ -193,6 +347,252 @@ func (q *Queries) GetOneProduct(ctx context.Context, id uuid.UUID) (GetOneProduc
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Category,
+		&i.TaxClass,
+		&i.Weight,
+		&i.Sku,
+		&i.ReorderThreshold,
+		&i.BackorderPolicy,
+		&i.AvailableAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CompareAtPrice,
+		&i.RatingAverage,
+		&i.RatingCount,
+	)
+	return i, err
+}
+
+const getProductByNameForUpdate = `-- name: GetProductByNameForUpdate :one
+SELECT id, name, description, price, stock, "createdAt", "updatedAt", "createdBy", category, "taxClass", weight, sku, "reorderThreshold", "backorderPolicy", "availableAt", "deletedAt", version, "compareAtPrice" FROM "product"
+WHERE name = $1
+LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetProductByNameForUpdate(ctx context.Context, name string) (Product, error) {
+	row := q.db.QueryRow(ctx, getProductByNameForUpdate, name)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Stock,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.Category,
+		&i.TaxClass,
+		&i.Weight,
+		&i.Sku,
+		&i.ReorderThreshold,
+		&i.BackorderPolicy,
+		&i.AvailableAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CompareAtPrice,
+	)
+	return i, err
+}
+
+const getProductBySkuForUpdate = `-- name: GetProductBySkuForUpdate :one
+SELECT id, name, description, price, stock, "createdAt", "updatedAt", "createdBy", category, "taxClass", weight, sku, "reorderThreshold", "backorderPolicy", "availableAt", "deletedAt", version, "compareAtPrice" FROM "product"
+WHERE sku = $1 AND sku <> ''
+LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetProductBySkuForUpdate(ctx context.Context, sku string) (Product, error) {
+	row := q.db.QueryRow(ctx, getProductBySkuForUpdate, sku)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Stock,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.Category,
+		&i.TaxClass,
+		&i.Weight,
+		&i.Sku,
+		&i.ReorderThreshold,
+		&i.BackorderPolicy,
+		&i.AvailableAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CompareAtPrice,
+	)
+	return i, err
+}
+
+const getProductIdsForUpdate = `-- name: GetProductIdsForUpdate :many
+SELECT id FROM "product"
+WHERE id = ANY($1::UUID[])
+ORDER BY id
+FOR UPDATE
+`
+
+func (q *Queries) GetProductIdsForUpdate(ctx context.Context, productIds []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, getProductIdsForUpdate, productIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProductPage = `-- name: GetProductPage :many
+SELECT
+    id,
+    name,
+    description,
+    price,
+    stock,
+    "createdBy",
+    "createdAt",
+    "updatedAt",
+    category,
+    "taxClass",
+    weight,
+    sku,
+    "reorderThreshold",
+    "backorderPolicy",
+    "availableAt",
+    "deletedAt",
+    version,
+    "compareAtPrice",
+    COALESCE("rating"."ratingAverage", 0)::FLOAT AS "ratingAverage",
+    COALESCE("rating"."ratingCount", 0)::INT AS "ratingCount"
+FROM "product"
+LEFT JOIN (
+    SELECT
+        "productId",
+        ROUND(AVG(rating)::NUMERIC, 2)::FLOAT AS "ratingAverage",
+        COUNT(*)::INT AS "ratingCount"
+    FROM "productReview"
+    WHERE status = 'APPROVED'
+    GROUP BY "productId"
+) AS "rating" ON "rating"."productId" = "product".id
+WHERE id > $1 AND "deletedAt" IS NULL
+ORDER BY id
+LIMIT $2
+`
+
+type GetProductPageParams struct {
+	AfterId  uuid.UUID `json:"afterId"`
+	PageSize int32     `json:"pageSize"`
+}
+
+type GetProductPageRow struct {
+	ID               uuid.UUID        `json:"id"`
+	Name             string           `json:"name"`
+	Description      string           `json:"description"`
+	Price            float64          `json:"price"`
+	Stock            int32            `json:"stock"`
+	CreatedBy        uuid.UUID        `json:"createdBy"`
+	CreatedAt        pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt        pgtype.Timestamp `json:"updatedAt"`
+	Category         string           `json:"category"`
+	TaxClass         string           `json:"taxClass"`
+	Weight           float64          `json:"weight"`
+	Sku              string           `json:"sku"`
+	ReorderThreshold int32            `json:"reorderThreshold"`
+	BackorderPolicy  BackorderPolicy  `json:"backorderPolicy"`
+	AvailableAt      pgtype.Timestamp `json:"availableAt"`
+	DeletedAt        pgtype.Timestamp `json:"deletedAt"`
+	Version          int32            `json:"version"`
+	CompareAtPrice   pgtype.Float8    `json:"compareAtPrice"`
+	RatingAverage    float64          `json:"ratingAverage"`
+	RatingCount      int32            `json:"ratingCount"`
+}
+
+func (q *Queries) GetProductPage(ctx context.Context, arg GetProductPageParams) ([]GetProductPageRow, error) {
+	rows, err := q.db.Query(ctx, getProductPage, arg.AfterId, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProductPageRow{}
+	for rows.Next() {
+		var i GetProductPageRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.Stock,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Category,
+			&i.TaxClass,
+			&i.Weight,
+			&i.Sku,
+			&i.ReorderThreshold,
+			&i.BackorderPolicy,
+			&i.AvailableAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.CompareAtPrice,
+			&i.RatingAverage,
+			&i.RatingCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreProduct = `-- name: RestoreProduct :one
+UPDATE product
+SET
+    "deletedAt" = NULL,
+    "updatedAt" = NOW()
+WHERE id = $1 AND "deletedAt" IS NOT NULL
+RETURNING id, name, description, price, stock, "createdAt", "updatedAt", "createdBy", category, "taxClass", weight, sku, "reorderThreshold", "backorderPolicy", "availableAt", "deletedAt", version, "compareAtPrice"
+`
+
+func (q *Queries) RestoreProduct(ctx context.Context, id uuid.UUID) (Product, error) {
+	row := q.db.QueryRow(ctx, restoreProduct, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Stock,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.Category,
+		&i.TaxClass,
+		&i.Weight,
+		&i.Sku,
+		&i.ReorderThreshold,
+		&i.BackorderPolicy,
+		&i.AvailableAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CompareAtPrice,
 	)
 	return i, err
 }
@@ -204,17 +604,34 @@ SET
     description = $2,
     price = $3,
     stock = $4,
-    updated_at = NOW()
-WHERE id = $5
-RETURNING id, name, description, price, stock, "createdAt", "updatedAt", "createdBy"
+    category = $5,
+    "taxClass" = $6,
+    weight = $7,
+    sku = $8,
+    "reorderThreshold" = $9,
+    "backorderPolicy" = $10,
+    "availableAt" = $11,
+    "compareAtPrice" = $12,
+    version = version + 1,
+    "updatedAt" = NOW()
+WHERE id = $13
+RETURNING id, name, description, price, stock, "createdAt", "updatedAt", "createdBy", category, "taxClass", weight, sku, "reorderThreshold", "backorderPolicy", "availableAt", "deletedAt", version, "compareAtPrice"
 `
 
 type UpdateOneProductParams struct {
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	Stock       int32     `json:"stock"`
-	ID          uuid.UUID `json:"id"`
+	Name             string           `json:"name"`
+	Description      string           `json:"description"`
+	Price            float64          `json:"price"`
+	Stock            int32            `json:"stock"`
+	Category         string           `json:"category"`
+	TaxClass         string           `json:"taxClass"`
+	Weight           float64          `json:"weight"`
+	Sku              string           `json:"sku"`
+	ReorderThreshold int32            `json:"reorderThreshold"`
+	BackorderPolicy  BackorderPolicy  `json:"backorderPolicy"`
+	AvailableAt      pgtype.Timestamp `json:"availableAt"`
+	CompareAtPrice   pgtype.Float8    `json:"compareAtPrice"`
+	ID               uuid.UUID        `json:"id"`
 }
 
 func (q *Queries) UpdateOneProduct(ctx context.Context, arg UpdateOneProductParams) (Product, error) {
@@ -223,6 +640,14 @@ func (q *Queries) UpdateOneProduct(ctx context.Context, arg UpdateOneProductPara
 		arg.Description,
 		arg.Price,
 		arg.Stock,
+		arg.Category,
+		arg.TaxClass,
+		arg.Weight,
+		arg.Sku,
+		arg.ReorderThreshold,
+		arg.BackorderPolicy,
+		arg.AvailableAt,
+		arg.CompareAtPrice,
 		arg.ID,
 	)
 	var i Product
@@ -235,6 +660,16 @@ func (q *Queries) UpdateOneProduct(ctx context.Context, arg UpdateOneProductPara
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.CreatedBy,
+		&i.Category,
+		&i.TaxClass,
+		&i.Weight,
+		&i.Sku,
+		&i.ReorderThreshold,
+		&i.BackorderPolicy,
+		&i.AvailableAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CompareAtPrice,
 	)
 	return i, err
 }
@@ -243,9 +678,9 @@ const updateProductStock = `-- name: UpdateProductStock :one
 UPDATE product
 SET
     stock = stock - $2,
-    updated_at = NOW()
+    "updatedAt" = NOW()
 WHERE id = $1
-RETURNING id, name, description, price, stock, "createdAt", "updatedAt", "createdBy"
+RETURNING id, name, description, price, stock, "createdAt", "updatedAt", "createdBy", category, "taxClass", weight, sku, "reorderThreshold", "backorderPolicy", "availableAt", "deletedAt", version, "compareAtPrice"
 `
 
 type UpdateProductStockParams struct {
@@ -265,6 +700,16 @@ func (q *Queries) UpdateProductStock(ctx context.Context, arg UpdateProductStock
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.CreatedBy,
+		&i.Category,
+		&i.TaxClass,
+		&i.Weight,
+		&i.Sku,
+		&i.ReorderThreshold,
+		&i.BackorderPolicy,
+		&i.AvailableAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CompareAtPrice,
 	)
 	return i, err
 }
@@ -8,26 +8,190 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	AddWarehouseStock(ctx context.Context, arg AddWarehouseStockParams) (WarehouseStock, error)
+	AddWishlistItem(ctx context.Context, arg AddWishlistItemParams) (WishlistItem, error)
+	ArchiveProduct(ctx context.Context, id uuid.UUID) (Product, error)
 	CancelOrder(ctx context.Context, arg CancelOrderParams) (Order, error)
+	CancelProductPrice(ctx context.Context, arg CancelProductPriceParams) (ProductPrice, error)
+	ClaimGuestUser(ctx context.Context, arg ClaimGuestUserParams) (User, error)
+	ClaimJobs(ctx context.Context, arg ClaimJobsParams) ([]Job, error)
+	ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]OutboxEvent, error)
+	ClearPrimaryProductImage(ctx context.Context, productId uuid.UUID) error
+	CompleteJob(ctx context.Context, id uuid.UUID) (Job, error)
+	CountBackorderedItems(ctx context.Context, orderId uuid.UUID) (int64, error)
+	CountUndeliveredShipment(ctx context.Context, orderid uuid.UUID) (int64, error)
+	CountUserCouponRedemption(ctx context.Context, arg CountUserCouponRedemptionParams) (int64, error)
+	CountWebhookDeliveriesForEvent(ctx context.Context, eventId uuid.UUID) (int64, error)
 	CreateAdminUser(ctx context.Context, arg CreateAdminUserParams) (User, error)
+	CreateCoupon(ctx context.Context, arg CreateCouponParams) (Coupon, error)
+	CreateCouponRedemption(ctx context.Context, arg CreateCouponRedemptionParams) (CouponRedemption, error)
+	CreateGuestOrder(ctx context.Context, arg CreateGuestOrderParams) (GuestOrder, error)
+	CreateGuestUser(ctx context.Context, arg CreateGuestUserParams) (User, error)
+	CreateJob(ctx context.Context, arg CreateJobParams) (Job, error)
+	CreateLowStockAlert(ctx context.Context, arg CreateLowStockAlertParams) (LowStockAlert, error)
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
 	CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error)
+	CreateOrderAllocation(ctx context.Context, arg CreateOrderAllocationParams) (OrderAllocation, error)
+	CreateOrderPromotion(ctx context.Context, arg CreateOrderPromotionParams) (OrderPromotion, error)
+	CreateOrderTax(ctx context.Context, arg CreateOrderTaxParams) (OrderTax, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (OutboxEvent, error)
 	CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error)
+	CreateProductImage(ctx context.Context, arg CreateProductImageParams) (ProductImage, error)
+	CreateProductPrice(ctx context.Context, arg CreateProductPriceParams) (ProductPrice, error)
+	CreateProductReview(ctx context.Context, arg CreateProductReviewParams) (ProductReview, error)
+	CreatePromotion(ctx context.Context, arg CreatePromotionParams) (Promotion, error)
+	CreateReturnEvent(ctx context.Context, arg CreateReturnEventParams) (ReturnEvent, error)
+	CreateReturnItem(ctx context.Context, arg CreateReturnItemParams) (ReturnItem, error)
+	CreateReturnRequest(ctx context.Context, arg CreateReturnRequestParams) (ReturnRequest, error)
+	CreateShipment(ctx context.Context, arg CreateShipmentParams) (Shipment, error)
+	CreateShipmentItem(ctx context.Context, arg CreateShipmentItemParams) (ShipmentItem, error)
+	CreateShippingMethod(ctx context.Context, arg CreateShippingMethodParams) (ShippingMethod, error)
+	CreateShippingZone(ctx context.Context, arg CreateShippingZoneParams) (ShippingZone, error)
+	CreateStockMovement(ctx context.Context, arg CreateStockMovementParams) (StockMovement, error)
+	CreateStockTransfer(ctx context.Context, arg CreateStockTransferParams) (StockTransfer, error)
+	CreateTaxRule(ctx context.Context, arg CreateTaxRuleParams) (TaxRule, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
-	DeleteOneProduct(ctx context.Context, id uuid.UUID) error
+	CreateWarehouse(ctx context.Context, arg CreateWarehouseParams) (Warehouse, error)
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	CreateWishlist(ctx context.Context, arg CreateWishlistParams) (Wishlist, error)
+	DecrementCouponUsage(ctx context.Context, id uuid.UUID) (Coupon, error)
+	DeleteCouponRedemptionByOrderId(ctx context.Context, orderId uuid.UUID) ([]CouponRedemption, error)
+	DeleteOneCoupon(ctx context.Context, id uuid.UUID) error
+	DeleteOneProductImage(ctx context.Context, id uuid.UUID) error
+	DeleteOnePromotion(ctx context.Context, id uuid.UUID) (Promotion, error)
+	DeleteOneShippingMethod(ctx context.Context, id uuid.UUID) error
+	DeleteOneShippingZone(ctx context.Context, id uuid.UUID) error
+	DeleteOneTaxRule(ctx context.Context, id uuid.UUID) error
+	DeleteOneWebhook(ctx context.Context, id uuid.UUID) (Webhook, error)
+	DeleteProductReview(ctx context.Context, id uuid.UUID) (ProductReview, error)
+	DeleteWishlist(ctx context.Context, arg DeleteWishlistParams) (Wishlist, error)
+	DeleteWishlistItem(ctx context.Context, arg DeleteWishlistItemParams) (WishlistItem, error)
+	DeleteWishlistItems(ctx context.Context, arg DeleteWishlistItemsParams) (int64, error)
+	FailOutboxEvent(ctx context.Context, arg FailOutboxEventParams) (OutboxEvent, error)
+	GetActivePromotions(ctx context.Context) ([]Promotion, error)
+	GetActiveShippingMethodByZoneId(ctx context.Context, zoneid uuid.UUID) ([]ShippingMethod, error)
+	GetAllCoupon(ctx context.Context) ([]Coupon, error)
+	GetAllJob(ctx context.Context, arg GetAllJobParams) ([]Job, error)
 	GetAllOrderByUserId(ctx context.Context, userid uuid.UUID) ([]Order, error)
 	GetAllOrderItem(ctx context.Context, orderid uuid.UUID) ([]OrderItem, error)
-	GetAllProduct(ctx context.Context) ([]GetAllProductRow, error)
+	GetAllProduct(ctx context.Context, sort string) ([]GetAllProductRow, error)
 	GetAllProductInOrder(ctx context.Context, orderid uuid.UUID) ([]GetAllProductInOrderRow, error)
+	GetAllProductReview(ctx context.Context, arg GetAllProductReviewParams) ([]ProductReview, error)
+	GetAllPromotion(ctx context.Context) ([]Promotion, error)
+	GetAllReturnRequest(ctx context.Context) ([]ReturnRequest, error)
+	GetAllShippingMethod(ctx context.Context) ([]ShippingMethod, error)
+	GetAllShippingZone(ctx context.Context) ([]ShippingZone, error)
+	GetAllTaxRule(ctx context.Context) ([]TaxRule, error)
+	GetAllWarehouse(ctx context.Context) ([]Warehouse, error)
+	GetAllWebhook(ctx context.Context) ([]Webhook, error)
+	GetAllocatableStock(ctx context.Context, productIds []uuid.UUID) ([]GetAllocatableStockRow, error)
+	GetAvailableStock(ctx context.Context, productIds []uuid.UUID) ([]GetAvailableStockRow, error)
+	GetBackorderedItemsForUpdate(ctx context.Context, productId uuid.UUID) ([]GetBackorderedItemsForUpdateRow, error)
+	GetCouponByCode(ctx context.Context, code string) (Coupon, error)
+	GetEffectiveProductPrice(ctx context.Context, arg GetEffectiveProductPriceParams) (ProductPrice, error)
+	GetGuestOrder(ctx context.Context, orderId uuid.UUID) (GuestOrder, error)
+	GetJobStats(ctx context.Context) ([]GetJobStatsRow, error)
+	GetLastOrderStatusEventId(ctx context.Context) (int64, error)
+	GetLedgerStock(ctx context.Context, productId uuid.UUID) (int32, error)
+	GetLowStockProducts(ctx context.Context, arg GetLowStockProductsParams) ([]GetLowStockProductsRow, error)
 	GetMultipleProductById(ctx context.Context, dollar_1 []uuid.UUID) ([]GetMultipleProductByIdRow, error)
+	GetNotificationPreference(ctx context.Context, userId uuid.UUID) (NotificationPreference, error)
+	GetOneCoupon(ctx context.Context, id uuid.UUID) (Coupon, error)
+	GetOneJob(ctx context.Context, id uuid.UUID) (Job, error)
+	GetOneLowStockAlert(ctx context.Context, id uuid.UUID) (LowStockAlert, error)
+	GetOneNotification(ctx context.Context, id uuid.UUID) (Notification, error)
 	GetOneProduct(ctx context.Context, id uuid.UUID) (GetOneProductRow, error)
+	GetOneProductImage(ctx context.Context, id uuid.UUID) (ProductImage, error)
+	GetOneProductReview(ctx context.Context, id uuid.UUID) (ProductReview, error)
+	GetOnePromotion(ctx context.Context, id uuid.UUID) (Promotion, error)
+	GetOneReturnRequest(ctx context.Context, id uuid.UUID) (ReturnRequest, error)
+	GetOneShipment(ctx context.Context, id uuid.UUID) (Shipment, error)
+	GetOneShippingMethod(ctx context.Context, id uuid.UUID) (ShippingMethod, error)
+	GetOneShippingZone(ctx context.Context, id uuid.UUID) (ShippingZone, error)
+	GetOneTaxRule(ctx context.Context, id uuid.UUID) (TaxRule, error)
+	GetOneWarehouse(ctx context.Context, id uuid.UUID) (Warehouse, error)
+	GetOneWebhook(ctx context.Context, id uuid.UUID) (Webhook, error)
+	GetOneWebhookDelivery(ctx context.Context, id uuid.UUID) (WebhookDelivery, error)
+	GetOrderAllocations(ctx context.Context, orderId uuid.UUID) ([]GetOrderAllocationsRow, error)
 	GetOrderById(ctx context.Context, id uuid.UUID) (Order, error)
+	GetOrderForUpdate(ctx context.Context, id uuid.UUID) (Order, error)
+	GetOrderItemDetails(ctx context.Context, orderId uuid.UUID) ([]GetOrderItemDetailsRow, error)
+	GetOrderItemReturnableQuantity(ctx context.Context, orderid uuid.UUID) ([]GetOrderItemReturnableQuantityRow, error)
+	GetOrderItemShippedQuantity(ctx context.Context, orderid uuid.UUID) ([]GetOrderItemShippedQuantityRow, error)
+	GetOrderPromotionsByOrderIds(ctx context.Context, orderids []uuid.UUID) ([]OrderPromotion, error)
+	GetOrderStatusEventsAfter(ctx context.Context, arg GetOrderStatusEventsAfterParams) ([]OrderStatusEvent, error)
+	GetOrderStatusEventsSince(ctx context.Context, arg GetOrderStatusEventsSinceParams) ([]OrderStatusEvent, error)
+	GetOrderTaxByOrderIds(ctx context.Context, orderids []uuid.UUID) ([]OrderTax, error)
+	GetOutboxEventForUpdate(ctx context.Context, id uuid.UUID) (OutboxEvent, error)
+	GetPrimaryWarehouse(ctx context.Context) (Warehouse, error)
+	GetProductByNameForUpdate(ctx context.Context, name string) (Product, error)
+	GetProductBySkuForUpdate(ctx context.Context, sku string) (Product, error)
+	GetProductForUpdate(ctx context.Context, id uuid.UUID) (Product, error)
+	GetProductIdsForUpdate(ctx context.Context, productIds []uuid.UUID) ([]uuid.UUID, error)
+	GetProductImageByProductIds(ctx context.Context, productids []uuid.UUID) ([]ProductImage, error)
+	GetProductPage(ctx context.Context, arg GetProductPageParams) ([]GetProductPageRow, error)
+	GetProductPrices(ctx context.Context, arg GetProductPricesParams) ([]ProductPrice, error)
+	GetProductReviews(ctx context.Context, arg GetProductReviewsParams) ([]ProductReview, error)
+	GetProductWarehouseStock(ctx context.Context, productId uuid.UUID) ([]GetProductWarehouseStockRow, error)
+	GetProductWatchers(ctx context.Context, productId uuid.UUID) ([]GetProductWatchersRow, error)
+	GetReturnEventByReturnIds(ctx context.Context, returnids []uuid.UUID) ([]ReturnEvent, error)
+	GetReturnItemByReturnIds(ctx context.Context, returnids []uuid.UUID) ([]ReturnItem, error)
+	GetReturnRequestByOrderId(ctx context.Context, orderid uuid.UUID) ([]ReturnRequest, error)
+	GetReturnRequestForUpdate(ctx context.Context, id uuid.UUID) (ReturnRequest, error)
+	GetShipmentByOrderId(ctx context.Context, orderid uuid.UUID) ([]Shipment, error)
+	GetShipmentItemByShipmentIds(ctx context.Context, shipmentids []uuid.UUID) ([]ShipmentItem, error)
+	GetStockDiscrepancies(ctx context.Context) ([]GetStockDiscrepanciesRow, error)
+	GetStockMovements(ctx context.Context, arg GetStockMovementsParams) ([]StockMovement, error)
+	GetStockTransfers(ctx context.Context, arg GetStockTransfersParams) ([]StockTransfer, error)
+	GetTaxRuleByTaxClass(ctx context.Context, taxclasses []string) ([]TaxRule, error)
+	GetUndeliveredWebhookDelivery(ctx context.Context, id uuid.UUID) (GetUndeliveredWebhookDeliveryRow, error)
 	GetUserById(ctx context.Context, email string) (GetUserByIdRow, error)
+	GetUserEmail(ctx context.Context, id uuid.UUID) (string, error)
+	GetUserWishlist(ctx context.Context, arg GetUserWishlistParams) (Wishlist, error)
+	GetUserWishlists(ctx context.Context, userId uuid.UUID) ([]Wishlist, error)
+	GetWarehouseStock(ctx context.Context, warehouseId uuid.UUID) ([]GetWarehouseStockRow, error)
+	GetWarehouseStockForUpdate(ctx context.Context, arg GetWarehouseStockForUpdateParams) (WarehouseStock, error)
+	GetWebhookDeliveries(ctx context.Context, arg GetWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	GetWebhooksForEvent(ctx context.Context, eventType string) ([]Webhook, error)
+	GetWishlistByShareToken(ctx context.Context, shareToken pgtype.Text) (Wishlist, error)
+	GetWishlistItems(ctx context.Context, wishlistIds []uuid.UUID) ([]GetWishlistItemsRow, error)
+	HasPurchasedProduct(ctx context.Context, arg HasPurchasedProductParams) (bool, error)
+	IncrementCouponUsage(ctx context.Context, id uuid.UUID) (Coupon, error)
+	KillJob(ctx context.Context, arg KillJobParams) (Job, error)
+	MarkLowStockAlertNotified(ctx context.Context, arg MarkLowStockAlertNotifiedParams) (LowStockAlert, error)
+	MarkOutboxEventDispatched(ctx context.Context, id uuid.UUID) (OutboxEvent, error)
+	RecordNotificationAttempt(ctx context.Context, arg RecordNotificationAttemptParams) (Notification, error)
+	RecordWebhookDeliveryAttempt(ctx context.Context, arg RecordWebhookDeliveryAttemptParams) (WebhookDelivery, error)
+	RequeueStaleJobs(ctx context.Context, lockedBefore pgtype.Timestamp) (int64, error)
+	ResolveLowStockAlerts(ctx context.Context, productId uuid.UUID) (int64, error)
+	RestoreProduct(ctx context.Context, id uuid.UUID) (Product, error)
+	ResurrectJob(ctx context.Context, arg ResurrectJobParams) (Job, error)
+	RetryJob(ctx context.Context, arg RetryJobParams) (Job, error)
+	RetryOutboxEvent(ctx context.Context, arg RetryOutboxEventParams) (OutboxEvent, error)
+	SetWishlistShareToken(ctx context.Context, arg SetWishlistShareTokenParams) (Wishlist, error)
+	UpdateOneCoupon(ctx context.Context, arg UpdateOneCouponParams) (Coupon, error)
 	UpdateOneProduct(ctx context.Context, arg UpdateOneProductParams) (Product, error)
+	UpdateOnePromotion(ctx context.Context, arg UpdateOnePromotionParams) (Promotion, error)
+	UpdateOneShipment(ctx context.Context, arg UpdateOneShipmentParams) (Shipment, error)
+	UpdateOneShippingMethod(ctx context.Context, arg UpdateOneShippingMethodParams) (ShippingMethod, error)
+	UpdateOneShippingZone(ctx context.Context, arg UpdateOneShippingZoneParams) (ShippingZone, error)
+	UpdateOneTaxRule(ctx context.Context, arg UpdateOneTaxRuleParams) (TaxRule, error)
+	UpdateOneWebhook(ctx context.Context, arg UpdateOneWebhookParams) (Webhook, error)
+	UpdateOrderItemBackordered(ctx context.Context, arg UpdateOrderItemBackorderedParams) (OrderItem, error)
 	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (Order, error)
+	UpdateProductImagePosition(ctx context.Context, arg UpdateProductImagePositionParams) (ProductImage, error)
+	UpdateProductPrice(ctx context.Context, arg UpdateProductPriceParams) (Product, error)
+	UpdateProductReviewStatus(ctx context.Context, arg UpdateProductReviewStatusParams) (ProductReview, error)
 	UpdateProductStock(ctx context.Context, arg UpdateProductStockParams) (Product, error)
+	UpdateReturnRequestStatus(ctx context.Context, arg UpdateReturnRequestStatusParams) (ReturnRequest, error)
+	UpdateWarehouse(ctx context.Context, arg UpdateWarehouseParams) (Warehouse, error)
+	UpdateWishlistName(ctx context.Context, arg UpdateWishlistNameParams) (Wishlist, error)
+	UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) (NotificationPreference, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -2,27 +2,102 @@ package db
 
 import (
 	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
 )
 
 // Store defines all functions to execute db queries and transactions
 type Store interface {
 	Querier
+	CreateProductTx(ctx context.Context, arg CreateProductParams) (Product, error, error)
 	UpdateProductTx(ctx context.Context, arg UpdateProductTxParams) (Product, error, error)
+	DeleteProductTx(ctx context.Context, productId uuid.UUID) (Product, error, error)
+	RestoreProductTx(ctx context.Context, productId uuid.UUID) (Product, error, error)
+	ScheduleProductPriceTx(ctx context.Context, arg CreateProductPriceParams) (ScheduleProductPriceTxResult, error, error)
+	CancelProductPriceTx(ctx context.Context, arg CancelProductPriceParams) (ScheduleProductPriceTxResult, error, error)
+	ApplyProductPriceTx(ctx context.Context, productId uuid.UUID) (Product, error, error)
 	CreateOrderTx(ctx context.Context, arg CreateOrderTxParams) (Order, map[string]string, error, error)
+	PreviewOrder(ctx context.Context, arg CreateOrderTxParams) (OrderPreview, map[string]string, error)
 	UpdateOrderTx(ctx context.Context, arg UpdateOrderTxParams) (Order, error)
+	UpdateCouponTx(ctx context.Context, arg UpdateCouponTxParams) (Coupon, error, error)
+	UpdateTaxRuleTx(ctx context.Context, arg UpdateTaxRuleTxParams) (TaxRule, error, error)
+	UpdateShippingZoneTx(ctx context.Context, arg UpdateShippingZoneTxParams) (ShippingZone, error, error)
+	UpdateShippingMethodTx(ctx context.Context, arg UpdateShippingMethodTxParams) (ShippingMethod, error, error)
+	QuoteShipping(ctx context.Context, arg QuoteShippingParams) (ShippingQuote, map[string]string, error)
+	CreateShipmentTx(ctx context.Context, arg CreateShipmentTxParams) (ShipmentTxResult, map[string]string, error, error)
+	UpdateShipmentTx(ctx context.Context, arg UpdateShipmentTxParams) (ShipmentTxResult, error, error)
+	CreateReturnTx(ctx context.Context, arg CreateReturnTxParams) (ReturnTxResult, map[string]string, error, error)
+	UpdateReturnStatusTx(ctx context.Context, arg UpdateReturnStatusTxParams) (ReturnTxResult, error, error)
+	CreateProductImageTx(ctx context.Context, arg CreateProductImageTxParams) (ProductImage, map[string]string, error, error)
+	ReorderProductImageTx(ctx context.Context, arg ReorderProductImageTxParams) ([]ProductImage, map[string]string, error, error)
+	DeleteProductImageTx(ctx context.Context, productId uuid.UUID, imageId uuid.UUID) (ProductImage, error, error)
+	ImportProductTx(ctx context.Context, arg ImportProductTxParams) ([]ImportedProduct, map[int]map[string]string, error, error)
+	UpdateWebhookTx(ctx context.Context, arg UpdateWebhookTxParams) (Webhook, error, error)
+	CreateWebhookDeliveriesTx(ctx context.Context, eventId uuid.UUID) ([]WebhookDelivery, error, error)
+	RedeliverWebhookTx(ctx context.Context, deliveryId uuid.UUID) (WebhookDelivery, error, error)
+	CreateNotificationTx(ctx context.Context, arg CreateNotificationTxParams) (Notification, error, error)
+	UpdateWarehouseTx(ctx context.Context, arg UpdateWarehouseTxParams) (Warehouse, error, error)
+	SetWarehouseStockTx(ctx context.Context, arg SetWarehouseStockTxParams) (Product, error, error)
+	TransferStockTx(ctx context.Context, arg TransferStockTxParams) (StockTransfer, error, error)
+	Listen(ctx context.Context, channel string, listening func(), notify func(payload string)) error
+}
+
+// Pool is what a SQLStore runs its queries and transactions on, usually a
+// *pgxpool.Pool
+type Pool interface {
+	DBTX
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
 // SQLStore provides all functions to execute SQL queries and transactions
 type SQLStore struct {
-	connPool *pgxpool.Pool
+	connPool Pool
 	*Queries
 }
 
 // NewStore creates a new store
-func NewStore(connPool *pgxpool.Pool) Store {
+func NewStore(connPool Pool) Store {
 	return &SQLStore{
 		connPool: connPool,
 		Queries:  New(connPool),
 	}
 }
+
+// Listen listens to the notifications sent on channel until ctx is done or
+// the connection fails. It holds a connection of the pool meanwhile.
+// listening is called once the channel is listened to: notifications sent
+// before are not received. notify is called with the payload of every
+// notification, one at a time.
+func (store *SQLStore) Listen(ctx context.Context, channel string, listening func(), notify func(payload string)) error {
+	acquirer, ok := store.connPool.(interface {
+		Acquire(ctx context.Context) (*pgxpool.Conn, error)
+	})
+	if !ok {
+		return errors.New("the pool of the store cannot hand out a connection to listen on")
+	}
+	pooled, err := acquirer.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection keeps listening to channel, so it must not go back to the pool
+	conn := pooled.Hijack()
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		conn.Close(closeCtx)
+	}()
+	if _, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+	listening()
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		notify(notification.Payload)
+	}
+}
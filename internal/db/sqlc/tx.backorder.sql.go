@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/fulfilment"
+)
+
+// allocatableStock locks and returns the stock the active warehouses hold of
+// the products, the warehouses in order of priority, along with how many
+// units of each product they hold together.
+func (q *Queries) allocatableStock(ctx context.Context, productIds []uuid.UUID) ([]fulfilment.Stock, map[uuid.UUID]int32, error) {
+	rows, err := q.GetAllocatableStock(ctx, productIds)
+	if err != nil {
+		return nil, nil, err
+	}
+	stock := make([]fulfilment.Stock, len(rows))
+	held := make(map[uuid.UUID]int32)
+	for i, row := range rows {
+		stock[i] = fulfilment.Stock{WarehouseId: row.WarehouseId, ProductId: row.ProductId, Stock: row.Stock}
+		held[row.ProductId] += row.Stock
+	}
+	return stock, held, nil
+}
+
+// availableStock returns the stock the active warehouses hold of the products
+// like allocatableStock, without locking it.
+func (q *Queries) availableStock(ctx context.Context, productIds []uuid.UUID) ([]fulfilment.Stock, map[uuid.UUID]int32, error) {
+	rows, err := q.GetAvailableStock(ctx, productIds)
+	if err != nil {
+		return nil, nil, err
+	}
+	stock := make([]fulfilment.Stock, len(rows))
+	held := make(map[uuid.UUID]int32)
+	for i, row := range rows {
+		stock[i] = fulfilment.Stock{WarehouseId: row.WarehouseId, ProductId: row.ProductId, Stock: row.Stock}
+		held[row.ProductId] += row.Stock
+	}
+	return stock, held, nil
+}
+
+// shipFromWarehouses takes the allocated units of an order off the stock of
+// their warehouses and records the allocations against the order items,
+// itemIds giving the order item of each product. It returns the product of
+// the last allocation after the change.
+func (q *Queries) shipFromWarehouses(ctx context.Context, orderId, userId uuid.UUID, itemIds map[uuid.UUID]uuid.UUID, allocations []fulfilment.Allocation) (Product, error) {
+	var product Product
+	var err error
+	for _, allocation := range allocations {
+		product, err = q.removeStock(ctx, allocation.ProductId, allocation.Quantity, StockChange{
+			Kind:        StockMovementKindORDERPLACED,
+			OrderId:     orderId,
+			ActorId:     userId,
+			WarehouseId: allocation.WarehouseId,
+		})
+		if err != nil {
+			return product, err
+		}
+		_, err = q.CreateOrderAllocation(ctx, CreateOrderAllocationParams{
+			ID:          uuid.New(),
+			OrderId:     orderId,
+			OrderItemId: itemIds[allocation.ProductId],
+			ProductId:   allocation.ProductId,
+			WarehouseId: allocation.WarehouseId,
+			Quantity:    allocation.Quantity,
+		})
+		if err != nil {
+			return product, err
+		}
+	}
+	return product, nil
+}
+
+// allocateBackorders hands the stock of a product just restocked to the
+// backordered orders waiting for it, oldest order first. Orders left with
+// nothing backordered move from BACKORDERED to PENDING. It returns the
+// product after the change.
+func (q *Queries) allocateBackorders(ctx context.Context, product Product) (Product, error) {
+	items, err := q.GetBackorderedItemsForUpdate(ctx, product.ID)
+	if err != nil {
+		return product, err
+	}
+	for _, item := range items {
+		stock, held, err := q.allocatableStock(ctx, []uuid.UUID{product.ID})
+		if err != nil {
+			return product, err
+		}
+		quantity := min(item.Backordered, held[product.ID])
+		if quantity == 0 {
+			break
+		}
+		allocations, err := fulfilment.Allocate([]fulfilment.Line{{ProductId: product.ID, Quantity: quantity}}, stock)
+		if err != nil {
+			return product, err
+		}
+		product, err = q.shipFromWarehouses(ctx, item.OrderId, item.UserId, map[uuid.UUID]uuid.UUID{product.ID: item.ID}, allocations)
+		if err != nil {
+			return product, err
+		}
+		_, err = q.UpdateOrderItemBackordered(ctx, UpdateOrderItemBackorderedParams{
+			ID:          item.ID,
+			Backordered: item.Backordered - quantity,
+		})
+		if err != nil {
+			return product, err
+		}
+		// The stock ran out before the item got all of its units
+		if quantity < item.Backordered {
+			break
+		}
+		remaining, err := q.CountBackorderedItems(ctx, item.OrderId)
+		if err != nil {
+			return product, err
+		}
+		if remaining > 0 {
+			continue
+		}
+		order, err := q.GetOrderForUpdate(ctx, item.OrderId)
+		if err != nil {
+			return product, err
+		}
+		if _, err = q.updateOrderStatus(ctx, order, OrderStatusPENDING); err != nil {
+			return product, err
+		}
+	}
+	return product, nil
+}
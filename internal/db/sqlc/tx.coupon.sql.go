@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/coupon"
+	"time"
+)
+
+type UpdateCouponTxParams struct {
+	ID            uuid.UUID         `json:"id"`
+	Code          *string           `json:"code,omitempty"`
+	DiscountType  *DiscountType     `json:"discountType,omitempty"`
+	Value         *float64          `json:"value,omitempty"`
+	MinOrderValue *float64          `json:"minOrderValue,omitempty"`
+	ProductIds    *[]uuid.UUID      `json:"productIds,omitempty"`
+	Categories    *[]string         `json:"categories,omitempty"`
+	UsageLimit    *int32            `json:"usageLimit,omitempty"`
+	PerUserLimit  *int32            `json:"perUserLimit,omitempty"`
+	StartsAt      *pgtype.Timestamp `json:"startsAt,omitempty"`
+	ExpiresAt     *pgtype.Timestamp `json:"expiresAt,omitempty"`
+	Active        *bool             `json:"active,omitempty"`
+}
+
+func (store *SQLStore) UpdateCouponTx(ctx context.Context, arg UpdateCouponTxParams) (Coupon, error, error) {
+	var result Coupon
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		current, err := q.GetOneCoupon(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if arg.Code == nil {
+			arg.Code = &current.Code
+		}
+		if arg.DiscountType == nil {
+			arg.DiscountType = &current.DiscountType
+		}
+		if arg.Value == nil {
+			arg.Value = &current.Value
+		}
+		if arg.MinOrderValue == nil {
+			arg.MinOrderValue = &current.MinOrderValue
+		}
+		if arg.ProductIds == nil {
+			arg.ProductIds = &current.ProductIds
+		}
+		if arg.Categories == nil {
+			arg.Categories = &current.Categories
+		}
+		if arg.UsageLimit == nil {
+			arg.UsageLimit = &current.UsageLimit
+		}
+		if arg.PerUserLimit == nil {
+			arg.PerUserLimit = &current.PerUserLimit
+		}
+		if arg.StartsAt == nil {
+			arg.StartsAt = &current.StartsAt
+		}
+		if arg.ExpiresAt == nil {
+			arg.ExpiresAt = &current.ExpiresAt
+		}
+		if arg.Active == nil {
+			arg.Active = &current.Active
+		}
+		result, err = q.UpdateOneCoupon(ctx, UpdateOneCouponParams{
+			ID:            arg.ID,
+			Code:          *arg.Code,
+			DiscountType:  *arg.DiscountType,
+			Value:         *arg.Value,
+			MinOrderValue: *arg.MinOrderValue,
+			ProductIds:    *arg.ProductIds,
+			Categories:    *arg.Categories,
+			UsageLimit:    *arg.UsageLimit,
+			PerUserLimit:  *arg.PerUserLimit,
+			StartsAt:      *arg.StartsAt,
+			ExpiresAt:     *arg.ExpiresAt,
+			Active:        *arg.Active,
+		})
+		return err
+	})
+	return result, execErr, txErr
+}
+
+// applyCoupon looks up the coupon with the given code and works out the discount
+// it gives on the order lines. A non-empty message is returned when the coupon
+// cannot be redeemed by the user.
+func (store *SQLStore) applyCoupon(ctx context.Context, code string, userId uuid.UUID, lines []coupon.Line) (Coupon, float64, string, error) {
+	appliedCoupon, err := store.GetCouponByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return appliedCoupon, 0, "coupon not found", nil
+		}
+		return appliedCoupon, 0, "", err
+	}
+	redemptions, err := store.CountUserCouponRedemption(ctx, CountUserCouponRedemptionParams{
+		CouponId: appliedCoupon.ID,
+		UserId:   userId,
+	})
+	if err != nil {
+		return appliedCoupon, 0, "", err
+	}
+	discount, err := coupon.Discount(couponRule(appliedCoupon), lines, redemptions, time.Now())
+	if err != nil {
+		return appliedCoupon, 0, err.Error(), nil
+	}
+	return appliedCoupon, discount, "", nil
+}
+
+// couponRule converts a stored coupon into the rule evaluated at checkout.
+func couponRule(c Coupon) coupon.Rule {
+	return coupon.Rule{
+		DiscountType:  string(c.DiscountType),
+		Value:         c.Value,
+		MinOrderValue: c.MinOrderValue,
+		ProductIds:    c.ProductIds,
+		Categories:    c.Categories,
+		UsageLimit:    c.UsageLimit,
+		UsageCount:    c.UsageCount,
+		PerUserLimit:  c.PerUserLimit,
+		StartsAt:      c.StartsAt.Time,
+		ExpiresAt:     c.ExpiresAt.Time,
+		Active:        c.Active,
+	}
+}
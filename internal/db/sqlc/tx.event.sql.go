@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+)
+
+// Types of the domain events.
+const (
+	EventOrderCreated       = "order.created"
+	EventOrderStatusChanged = "order.status_changed"
+	EventProductCreated     = "product.created"
+	EventProductUpdated     = "product.updated"
+	EventProductDeleted     = "product.deleted"
+	EventProductStockLow    = "product.stock_low"
+	EventProductBackInStock = "product.back_in_stock"
+)
+
+// EventTypes lists the types of the domain events.
+var EventTypes = []string{
+	EventOrderCreated,
+	EventOrderStatusChanged,
+	EventProductCreated,
+	EventProductUpdated,
+	EventProductDeleted,
+	EventProductStockLow,
+	EventProductBackInStock,
+}
+
+// Event is a domain event. Transactions save the events they raise to the
+// outbox, from where they are relayed once the transaction commits.
+type Event interface {
+	EventType() string
+}
+
+// OrderEventItem is a product of the order in an order event.
+type OrderEventItem struct {
+	ProductId uuid.UUID `json:"productId"`
+	Quantity  int32     `json:"quantity"`
+	Price     float64   `json:"price"`
+	Tax       float64   `json:"tax"`
+	// Backordered is how many of the units wait for the product to be restocked
+	Backordered int32 `json:"backordered,omitempty"`
+}
+
+// OrderCreated is raised when an order is placed.
+type OrderCreated struct {
+	Order Order            `json:"order"`
+	Items []OrderEventItem `json:"items"`
+}
+
+func (OrderCreated) EventType() string { return EventOrderCreated }
+
+// OrderStatusChanged is raised when an order moves to another status.
+type OrderStatusChanged struct {
+	Order          Order       `json:"order"`
+	PreviousStatus OrderStatus `json:"previousStatus"`
+}
+
+func (OrderStatusChanged) EventType() string { return EventOrderStatusChanged }
+
+// ProductCreated is raised when a product is added to the catalog.
+type ProductCreated struct {
+	Product Product `json:"product"`
+}
+
+func (ProductCreated) EventType() string { return EventProductCreated }
+
+// ProductUpdated is raised when an admin changes a product.
+type ProductUpdated struct {
+	Product Product `json:"product"`
+}
+
+func (ProductUpdated) EventType() string { return EventProductUpdated }
+
+// ProductDeleted is raised when a product is removed from the catalog.
+type ProductDeleted struct {
+	Product Product `json:"product"`
+}
+
+func (ProductDeleted) EventType() string { return EventProductDeleted }
+
+// ProductStockLow is raised when the stock of a product falls to its reorder
+// threshold or below, along with the alert it opened.
+type ProductStockLow struct {
+	Product   Product   `json:"product"`
+	Threshold int32     `json:"threshold"`
+	AlertId   uuid.UUID `json:"alertId"`
+}
+
+func (ProductStockLow) EventType() string { return EventProductStockLow }
+
+// ProductBackInStock is raised when a product that was out of stock is
+// restocked.
+type ProductBackInStock struct {
+	Product Product `json:"product"`
+}
+
+func (ProductBackInStock) EventType() string { return EventProductBackInStock }
+
+// publish saves events to the outbox within the transaction of q, so that
+// they are only relayed if it commits.
+func (q *Queries) publish(ctx context.Context, events ...Event) error {
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		_, err = q.CreateOutboxEvent(ctx, CreateOutboxEventParams{
+			ID:      uuid.New(),
+			Type:    event.EventType(),
+			Payload: payload,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateOrderStatus moves an order to status and raises OrderStatusChanged.
+func (q *Queries) updateOrderStatus(ctx context.Context, order Order, status OrderStatus) (Order, error) {
+	updated, err := q.UpdateOrderStatus(ctx, UpdateOrderStatusParams{
+		ID:     order.ID,
+		Status: status,
+	})
+	if err != nil || updated.Status == order.Status {
+		return updated, err
+	}
+	return updated, q.publish(ctx, OrderStatusChanged{Order: updated, PreviousStatus: order.Status})
+}
+
+// removeStock takes quantity off the stock of a product, records the
+// movement in the stock ledger, and raises ProductStockLow when that leaves
+// the product low on stock. A negative quantity restocks the product, which
+// raises ProductBackInStock when it was out of stock.
+func (q *Queries) removeStock(ctx context.Context, productId uuid.UUID, quantity int32, change StockChange) (Product, error) {
+	product, err := q.UpdateProductStock(ctx, UpdateProductStockParams{
+		ID:    productId,
+		Stock: quantity,
+	})
+	if err != nil {
+		return product, err
+	}
+	if err = q.recordStockMovement(ctx, product, -quantity, change); err != nil {
+		return product, err
+	}
+	if err = q.checkStockLow(ctx, product, product.Stock+quantity <= product.ReorderThreshold); err != nil {
+		return product, err
+	}
+	return product, q.checkBackInStock(ctx, product, product.Stock+quantity)
+}
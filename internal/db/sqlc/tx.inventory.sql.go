@@ -0,0 +1,171 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/fulfilment"
+)
+
+// DefaultReorderThreshold is the reorder threshold of products created
+// without one.
+const DefaultReorderThreshold int32 = 5
+
+// IsLowStock tells if a product is at or below its reorder threshold.
+func IsLowStock(product Product) bool {
+	return product.Stock <= product.ReorderThreshold
+}
+
+// checkStockLow keeps the low-stock alert of a product in step with its
+// stock, wasLow telling if it was low on stock before the change. A product
+// falling to its reorder threshold or below opens an alert and raises
+// ProductStockLow, one restocked above it has its alert resolved.
+func (q *Queries) checkStockLow(ctx context.Context, product Product, wasLow bool) error {
+	isLow := IsLowStock(product)
+	if isLow && !wasLow {
+		alert, err := q.CreateLowStockAlert(ctx, CreateLowStockAlertParams{
+			ID:        uuid.New(),
+			ProductId: product.ID,
+			Stock:     product.Stock,
+			Threshold: product.ReorderThreshold,
+		})
+		// The product already has an open alert
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return q.publish(ctx, ProductStockLow{Product: product, Threshold: product.ReorderThreshold, AlertId: alert.ID})
+	}
+	if !isLow && wasLow {
+		_, err := q.ResolveLowStockAlerts(ctx, product.ID)
+		return err
+	}
+	return nil
+}
+
+// checkBackInStock raises ProductBackInStock when a product out of stock,
+// previousStock units before the change, is restocked.
+func (q *Queries) checkBackInStock(ctx context.Context, product Product, previousStock int32) error {
+	if previousStock > 0 || product.Stock <= 0 {
+		return nil
+	}
+	return q.publish(ctx, ProductBackInStock{Product: product})
+}
+
+// StockChange tells why the stock of a product changes, and is recorded in
+// its stock movement. WarehouseId is the warehouse the units are added to or
+// taken from. When not set, units are added to the primary warehouse and
+// taken off the active warehouses in order of priority.
+type StockChange struct {
+	Kind        StockMovementKind
+	OrderId     uuid.UUID
+	ReturnId    uuid.UUID
+	ActorId     uuid.UUID
+	WarehouseId uuid.UUID
+	Note        string
+}
+
+func nullUUID(id uuid.UUID) pgtype.UUID {
+	return pgtype.UUID{Bytes: id, Valid: id != uuid.Nil}
+}
+
+// recordStockMovement adds quantity units, taken off when negative, of a
+// product to the stock of a warehouse and to the stock ledger. product is the
+// product after the change. Units taken off without a warehouse come off the
+// active warehouses the way fulfilment.Allocate ships an order, so that any
+// stock they hold together can be taken off; it fails with
+// fulfilment.ErrNotEnoughStock when they hold fewer units than are taken off.
+func (q *Queries) recordStockMovement(ctx context.Context, product Product, quantity int32, change StockChange) error {
+	if quantity == 0 {
+		return nil
+	}
+	if quantity > 0 || change.WarehouseId != uuid.Nil {
+		return q.recordWarehouseStockMovement(ctx, product.ID, product.Stock, quantity, change)
+	}
+	stock, held, err := q.allocatableStock(ctx, []uuid.UUID{product.ID})
+	if err != nil {
+		return err
+	}
+	if len(stock) == 0 {
+		return fulfilment.ErrNoWarehouse
+	}
+	if held[product.ID] < -quantity {
+		return fulfilment.ErrNotEnoughStock
+	}
+	allocations, err := fulfilment.Allocate([]fulfilment.Line{{ProductId: product.ID, Quantity: -quantity}}, stock)
+	if err != nil {
+		return err
+	}
+	// Each movement records the stock left once it is taken off
+	stockAfter := product.Stock - quantity
+	for _, allocation := range allocations {
+		stockAfter -= allocation.Quantity
+		change.WarehouseId = allocation.WarehouseId
+		err = q.recordWarehouseStockMovement(ctx, product.ID, stockAfter, -allocation.Quantity, change)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordWarehouseStockMovement adds quantity units, taken off when negative,
+// of a product to the stock of the warehouse of change, and to the stock
+// ledger with stockAfter as the stock of the product after the movement.
+func (q *Queries) recordWarehouseStockMovement(ctx context.Context, productId uuid.UUID, stockAfter, quantity int32, change StockChange) error {
+	warehouseId, err := q.addWarehouseStock(ctx, change.WarehouseId, productId, quantity)
+	if err != nil {
+		return err
+	}
+	_, err = q.CreateStockMovement(ctx, CreateStockMovementParams{
+		ProductId:   productId,
+		Quantity:    quantity,
+		StockAfter:  stockAfter,
+		Kind:        change.Kind,
+		OrderId:     nullUUID(change.OrderId),
+		ReturnId:    nullUUID(change.ReturnId),
+		CreatedBy:   nullUUID(change.ActorId),
+		Note:        change.Note,
+		WarehouseId: nullUUID(warehouseId),
+	})
+	return err
+}
+
+// addWarehouseStock adds quantity units, taken off when negative, of a product
+// to the stock of a warehouse, the primary warehouse when warehouseId is not
+// set, and returns the warehouse. It fails with fulfilment.ErrNotEnoughStock
+// when the warehouse holds fewer units than are taken off.
+func (q *Queries) addWarehouseStock(ctx context.Context, warehouseId, productId uuid.UUID, quantity int32) (uuid.UUID, error) {
+	if warehouseId == uuid.Nil {
+		warehouse, err := q.GetPrimaryWarehouse(ctx)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return warehouseId, fulfilment.ErrNoWarehouse
+		}
+		if err != nil {
+			return warehouseId, err
+		}
+		warehouseId = warehouse.ID
+	}
+	if quantity < 0 {
+		stock, err := q.GetWarehouseStockForUpdate(ctx, GetWarehouseStockForUpdateParams{
+			WarehouseId: warehouseId,
+			ProductId:   productId,
+		})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return warehouseId, err
+		}
+		if stock.Stock+quantity < 0 {
+			return warehouseId, fulfilment.ErrNotEnoughStock
+		}
+	}
+	_, err := q.AddWarehouseStock(ctx, AddWarehouseStockParams{
+		WarehouseId: warehouseId,
+		ProductId:   productId,
+		Stock:       quantity,
+	})
+	return warehouseId, err
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// SendNotificationJob is the kind of the jobs sending a notification email.
+const SendNotificationJob = "notification.send"
+
+// notificationMaxAttempts gives a mail server a few minutes to recover.
+const notificationMaxAttempts = 5
+
+// SendNotificationArgs is the payload of a SendNotificationJob.
+type SendNotificationArgs struct {
+	NotificationId uuid.UUID `json:"notificationId"`
+}
+
+// CreateNotificationTxParams describes an email raised by an event.
+type CreateNotificationTxParams struct {
+	UserId  uuid.UUID        `json:"userId"`
+	OrderId uuid.UUID        `json:"orderId"`
+	EventId uuid.UUID        `json:"eventId"`
+	Kind    NotificationKind `json:"kind"`
+}
+
+// CreateNotificationTx records an email to the user along with the job
+// sending it. An event raises each email once: when it was already recorded
+// pgx.ErrNoRows is returned and no job is enqueued.
+func (store *SQLStore) CreateNotificationTx(ctx context.Context, arg CreateNotificationTxParams) (Notification, error, error) {
+	var notification Notification
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		email, err := q.GetUserEmail(ctx, arg.UserId)
+		if err != nil {
+			return err
+		}
+		notification, err = q.CreateNotification(ctx, CreateNotificationParams{
+			ID:      uuid.New(),
+			UserId:  arg.UserId,
+			OrderId: arg.OrderId,
+			EventId: arg.EventId,
+			Kind:    arg.Kind,
+			Email:   email,
+		})
+		if err != nil {
+			return err
+		}
+		return q.enqueueJob(ctx, SendNotificationJob, SendNotificationArgs{NotificationId: notification.ID}, notificationMaxAttempts)
+	})
+	return notification, execErr, txErr
+}
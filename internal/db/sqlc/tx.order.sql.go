@@ -2,80 +2,314 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
-	"math"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/coupon"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/fulfilment"
 	"strings"
 )
 
 type CreateOrderTxParams struct {
-	ID         uuid.UUID           `json:"id"`
-	UserId     uuid.UUID           `json:"userId"`
-	ProductIds []uuid.UUID         `json:"productIds"`
-	Items      map[uuid.UUID]int32 `json:"items"`
+	ID               uuid.UUID           `json:"id"`
+	UserId           uuid.UUID           `json:"userId"`
+	ProductIds       []uuid.UUID         `json:"productIds"`
+	Items            map[uuid.UUID]int32 `json:"items"`
+	CouponCode       string              `json:"couponCode,omitempty"`
+	ShippingRegion   string              `json:"shippingRegion,omitempty"`
+	ShippingMethodId uuid.UUID           `json:"shippingMethodId,omitempty"`
+	// Guest holds the email and address of an order placed as a guest,
+	// recorded along with the order. It is nil for customers' orders.
+	Guest *CreateGuestOrderParams `json:"guest,omitempty"`
 }
 
 func (store *SQLStore) CreateOrderTx(ctx context.Context, arg CreateOrderTxParams) (Order, map[string]string, error, error) {
 	var order Order
-	var invalidProducts = make(map[string]string)
-	var orderTotal float64
-	var prices = make(map[uuid.UUID]float64)
-	var orderQuantities = make(map[uuid.UUID]int32)
-	var values []interface{}
-	var placeholders []string
-	products, err := store.GetMultipleProductById(ctx, arg.ProductIds)
-	if err != nil {
+	pricing, invalidProducts, err := store.priceOrder(ctx, arg)
+	if err != nil || len(invalidProducts) > 0 {
 		return order, invalidProducts, err, nil
 	}
-	for i, product := range products {
-		prices[product.ID] = product.Price
-		quantity, ok := arg.Items[product.ID]
-		if !ok {
-			invalidProducts[product.ID.String()] = "product not found"
-		}
-		if quantity > product.Stock {
-			invalidProducts[product.ID.String()] = "quantity less than available stock"
-		}
-		orderQuantities[product.ID] = quantity
-		productPrice := math.Round((product.Price*float64(quantity))*100) / 100
-		// Create a group of placeholders for each record
-		placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)",
-			i*5+1, i*5+2, i*5+3, i*5+4, i*5+5))
-		values = append(values, uuid.New(), arg.ID, product.ID, quantity, productPrice)
-		orderTotal += productPrice
+	itemIds := make(map[uuid.UUID]uuid.UUID, len(pricing.Lines))
+	productIds := make([]uuid.UUID, len(pricing.Lines))
+	for i, line := range pricing.Lines {
+		itemIds[line.ProductId] = uuid.New()
+		productIds[i] = line.ProductId
 	}
-	if len(invalidProducts) > 0 {
-		return order, invalidProducts, nil, nil
+	var couponId pgtype.UUID
+	if pricing.Coupon != nil {
+		couponId = pgtype.UUID{Bytes: pricing.Coupon.ID, Valid: true}
+	}
+	var shippingMethodId pgtype.UUID
+	var shippingMethodName string
+	if pricing.ShippingMethod != nil {
+		shippingMethodId = pgtype.UUID{Bytes: pricing.ShippingMethod.ID, Valid: true}
+		shippingMethodName = pricing.ShippingMethod.Name
 	}
-	// Join placeholders with commas and append to the query
-	query := fmt.Sprint(`INSERT`, ` INTO`, ` "orderItem"`, ` ("id", "orderId", "productId", "quantity", "price")`, ` VALUES `, strings.Join(placeholders, ", "))
 	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		// The products are locked before their warehouse stock, in the lock
+		// order of execTx, so that orders and restocks cannot deadlock.
+		// Both stay locked until the order is placed so that concurrent
+		// orders cannot be allocated the same units.
+		_, err := q.GetProductIdsForUpdate(ctx, productIds)
+		if err != nil {
+			return err
+		}
+		stock, held, err := q.allocatableStock(ctx, productIds)
+		if err != nil {
+			return err
+		}
+		allocations, backordered, status, err := allocateLines(pricing.Lines, stock, held)
+		if err != nil {
+			var insufficient *fulfilment.InsufficientStockError
+			if errors.As(err, &insufficient) {
+				invalidProducts[insufficient.ProductId.String()] = "quantity less than available stock"
+			}
+			return err
+		}
 		order, err = q.CreateOrder(ctx, CreateOrderParams{
-			ID:     arg.ID,
-			UserId: arg.UserId,
-			Total:  orderTotal,
+			ID:               arg.ID,
+			UserId:           arg.UserId,
+			Subtotal:         pricing.Subtotal,
+			Discount:         pricing.Discount,
+			Tax:              pricing.Tax,
+			Total:            pricing.Total,
+			CouponId:         couponId,
+			ShippingRegion:   arg.ShippingRegion,
+			ShippingMethodId: shippingMethodId,
+			ShippingMethod:   shippingMethodName,
+			ShippingCost:     pricing.Shipping,
+			Status:           status,
 		})
 		if err != nil {
 			return err
 		}
+		if arg.Guest != nil {
+			guest := *arg.Guest
+			guest.OrderId = arg.ID
+			_, err = q.CreateGuestOrder(ctx, guest)
+			if err != nil {
+				return err
+			}
+		}
+		var values []interface{}
+		var placeholders []string
+		for i, line := range pricing.Lines {
+			// Create a group of placeholders for each record
+			placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+				i*7+1, i*7+2, i*7+3, i*7+4, i*7+5, i*7+6, i*7+7))
+			values = append(values, itemIds[line.ProductId], arg.ID, line.ProductId, line.Quantity, line.Price, line.Tax, backordered[line.ProductId])
+		}
+		// Join placeholders with commas and append to the query
+		query := fmt.Sprint(`INSERT`, ` INTO`, ` "orderItem"`, ` ("id", "orderId", "productId", "quantity", "price", "tax", "backordered")`, ` VALUES `, strings.Join(placeholders, ", "))
 		_, err = q.db.Exec(ctx, query, values...)
 		if err != nil {
 			return err
 		}
-		for productId, quantity := range orderQuantities {
-			_, err = q.UpdateProductStock(ctx, UpdateProductStockParams{
-				ID:    productId,
-				Stock: quantity,
+		_, err = q.shipFromWarehouses(ctx, arg.ID, arg.UserId, itemIds, allocations)
+		if err != nil {
+			return err
+		}
+		items := make([]OrderEventItem, len(pricing.Lines))
+		for i, line := range pricing.Lines {
+			items[i] = OrderEventItem{ProductId: line.ProductId, Quantity: line.Quantity, Price: line.Price, Tax: line.Tax, Backordered: backordered[line.ProductId]}
+		}
+		for _, taxAmount := range pricing.Taxes {
+			_, err = q.CreateOrderTax(ctx, CreateOrderTaxParams{
+				ID:        uuid.New(),
+				OrderId:   arg.ID,
+				TaxRuleId: pgtype.UUID{Bytes: taxAmount.RuleID, Valid: true},
+				Name:      taxAmount.Name,
+				Rate:      taxAmount.Rate,
+				Inclusive: taxAmount.Inclusive,
+				Amount:    taxAmount.Amount,
 			})
 			if err != nil {
 				return err
 			}
 		}
-		return nil
+		for _, applied := range pricing.Promotions {
+			_, err = q.CreateOrderPromotion(ctx, CreateOrderPromotionParams{
+				ID:          uuid.New(),
+				OrderId:     arg.ID,
+				PromotionId: pgtype.UUID{Bytes: applied.ID, Valid: true},
+				Name:        applied.Name,
+				Kind:        PromotionKind(applied.Kind),
+				Discount:    applied.Discount,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if pricing.Coupon != nil {
+			// The usage limits are checked again here as concurrent orders may
+			// have redeemed the coupon since it was validated above. The
+			// coupon row stays locked by the increment until the order is
+			// placed, so the redemptions of the user are counted after those
+			// of any concurrent order.
+			redeemed, err := q.IncrementCouponUsage(ctx, pricing.Coupon.ID)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					invalidProducts["couponCode"] = coupon.ErrUsageLimitReached.Error()
+				}
+				return err
+			}
+			if redeemed.PerUserLimit > 0 {
+				redemptions, err := q.CountUserCouponRedemption(ctx, CountUserCouponRedemptionParams{
+					CouponId: redeemed.ID,
+					UserId:   arg.UserId,
+				})
+				if err != nil {
+					return err
+				}
+				if redemptions >= int64(redeemed.PerUserLimit) {
+					invalidProducts["couponCode"] = coupon.ErrUserLimitReached.Error()
+					return coupon.ErrUserLimitReached
+				}
+			}
+			_, err = q.CreateCouponRedemption(ctx, CreateCouponRedemptionParams{
+				ID:       uuid.New(),
+				CouponId: pricing.Coupon.ID,
+				UserId:   arg.UserId,
+				OrderId:  arg.ID,
+				Discount: pricing.CouponDiscount,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return q.publish(ctx, OrderCreated{Order: order, Items: items})
 	})
+	if len(invalidProducts) > 0 {
+		return order, invalidProducts, nil, txErr
+	}
 	return order, invalidProducts, execErr, txErr
 }
 
+// AppliedPromotion is a promotion that discounted an order preview.
+type AppliedPromotion struct {
+	PromotionId uuid.UUID     `json:"promotionId"`
+	Name        string        `json:"name"`
+	Kind        PromotionKind `json:"kind"`
+	Discount    float64       `json:"discount"`
+}
+
+// OrderPreviewItem is a line of an order preview. Price is the line total
+// before Discount, the share of the promotion and coupon discounts taken off
+// the line.
+type OrderPreviewItem struct {
+	ProductId   uuid.UUID `json:"productId"`
+	Quantity    int32     `json:"quantity"`
+	Price       float64   `json:"price"`
+	Discount    float64   `json:"discount"`
+	Tax         float64   `json:"tax"`
+	Backordered int32     `json:"backordered"`
+}
+
+// OrderPreviewTax is the tax an order preview would be charged at a rate.
+type OrderPreviewTax struct {
+	TaxRuleId uuid.UUID `json:"taxRuleId"`
+	Name      string    `json:"name"`
+	Rate      float64   `json:"rate"`
+	Inclusive bool      `json:"inclusive"`
+	Amount    float64   `json:"amount"`
+}
+
+// OrderPreview holds what an order would be created with.
+type OrderPreview struct {
+	Status           OrderStatus        `json:"status"`
+	Subtotal         float64            `json:"subtotal"`
+	Discount         float64            `json:"discount"`
+	CouponDiscount   float64            `json:"couponDiscount"`
+	Tax              float64            `json:"tax"`
+	Total            float64            `json:"total"`
+	CouponId         pgtype.UUID        `json:"couponId"`
+	ShippingRegion   string             `json:"shippingRegion"`
+	ShippingMethodId pgtype.UUID        `json:"shippingMethodId"`
+	ShippingMethod   string             `json:"shippingMethod"`
+	ShippingCost     float64            `json:"shippingCost"`
+	Items            []OrderPreviewItem `json:"items"`
+	Taxes            []OrderPreviewTax  `json:"taxes"`
+	Promotions       []AppliedPromotion `json:"promotions"`
+}
+
+// PreviewOrder prices the order and checks the warehouses can ship it the same
+// way CreateOrderTx does, without placing it, taking stock or redeeming the
+// coupon. Problems with the request are returned in the message map keyed by
+// product id or field name, as CreateOrderTx returns them.
+func (store *SQLStore) PreviewOrder(ctx context.Context, arg CreateOrderTxParams) (OrderPreview, map[string]string, error) {
+	preview := OrderPreview{
+		ShippingRegion: arg.ShippingRegion,
+		Items:          []OrderPreviewItem{},
+		Taxes:          []OrderPreviewTax{},
+		Promotions:     []AppliedPromotion{},
+	}
+	pricing, invalidProducts, err := store.priceOrder(ctx, arg)
+	if err != nil || len(invalidProducts) > 0 {
+		return preview, invalidProducts, err
+	}
+	productIds := make([]uuid.UUID, len(pricing.Lines))
+	for i, line := range pricing.Lines {
+		productIds[i] = line.ProductId
+	}
+	stock, held, err := store.availableStock(ctx, productIds)
+	if err != nil {
+		return preview, invalidProducts, err
+	}
+	_, backordered, status, err := allocateLines(pricing.Lines, stock, held)
+	if err != nil {
+		var insufficient *fulfilment.InsufficientStockError
+		if errors.As(err, &insufficient) {
+			invalidProducts[insufficient.ProductId.String()] = "quantity less than available stock"
+			return preview, invalidProducts, nil
+		}
+		return preview, invalidProducts, err
+	}
+	preview.Status = status
+	preview.Subtotal = pricing.Subtotal
+	preview.Discount = pricing.Discount
+	preview.CouponDiscount = pricing.CouponDiscount
+	preview.Tax = pricing.Tax
+	preview.Total = pricing.Total
+	preview.ShippingCost = pricing.Shipping
+	if pricing.Coupon != nil {
+		preview.CouponId = pgtype.UUID{Bytes: pricing.Coupon.ID, Valid: true}
+	}
+	if pricing.ShippingMethod != nil {
+		preview.ShippingMethodId = pgtype.UUID{Bytes: pricing.ShippingMethod.ID, Valid: true}
+		preview.ShippingMethod = pricing.ShippingMethod.Name
+	}
+	for _, line := range pricing.Lines {
+		preview.Items = append(preview.Items, OrderPreviewItem{
+			ProductId:   line.ProductId,
+			Quantity:    line.Quantity,
+			Price:       line.Price,
+			Discount:    line.Discount,
+			Tax:         line.Tax,
+			Backordered: backordered[line.ProductId],
+		})
+	}
+	for _, taxAmount := range pricing.Taxes {
+		preview.Taxes = append(preview.Taxes, OrderPreviewTax{
+			TaxRuleId: taxAmount.RuleID,
+			Name:      taxAmount.Name,
+			Rate:      taxAmount.Rate,
+			Inclusive: taxAmount.Inclusive,
+			Amount:    taxAmount.Amount,
+		})
+	}
+	for _, applied := range pricing.Promotions {
+		preview.Promotions = append(preview.Promotions, AppliedPromotion{
+			PromotionId: applied.ID,
+			Name:        applied.Name,
+			Kind:        PromotionKind(applied.Kind),
+			Discount:    applied.Discount,
+		})
+	}
+	return preview, invalidProducts, nil
+}
+
 type UpdateOrderTxParams struct {
 	ID     uuid.UUID   `json:"id"`
 	UserId uuid.UUID   `json:"userId"`
@@ -83,31 +317,123 @@ type UpdateOrderTxParams struct {
 	Status OrderStatus `json:"status"`
 }
 
-func (store *SQLStore) UpdateOrderTx(ctx context.Context, arg UpdateOrderTxParams) (Order, error) {
-	if arg.Status != OrderStatusCANCELLED {
-		return store.UpdateOrderStatus(ctx, UpdateOrderStatusParams{
-			ID:     arg.ID,
-			Status: arg.Status,
-		})
+// orderStatusChanges lists the statuses an admin can move an order to, other
+// than CANCELLED, by its current status. Orders move to the shipping statuses
+// through their shipments and out of BACKORDERED once restocked, and nothing
+// moves them back.
+var orderStatusChanges = map[OrderStatus][]OrderStatus{
+	OrderStatusPENDING: {OrderStatusCOMPLETED},
+}
+
+// CanChangeStatus reports whether an admin can move an order from a status to
+// another one than CANCELLED.
+func CanChangeStatus(from OrderStatus, to OrderStatus) bool {
+	for _, status := range orderStatusChanges[from] {
+		if status == to {
+			return true
+		}
 	}
-	products, err := store.GetAllProductInOrder(ctx, arg.ID)
-	if err != nil {
-		return Order{}, err
+	return false
+}
+
+// CanCancel reports whether an order in the status can be cancelled. The units
+// of shipped orders have left the warehouses and those of cancelled orders are
+// back in stock, so cancelling either would restock units that are not there.
+// Customers cannot cancel orders once they are COMPLETED. Orders with
+// shipments cannot be cancelled either, whatever their status.
+func CanCancel(status OrderStatus, admin bool) bool {
+	switch status {
+	case OrderStatusPENDING, OrderStatusBACKORDERED:
+		return true
+	case OrderStatusCOMPLETED:
+		return admin
 	}
+	return false
+}
+
+func (store *SQLStore) UpdateOrderTx(ctx context.Context, arg UpdateOrderTxParams) (Order, error) {
 	var order Order
 	execErr, _ := store.execTx(ctx, func(q *Queries) error {
-		for _, product := range products {
-			stock := product.Quantity * -1
-			if arg.Status == OrderStatusPENDING {
-				stock = product.Quantity
-			}
-			_, err := q.UpdateProductStock(ctx, UpdateProductStockParams{
-				ID:    product.ProductId,
-				Stock: stock,
+		current, err := q.GetOrderForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if arg.Status != OrderStatusCANCELLED {
+			// Backordered orders move on by themselves once restocked
+			if current.Status == OrderStatusBACKORDERED {
+				return fulfilment.ErrBackordered
+			}
+			if !CanChangeStatus(current.Status, arg.Status) {
+				return fulfilment.ErrStatusChange
+			}
+			order, err = q.updateOrderStatus(ctx, current, arg.Status)
+			return err
+		}
+		if !arg.Admin && current.UserId != arg.UserId {
+			return pgx.ErrNoRows
+		}
+		if !CanCancel(current.Status, arg.Admin) {
+			return fulfilment.ErrNotCancellable
+		}
+		shipments, err := q.GetShipmentByOrderId(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if len(shipments) > 0 {
+			return fulfilment.ErrNotCancellable
+		}
+		// The units go back to the warehouses they were allocated from, or to
+		// the primary warehouse for orders placed before allocations existed.
+		allocations, err := q.GetOrderAllocations(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if len(allocations) == 0 {
+			products, err := q.GetAllProductInOrder(ctx, arg.ID)
+			if err != nil {
+				return err
+			}
+			for _, product := range products {
+				allocations = append(allocations, GetOrderAllocationsRow{ProductId: product.ProductId, Quantity: product.Quantity})
+			}
+		}
+		// The products are locked in one go after the order, in the lock
+		// order of execTx
+		restocked := make(map[uuid.UUID]Product, len(allocations))
+		var restockedIds []uuid.UUID
+		for _, allocation := range allocations {
+			if _, ok := restocked[allocation.ProductId]; !ok {
+				restocked[allocation.ProductId] = Product{}
+				restockedIds = append(restockedIds, allocation.ProductId)
+			}
+		}
+		_, err = q.GetProductIdsForUpdate(ctx, restockedIds)
+		if err != nil {
+			return err
+		}
+		for _, allocation := range allocations {
+			product, err := q.removeStock(ctx, allocation.ProductId, allocation.Quantity*-1, StockChange{
+				Kind:        StockMovementKindORDERCANCELLED,
+				OrderId:     arg.ID,
+				ActorId:     arg.UserId,
+				WarehouseId: allocation.WarehouseId,
 			})
 			if err != nil {
 				return err
 			}
+			restocked[product.ID] = product
+		}
+		// The coupon redeemed by the order can be used again, both towards
+		// its usage limit and the limit of the user
+		redemptions, err := q.DeleteCouponRedemptionByOrderId(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		for _, redemption := range redemptions {
+			_, err = q.DecrementCouponUsage(ctx, redemption.CouponId)
+			if err != nil {
+				return err
+			}
 		}
 		if !arg.Admin {
 			order, err = q.CancelOrder(ctx, CancelOrderParams{
@@ -117,12 +443,17 @@ func (store *SQLStore) UpdateOrderTx(ctx context.Context, arg UpdateOrderTxParam
 			if err != nil {
 				return err
 			}
+			err = q.publish(ctx, OrderStatusChanged{Order: order, PreviousStatus: current.Status})
 		} else {
-			order, err = q.UpdateOrderStatus(ctx, UpdateOrderStatusParams{
-				ID:     arg.ID,
-				Status: arg.Status,
-			})
-			if err != nil {
+			order, err = q.updateOrderStatus(ctx, current, arg.Status)
+		}
+		if err != nil {
+			return err
+		}
+		// The units put back go to the orders waiting for them, once this one
+		// is cancelled so that it is not handed its own units back
+		for _, productId := range restockedIds {
+			if _, err = q.allocateBackorders(ctx, restocked[productId]); err != nil {
 				return err
 			}
 		}
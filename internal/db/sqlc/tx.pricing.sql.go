@@ -0,0 +1,190 @@
+package db
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/coupon"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/fulfilment"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/promotion"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/shipping"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/tax"
+	"math"
+)
+
+// orderLine is a single priced product of an order.
+type orderLine struct {
+	ProductId uuid.UUID
+	Quantity  int32
+	Price     float64
+	Discount  float64
+	Tax       float64
+	// Backorderable lines may be ordered beyond the stock of the product
+	Backorderable bool
+}
+
+// orderPricing holds the amounts an order is created with. Discount is the
+// total taken off by promotions and the coupon, CouponDiscount the part of it
+// taken off by the coupon.
+type orderPricing struct {
+	Lines          []orderLine
+	Subtotal       float64
+	Discount       float64
+	CouponDiscount float64
+	Tax            float64
+	Shipping       float64
+	Total          float64
+	Weight         float64
+	Coupon         *Coupon
+	ShippingMethod *ShippingMethod
+	Taxes          []tax.Amount
+	Promotions     []promotion.Applied
+}
+
+// priceOrder validates the requested items against the current stock and works
+// out the subtotal, promotion and coupon discounts, taxes, shipping cost and total of the order.
+// Problems with the request are returned in the message map keyed by product id or field name.
+func (store *SQLStore) priceOrder(ctx context.Context, arg CreateOrderTxParams) (orderPricing, map[string]string, error) {
+	var pricing orderPricing
+	var invalidProducts = make(map[string]string)
+	var promotionLines []promotion.Line
+	var taxClasses []string
+	products, err := store.GetMultipleProductById(ctx, arg.ProductIds)
+	if err != nil {
+		return pricing, invalidProducts, err
+	}
+	found := make(map[uuid.UUID]bool)
+	for _, product := range products {
+		found[product.ID] = true
+		quantity, ok := arg.Items[product.ID]
+		if !ok {
+			invalidProducts[product.ID.String()] = "product not found"
+		}
+		backorderable := product.BackorderPolicy != BackorderPolicyNONE
+		if quantity > product.Stock && !backorderable {
+			invalidProducts[product.ID.String()] = "quantity less than available stock"
+		}
+		productPrice := math.Round((product.Price*float64(quantity))*100) / 100
+		pricing.Lines = append(pricing.Lines, orderLine{
+			ProductId:     product.ID,
+			Quantity:      quantity,
+			Price:         productPrice,
+			Backorderable: backorderable,
+		})
+		promotionLines = append(promotionLines, promotion.Line{ProductId: product.ID, Category: product.Category, Quantity: quantity, Amount: productPrice})
+		taxClasses = append(taxClasses, product.TaxClass)
+		pricing.Subtotal += productPrice
+		pricing.Weight += product.Weight * float64(quantity)
+	}
+	for _, productId := range arg.ProductIds {
+		if !found[productId] {
+			invalidProducts[productId.String()] = "product not found"
+		}
+	}
+	pricing.Subtotal = math.Round(pricing.Subtotal*100) / 100
+	pricing.Weight = math.Round(pricing.Weight*1000) / 1000
+	if len(invalidProducts) > 0 {
+		return pricing, invalidProducts, nil
+	}
+	// Promotions are applied first and the coupon is evaluated against what
+	// is left of each line.
+	promotions, discounts, err := store.applyPromotions(ctx, promotionLines)
+	if err != nil {
+		return pricing, invalidProducts, err
+	}
+	pricing.Promotions = promotions
+	couponLines := make([]coupon.Line, len(promotionLines))
+	for i, line := range promotionLines {
+		couponLines[i] = coupon.Line{ProductId: line.ProductId, Category: line.Category, Amount: math.Round((line.Amount-discounts[i])*100) / 100}
+		pricing.Discount += discounts[i]
+	}
+	if arg.CouponCode != "" {
+		appliedCoupon, discount, couponErrMessage, err := store.applyCoupon(ctx, arg.CouponCode, arg.UserId, couponLines)
+		if err != nil {
+			return pricing, invalidProducts, err
+		}
+		if couponErrMessage != "" {
+			invalidProducts["couponCode"] = couponErrMessage
+			return pricing, invalidProducts, nil
+		}
+		pricing.Coupon = &appliedCoupon
+		pricing.CouponDiscount = discount
+		pricing.Discount += discount
+		for i, share := range coupon.Allocate(couponRule(appliedCoupon), couponLines, discount) {
+			discounts[i] += share
+		}
+	}
+	pricing.Discount = math.Round(pricing.Discount*100) / 100
+	if arg.ShippingMethodId != uuid.Nil {
+		// Free shipping thresholds are checked against the subtotal before any
+		// discount so that the cost matches the shipping quote.
+		shippingMethod, cost, shippingErrMessage, err := store.shipOrder(ctx, arg.ShippingMethodId, arg.ShippingRegion, shipping.Basket{
+			Subtotal: pricing.Subtotal,
+			Weight:   pricing.Weight,
+		})
+		if err != nil {
+			return pricing, invalidProducts, err
+		}
+		if shippingErrMessage != "" {
+			invalidProducts["shippingMethodId"] = shippingErrMessage
+			return pricing, invalidProducts, nil
+		}
+		pricing.ShippingMethod = &shippingMethod
+		pricing.Shipping = cost
+	}
+	taxRules, err := store.GetTaxRuleByTaxClass(ctx, taxClasses)
+	if err != nil {
+		return pricing, invalidProducts, err
+	}
+	taxLines := make([]tax.Line, len(pricing.Lines))
+	for i, line := range pricing.Lines {
+		taxLines[i] = tax.Line{TaxClass: taxClasses[i], Amount: line.Price - discounts[i]}
+	}
+	taxResult := tax.Calculate(taxRulesOf(taxRules), arg.ShippingRegion, taxLines)
+	for i := range pricing.Lines {
+		pricing.Lines[i].Discount = math.Round(discounts[i]*100) / 100
+		pricing.Lines[i].Tax = taxResult.Lines[i].Tax
+	}
+	pricing.Tax = taxResult.Tax
+	pricing.Taxes = taxResult.Breakdown
+	pricing.Total = math.Round((pricing.Subtotal-pricing.Discount+taxResult.Exclusive+pricing.Shipping)*100) / 100
+	return pricing, invalidProducts, nil
+}
+
+// allocateLines picks the warehouses the priced lines are shipped from given
+// the stock of the warehouses and how many units of each product they hold
+// together. Products taking backorders get what is in stock, the rest of the
+// units waits for the product to be restocked and the order is BACKORDERED.
+func allocateLines(lines []orderLine, stock []fulfilment.Stock, held map[uuid.UUID]int32) ([]fulfilment.Allocation, map[uuid.UUID]int32, OrderStatus, error) {
+	var allocate []fulfilment.Line
+	backordered := make(map[uuid.UUID]int32)
+	status := OrderStatusPENDING
+	for _, line := range lines {
+		quantity := line.Quantity
+		if line.Backorderable && quantity > held[line.ProductId] {
+			quantity = held[line.ProductId]
+			backordered[line.ProductId] = line.Quantity - quantity
+			status = OrderStatusBACKORDERED
+		}
+		if quantity > 0 {
+			allocate = append(allocate, fulfilment.Line{ProductId: line.ProductId, Quantity: quantity})
+		}
+	}
+	allocations, err := fulfilment.Allocate(allocate, stock)
+	return allocations, backordered, status, err
+}
+
+// taxRulesOf converts stored tax rules into the rules evaluated at checkout.
+func taxRulesOf(rules []TaxRule) []tax.Rule {
+	taxRules := make([]tax.Rule, len(rules))
+	for i, rule := range rules {
+		taxRules[i] = tax.Rule{
+			ID:        rule.ID,
+			Name:      rule.Name,
+			TaxClass:  rule.TaxClass,
+			Region:    rule.Region,
+			Rate:      rule.Rate,
+			Inclusive: rule.Inclusive,
+		}
+	}
+	return taxRules
+}
@@ -2,15 +2,34 @@ package db
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// ErrProductVersionMismatch is returned when a product was updated since the
+// version an update was made against.
+var ErrProductVersionMismatch = errors.New("product version mismatch")
+
 type UpdateProductTxParams struct {
-	ID          uuid.UUID `json:"id"`
-	Name        *string   `json:"name,omitempty"`
-	Description *string   `json:"description,omitempty"`
-	Price       *float64  `json:"price,omitempty"`
-	Stock       *int32    `json:"stock,omitempty"`
+	ID               uuid.UUID `json:"id"`
+	Name             *string   `json:"name,omitempty"`
+	Description      *string   `json:"description,omitempty"`
+	Price            *float64  `json:"price,omitempty"`
+	Stock            *int32    `json:"stock,omitempty"`
+	Category         *string   `json:"category,omitempty"`
+	TaxClass         *string   `json:"taxClass,omitempty"`
+	Weight           *float64  `json:"weight,omitempty"`
+	Sku              *string   `json:"sku,omitempty"`
+	ReorderThreshold *int32    `json:"reorderThreshold,omitempty"`
+	// BackorderPolicy set to NONE clears AvailableAt
+	BackorderPolicy *BackorderPolicy  `json:"backorderPolicy,omitempty"`
+	AvailableAt     *pgtype.Timestamp `json:"availableAt,omitempty"`
+	// StockNote is recorded in the stock ledger when Stock changes
+	StockNote string    `json:"stockNote,omitempty"`
+	UpdatedBy uuid.UUID `json:"updatedBy"`
+	// Version the update was made against, any version when nil
+	Version *int32 `json:"version,omitempty"`
 }
 
 type UpdateProductTxResult Product
@@ -19,10 +38,15 @@ func (store *SQLStore) UpdateProductTx(ctx context.Context, arg UpdateProductTxP
 	var result Product
 	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
 		var err error
-		product, err := q.GetOneProduct(ctx, arg.ID)
+		// The product stays locked so that no other update slips in between
+		// the version check and the write
+		product, err := q.GetProductForUpdate(ctx, arg.ID)
 		if err != nil {
 			return err
 		}
+		if arg.Version != nil && *arg.Version != product.Version {
+			return ErrProductVersionMismatch
+		}
 		if arg.Name == nil {
 			arg.Name = &product.Name
 		}
@@ -35,18 +59,139 @@ func (store *SQLStore) UpdateProductTx(ctx context.Context, arg UpdateProductTxP
 		if arg.Stock == nil {
 			arg.Stock = &product.Stock
 		}
+		if arg.Category == nil {
+			arg.Category = &product.Category
+		}
+		if arg.TaxClass == nil {
+			arg.TaxClass = &product.TaxClass
+		}
+		if arg.Weight == nil {
+			arg.Weight = &product.Weight
+		}
+		if arg.Sku == nil {
+			arg.Sku = &product.Sku
+		}
+		if arg.ReorderThreshold == nil {
+			arg.ReorderThreshold = &product.ReorderThreshold
+		}
+		if arg.BackorderPolicy == nil {
+			arg.BackorderPolicy = &product.BackorderPolicy
+		}
+		if arg.AvailableAt == nil {
+			arg.AvailableAt = &product.AvailableAt
+		}
+		if *arg.BackorderPolicy == BackorderPolicyNONE {
+			arg.AvailableAt = &pgtype.Timestamp{}
+		}
+		// A price set directly ends any sale the product is on
+		compareAtPrice := product.CompareAtPrice
+		if *arg.Price != product.Price {
+			compareAtPrice = pgtype.Float8{}
+		}
 		updatedProduct, err := q.UpdateOneProduct(ctx, UpdateOneProductParams{
-			ID:          arg.ID,
-			Name:        *arg.Name,
-			Description: *arg.Description,
-			Price:       *arg.Price,
-			Stock:       *arg.Stock,
+			ID:               arg.ID,
+			Name:             *arg.Name,
+			Description:      *arg.Description,
+			Price:            *arg.Price,
+			Stock:            *arg.Stock,
+			Category:         *arg.Category,
+			TaxClass:         *arg.TaxClass,
+			Weight:           *arg.Weight,
+			Sku:              *arg.Sku,
+			ReorderThreshold: *arg.ReorderThreshold,
+			BackorderPolicy:  *arg.BackorderPolicy,
+			AvailableAt:      *arg.AvailableAt,
+			CompareAtPrice:   compareAtPrice,
 		})
 		if err != nil {
 			return err
 		}
 		result = updatedProduct
-		return nil
+		if updatedProduct.Price != product.Price {
+			if err = q.recordPrice(ctx, updatedProduct, arg.UpdatedBy); err != nil {
+				return err
+			}
+		}
+		err = q.recordStockMovement(ctx, updatedProduct, updatedProduct.Stock-product.Stock, StockChange{
+			Kind:    StockMovementKindADJUSTMENT,
+			ActorId: arg.UpdatedBy,
+			Note:    arg.StockNote,
+		})
+		if err != nil {
+			return err
+		}
+		err = q.publish(ctx, ProductUpdated{Product: updatedProduct})
+		if err != nil {
+			return err
+		}
+		err = q.checkStockLow(ctx, updatedProduct, product.Stock <= product.ReorderThreshold)
+		if err != nil {
+			return err
+		}
+		err = q.checkBackInStock(ctx, updatedProduct, product.Stock)
+		if err != nil || result.Stock <= product.Stock {
+			return err
+		}
+		result, err = q.allocateBackorders(ctx, result)
+		return err
+	})
+	return result, execErr, txErr
+}
+
+// CreateProductTx adds a product to the catalog, records its initial stock in
+// the stock ledger and its price in its price history, and raises
+// ProductCreated.
+func (store *SQLStore) CreateProductTx(ctx context.Context, arg CreateProductParams) (Product, error, error) {
+	var result Product
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.CreateProduct(ctx, arg)
+		if err != nil {
+			return err
+		}
+		err = q.recordStockMovement(ctx, result, result.Stock, StockChange{
+			Kind:    StockMovementKindADJUSTMENT,
+			ActorId: arg.CreatedBy,
+			Note:    "Initial stock",
+		})
+		if err != nil {
+			return err
+		}
+		if err = q.recordPrice(ctx, result, arg.CreatedBy); err != nil {
+			return err
+		}
+		return q.publish(ctx, ProductCreated{Product: result})
+	})
+	return result, execErr, txErr
+}
+
+// DeleteProductTx removes a product from the catalog and raises ProductDeleted.
+// The product is archived rather than deleted so that the orders it is in
+// still resolve it.
+func (store *SQLStore) DeleteProductTx(ctx context.Context, productId uuid.UUID) (Product, error, error) {
+	var result Product
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.ArchiveProduct(ctx, productId)
+		if err != nil {
+			return err
+		}
+		return q.publish(ctx, ProductDeleted{Product: result})
+	})
+	return result, execErr, txErr
+}
+
+// RestoreProductTx puts an archived product back in the catalog and raises
+// ProductUpdated.
+func (store *SQLStore) RestoreProductTx(ctx context.Context, productId uuid.UUID) (Product, error, error) {
+	var result Product
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.RestoreProduct(ctx, productId)
+		if err != nil {
+			return err
+		}
+		return q.publish(ctx, ProductUpdated{Product: result})
 	})
 	return result, execErr, txErr
 }
@@ -0,0 +1,204 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// MaxProductImages is the number of images a product can have.
+const MaxProductImages = 10
+
+// errProductImageRejected rolls back a product image transaction whose request
+// was found to be invalid once the product was locked.
+var errProductImageRejected = errors.New("product image rejected")
+
+type CreateProductImageTxParams struct {
+	ID          uuid.UUID `json:"id"`
+	ProductId   uuid.UUID `json:"productId"`
+	Key         string    `json:"key"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	Primary     bool      `json:"primary"`
+	CreatedBy   uuid.UUID `json:"createdBy"`
+}
+
+// CreateProductImageTx adds an image after the existing images of a product.
+// The first image of a product always becomes its primary image.
+func (store *SQLStore) CreateProductImageTx(ctx context.Context, arg CreateProductImageTxParams) (ProductImage, map[string]string, error, error) {
+	var image ProductImage
+	var invalidFields = make(map[string]string)
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		// Locking the product keeps positions unique between concurrent uploads.
+		_, err := q.GetProductForUpdate(ctx, arg.ProductId)
+		if err != nil {
+			return err
+		}
+		images, err := q.GetProductImageByProductIds(ctx, []uuid.UUID{arg.ProductId})
+		if err != nil {
+			return err
+		}
+		if len(images) >= MaxProductImages {
+			invalidFields["image"] = fmt.Sprintf("a product can have at most %d images", MaxProductImages)
+			return errProductImageRejected
+		}
+		primary := arg.Primary || len(images) == 0
+		if primary {
+			if err = q.ClearPrimaryProductImage(ctx, arg.ProductId); err != nil {
+				return err
+			}
+		}
+		image, err = q.CreateProductImage(ctx, CreateProductImageParams{
+			ID:          arg.ID,
+			ProductId:   arg.ProductId,
+			Key:         arg.Key,
+			ContentType: arg.ContentType,
+			Size:        arg.Size,
+			Position:    nextImagePosition(images),
+			IsPrimary:   primary,
+			CreatedBy:   arg.CreatedBy,
+		})
+		return err
+	})
+	if len(invalidFields) > 0 {
+		return image, invalidFields, nil, txErr
+	}
+	return image, invalidFields, execErr, txErr
+}
+
+type ReorderProductImageTxParams struct {
+	ProductId      uuid.UUID   `json:"productId"`
+	ImageIds       []uuid.UUID `json:"imageIds"`
+	PrimaryImageId uuid.UUID   `json:"primaryImageId"`
+}
+
+// ReorderProductImageTx puts the images of a product in the order of ImageIds
+// and makes PrimaryImageId its primary image. ImageIds must list every image
+// of the product when given; leaving it empty keeps the current order, and
+// leaving PrimaryImageId empty keeps the current primary image.
+func (store *SQLStore) ReorderProductImageTx(ctx context.Context, arg ReorderProductImageTxParams) ([]ProductImage, map[string]string, error, error) {
+	var result []ProductImage
+	var invalidFields = make(map[string]string)
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetProductForUpdate(ctx, arg.ProductId)
+		if err != nil {
+			return err
+		}
+		images, err := q.GetProductImageByProductIds(ctx, []uuid.UUID{arg.ProductId})
+		if err != nil {
+			return err
+		}
+		byId := make(map[uuid.UUID]ProductImage, len(images))
+		primaryId := uuid.Nil
+		for _, image := range images {
+			byId[image.ID] = image
+			if image.IsPrimary {
+				primaryId = image.ID
+			}
+		}
+		order := arg.ImageIds
+		if len(order) == 0 {
+			for _, image := range images {
+				order = append(order, image.ID)
+			}
+		}
+		seen := make(map[uuid.UUID]bool, len(order))
+		for _, imageId := range order {
+			if _, ok := byId[imageId]; !ok || seen[imageId] {
+				invalidFields["imageIds"] = "must list each image of the product once"
+				return errProductImageRejected
+			}
+			seen[imageId] = true
+		}
+		if len(order) != len(images) {
+			invalidFields["imageIds"] = "must list each image of the product once"
+			return errProductImageRejected
+		}
+		if arg.PrimaryImageId != uuid.Nil {
+			if _, ok := byId[arg.PrimaryImageId]; !ok {
+				invalidFields["primaryImageId"] = "image not found"
+				return errProductImageRejected
+			}
+			primaryId = arg.PrimaryImageId
+		}
+		// Clear the primary image first so the new one does not clash with it.
+		if err = q.ClearPrimaryProductImage(ctx, arg.ProductId); err != nil {
+			return err
+		}
+		for position, imageId := range order {
+			image, err := q.UpdateProductImagePosition(ctx, UpdateProductImagePositionParams{
+				ID:        imageId,
+				Position:  int32(position),
+				IsPrimary: imageId == primaryId,
+			})
+			if err != nil {
+				return err
+			}
+			result = append(result, image)
+		}
+		return nil
+	})
+	if len(invalidFields) > 0 {
+		return result, invalidFields, nil, txErr
+	}
+	return result, invalidFields, execErr, txErr
+}
+
+// DeleteProductImageTx removes an image from a product and closes the gap it
+// leaves in the order. When the primary image is removed the first remaining
+// image takes its place.
+func (store *SQLStore) DeleteProductImageTx(ctx context.Context, productId uuid.UUID, imageId uuid.UUID) (ProductImage, error, error) {
+	var deleted ProductImage
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetProductForUpdate(ctx, productId)
+		if err != nil {
+			return err
+		}
+		images, err := q.GetProductImageByProductIds(ctx, []uuid.UUID{productId})
+		if err != nil {
+			return err
+		}
+		var remaining []ProductImage
+		for _, image := range images {
+			if image.ID == imageId {
+				deleted = image
+				continue
+			}
+			remaining = append(remaining, image)
+		}
+		if deleted.ID == uuid.Nil {
+			return pgx.ErrNoRows
+		}
+		if err = q.DeleteOneProductImage(ctx, imageId); err != nil {
+			return err
+		}
+		for position, image := range remaining {
+			primary := image.IsPrimary || (deleted.IsPrimary && position == 0)
+			if image.Position == int32(position) && image.IsPrimary == primary {
+				continue
+			}
+			_, err = q.UpdateProductImagePosition(ctx, UpdateProductImagePositionParams{
+				ID:        image.ID,
+				Position:  int32(position),
+				IsPrimary: primary,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return deleted, execErr, txErr
+}
+
+func nextImagePosition(images []ProductImage) int32 {
+	var next int32
+	for _, image := range images {
+		if image.Position >= next {
+			next = image.Position + 1
+		}
+	}
+	return next
+}
@@ -0,0 +1,204 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/fulfilment"
+)
+
+var (
+	// errProductImportRejected rolls back an import in which some rows clash
+	// with the products already in the catalog.
+	errProductImportRejected = errors.New("product import rejected")
+	// errProductImportDryRun rolls back an import that was only checked.
+	errProductImportDryRun = errors.New("product import dry run")
+)
+
+// ImportProductRow is a product to create, or to update when a product with
+// the same sku, or else the same name, already exists.
+type ImportProductRow struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price"`
+	Stock       int32   `json:"stock"`
+	Category    string  `json:"category"`
+	TaxClass    string  `json:"taxClass"`
+	Weight      float64 `json:"weight"`
+	Sku         string  `json:"sku"`
+}
+
+type ImportProductTxParams struct {
+	Rows      []ImportProductRow `json:"rows"`
+	CreatedBy uuid.UUID          `json:"createdBy"`
+	// DryRun rolls the import back once every row went through
+	DryRun bool `json:"dryRun"`
+}
+
+// ImportedProduct is the product a row was saved as.
+type ImportedProduct struct {
+	Product
+	Created bool `json:"created"`
+}
+
+// ImportProductTx upserts every row in order in a single transaction, so
+// either the whole import is saved or nothing is. Rows clashing with other
+// products, or lowering the stock by more than the warehouses hold, are
+// reported by row index and field, and reject the import.
+func (store *SQLStore) ImportProductTx(ctx context.Context, arg ImportProductTxParams) ([]ImportedProduct, map[int]map[string]string, error, error) {
+	var result []ImportedProduct
+	var invalidRows = make(map[int]map[string]string)
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		for i, row := range arg.Rows {
+			product, found, msg, err := matchImportProductRow(ctx, q, row)
+			if err != nil {
+				return err
+			}
+			if msg != nil {
+				invalidRows[i] = msg
+				continue
+			}
+			wasLow, previousStock, previousPrice := IsLowStock(product), product.Stock, product.Price
+			if found {
+				compareAtPrice := product.CompareAtPrice
+				if row.Price != product.Price {
+					compareAtPrice = pgtype.Float8{}
+				}
+				product, err = q.UpdateOneProduct(ctx, UpdateOneProductParams{
+					ID:               product.ID,
+					Name:             row.Name,
+					Description:      row.Description,
+					Price:            row.Price,
+					Stock:            row.Stock,
+					Category:         row.Category,
+					TaxClass:         row.TaxClass,
+					Weight:           row.Weight,
+					Sku:              row.Sku,
+					ReorderThreshold: product.ReorderThreshold,
+					BackorderPolicy:  product.BackorderPolicy,
+					AvailableAt:      product.AvailableAt,
+					CompareAtPrice:   compareAtPrice,
+				})
+			} else {
+				product, err = q.CreateProduct(ctx, CreateProductParams{
+					ID:               uuid.New(),
+					Name:             row.Name,
+					Description:      row.Description,
+					Price:            row.Price,
+					Stock:            row.Stock,
+					Category:         row.Category,
+					TaxClass:         row.TaxClass,
+					Weight:           row.Weight,
+					CreatedBy:        arg.CreatedBy,
+					Sku:              row.Sku,
+					ReorderThreshold: DefaultReorderThreshold,
+					BackorderPolicy:  BackorderPolicyNONE,
+				})
+			}
+			if err != nil {
+				return err
+			}
+			if !found || product.Price != previousPrice {
+				if err = q.recordPrice(ctx, product, arg.CreatedBy); err != nil {
+					return err
+				}
+			}
+			err = q.recordStockMovement(ctx, product, product.Stock-previousStock, StockChange{
+				Kind:    StockMovementKindIMPORT,
+				ActorId: arg.CreatedBy,
+			})
+			switch {
+			case errors.Is(err, fulfilment.ErrNoWarehouse):
+				invalidRows[i] = map[string]string{"stock": "no warehouse is active to hold the stock"}
+				continue
+			case errors.Is(err, fulfilment.ErrNotEnoughStock):
+				invalidRows[i] = map[string]string{"stock": "stock cannot be lowered by more than the active warehouses hold"}
+				continue
+			case err != nil:
+				return err
+			}
+			var event Event = ProductCreated{Product: product}
+			if found {
+				event = ProductUpdated{Product: product}
+			}
+			if err = q.publish(ctx, event); err != nil {
+				return err
+			}
+			if found {
+				if err = q.checkStockLow(ctx, product, wasLow); err != nil {
+					return err
+				}
+				if err = q.checkBackInStock(ctx, product, previousStock); err != nil {
+					return err
+				}
+				if product.Stock > previousStock {
+					product, err = q.allocateBackorders(ctx, product)
+					if err != nil {
+						return err
+					}
+				}
+			}
+			result = append(result, ImportedProduct{Product: product, Created: !found})
+		}
+		if len(invalidRows) > 0 {
+			return errProductImportRejected
+		}
+		if arg.DryRun {
+			return errProductImportDryRun
+		}
+		return nil
+	})
+	if len(invalidRows) > 0 {
+		return nil, invalidRows, nil, txErr
+	}
+	if errors.Is(execErr, errProductImportDryRun) {
+		execErr = nil
+	}
+	return result, invalidRows, execErr, txErr
+}
+
+// matchImportProductRow finds the product a row updates, by sku first and
+// then by name, and locks it until the import is saved, before any of its
+// warehouse stock as execTx requires. The row is invalid when its name
+// belongs to another product, or when it matches an archived product, which
+// has to be restored before it can be imported again.
+func matchImportProductRow(ctx context.Context, q *Queries, row ImportProductRow) (Product, bool, map[string]string, error) {
+	if row.Sku != "" {
+		product, err := q.GetProductBySkuForUpdate(ctx, row.Sku)
+		if err == nil {
+			if product.DeletedAt.Valid {
+				return Product{}, false, map[string]string{"sku": "sku belongs to an archived product, restore it first"}, nil
+			}
+			if product.Name != row.Name {
+				other, err := q.GetProductByNameForUpdate(ctx, row.Name)
+				if err == nil && other.ID != product.ID {
+					return Product{}, false, map[string]string{"name": "name is already used by another product"}, nil
+				}
+				if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+					return Product{}, false, nil, err
+				}
+			}
+			return product, true, nil, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return Product{}, false, nil, err
+		}
+	}
+	product, err := q.GetProductByNameForUpdate(ctx, row.Name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Product{}, false, nil, nil
+		}
+		return Product{}, false, nil, err
+	}
+	if product.DeletedAt.Valid {
+		return Product{}, false, map[string]string{"name": "name belongs to an archived product, restore it first"}, nil
+	}
+	if row.Sku != "" && product.Sku != "" && product.Sku != row.Sku {
+		return Product{}, false, map[string]string{"name": fmt.Sprintf("name is already used by the product with sku %s", product.Sku)}, nil
+	}
+	return product, true, nil, nil
+}
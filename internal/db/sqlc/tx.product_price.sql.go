@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// ApplyProductPriceJob is the kind of the jobs bringing the price of a
+// product in line with its price history when a scheduled price comes into
+// or goes out of effect.
+const ApplyProductPriceJob = "product.apply_price"
+
+// applyPriceMaxAttempts keeps retrying for hours, as a price left unapplied
+// is a sale that does not start or end.
+const applyPriceMaxAttempts int32 = 12
+
+// ApplyProductPriceArgs is the payload of an ApplyProductPriceJob.
+type ApplyProductPriceArgs struct {
+	ProductId uuid.UUID `json:"productId"`
+}
+
+// applyPrice sets the price and compare-at price of a product, which must be
+// locked, to those of the price in effect at at, raising ProductUpdated when
+// they change. A product without a price in effect keeps its price.
+func (q *Queries) applyPrice(ctx context.Context, product Product, at time.Time) (Product, error) {
+	price, err := q.GetEffectiveProductPrice(ctx, GetEffectiveProductPriceParams{
+		ProductId: product.ID,
+		At:        pgtype.Timestamp{Time: at.UTC(), Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return product, nil
+	}
+	if err != nil {
+		return product, err
+	}
+	if price.Price == product.Price && price.CompareAtPrice == product.CompareAtPrice {
+		return product, nil
+	}
+	product, err = q.UpdateProductPrice(ctx, UpdateProductPriceParams{
+		Price:          price.Price,
+		CompareAtPrice: price.CompareAtPrice,
+		ID:             product.ID,
+	})
+	if err != nil {
+		return product, err
+	}
+	return product, q.publish(ctx, ProductUpdated{Product: product})
+}
+
+// recordPrice adds a price set on a product directly, open-ended and in
+// effect from now, to its price history.
+func (q *Queries) recordPrice(ctx context.Context, product Product, actorId uuid.UUID) error {
+	_, err := q.CreateProductPrice(ctx, CreateProductPriceParams{
+		ID:             uuid.New(),
+		ProductId:      product.ID,
+		Price:          product.Price,
+		CompareAtPrice: product.CompareAtPrice,
+		EffectiveFrom:  pgtype.Timestamp{Time: time.Now().UTC(), Valid: true},
+		CreatedBy:      nullUUID(actorId),
+	})
+	return err
+}
+
+type ScheduleProductPriceTxResult struct {
+	Price   ProductPrice `json:"price"`
+	Product Product      `json:"product"`
+}
+
+// ScheduleProductPriceTx adds a price to the history of a product. A price
+// already in effect is applied at once, and jobs are queued to apply the
+// price history again when it comes into and goes out of effect.
+func (store *SQLStore) ScheduleProductPriceTx(ctx context.Context, arg CreateProductPriceParams) (ScheduleProductPriceTxResult, error, error) {
+	var result ScheduleProductPriceTxResult
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Product, err = q.GetProductForUpdate(ctx, arg.ProductId)
+		if err != nil {
+			return err
+		}
+		result.Price, err = q.CreateProductPrice(ctx, arg)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, at := range []pgtype.Timestamp{arg.EffectiveFrom, arg.EffectiveTo} {
+			if !at.Valid || !at.Time.After(now) {
+				continue
+			}
+			err = q.enqueueJobAt(ctx, ApplyProductPriceJob, ApplyProductPriceArgs{ProductId: arg.ProductId}, applyPriceMaxAttempts, at.Time)
+			if err != nil {
+				return err
+			}
+		}
+		result.Product, err = q.applyPrice(ctx, result.Product, now)
+		return err
+	})
+	return result, execErr, txErr
+}
+
+// CancelProductPriceTx cancels a price of a product that has not gone out
+// of effect, and applies what is left of its price history.
+func (store *SQLStore) CancelProductPriceTx(ctx context.Context, arg CancelProductPriceParams) (ScheduleProductPriceTxResult, error, error) {
+	var result ScheduleProductPriceTxResult
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Product, err = q.GetProductForUpdate(ctx, arg.ProductId)
+		if err != nil {
+			return err
+		}
+		result.Price, err = q.CancelProductPrice(ctx, arg)
+		if err != nil {
+			return err
+		}
+		result.Product, err = q.applyPrice(ctx, result.Product, time.Now())
+		return err
+	})
+	return result, execErr, txErr
+}
+
+// ApplyProductPriceTx brings the price of a product in line with the price
+// in effect now.
+func (store *SQLStore) ApplyProductPriceTx(ctx context.Context, productId uuid.UUID) (Product, error, error) {
+	var result Product
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		product, err := q.GetProductForUpdate(ctx, productId)
+		if err != nil {
+			return err
+		}
+		result, err = q.applyPrice(ctx, product, time.Now())
+		return err
+	})
+	return result, execErr, txErr
+}
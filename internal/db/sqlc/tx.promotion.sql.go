@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/promotion"
+)
+
+// applyPromotions evaluates the promotions running now against the order lines
+// and returns the promotions that discounted the order along with the discount
+// taken off each line.
+func (store *SQLStore) applyPromotions(ctx context.Context, lines []promotion.Line) ([]promotion.Applied, []float64, error) {
+	promotions, err := store.GetActivePromotions(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	rules := make([]promotion.Rule, len(promotions))
+	for i, p := range promotions {
+		rules[i], err = promotionRule(p)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	applied, discounts := promotion.Apply(rules, lines)
+	return applied, discounts, nil
+}
+
+// promotionRule converts a stored promotion into the rule evaluated at checkout.
+func promotionRule(p Promotion) (promotion.Rule, error) {
+	rule := promotion.Rule{
+		ID:          p.ID,
+		Name:        p.Name,
+		Kind:        string(p.Kind),
+		Priority:    p.Priority,
+		Stackable:   p.Stackable,
+		ProductIds:  p.ProductIds,
+		Categories:  p.Categories,
+		BuyQuantity: p.BuyQuantity,
+		GetQuantity: p.GetQuantity,
+		Percent:     p.Percent,
+		BundlePrice: p.BundlePrice,
+	}
+	if len(p.Tiers) > 0 {
+		if err := json.Unmarshal(p.Tiers, &rule.Tiers); err != nil {
+			return rule, err
+		}
+	}
+	return rule, nil
+}
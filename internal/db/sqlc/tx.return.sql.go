@@ -0,0 +1,222 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/rma"
+	"time"
+)
+
+// errReturnRejected rolls back a return transaction whose request was found
+// to be invalid once the order was locked.
+var errReturnRejected = errors.New("return rejected")
+
+type ReturnItemTxParams struct {
+	Quantity int32  `json:"quantity"`
+	Reason   string `json:"reason"`
+}
+
+type CreateReturnTxParams struct {
+	ID      uuid.UUID                        `json:"id"`
+	OrderId uuid.UUID                        `json:"orderId"`
+	UserId  uuid.UUID                        `json:"userId"`
+	Items   map[uuid.UUID]ReturnItemTxParams `json:"items"`
+	Note    string                           `json:"note"`
+}
+
+type ReturnTxResult struct {
+	Return  ReturnRequest `json:"return"`
+	Items   []ReturnItem  `json:"items"`
+	History []ReturnEvent `json:"history"`
+}
+
+// CreateReturnTx opens a return for shipped products of one of the user's
+// orders. Problems with the request are returned in the message map keyed by
+// product id or field name.
+func (store *SQLStore) CreateReturnTx(ctx context.Context, arg CreateReturnTxParams) (ReturnTxResult, map[string]string, error, error) {
+	var result ReturnTxResult
+	var invalidItems = make(map[string]string)
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		// Locking the order stops concurrent returns from returning the same units twice.
+		order, err := q.GetOrderForUpdate(ctx, arg.OrderId)
+		if err != nil {
+			return err
+		}
+		if order.UserId != arg.UserId {
+			return pgx.ErrNoRows
+		}
+		if order.Status != OrderStatusPARTIALLYSHIPPED && order.Status != OrderStatusSHIPPED && order.Status != OrderStatusDELIVERED {
+			invalidItems["status"] = "only shipped orders can be returned"
+			return errReturnRejected
+		}
+		orderItems, err := q.GetOrderItemReturnableQuantity(ctx, arg.OrderId)
+		if err != nil {
+			return err
+		}
+		byProduct := make(map[uuid.UUID]GetOrderItemReturnableQuantityRow)
+		for _, orderItem := range orderItems {
+			byProduct[orderItem.ProductId] = orderItem
+		}
+		var lines []rma.Line
+		for productId, item := range arg.Items {
+			orderItem, ok := byProduct[productId]
+			if !ok {
+				invalidItems[productId.String()] = "product not in order"
+				continue
+			}
+			if item.Quantity > orderItem.Shipped-orderItem.Returned {
+				invalidItems[productId.String()] = "quantity more than shipped and not yet returned"
+				continue
+			}
+			lines = append(lines, rma.Line{Price: orderItem.Price, Quantity: orderItem.Quantity, Returned: item.Quantity})
+		}
+		if len(invalidItems) > 0 {
+			return errReturnRejected
+		}
+		paidRatio := 1.0
+		if order.Subtotal > 0 {
+			paidRatio = (order.Total - order.ShippingCost) / order.Subtotal
+		}
+		result.Return, err = q.CreateReturnRequest(ctx, CreateReturnRequestParams{
+			ID:           arg.ID,
+			OrderId:      arg.OrderId,
+			UserId:       arg.UserId,
+			RefundAmount: rma.RefundAmount(lines, paidRatio),
+		})
+		if err != nil {
+			return err
+		}
+		for productId, item := range arg.Items {
+			returnItem, err := q.CreateReturnItem(ctx, CreateReturnItemParams{
+				ID:          uuid.New(),
+				ReturnId:    arg.ID,
+				OrderItemId: byProduct[productId].ID,
+				ProductId:   productId,
+				Quantity:    item.Quantity,
+				Reason:      item.Reason,
+			})
+			if err != nil {
+				return err
+			}
+			result.Items = append(result.Items, returnItem)
+		}
+		event, err := q.CreateReturnEvent(ctx, CreateReturnEventParams{
+			ID:       uuid.New(),
+			ReturnId: arg.ID,
+			ToStatus: ReturnStatusREQUESTED,
+			Note:     arg.Note,
+			ActorId:  arg.UserId,
+		})
+		if err != nil {
+			return err
+		}
+		result.History = []ReturnEvent{event}
+		return nil
+	})
+	if len(invalidItems) > 0 {
+		return result, invalidItems, nil, txErr
+	}
+	return result, invalidItems, execErr, txErr
+}
+
+type UpdateReturnStatusTxParams struct {
+	ID              uuid.UUID    `json:"id"`
+	Status          ReturnStatus `json:"status"`
+	ActorId         uuid.UUID    `json:"actorId"`
+	Note            string       `json:"note"`
+	RefundReference string       `json:"refundReference"`
+	// Release moves a REFUNDING return back to RECEIVED after its refund
+	// failed, Status being ignored
+	Release bool `json:"release"`
+	// ReclaimBefore lets a REFUNDING return last updated before it be claimed
+	// again, its refund having been interrupted
+	ReclaimBefore time.Time `json:"reclaimBefore"`
+}
+
+// UpdateReturnStatusTx moves a return to its next state and records the
+// transition in its history. Received products are put back in stock.
+//
+// The return is locked for the transition, so of the concurrent refunds of a
+// return only one claims it as REFUNDING; the others get
+// rma.ErrInvalidTransition.
+func (store *SQLStore) UpdateReturnStatusTx(ctx context.Context, arg UpdateReturnStatusTxParams) (ReturnTxResult, error, error) {
+	var result ReturnTxResult
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		current, err := q.GetReturnRequestForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case arg.Release:
+			arg.Status = ReturnStatusRECEIVED
+			err = rma.Release(string(current.Status))
+		case arg.Status == ReturnStatusREFUNDING && current.Status == ReturnStatusREFUNDING &&
+			current.UpdatedAt.Time.Before(arg.ReclaimBefore):
+		default:
+			err = rma.Transition(string(current.Status), string(arg.Status))
+		}
+		if err != nil {
+			return err
+		}
+		result.Items, err = q.GetReturnItemByReturnIds(ctx, []uuid.UUID{arg.ID})
+		if err != nil {
+			return err
+		}
+		if arg.Status == ReturnStatusRECEIVED && !arg.Release {
+			// Returned units go back to the warehouse that shipped them
+			allocations, err := q.GetOrderAllocations(ctx, current.OrderId)
+			if err != nil {
+				return err
+			}
+			warehouses := make(map[uuid.UUID]uuid.UUID, len(allocations))
+			for _, allocation := range allocations {
+				if _, ok := warehouses[allocation.ProductId]; !ok {
+					warehouses[allocation.ProductId] = allocation.WarehouseId
+				}
+			}
+			for _, item := range result.Items {
+				_, err = q.removeStock(ctx, item.ProductId, item.Quantity*-1, StockChange{
+					Kind:        StockMovementKindRETURN,
+					OrderId:     current.OrderId,
+					ReturnId:    arg.ID,
+					ActorId:     arg.ActorId,
+					WarehouseId: warehouses[item.ProductId],
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		refundReference, refundedAt := current.RefundReference, current.RefundedAt
+		if arg.Status == ReturnStatusREFUNDED {
+			refundReference = arg.RefundReference
+			refundedAt = pgtype.Timestamp{Time: time.Now().UTC(), Valid: true}
+		}
+		result.Return, err = q.UpdateReturnRequestStatus(ctx, UpdateReturnRequestStatusParams{
+			ID:              arg.ID,
+			Status:          arg.Status,
+			RefundReference: refundReference,
+			RefundedAt:      refundedAt,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = q.CreateReturnEvent(ctx, CreateReturnEventParams{
+			ID:         uuid.New(),
+			ReturnId:   arg.ID,
+			FromStatus: NullReturnStatus{ReturnStatus: current.Status, Valid: true},
+			ToStatus:   arg.Status,
+			Note:       arg.Note,
+			ActorId:    arg.ActorId,
+		})
+		if err != nil {
+			return err
+		}
+		result.History, err = q.GetReturnEventByReturnIds(ctx, []uuid.UUID{arg.ID})
+		return err
+	})
+	return result, execErr, txErr
+}
@@ -0,0 +1,192 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// errShipmentRejected rolls back a shipment transaction whose request was
+// found to be invalid once the order was locked.
+var errShipmentRejected = errors.New("shipment rejected")
+
+type CreateShipmentTxParams struct {
+	ID             uuid.UUID           `json:"id"`
+	OrderId        uuid.UUID           `json:"orderId"`
+	Carrier        string              `json:"carrier"`
+	TrackingNumber string              `json:"trackingNumber"`
+	Items          map[uuid.UUID]int32 `json:"items"`
+	CreatedBy      uuid.UUID           `json:"createdBy"`
+}
+
+type ShipmentTxResult struct {
+	Shipment Shipment       `json:"shipment"`
+	Items    []ShipmentItem `json:"items"`
+	Order    Order          `json:"order"`
+}
+
+// CreateShipmentTx ships the requested quantity of each product of the order,
+// or everything left to ship when no items are given, and moves the order to
+// PARTIALLY_SHIPPED or SHIPPED. Problems with the request are returned in the
+// message map keyed by product id or field name.
+func (store *SQLStore) CreateShipmentTx(ctx context.Context, arg CreateShipmentTxParams) (ShipmentTxResult, map[string]string, error, error) {
+	var result ShipmentTxResult
+	var invalidItems = make(map[string]string)
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		// Locking the order serialises concurrent shipments of the same order.
+		order, err := q.GetOrderForUpdate(ctx, arg.OrderId)
+		if err != nil {
+			return err
+		}
+		if order.Status != OrderStatusCOMPLETED && order.Status != OrderStatusPARTIALLYSHIPPED {
+			invalidItems["status"] = "only COMPLETED or PARTIALLY_SHIPPED orders can be shipped"
+			return errShipmentRejected
+		}
+		orderItems, err := q.GetOrderItemShippedQuantity(ctx, arg.OrderId)
+		if err != nil {
+			return err
+		}
+		byProduct := make(map[uuid.UUID]GetOrderItemShippedQuantityRow)
+		for _, orderItem := range orderItems {
+			byProduct[orderItem.ProductId] = orderItem
+		}
+		toShip := make(map[uuid.UUID]int32)
+		if len(arg.Items) == 0 {
+			for _, orderItem := range orderItems {
+				if remaining := orderItem.Quantity - orderItem.Shipped; remaining > 0 {
+					toShip[orderItem.ProductId] = remaining
+				}
+			}
+			if len(toShip) == 0 {
+				invalidItems["items"] = "all items of the order have already been shipped"
+			}
+		}
+		for productId, quantity := range arg.Items {
+			orderItem, ok := byProduct[productId]
+			if !ok {
+				invalidItems[productId.String()] = "product not in order"
+				continue
+			}
+			if quantity > orderItem.Quantity-orderItem.Shipped {
+				invalidItems[productId.String()] = "quantity more than left to ship"
+				continue
+			}
+			toShip[productId] = quantity
+		}
+		if len(invalidItems) > 0 {
+			return errShipmentRejected
+		}
+		result.Shipment, err = q.CreateShipment(ctx, CreateShipmentParams{
+			ID:             arg.ID,
+			OrderId:        arg.OrderId,
+			Carrier:        arg.Carrier,
+			TrackingNumber: arg.TrackingNumber,
+			CreatedBy:      arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+		fullyShipped := true
+		for _, orderItem := range orderItems {
+			quantity := toShip[orderItem.ProductId]
+			if orderItem.Shipped+quantity < orderItem.Quantity {
+				fullyShipped = false
+			}
+			if quantity == 0 {
+				continue
+			}
+			shipmentItem, err := q.CreateShipmentItem(ctx, CreateShipmentItemParams{
+				ID:          uuid.New(),
+				ShipmentId:  arg.ID,
+				OrderItemId: orderItem.ID,
+				ProductId:   orderItem.ProductId,
+				Quantity:    quantity,
+			})
+			if err != nil {
+				return err
+			}
+			result.Items = append(result.Items, shipmentItem)
+		}
+		status := OrderStatusPARTIALLYSHIPPED
+		if fullyShipped {
+			status = OrderStatusSHIPPED
+		}
+		result.Order, err = q.updateOrderStatus(ctx, order, status)
+		return err
+	})
+	if len(invalidItems) > 0 {
+		return result, invalidItems, nil, txErr
+	}
+	return result, invalidItems, execErr, txErr
+}
+
+type UpdateShipmentTxParams struct {
+	ID             uuid.UUID `json:"id"`
+	Carrier        *string   `json:"carrier,omitempty"`
+	TrackingNumber *string   `json:"trackingNumber,omitempty"`
+	Delivered      *bool     `json:"delivered,omitempty"`
+}
+
+// UpdateShipmentTx updates the tracking details of a shipment and records its
+// delivery. A SHIPPED order becomes DELIVERED once all its shipments are
+// delivered and goes back to SHIPPED if a delivery is undone.
+func (store *SQLStore) UpdateShipmentTx(ctx context.Context, arg UpdateShipmentTxParams) (ShipmentTxResult, error, error) {
+	var result ShipmentTxResult
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		shipment, err := q.GetOneShipment(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		order, err := q.GetOrderForUpdate(ctx, shipment.OrderId)
+		if err != nil {
+			return err
+		}
+		if arg.Carrier == nil {
+			arg.Carrier = &shipment.Carrier
+		}
+		if arg.TrackingNumber == nil {
+			arg.TrackingNumber = &shipment.TrackingNumber
+		}
+		deliveredAt := shipment.DeliveredAt
+		if arg.Delivered != nil {
+			if !*arg.Delivered {
+				deliveredAt = pgtype.Timestamp{}
+			} else if !deliveredAt.Valid {
+				deliveredAt = pgtype.Timestamp{Time: time.Now().UTC(), Valid: true}
+			}
+		}
+		result.Shipment, err = q.UpdateOneShipment(ctx, UpdateOneShipmentParams{
+			ID:             arg.ID,
+			Carrier:        *arg.Carrier,
+			TrackingNumber: *arg.TrackingNumber,
+			DeliveredAt:    deliveredAt,
+		})
+		if err != nil {
+			return err
+		}
+		result.Items, err = q.GetShipmentItemByShipmentIds(ctx, []uuid.UUID{arg.ID})
+		if err != nil {
+			return err
+		}
+		result.Order = order
+		if order.Status != OrderStatusSHIPPED && order.Status != OrderStatusDELIVERED {
+			return nil
+		}
+		undelivered, err := q.CountUndeliveredShipment(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		status := OrderStatusSHIPPED
+		if undelivered == 0 {
+			status = OrderStatusDELIVERED
+		}
+		if status == order.Status {
+			return nil
+		}
+		result.Order, err = q.updateOrderStatus(ctx, order, status)
+		return err
+	})
+	return result, execErr, txErr
+}
@@ -0,0 +1,213 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/shipping"
+	"math"
+)
+
+type UpdateShippingZoneTxParams struct {
+	ID      uuid.UUID `json:"id"`
+	Name    *string   `json:"name,omitempty"`
+	Regions *[]string `json:"regions,omitempty"`
+}
+
+func (store *SQLStore) UpdateShippingZoneTx(ctx context.Context, arg UpdateShippingZoneTxParams) (ShippingZone, error, error) {
+	var result ShippingZone
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		zone, err := q.GetOneShippingZone(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if arg.Name == nil {
+			arg.Name = &zone.Name
+		}
+		if arg.Regions == nil {
+			arg.Regions = &zone.Regions
+		}
+		result, err = q.UpdateOneShippingZone(ctx, UpdateOneShippingZoneParams{
+			ID:      arg.ID,
+			Name:    *arg.Name,
+			Regions: *arg.Regions,
+		})
+		return err
+	})
+	return result, execErr, txErr
+}
+
+type UpdateShippingMethodTxParams struct {
+	ID        uuid.UUID         `json:"id"`
+	Name      *string           `json:"name,omitempty"`
+	RateType  *ShippingRateType `json:"rateType,omitempty"`
+	Rate      *float64          `json:"rate,omitempty"`
+	RatePerKg *float64          `json:"ratePerKg,omitempty"`
+	FreeOver  *float64          `json:"freeOver,omitempty"`
+	Active    *bool             `json:"active,omitempty"`
+}
+
+func (store *SQLStore) UpdateShippingMethodTx(ctx context.Context, arg UpdateShippingMethodTxParams) (ShippingMethod, error, error) {
+	var result ShippingMethod
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		method, err := q.GetOneShippingMethod(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if arg.Name == nil {
+			arg.Name = &method.Name
+		}
+		if arg.RateType == nil {
+			arg.RateType = &method.RateType
+		}
+		if arg.Rate == nil {
+			arg.Rate = &method.Rate
+		}
+		if arg.RatePerKg == nil {
+			arg.RatePerKg = &method.RatePerKg
+		}
+		if arg.FreeOver == nil {
+			arg.FreeOver = &method.FreeOver
+		}
+		if arg.Active == nil {
+			arg.Active = &method.Active
+		}
+		result, err = q.UpdateOneShippingMethod(ctx, UpdateOneShippingMethodParams{
+			ID:        arg.ID,
+			Name:      *arg.Name,
+			RateType:  *arg.RateType,
+			Rate:      *arg.Rate,
+			RatePerKg: *arg.RatePerKg,
+			FreeOver:  *arg.FreeOver,
+			Active:    *arg.Active,
+		})
+		return err
+	})
+	return result, execErr, txErr
+}
+
+type QuoteShippingParams struct {
+	ProductIds []uuid.UUID         `json:"productIds"`
+	Items      map[uuid.UUID]int32 `json:"items"`
+	Region     string              `json:"region"`
+}
+
+// ShippingRate is a shipping method together with what it costs for a basket.
+type ShippingRate struct {
+	ShippingMethod
+	Cost float64 `json:"cost"`
+}
+
+// ShippingQuote lists the shipping methods available for a basket.
+type ShippingQuote struct {
+	Region   string         `json:"region"`
+	Zone     ShippingZone   `json:"zone"`
+	Subtotal float64        `json:"subtotal"`
+	Weight   float64        `json:"weight"`
+	Rates    []ShippingRate `json:"rates"`
+}
+
+// QuoteShipping prices every active shipping method of the zone covering the
+// region for the requested items. Problems with the request are returned in the
+// message map keyed by product id or field name.
+func (store *SQLStore) QuoteShipping(ctx context.Context, arg QuoteShippingParams) (ShippingQuote, map[string]string, error) {
+	var quote = ShippingQuote{Region: arg.Region, Rates: []ShippingRate{}}
+	var invalidProducts = make(map[string]string)
+	products, err := store.GetMultipleProductById(ctx, arg.ProductIds)
+	if err != nil {
+		return quote, invalidProducts, err
+	}
+	found := make(map[uuid.UUID]bool)
+	for _, product := range products {
+		found[product.ID] = true
+		quantity := arg.Items[product.ID]
+		quote.Subtotal += product.Price * float64(quantity)
+		quote.Weight += product.Weight * float64(quantity)
+	}
+	for _, productId := range arg.ProductIds {
+		if !found[productId] {
+			invalidProducts[productId.String()] = "product not found"
+		}
+	}
+	if len(invalidProducts) > 0 {
+		return quote, invalidProducts, nil
+	}
+	quote.Subtotal = math.Round(quote.Subtotal*100) / 100
+	quote.Weight = math.Round(quote.Weight*1000) / 1000
+	quote.Zone, err = store.shippingZone(ctx, arg.Region)
+	if err != nil {
+		if errors.Is(err, shipping.ErrNoZone) {
+			invalidProducts["region"] = err.Error()
+			return quote, invalidProducts, nil
+		}
+		return quote, invalidProducts, err
+	}
+	methods, err := store.GetActiveShippingMethodByZoneId(ctx, quote.Zone.ID)
+	if err != nil {
+		return quote, invalidProducts, err
+	}
+	basket := shipping.Basket{Subtotal: quote.Subtotal, Weight: quote.Weight}
+	for _, method := range methods {
+		quote.Rates = append(quote.Rates, ShippingRate{
+			ShippingMethod: method,
+			Cost:           shipping.Cost(shippingMethodOf(method), basket),
+		})
+	}
+	return quote, invalidProducts, nil
+}
+
+// shipOrder checks that the shipping method can ship to the region and returns
+// its cost for the basket. A non empty message means the method cannot be used.
+func (store *SQLStore) shipOrder(ctx context.Context, methodId uuid.UUID, region string, basket shipping.Basket) (ShippingMethod, float64, string, error) {
+	method, err := store.GetOneShippingMethod(ctx, methodId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return method, 0, "shipping method not found", nil
+		}
+		return method, 0, "", err
+	}
+	zone, err := store.shippingZone(ctx, region)
+	if err != nil && !errors.Is(err, shipping.ErrNoZone) {
+		return method, 0, "", err
+	}
+	if err != nil || !method.Active || zone.ID != method.ZoneId {
+		return method, 0, shipping.ErrMethodUnavailable.Error(), nil
+	}
+	return method, shipping.Cost(shippingMethodOf(method), basket), "", nil
+}
+
+// shippingZone returns the zone that ships to the region.
+func (store *SQLStore) shippingZone(ctx context.Context, region string) (ShippingZone, error) {
+	zones, err := store.GetAllShippingZone(ctx)
+	if err != nil {
+		return ShippingZone{}, err
+	}
+	candidates := make([]shipping.Zone, len(zones))
+	for i, zone := range zones {
+		candidates[i] = shipping.Zone{ID: zone.ID, Name: zone.Name, Regions: zone.Regions}
+	}
+	matched, err := shipping.MatchZone(candidates, region)
+	if err != nil {
+		return ShippingZone{}, err
+	}
+	for _, zone := range zones {
+		if zone.ID == matched.ID {
+			return zone, nil
+		}
+	}
+	return ShippingZone{}, shipping.ErrNoZone
+}
+
+// shippingMethodOf converts a stored shipping method into the method priced at checkout.
+func shippingMethodOf(method ShippingMethod) shipping.Method {
+	return shipping.Method{
+		ID:        method.ID,
+		ZoneID:    method.ZoneId,
+		Name:      method.Name,
+		RateType:  string(method.RateType),
+		Rate:      method.Rate,
+		RatePerKg: method.RatePerKg,
+		FreeOver:  method.FreeOver,
+	}
+}
@@ -4,7 +4,22 @@ import (
 	"context"
 )
 
-// ExecTx executes a function within a database transaction
+// ExecTx executes a function within a database transaction.
+//
+// Transactions that lock more than one kind of row take their locks in this
+// order, so that they wait on each other instead of deadlocking:
+//
+//  1. the order being changed, with GetOrderForUpdate
+//  2. the products whose stock changes, in one go and sorted by id with
+//     GetProductIdsForUpdate, or one by one with the ForUpdate queries of
+//     the product
+//  3. the stock the warehouses hold of those products, with
+//     GetAllocatableStock or GetWarehouseStockForUpdate
+//  4. the coupon redeemed by the order, with IncrementCouponUsage
+//
+// Restocks hand units to backordered orders after the products and their
+// stock are locked, so those orders come last. Imports lock their products
+// in the order of the rows.
 func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) (execErr error, txErr error) {
 	tx, err := store.connPool.Begin(ctx)
 	if err != nil {
@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+type UpdateTaxRuleTxParams struct {
+	ID        uuid.UUID `json:"id"`
+	Name      *string   `json:"name,omitempty"`
+	TaxClass  *string   `json:"taxClass,omitempty"`
+	Region    *string   `json:"region,omitempty"`
+	Rate      *float64  `json:"rate,omitempty"`
+	Inclusive *bool     `json:"inclusive,omitempty"`
+}
+
+func (store *SQLStore) UpdateTaxRuleTx(ctx context.Context, arg UpdateTaxRuleTxParams) (TaxRule, error, error) {
+	var result TaxRule
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		rule, err := q.GetOneTaxRule(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if arg.Name == nil {
+			arg.Name = &rule.Name
+		}
+		if arg.TaxClass == nil {
+			arg.TaxClass = &rule.TaxClass
+		}
+		if arg.Region == nil {
+			arg.Region = &rule.Region
+		}
+		if arg.Rate == nil {
+			arg.Rate = &rule.Rate
+		}
+		if arg.Inclusive == nil {
+			arg.Inclusive = &rule.Inclusive
+		}
+		result, err = q.UpdateOneTaxRule(ctx, UpdateOneTaxRuleParams{
+			ID:        arg.ID,
+			Name:      *arg.Name,
+			TaxClass:  *arg.TaxClass,
+			Region:    *arg.Region,
+			Rate:      *arg.Rate,
+			Inclusive: *arg.Inclusive,
+		})
+		return err
+	})
+	return result, execErr, txErr
+}
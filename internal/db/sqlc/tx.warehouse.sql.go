@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type UpdateWarehouseTxParams struct {
+	ID       uuid.UUID `json:"id"`
+	Name     *string   `json:"name,omitempty"`
+	Priority *int32    `json:"priority,omitempty"`
+	Active   *bool     `json:"active,omitempty"`
+}
+
+func (store *SQLStore) UpdateWarehouseTx(ctx context.Context, arg UpdateWarehouseTxParams) (Warehouse, error, error) {
+	var result Warehouse
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		warehouse, err := q.GetOneWarehouse(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if arg.Name == nil {
+			arg.Name = &warehouse.Name
+		}
+		if arg.Priority == nil {
+			arg.Priority = &warehouse.Priority
+		}
+		if arg.Active == nil {
+			arg.Active = &warehouse.Active
+		}
+		result, err = q.UpdateWarehouse(ctx, UpdateWarehouseParams{
+			ID:       arg.ID,
+			Name:     *arg.Name,
+			Priority: *arg.Priority,
+			Active:   *arg.Active,
+		})
+		return err
+	})
+	return result, execErr, txErr
+}
+
+type SetWarehouseStockTxParams struct {
+	WarehouseId uuid.UUID `json:"warehouseId"`
+	ProductId   uuid.UUID `json:"productId"`
+	Stock       int32     `json:"stock"`
+	Note        string    `json:"note"`
+	ActorId     uuid.UUID `json:"actorId"`
+}
+
+// SetWarehouseStockTx sets how many units of a product a warehouse holds. The
+// stock of the product changes by as much, and the change is recorded in the
+// stock ledger as an adjustment. Units added go to the backordered orders
+// waiting for the product first.
+func (store *SQLStore) SetWarehouseStockTx(ctx context.Context, arg SetWarehouseStockTxParams) (Product, error, error) {
+	var result Product
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetOneWarehouse(ctx, arg.WarehouseId)
+		if err != nil {
+			return err
+		}
+		product, err := q.GetProductForUpdate(ctx, arg.ProductId)
+		if err != nil {
+			return err
+		}
+		result = product
+		current, err := q.GetWarehouseStockForUpdate(ctx, GetWarehouseStockForUpdateParams{
+			WarehouseId: arg.WarehouseId,
+			ProductId:   arg.ProductId,
+		})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+		if arg.Stock == current.Stock {
+			return nil
+		}
+		result, err = q.removeStock(ctx, arg.ProductId, current.Stock-arg.Stock, StockChange{
+			Kind:        StockMovementKindADJUSTMENT,
+			ActorId:     arg.ActorId,
+			WarehouseId: arg.WarehouseId,
+			Note:        arg.Note,
+		})
+		if err != nil || arg.Stock < current.Stock {
+			return err
+		}
+		result, err = q.allocateBackorders(ctx, result)
+		return err
+	})
+	return result, execErr, txErr
+}
+
+type TransferStockTxParams struct {
+	ProductId       uuid.UUID `json:"productId"`
+	FromWarehouseId uuid.UUID `json:"fromWarehouseId"`
+	ToWarehouseId   uuid.UUID `json:"toWarehouseId"`
+	Quantity        int32     `json:"quantity"`
+	Note            string    `json:"note"`
+	ActorId         uuid.UUID `json:"actorId"`
+}
+
+// TransferStockTx moves units of a product from a warehouse to another. The
+// stock of the product is left as it is, the units taken off and added are
+// both recorded in the stock ledger. It fails with
+// fulfilment.ErrNotEnoughStock when the warehouse the units are moved from
+// holds fewer of them.
+func (store *SQLStore) TransferStockTx(ctx context.Context, arg TransferStockTxParams) (StockTransfer, error, error) {
+	var result StockTransfer
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		product, err := q.GetProductForUpdate(ctx, arg.ProductId)
+		if err != nil {
+			return err
+		}
+		for _, warehouseId := range []uuid.UUID{arg.FromWarehouseId, arg.ToWarehouseId} {
+			if _, err = q.GetOneWarehouse(ctx, warehouseId); err != nil {
+				return err
+			}
+		}
+		change := StockChange{
+			Kind:        StockMovementKindTRANSFER,
+			ActorId:     arg.ActorId,
+			WarehouseId: arg.FromWarehouseId,
+			Note:        arg.Note,
+		}
+		if err = q.recordStockMovement(ctx, product, arg.Quantity*-1, change); err != nil {
+			return err
+		}
+		change.WarehouseId = arg.ToWarehouseId
+		if err = q.recordStockMovement(ctx, product, arg.Quantity, change); err != nil {
+			return err
+		}
+		result, err = q.CreateStockTransfer(ctx, CreateStockTransferParams{
+			ID:              uuid.New(),
+			ProductId:       arg.ProductId,
+			FromWarehouseId: arg.FromWarehouseId,
+			ToWarehouseId:   arg.ToWarehouseId,
+			Quantity:        arg.Quantity,
+			Note:            arg.Note,
+			CreatedBy:       arg.ActorId,
+		})
+		return err
+	})
+	return result, execErr, txErr
+}
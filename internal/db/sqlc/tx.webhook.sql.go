@@ -0,0 +1,142 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// DeliverWebhookJob is the kind of the jobs sending webhook deliveries.
+const DeliverWebhookJob = "webhook.deliver"
+
+// webhookMaxAttempts is the number of times a delivery is tried, about 20
+// minutes of retries for a webhook that is down.
+const webhookMaxAttempts int32 = 8
+
+// DeliverWebhookArgs is the payload of a DeliverWebhookJob.
+type DeliverWebhookArgs struct {
+	DeliveryId uuid.UUID `json:"deliveryId"`
+}
+
+// enqueueJob adds a job to the queue within the transaction of q. It mirrors
+// jobs.Enqueue, which cannot be used here as the jobs package depends on db.
+func (q *Queries) enqueueJob(ctx context.Context, kind string, args any, maxAttempts int32) error {
+	return q.enqueueJobAt(ctx, kind, args, maxAttempts, time.Now())
+}
+
+// enqueueJobAt adds a job to be run at runAt to the queue within the
+// transaction of q.
+func (q *Queries) enqueueJobAt(ctx context.Context, kind string, args any, maxAttempts int32, runAt time.Time) error {
+	payload, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	_, err = q.CreateJob(ctx, CreateJobParams{
+		ID:          uuid.New(),
+		Kind:        kind,
+		Payload:     payload,
+		MaxAttempts: maxAttempts,
+		RunAt:       pgtype.Timestamp{Time: runAt.UTC(), Valid: true},
+	})
+	return err
+}
+
+// CreateWebhookDeliveriesTx creates a delivery, and a job sending it, for
+// every active webhook subscribed to an event. The deliveries of an event
+// are only created once, however often the event is relayed.
+func (store *SQLStore) CreateWebhookDeliveriesTx(ctx context.Context, eventId uuid.UUID) ([]WebhookDelivery, error, error) {
+	var deliveries []WebhookDelivery
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		// Locking the event serialises concurrent relays of the same event.
+		event, err := q.GetOutboxEventForUpdate(ctx, eventId)
+		if err != nil {
+			return err
+		}
+		count, err := q.CountWebhookDeliveriesForEvent(ctx, event.ID)
+		if err != nil || count > 0 {
+			return err
+		}
+		webhooks, err := q.GetWebhooksForEvent(ctx, event.Type)
+		if err != nil {
+			return err
+		}
+		for _, hook := range webhooks {
+			delivery, err := q.createWebhookDelivery(ctx, hook.ID, event.ID, event.Type)
+			if err != nil {
+				return err
+			}
+			deliveries = append(deliveries, delivery)
+		}
+		return nil
+	})
+	return deliveries, execErr, txErr
+}
+
+// RedeliverWebhookTx sends the event of a delivery to its webhook again, as a
+// new delivery.
+func (store *SQLStore) RedeliverWebhookTx(ctx context.Context, deliveryId uuid.UUID) (WebhookDelivery, error, error) {
+	var delivery WebhookDelivery
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		previous, err := q.GetOneWebhookDelivery(ctx, deliveryId)
+		if err != nil {
+			return err
+		}
+		delivery, err = q.createWebhookDelivery(ctx, previous.WebhookId, previous.EventId, previous.EventType)
+		return err
+	})
+	return delivery, execErr, txErr
+}
+
+func (q *Queries) createWebhookDelivery(ctx context.Context, webhookId uuid.UUID, eventId uuid.UUID, eventType string) (WebhookDelivery, error) {
+	delivery, err := q.CreateWebhookDelivery(ctx, CreateWebhookDeliveryParams{
+		ID:        uuid.New(),
+		WebhookId: webhookId,
+		EventId:   eventId,
+		EventType: eventType,
+	})
+	if err != nil {
+		return delivery, err
+	}
+	return delivery, q.enqueueJob(ctx, DeliverWebhookJob, DeliverWebhookArgs{DeliveryId: delivery.ID}, webhookMaxAttempts)
+}
+
+type UpdateWebhookTxParams struct {
+	ID     uuid.UUID `json:"id"`
+	Url    *string   `json:"url,omitempty"`
+	Secret *string   `json:"secret,omitempty"`
+	Events *[]string `json:"events,omitempty"`
+	Active *bool     `json:"active,omitempty"`
+}
+
+func (store *SQLStore) UpdateWebhookTx(ctx context.Context, arg UpdateWebhookTxParams) (Webhook, error, error) {
+	var result Webhook
+	execErr, txErr := store.execTx(ctx, func(q *Queries) error {
+		current, err := q.GetOneWebhook(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if arg.Url == nil {
+			arg.Url = &current.Url
+		}
+		if arg.Secret == nil {
+			arg.Secret = &current.Secret
+		}
+		if arg.Events == nil {
+			arg.Events = &current.Events
+		}
+		if arg.Active == nil {
+			arg.Active = &current.Active
+		}
+		result, err = q.UpdateOneWebhook(ctx, UpdateOneWebhookParams{
+			ID:     arg.ID,
+			Url:    *arg.Url,
+			Secret: *arg.Secret,
+			Events: *arg.Events,
+			Active: *arg.Active,
+		})
+		return err
+	})
+	return result, execErr, txErr
+}
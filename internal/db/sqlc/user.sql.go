@@ -11,6 +11,35 @@ import (
 	"github.com/google/uuid"
 )
 
+const claimGuestUser = `-- name: ClaimGuestUser :one
+UPDATE "user"
+SET password = $2,
+    guest = false,
+    "updatedAt" = NOW()
+WHERE id = $1 AND guest
+RETURNING id, email, password, admin, "createdAt", "updatedAt", guest
+`
+
+type ClaimGuestUserParams struct {
+	ID       uuid.UUID `json:"id"`
+	Password string    `json:"password"`
+}
+
+func (q *Queries) ClaimGuestUser(ctx context.Context, arg ClaimGuestUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, claimGuestUser, arg.ID, arg.Password)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Password,
+		&i.Admin,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Guest,
+	)
+	return i, err
+}
+
 const createAdminUser = `-- name: CreateAdminUser :one
 INSERT INTO "user" (
     id,
@@ -19,7 +48,7 @@ INSERT INTO "user" (
     admin
 ) VALUES (
     $1, $2, $3, true
-) RETURNING id, email, password, admin, "createdAt", "updatedAt"
+) RETURNING id, email, password, admin, "createdAt", "updatedAt", guest
 `
 
 type CreateAdminUserParams struct {
@@ -38,6 +67,38 @@ func (q *Queries) CreateAdminUser(ctx context.Context, arg CreateAdminUserParams
 		&i.Admin,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Guest,
+	)
+	return i, err
+}
+
+const createGuestUser = `-- name: CreateGuestUser :one
+INSERT INTO "user" (
+    id,
+    email,
+    password,
+    guest
+) VALUES (
+    $1, $2, '', true
+) RETURNING id, email, password, admin, "createdAt", "updatedAt", guest
+`
+
+type CreateGuestUserParams struct {
+	ID    uuid.UUID `json:"id"`
+	Email string    `json:"email"`
+}
+
+func (q *Queries) CreateGuestUser(ctx context.Context, arg CreateGuestUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, createGuestUser, arg.ID, arg.Email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Password,
+		&i.Admin,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Guest,
 	)
 	return i, err
 }
@@ -49,7 +110,7 @@ INSERT INTO "user" (
     password
 ) VALUES (
     $1, $2, $3
-) RETURNING id, email, password, admin, "createdAt", "updatedAt"
+) RETURNING id, email, password, admin, "createdAt", "updatedAt", guest
 `
 
 type CreateUserParams struct {
@@ -68,12 +129,13 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Admin,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Guest,
 	)
 	return i, err
 }
 
 const getUserById = `-- name: GetUserById :one
-SELECT id, email, password, admin
+SELECT id, email, password, admin, guest
 FROM "user"
 WHERE email = $1 LIMIT 1
 `
@@ -83,6 +145,7 @@ type GetUserByIdRow struct {
 	Email    string    `json:"email"`
 	Password string    `json:"password"`
 	Admin    bool      `json:"admin"`
+	Guest    bool      `json:"guest"`
 }
 
 func (q *Queries) GetUserById(ctx context.Context, email string) (GetUserByIdRow, error) {
@@ -93,6 +156,7 @@ func (q *Queries) GetUserById(ctx context.Context, email string) (GetUserByIdRow
 		&i.Email,
 		&i.Password,
 		&i.Admin,
+		&i.Guest,
 	)
 	return i, err
 }
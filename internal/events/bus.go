@@ -0,0 +1,75 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"sync"
+)
+
+// Handler handles an event published on the Bus.
+type Handler func(ctx context.Context, event Envelope) error
+
+// Bus is an in-process event bus. As a sink of the Dispatcher, it runs the
+// handlers subscribed to each event once the event is committed.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// NewBus creates a new Bus instance.
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// All subscribes a handler to every event.
+const All = "*"
+
+// Subscribe runs handler for the events of type eventType, or of any type
+// with All.
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// On runs handler for the events of type T, decoded.
+func On[T db.Event](b *Bus, handler func(ctx context.Context, event T) error) {
+	var zero T
+	b.Subscribe(zero.EventType(), func(ctx context.Context, event Envelope) error {
+		data, err := Decode[T](event)
+		if err != nil {
+			return err
+		}
+		return handler(ctx, data)
+	})
+}
+
+func (b *Bus) Name() string {
+	return "bus"
+}
+
+// Send runs every handler subscribed to the event. The event is relayed
+// again when any of them fails, so handlers must be idempotent.
+func (b *Bus) Send(ctx context.Context, event Envelope) error {
+	b.mu.RLock()
+	handlers := append(append([]Handler{}, b.handlers[event.Type]...), b.handlers[All]...)
+	b.mu.RUnlock()
+	var errs []error
+	for _, handler := range handlers {
+		if err := runHandler(ctx, handler, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func runHandler(ctx context.Context, handler Handler, event Envelope) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler of %s panicked: %v", event.Type, r)
+		}
+	}()
+	return handler(ctx, event)
+}
@@ -0,0 +1,191 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/jobs"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config configures a Dispatcher.
+type Config struct {
+	// PollInterval is how long the dispatcher waits before looking for
+	// events again once the outbox is empty
+	PollInterval time.Duration
+	// BatchSize is the number of events relayed at a time
+	BatchSize int32
+	// Lease is how long other dispatchers leave the events being relayed
+	// alone. Events of a dispatcher that died are relayed again after it.
+	Lease time.Duration
+	// MaxAttempts is the number of times an event is relayed to the sinks
+	// that fail to take it before the dispatcher gives up on it
+	MaxAttempts int32
+}
+
+// DefaultMaxAttempts is the number of times an event is relayed before the
+// dispatcher gives up on it, which with the backoff of jobs is about half a day.
+const DefaultMaxAttempts = 20
+
+// Dispatcher relays the events of the outbox to sinks.
+type Dispatcher struct {
+	store  db.Store
+	config Config
+	sinks  []Sink
+
+	mu      sync.Mutex
+	started bool
+	stop    chan struct{}
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewDispatcher creates a new Dispatcher instance relaying events to sinks.
+func NewDispatcher(store db.Store, config Config, sinks ...Sink) *Dispatcher {
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.Lease <= 0 {
+		config.Lease = time.Minute
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	return &Dispatcher{
+		store:  store,
+		config: config,
+		sinks:  sinks,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start starts relaying events until Shutdown is called.
+func (d *Dispatcher) Start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.started {
+		return
+	}
+	d.started = true
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	go d.run(ctx)
+}
+
+// Shutdown stops relaying events once the current batch is relayed. When ctx
+// is done first the batch is cancelled; its events are relayed again once
+// their lease ends.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.started {
+		d.mu.Unlock()
+		return nil
+	}
+	d.started = false
+	close(d.stop)
+	d.mu.Unlock()
+	select {
+	case <-d.done:
+		d.cancel()
+		return nil
+	case <-ctx.Done():
+		d.cancel()
+		<-d.done
+		return ctx.Err()
+	}
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	defer close(d.done)
+	for {
+		select {
+		case <-d.stop:
+			return
+		default:
+		}
+		count, err := d.Dispatch(ctx)
+		if err != nil {
+			log.Printf("Error while dispatching events: %v", err)
+		}
+		// A full batch means more events are likely waiting
+		if err == nil && count == int(d.config.BatchSize) {
+			continue
+		}
+		select {
+		case <-d.stop:
+			return
+		case <-time.After(d.config.PollInterval):
+		}
+	}
+}
+
+// Dispatch relays a batch of due events and returns how many were claimed.
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	events, err := d.store.ClaimOutboxEvents(ctx, db.ClaimOutboxEventsParams{
+		LeaseUntil: pgtype.Timestamp{Time: now.Add(d.config.Lease), Valid: true},
+		Now:        pgtype.Timestamp{Time: now, Valid: true},
+		BatchSize:  d.config.BatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, event := range events {
+		d.relay(ctx, event)
+	}
+	return len(events), nil
+}
+
+// relay sends an event to every sink that has not taken it yet and records
+// the outcome. The sinks that took the event are recorded along with it so
+// that a retry only goes to the sinks that failed. Once the event has been
+// relayed MaxAttempts times the dispatcher gives up on it.
+func (d *Dispatcher) relay(ctx context.Context, event db.OutboxEvent) {
+	delivered := append([]string{}, event.DeliveredSinks...)
+	var failures []string
+	for _, sink := range d.sinks {
+		if slices.Contains(event.DeliveredSinks, sink.Name()) {
+			continue
+		}
+		if err := sink.Send(ctx, envelope(event)); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", sink.Name(), err))
+			continue
+		}
+		delivered = append(delivered, sink.Name())
+	}
+	// The outcome is recorded even when the dispatcher is being cancelled.
+	ctx = context.WithoutCancel(ctx)
+	var err error
+	lastError := strings.Join(failures, "; ")
+	switch {
+	case len(failures) == 0:
+		_, err = d.store.MarkOutboxEventDispatched(ctx, event.ID)
+	case event.Attempts >= d.config.MaxAttempts:
+		log.Printf("Event %s (%s) not relayed after %d attempts, giving up: %s", event.ID, event.Type, event.Attempts, lastError)
+		_, err = d.store.FailOutboxEvent(ctx, db.FailOutboxEventParams{
+			ID:             event.ID,
+			LastError:      lastError,
+			DeliveredSinks: delivered,
+		})
+	default:
+		log.Printf("Event %s (%s) not relayed, retrying: %s", event.ID, event.Type, lastError)
+		_, err = d.store.RetryOutboxEvent(ctx, db.RetryOutboxEventParams{
+			ID:             event.ID,
+			LastError:      lastError,
+			DeliveredSinks: delivered,
+			NextAttemptAt:  pgtype.Timestamp{Time: time.Now().UTC().Add(jobs.Backoff(event.Attempts)), Valid: true},
+		})
+	}
+	if err != nil {
+		log.Printf("Error while saving the outcome of event %s: %v", event.ID, err)
+	}
+}
@@ -0,0 +1,56 @@
+// Package events relays the domain events saved to the outbox to the sinks
+// interested in them: the in-process bus, webhooks, the log, NATS or Kafka.
+//
+// Transactions save the events they raise to the outbox table, so an event
+// exists if and only if what it describes was committed. The Dispatcher
+// polls the outbox and hands every event to each sink, retrying with backoff
+// the events a sink failed to take. Delivery is at least once: a sink can see
+// an event again after a failure of another sink or a crash, and should use
+// the event id to drop duplicates.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"time"
+)
+
+// Envelope is an event as relayed to the sinks.
+type Envelope struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Sink receives the events relayed from the outbox.
+type Sink interface {
+	// Name identifies the sink in logs and errors, and in the outbox among
+	// the sinks that took an event, so it must be unique and stable
+	Name() string
+	// Send takes an event. Returning an error relays it again later, to the
+	// sinks that failed only.
+	Send(ctx context.Context, event Envelope) error
+}
+
+func envelope(event db.OutboxEvent) Envelope {
+	return Envelope{
+		ID:        event.ID,
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt.Time,
+		Data:      event.Payload,
+	}
+}
+
+// Decode decodes the data of an event into its typed domain event.
+func Decode[T db.Event](event Envelope) (T, error) {
+	var data T
+	if event.Type != data.EventType() {
+		return data, fmt.Errorf("event %s is a %s, not a %s", event.ID, event.Type, data.EventType())
+	}
+	err := json.Unmarshal(event.Data, &data)
+	return data, err
+}
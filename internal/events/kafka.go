@@ -0,0 +1,39 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// KafkaProducer writes a message to a Kafka topic and returns once the
+// brokers acknowledged it. It is met by a small adapter over the Kafka
+// client in use, e.g. a kafka-go Writer or a franz-go Client.
+type KafkaProducer interface {
+	Produce(ctx context.Context, topic string, key, value []byte, headers map[string]string) error
+}
+
+// KafkaSink writes every event to a Kafka topic, keyed by the event id.
+type KafkaSink struct {
+	producer KafkaProducer
+	topic    string
+}
+
+// NewKafkaSink creates a new KafkaSink instance writing to topic.
+func NewKafkaSink(producer KafkaProducer, topic string) *KafkaSink {
+	return &KafkaSink{producer: producer, topic: topic}
+}
+
+func (s *KafkaSink) Name() string {
+	return "kafka"
+}
+
+func (s *KafkaSink) Send(ctx context.Context, event Envelope) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return s.producer.Produce(ctx, s.topic, []byte(event.ID.String()), value, map[string]string{
+		"event-id":   event.ID.String(),
+		"event-type": event.Type,
+	})
+}
@@ -0,0 +1,40 @@
+package events
+
+import (
+	"context"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"log"
+)
+
+// LogSink writes every event to the standard logger.
+type LogSink struct{}
+
+func (LogSink) Name() string {
+	return "log"
+}
+
+func (LogSink) Send(ctx context.Context, event Envelope) error {
+	log.Printf("Event %s %s: %s", event.ID, event.Type, event.Data)
+	return nil
+}
+
+// WebhookSink hands every event to the webhooks subscribed to it. The
+// deliveries themselves are sent by the job queue.
+type WebhookSink struct {
+	store db.Store
+}
+
+// NewWebhookSink creates a new WebhookSink instance.
+func NewWebhookSink(store db.Store) *WebhookSink {
+	return &WebhookSink{store: store}
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *WebhookSink) Send(ctx context.Context, event Envelope) error {
+	_, execErr, txErr := s.store.CreateWebhookDeliveriesTx(ctx, event.ID)
+	return utils.ConcatenateErrors(execErr, txErr)
+}
@@ -0,0 +1,125 @@
+// Package fulfilment routes the lines of an order to the warehouses that
+// ship them.
+package fulfilment
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNoWarehouse    = errors.New("no warehouse is active")
+	ErrNotEnoughStock = errors.New("the warehouse does not hold enough stock")
+	// ErrBackordered is returned when a backordered order, still waiting for
+	// some of its units, is moved to another status than CANCELLED.
+	ErrBackordered = errors.New("order is waiting for backordered units")
+	// ErrNotCancellable is returned when an order that has shipped or is
+	// already cancelled is cancelled.
+	ErrNotCancellable = errors.New("order can no longer be cancelled")
+	// ErrStatusChange is returned when an order is moved to a status it
+	// cannot reach from its current one.
+	ErrStatusChange = errors.New("order cannot move to this status")
+)
+
+// Line is a product of an order and how many units of it are ordered.
+type Line struct {
+	ProductId uuid.UUID
+	Quantity  int32
+}
+
+// Stock is how many units of a product a warehouse holds.
+type Stock struct {
+	WarehouseId uuid.UUID
+	ProductId   uuid.UUID
+	Stock       int32
+}
+
+// Allocation is a number of units of a product shipped from a warehouse.
+type Allocation struct {
+	WarehouseId uuid.UUID
+	ProductId   uuid.UUID
+	Quantity    int32
+}
+
+// InsufficientStockError is returned when the warehouses together do not hold
+// enough units of a product.
+type InsufficientStockError struct {
+	ProductId uuid.UUID
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("not enough stock of product %s in the warehouses", e.ProductId)
+}
+
+// Allocate picks the warehouses the lines of an order are shipped from.
+// stock lists what the warehouses hold, the warehouses in order of priority.
+//
+// The first warehouse able to ship the whole order ships it. Failing that,
+// each line is shipped by the first warehouse able to ship all of it, and
+// lines no warehouse can ship on its own are split across the warehouses in
+// order of priority.
+func Allocate(lines []Line, stock []Stock) ([]Allocation, error) {
+	var warehouses []uuid.UUID
+	held := make(map[uuid.UUID]map[uuid.UUID]int32)
+	for _, row := range stock {
+		if _, ok := held[row.WarehouseId]; !ok {
+			warehouses = append(warehouses, row.WarehouseId)
+			held[row.WarehouseId] = make(map[uuid.UUID]int32)
+		}
+		held[row.WarehouseId][row.ProductId] += row.Stock
+	}
+	for _, warehouseId := range warehouses {
+		if holdsAll(held[warehouseId], lines) {
+			allocations := make([]Allocation, 0, len(lines))
+			for _, line := range lines {
+				allocations = append(allocations, Allocation{WarehouseId: warehouseId, ProductId: line.ProductId, Quantity: line.Quantity})
+			}
+			return allocations, nil
+		}
+	}
+	var allocations []Allocation
+	for _, line := range lines {
+		lineAllocations, err := allocateLine(line, warehouses, held)
+		if err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, lineAllocations...)
+	}
+	return allocations, nil
+}
+
+func holdsAll(stock map[uuid.UUID]int32, lines []Line) bool {
+	for _, line := range lines {
+		if stock[line.ProductId] < line.Quantity {
+			return false
+		}
+	}
+	return true
+}
+
+// allocateLine allocates a line, taking what it allocates off held so that
+// the next lines only get what is left.
+func allocateLine(line Line, warehouses []uuid.UUID, held map[uuid.UUID]map[uuid.UUID]int32) ([]Allocation, error) {
+	for _, warehouseId := range warehouses {
+		if held[warehouseId][line.ProductId] >= line.Quantity {
+			held[warehouseId][line.ProductId] -= line.Quantity
+			return []Allocation{{WarehouseId: warehouseId, ProductId: line.ProductId, Quantity: line.Quantity}}, nil
+		}
+	}
+	var allocations []Allocation
+	remaining := line.Quantity
+	for _, warehouseId := range warehouses {
+		quantity := min(held[warehouseId][line.ProductId], remaining)
+		if quantity <= 0 {
+			continue
+		}
+		held[warehouseId][line.ProductId] -= quantity
+		remaining -= quantity
+		allocations = append(allocations, Allocation{WarehouseId: warehouseId, ProductId: line.ProductId, Quantity: quantity})
+		if remaining == 0 {
+			return allocations, nil
+		}
+	}
+	return nil, &InsufficientStockError{ProductId: line.ProductId}
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"log"
+	"net/http"
+)
+
+// CouponHandler handles coupon related operations.
+type CouponHandler struct {
+	couponService *services.CouponService
+}
+
+// NewCouponHandler creates a new CouponHandler instance.
+func NewCouponHandler(store db.Store) *CouponHandler {
+	return &CouponHandler{couponService: services.NewCouponService(store)}
+}
+
+// CreateCoupon godoc
+// @Summary      Create a new coupon. Requires admin privilege
+// @Description  Create a new percentage or fixed amount coupon. Requires admin privilege
+// @Tags         coupon
+// @Accept       json
+// @Produce      json
+// @Param        payload   body	types.CreateCouponInput  true  "Create Coupon request body"
+// @Success      201  {object}  types.Coupon
+// @Failure      400  {object}  types.CouponError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/coupons [post]
+func (h *CouponHandler) CreateCoupon(ctx *gin.Context) {
+	var err error
+	var req types.CreateCouponInput
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.couponService.CreateCoupon(ctx, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Coupon not created",
+			"error":   errMessage,
+		})
+		log.Printf("Error while creating coupon: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Coupon created",
+		"data":    response,
+	})
+}
+
+// GetAllCoupon godoc
+// @Summary      List all coupons. Requires admin privilege
+// @Description  List all coupons. Requires admin privilege
+// @Tags         coupon
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   types.Coupon
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/coupons [get]
+func (h *CouponHandler) GetAllCoupon(ctx *gin.Context) {
+	var err error
+	response, errMessage, statusCode, err := h.couponService.GetAllCoupon(ctx)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch coupons",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching coupons: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Coupons retrieved",
+		"data":    response,
+	})
+}
+
+// GetOneCoupon godoc
+// @Summary      Fetch One Coupon. Requires admin privilege
+// @Description  Fetch One Coupon. Requires admin privilege
+// @Tags         coupon
+// @Accept       json
+// @Produce      json
+// @Param        couponId   path	string  true  "Unique coupon id"
+// @Success      200  {object}  types.Coupon
+// @Failure      404  {object}  types.CouponError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/coupons/{couponId} [get]
+func (h *CouponHandler) GetOneCoupon(ctx *gin.Context) {
+	var err error
+	var couponId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.couponService.GetOneCoupon(ctx, couponId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch coupon",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching coupon: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Coupon retrieved",
+		"data":    response,
+	})
+}
+
+// UpdateOneCoupon godoc
+// @Summary      Update a single Coupon. Requires admin privilege
+// @Description  Update a single Coupon. Only the fields present in the body are changed. Requires admin privilege
+// @Tags         coupon
+// @Accept       json
+// @Produce      json
+// @Param        couponId   path	string  true  "Unique coupon id"
+// @Param        payload   	body	types.CouponUpdateInput  true  "Update Coupon request body"
+// @Success      200  {object}	types.Coupon
+// @Failure      400  {object}  types.CouponError
+// @Failure      404  {object}  types.CouponError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/coupons/{couponId} [put]
+func (h *CouponHandler) UpdateOneCoupon(ctx *gin.Context) {
+	var err error
+	var req types.CouponUpdateInput
+	var couponId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.couponService.UpdateOneCoupon(ctx, couponId, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Coupon not updated",
+			"error":   errMessage,
+		})
+		log.Printf("Error while updating coupon: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Coupon updated",
+		"data":    response,
+	})
+}
+
+// DeleteOneCoupon godoc
+// @Summary      Delete One Coupon. Requires admin privilege
+// @Description  Delete One Coupon. A coupon that has been redeemed cannot be deleted, deactivate it instead. Requires admin privilege
+// @Tags         coupon
+// @Accept       json
+// @Produce      json
+// @Param        couponId   path	string  true  "Unique coupon id"
+// @Success      204
+// @Failure      400  {object}  types.CouponError
+// @Failure      409  {object}  types.CouponError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/coupons/{couponId} [delete]
+func (h *CouponHandler) DeleteOneCoupon(ctx *gin.Context) {
+	var err error
+	var couponId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	_, errMessage, statusCode, err := h.couponService.DeleteOneCoupon(ctx, couponId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to delete coupon",
+			"error":   errMessage,
+		})
+		log.Printf("Error while deleting coupon: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Coupon deleted",
+		"data":    gin.H{},
+	})
+}
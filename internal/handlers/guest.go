@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/pkg/token"
+	"log"
+	"net/http"
+)
+
+// GuestHandler handles orders placed without an account.
+type GuestHandler struct {
+	guestService *services.GuestService
+}
+
+// NewGuestHandler creates a new GuestHandler instance.
+func NewGuestHandler(store db.Store, jwtToken *token.JWT) *GuestHandler {
+	return &GuestHandler{guestService: services.NewGuestService(store, jwtToken, nil)}
+}
+
+// CreateGuestOrder godoc
+// @Summary      Place an order as a guest
+// @Description  Place an order without an account, shipped to the address given. A token to look up the order is emailed to the guest. Emails belonging to an account have to log in instead
+// @Tags         guest
+// @Accept       json
+// @Produce      json
+// @Param        payload   body	types.GuestOrderInput  true  "Guest Order request body"
+// @Success      201  {object}  types.Order
+// @Failure      400  {object}  types.GuestOrderError
+// @Failure      409  {object}  types.GuestOrderError
+// @Failure      500  {object}  types.InterServerError
+// @Router       /guest/orders [post]
+func (h *GuestHandler) CreateGuestOrder(ctx *gin.Context) {
+	var req types.GuestOrderInput
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.guestService.CreateGuestOrder(ctx, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Order not created",
+			"error":   errMessage,
+		})
+		log.Printf("Error while creating guest order: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Order created, a token to look it up was emailed",
+		"data":    response,
+	})
+}
+
+// GetGuestOrder godoc
+// @Summary      Look up a guest order
+// @Description  Get an order placed as a guest, along with its shipping address, with the token emailed to the guest. The token is sent in the body to keep it out of URLs. No sign in is needed
+// @Tags         guest
+// @Accept       json
+// @Produce      json
+// @Param        payload   body	types.GuestOrderLookupInput  true  "Guest Order Lookup request body"
+// @Success      200  {object}  types.GuestOrder
+// @Failure      400  {object}  types.GuestOrderError
+// @Failure      401  {object}  types.GuestOrderError
+// @Failure      404  {object}  types.GuestOrderError
+// @Failure      500  {object}  types.InterServerError
+// @Router       /guest/orders/lookup [post]
+func (h *GuestHandler) GetGuestOrder(ctx *gin.Context) {
+	var req types.GuestOrderLookupInput
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.guestService.GetGuestOrder(ctx, req.Token)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch order",
+			"error":   errMessage,
+		})
+		log.Printf("Error while looking up guest order: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Order retrieved",
+		"data":    response,
+	})
+}
+
+// RequestAccountClaim godoc
+// @Summary      Request a token to claim the account of a guest
+// @Description  Email a guest a token to claim their account with, valid for an hour and for a single claim. The response is the same whether or not the email has guest orders
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        payload   body	types.AccountClaimRequestInput  true  "Account Claim request body"
+// @Success      202
+// @Failure      400  {object}  types.ClaimAccountError
+// @Failure      500  {object}  types.InterServerError
+// @Router       /auth/claim/request [post]
+func (h *GuestHandler) RequestAccountClaim(ctx *gin.Context) {
+	var req types.AccountClaimRequestInput
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	errMessage, statusCode, err := h.guestService.RequestAccountClaim(ctx, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Account claim not requested",
+			"error":   errMessage,
+		})
+		log.Printf("Error while requesting guest account claim: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "A token to claim the account is emailed to guests",
+	})
+}
+
+// ClaimAccount godoc
+// @Summary      Claim the account of a guest
+// @Description  Turn the email a guest ordered with into an account with a password, proving it with the account claim token emailed to the guest. Order lookup tokens are not accepted. Every order placed as a guest with the email is kept. Generates an access token for the account
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        payload   body	types.ClaimAccountInput  true  "Claim Account request body"
+// @Success      200  {object}  types.LoginUserOutput
+// @Failure      400  {object}  types.ClaimAccountError
+// @Failure      401  {object}  types.ClaimAccountError
+// @Failure      409  {object}  types.ClaimAccountError
+// @Failure      500  {object}  types.InterServerError
+// @Router       /auth/claim [post]
+func (h *GuestHandler) ClaimAccount(ctx *gin.Context) {
+	var req types.ClaimAccountInput
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.guestService.ClaimAccount(ctx, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Account not claimed",
+			"error":   errMessage,
+		})
+		log.Printf("Error while claiming guest account: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Account claimed",
+		"data":    response,
+	})
+}
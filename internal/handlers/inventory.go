@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// InventoryHandler handles stock level related operations.
+type InventoryHandler struct {
+	inventoryService *services.InventoryService
+}
+
+// NewInventoryHandler creates a new InventoryHandler instance.
+func NewInventoryHandler(store db.Store) *InventoryHandler {
+	return &InventoryHandler{inventoryService: services.NewInventoryService(store, nil, nil)}
+}
+
+// GetLowStockProducts godoc
+// @Summary      Fetch the products low on stock. Requires admin privilege
+// @Description  Fetch the products whose stock is at or below their reorder threshold, those furthest below it first, with the alert opened when they ran low. Requires admin privilege
+// @Tags         inventory
+// @Accept       json
+// @Produce      json
+// @Param        category query	string  false  "Only the products of this category"
+// @Param        limit    query	int     false  "Number of products to return, 100 by default and at most 500"
+// @Success      200  {array}   types.LowStockProduct
+// @Failure      400  {object}  types.InventoryError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/inventory/low-stock [get]
+func (h *InventoryHandler) GetLowStockProducts(ctx *gin.Context) {
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "100"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid query parameters",
+			"error":   types.InventoryErrMessage{Limit: "limit must be a number"},
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.inventoryService.GetLowStockProducts(ctx, ctx.Query("category"), limit)
+	if err != nil || statusCode != http.StatusOK {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch low-stock products",
+			"error":   errMessage,
+		})
+		if err != nil {
+			log.Printf("Error while fetching low-stock products: %v", err)
+		}
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Low-stock products retrieved",
+		"data":    response,
+	})
+}
+
+// GetStockMovements godoc
+// @Summary      Fetch the stock ledger of a product. Requires admin privilege
+// @Description  Fetch the movements of the stock of a product, newest first: orders placed and cancelled, manual adjustments, imports, returns and transfers between warehouses. Pass the id of the last movement of a page as before to get the next one. ledgerStock is the sum of every movement and equals stock unless the stock was changed outside of the ledger. Requires admin privilege
+// @Tags         inventory
+// @Accept       json
+// @Produce      json
+// @Param        productId   path	string  true   "Unique product id"
+// @Param        before      query	int     false  "Only the movements before the movement with this id"
+// @Param        limit       query	int     false  "Number of movements to return, 50 by default and at most 500"
+// @Success      200  {object}  types.StockMovements
+// @Failure      400  {object}  types.InventoryError
+// @Failure      404  {object}  types.InventoryError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/products/{productId}/stock-movements [get]
+func (h *InventoryHandler) GetStockMovements(ctx *gin.Context) {
+	before, err := strconv.ParseInt(ctx.DefaultQuery("before", "0"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid query parameters",
+			"error":   types.InventoryErrMessage{Before: "before must be the id of a movement"},
+		})
+		return
+	}
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "50"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid query parameters",
+			"error":   types.InventoryErrMessage{Limit: "limit must be a number"},
+		})
+		return
+	}
+	productId := utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.inventoryService.GetStockMovements(ctx, productId, before, limit)
+	if err != nil || statusCode != http.StatusOK {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch stock movements",
+			"error":   errMessage,
+		})
+		if err != nil {
+			log.Printf("Error while fetching stock movements: %v", err)
+		}
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Stock movements retrieved",
+		"data":    response,
+	})
+}
+
+// ReconcileStock godoc
+// @Summary      Check the stock of the products against their stock ledger. Requires admin privilege
+// @Description  List the products whose stock is not the sum of their stock movements, or not the sum of what the warehouses hold. The list is empty when they all agree. Requires admin privilege
+// @Tags         inventory
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   types.StockDiscrepancy
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/inventory/reconciliation [get]
+func (h *InventoryHandler) ReconcileStock(ctx *gin.Context) {
+	response, errMessage, statusCode, err := h.inventoryService.ReconcileStock(ctx)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to reconcile stock",
+			"error":   errMessage,
+		})
+		log.Printf("Error while reconciling stock: %v", err)
+		return
+	}
+	message := "Stock matches the ledger"
+	if len(response) > 0 {
+		message = "Stock does not match the ledger"
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": message,
+		"data":    response,
+	})
+}
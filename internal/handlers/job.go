@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// JobHandler handles background job related operations.
+type JobHandler struct {
+	jobService *services.JobService
+}
+
+// NewJobHandler creates a new JobHandler instance.
+func NewJobHandler(store db.Store) *JobHandler {
+	return &JobHandler{jobService: services.NewJobService(store)}
+}
+
+// GetAllJob godoc
+// @Summary      Fetch background jobs. Requires admin privilege
+// @Description  Fetch the latest background jobs, newest first, optionally only those in a given status. DEAD jobs failed every attempt and wait to be retried. Requires admin privilege
+// @Tags         job
+// @Accept       json
+// @Produce      json
+// @Param        status   query	string  false  "PENDING, RUNNING, SUCCEEDED or DEAD"
+// @Param        limit    query	int     false  "Number of jobs to return, 50 by default and at most 500"
+// @Success      200  {array}   types.Job
+// @Failure      400  {object}  types.JobError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/jobs [get]
+func (h *JobHandler) GetAllJob(ctx *gin.Context) {
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "50"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid query parameters",
+			"error":   types.JobErrMessage{Limit: "limit must be a number"},
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.jobService.GetAllJob(ctx, ctx.Query("status"), limit)
+	if err != nil || statusCode != http.StatusOK {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch jobs",
+			"error":   errMessage,
+		})
+		if err != nil {
+			log.Printf("Error while fetching jobs: %v", err)
+		}
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Jobs retrieved",
+		"data":    response,
+	})
+}
+
+// GetJobStats godoc
+// @Summary      Count background jobs by status. Requires admin privilege
+// @Description  Count the background jobs in each status, e.g. to alert on DEAD jobs. Requires admin privilege
+// @Tags         job
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  types.JobStats
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/jobs/stats [get]
+func (h *JobHandler) GetJobStats(ctx *gin.Context) {
+	response, errMessage, statusCode, err := h.jobService.GetJobStats(ctx)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to count jobs",
+			"error":   errMessage,
+		})
+		log.Printf("Error while counting jobs: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Jobs counted",
+		"data":    response,
+	})
+}
+
+// GetOneJob godoc
+// @Summary      Fetch a background job. Requires admin privilege
+// @Description  Fetch a background job, e.g. to follow an async product import. Requires admin privilege
+// @Tags         job
+// @Accept       json
+// @Produce      json
+// @Param        jobId   path	string  true  "Unique job id"
+// @Success      200  {object}  types.Job
+// @Failure      404  {object}  types.JobError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/jobs/{jobId} [get]
+func (h *JobHandler) GetOneJob(ctx *gin.Context) {
+	var jobId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.jobService.GetOneJob(ctx, jobId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch job",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching job: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Job retrieved",
+		"data":    response,
+	})
+}
+
+// RetryJob godoc
+// @Summary      Retry a dead background job. Requires admin privilege
+// @Description  Queue a DEAD job again with a fresh set of attempts, e.g. once what made it fail is fixed. Requires admin privilege
+// @Tags         job
+// @Accept       json
+// @Produce      json
+// @Param        jobId   path	string  true  "Unique job id"
+// @Success      200  {object}  types.Job
+// @Failure      404  {object}  types.JobError
+// @Failure      409  {object}  types.JobError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/jobs/{jobId}/retry [post]
+func (h *JobHandler) RetryJob(ctx *gin.Context) {
+	var jobId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.jobService.RetryJob(ctx, jobId)
+	if err != nil || statusCode != http.StatusOK {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Job not retried",
+			"error":   errMessage,
+		})
+		if err != nil {
+			log.Printf("Error while retrying job: %v", err)
+		}
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Job queued",
+		"data":    response,
+	})
+}
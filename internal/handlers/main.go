@@ -3,6 +3,9 @@ package handlers
 import (
 	"github.com/gin-gonic/gin"
 	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/orderstream"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/storage"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/thumbnail"
 	"github.com/slamchillz/getinstashop-ecommerce-api/pkg/token"
 )
 
@@ -10,6 +13,22 @@ type AllHandler struct {
 	*UserHandler
 	*ProductHandler
 	*OrderHandler
+	*OrderStreamHandler
+	*CouponHandler
+	*PromotionHandler
+	*TaxHandler
+	*ShippingHandler
+	*ShipmentHandler
+	*ReturnHandler
+	*MediaHandler
+	*JobHandler
+	*WebhookHandler
+	*NotificationHandler
+	*InventoryHandler
+	*WarehouseHandler
+	*ReviewHandler
+	*WishlistHandler
+	*GuestHandler
 }
 
 type Handler interface {
@@ -17,10 +36,26 @@ type Handler interface {
 	LoginUser(ctx *gin.Context)
 }
 
-func RegisterHandlers(store db.Store, jwtToken *token.JWT) *AllHandler {
+func RegisterHandlers(store db.Store, jwtToken *token.JWT, blobs storage.BlobStore, thumbnails *thumbnail.Generator, orders *orderstream.Broker) *AllHandler {
 	return &AllHandler{
-		UserHandler:    NewUserHandler(store, jwtToken),
-		ProductHandler: NewProductHandler(store),
-		OrderHandler:   NewOrderHandler(store),
+		UserHandler:         NewUserHandler(store, jwtToken),
+		ProductHandler:      NewProductHandler(store, blobs, thumbnails),
+		OrderHandler:        NewOrderHandler(store),
+		OrderStreamHandler:  NewOrderStreamHandler(store, orders),
+		CouponHandler:       NewCouponHandler(store),
+		PromotionHandler:    NewPromotionHandler(store),
+		TaxHandler:          NewTaxHandler(store),
+		ShippingHandler:     NewShippingHandler(store),
+		ShipmentHandler:     NewShipmentHandler(store),
+		ReturnHandler:       NewReturnHandler(store),
+		MediaHandler:        NewMediaHandler(blobs),
+		JobHandler:          NewJobHandler(store),
+		WebhookHandler:      NewWebhookHandler(store),
+		NotificationHandler: NewNotificationHandler(store),
+		InventoryHandler:    NewInventoryHandler(store),
+		WarehouseHandler:    NewWarehouseHandler(store),
+		ReviewHandler:       NewReviewHandler(store),
+		WishlistHandler:     NewWishlistHandler(store),
+		GuestHandler:        NewGuestHandler(store, jwtToken),
 	}
 }
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/storage"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// MediaHandler serves uploaded files such as product images.
+type MediaHandler struct {
+	blobs storage.BlobStore
+}
+
+// NewMediaHandler creates a new MediaHandler instance.
+func NewMediaHandler(blobs storage.BlobStore) *MediaHandler {
+	return &MediaHandler{blobs: blobs}
+}
+
+// GetMedia godoc
+// @Summary      Download an uploaded file
+// @Description  Download an uploaded file such as a product image. Files never change once uploaded, so they can be cached for as long as needed
+// @Tags         media
+// @Produce      image/jpeg,image/png,image/gif,image/webp
+// @Param        key   path	string  true  "Key of the file, as found at the end of its url"
+// @Success      200  {file}    binary
+// @Success      304
+// @Failure      404  {object}  types.InterServerError
+// @Router       /media/{key} [get]
+func (h *MediaHandler) GetMedia(ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+	body, object, err := h.blobs.Get(ctx, key)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+			statusCode = http.StatusNotFound
+		} else {
+			log.Printf("Error while fetching media: %v", err)
+		}
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "File not found",
+		})
+		return
+	}
+	defer body.Close()
+	// Keys are unique per upload, so the content under a key never changes.
+	serveBlob(ctx, body, object, "public, max-age=31536000, immutable")
+}
+
+// serveBlob writes a blob with caching headers, answering a request that
+// already holds the current version with 304 Not Modified.
+func serveBlob(ctx *gin.Context, body io.Reader, object storage.Object, cacheControl string) {
+	ctx.Header("Cache-Control", cacheControl)
+	if object.ETag != "" {
+		ctx.Header("ETag", object.ETag)
+		if etagMatches(ctx.GetHeader("If-None-Match"), object.ETag) {
+			ctx.Status(http.StatusNotModified)
+			return
+		}
+	}
+	if !object.LastModified.IsZero() {
+		ctx.Header("Last-Modified", object.LastModified.UTC().Format(http.TimeFormat))
+	}
+	ctx.DataFromReader(http.StatusOK, object.Size, object.ContentType, body, nil)
+}
+
+// etagMatches reports whether an If-None-Match header lists etag. Weak
+// validators match too, as the comparison is only used for caching.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
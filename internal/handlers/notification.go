@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"log"
+	"net/http"
+)
+
+// NotificationHandler handles the notification preferences of users.
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+// NewNotificationHandler creates a new NotificationHandler instance.
+func NewNotificationHandler(store db.Store) *NotificationHandler {
+	return &NotificationHandler{notificationService: services.NewNotificationService(store, nil)}
+}
+
+// GetNotificationPreference godoc
+// @Summary      Fetch the notification preferences of the user
+// @Description  Fetch which order emails the user gets. Users who never changed them get every email
+// @Tags         notification
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  types.NotificationPreference
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /me/notification-preferences [get]
+func (h *NotificationHandler) GetNotificationPreference(ctx *gin.Context) {
+	response, statusCode, err := h.notificationService.GetNotificationPreference(ctx)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch notification preferences",
+		})
+		log.Printf("Error while fetching notification preferences: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Notification preferences retrieved",
+		"data":    response,
+	})
+}
+
+// UpdateNotificationPreference godoc
+// @Summary      Update the notification preferences of the user
+// @Description  Turn the order placed, cancelled, completed and shipped emails on or off. Only the fields present in the body are changed
+// @Tags         notification
+// @Accept       json
+// @Produce      json
+// @Param        payload   body	types.NotificationPreferenceInput  true  "Notification preferences request body"
+// @Success      200  {object}  types.NotificationPreference
+// @Failure      400  {object}  types.InterServerError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /me/notification-preferences [put]
+func (h *NotificationHandler) UpdateNotificationPreference(ctx *gin.Context) {
+	var req types.NotificationPreferenceInput
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, statusCode, err := h.notificationService.UpdateNotificationPreference(ctx, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Notification preferences not updated",
+		})
+		log.Printf("Error while updating notification preferences: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Notification preferences updated",
+		"data":    response,
+	})
+}
@@ -72,6 +72,57 @@ func (h *OrderHandler) CreateOrder(ctx *gin.Context) {
 	})
 }
 
+// PreviewOrder godoc
+// @Summary      Preview an order for one or more Product
+// @Description  Run the checks and pricing of placing an order without placing it: stock in the warehouses, line totals, promotion and coupon discounts, taxes and shipping cost. Nothing is written, no stock is taken and the coupon is not redeemed. The status is BACKORDERED when some units would wait for a restock. Problems with the order are returned keyed by product id or field name, the same as when placing it
+// @Tags         order
+// @Accept       json
+// @Produce      json
+// @Param        payload   body	types.CreateOrderInput  true  "Preview Order request body"
+// @Success      200  {object}  types.OrderPreview
+// @Failure      400  {object}  types.OrderError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /orders/preview [post]
+func (h *OrderHandler) PreviewOrder(ctx *gin.Context) {
+	var err error
+	var req types.CreateOrderInput
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+			"error": gin.H{
+				"productId": "must be a valid product id",
+				"quantity":  "must be an integer",
+			},
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.OrderService.PreviewOrder(ctx, req)
+	if errMessage.Items != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Order not priced",
+			"error":   errMessage.Items,
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Order not priced",
+			"error":   errMessage,
+		})
+		log.Printf("Error while previewing Order: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Order priced",
+		"data":    response,
+	})
+}
+
 // GetUserOrders godoc
 // @Summary      Fetch all orders placed by a user
 // @Description  Fetch all orders placed by a user
@@ -104,13 +155,14 @@ func (h *OrderHandler) GetUserOrders(ctx *gin.Context) {
 
 // CancelOrder godoc
 // @Summary      Cancels an order only if it is in PENDING state
-// @Description  Cancels an order only if it is in PENDING state
+// @Description  Cancels an order only if it is in PENDING or BACKORDERED state and has not shipped. Its units go back in stock and its coupon can be redeemed again
 // @Tags         order
 // @Accept       json
 // @Produce      json
 // @Param        orderId   path		string  	true  "Unique uuid of the order whose status is to be cancelled"
 // @Success      200  {object}  types.Order
 // @Failure      400  {object}  types.OrderCancelError
+// @Failure      409  {object}  types.OrderCancelError
 // @Failure      500  {object}  types.InterServerError
 // @Security	 BearerAuth
 // @Router       /orders/{orderId} [patch]
@@ -145,7 +197,7 @@ func (h *OrderHandler) CancelOrder(ctx *gin.Context) {
 
 // UpdateOrderStatus godoc
 // @Summary      Updates the status of any order. Requires admin privilege
-// @Description  Updates the status of any order. Requires admin privilege
+// @Description  Updates the status of any order. Requires admin privilege. PENDING orders can be moved to COMPLETED, and orders that have not shipped can be cancelled
 // @Tags         order
 // @Accept       json
 // @Produce      json
@@ -154,6 +206,7 @@ func (h *OrderHandler) CancelOrder(ctx *gin.Context) {
 // @Success      200  {object}  types.Order
 // @Failure      400  {object}  types.OrderCancelError
 // @Failure      404  {object}  types.OrderCancelError
+// @Failure      409  {object}  types.OrderCancelError
 // @Failure      500  {object}  types.InterServerError
 // @Security	 BearerAuth
 // @Router       /admin/orders/{orderId} [patch]
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/orderstream"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"log"
+	"net/http"
+	"time"
+)
+
+// orderStreamRecentIds is the number of ids of the changes sent on a stream
+// remembered to drop the changes sent twice.
+const orderStreamRecentIds = 1024
+
+// OrderStreamHandler handles the live stream of order status changes.
+type OrderStreamHandler struct {
+	orderStreamService *services.OrderStreamService
+	heartbeat          time.Duration
+}
+
+// NewOrderStreamHandler creates a new OrderStreamHandler instance.
+func NewOrderStreamHandler(store db.Store, broker *orderstream.Broker) *OrderStreamHandler {
+	return &OrderStreamHandler{
+		orderStreamService: services.NewOrderStreamService(store, broker),
+		heartbeat:          broker.Heartbeat(),
+	}
+}
+
+// StreamOrders godoc
+// @Summary      Stream the status changes of the user's orders
+// @Description  Server-sent events stream of the status changes of the orders of the user. Every change is an order.status_changed event whose id can be sent back in the Last-Event-ID header (or lastEventId query parameter) to resume after the changes already received. As changes can commit out of order, the changes just before the resumed one are sent again, and clients should ignore the ids they already have. A heartbeat comment is sent while the stream is idle
+// @Tags         order
+// @Produce      text/event-stream
+// @Param        Last-Event-ID   header	string  false  "Id of the last event received"
+// @Param        lastEventId     query	string  false  "Id of the last event received, for clients that cannot set headers"
+// @Success      200  {object}  types.OrderStatusEvent
+// @Failure      400  {object}  types.OrderStreamError
+// @Failure      503  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /orders/stream [get]
+func (h *OrderStreamHandler) StreamOrders(ctx *gin.Context) {
+	lastEventId := ctx.GetHeader("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = ctx.Query("lastEventId")
+	}
+	sub, missed, errMessage, statusCode, err := h.orderStreamService.Subscribe(ctx, lastEventId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to stream orders",
+			"error":   errMessage,
+		})
+		log.Printf("Error while streaming orders: %v", err)
+		return
+	}
+	defer sub.Close()
+
+	w := ctx.Writer
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Keep proxies such as nginx from buffering the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", (3 * time.Second).Milliseconds())
+
+	// The missed changes and the first changes of the subscription can overlap
+	sent := orderstream.NewRecentIds(orderStreamRecentIds)
+	send := func(event db.OrderStatusEvent) error {
+		if !sent.Add(event.ID) {
+			return nil
+		}
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, db.EventOrderStatusChanged, data)
+		return err
+	}
+	for _, event := range missed {
+		if err = send(event); err != nil {
+			return
+		}
+	}
+	w.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case event, ok := <-sub.Events():
+			// The subscription fell behind or the server is shutting down:
+			// the client reconnects and resumes from the last event it got
+			if !ok {
+				return
+			}
+			if err = send(event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err = fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		w.Flush()
+	}
+}
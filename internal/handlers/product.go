@@ -1,14 +1,20 @@
 package handlers
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
 	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/storage"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/thumbnail"
 	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
 	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 // ProductHandler handles user-related operations.
@@ -16,9 +22,9 @@ type ProductHandler struct {
 	productService *services.ProductService
 }
 
-// NewProductHandler creates a new UserHandler instance.
-func NewProductHandler(store db.Store) *ProductHandler {
-	return &ProductHandler{productService: services.NewProductService(store)}
+// NewProductHandler creates a new ProductHandler instance.
+func NewProductHandler(store db.Store, blobs storage.BlobStore, thumbnails *thumbnail.Generator) *ProductHandler {
+	return &ProductHandler{productService: services.NewProductService(store, blobs, thumbnails)}
 }
 
 // CreateProduct godoc
@@ -66,6 +72,7 @@ func (h *ProductHandler) CreateProduct(ctx *gin.Context) {
 // @Tags         product
 // @Accept       json
 // @Produce      json
+// @Param        sort   query	string  false  "rating to list the best rated products first"
 // @Success      200  {array}  types.Product
 // @Failure      400  {object}  types.ProductError
 // @Failure      500  {object}  types.InterServerError
@@ -73,7 +80,7 @@ func (h *ProductHandler) CreateProduct(ctx *gin.Context) {
 // @Router       /products [get]
 func (h *ProductHandler) GetAllProduct(ctx *gin.Context) {
 	var err error
-	response, errMessage, statusCode, err := h.productService.GetAllProduct(ctx)
+	response, errMessage, statusCode, err := h.productService.GetAllProduct(ctx, ctx.Query("sort"))
 	if err != nil {
 		ctx.JSON(statusCode, gin.H{
 			"status":  "failed",
@@ -115,6 +122,7 @@ func (h *ProductHandler) GetOneProduct(ctx *gin.Context) {
 		log.Printf("Error while fetching product: %v", err)
 		return
 	}
+	ctx.Header("ETag", productETag(response.Version))
 	ctx.JSON(statusCode, gin.H{
 		"status":  "success",
 		"message": "Product retrieved",
@@ -124,7 +132,7 @@ func (h *ProductHandler) GetOneProduct(ctx *gin.Context) {
 
 // DeleteOneProduct godoc
 // @Summary      Delete One Product. Requires admin privilege
-// @Description  Delete One Product. Requires admin privilege
+// @Description  Archive One Product. It is hidden from the catalog but kept for the orders it is in, and can be restored. Requires admin privilege
 // @Tags         product
 // @Accept       json
 // @Produce      json
@@ -154,6 +162,38 @@ func (h *ProductHandler) DeleteOneProduct(ctx *gin.Context) {
 	})
 }
 
+// RestoreProduct godoc
+// @Summary      Restore an archived Product. Requires admin privilege
+// @Description  Put a deleted product back in the catalog. Requires admin privilege
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param        productId   path	string  true  "Unique product id"
+// @Success      200  {object}  types.Product
+// @Failure      404  {object}  types.ProductError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/products/{productId}/restore [post]
+func (h *ProductHandler) RestoreProduct(ctx *gin.Context) {
+	var err error
+	var productId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.productService.RestoreProduct(ctx, productId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to restore product",
+			"error":   errMessage,
+		})
+		log.Printf("Error while restoring product: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Product restored",
+		"data":    response,
+	})
+}
+
 // UpdateOneProduct godoc
 // @Summary      Update a single Product. Requires admin privilege
 // @Description  Update a single Product. Requires admin privilege
@@ -161,9 +201,12 @@ func (h *ProductHandler) DeleteOneProduct(ctx *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        productId   path	string  true  "Unique product id"
+// @Param        If-Match    header	string  true  "ETag of the product as fetched, or * to overwrite any version"
 // @Param        payload   	 body	types.CreateProductInput  true  "Update Product request body"
 // @Success      200  {object}	types.Product
 // @Failure      400  {object}  types.ProductError
+// @Failure      412  {object}  types.ProductError
+// @Failure      428  {object}  types.ProductError
 // @Failure      500  {object}  types.InterServerError
 // @Security	 BearerAuth
 // @Router       /admin/products/{productId} [put]
@@ -171,6 +214,24 @@ func (h *ProductHandler) UpdateOneProduct(ctx *gin.Context) {
 	var err error
 	var req types.ProductUpdateInput
 	var productId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	ifMatch := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if ifMatch == "" {
+		ctx.JSON(http.StatusPreconditionRequired, gin.H{
+			"status":  "failed",
+			"message": "Product not updated",
+			"error":   types.ProductErrMessage{Version: "If-Match header with the ETag of the product is required"},
+		})
+		return
+	}
+	version, ok := productVersion(ifMatch)
+	if !ok {
+		ctx.JSON(http.StatusPreconditionFailed, gin.H{
+			"status":  "failed",
+			"message": "Product not updated",
+			"error":   types.ProductErrMessage{Version: "If-Match does not match the ETag of the product"},
+		})
+		return
+	}
 	if err = ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{
 			"status":  "failed",
@@ -178,7 +239,7 @@ func (h *ProductHandler) UpdateOneProduct(ctx *gin.Context) {
 		})
 		return
 	}
-	response, errMessage, statusCode, err := h.productService.UpdateOneProduct(ctx, productId, req)
+	response, errMessage, statusCode, err := h.productService.UpdateOneProduct(ctx, productId, req, version)
 	if err != nil {
 		ctx.JSON(statusCode, gin.H{
 			"status":  "failed",
@@ -188,9 +249,198 @@ func (h *ProductHandler) UpdateOneProduct(ctx *gin.Context) {
 		log.Printf("Error while updating product: %v", err)
 		return
 	}
+	ctx.Header("ETag", productETag(response.Version))
 	ctx.JSON(statusCode, gin.H{
 		"status":  "success",
 		"message": "Product updated",
 		"data":    response,
 	})
 }
+
+// productETag is the ETag of a product at version.
+func productETag(version int32) string {
+	return fmt.Sprintf("\"%d\"", version)
+}
+
+// productVersion reads the product version an If-Match header asks for, nil
+// for * which matches any version. It is false when the header does not name
+// a product version, as such a header can never match.
+func productVersion(ifMatch string) (*int32, bool) {
+	if ifMatch == "*" {
+		return nil, true
+	}
+	// A weak ETag never matches If-Match
+	if !strings.HasPrefix(ifMatch, "\"") || !strings.HasSuffix(ifMatch, "\"") || len(ifMatch) < 2 {
+		return nil, false
+	}
+	version, err := strconv.ParseInt(ifMatch[1:len(ifMatch)-1], 10, 32)
+	if err != nil {
+		return nil, false
+	}
+	v := int32(version)
+	return &v, true
+}
+
+// UploadProductImage godoc
+// @Summary      Upload an image of a product. Requires admin privilege
+// @Description  Upload a JPEG, PNG, GIF or WebP image of at most 5 MB as the "image" field of a multipart form. The image is added after the existing images of the product; set "primary" to true to make it the main image. The first image of a product is always its main image. Requires admin privilege
+// @Tags         product
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        productId   path	string  true  "Unique product id"
+// @Param        image       formData	file  true  "Image file"
+// @Param        primary     formData	bool  false  "Make the image the main image of the product"
+// @Success      201  {object}  types.ProductImage
+// @Failure      400  {object}  types.ProductImageError
+// @Failure      404  {object}  types.ProductImageError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/products/{productId}/images [post]
+func (h *ProductHandler) UploadProductImage(ctx *gin.Context) {
+	var productId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	// Leave some room for the rest of the form on top of the image itself.
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, validators.MaxImageSize+1<<20)
+	fileHeader, err := ctx.FormFile("image")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid multipart payload",
+			"error":   types.ProductImageErrMessage{Image: "image file of at most 5 MB is required"},
+		})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid multipart payload",
+			"error":   types.ProductImageErrMessage{Image: "unable to read image"},
+		})
+		return
+	}
+	defer file.Close()
+	primary := ctx.PostForm("primary") == "true"
+	response, errMessage, statusCode, err := h.productService.UploadProductImage(ctx, productId, file, fileHeader.Size, primary)
+	if err != nil || errMessage.Image != "" {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Image not uploaded",
+			"error":   errMessage,
+		})
+		log.Printf("Error while uploading product image: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Image uploaded",
+		"data":    response,
+	})
+}
+
+// ReorderProductImages godoc
+// @Summary      Reorder the images of a product. Requires admin privilege
+// @Description  Put the images of a product in the order of imageIds, which must list every image of the product, and/or change its main image. Requires admin privilege
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param        productId   path	string  true  "Unique product id"
+// @Param        payload     body	types.ProductImageOrderInput  true  "Image order request body"
+// @Success      200  {array}   types.ProductImage
+// @Failure      400  {object}  types.ProductImageError
+// @Failure      404  {object}  types.ProductImageError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/products/{productId}/images [patch]
+func (h *ProductHandler) ReorderProductImages(ctx *gin.Context) {
+	var err error
+	var req types.ProductImageOrderInput
+	var productId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.productService.ReorderProductImages(ctx, productId, req)
+	if err != nil || errMessage.ImageIds != "" || errMessage.PrimaryImageId != "" {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Images not reordered",
+			"error":   errMessage,
+		})
+		log.Printf("Error while reordering product images: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Images reordered",
+		"data":    response,
+	})
+}
+
+// DeleteProductImage godoc
+// @Summary      Delete an image of a product. Requires admin privilege
+// @Description  Delete an image of a product. When the main image is deleted the next image becomes the main image. Requires admin privilege
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param        productId   path	string  true  "Unique product id"
+// @Param        imageId     path	string  true  "Unique image id"
+// @Success      204
+// @Failure      404  {object}  types.ProductImageError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/products/{productId}/images/{imageId} [delete]
+func (h *ProductHandler) DeleteProductImage(ctx *gin.Context) {
+	var productId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	var imageId uuid.UUID = utils.ParseStringToUUID(ctx.Param("imageId"))
+	errMessage, statusCode, err := h.productService.DeleteProductImage(ctx, productId, imageId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to delete image",
+			"error":   errMessage,
+		})
+		log.Printf("Error while deleting product image: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Image deleted",
+		"data":    gin.H{},
+	})
+}
+
+// GetProductImageThumbnail godoc
+// @Summary      Download a thumbnail of a product image
+// @Description  Download a thumbnail of a product image, e.g. medium.webp. The sizes and urls of the thumbnails are listed with each image. Thumbnails are made on the first request for them, revalidate them with If-None-Match
+// @Tags         product
+// @Produce      image/jpeg,image/png,image/webp
+// @Param        imageId   path	string  true  "Unique image id"
+// @Param        variant   path	string  true  "Size and format of the thumbnail, e.g. small.jpg or small.webp"
+// @Success      200  {file}    binary
+// @Success      304
+// @Failure      404  {object}  types.ProductImageError
+// @Failure      500  {object}  types.InterServerError
+// @Router       /images/{imageId}/{variant} [get]
+func (h *ProductHandler) GetProductImageThumbnail(ctx *gin.Context) {
+	var imageId uuid.UUID = utils.ParseStringToUUID(ctx.Param("imageId"))
+	size, format, _ := strings.Cut(ctx.Param("variant"), ".")
+	body, object, errMessage, statusCode, err := h.productService.GetProductImageThumbnail(ctx, imageId, size, format)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Thumbnail not found",
+			"error":   errMessage,
+		})
+		if statusCode == http.StatusInternalServerError {
+			log.Printf("Error while fetching thumbnail: %v", err)
+		}
+		return
+	}
+	defer body.Close()
+	// A thumbnail changes only when its size is reconfigured, so clients
+	// revalidate it once a day.
+	serveBlob(ctx, body, object, "public, max-age=86400")
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/catalog"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// maxImportSize is the largest catalog file that can be imported.
+const maxImportSize = 10 << 20
+
+// ImportProducts godoc
+// @Summary      Import products from a CSV or JSON Lines file. Requires admin privilege
+// @Description  Create or update products in bulk. Send the file as the request body with a text/csv or application/x-ndjson content type, or as the "file" field of a multipart form. CSV files start with a header naming their columns among id, sku, name, description, price, stock, category, taxClass and weight; name, description and price are required. A product with the same sku, or else the same name, is updated, any other is created. Every row is validated and the import is saved in a single transaction, so an invalid row saves nothing. Set dryRun to check a file without saving it, or async to check it and leave saving it to a background job whose id is returned. The files written by the export can be imported as they are. Requires admin privilege
+// @Tags         product
+// @Accept       text/csv,application/x-ndjson,multipart/form-data
+// @Produce      json
+// @Param        format   query	string  false  "csv or jsonl, detected from the content type or file name when omitted"
+// @Param        dryRun   query	bool    false  "Validate the file and report what would change without saving anything"
+// @Param        async    query	bool    false  "Validate the file, then save it in the background and return the id of the job"
+// @Param        file     formData	file  false  "Catalog file, when sent as a multipart form"
+// @Success      200  {object}  types.ProductImportOutput
+// @Success      202  {object}  types.ProductImportOutput
+// @Failure      400  {object}  types.ProductImportError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/products/import [post]
+func (h *ProductHandler) ImportProducts(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportSize)
+	dryRun, _ := strconv.ParseBool(ctx.Query("dryRun"))
+	async, _ := strconv.ParseBool(ctx.Query("async"))
+	format := ctx.Query("format")
+	var file io.Reader = ctx.Request.Body
+	mediaType, _, _ := mime.ParseMediaType(ctx.GetHeader("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		fileHeader, err := ctx.FormFile("file")
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"status":  "failed",
+				"message": "Invalid multipart payload",
+				"error":   types.ProductImportErrMessage{File: fmt.Sprintf("file of at most %d MB is required", maxImportSize>>20)},
+			})
+			return
+		}
+		upload, err := fileHeader.Open()
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"status":  "failed",
+				"message": "Invalid multipart payload",
+				"error":   types.ProductImportErrMessage{File: "unable to read file"},
+			})
+			return
+		}
+		defer upload.Close()
+		file = upload
+		if format == "" {
+			format = importFormat("", filepath.Ext(fileHeader.Filename))
+		}
+	}
+	if format == "" {
+		format = importFormat(mediaType, "")
+	}
+	if !catalog.ValidFormat(format) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Unsupported file format",
+			"error":   types.ProductImportErrMessage{File: catalog.ErrUnknownFormat.Error()},
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.productService.ImportProducts(ctx, file, format, dryRun, async)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Products not imported",
+			"error":   errMessage,
+		})
+		log.Printf("Error while importing products: %v", err)
+		return
+	}
+	message := "Products imported"
+	if dryRun {
+		message = "Products checked, nothing was saved"
+	} else if response.JobId != nil {
+		message = "Products checked, the import is queued"
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": message,
+		"data":    response,
+	})
+}
+
+// importFormat guesses the format of an imported file from its content type
+// or file extension.
+func importFormat(mediaType, extension string) string {
+	switch {
+	case mediaType == "text/csv" || strings.EqualFold(extension, ".csv"):
+		return catalog.CSV
+	case mediaType == "application/x-ndjson" || mediaType == "application/jsonl" || mediaType == "application/x-jsonlines":
+		return catalog.JSONL
+	case strings.EqualFold(extension, ".jsonl") || strings.EqualFold(extension, ".ndjson"):
+		return catalog.JSONL
+	}
+	return ""
+}
+
+// ExportProducts godoc
+// @Summary      Export the catalog as a CSV or JSON Lines file. Requires admin privilege
+// @Description  Download every product, streamed as it is read so that large catalogs export quickly. The file has the columns accepted by the import. Requires admin privilege
+// @Tags         product
+// @Produce      text/csv,application/x-ndjson
+// @Param        format   query	string  false  "csv (default) or jsonl"
+// @Success      200  {file}    binary
+// @Failure      400  {object}  types.ProductImportError
+// @Security	 BearerAuth
+// @Router       /admin/products/export [get]
+func (h *ProductHandler) ExportProducts(ctx *gin.Context) {
+	format := ctx.DefaultQuery("format", catalog.CSV)
+	if !catalog.ValidFormat(format) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Unsupported file format",
+			"error":   types.ProductImportErrMessage{File: catalog.ErrUnknownFormat.Error()},
+		})
+		return
+	}
+	ctx.Header("Content-Type", catalog.ContentType(format))
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="products.%s"`, format))
+	ctx.Status(http.StatusOK)
+	// Once streaming has started the status can no longer change, so a
+	// failure only cuts the file short.
+	if err := h.productService.ExportProducts(ctx, ctx.Writer, format); err != nil {
+		log.Printf("Error while exporting products: %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// ScheduleProductPrice godoc
+// @Summary      Set or schedule a price of a product. Requires admin privilege
+// @Description  Price a product from effectiveFrom, now by default, until effectiveTo, for good by default. The latest started of the prices in effect is the price of the product, so a sale ending falls back to the price before it. compareAtPrice is shown struck through while the price is in effect. Scheduled prices are applied when they come into and go out of effect. Requires admin privilege
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param        productId   path	string  true  "Unique product id"
+// @Param        payload     body	types.ProductPriceInput  true  "Product Price request body"
+// @Success      201  {object}  types.ScheduledProductPrice
+// @Failure      400  {object}  types.ProductPriceError
+// @Failure      404  {object}  types.ProductPriceError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/products/{productId}/prices [post]
+func (h *ProductHandler) ScheduleProductPrice(ctx *gin.Context) {
+	var err error
+	var req types.ProductPriceInput
+	var productId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.productService.ScheduleProductPrice(ctx, productId, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Price not set",
+			"error":   errMessage,
+		})
+		log.Printf("Error while setting product price: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Price set",
+		"data":    response,
+	})
+}
+
+// GetProductPrices godoc
+// @Summary      Fetch the price history of a product. Requires admin privilege
+// @Description  Fetch the prices of a product, the latest to come into effect first, including scheduled and cancelled prices. Requires admin privilege
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param        productId   path	string  true   "Unique product id"
+// @Param        limit       query	int     false  "Number of prices to return, 50 by default and at most 500"
+// @Success      200  {array}   types.ProductPrice
+// @Failure      400  {object}  types.ProductPriceError
+// @Failure      404  {object}  types.ProductPriceError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/products/{productId}/prices [get]
+func (h *ProductHandler) GetProductPrices(ctx *gin.Context) {
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "50"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid query parameters",
+			"error":   types.ProductPriceErrMessage{Limit: "limit must be a number"},
+		})
+		return
+	}
+	productId := utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.productService.GetProductPrices(ctx, productId, limit)
+	if err != nil || statusCode != http.StatusOK {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch price history",
+			"error":   errMessage,
+		})
+		if err != nil {
+			log.Printf("Error while fetching price history: %v", err)
+		}
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Price history retrieved",
+		"data":    response,
+	})
+}
+
+// CancelProductPrice godoc
+// @Summary      Cancel a price of a product. Requires admin privilege
+// @Description  Cancel a scheduled price, or end one in effect early. The product goes back to the price in effect without it. Requires admin privilege
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param        productId   path	string  true  "Unique product id"
+// @Param        priceId     path	string  true  "Unique price id"
+// @Success      200  {object}  types.ScheduledProductPrice
+// @Failure      404  {object}  types.ProductPriceError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/products/{productId}/prices/{priceId} [delete]
+func (h *ProductHandler) CancelProductPrice(ctx *gin.Context) {
+	productId := utils.ParseStringToUUID(ctx.Param("id"))
+	priceId := utils.ParseStringToUUID(ctx.Param("priceId"))
+	response, errMessage, statusCode, err := h.productService.CancelProductPrice(ctx, productId, priceId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Price not cancelled",
+			"error":   errMessage,
+		})
+		log.Printf("Error while cancelling product price: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Price cancelled",
+		"data":    response,
+	})
+}
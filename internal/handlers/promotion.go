@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"log"
+	"net/http"
+)
+
+// PromotionHandler handles promotion related operations.
+type PromotionHandler struct {
+	promotionService *services.PromotionService
+}
+
+// NewPromotionHandler creates a new PromotionHandler instance.
+func NewPromotionHandler(store db.Store) *PromotionHandler {
+	return &PromotionHandler{promotionService: services.NewPromotionService(store)}
+}
+
+// CreatePromotion godoc
+// @Summary      Create a new promotion. Requires admin privilege
+// @Description  Create a promotion applied automatically at checkout. BUY_X_GET_Y takes percent, 100 by default, off getQuantity of every buyQuantity + getQuantity units, the cheapest first. BUNDLE sells one of each of productIds for bundlePrice. QUANTITY_TIER takes the percent of the highest tier reached off each line. CATEGORY_SALE takes percent off every product in categories. Promotions are applied from the highest priority down and one that is not stackable only discounts lines no other promotion has discounted. Requires admin privilege
+// @Tags         promotion
+// @Accept       json
+// @Produce      json
+// @Param        payload   body	types.CreatePromotionInput  true  "Create Promotion request body"
+// @Success      201  {object}  types.Promotion
+// @Failure      400  {object}  types.PromotionError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/promotions [post]
+func (h *PromotionHandler) CreatePromotion(ctx *gin.Context) {
+	var err error
+	var req types.CreatePromotionInput
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.promotionService.CreatePromotion(ctx, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Promotion not created",
+			"error":   errMessage,
+		})
+		log.Printf("Error while creating promotion: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Promotion created",
+		"data":    response,
+	})
+}
+
+// GetAllPromotion godoc
+// @Summary      List all promotions. Requires admin privilege
+// @Description  List all promotions, the highest priority first. Requires admin privilege
+// @Tags         promotion
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   types.Promotion
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/promotions [get]
+func (h *PromotionHandler) GetAllPromotion(ctx *gin.Context) {
+	var err error
+	response, errMessage, statusCode, err := h.promotionService.GetAllPromotion(ctx)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch promotions",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching promotions: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Promotions retrieved",
+		"data":    response,
+	})
+}
+
+// GetOnePromotion godoc
+// @Summary      Fetch One Promotion. Requires admin privilege
+// @Description  Fetch One Promotion. Requires admin privilege
+// @Tags         promotion
+// @Accept       json
+// @Produce      json
+// @Param        promotionId   path	string  true  "Unique promotion id"
+// @Success      200  {object}  types.Promotion
+// @Failure      404  {object}  types.PromotionError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/promotions/{promotionId} [get]
+func (h *PromotionHandler) GetOnePromotion(ctx *gin.Context) {
+	var err error
+	var promotionId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.promotionService.GetOnePromotion(ctx, promotionId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch promotion",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching promotion: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Promotion retrieved",
+		"data":    response,
+	})
+}
+
+// UpdateOnePromotion godoc
+// @Summary      Update a single Promotion. Requires admin privilege
+// @Description  Update a single Promotion. Only the fields present in the body are changed and the promotion is validated as a whole against its kind. Requires admin privilege
+// @Tags         promotion
+// @Accept       json
+// @Produce      json
+// @Param        promotionId   path	string  true  "Unique promotion id"
+// @Param        payload   	body	types.PromotionUpdateInput  true  "Update Promotion request body"
+// @Success      200  {object}	types.Promotion
+// @Failure      400  {object}  types.PromotionError
+// @Failure      404  {object}  types.PromotionError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/promotions/{promotionId} [put]
+func (h *PromotionHandler) UpdateOnePromotion(ctx *gin.Context) {
+	var err error
+	var req types.PromotionUpdateInput
+	var promotionId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.promotionService.UpdateOnePromotion(ctx, promotionId, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Promotion not updated",
+			"error":   errMessage,
+		})
+		log.Printf("Error while updating promotion: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Promotion updated",
+		"data":    response,
+	})
+}
+
+// DeleteOnePromotion godoc
+// @Summary      Delete One Promotion. Requires admin privilege
+// @Description  Delete One Promotion. Orders the promotion was applied to keep their discount. Requires admin privilege
+// @Tags         promotion
+// @Accept       json
+// @Produce      json
+// @Param        promotionId   path	string  true  "Unique promotion id"
+// @Success      204
+// @Failure      404  {object}  types.PromotionError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/promotions/{promotionId} [delete]
+func (h *PromotionHandler) DeleteOnePromotion(ctx *gin.Context) {
+	var err error
+	var promotionId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	_, errMessage, statusCode, err := h.promotionService.DeleteOnePromotion(ctx, promotionId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to delete promotion",
+			"error":   errMessage,
+		})
+		log.Printf("Error while deleting promotion: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Promotion deleted",
+		"data":    gin.H{},
+	})
+}
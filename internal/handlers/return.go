@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/rma"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"log"
+	"net/http"
+)
+
+// ReturnHandler handles return (RMA) related operations.
+type ReturnHandler struct {
+	returnService *services.ReturnService
+}
+
+// NewReturnHandler creates a new ReturnHandler instance. Refunds are settled
+// manually until a payment provider is configured.
+func NewReturnHandler(store db.Store) *ReturnHandler {
+	return &ReturnHandler{returnService: services.NewReturnService(store, rma.ManualRefunder{})}
+}
+
+// CreateReturn godoc
+// @Summary      Request a return for products of an order
+// @Description  Request a return for shipped products of an order placed by the user. Each product can be returned up to the quantity shipped and not yet returned, and every item needs a reason
+// @Tags         return
+// @Accept       json
+// @Produce      json
+// @Param        orderId   path	string  true  "Unique uuid of the order"
+// @Param        payload   body	types.CreateReturnInput  true  "Create Return request body"
+// @Success      201  {object}  types.Return
+// @Failure      400  {object}  types.ReturnError
+// @Failure      404  {object}  types.ReturnError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /orders/{orderId}/returns [post]
+func (h *ReturnHandler) CreateReturn(ctx *gin.Context) {
+	var err error
+	var req types.CreateReturnInput
+	var orderId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.returnService.CreateReturn(ctx, orderId, req)
+	if err != nil || errMessage.Items != nil || errMessage.Status != "" || errMessage.Note != "" {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Return not created",
+			"error":   errMessage,
+		})
+		log.Printf("Error while creating return: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Return created",
+		"data":    response,
+	})
+}
+
+// GetOrderReturns godoc
+// @Summary      Fetch the returns of an order
+// @Description  Fetch the returns of an order placed by the user together with their items and state history
+// @Tags         return
+// @Accept       json
+// @Produce      json
+// @Param        orderId   path	string  true  "Unique uuid of the order"
+// @Success      200  {array}   types.Return
+// @Failure      404  {object}  types.ReturnError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /orders/{orderId}/returns [get]
+func (h *ReturnHandler) GetOrderReturns(ctx *gin.Context) {
+	var err error
+	var orderId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.returnService.GetOrderReturns(ctx, orderId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch returns",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching returns: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Returns retrieved",
+		"data":    response,
+	})
+}
+
+// GetAllReturn godoc
+// @Summary      Fetch all returns. Requires admin privilege
+// @Description  Fetch all returns, newest first, together with their items and state history. Requires admin privilege
+// @Tags         return
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   types.Return
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/returns [get]
+func (h *ReturnHandler) GetAllReturn(ctx *gin.Context) {
+	response, errMessage, statusCode, err := h.returnService.GetAllReturn(ctx)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch returns",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching returns: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Returns retrieved",
+		"data":    response,
+	})
+}
+
+// ApproveReturn godoc
+// @Summary      Approve a requested return. Requires admin privilege
+// @Description  Approve a REQUESTED return so the customer can send the products back. Requires admin privilege
+// @Tags         return
+// @Accept       json
+// @Produce      json
+// @Param        returnId   path	string  true  "Unique return id"
+// @Param        payload    body	types.ReturnActionInput  false  "Optional note recorded in the return history"
+// @Success      200  {object}  types.Return
+// @Failure      404  {object}  types.ReturnError
+// @Failure      409  {object}  types.ReturnError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/returns/{returnId}/approve [post]
+func (h *ReturnHandler) ApproveReturn(ctx *gin.Context) {
+	h.updateReturnStatus(ctx, db.ReturnStatusAPPROVED)
+}
+
+// RejectReturn godoc
+// @Summary      Reject a return. Requires admin privilege
+// @Description  Reject a REQUESTED or APPROVED return. Rejected returns are final. Requires admin privilege
+// @Tags         return
+// @Accept       json
+// @Produce      json
+// @Param        returnId   path	string  true  "Unique return id"
+// @Param        payload    body	types.ReturnActionInput  false  "Optional note recorded in the return history"
+// @Success      200  {object}  types.Return
+// @Failure      404  {object}  types.ReturnError
+// @Failure      409  {object}  types.ReturnError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/returns/{returnId}/reject [post]
+func (h *ReturnHandler) RejectReturn(ctx *gin.Context) {
+	h.updateReturnStatus(ctx, db.ReturnStatusREJECTED)
+}
+
+// ReceiveReturn godoc
+// @Summary      Mark the products of an approved return as received. Requires admin privilege
+// @Description  Mark an APPROVED return as RECEIVED, putting the returned products back in stock and refunding the customer. When the refund fails the return stays RECEIVED and can be refunded later. Requires admin privilege
+// @Tags         return
+// @Accept       json
+// @Produce      json
+// @Param        returnId   path	string  true  "Unique return id"
+// @Param        payload    body	types.ReturnActionInput  false  "Optional note recorded in the return history"
+// @Success      200  {object}  types.Return
+// @Failure      404  {object}  types.ReturnError
+// @Failure      409  {object}  types.ReturnError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/returns/{returnId}/receive [post]
+func (h *ReturnHandler) ReceiveReturn(ctx *gin.Context) {
+	h.updateReturnStatus(ctx, db.ReturnStatusRECEIVED)
+}
+
+// RefundReturn godoc
+// @Summary      Refund a received return. Requires admin privilege
+// @Description  Retry the refund of a RECEIVED return. A return being refunded concurrently is not refunded again. Requires admin privilege
+// @Tags         return
+// @Accept       json
+// @Produce      json
+// @Param        returnId   path	string  true  "Unique return id"
+// @Param        payload    body	types.ReturnActionInput  false  "Optional note recorded in the return history"
+// @Success      200  {object}  types.Return
+// @Failure      404  {object}  types.ReturnError
+// @Failure      409  {object}  types.ReturnError
+// @Failure      502  {object}  types.ReturnError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/returns/{returnId}/refund [post]
+func (h *ReturnHandler) RefundReturn(ctx *gin.Context) {
+	var req types.ReturnActionInput
+	var returnId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"status":  "failed",
+				"message": "Invalid JSON payload",
+			})
+			return
+		}
+	}
+	response, errMessage, statusCode, err := h.returnService.RefundReturn(ctx, returnId, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Return not refunded",
+			"error":   errMessage,
+		})
+		log.Printf("Error while refunding return: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Return refunded",
+		"data":    response,
+	})
+}
+
+func (h *ReturnHandler) updateReturnStatus(ctx *gin.Context, status db.ReturnStatus) {
+	var req types.ReturnActionInput
+	var returnId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	// The note is optional, so an empty body is accepted.
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"status":  "failed",
+				"message": "Invalid JSON payload",
+			})
+			return
+		}
+	}
+	response, errMessage, statusCode, err := h.returnService.UpdateReturnStatus(ctx, returnId, status, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Return not updated",
+			"error":   errMessage,
+		})
+		log.Printf("Error while updating return: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Return updated",
+		"data":    response,
+	})
+}
@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// ReviewHandler handles product review related operations.
+type ReviewHandler struct {
+	reviewService *services.ReviewService
+}
+
+// NewReviewHandler creates a new ReviewHandler instance.
+func NewReviewHandler(store db.Store) *ReviewHandler {
+	return &ReviewHandler{reviewService: services.NewReviewService(store)}
+}
+
+// CreateReview godoc
+// @Summary      Review a product
+// @Description  Rate a product from 1 to 5 stars with a title and an optional body. Only customers with a completed order of the product can review it, once. The review is shown once an admin approves it
+// @Tags         review
+// @Accept       json
+// @Produce      json
+// @Param        productId   path	string  true  "Unique product id"
+// @Param        payload     body	types.CreateReviewInput  true  "Create Review request body"
+// @Success      201  {object}  types.Review
+// @Failure      400  {object}  types.ReviewError
+// @Failure      403  {object}  types.ReviewError
+// @Failure      404  {object}  types.ReviewError
+// @Failure      409  {object}  types.ReviewError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /products/{productId}/reviews [post]
+func (h *ReviewHandler) CreateReview(ctx *gin.Context) {
+	var err error
+	var req types.CreateReviewInput
+	var productId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.reviewService.CreateReview(ctx, productId, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Review not created",
+			"error":   errMessage,
+		})
+		log.Printf("Error while creating review: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Review created",
+		"data":    response,
+	})
+}
+
+// GetProductReviews godoc
+// @Summary      List the reviews of a product
+// @Description  List the approved reviews of a product, newest first
+// @Tags         review
+// @Accept       json
+// @Produce      json
+// @Param        productId   path	string  true   "Unique product id"
+// @Param        limit       query	int     false  "Number of reviews to return, 20 by default and at most 100"
+// @Success      200  {array}   types.Review
+// @Failure      400  {object}  types.ReviewError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /products/{productId}/reviews [get]
+func (h *ReviewHandler) GetProductReviews(ctx *gin.Context) {
+	var productId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid query parameters",
+			"error":   types.ReviewErrMessage{Limit: "limit must be a number"},
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.reviewService.GetProductReviews(ctx, productId, limit)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch reviews",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching reviews: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Reviews retrieved",
+		"data":    response,
+	})
+}
+
+// GetAllReview godoc
+// @Summary      List the reviews to moderate. Requires admin privilege
+// @Description  List the latest reviews of all products, newest first. Requires admin privilege
+// @Tags         review
+// @Accept       json
+// @Produce      json
+// @Param        status   query	string  false  "Only the reviews in this state: PENDING, APPROVED or REJECTED"
+// @Param        limit    query	int     false  "Number of reviews to return, 50 by default and at most 500"
+// @Success      200  {array}   types.Review
+// @Failure      400  {object}  types.ReviewError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/reviews [get]
+func (h *ReviewHandler) GetAllReview(ctx *gin.Context) {
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "50"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid query parameters",
+			"error":   types.ReviewErrMessage{Limit: "limit must be a number"},
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.reviewService.GetAllReview(ctx, ctx.Query("status"), limit)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch reviews",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching reviews: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Reviews retrieved",
+		"data":    response,
+	})
+}
+
+// ApproveReview godoc
+// @Summary      Approve a review. Requires admin privilege
+// @Description  Show a review on the product and count it in the rating of the product. Requires admin privilege
+// @Tags         review
+// @Accept       json
+// @Produce      json
+// @Param        reviewId   path	string  true  "Unique review id"
+// @Success      200  {object}  types.Review
+// @Failure      404  {object}  types.ReviewError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/reviews/{reviewId}/approve [post]
+func (h *ReviewHandler) ApproveReview(ctx *gin.Context) {
+	h.moderateReview(ctx, db.ReviewStatusAPPROVED)
+}
+
+// RejectReview godoc
+// @Summary      Reject a review. Requires admin privilege
+// @Description  Hide a review from the product and leave it out of the rating of the product. Requires admin privilege
+// @Tags         review
+// @Accept       json
+// @Produce      json
+// @Param        reviewId   path	string  true  "Unique review id"
+// @Success      200  {object}  types.Review
+// @Failure      404  {object}  types.ReviewError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/reviews/{reviewId}/reject [post]
+func (h *ReviewHandler) RejectReview(ctx *gin.Context) {
+	h.moderateReview(ctx, db.ReviewStatusREJECTED)
+}
+
+func (h *ReviewHandler) moderateReview(ctx *gin.Context, status db.ReviewStatus) {
+	var reviewId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.reviewService.ModerateReview(ctx, reviewId, status)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Review not updated",
+			"error":   errMessage,
+		})
+		log.Printf("Error while moderating review: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Review updated",
+		"data":    response,
+	})
+}
+
+// DeleteReview godoc
+// @Summary      Delete a review. Requires admin privilege
+// @Description  Delete a review. Requires admin privilege
+// @Tags         review
+// @Accept       json
+// @Produce      json
+// @Param        reviewId   path	string  true  "Unique review id"
+// @Success      204
+// @Failure      404  {object}  types.ReviewError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/reviews/{reviewId} [delete]
+func (h *ReviewHandler) DeleteReview(ctx *gin.Context) {
+	var reviewId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	_, errMessage, statusCode, err := h.reviewService.DeleteReview(ctx, reviewId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to delete review",
+			"error":   errMessage,
+		})
+		log.Printf("Error while deleting review: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Review deleted",
+		"data":    gin.H{},
+	})
+}
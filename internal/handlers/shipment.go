@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"log"
+	"net/http"
+)
+
+// ShipmentHandler handles order fulfilment related operations.
+type ShipmentHandler struct {
+	shipmentService *services.ShipmentService
+}
+
+// NewShipmentHandler creates a new ShipmentHandler instance.
+func NewShipmentHandler(store db.Store) *ShipmentHandler {
+	return &ShipmentHandler{shipmentService: services.NewShipmentService(store)}
+}
+
+// CreateShipment godoc
+// @Summary      Ship all or part of an order. Requires admin privilege
+// @Description  Ship the given quantity of each product of a COMPLETED or PARTIALLY_SHIPPED order, or everything left to ship when no items are given. The order moves to PARTIALLY_SHIPPED or SHIPPED automatically. Requires admin privilege
+// @Tags         shipment
+// @Accept       json
+// @Produce      json
+// @Param        orderId   path	string  true  "Unique uuid of the order being shipped"
+// @Param        payload   body	types.CreateShipmentInput  true  "Create Shipment request body"
+// @Success      201  {object}  types.Shipment
+// @Failure      400  {object}  types.ShipmentError
+// @Failure      404  {object}  types.ShipmentError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/orders/{orderId}/shipments [post]
+func (h *ShipmentHandler) CreateShipment(ctx *gin.Context) {
+	var err error
+	var req types.CreateShipmentInput
+	var orderId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.shipmentService.CreateShipment(ctx, orderId, req)
+	if err != nil || errMessage.Items != nil || errMessage.Status != "" {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Shipment not created",
+			"error":   errMessage,
+		})
+		log.Printf("Error while creating shipment: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Shipment created",
+		"data":    response,
+	})
+}
+
+// UpdateOneShipment godoc
+// @Summary      Update the tracking details or delivery of a shipment. Requires admin privilege
+// @Description  Update the carrier and tracking number of a shipment or mark it as delivered. A SHIPPED order becomes DELIVERED once all its shipments are delivered. Requires admin privilege
+// @Tags         shipment
+// @Accept       json
+// @Produce      json
+// @Param        shipmentId   path	string  true  "Unique shipment id"
+// @Param        payload   	  body	types.ShipmentUpdateInput  true  "Update Shipment request body"
+// @Success      200  {object}	types.Shipment
+// @Failure      400  {object}  types.ShipmentError
+// @Failure      404  {object}  types.ShipmentError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/shipments/{shipmentId} [patch]
+func (h *ShipmentHandler) UpdateOneShipment(ctx *gin.Context) {
+	var err error
+	var req types.ShipmentUpdateInput
+	var shipmentId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.shipmentService.UpdateOneShipment(ctx, shipmentId, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Shipment not updated",
+			"error":   errMessage,
+		})
+		log.Printf("Error while updating shipment: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Shipment updated",
+		"data":    response,
+	})
+}
+
+// GetOrderShipments godoc
+// @Summary      Fetch the shipments of an order
+// @Description  Fetch the shipments of an order placed by the user together with the items in each shipment
+// @Tags         shipment
+// @Accept       json
+// @Produce      json
+// @Param        orderId   path	string  true  "Unique uuid of the order"
+// @Success      200  {array}   types.Shipment
+// @Failure      404  {object}  types.ShipmentError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /orders/{orderId}/shipments [get]
+func (h *ShipmentHandler) GetOrderShipments(ctx *gin.Context) {
+	var err error
+	var orderId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.shipmentService.GetOrderShipments(ctx, orderId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch shipments",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching shipments: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Shipments retrieved",
+		"data":    response,
+	})
+}
@@ -0,0 +1,343 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"log"
+	"net/http"
+)
+
+// ShippingHandler handles shipping zone, method and quote related operations.
+type ShippingHandler struct {
+	shippingService *services.ShippingService
+}
+
+// NewShippingHandler creates a new ShippingHandler instance.
+func NewShippingHandler(store db.Store) *ShippingHandler {
+	return &ShippingHandler{shippingService: services.NewShippingService(store)}
+}
+
+// QuoteShipping godoc
+// @Summary      Price the shipping of a basket to an address
+// @Description  List the active shipping methods of the zone covering the address together with their cost for the basket. Free shipping thresholds are checked against the basket subtotal before any discount
+// @Tags         shipping
+// @Accept       json
+// @Produce      json
+// @Param        payload   body	types.ShippingQuoteInput  true  "Shipping Quote request body"
+// @Success      200  {object}  types.ShippingQuote
+// @Failure      400  {object}  types.ShippingQuoteError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /shipping/quote [post]
+func (h *ShippingHandler) QuoteShipping(ctx *gin.Context) {
+	var err error
+	var req types.ShippingQuoteInput
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.shippingService.QuoteShipping(ctx, req)
+	if err != nil || errMessage.Items != nil || errMessage.Address != "" {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to quote shipping",
+			"error":   errMessage,
+		})
+		log.Printf("Error while quoting shipping: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Shipping quote retrieved",
+		"data":    response,
+	})
+}
+
+// CreateShippingZone godoc
+// @Summary      Create a new shipping zone. Requires admin privilege
+// @Description  Create a zone covering one or more regions, e.g. GB or US-CA. A zone without regions covers every region not covered by another zone. Requires admin privilege
+// @Tags         shipping
+// @Accept       json
+// @Produce      json
+// @Param        payload   body	types.CreateShippingZoneInput  true  "Create Shipping Zone request body"
+// @Success      201  {object}  types.ShippingZone
+// @Failure      400  {object}  types.ShippingZoneError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/shipping-zones [post]
+func (h *ShippingHandler) CreateShippingZone(ctx *gin.Context) {
+	var err error
+	var req types.CreateShippingZoneInput
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.shippingService.CreateShippingZone(ctx, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Shipping zone not created",
+			"error":   errMessage,
+		})
+		log.Printf("Error while creating shipping zone: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Shipping zone created",
+		"data":    response,
+	})
+}
+
+// GetAllShippingZone godoc
+// @Summary      List all shipping zones. Requires admin privilege
+// @Description  List all shipping zones. Requires admin privilege
+// @Tags         shipping
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   types.ShippingZone
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/shipping-zones [get]
+func (h *ShippingHandler) GetAllShippingZone(ctx *gin.Context) {
+	var err error
+	response, errMessage, statusCode, err := h.shippingService.GetAllShippingZone(ctx)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch shipping zones",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching shipping zones: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Shipping zones retrieved",
+		"data":    response,
+	})
+}
+
+// UpdateOneShippingZone godoc
+// @Summary      Update a single shipping zone. Requires admin privilege
+// @Description  Update a single shipping zone. Requires admin privilege
+// @Tags         shipping
+// @Accept       json
+// @Produce      json
+// @Param        shippingZoneId   path	string  true  "Unique shipping zone id"
+// @Param        payload   	 body	types.ShippingZoneUpdateInput  true  "Update Shipping Zone request body"
+// @Success      200  {object}	types.ShippingZone
+// @Failure      400  {object}  types.ShippingZoneError
+// @Failure      404  {object}  types.ShippingZoneError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/shipping-zones/{shippingZoneId} [put]
+func (h *ShippingHandler) UpdateOneShippingZone(ctx *gin.Context) {
+	var err error
+	var req types.ShippingZoneUpdateInput
+	var zoneId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.shippingService.UpdateOneShippingZone(ctx, zoneId, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Shipping zone not updated",
+			"error":   errMessage,
+		})
+		log.Printf("Error while updating shipping zone: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Shipping zone updated",
+		"data":    response,
+	})
+}
+
+// DeleteOneShippingZone godoc
+// @Summary      Delete a single shipping zone. Requires admin privilege
+// @Description  Delete a single shipping zone together with its shipping methods. Requires admin privilege
+// @Tags         shipping
+// @Accept       json
+// @Produce      json
+// @Param        shippingZoneId   path	string  true  "Unique shipping zone id"
+// @Success      204
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/shipping-zones/{shippingZoneId} [delete]
+func (h *ShippingHandler) DeleteOneShippingZone(ctx *gin.Context) {
+	var err error
+	var zoneId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	_, errMessage, statusCode, err := h.shippingService.DeleteOneShippingZone(ctx, zoneId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to delete shipping zone",
+			"error":   errMessage,
+		})
+		log.Printf("Error while deleting shipping zone: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Shipping zone deleted",
+		"data":    gin.H{},
+	})
+}
+
+// CreateShippingMethod godoc
+// @Summary      Create a new shipping method. Requires admin privilege
+// @Description  Create a FLAT, WEIGHT or FREE_OVER shipping method for a zone. WEIGHT methods cost rate plus ratePerKg for every kilogram, FREE_OVER methods cost rate until the basket subtotal reaches freeOver. Requires admin privilege
+// @Tags         shipping
+// @Accept       json
+// @Produce      json
+// @Param        payload   body	types.CreateShippingMethodInput  true  "Create Shipping Method request body"
+// @Success      201  {object}  types.ShippingMethod
+// @Failure      400  {object}  types.ShippingMethodError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/shipping-methods [post]
+func (h *ShippingHandler) CreateShippingMethod(ctx *gin.Context) {
+	var err error
+	var req types.CreateShippingMethodInput
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.shippingService.CreateShippingMethod(ctx, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Shipping method not created",
+			"error":   errMessage,
+		})
+		log.Printf("Error while creating shipping method: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Shipping method created",
+		"data":    response,
+	})
+}
+
+// GetAllShippingMethod godoc
+// @Summary      List all shipping methods. Requires admin privilege
+// @Description  List all shipping methods. Requires admin privilege
+// @Tags         shipping
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   types.ShippingMethod
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/shipping-methods [get]
+func (h *ShippingHandler) GetAllShippingMethod(ctx *gin.Context) {
+	var err error
+	response, errMessage, statusCode, err := h.shippingService.GetAllShippingMethod(ctx)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch shipping methods",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching shipping methods: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Shipping methods retrieved",
+		"data":    response,
+	})
+}
+
+// UpdateOneShippingMethod godoc
+// @Summary      Update a single shipping method. Requires admin privilege
+// @Description  Update a single shipping method. Orders already placed keep the shipping cost they were charged. Requires admin privilege
+// @Tags         shipping
+// @Accept       json
+// @Produce      json
+// @Param        shippingMethodId   path	string  true  "Unique shipping method id"
+// @Param        payload   	 body	types.ShippingMethodUpdateInput  true  "Update Shipping Method request body"
+// @Success      200  {object}	types.ShippingMethod
+// @Failure      400  {object}  types.ShippingMethodError
+// @Failure      404  {object}  types.ShippingMethodError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/shipping-methods/{shippingMethodId} [put]
+func (h *ShippingHandler) UpdateOneShippingMethod(ctx *gin.Context) {
+	var err error
+	var req types.ShippingMethodUpdateInput
+	var methodId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.shippingService.UpdateOneShippingMethod(ctx, methodId, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Shipping method not updated",
+			"error":   errMessage,
+		})
+		log.Printf("Error while updating shipping method: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Shipping method updated",
+		"data":    response,
+	})
+}
+
+// DeleteOneShippingMethod godoc
+// @Summary      Delete a single shipping method. Requires admin privilege
+// @Description  Delete a single shipping method. Requires admin privilege
+// @Tags         shipping
+// @Accept       json
+// @Produce      json
+// @Param        shippingMethodId   path	string  true  "Unique shipping method id"
+// @Success      204
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/shipping-methods/{shippingMethodId} [delete]
+func (h *ShippingHandler) DeleteOneShippingMethod(ctx *gin.Context) {
+	var err error
+	var methodId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	_, errMessage, statusCode, err := h.shippingService.DeleteOneShippingMethod(ctx, methodId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to delete shipping method",
+			"error":   errMessage,
+		})
+		log.Printf("Error while deleting shipping method: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Shipping method deleted",
+		"data":    gin.H{},
+	})
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"log"
+	"net/http"
+)
+
+// TaxHandler handles tax rule related operations.
+type TaxHandler struct {
+	taxService *services.TaxService
+}
+
+// NewTaxHandler creates a new TaxHandler instance.
+func NewTaxHandler(store db.Store) *TaxHandler {
+	return &TaxHandler{taxService: services.NewTaxService(store)}
+}
+
+// CreateTaxRule godoc
+// @Summary      Create a new tax rule. Requires admin privilege
+// @Description  Create a tax rate for a product tax class in a shipping region. An empty region applies to every region. Requires admin privilege
+// @Tags         tax
+// @Accept       json
+// @Produce      json
+// @Param        payload   body	types.CreateTaxRuleInput  true  "Create Tax Rule request body"
+// @Success      201  {object}  types.TaxRule
+// @Failure      400  {object}  types.TaxRuleError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/tax-rules [post]
+func (h *TaxHandler) CreateTaxRule(ctx *gin.Context) {
+	var err error
+	var req types.CreateTaxRuleInput
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.taxService.CreateTaxRule(ctx, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Tax rule not created",
+			"error":   errMessage,
+		})
+		log.Printf("Error while creating tax rule: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Tax rule created",
+		"data":    response,
+	})
+}
+
+// GetAllTaxRule godoc
+// @Summary      List all tax rules. Requires admin privilege
+// @Description  List all tax rules. Requires admin privilege
+// @Tags         tax
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   types.TaxRule
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/tax-rules [get]
+func (h *TaxHandler) GetAllTaxRule(ctx *gin.Context) {
+	var err error
+	response, errMessage, statusCode, err := h.taxService.GetAllTaxRule(ctx)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch tax rules",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching tax rules: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Tax rules retrieved",
+		"data":    response,
+	})
+}
+
+// UpdateOneTaxRule godoc
+// @Summary      Update a single tax rule. Requires admin privilege
+// @Description  Update a single tax rule. Orders already placed keep the rate they were charged. Requires admin privilege
+// @Tags         tax
+// @Accept       json
+// @Produce      json
+// @Param        taxRuleId   path	string  true  "Unique tax rule id"
+// @Param        payload   	 body	types.TaxRuleUpdateInput  true  "Update Tax Rule request body"
+// @Success      200  {object}	types.TaxRule
+// @Failure      400  {object}  types.TaxRuleError
+// @Failure      404  {object}  types.TaxRuleError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/tax-rules/{taxRuleId} [put]
+func (h *TaxHandler) UpdateOneTaxRule(ctx *gin.Context) {
+	var err error
+	var req types.TaxRuleUpdateInput
+	var ruleId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.taxService.UpdateOneTaxRule(ctx, ruleId, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Tax rule not updated",
+			"error":   errMessage,
+		})
+		log.Printf("Error while updating tax rule: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Tax rule updated",
+		"data":    response,
+	})
+}
+
+// DeleteOneTaxRule godoc
+// @Summary      Delete a single tax rule. Requires admin privilege
+// @Description  Delete a single tax rule. Requires admin privilege
+// @Tags         tax
+// @Accept       json
+// @Produce      json
+// @Param        taxRuleId   path	string  true  "Unique tax rule id"
+// @Success      204
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/tax-rules/{taxRuleId} [delete]
+func (h *TaxHandler) DeleteOneTaxRule(ctx *gin.Context) {
+	var err error
+	var ruleId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	_, errMessage, statusCode, err := h.taxService.DeleteOneTaxRule(ctx, ruleId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to delete tax rule",
+			"error":   errMessage,
+		})
+		log.Printf("Error while deleting tax rule: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Tax rule deleted",
+		"data":    gin.H{},
+	})
+}
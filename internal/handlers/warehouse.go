@@ -0,0 +1,404 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// WarehouseHandler handles warehouse and warehouse stock related operations.
+type WarehouseHandler struct {
+	warehouseService *services.WarehouseService
+}
+
+// NewWarehouseHandler creates a new WarehouseHandler instance.
+func NewWarehouseHandler(store db.Store) *WarehouseHandler {
+	return &WarehouseHandler{warehouseService: services.NewWarehouseService(store)}
+}
+
+// CreateWarehouse godoc
+// @Summary      Create a new warehouse. Requires admin privilege
+// @Description  Create a warehouse. Orders are shipped from the active warehouses with the lowest priority first. Requires admin privilege
+// @Tags         warehouse
+// @Accept       json
+// @Produce      json
+// @Param        payload   body	types.CreateWarehouseInput  true  "Create Warehouse request body"
+// @Success      201  {object}  types.Warehouse
+// @Failure      400  {object}  types.WarehouseError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/warehouses [post]
+func (h *WarehouseHandler) CreateWarehouse(ctx *gin.Context) {
+	var err error
+	var req types.CreateWarehouseInput
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.warehouseService.CreateWarehouse(ctx, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Warehouse not created",
+			"error":   errMessage,
+		})
+		log.Printf("Error while creating warehouse: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Warehouse created",
+		"data":    response,
+	})
+}
+
+// GetAllWarehouse godoc
+// @Summary      List all warehouses. Requires admin privilege
+// @Description  List all warehouses in the order orders are shipped from them. Requires admin privilege
+// @Tags         warehouse
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   types.Warehouse
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/warehouses [get]
+func (h *WarehouseHandler) GetAllWarehouse(ctx *gin.Context) {
+	var err error
+	response, errMessage, statusCode, err := h.warehouseService.GetAllWarehouse(ctx)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch warehouses",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching warehouses: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Warehouses retrieved",
+		"data":    response,
+	})
+}
+
+// GetOneWarehouse godoc
+// @Summary      Fetch a single warehouse. Requires admin privilege
+// @Description  Fetch a single warehouse. Requires admin privilege
+// @Tags         warehouse
+// @Accept       json
+// @Produce      json
+// @Param        warehouseId   path	string  true  "Unique warehouse id"
+// @Success      200  {object}  types.Warehouse
+// @Failure      404  {object}  types.WarehouseError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/warehouses/{warehouseId} [get]
+func (h *WarehouseHandler) GetOneWarehouse(ctx *gin.Context) {
+	var err error
+	var warehouseId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.warehouseService.GetOneWarehouse(ctx, warehouseId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch warehouse",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching warehouse: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Warehouse retrieved",
+		"data":    response,
+	})
+}
+
+// UpdateOneWarehouse godoc
+// @Summary      Update a single warehouse. Requires admin privilege
+// @Description  Update a single warehouse. Inactive warehouses keep their stock but no order is shipped from them. Requires admin privilege
+// @Tags         warehouse
+// @Accept       json
+// @Produce      json
+// @Param        warehouseId   path	string  true  "Unique warehouse id"
+// @Param        payload   	   body	types.WarehouseUpdateInput  true  "Update Warehouse request body"
+// @Success      200  {object}	types.Warehouse
+// @Failure      400  {object}  types.WarehouseError
+// @Failure      404  {object}  types.WarehouseError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/warehouses/{warehouseId} [put]
+func (h *WarehouseHandler) UpdateOneWarehouse(ctx *gin.Context) {
+	var err error
+	var req types.WarehouseUpdateInput
+	var warehouseId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.warehouseService.UpdateOneWarehouse(ctx, warehouseId, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Warehouse not updated",
+			"error":   errMessage,
+		})
+		log.Printf("Error while updating warehouse: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Warehouse updated",
+		"data":    response,
+	})
+}
+
+// GetWarehouseStock godoc
+// @Summary      Fetch the stock of a warehouse. Requires admin privilege
+// @Description  Fetch how many units of each product a warehouse holds. Requires admin privilege
+// @Tags         warehouse
+// @Accept       json
+// @Produce      json
+// @Param        warehouseId   path	string  true  "Unique warehouse id"
+// @Success      200  {array}   types.WarehouseStock
+// @Failure      404  {object}  types.WarehouseError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/warehouses/{warehouseId}/stock [get]
+func (h *WarehouseHandler) GetWarehouseStock(ctx *gin.Context) {
+	var err error
+	var warehouseId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.warehouseService.GetWarehouseStock(ctx, warehouseId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch warehouse stock",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching warehouse stock: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Warehouse stock retrieved",
+		"data":    response,
+	})
+}
+
+// SetWarehouseStock godoc
+// @Summary      Set the stock of a product in a warehouse. Requires admin privilege
+// @Description  Set how many units of a product a warehouse holds. The stock of the product goes up or down by the difference, which is recorded in its stock ledger as an adjustment. Requires admin privilege
+// @Tags         warehouse
+// @Accept       json
+// @Produce      json
+// @Param        warehouseId   path	string  true  "Unique warehouse id"
+// @Param        productId     path	string  true  "Unique product id"
+// @Param        payload   	   body	types.WarehouseStockInput  true  "Warehouse Stock request body"
+// @Success      200  {object}	types.Product
+// @Failure      400  {object}  types.WarehouseError
+// @Failure      404  {object}  types.WarehouseError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/warehouses/{warehouseId}/stock/{productId} [put]
+func (h *WarehouseHandler) SetWarehouseStock(ctx *gin.Context) {
+	var err error
+	var req types.WarehouseStockInput
+	var warehouseId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	var productId uuid.UUID = utils.ParseStringToUUID(ctx.Param("productId"))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.warehouseService.SetWarehouseStock(ctx, warehouseId, productId, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Warehouse stock not updated",
+			"error":   errMessage,
+		})
+		log.Printf("Error while updating warehouse stock: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Warehouse stock updated",
+		"data":    response,
+	})
+}
+
+// GetProductWarehouseStock godoc
+// @Summary      Fetch the stock of a product in each warehouse. Requires admin privilege
+// @Description  Fetch how many units of a product each warehouse holds, in the order orders are shipped from them. Requires admin privilege
+// @Tags         warehouse
+// @Accept       json
+// @Produce      json
+// @Param        productId   path	string  true  "Unique product id"
+// @Success      200  {array}   types.ProductWarehouseStock
+// @Failure      404  {object}  types.WarehouseError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/products/{productId}/warehouse-stock [get]
+func (h *WarehouseHandler) GetProductWarehouseStock(ctx *gin.Context) {
+	var err error
+	var productId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.warehouseService.GetProductWarehouseStock(ctx, productId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch product warehouse stock",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching product warehouse stock: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Product warehouse stock retrieved",
+		"data":    response,
+	})
+}
+
+// TransferStock godoc
+// @Summary      Transfer stock between warehouses. Requires admin privilege
+// @Description  Move units of a product from a warehouse to another. The stock of the product does not change, both sides of the transfer are recorded in its stock ledger. Requires admin privilege
+// @Tags         warehouse
+// @Accept       json
+// @Produce      json
+// @Param        payload   body	types.StockTransferInput  true  "Stock Transfer request body"
+// @Success      201  {object}  types.StockTransfer
+// @Failure      400  {object}  types.WarehouseError
+// @Failure      404  {object}  types.WarehouseError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/stock-transfers [post]
+func (h *WarehouseHandler) TransferStock(ctx *gin.Context) {
+	var err error
+	var req types.StockTransferInput
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.warehouseService.TransferStock(ctx, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Stock not transferred",
+			"error":   errMessage,
+		})
+		log.Printf("Error while transferring stock: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Stock transferred",
+		"data":    response,
+	})
+}
+
+// GetStockTransfers godoc
+// @Summary      List the stock transfers. Requires admin privilege
+// @Description  List the latest stock transfers between warehouses, newest first. Requires admin privilege
+// @Tags         warehouse
+// @Accept       json
+// @Produce      json
+// @Param        productId     query	string  false  "Only the transfers of this product"
+// @Param        warehouseId   query	string  false  "Only the transfers from or to this warehouse"
+// @Param        limit         query	int     false  "Number of transfers to return, 50 by default and at most 500"
+// @Success      200  {array}   types.StockTransfer
+// @Failure      400  {object}  types.WarehouseError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/stock-transfers [get]
+func (h *WarehouseHandler) GetStockTransfers(ctx *gin.Context) {
+	var productId, warehouseId uuid.UUID
+	var errMessage types.WarehouseErrMessage
+	var err error
+	if id := ctx.Query("productId"); id != "" {
+		if productId, err = uuid.Parse(id); err != nil {
+			errMessage.ProductId = "productId must be a valid id"
+		}
+	}
+	if id := ctx.Query("warehouseId"); id != "" {
+		if warehouseId, err = uuid.Parse(id); err != nil {
+			errMessage.WarehouseId = "warehouseId must be a valid id"
+		}
+	}
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "50"))
+	if err != nil {
+		errMessage.Limit = "limit must be a number"
+	}
+	if errMessage != (types.WarehouseErrMessage{}) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid query parameters",
+			"error":   errMessage,
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.warehouseService.GetStockTransfers(ctx, productId, warehouseId, limit)
+	if err != nil || statusCode != http.StatusOK {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch stock transfers",
+			"error":   errMessage,
+		})
+		if err != nil {
+			log.Printf("Error while fetching stock transfers: %v", err)
+		}
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Stock transfers retrieved",
+		"data":    response,
+	})
+}
+
+// GetOrderAllocations godoc
+// @Summary      Fetch the warehouses an order is shipped from. Requires admin privilege
+// @Description  Fetch how many units of each product of an order are shipped from each warehouse. Requires admin privilege
+// @Tags         warehouse
+// @Accept       json
+// @Produce      json
+// @Param        orderId   path	string  true  "Unique order id"
+// @Success      200  {array}   types.OrderAllocation
+// @Failure      404  {object}  types.WarehouseError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/orders/{orderId}/allocations [get]
+func (h *WarehouseHandler) GetOrderAllocations(ctx *gin.Context) {
+	var err error
+	var orderId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.warehouseService.GetOrderAllocations(ctx, orderId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch order allocations",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching order allocations: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Order allocations retrieved",
+		"data":    response,
+	})
+}
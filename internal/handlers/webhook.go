@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// WebhookHandler handles webhook related operations.
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+// NewWebhookHandler creates a new WebhookHandler instance.
+func NewWebhookHandler(store db.Store) *WebhookHandler {
+	return &WebhookHandler{webhookService: services.NewWebhookService(store)}
+}
+
+// CreateWebhook godoc
+// @Summary      Create a new webhook. Requires admin privilege
+// @Description  Subscribe a URL to order.created, order.status_changed, product.created, product.updated, product.deleted or product.stock_low events. Every delivery is signed with the secret in the X-Webhook-Signature header, sha256= followed by the hex HMAC-SHA256 of the X-Webhook-Timestamp header, a dot and the body. A secret is generated when none is given. Requires admin privilege
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        payload   body	types.CreateWebhookInput  true  "Create Webhook request body"
+// @Success      201  {object}  types.Webhook
+// @Failure      400  {object}  types.WebhookError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(ctx *gin.Context) {
+	var err error
+	var req types.CreateWebhookInput
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.webhookService.CreateWebhook(ctx, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Webhook not created",
+			"error":   errMessage,
+		})
+		log.Printf("Error while creating webhook: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Webhook created",
+		"data":    response,
+	})
+}
+
+// GetAllWebhook godoc
+// @Summary      List all webhooks. Requires admin privilege
+// @Description  List all webhooks. Requires admin privilege
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   types.Webhook
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/webhooks [get]
+func (h *WebhookHandler) GetAllWebhook(ctx *gin.Context) {
+	var err error
+	response, errMessage, statusCode, err := h.webhookService.GetAllWebhook(ctx)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch webhooks",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching webhooks: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Webhooks retrieved",
+		"data":    response,
+	})
+}
+
+// GetOneWebhook godoc
+// @Summary      Fetch One Webhook. Requires admin privilege
+// @Description  Fetch One Webhook. Requires admin privilege
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        webhookId   path	string  true  "Unique webhook id"
+// @Success      200  {object}  types.Webhook
+// @Failure      404  {object}  types.WebhookError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/webhooks/{webhookId} [get]
+func (h *WebhookHandler) GetOneWebhook(ctx *gin.Context) {
+	var err error
+	var webhookId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.webhookService.GetOneWebhook(ctx, webhookId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch webhook",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching webhook: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Webhook retrieved",
+		"data":    response,
+	})
+}
+
+// UpdateOneWebhook godoc
+// @Summary      Update a single Webhook. Requires admin privilege
+// @Description  Update a single Webhook. Only the fields present in the body are changed. Deliveries of an inactive webhook fail without being sent. Requires admin privilege
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        webhookId   path	string  true  "Unique webhook id"
+// @Param        payload   	body	types.WebhookUpdateInput  true  "Update Webhook request body"
+// @Success      200  {object}	types.Webhook
+// @Failure      400  {object}  types.WebhookError
+// @Failure      404  {object}  types.WebhookError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/webhooks/{webhookId} [put]
+func (h *WebhookHandler) UpdateOneWebhook(ctx *gin.Context) {
+	var err error
+	var req types.WebhookUpdateInput
+	var webhookId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.webhookService.UpdateOneWebhook(ctx, webhookId, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Webhook not updated",
+			"error":   errMessage,
+		})
+		log.Printf("Error while updating webhook: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Webhook updated",
+		"data":    response,
+	})
+}
+
+// DeleteOneWebhook godoc
+// @Summary      Delete One Webhook. Requires admin privilege
+// @Description  Delete One Webhook along with its delivery log. Requires admin privilege
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        webhookId   path	string  true  "Unique webhook id"
+// @Success      204
+// @Failure      404  {object}  types.WebhookError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/webhooks/{webhookId} [delete]
+func (h *WebhookHandler) DeleteOneWebhook(ctx *gin.Context) {
+	var err error
+	var webhookId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	_, errMessage, statusCode, err := h.webhookService.DeleteOneWebhook(ctx, webhookId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to delete webhook",
+			"error":   errMessage,
+		})
+		log.Printf("Error while deleting webhook: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Webhook deleted",
+		"data":    gin.H{},
+	})
+}
+
+// GetWebhookDeliveries godoc
+// @Summary      Fetch the delivery log of a webhook. Requires admin privilege
+// @Description  Fetch the latest deliveries to a webhook, newest first, with the attempts made and the last response. Requires admin privilege
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        webhookId   path	string  true  "Unique webhook id"
+// @Param        limit    query	int     false  "Number of deliveries to return, 50 by default and at most 500"
+// @Success      200  {array}   types.WebhookDelivery
+// @Failure      400  {object}  types.WebhookError
+// @Failure      404  {object}  types.WebhookError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/webhooks/{webhookId}/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveries(ctx *gin.Context) {
+	var webhookId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "50"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid query parameters",
+			"error":   types.WebhookErrMessage{Limit: "limit must be a number"},
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.webhookService.GetWebhookDeliveries(ctx, webhookId, limit)
+	if err != nil || statusCode != http.StatusOK {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch webhook deliveries",
+			"error":   errMessage,
+		})
+		if err != nil {
+			log.Printf("Error while fetching webhook deliveries: %v", err)
+		}
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Webhook deliveries retrieved",
+		"data":    response,
+	})
+}
+
+// RedeliverWebhook godoc
+// @Summary      Send a webhook delivery again. Requires admin privilege
+// @Description  Send the event of a delivery to its webhook again, e.g. once the receiver is fixed. The event is sent as a new delivery with the same event id. Requires admin privilege
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        deliveryId   path	string  true  "Unique webhook delivery id"
+// @Success      201  {object}  types.WebhookDelivery
+// @Failure      404  {object}  types.WebhookError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /admin/webhook-deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookHandler) RedeliverWebhook(ctx *gin.Context) {
+	var err error
+	var deliveryId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.webhookService.RedeliverWebhook(ctx, deliveryId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Webhook not redelivered",
+			"error":   errMessage,
+		})
+		log.Printf("Error while redelivering webhook: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Webhook delivery queued",
+		"data":    response,
+	})
+}
@@ -0,0 +1,406 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"log"
+	"net/http"
+)
+
+// WishlistHandler handles wishlist related operations.
+type WishlistHandler struct {
+	wishlistService *services.WishlistService
+}
+
+// NewWishlistHandler creates a new WishlistHandler instance.
+func NewWishlistHandler(store db.Store) *WishlistHandler {
+	return &WishlistHandler{wishlistService: services.NewWishlistService(store, nil)}
+}
+
+// CreateWishlist godoc
+// @Summary      Create a wishlist
+// @Description  Create a named wishlist to save products to. A customer can have many wishlists, each with a different name
+// @Tags         wishlist
+// @Accept       json
+// @Produce      json
+// @Param        payload   body	types.WishlistInput  true  "Create Wishlist request body"
+// @Success      201  {object}  types.Wishlist
+// @Failure      400  {object}  types.WishlistError
+// @Failure      409  {object}  types.WishlistError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /me/wishlists [post]
+func (h *WishlistHandler) CreateWishlist(ctx *gin.Context) {
+	var err error
+	var req types.WishlistInput
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.wishlistService.CreateWishlist(ctx, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Wishlist not created",
+			"error":   errMessage,
+		})
+		log.Printf("Error while creating wishlist: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Wishlist created",
+		"data":    response,
+	})
+}
+
+// GetUserWishlists godoc
+// @Summary      List my wishlists
+// @Description  List the wishlists of the signed in customer along with their products, oldest first
+// @Tags         wishlist
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   types.Wishlist
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /me/wishlists [get]
+func (h *WishlistHandler) GetUserWishlists(ctx *gin.Context) {
+	response, errMessage, statusCode, err := h.wishlistService.GetUserWishlists(ctx)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch wishlists",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching wishlists: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Wishlists retrieved",
+		"data":    response,
+	})
+}
+
+// GetUserWishlist godoc
+// @Summary      Get one of my wishlists
+// @Description  Get a wishlist of the signed in customer along with its products
+// @Tags         wishlist
+// @Accept       json
+// @Produce      json
+// @Param        wishlistId   path	string  true  "Unique wishlist id"
+// @Success      200  {object}  types.Wishlist
+// @Failure      404  {object}  types.WishlistError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /me/wishlists/{wishlistId} [get]
+func (h *WishlistHandler) GetUserWishlist(ctx *gin.Context) {
+	var wishlistId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.wishlistService.GetUserWishlist(ctx, wishlistId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch wishlist",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching wishlist: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Wishlist retrieved",
+		"data":    response,
+	})
+}
+
+// UpdateWishlist godoc
+// @Summary      Rename a wishlist
+// @Description  Rename a wishlist of the signed in customer
+// @Tags         wishlist
+// @Accept       json
+// @Produce      json
+// @Param        wishlistId   path	string  true  "Unique wishlist id"
+// @Param        payload      body	types.WishlistInput  true  "Update Wishlist request body"
+// @Success      200  {object}  types.Wishlist
+// @Failure      400  {object}  types.WishlistError
+// @Failure      404  {object}  types.WishlistError
+// @Failure      409  {object}  types.WishlistError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /me/wishlists/{wishlistId} [put]
+func (h *WishlistHandler) UpdateWishlist(ctx *gin.Context) {
+	var err error
+	var req types.WishlistInput
+	var wishlistId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.wishlistService.UpdateWishlist(ctx, wishlistId, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Wishlist not updated",
+			"error":   errMessage,
+		})
+		log.Printf("Error while updating wishlist: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Wishlist updated",
+		"data":    response,
+	})
+}
+
+// DeleteWishlist godoc
+// @Summary      Delete a wishlist
+// @Description  Delete a wishlist of the signed in customer along with its products
+// @Tags         wishlist
+// @Accept       json
+// @Produce      json
+// @Param        wishlistId   path	string  true  "Unique wishlist id"
+// @Success      200  {object}  types.Wishlist
+// @Failure      404  {object}  types.WishlistError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /me/wishlists/{wishlistId} [delete]
+func (h *WishlistHandler) DeleteWishlist(ctx *gin.Context) {
+	var wishlistId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.wishlistService.DeleteWishlist(ctx, wishlistId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Wishlist not deleted",
+			"error":   errMessage,
+		})
+		log.Printf("Error while deleting wishlist: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Wishlist deleted",
+		"data":    response,
+	})
+}
+
+// AddWishlistItem godoc
+// @Summary      Save a product to a wishlist
+// @Description  Save a product to a wishlist, 1 unit by default. Saving a product already on the wishlist sets its quantity
+// @Tags         wishlist
+// @Accept       json
+// @Produce      json
+// @Param        wishlistId   path	string  true  "Unique wishlist id"
+// @Param        payload      body	types.WishlistItemInput  true  "Wishlist Item request body"
+// @Success      201  {object}  types.WishlistItem
+// @Failure      400  {object}  types.WishlistError
+// @Failure      404  {object}  types.WishlistError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /me/wishlists/{wishlistId}/items [post]
+func (h *WishlistHandler) AddWishlistItem(ctx *gin.Context) {
+	var err error
+	var req types.WishlistItemInput
+	var wishlistId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "failed",
+			"message": "Invalid JSON payload",
+		})
+		return
+	}
+	response, errMessage, statusCode, err := h.wishlistService.AddWishlistItem(ctx, wishlistId, req)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Product not saved to wishlist",
+			"error":   errMessage,
+		})
+		log.Printf("Error while saving product to wishlist: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Product saved to wishlist",
+		"data":    response,
+	})
+}
+
+// RemoveWishlistItem godoc
+// @Summary      Remove a product from a wishlist
+// @Description  Remove a product from a wishlist of the signed in customer
+// @Tags         wishlist
+// @Accept       json
+// @Produce      json
+// @Param        wishlistId   path	string  true  "Unique wishlist id"
+// @Param        productId    path	string  true  "Unique product id"
+// @Success      200  {object}  types.WishlistItem
+// @Failure      404  {object}  types.WishlistError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /me/wishlists/{wishlistId}/items/{productId} [delete]
+func (h *WishlistHandler) RemoveWishlistItem(ctx *gin.Context) {
+	var wishlistId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	var productId uuid.UUID = utils.ParseStringToUUID(ctx.Param("productId"))
+	response, errMessage, statusCode, err := h.wishlistService.RemoveWishlistItem(ctx, wishlistId, productId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Product not removed from wishlist",
+			"error":   errMessage,
+		})
+		log.Printf("Error while removing product from wishlist: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Product removed from wishlist",
+		"data":    response,
+	})
+}
+
+// ShareWishlist godoc
+// @Summary      Share a wishlist
+// @Description  Give a wishlist a share token, which lets anyone see the wishlist at /wishlists/shared/{token} without signing in. Sharing a wishlist again replaces its token, so the old link stops working
+// @Tags         wishlist
+// @Accept       json
+// @Produce      json
+// @Param        wishlistId   path	string  true  "Unique wishlist id"
+// @Success      200  {object}  types.Wishlist
+// @Failure      404  {object}  types.WishlistError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /me/wishlists/{wishlistId}/share [post]
+func (h *WishlistHandler) ShareWishlist(ctx *gin.Context) {
+	var wishlistId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.wishlistService.ShareWishlist(ctx, wishlistId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Wishlist not shared",
+			"error":   errMessage,
+		})
+		log.Printf("Error while sharing wishlist: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Wishlist shared",
+		"data":    response,
+	})
+}
+
+// UnshareWishlist godoc
+// @Summary      Stop sharing a wishlist
+// @Description  Remove the share token of a wishlist, so its link stops working
+// @Tags         wishlist
+// @Accept       json
+// @Produce      json
+// @Param        wishlistId   path	string  true  "Unique wishlist id"
+// @Success      200  {object}  types.Wishlist
+// @Failure      404  {object}  types.WishlistError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /me/wishlists/{wishlistId}/share [delete]
+func (h *WishlistHandler) UnshareWishlist(ctx *gin.Context) {
+	var wishlistId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	response, errMessage, statusCode, err := h.wishlistService.UnshareWishlist(ctx, wishlistId)
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Wishlist still shared",
+			"error":   errMessage,
+		})
+		log.Printf("Error while unsharing wishlist: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Wishlist no longer shared",
+		"data":    response,
+	})
+}
+
+// GetSharedWishlist godoc
+// @Summary      Get a shared wishlist
+// @Description  Get the name and products of a wishlist shared with a link. No sign in is needed
+// @Tags         wishlist
+// @Accept       json
+// @Produce      json
+// @Param        token   path	string  true  "Share token of the wishlist"
+// @Success      200  {object}  types.SharedWishlist
+// @Failure      404  {object}  types.WishlistError
+// @Failure      500  {object}  types.InterServerError
+// @Router       /wishlists/shared/{token} [get]
+func (h *WishlistHandler) GetSharedWishlist(ctx *gin.Context) {
+	response, errMessage, statusCode, err := h.wishlistService.GetSharedWishlist(ctx, ctx.Param("token"))
+	if err != nil {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Unable to fetch wishlist",
+			"error":   errMessage,
+		})
+		log.Printf("Error while fetching shared wishlist: %v", err)
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Wishlist retrieved",
+		"data":    response,
+	})
+}
+
+// OrderWishlist godoc
+// @Summary      Order the products of a wishlist
+// @Description  Place an order for the products of a wishlist, or only those in productIds, in the quantities saved on it. The products ordered are removed from the wishlist
+// @Tags         wishlist
+// @Accept       json
+// @Produce      json
+// @Param        wishlistId   path	string  true  "Unique wishlist id"
+// @Param        payload      body	types.OrderWishlistInput  false  "Order Wishlist request body"
+// @Success      201  {object}  types.Order
+// @Failure      400  {object}  types.WishlistError
+// @Failure      404  {object}  types.WishlistError
+// @Failure      500  {object}  types.InterServerError
+// @Security	 BearerAuth
+// @Router       /me/wishlists/{wishlistId}/order [post]
+func (h *WishlistHandler) OrderWishlist(ctx *gin.Context) {
+	var req types.OrderWishlistInput
+	var wishlistId uuid.UUID = utils.ParseStringToUUID(ctx.Param("id"))
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"status":  "failed",
+				"message": "Invalid JSON payload",
+			})
+			return
+		}
+	}
+	response, errMessage, statusCode, err := h.wishlistService.OrderWishlist(ctx, wishlistId, req)
+	if err != nil || statusCode != http.StatusCreated {
+		ctx.JSON(statusCode, gin.H{
+			"status":  "failed",
+			"message": "Order not created",
+			"error":   errMessage,
+		})
+		if err != nil {
+			log.Printf("Error while ordering wishlist: %v", err)
+		}
+		return
+	}
+	ctx.JSON(statusCode, gin.H{
+		"status":  "success",
+		"message": "Order created",
+		"data":    response,
+	})
+}
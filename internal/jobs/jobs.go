@@ -0,0 +1,296 @@
+// Package jobs runs background work through a job queue kept in Postgres.
+//
+// Jobs are rows of the job table. Workers claim due jobs with
+// SELECT ... FOR UPDATE SKIP LOCKED, so any number of workers, in any number
+// of processes, can share the queue without running a job twice. A failing
+// job is retried with exponential backoff until it runs out of attempts, and
+// is then left DEAD for an admin to look at and retry.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"log"
+	"math/rand"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is the number of times a job is tried before it is dead.
+	DefaultMaxAttempts = 5
+	baseBackoff        = 10 * time.Second
+	maxBackoff         = time.Hour
+)
+
+var ErrNoHandler = errors.New("no handler is registered for the job kind")
+
+// Handler runs a job of a given kind. Returning an error retries the job,
+// unless the error is wrapped with Permanent.
+type Handler func(ctx context.Context, payload json.RawMessage) error
+
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks an error retrying cannot fix, such as an invalid payload.
+// The job is dead as soon as it returns it.
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+// EnqueueParams describes a job to enqueue. Zero values pick the defaults.
+type EnqueueParams struct {
+	Kind        string
+	Payload     any
+	MaxAttempts int32
+	RunAt       time.Time
+	// IdempotencyKey, when set, enqueues the job only if no job was enqueued
+	// with the same key before, e.g. by a handler running again for an event
+	IdempotencyKey string
+}
+
+// Enqueue adds a job to the queue. Pass the Queries of a transaction to
+// enqueue the job only if the transaction commits. The zero Job is returned
+// when a job with the same IdempotencyKey is already enqueued.
+func Enqueue(ctx context.Context, q db.Querier, arg EnqueueParams) (db.Job, error) {
+	payload, err := json.Marshal(arg.Payload)
+	if err != nil {
+		return db.Job{}, err
+	}
+	if arg.MaxAttempts <= 0 {
+		arg.MaxAttempts = DefaultMaxAttempts
+	}
+	if arg.RunAt.IsZero() {
+		arg.RunAt = time.Now()
+	}
+	job, err := q.CreateJob(ctx, db.CreateJobParams{
+		ID:             uuid.New(),
+		Kind:           arg.Kind,
+		Payload:        payload,
+		MaxAttempts:    arg.MaxAttempts,
+		RunAt:          pgtype.Timestamp{Time: arg.RunAt.UTC(), Valid: true},
+		IdempotencyKey: arg.IdempotencyKey,
+	})
+	if errors.Is(err, pgx.ErrNoRows) && arg.IdempotencyKey != "" {
+		return db.Job{}, nil
+	}
+	return job, err
+}
+
+// Backoff is how long a job waits before its next attempt after failing
+// attempt times: 10s, 20s, 40s and so on up to an hour, with some jitter so
+// that jobs failing together do not retry together.
+func Backoff(attempt int32) time.Duration {
+	backoff := maxBackoff
+	if attempt < 10 {
+		backoff = min(baseBackoff<<max(attempt-1, 0), maxBackoff)
+	}
+	return backoff + time.Duration(rand.Int63n(int64(backoff/10)+1))
+}
+
+// Config configures a Pool.
+type Config struct {
+	// Workers is the number of jobs run at the same time
+	Workers int
+	// PollInterval is how long an idle worker waits before looking for jobs again
+	PollInterval time.Duration
+	// StaleAfter is how long a job can stay RUNNING before it is assumed its
+	// worker died and it is run again. It must be longer than any job takes.
+	StaleAfter time.Duration
+}
+
+// Pool runs queued jobs with a fixed number of workers.
+type Pool struct {
+	store    db.Store
+	config   Config
+	name     string
+	handlers map[string]Handler
+
+	mu      sync.Mutex
+	started bool
+	stop    chan struct{}
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewPool creates a new Pool instance. Register the handlers before starting it.
+func NewPool(store db.Store, config Config) *Pool {
+	if config.Workers <= 0 {
+		config.Workers = 4
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+	if config.StaleAfter <= 0 {
+		config.StaleAfter = 15 * time.Minute
+	}
+	hostname, _ := os.Hostname()
+	return &Pool{
+		store:    store,
+		config:   config,
+		name:     fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		handlers: make(map[string]Handler),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Register sets the handler running jobs of kind.
+func (p *Pool) Register(kind string, handler Handler) {
+	p.handlers[kind] = handler
+}
+
+// Start starts the workers. Jobs keep running until Shutdown is called.
+func (p *Pool) Start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.started {
+		return
+	}
+	p.started = true
+	// Running jobs get their own context so that a shutdown lets them finish.
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.wg.Add(p.config.Workers + 1)
+	go p.requeueStale(ctx)
+	for i := 0; i < p.config.Workers; i++ {
+		go p.work(ctx, fmt.Sprintf("%s-%d", p.name, i))
+	}
+}
+
+// Shutdown stops claiming jobs and waits for the running ones to finish. When
+// ctx is done first the running jobs are cancelled; they run again once
+// they are found stale.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.started {
+		p.mu.Unlock()
+		return nil
+	}
+	p.started = false
+	close(p.stop)
+	p.mu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (p *Pool) work(ctx context.Context, worker string) {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.stop:
+			return
+		default:
+		}
+		jobs, err := p.store.ClaimJobs(ctx, db.ClaimJobsParams{
+			Now:       pgtype.Timestamp{Time: time.Now().UTC(), Valid: true},
+			LockedBy:  worker,
+			BatchSize: 1,
+		})
+		if err != nil {
+			log.Printf("Error while claiming jobs: %v", err)
+		}
+		if len(jobs) == 0 {
+			select {
+			case <-p.stop:
+				return
+			case <-time.After(p.config.PollInterval):
+			}
+			continue
+		}
+		p.run(ctx, jobs[0])
+	}
+}
+
+// run runs a claimed job and records its outcome.
+func (p *Pool) run(ctx context.Context, job db.Job) {
+	err := p.handle(ctx, job)
+	// The outcome is recorded even when the pool is being cancelled.
+	ctx = context.WithoutCancel(ctx)
+	switch {
+	case err == nil:
+		_, err = p.store.CompleteJob(ctx, job.ID)
+	case errors.As(err, &permanentError{}) || errors.Is(err, ErrNoHandler) || job.Attempts >= job.MaxAttempts:
+		log.Printf("Job %s (%s) is dead after %d attempts: %v", job.ID, job.Kind, job.Attempts, err)
+		_, err = p.store.KillJob(ctx, db.KillJobParams{ID: job.ID, LastError: err.Error()})
+	default:
+		log.Printf("Job %s (%s) failed, retrying: %v", job.ID, job.Kind, err)
+		_, err = p.store.RetryJob(ctx, db.RetryJobParams{
+			ID:        job.ID,
+			LastError: err.Error(),
+			RunAt:     pgtype.Timestamp{Time: time.Now().UTC().Add(Backoff(job.Attempts)), Valid: true},
+		})
+	}
+	if err != nil {
+		log.Printf("Error while saving the outcome of job %s: %v", job.ID, err)
+	}
+}
+
+type jobKey struct{}
+
+// LastAttempt reports whether the job run by a handler is on its last
+// attempt, e.g. to record that it failed for good.
+func LastAttempt(ctx context.Context) bool {
+	job, ok := ctx.Value(jobKey{}).(db.Job)
+	return ok && job.Attempts >= job.MaxAttempts
+}
+
+func (p *Pool) handle(ctx context.Context, job db.Job) (err error) {
+	handler, ok := p.handlers[job.Kind]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoHandler, job.Kind)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Job %s (%s) panicked: %v\n%s", job.ID, job.Kind, r, debug.Stack())
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler(context.WithValue(ctx, jobKey{}, job), job.Payload)
+}
+
+// requeueStale puts back in the queue the jobs of workers that died while
+// running them.
+func (p *Pool) requeueStale(ctx context.Context) {
+	defer p.wg.Done()
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		lockedBefore := time.Now().UTC().Add(-p.config.StaleAfter)
+		count, err := p.store.RequeueStaleJobs(ctx, pgtype.Timestamp{Time: lockedBefore, Valid: true})
+		if err != nil {
+			log.Printf("Error while requeueing stale jobs: %v", err)
+		} else if count > 0 {
+			log.Printf("Requeued %d stale jobs", count)
+		}
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
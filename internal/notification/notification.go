@@ -0,0 +1,151 @@
+// Package notification renders the emails sent to customers about their
+// orders and about products on their wishlists coming back in stock, to
+// guests with the tokens to look up their order and to claim their account,
+// and to the staff about products low on stock, from the templates in the
+// templates directory.
+//
+// Each kind of email has an HTML and a text template defining its subject
+// and content, laid out by layout.html and layout.txt. Emails that are not
+// about an order also define their footer.
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/mailer"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates
+var files embed.FS
+
+// templateNames maps the kinds of emails to their templates.
+var templateNames = map[db.NotificationKind]string{
+	db.NotificationKindORDERPLACED:    "order_placed",
+	db.NotificationKindORDERCANCELLED: "order_cancelled",
+	db.NotificationKindORDERCOMPLETED: "order_completed",
+	db.NotificationKindORDERSHIPPED:   "order_shipped",
+}
+
+// OrderData is the data of the order emails.
+type OrderData struct {
+	Order db.Order
+	Items []db.GetOrderItemDetailsRow
+}
+
+// LowStockData is the data of the low-stock alert emails.
+type LowStockData struct {
+	Product db.GetOneProductRow
+	Alert   db.LowStockAlert
+}
+
+// BackInStockData is the data of the back-in-stock emails.
+type BackInStockData struct {
+	Product db.GetOneProductRow
+}
+
+// OrderLookupData is the data of the emails sending guests the token to look
+// up their order with.
+type OrderLookupData struct {
+	Order   db.Order
+	Address db.GuestOrder
+	Token   string
+}
+
+// AccountClaimData is the data of the emails sending guests the token to
+// claim their account with.
+type AccountClaimData struct {
+	Email string
+	Token string
+}
+
+type templates struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+var parsed = mustParse()
+
+var lowStock = mustParseTemplates("low_stock")
+
+var backInStock = mustParseTemplates("back_in_stock")
+
+var orderLookup = mustParseTemplates("order_lookup")
+
+var accountClaim = mustParseTemplates("account_claim")
+
+func money(amount float64) string {
+	return fmt.Sprintf("%.2f", amount)
+}
+
+func mustParse() map[db.NotificationKind]templates {
+	result := make(map[db.NotificationKind]templates, len(templateNames))
+	for kind, name := range templateNames {
+		result[kind] = mustParseTemplates(name)
+	}
+	return result
+}
+
+func mustParseTemplates(name string) templates {
+	return templates{
+		html: htmltemplate.Must(htmltemplate.New(name).Funcs(htmltemplate.FuncMap{"money": money}).
+			ParseFS(files, "templates/layout.html", "templates/"+name+".html")),
+		text: texttemplate.Must(texttemplate.New(name).Funcs(texttemplate.FuncMap{"money": money}).
+			ParseFS(files, "templates/layout.txt", "templates/"+name+".txt")),
+	}
+}
+
+// Render renders the email of a kind to be sent to to.
+func Render(kind db.NotificationKind, to string, data OrderData) (mailer.Message, error) {
+	tmpl, ok := parsed[kind]
+	if !ok {
+		return mailer.Message{}, fmt.Errorf("no template for %s notifications", kind)
+	}
+	return render(tmpl, to, data)
+}
+
+// RenderLowStock renders the low-stock alert email to be sent to to.
+func RenderLowStock(to string, data LowStockData) (mailer.Message, error) {
+	return render(lowStock, to, data)
+}
+
+// RenderBackInStock renders the email telling to that a product on their
+// wishlist is back in stock.
+func RenderBackInStock(to string, data BackInStockData) (mailer.Message, error) {
+	return render(backInStock, to, data)
+}
+
+// RenderOrderLookup renders the email sending a guest the token to look up the
+// order they placed as a guest.
+func RenderOrderLookup(to string, data OrderLookupData) (mailer.Message, error) {
+	return render(orderLookup, to, data)
+}
+
+// RenderAccountClaim renders the email sending a guest the token to claim
+// their account with.
+func RenderAccountClaim(to string, data AccountClaimData) (mailer.Message, error) {
+	return render(accountClaim, to, data)
+}
+
+func render(tmpl templates, to string, data any) (mailer.Message, error) {
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return mailer.Message{}, err
+	}
+	if err := tmpl.text.ExecuteTemplate(&text, "layout", data); err != nil {
+		return mailer.Message{}, err
+	}
+	if err := tmpl.html.ExecuteTemplate(&html, "layout", data); err != nil {
+		return mailer.Message{}, err
+	}
+	return mailer.Message{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
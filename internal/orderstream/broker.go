@@ -0,0 +1,285 @@
+// Package orderstream fans the status changes of orders out to the clients
+// streaming them.
+//
+// A trigger on the order table records every status change and sends it on
+// the order_status channel once the transaction commits. The Broker listens
+// to the channel on a single connection and hands every change to the
+// subscriptions of the owner of the order.
+package orderstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"log"
+	"sync"
+	"time"
+)
+
+// Channel is the notification channel the trigger sends the changes on.
+const Channel = "order_status"
+
+// catchUpPageSize is the number of changes read at a time when catching up.
+const catchUpPageSize = 100
+
+// CatchUpOverlap is how many ids before the last change seen are read again
+// when catching up, for the changes that committed after changes with higher
+// ids.
+const CatchUpOverlap = 100
+
+// recentIdsSize is the number of change ids remembered to drop repeats. It
+// covers the overlap read again when catching up.
+const recentIdsSize = 1024
+
+// ErrClosed is returned when subscribing to a closed Broker.
+var ErrClosed = errors.New("order stream is closed")
+
+// Config configures a Broker.
+type Config struct {
+	// Buffer is the number of changes a subscription holds before it is
+	// dropped for falling behind. The client resumes from its last change.
+	Buffer int
+	// Heartbeat is how often streams send a comment to keep idle
+	// connections open
+	Heartbeat time.Duration
+	// RetryInterval is how long the broker waits before listening again
+	// after losing its connection
+	RetryInterval time.Duration
+}
+
+// Subscription receives the status changes of the orders of a user.
+type Subscription struct {
+	userId uuid.UUID
+	events chan db.OrderStatusEvent
+	broker *Broker
+	once   sync.Once
+}
+
+// Events returns the changes. It is closed when the subscription is closed,
+// falls behind or the broker shuts down.
+func (s *Subscription) Events() <-chan db.OrderStatusEvent {
+	return s.events
+}
+
+// Close stops the subscription.
+func (s *Subscription) Close() {
+	s.broker.remove(s)
+}
+
+// Broker listens to the order status changes and hands them to subscriptions.
+type Broker struct {
+	store  db.Store
+	config Config
+
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[*Subscription]struct{}
+	started     bool
+	closed      bool
+	ready       chan struct{}
+	cancel      context.CancelFunc
+	done        chan struct{}
+
+	// Only used by the listening goroutine
+	listened bool
+	lastId   int64
+	seen     *RecentIds
+}
+
+// NewBroker creates a new Broker instance. It starts listening with the
+// first subscription.
+func NewBroker(store db.Store, config Config) *Broker {
+	if config.Buffer <= 0 {
+		config.Buffer = 32
+	}
+	if config.Heartbeat <= 0 {
+		config.Heartbeat = 15 * time.Second
+	}
+	if config.RetryInterval <= 0 {
+		config.RetryInterval = time.Second
+	}
+	return &Broker{
+		store:       store,
+		config:      config,
+		subscribers: make(map[uuid.UUID]map[*Subscription]struct{}),
+		ready:       make(chan struct{}),
+		done:        make(chan struct{}),
+		seen:        NewRecentIds(recentIdsSize),
+	}
+}
+
+// Heartbeat returns how often streams send a heartbeat.
+func (b *Broker) Heartbeat() time.Duration {
+	return b.config.Heartbeat
+}
+
+// Subscribe subscribes to the status changes of the orders of a user. It
+// returns once the broker listens, so that no later change is missed.
+func (b *Broker) Subscribe(ctx context.Context, userId uuid.UUID) (*Subscription, error) {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil, ErrClosed
+	}
+	if !b.started {
+		b.started = true
+		listenCtx, cancel := context.WithCancel(context.Background())
+		b.cancel = cancel
+		go b.run(listenCtx)
+	}
+	sub := &Subscription{userId: userId, events: make(chan db.OrderStatusEvent, b.config.Buffer), broker: b}
+	if b.subscribers[userId] == nil {
+		b.subscribers[userId] = make(map[*Subscription]struct{})
+	}
+	b.subscribers[userId][sub] = struct{}{}
+	b.mu.Unlock()
+
+	select {
+	case <-b.ready:
+		return sub, nil
+	case <-b.done:
+		sub.Close()
+		return nil, ErrClosed
+	case <-ctx.Done():
+		sub.Close()
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops listening and closes every subscription.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	for _, subs := range b.subscribers {
+		for sub := range subs {
+			sub.once.Do(func() { close(sub.events) })
+		}
+	}
+	b.subscribers = nil
+	started := b.started
+	if started {
+		b.cancel()
+	}
+	b.mu.Unlock()
+	if started {
+		<-b.done
+	}
+}
+
+func (b *Broker) remove(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.removeLocked(sub)
+}
+
+func (b *Broker) removeLocked(sub *Subscription) {
+	if subs := b.subscribers[sub.userId]; subs != nil {
+		delete(subs, sub)
+		if len(subs) == 0 {
+			delete(b.subscribers, sub.userId)
+		}
+	}
+	sub.once.Do(func() { close(sub.events) })
+}
+
+// run listens to the channel until the broker is closed, listening again
+// whenever the connection is lost.
+func (b *Broker) run(ctx context.Context) {
+	defer close(b.done)
+	for {
+		err := b.store.Listen(ctx, Channel, func() { b.catchUp(ctx) }, b.receive)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Error while listening to order status changes: %v", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(b.config.RetryInterval):
+		}
+	}
+}
+
+// catchUp runs every time the channel is listened to. The first time it
+// notes the latest changes, which were made before the broker started; after
+// a reconnection it publishes the changes sent while the broker was not
+// listening.
+func (b *Broker) catchUp(ctx context.Context) {
+	if !b.listened {
+		lastId, err := b.store.GetLastOrderStatusEventId(ctx)
+		if err != nil {
+			log.Printf("Error while fetching the last order status change: %v", err)
+		} else {
+			b.lastId = lastId
+			b.listened = b.readSince(ctx, false)
+		}
+		select {
+		case <-b.ready:
+		default:
+			close(b.ready)
+		}
+		return
+	}
+	b.readSince(ctx, true)
+}
+
+// readSince reads the changes from CatchUpOverlap ids before the last change
+// seen on, publishing those not seen yet when publish is set and only noting
+// them otherwise. It reports whether every change could be read.
+func (b *Broker) readSince(ctx context.Context, publish bool) bool {
+	afterId := max(b.lastId-CatchUpOverlap, 0)
+	for {
+		events, err := b.store.GetOrderStatusEventsSince(ctx, db.GetOrderStatusEventsSinceParams{
+			AfterId:  afterId,
+			PageSize: catchUpPageSize,
+		})
+		if err != nil {
+			log.Printf("Error while catching up on order status changes: %v", err)
+			return false
+		}
+		for _, event := range events {
+			if publish {
+				b.publish(event)
+			} else if b.seen.Add(event.ID) {
+				b.lastId = max(b.lastId, event.ID)
+			}
+		}
+		if len(events) < catchUpPageSize {
+			return true
+		}
+		afterId = events[len(events)-1].ID
+	}
+}
+
+func (b *Broker) receive(payload string) {
+	var event db.OrderStatusEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		log.Printf("Error while decoding order status change %q: %v", payload, err)
+		return
+	}
+	b.publish(event)
+}
+
+// publish hands a change to the subscriptions of the owner of the order.
+// Subscriptions with a full buffer are dropped rather than waited for.
+func (b *Broker) publish(event db.OrderStatusEvent) {
+	if !b.seen.Add(event.ID) {
+		return
+	}
+	b.lastId = max(b.lastId, event.ID)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subscribers[event.UserId] {
+		select {
+		case sub.events <- event:
+		default:
+			log.Printf("Dropping the order stream of user %s as it fell behind", sub.userId)
+			b.removeLocked(sub)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package orderstream
+
+// RecentIds remembers the ids of the last changes seen, up to a fixed number,
+// to drop the changes seen twice.
+//
+// Change ids are handed out when a change is made, not when its transaction
+// commits, so a change can be seen after changes with higher ids. Remembering
+// the ids themselves, rather than only the highest one, keeps such a change
+// from being taken for a repeat.
+type RecentIds struct {
+	ids   map[int64]struct{}
+	order []int64
+	next  int
+}
+
+// NewRecentIds creates a RecentIds remembering up to size ids.
+func NewRecentIds(size int) *RecentIds {
+	return &RecentIds{
+		ids:   make(map[int64]struct{}, size),
+		order: make([]int64, 0, size),
+	}
+}
+
+// Add records an id and reports whether it was not seen before. The oldest
+// id recorded is forgotten once size ids are remembered.
+func (r *RecentIds) Add(id int64) bool {
+	if _, ok := r.ids[id]; ok {
+		return false
+	}
+	if len(r.order) < cap(r.order) {
+		r.order = append(r.order, id)
+	} else {
+		delete(r.ids, r.order[r.next])
+		r.order[r.next] = id
+		r.next = (r.next + 1) % len(r.order)
+	}
+	r.ids[id] = struct{}{}
+	return true
+}
@@ -0,0 +1,216 @@
+package promotion
+
+import (
+	"github.com/google/uuid"
+	"math"
+	"sort"
+)
+
+const (
+	BuyXGetY     = "BUY_X_GET_Y"
+	Bundle       = "BUNDLE"
+	QuantityTier = "QUANTITY_TIER"
+	CategorySale = "CATEGORY_SALE"
+)
+
+// Tier is a percentage taken off a line ordered in at least MinQuantity units.
+// Tiers are stored as JSON on the promotion.
+type Tier struct {
+	MinQuantity int32   `json:"minQuantity"`
+	Percent     float64 `json:"percent"`
+}
+
+// Rule describes a single automatic promotion.
+//
+// BuyXGetY takes Percent off GetQuantity of every BuyQuantity + GetQuantity
+// units, the cheapest units first. Bundle sells one of each of ProductIds for
+// BundlePrice. QuantityTier takes the Percent of the highest tier reached off
+// each line and CategorySale takes Percent off every line it applies to.
+//
+// Promotions are evaluated from the highest Priority down. A promotion that
+// is not Stackable only discounts lines no other promotion has discounted,
+// and no promotion evaluated after it discounts its lines.
+type Rule struct {
+	ID          uuid.UUID
+	Name        string
+	Kind        string
+	Priority    int32
+	Stackable   bool
+	ProductIds  []uuid.UUID
+	Categories  []string
+	BuyQuantity int32
+	GetQuantity int32
+	Percent     float64
+	BundlePrice float64
+	Tiers       []Tier
+}
+
+// Line is a single priced order line the promotions are evaluated against.
+type Line struct {
+	ProductId uuid.UUID
+	Category  string
+	Quantity  int32
+	Amount    float64
+}
+
+// Applied is a promotion that discounted the order.
+type Applied struct {
+	ID       uuid.UUID
+	Name     string
+	Kind     string
+	Discount float64
+}
+
+// Apply evaluates the rules against the basket and returns the promotions
+// that discounted it along with the discount taken off each line.
+func Apply(rules []Rule, lines []Line) ([]Applied, []float64) {
+	var applied []Applied
+	discounts := make([]float64, len(lines))
+	exclusive := make([]bool, len(lines))
+	ordered := make([]Rule, len(rules))
+	copy(ordered, rules)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Priority > ordered[j].Priority
+	})
+	for _, rule := range ordered {
+		remaining := make([]float64, len(lines))
+		eligible := make([]bool, len(lines))
+		for i, line := range lines {
+			remaining[i] = line.Amount - discounts[i]
+			eligible[i] = !exclusive[i] && remaining[i] > 0 && rule.applies(line)
+			if !rule.Stackable && discounts[i] > 0 {
+				eligible[i] = false
+			}
+		}
+		shares := rule.discount(lines, remaining, eligible)
+		var total float64
+		for i, share := range shares {
+			share = math.Min(math.Round(share*100)/100, remaining[i])
+			if share <= 0 {
+				continue
+			}
+			discounts[i] = math.Round((discounts[i]+share)*100) / 100
+			total += share
+			if !rule.Stackable {
+				exclusive[i] = true
+			}
+		}
+		if total > 0 {
+			applied = append(applied, Applied{
+				ID:       rule.ID,
+				Name:     rule.Name,
+				Kind:     rule.Kind,
+				Discount: math.Round(total*100) / 100,
+			})
+		}
+	}
+	return applied, discounts
+}
+
+// applies reports whether the line falls within the product and category
+// restrictions of the rule. A rule without restrictions applies to every line
+// and a bundle only ever applies to its own products.
+func (rule Rule) applies(line Line) bool {
+	if len(rule.ProductIds) == 0 && len(rule.Categories) == 0 {
+		return rule.Kind != Bundle
+	}
+	for _, id := range rule.ProductIds {
+		if id == line.ProductId {
+			return true
+		}
+	}
+	if rule.Kind == Bundle {
+		return false
+	}
+	for _, category := range rule.Categories {
+		if category == line.Category {
+			return true
+		}
+	}
+	return false
+}
+
+// discount works out the unrounded discount of the rule on each of the
+// eligible lines given what is left of their amounts.
+func (rule Rule) discount(lines []Line, remaining []float64, eligible []bool) []float64 {
+	shares := make([]float64, len(lines))
+	switch rule.Kind {
+	case BuyXGetY:
+		// Units are pooled across the eligible lines and the cheapest go
+		// at a discount.
+		var units int32
+		var indexes []int
+		for i, line := range lines {
+			if eligible[i] {
+				units += line.Quantity
+				indexes = append(indexes, i)
+			}
+		}
+		group := rule.BuyQuantity + rule.GetQuantity
+		if group <= 0 {
+			return shares
+		}
+		discounted := units / group * rule.GetQuantity
+		sort.SliceStable(indexes, func(a, b int) bool {
+			return remaining[indexes[a]]/float64(lines[indexes[a]].Quantity) < remaining[indexes[b]]/float64(lines[indexes[b]].Quantity)
+		})
+		for _, i := range indexes {
+			if discounted <= 0 {
+				break
+			}
+			quantity := min(discounted, lines[i].Quantity)
+			shares[i] = remaining[i] / float64(lines[i].Quantity) * float64(quantity) * rule.Percent / 100
+			discounted -= quantity
+		}
+	case Bundle:
+		bundles := int32(-1)
+		var value float64
+		for _, id := range rule.ProductIds {
+			found := false
+			for i, line := range lines {
+				if line.ProductId != id || !eligible[i] {
+					continue
+				}
+				found = true
+				if bundles < 0 || line.Quantity < bundles {
+					bundles = line.Quantity
+				}
+				value += remaining[i] / float64(line.Quantity)
+			}
+			if !found {
+				return shares
+			}
+		}
+		if bundles <= 0 || value <= rule.BundlePrice {
+			return shares
+		}
+		// The saving on each bundle is spread across its products in
+		// proportion to their price.
+		saving := float64(bundles) * (value - rule.BundlePrice)
+		for i, line := range lines {
+			if eligible[i] {
+				shares[i] = saving * (remaining[i] / float64(line.Quantity)) / value
+			}
+		}
+	case QuantityTier:
+		for i, line := range lines {
+			if !eligible[i] {
+				continue
+			}
+			var best Tier
+			for _, tier := range rule.Tiers {
+				if line.Quantity >= tier.MinQuantity && tier.MinQuantity >= best.MinQuantity {
+					best = tier
+				}
+			}
+			shares[i] = remaining[i] * best.Percent / 100
+		}
+	case CategorySale:
+		for i := range lines {
+			if eligible[i] {
+				shares[i] = remaining[i] * rule.Percent / 100
+			}
+		}
+	}
+	return shares
+}
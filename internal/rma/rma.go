@@ -0,0 +1,99 @@
+package rma
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"math"
+)
+
+const (
+	Requested = "REQUESTED"
+	Approved  = "APPROVED"
+	Rejected  = "REJECTED"
+	Received  = "RECEIVED"
+	Refunding = "REFUNDING"
+	Refunded  = "REFUNDED"
+)
+
+var ErrInvalidTransition = errors.New("invalid return status transition")
+
+// transitions lists the states a return can move to from each state. Rejected
+// and refunded returns are final. A received return is claimed as REFUNDING
+// before the refund is issued; a failed refund releases it back to RECEIVED
+// with Release rather than a transition.
+var transitions = map[string][]string{
+	Requested: {Approved, Rejected},
+	Approved:  {Received, Rejected},
+	Received:  {Refunding},
+	Refunding: {Refunded},
+}
+
+// Transition checks that a return can move from one state to another.
+func Transition(from, to string) error {
+	for _, next := range transitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, to)
+}
+
+// Release checks that a return can be moved back to RECEIVED after its refund
+// failed.
+func Release(from string) error {
+	if from != Refunding {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, Received)
+	}
+	return nil
+}
+
+// Line is a single returned order item. Price is what the whole order item
+// cost before any discount, Quantity how many units were ordered and Returned
+// how many of them are being returned.
+type Line struct {
+	Price    float64
+	Quantity int32
+	Returned int32
+}
+
+// RefundAmount works out how much to refund for the returned lines. paidRatio
+// is the share of the product subtotal the customer actually paid, after
+// discounts and exclusive taxes and excluding shipping, so that the refund
+// matches what was charged for the returned units.
+func RefundAmount(lines []Line, paidRatio float64) float64 {
+	var amount float64
+	for _, line := range lines {
+		if line.Quantity <= 0 {
+			continue
+		}
+		amount += line.Price / float64(line.Quantity) * float64(line.Returned)
+	}
+	return math.Round(amount*paidRatio*100) / 100
+}
+
+// Refund is a refund to issue for a received return. IdempotencyKey is the
+// return id, stable across retries.
+type Refund struct {
+	ReturnID       uuid.UUID
+	OrderID        uuid.UUID
+	UserID         uuid.UUID
+	Amount         float64
+	IdempotencyKey string
+}
+
+// Refunder issues refunds through a payment provider and returns the
+// provider's reference for the refund. IdempotencyKey has to be passed to the
+// provider so that a retried refund is never paid twice.
+type Refunder interface {
+	Refund(ctx context.Context, refund Refund) (string, error)
+}
+
+// ManualRefunder is used when no payment provider is configured. It does not
+// move any money and leaves the refund to be settled outside the API.
+type ManualRefunder struct{}
+
+func (ManualRefunder) Refund(_ context.Context, refund Refund) (string, error) {
+	return "manual-" + refund.ReturnID.String(), nil
+}
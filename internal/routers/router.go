@@ -24,25 +24,127 @@ func InitRouters(handler *handlers.AllHandler, token *token.JWT) *gin.Engine {
 		{
 			auth.POST("/register", handler.UserHandler.CreateUser)
 			auth.POST("/login", handler.UserHandler.LoginUser)
+			auth.POST("/claim/request", handler.RequestAccountClaim)
+			auth.POST("/claim", handler.ClaimAccount)
 		}
+		// Guests order without an account and look their orders up with the emailed token
+		guest := v1.Group("/guest")
+		{
+			guest.POST("/orders", handler.CreateGuestOrder)
+			guest.POST("/orders/lookup", handler.GetGuestOrder)
+		}
+		// Uploaded files are public so that they can be used in img tags
+		v1.GET("/media/*key", handler.GetMedia)
+		v1.GET("/images/:imageId/:variant", handler.GetProductImageThumbnail)
+		// Shared wishlists are public so that their links can be sent to anyone
+		v1.GET("/wishlists/shared/:token", handler.GetSharedWishlist)
 		v1.Use(middlewares.AuthMiddy(token))
 		// Orders routes
 		orders := v1.Group("/orders")
 		{
 			orders.POST("", handler.CreateOrder)
+			orders.POST("/preview", handler.PreviewOrder)
 			orders.GET("", handler.GetUserOrders)
+			orders.GET("/stream", handler.StreamOrders)
 			orders.PATCH("/:id", handler.CancelOrder)
+			orders.GET("/:id/shipments", handler.GetOrderShipments)
+			orders.POST("/:id/returns", handler.CreateReturn)
+			orders.GET("/:id/returns", handler.GetOrderReturns)
+		}
+		me := v1.Group("/me")
+		{
+			me.GET("/notification-preferences", handler.GetNotificationPreference)
+			me.PUT("/notification-preferences", handler.UpdateNotificationPreference)
+			me.GET("/wishlists", handler.GetUserWishlists)
+			me.POST("/wishlists", handler.CreateWishlist)
+			me.GET("/wishlists/:id", handler.GetUserWishlist)
+			me.PUT("/wishlists/:id", handler.UpdateWishlist)
+			me.DELETE("/wishlists/:id", handler.DeleteWishlist)
+			me.POST("/wishlists/:id/items", handler.AddWishlistItem)
+			me.DELETE("/wishlists/:id/items/:productId", handler.RemoveWishlistItem)
+			me.POST("/wishlists/:id/share", handler.ShareWishlist)
+			me.DELETE("/wishlists/:id/share", handler.UnshareWishlist)
+			me.POST("/wishlists/:id/order", handler.OrderWishlist)
 		}
 		v1.GET("/products", handler.GetAllProduct)
+		v1.POST("/products/:id/reviews", handler.CreateReview)
+		v1.GET("/products/:id/reviews", handler.GetProductReviews)
+		v1.POST("/shipping/quote", handler.QuoteShipping)
 		// Admin routes
 		admin := v1.Group("/admin")
 		{
 			admin.Use(middlewares.AdminMiddy)
 			admin.POST("/products", handler.CreateProduct)
+			admin.POST("/products/import", handler.ImportProducts)
+			admin.GET("/products/export", handler.ExportProducts)
 			admin.GET("/products/:id", handler.GetOneProduct)
 			admin.DELETE("/products/:id", handler.DeleteOneProduct)
 			admin.PUT("/products/:id", handler.UpdateOneProduct)
+			admin.POST("/products/:id/restore", handler.RestoreProduct)
+			admin.POST("/products/:id/images", handler.UploadProductImage)
+			admin.PATCH("/products/:id/images", handler.ReorderProductImages)
+			admin.DELETE("/products/:id/images/:imageId", handler.DeleteProductImage)
 			admin.PATCH("/orders/:id", handler.OrderHandler.UpdateOrderStatus)
+			admin.POST("/orders/:id/shipments", handler.CreateShipment)
+			admin.PATCH("/shipments/:id", handler.UpdateOneShipment)
+			admin.GET("/returns", handler.GetAllReturn)
+			admin.POST("/returns/:id/approve", handler.ApproveReturn)
+			admin.POST("/returns/:id/reject", handler.RejectReturn)
+			admin.POST("/returns/:id/receive", handler.ReceiveReturn)
+			admin.POST("/returns/:id/refund", handler.RefundReturn)
+			admin.GET("/products/:id/stock-movements", handler.GetStockMovements)
+			admin.POST("/products/:id/prices", handler.ScheduleProductPrice)
+			admin.GET("/products/:id/prices", handler.GetProductPrices)
+			admin.DELETE("/products/:id/prices/:priceId", handler.CancelProductPrice)
+			admin.GET("/inventory/low-stock", handler.GetLowStockProducts)
+			admin.GET("/inventory/reconciliation", handler.ReconcileStock)
+			admin.POST("/warehouses", handler.CreateWarehouse)
+			admin.GET("/warehouses", handler.GetAllWarehouse)
+			admin.GET("/warehouses/:id", handler.GetOneWarehouse)
+			admin.PUT("/warehouses/:id", handler.UpdateOneWarehouse)
+			admin.GET("/warehouses/:id/stock", handler.GetWarehouseStock)
+			admin.PUT("/warehouses/:id/stock/:productId", handler.SetWarehouseStock)
+			admin.GET("/products/:id/warehouse-stock", handler.GetProductWarehouseStock)
+			admin.POST("/stock-transfers", handler.TransferStock)
+			admin.GET("/stock-transfers", handler.GetStockTransfers)
+			admin.GET("/orders/:id/allocations", handler.GetOrderAllocations)
+			admin.GET("/reviews", handler.GetAllReview)
+			admin.POST("/reviews/:id/approve", handler.ApproveReview)
+			admin.POST("/reviews/:id/reject", handler.RejectReview)
+			admin.DELETE("/reviews/:id", handler.DeleteReview)
+			admin.GET("/jobs", handler.GetAllJob)
+			admin.GET("/jobs/stats", handler.GetJobStats)
+			admin.GET("/jobs/:id", handler.GetOneJob)
+			admin.POST("/jobs/:id/retry", handler.RetryJob)
+			admin.POST("/webhooks", handler.CreateWebhook)
+			admin.GET("/webhooks", handler.GetAllWebhook)
+			admin.GET("/webhooks/:id", handler.GetOneWebhook)
+			admin.PUT("/webhooks/:id", handler.UpdateOneWebhook)
+			admin.DELETE("/webhooks/:id", handler.DeleteOneWebhook)
+			admin.GET("/webhooks/:id/deliveries", handler.GetWebhookDeliveries)
+			admin.POST("/webhook-deliveries/:id/redeliver", handler.RedeliverWebhook)
+			admin.POST("/coupons", handler.CreateCoupon)
+			admin.GET("/coupons", handler.GetAllCoupon)
+			admin.GET("/coupons/:id", handler.GetOneCoupon)
+			admin.PUT("/coupons/:id", handler.UpdateOneCoupon)
+			admin.DELETE("/coupons/:id", handler.DeleteOneCoupon)
+			admin.POST("/promotions", handler.CreatePromotion)
+			admin.GET("/promotions", handler.GetAllPromotion)
+			admin.GET("/promotions/:id", handler.GetOnePromotion)
+			admin.PUT("/promotions/:id", handler.UpdateOnePromotion)
+			admin.DELETE("/promotions/:id", handler.DeleteOnePromotion)
+			admin.POST("/tax-rules", handler.CreateTaxRule)
+			admin.GET("/tax-rules", handler.GetAllTaxRule)
+			admin.PUT("/tax-rules/:id", handler.UpdateOneTaxRule)
+			admin.DELETE("/tax-rules/:id", handler.DeleteOneTaxRule)
+			admin.POST("/shipping-zones", handler.CreateShippingZone)
+			admin.GET("/shipping-zones", handler.GetAllShippingZone)
+			admin.PUT("/shipping-zones/:id", handler.UpdateOneShippingZone)
+			admin.DELETE("/shipping-zones/:id", handler.DeleteOneShippingZone)
+			admin.POST("/shipping-methods", handler.CreateShippingMethod)
+			admin.GET("/shipping-methods", handler.GetAllShippingMethod)
+			admin.PUT("/shipping-methods/:id", handler.UpdateOneShippingMethod)
+			admin.DELETE("/shipping-methods/:id", handler.DeleteOneShippingMethod)
 		}
 	}
 	//v1.GET("/docs", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/constants"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CouponService provides business logic for coupon operations.
+type CouponService struct {
+	store db.Store
+}
+
+// NewCouponService creates a new CouponService instance.
+func NewCouponService(store db.Store) *CouponService {
+	return &CouponService{
+		store: store,
+	}
+}
+
+func (s *CouponService) CreateCoupon(ctx context.Context, input types.CreateCouponInput) (db.Coupon, types.CouponErrMessage, int, error) {
+	input.Code = strings.ToUpper(strings.TrimSpace(input.Code))
+	input.DiscountType = strings.ToUpper(input.DiscountType)
+	errMessage, err := validators.ValidateCoupon(input)
+	if err != nil {
+		return db.Coupon{}, errMessage, http.StatusBadRequest, err
+	}
+	active := true
+	if input.Active != nil {
+		active = *input.Active
+	}
+	if input.ProductIds == nil {
+		input.ProductIds = []uuid.UUID{}
+	}
+	if input.Categories == nil {
+		input.Categories = []string{}
+	}
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	newCoupon, err := s.store.CreateCoupon(ctx, db.CreateCouponParams{
+		ID:            uuid.New(),
+		Code:          input.Code,
+		DiscountType:  db.DiscountType(input.DiscountType),
+		Value:         math.Round(input.Value*100) / 100,
+		MinOrderValue: math.Round(input.MinOrderValue*100) / 100,
+		ProductIds:    input.ProductIds,
+		Categories:    input.Categories,
+		UsageLimit:    input.UsageLimit,
+		PerUserLimit:  input.PerUserLimit,
+		StartsAt:      toTimestamp(input.StartsAt),
+		ExpiresAt:     toTimestamp(input.ExpiresAt),
+		Active:        active,
+		CreatedBy:     userId,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				errMessage.Code = "coupon code already exists"
+				return db.Coupon{}, errMessage, http.StatusBadRequest, err
+			}
+		}
+		return db.Coupon{}, errMessage, http.StatusInternalServerError, err
+	}
+	return newCoupon, errMessage, http.StatusCreated, nil
+}
+
+func (s *CouponService) GetAllCoupon(ctx context.Context) ([]db.Coupon, types.CouponErrMessage, int, error) {
+	var errMessage types.CouponErrMessage
+	coupons, err := s.store.GetAllCoupon(ctx)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return coupons, errMessage, http.StatusOK, nil
+}
+
+func (s *CouponService) GetOneCoupon(ctx context.Context, couponId uuid.UUID) (db.Coupon, types.CouponErrMessage, int, error) {
+	var errMessage types.CouponErrMessage
+	coupon, err := s.store.GetOneCoupon(ctx, couponId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "coupon not found"
+			return coupon, errMessage, http.StatusNotFound, err
+		}
+		return coupon, errMessage, http.StatusInternalServerError, err
+	}
+	return coupon, errMessage, http.StatusOK, nil
+}
+
+func (s *CouponService) UpdateOneCoupon(ctx context.Context, couponId uuid.UUID, input types.CouponUpdateInput) (db.Coupon, types.CouponErrMessage, int, error) {
+	current, errMessage, statusCode, err := s.GetOneCoupon(ctx, couponId)
+	if err != nil {
+		return current, errMessage, statusCode, err
+	}
+	if input.Code != nil {
+		code := strings.ToUpper(strings.TrimSpace(*input.Code))
+		input.Code = &code
+	}
+	if input.DiscountType != nil {
+		discountType := strings.ToUpper(*input.DiscountType)
+		input.DiscountType = &discountType
+	}
+	errMessage, err = validators.ValidateCouponUpdateInput(input, current)
+	if err != nil {
+		return current, errMessage, http.StatusBadRequest, err
+	}
+	arg := db.UpdateCouponTxParams{
+		ID:            couponId,
+		Code:          input.Code,
+		Value:         input.Value,
+		MinOrderValue: input.MinOrderValue,
+		ProductIds:    input.ProductIds,
+		Categories:    input.Categories,
+		UsageLimit:    input.UsageLimit,
+		PerUserLimit:  input.PerUserLimit,
+		Active:        input.Active,
+	}
+	if input.DiscountType != nil {
+		discountType := db.DiscountType(*input.DiscountType)
+		arg.DiscountType = &discountType
+	}
+	if input.StartsAt != nil {
+		startsAt := toTimestamp(input.StartsAt)
+		arg.StartsAt = &startsAt
+	}
+	if input.ExpiresAt != nil {
+		expiresAt := toTimestamp(input.ExpiresAt)
+		arg.ExpiresAt = &expiresAt
+	}
+	updatedCoupon, execErr, txErr := s.store.UpdateCouponTx(ctx, arg)
+	if execErr != nil || txErr != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(execErr, &pgErr) {
+			if pgErr.Code == "23505" {
+				errMessage.Code = "coupon code already exists"
+				return updatedCoupon, errMessage, http.StatusBadRequest, execErr
+			}
+		}
+		return updatedCoupon, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	return updatedCoupon, errMessage, http.StatusOK, nil
+}
+
+func (s *CouponService) DeleteOneCoupon(ctx context.Context, couponId uuid.UUID) (db.Coupon, types.CouponErrMessage, int, error) {
+	var coupon db.Coupon
+	var errMessage types.CouponErrMessage
+	err := s.store.DeleteOneCoupon(ctx, couponId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "coupon not found"
+			return coupon, errMessage, http.StatusNotFound, err
+		}
+		// Redemptions keep the coupons they were made with
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			errMessage.ID = "coupon has been redeemed, deactivate it instead"
+			return coupon, errMessage, http.StatusConflict, err
+		}
+		return coupon, errMessage, http.StatusInternalServerError, err
+	}
+	return coupon, errMessage, http.StatusNoContent, nil
+}
+
+// toTimestamp converts an optional time into a nullable database timestamp in UTC.
+func toTimestamp(t *time.Time) pgtype.Timestamp {
+	if t == nil {
+		return pgtype.Timestamp{}
+	}
+	return pgtype.Timestamp{Time: t.UTC(), Valid: true}
+}
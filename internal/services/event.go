@@ -0,0 +1,20 @@
+package services
+
+import (
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/events"
+)
+
+// RegisterEventHandlers subscribes the services to the domain events they
+// react to. Low-stock alerts are emailed to lowStockEmails.
+func RegisterEventHandlers(bus *events.Bus, store db.Store, lowStockEmails []string) {
+	notifications := NewNotificationService(store, nil)
+	bus.Subscribe(db.EventOrderCreated, notifications.OnOrderCreated)
+	bus.Subscribe(db.EventOrderStatusChanged, notifications.OnOrderStatusChanged)
+	inventory := NewInventoryService(store, nil, lowStockEmails)
+	bus.Subscribe(db.EventProductStockLow, inventory.OnProductStockLow)
+	wishlists := NewWishlistService(store, nil)
+	bus.Subscribe(db.EventProductBackInStock, wishlists.OnProductBackInStock)
+	guests := NewGuestService(store, nil, nil)
+	bus.Subscribe(db.EventOrderCreated, guests.OnOrderCreated)
+}
@@ -0,0 +1,345 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/events"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/jobs"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/mailer"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/notification"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
+	"github.com/slamchillz/getinstashop-ecommerce-api/pkg/token"
+	"net/http"
+	"strings"
+)
+
+// orderLookupMaxAttempts and accountClaimMaxAttempts give a mail server a few
+// minutes to recover.
+const (
+	orderLookupMaxAttempts  = 5
+	accountClaimMaxAttempts = 5
+)
+
+// GuestService provides business logic for orders placed without an account.
+//
+// A guest order is placed under a shadow user holding the email of the guest,
+// which cannot log in. The guest is emailed a signed token to look up the
+// order with. To claim the account the guest asks for a short-lived claim
+// token, emailed to them as proof they own the email: claiming the account
+// with it and a password turns the shadow user into a regular account, keeping
+// every order placed as a guest with that email. A claim token is only good
+// once, as a claimed account is no longer a shadow user.
+type GuestService struct {
+	store    db.Store
+	jwtToken *token.JWT
+	mailer   mailer.Mailer
+	orders   *OrderService
+}
+
+// NewGuestService creates a new GuestService instance. mail is only needed to
+// run the order lookup jobs.
+func NewGuestService(store db.Store, jwtToken *token.JWT, mail mailer.Mailer) *GuestService {
+	return &GuestService{
+		store:    store,
+		jwtToken: jwtToken,
+		mailer:   mail,
+		orders:   NewOrderService(store),
+	}
+}
+
+// CreateGuestOrder places an order for a guest. An email that belongs to an
+// account has to log in to order instead.
+func (s *GuestService) CreateGuestOrder(ctx context.Context, input types.GuestOrderInput) (types.OrderOutput, types.GuestOrderErrMessage, int, error) {
+	input.Email = strings.TrimSpace(input.Email)
+	input.Address = normaliseGuestAddress(input.Address)
+	errMessage, err := validators.ValidateGuestOrder(input)
+	if err != nil {
+		return types.OrderOutput{}, errMessage, http.StatusBadRequest, err
+	}
+	region := input.Address.Country
+	if input.Address.State != "" {
+		region += "-" + input.Address.State
+	}
+	arg, orderErrMessage := orderParams(ctx, types.CreateOrderInput{
+		Items:            input.Items,
+		CouponCode:       input.CouponCode,
+		ShippingRegion:   region,
+		ShippingMethodId: input.ShippingMethodId,
+	})
+	if orderErrMessage.Items != nil {
+		errMessage.Items = orderErrMessage.Items
+		return types.OrderOutput{}, errMessage, http.StatusBadRequest, errors.New("invalid guest order input")
+	}
+	userId, errMessage, statusCode, err := s.shadowUser(ctx, input.Email)
+	if err != nil {
+		return types.OrderOutput{}, errMessage, statusCode, err
+	}
+	arg.UserId = userId
+	arg.Guest = &db.CreateGuestOrderParams{
+		Email:      input.Email,
+		Name:       input.Address.Name,
+		Line1:      input.Address.Line1,
+		Line2:      input.Address.Line2,
+		City:       input.Address.City,
+		PostalCode: input.Address.PostalCode,
+		Country:    input.Address.Country,
+		State:      input.Address.State,
+	}
+	order, invalidItems, execErr, txErr := s.store.CreateOrderTx(ctx, arg)
+	if len(invalidItems) > 0 {
+		errMessage.Items = invalidItems
+		return types.OrderOutput{Order: order}, errMessage, http.StatusBadRequest, errors.New("invalid guest order input")
+	}
+	if execErr != nil || txErr != nil {
+		return types.OrderOutput{Order: order}, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	output, err := s.orders.withBreakdown(ctx, []db.Order{order})
+	if err != nil {
+		return types.OrderOutput{Order: order}, errMessage, http.StatusInternalServerError, err
+	}
+	return output[0], errMessage, http.StatusCreated, nil
+}
+
+// shadowUser returns the shadow user of the guest with the email, creating it
+// on their first order.
+func (s *GuestService) shadowUser(ctx context.Context, email string) (uuid.UUID, types.GuestOrderErrMessage, int, error) {
+	var errMessage types.GuestOrderErrMessage
+	user, err := s.store.GetUserById(ctx, email)
+	if err == nil {
+		if !user.Guest {
+			errMessage.Email = "email belongs to an account, log in to place the order"
+			return uuid.Nil, errMessage, http.StatusConflict, errors.New("guest order for an existing account")
+		}
+		return user.ID, errMessage, http.StatusOK, nil
+	}
+	if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) != err.Error() {
+		return uuid.Nil, errMessage, http.StatusInternalServerError, err
+	}
+	newUser, err := s.store.CreateGuestUser(ctx, db.CreateGuestUserParams{
+		ID:    uuid.New(),
+		Email: email,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		// A concurrent order or signup took the email first
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return s.shadowUser(ctx, email)
+		}
+		return uuid.Nil, errMessage, http.StatusInternalServerError, err
+	}
+	return newUser.ID, errMessage, http.StatusOK, nil
+}
+
+func normaliseGuestAddress(address types.GuestAddress) types.GuestAddress {
+	address.Name = strings.TrimSpace(address.Name)
+	address.Line1 = strings.TrimSpace(address.Line1)
+	address.Line2 = strings.TrimSpace(address.Line2)
+	address.City = strings.TrimSpace(address.City)
+	address.PostalCode = strings.TrimSpace(address.PostalCode)
+	address.Country = strings.ToUpper(strings.TrimSpace(address.Country))
+	address.State = strings.ToUpper(strings.TrimSpace(address.State))
+	return address
+}
+
+// GetGuestOrder looks up the order an order lookup token was issued for.
+func (s *GuestService) GetGuestOrder(ctx context.Context, lookupToken string) (types.GuestOrderOutput, types.GuestOrderErrMessage, int, error) {
+	var errMessage types.GuestOrderErrMessage
+	payload, err := s.jwtToken.VerifyOrderLookupToken(lookupToken)
+	if err != nil {
+		errMessage.Token = "token is invalid or expired"
+		return types.GuestOrderOutput{}, errMessage, http.StatusUnauthorized, err
+	}
+	order, err := s.store.GetOrderById(ctx, payload.OrderID)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.Token = "order not found"
+			return types.GuestOrderOutput{}, errMessage, http.StatusNotFound, err
+		}
+		return types.GuestOrderOutput{}, errMessage, http.StatusInternalServerError, err
+	}
+	address, err := s.store.GetGuestOrder(ctx, order.ID)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.Token = "order not found"
+			return types.GuestOrderOutput{}, errMessage, http.StatusNotFound, err
+		}
+		return types.GuestOrderOutput{}, errMessage, http.StatusInternalServerError, err
+	}
+	output, err := s.orders.withBreakdown(ctx, []db.Order{order})
+	if err != nil {
+		return types.GuestOrderOutput{}, errMessage, http.StatusInternalServerError, err
+	}
+	return types.GuestOrderOutput{OrderOutput: output[0], ShippingAddress: address}, errMessage, http.StatusOK, nil
+}
+
+// RequestAccountClaim queues the email sending a guest the token to claim
+// their account with. Whether the email belongs to a guest is not disclosed:
+// nothing is sent to emails without guest orders.
+func (s *GuestService) RequestAccountClaim(ctx context.Context, input types.AccountClaimRequestInput) (types.ClaimAccountErrMessage, int, error) {
+	var errMessage types.ClaimAccountErrMessage
+	input.Email = strings.TrimSpace(input.Email)
+	if msg := validators.ValidateEmail(input.Email); msg != "" {
+		errMessage.Email = msg
+		return errMessage, http.StatusBadRequest, errors.New(msg)
+	}
+	user, err := s.store.GetUserById(ctx, input.Email)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			return errMessage, http.StatusAccepted, nil
+		}
+		return errMessage, http.StatusInternalServerError, err
+	}
+	if !user.Guest {
+		return errMessage, http.StatusAccepted, nil
+	}
+	_, err = jobs.Enqueue(ctx, s.store, jobs.EnqueueParams{
+		Kind:        SendAccountClaimJob,
+		Payload:     types.AccountClaimJobArgs{UserId: user.ID, Email: user.Email},
+		MaxAttempts: accountClaimMaxAttempts,
+	})
+	if err != nil {
+		return errMessage, http.StatusInternalServerError, err
+	}
+	return errMessage, http.StatusAccepted, nil
+}
+
+// ClaimAccount turns the shadow user an account claim token was issued for
+// into an account with the password, and logs it in.
+func (s *GuestService) ClaimAccount(ctx context.Context, input types.ClaimAccountInput) (types.LoginUserOutput, types.ClaimAccountErrMessage, int, error) {
+	var output types.LoginUserOutput
+	var errMessage types.ClaimAccountErrMessage
+	payload, err := s.jwtToken.VerifyAccountClaimToken(input.Token)
+	if err != nil {
+		errMessage.Token = "token is invalid or expired"
+		return output, errMessage, http.StatusUnauthorized, err
+	}
+	authErrMessage, err := validators.ValidateAuthPayload(types.AuthPayload{Email: payload.Email, Password: input.Password})
+	if err != nil {
+		errMessage.Password = authErrMessage.Password
+		return output, errMessage, http.StatusBadRequest, err
+	}
+	password, err := utils.HashPassword(input.Password)
+	if err != nil {
+		return output, errMessage, http.StatusInternalServerError, err
+	}
+	user, err := s.store.ClaimGuestUser(ctx, db.ClaimGuestUserParams{
+		ID:       payload.UserID,
+		Password: password,
+	})
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.Token = "account already claimed, log in instead"
+			return output, errMessage, http.StatusConflict, err
+		}
+		return output, errMessage, http.StatusInternalServerError, err
+	}
+	accessToken, err := s.jwtToken.CreateToken(user.ID, user.Admin)
+	if err != nil {
+		return output, errMessage, http.StatusInternalServerError, err
+	}
+	output.Token = accessToken
+	return output, errMessage, http.StatusOK, nil
+}
+
+// OnOrderCreated queues the email sending a guest the token to look up the
+// order they placed, which also stands for the order placed email. The job
+// is keyed by the order, so relaying the event again does not send it twice.
+func (s *GuestService) OnOrderCreated(ctx context.Context, event events.Envelope) error {
+	created, err := events.Decode[db.OrderCreated](event)
+	if err != nil {
+		return err
+	}
+	_, err = s.store.GetGuestOrder(ctx, created.Order.ID)
+	if err != nil {
+		// Only guest orders are looked up with a token
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			return nil
+		}
+		return err
+	}
+	_, err = jobs.Enqueue(ctx, s.store, jobs.EnqueueParams{
+		Kind:           SendOrderLookupJob,
+		Payload:        types.OrderLookupJobArgs{OrderId: created.Order.ID},
+		MaxAttempts:    orderLookupMaxAttempts,
+		IdempotencyKey: fmt.Sprintf("%s:%s", SendOrderLookupJob, created.Order.ID),
+	})
+	return err
+}
+
+// RunOrderLookupJob emails a guest a freshly signed token to look up their
+// order with. Nothing is sent when the order was deleted before the job ran.
+func (s *GuestService) RunOrderLookupJob(ctx context.Context, payload json.RawMessage) error {
+	var args types.OrderLookupJobArgs
+	if err := json.Unmarshal(payload, &args); err != nil {
+		return jobs.Permanent(err)
+	}
+	order, err := s.store.GetOrderById(ctx, args.OrderId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			return nil
+		}
+		return err
+	}
+	address, err := s.store.GetGuestOrder(ctx, order.ID)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			return nil
+		}
+		return err
+	}
+	lookupToken, err := s.jwtToken.CreateOrderLookupToken(order.ID, order.UserId, address.Email)
+	if err != nil {
+		return err
+	}
+	msg, err := notification.RenderOrderLookup(address.Email, notification.OrderLookupData{
+		Order:   order,
+		Address: address,
+		Token:   lookupToken,
+	})
+	if err != nil {
+		// A template that fails to render fails every time
+		return jobs.Permanent(err)
+	}
+	return s.mailer.Send(ctx, msg)
+}
+
+// RunAccountClaimJob emails a guest a freshly signed token to claim their
+// account with. Nothing is sent when the account was claimed or deleted
+// before the job ran.
+func (s *GuestService) RunAccountClaimJob(ctx context.Context, payload json.RawMessage) error {
+	var args types.AccountClaimJobArgs
+	if err := json.Unmarshal(payload, &args); err != nil {
+		return jobs.Permanent(err)
+	}
+	user, err := s.store.GetUserById(ctx, args.Email)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			return nil
+		}
+		return err
+	}
+	if !user.Guest || user.ID != args.UserId {
+		return nil
+	}
+	claimToken, err := s.jwtToken.CreateAccountClaimToken(user.ID, user.Email)
+	if err != nil {
+		return err
+	}
+	msg, err := notification.RenderAccountClaim(user.Email, notification.AccountClaimData{
+		Email: user.Email,
+		Token: claimToken,
+	})
+	if err != nil {
+		// A template that fails to render fails every time
+		return jobs.Permanent(err)
+	}
+	return s.mailer.Send(ctx, msg)
+}
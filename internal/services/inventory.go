@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/events"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/jobs"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/mailer"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/notification"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// lowStockAlertMaxAttempts gives a mail server a few minutes to recover.
+const lowStockAlertMaxAttempts = 5
+
+// InventoryService provides business logic for keeping products in stock.
+type InventoryService struct {
+	store      db.Store
+	mailer     mailer.Mailer
+	recipients []string
+}
+
+// NewInventoryService creates a new InventoryService instance. Low-stock
+// alerts are emailed to recipients with mail, which is only needed to run
+// the alert jobs.
+func NewInventoryService(store db.Store, mail mailer.Mailer, recipients []string) *InventoryService {
+	return &InventoryService{
+		store:      store,
+		mailer:     mail,
+		recipients: recipients,
+	}
+}
+
+// GetLowStockProducts lists the products at or below their reorder
+// threshold, those furthest below it first.
+func (s *InventoryService) GetLowStockProducts(ctx context.Context, category string, limit int) ([]db.GetLowStockProductsRow, types.InventoryErrMessage, int, error) {
+	var errMessage types.InventoryErrMessage
+	if msg := validators.ValidateCategory(category); msg != "" {
+		errMessage.Category = msg
+		return nil, errMessage, http.StatusBadRequest, nil
+	}
+	if limit < 1 || limit > 500 {
+		errMessage.Limit = "limit must be between 1 and 500"
+		return nil, errMessage, http.StatusBadRequest, nil
+	}
+	products, err := s.store.GetLowStockProducts(ctx, db.GetLowStockProductsParams{
+		Category: category,
+		PageSize: int32(limit),
+	})
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return products, errMessage, http.StatusOK, nil
+}
+
+// GetStockMovements lists the stock movements of a product, newest first,
+// from before the movement with id before when it is not 0. The stock the
+// whole ledger adds up to comes along, to be checked against the stock.
+func (s *InventoryService) GetStockMovements(ctx context.Context, productId uuid.UUID, before int64, limit int) (types.StockMovementOutput, types.InventoryErrMessage, int, error) {
+	var errMessage types.InventoryErrMessage
+	if before < 0 {
+		errMessage.Before = "before must be the id of a movement"
+		return types.StockMovementOutput{}, errMessage, http.StatusBadRequest, nil
+	}
+	if limit < 1 || limit > 500 {
+		errMessage.Limit = "limit must be between 1 and 500"
+		return types.StockMovementOutput{}, errMessage, http.StatusBadRequest, nil
+	}
+	product, err := s.store.GetOneProduct(ctx, productId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "product not found"
+			return types.StockMovementOutput{}, errMessage, http.StatusNotFound, err
+		}
+		return types.StockMovementOutput{}, errMessage, http.StatusInternalServerError, err
+	}
+	ledgerStock, err := s.store.GetLedgerStock(ctx, productId)
+	if err != nil {
+		return types.StockMovementOutput{}, errMessage, http.StatusInternalServerError, err
+	}
+	movements, err := s.store.GetStockMovements(ctx, db.GetStockMovementsParams{
+		ProductId: productId,
+		BeforeId:  before,
+		PageSize:  int32(limit),
+	})
+	if err != nil {
+		return types.StockMovementOutput{}, errMessage, http.StatusInternalServerError, err
+	}
+	return types.StockMovementOutput{
+		ProductId:   productId,
+		Stock:       product.Stock,
+		LedgerStock: ledgerStock,
+		Movements:   movements,
+	}, errMessage, http.StatusOK, nil
+}
+
+// ReconcileStock lists the products whose stock is not what their stock
+// ledger, or the stock of the warehouses, adds up to. None are listed when
+// every change went through the ledger.
+func (s *InventoryService) ReconcileStock(ctx context.Context) ([]db.GetStockDiscrepanciesRow, types.InventoryErrMessage, int, error) {
+	var errMessage types.InventoryErrMessage
+	discrepancies, err := s.store.GetStockDiscrepancies(ctx)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return discrepancies, errMessage, http.StatusOK, nil
+}
+
+// OnProductStockLow queues the email of the alert opened by a product
+// running low on stock, when anyone is to be emailed.
+func (s *InventoryService) OnProductStockLow(ctx context.Context, event events.Envelope) error {
+	if len(s.recipients) == 0 {
+		return nil
+	}
+	low, err := events.Decode[db.ProductStockLow](event)
+	if err != nil {
+		return err
+	}
+	_, err = jobs.Enqueue(ctx, s.store, jobs.EnqueueParams{
+		Kind:        SendLowStockAlertJob,
+		Payload:     types.LowStockAlertJobArgs{AlertId: low.AlertId, Recipients: s.recipients},
+		MaxAttempts: lowStockAlertMaxAttempts,
+	})
+	return err
+}
+
+// RunLowStockAlertJob emails a low-stock alert to its recipients. Alerts
+// already emailed, or resolved before they could be, are skipped.
+func (s *InventoryService) RunLowStockAlertJob(ctx context.Context, payload json.RawMessage) error {
+	var args types.LowStockAlertJobArgs
+	if err := json.Unmarshal(payload, &args); err != nil {
+		return jobs.Permanent(err)
+	}
+	alert, err := s.store.GetOneLowStockAlert(ctx, args.AlertId)
+	if err != nil {
+		// The product was deleted along with its alerts
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			return nil
+		}
+		return err
+	}
+	if alert.NotifiedAt.Valid || alert.ResolvedAt.Valid {
+		return nil
+	}
+	product, err := s.store.GetOneProduct(ctx, alert.ProductId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			return nil
+		}
+		return err
+	}
+	var sendErr error
+	for _, to := range args.Recipients {
+		msg, err := notification.RenderLowStock(to, notification.LowStockData{Product: product, Alert: alert})
+		if err != nil {
+			// A template that fails to render fails every time
+			return jobs.Permanent(err)
+		}
+		sendErr = errors.Join(sendErr, s.mailer.Send(ctx, msg))
+	}
+	if sendErr != nil {
+		return sendErr
+	}
+	_, err = s.store.MarkLowStockAlertNotified(context.WithoutCancel(ctx), db.MarkLowStockAlertNotifiedParams{
+		ID:         alert.ID,
+		NotifiedAt: pgtype.Timestamp{Time: time.Now().UTC(), Valid: true},
+	})
+	return err
+}
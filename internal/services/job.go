@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/jobs"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/mailer"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/storage"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/thumbnail"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/pkg/token"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Kinds of the jobs enqueued by the services.
+const (
+	ImportProductsJob    = "product.import"
+	SendLowStockAlertJob = "inventory.low_stock_alert"
+	SendBackInStockJob   = "wishlist.back_in_stock"
+	SendOrderLookupJob   = "guest.order_lookup"
+	SendAccountClaimJob  = "guest.account_claim"
+)
+
+var jobStatuses = []db.JobStatus{db.JobStatusPENDING, db.JobStatusRUNNING, db.JobStatusSUCCEEDED, db.JobStatusDEAD}
+
+// RegisterJobHandlers sets the handlers running the jobs the services enqueue.
+func RegisterJobHandlers(pool *jobs.Pool, store db.Store, blobs storage.BlobStore, thumbnails *thumbnail.Generator, mail mailer.Mailer, jwtToken *token.JWT) {
+	products := NewProductService(store, blobs, thumbnails)
+	pool.Register(ImportProductsJob, products.RunImportJob)
+	pool.Register(db.ApplyProductPriceJob, products.RunApplyPriceJob)
+	webhooks := NewWebhookService(store)
+	pool.Register(db.DeliverWebhookJob, webhooks.RunDeliverJob)
+	notifications := NewNotificationService(store, mail)
+	pool.Register(db.SendNotificationJob, notifications.RunSendJob)
+	inventory := NewInventoryService(store, mail, nil)
+	pool.Register(SendLowStockAlertJob, inventory.RunLowStockAlertJob)
+	wishlists := NewWishlistService(store, mail)
+	pool.Register(SendBackInStockJob, wishlists.RunBackInStockJob)
+	guests := NewGuestService(store, jwtToken, mail)
+	pool.Register(SendOrderLookupJob, guests.RunOrderLookupJob)
+	pool.Register(SendAccountClaimJob, guests.RunAccountClaimJob)
+}
+
+// JobService provides business logic for inspecting the job queue.
+type JobService struct {
+	store db.Store
+}
+
+// NewJobService creates a new JobService instance.
+func NewJobService(store db.Store) *JobService {
+	return &JobService{
+		store: store,
+	}
+}
+
+// GetAllJob lists the latest jobs, optionally only those in a given status.
+func (s *JobService) GetAllJob(ctx context.Context, status string, limit int) ([]types.JobOutput, types.JobErrMessage, int, error) {
+	var errMessage types.JobErrMessage
+	if status != "" && !slices.Contains(jobStatuses, db.JobStatus(status)) {
+		errMessage.Status = "status must be PENDING, RUNNING, SUCCEEDED or DEAD"
+		return nil, errMessage, http.StatusBadRequest, nil
+	}
+	if limit < 1 || limit > 500 {
+		errMessage.Limit = "limit must be between 1 and 500"
+		return nil, errMessage, http.StatusBadRequest, nil
+	}
+	allJob, err := s.store.GetAllJob(ctx, db.GetAllJobParams{Status: status, PageSize: int32(limit)})
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	output := make([]types.JobOutput, len(allJob))
+	for i, job := range allJob {
+		output[i] = jobOutput(job)
+	}
+	return output, errMessage, http.StatusOK, nil
+}
+
+func (s *JobService) GetOneJob(ctx context.Context, jobId uuid.UUID) (types.JobOutput, types.JobErrMessage, int, error) {
+	var errMessage types.JobErrMessage
+	job, err := s.store.GetOneJob(ctx, jobId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "job not found"
+			return types.JobOutput{}, errMessage, http.StatusNotFound, err
+		}
+		return types.JobOutput{}, errMessage, http.StatusInternalServerError, err
+	}
+	return jobOutput(job), errMessage, http.StatusOK, nil
+}
+
+// GetJobStats counts the jobs in each status.
+func (s *JobService) GetJobStats(ctx context.Context) (map[db.JobStatus]int64, types.JobErrMessage, int, error) {
+	var errMessage types.JobErrMessage
+	rows, err := s.store.GetJobStats(ctx)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	stats := make(map[db.JobStatus]int64)
+	for _, status := range jobStatuses {
+		stats[status] = 0
+	}
+	for _, row := range rows {
+		stats[row.Status] = row.Count
+	}
+	return stats, errMessage, http.StatusOK, nil
+}
+
+// RetryJob gives a dead job a fresh set of attempts.
+func (s *JobService) RetryJob(ctx context.Context, jobId uuid.UUID) (types.JobOutput, types.JobErrMessage, int, error) {
+	var errMessage types.JobErrMessage
+	job, err := s.store.ResurrectJob(ctx, db.ResurrectJobParams{
+		ID:    jobId,
+		RunAt: pgtype.Timestamp{Time: time.Now().UTC(), Valid: true},
+	})
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) != err.Error() {
+			return types.JobOutput{}, errMessage, http.StatusInternalServerError, err
+		}
+		// Tell a missing job from one that is not dead
+		output, errMessage, statusCode, err := s.GetOneJob(ctx, jobId)
+		if err != nil {
+			return output, errMessage, statusCode, err
+		}
+		errMessage.Status = "only DEAD jobs can be retried"
+		return types.JobOutput{}, errMessage, http.StatusConflict, nil
+	}
+	return jobOutput(job), errMessage, http.StatusOK, nil
+}
+
+func jobOutput(job db.Job) types.JobOutput {
+	return types.JobOutput{
+		ID:          job.ID,
+		Kind:        job.Kind,
+		Payload:     job.Payload,
+		Status:      job.Status,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt,
+		LockedBy:    job.LockedBy,
+		LastError:   job.LastError,
+		CompletedAt: job.CompletedAt,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+	}
+}
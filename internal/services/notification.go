@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/constants"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/events"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/jobs"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/mailer"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/notification"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// statusNotifications maps the order statuses customers are emailed about
+// to their emails.
+var statusNotifications = map[db.OrderStatus]db.NotificationKind{
+	db.OrderStatusCANCELLED: db.NotificationKindORDERCANCELLED,
+	db.OrderStatusCOMPLETED: db.NotificationKindORDERCOMPLETED,
+	db.OrderStatusSHIPPED:   db.NotificationKindORDERSHIPPED,
+}
+
+// NotificationService provides business logic for the emails sent to customers.
+type NotificationService struct {
+	store  db.Store
+	mailer mailer.Mailer
+}
+
+// NewNotificationService creates a new NotificationService instance. The
+// emails are sent with mail, which is only needed to run the send jobs.
+func NewNotificationService(store db.Store, mail mailer.Mailer) *NotificationService {
+	return &NotificationService{
+		store:  store,
+		mailer: mail,
+	}
+}
+
+// defaultNotificationPreference is the preference of users who never set one.
+func defaultNotificationPreference(userId uuid.UUID) db.NotificationPreference {
+	return db.NotificationPreference{
+		UserId:         userId,
+		OrderPlaced:    true,
+		OrderCancelled: true,
+		OrderCompleted: true,
+		OrderShipped:   true,
+	}
+}
+
+func (s *NotificationService) getPreference(ctx context.Context, userId uuid.UUID) (db.NotificationPreference, error) {
+	preference, err := s.store.GetNotificationPreference(ctx, userId)
+	if err != nil && strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+		return defaultNotificationPreference(userId), nil
+	}
+	return preference, err
+}
+
+func (s *NotificationService) GetNotificationPreference(ctx context.Context) (db.NotificationPreference, int, error) {
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	preference, err := s.getPreference(ctx, userId)
+	if err != nil {
+		return preference, http.StatusInternalServerError, err
+	}
+	return preference, http.StatusOK, nil
+}
+
+func (s *NotificationService) UpdateNotificationPreference(ctx context.Context, input types.NotificationPreferenceInput) (db.NotificationPreference, int, error) {
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	preference, err := s.getPreference(ctx, userId)
+	if err != nil {
+		return preference, http.StatusInternalServerError, err
+	}
+	arg := db.UpsertNotificationPreferenceParams{
+		UserId:         userId,
+		OrderPlaced:    preference.OrderPlaced,
+		OrderCancelled: preference.OrderCancelled,
+		OrderCompleted: preference.OrderCompleted,
+		OrderShipped:   preference.OrderShipped,
+	}
+	if input.OrderPlaced != nil {
+		arg.OrderPlaced = *input.OrderPlaced
+	}
+	if input.OrderCancelled != nil {
+		arg.OrderCancelled = *input.OrderCancelled
+	}
+	if input.OrderCompleted != nil {
+		arg.OrderCompleted = *input.OrderCompleted
+	}
+	if input.OrderShipped != nil {
+		arg.OrderShipped = *input.OrderShipped
+	}
+	preference, err = s.store.UpsertNotificationPreference(ctx, arg)
+	if err != nil {
+		return preference, http.StatusInternalServerError, err
+	}
+	return preference, http.StatusOK, nil
+}
+
+// OnOrderCreated emails the customer who placed an order. Guests get the
+// order lookup email instead, sent by the GuestService.
+func (s *NotificationService) OnOrderCreated(ctx context.Context, event events.Envelope) error {
+	created, err := events.Decode[db.OrderCreated](event)
+	if err != nil {
+		return err
+	}
+	_, err = s.store.GetGuestOrder(ctx, created.Order.ID)
+	if err == nil {
+		return nil
+	}
+	if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) != err.Error() {
+		return err
+	}
+	return s.notify(ctx, event.ID, created.Order, db.NotificationKindORDERPLACED)
+}
+
+// OnOrderStatusChanged emails the customer when their order is cancelled,
+// completed or shipped.
+func (s *NotificationService) OnOrderStatusChanged(ctx context.Context, event events.Envelope) error {
+	changed, err := events.Decode[db.OrderStatusChanged](event)
+	if err != nil {
+		return err
+	}
+	kind, ok := statusNotifications[changed.Order.Status]
+	if !ok {
+		return nil
+	}
+	return s.notify(ctx, event.ID, changed.Order, kind)
+}
+
+// notify records an email about an order unless the customer turned it off.
+func (s *NotificationService) notify(ctx context.Context, eventId uuid.UUID, order db.Order, kind db.NotificationKind) error {
+	preference, err := s.getPreference(ctx, order.UserId)
+	if err != nil {
+		return err
+	}
+	if !wantsNotification(preference, kind) {
+		return nil
+	}
+	_, execErr, txErr := s.store.CreateNotificationTx(ctx, db.CreateNotificationTxParams{
+		UserId:  order.UserId,
+		OrderId: order.ID,
+		EventId: eventId,
+		Kind:    kind,
+	})
+	// The event was relayed before and the email already recorded
+	if execErr != nil && strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+		return nil
+	}
+	return utils.ConcatenateErrors(execErr, txErr)
+}
+
+func wantsNotification(preference db.NotificationPreference, kind db.NotificationKind) bool {
+	switch kind {
+	case db.NotificationKindORDERPLACED:
+		return preference.OrderPlaced
+	case db.NotificationKindORDERCANCELLED:
+		return preference.OrderCancelled
+	case db.NotificationKindORDERCOMPLETED:
+		return preference.OrderCompleted
+	case db.NotificationKindORDERSHIPPED:
+		return preference.OrderShipped
+	}
+	return false
+}
+
+// RunSendJob renders a notification and sends it. Failed attempts are
+// retried by the job queue until the last one, which leaves the notification
+// FAILED.
+func (s *NotificationService) RunSendJob(ctx context.Context, payload json.RawMessage) error {
+	var args db.SendNotificationArgs
+	if err := json.Unmarshal(payload, &args); err != nil {
+		return jobs.Permanent(err)
+	}
+	target, err := s.store.GetOneNotification(ctx, args.NotificationId)
+	if err != nil {
+		// The user or the order was deleted along with the notification
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			return nil
+		}
+		return err
+	}
+	if target.Status != db.NotificationStatusPENDING {
+		return nil
+	}
+	order, err := s.store.GetOrderById(ctx, target.OrderId)
+	if err != nil {
+		return err
+	}
+	items, err := s.store.GetOrderItemDetails(ctx, target.OrderId)
+	if err != nil {
+		return err
+	}
+	record := db.RecordNotificationAttemptParams{ID: target.ID, Status: db.NotificationStatusPENDING}
+	msg, err := notification.Render(target.Kind, target.Email, notification.OrderData{Order: order, Items: items})
+	var sendErr error
+	if err != nil {
+		// A template that fails to render fails every time
+		sendErr = jobs.Permanent(err)
+		record.Status = db.NotificationStatusFAILED
+	} else if sendErr = s.mailer.Send(ctx, msg); sendErr == nil {
+		record.Status = db.NotificationStatusSENT
+		record.SentAt = pgtype.Timestamp{Time: time.Now().UTC(), Valid: true}
+	} else if jobs.LastAttempt(ctx) {
+		record.Status = db.NotificationStatusFAILED
+	}
+	if sendErr != nil {
+		record.LastError = sendErr.Error()
+	}
+	if _, err = s.store.RecordNotificationAttempt(context.WithoutCancel(ctx), record); err != nil {
+		return utils.ConcatenateErrors(sendErr, err)
+	}
+	return sendErr
+}
@@ -3,11 +3,14 @@ package services
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"github.com/google/uuid"
 	"github.com/slamchillz/getinstashop-ecommerce-api/internal/constants"
 	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/fulfilment"
 	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
 	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
 	"log"
 	"net/http"
 	"strings"
@@ -32,51 +35,136 @@ func NewOrderService(store db.Store) *OrderService {
 	}
 }
 
-func (s *OrderService) CreateOrder(ctx context.Context, orderReq types.CreateOrderInput) (db.Order, types.OrderErrMessage, int, error) {
-	var productIds []uuid.UUID
-	var items = make(map[uuid.UUID]int32)
-	var order db.Order
+func (s *OrderService) CreateOrder(ctx context.Context, orderReq types.CreateOrderInput) (types.OrderOutput, types.OrderErrMessage, int, error) {
+	arg, errMessage := orderParams(ctx, orderReq)
+	log.Printf("Items: %+v", arg.Items)
+	if errMessage.Items != nil {
+		return types.OrderOutput{}, errMessage, http.StatusBadRequest, nil
+	}
+	order, orderErrMessage, execErr, txErr := s.store.CreateOrderTx(ctx, arg)
+	if len(orderErrMessage) > 0 {
+		errMessage.Items = orderErrMessage
+		return types.OrderOutput{Order: order}, errMessage, http.StatusBadRequest, nil
+	}
+	if execErr != nil || txErr != nil {
+		return types.OrderOutput{Order: order}, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	output, err := s.withBreakdown(ctx, []db.Order{order})
+	if err != nil {
+		return types.OrderOutput{Order: order}, errMessage, http.StatusInternalServerError, err
+	}
+	return output[0], errMessage, http.StatusCreated, nil
+}
+
+// PreviewOrder runs the validation and pricing of CreateOrder without placing
+// the order. Problems with the order are returned in the same shape.
+func (s *OrderService) PreviewOrder(ctx context.Context, orderReq types.CreateOrderInput) (db.OrderPreview, types.OrderErrMessage, int, error) {
+	arg, errMessage := orderParams(ctx, orderReq)
+	if errMessage.Items != nil {
+		return db.OrderPreview{}, errMessage, http.StatusBadRequest, nil
+	}
+	preview, orderErrMessage, err := s.store.PreviewOrder(ctx, arg)
+	if err != nil {
+		return preview, errMessage, http.StatusInternalServerError, err
+	}
+	if len(orderErrMessage) > 0 {
+		errMessage.Items = orderErrMessage
+		return preview, errMessage, http.StatusBadRequest, nil
+	}
+	return preview, errMessage, http.StatusOK, nil
+}
+
+// orderParams validates the order request and turns it into the parameters
+// the order is priced and created with.
+func orderParams(ctx context.Context, orderReq types.CreateOrderInput) (db.CreateOrderTxParams, types.OrderErrMessage) {
 	var errMessage types.OrderErrMessage
-	log.Printf("Items: %+v", items)
+	arg := db.CreateOrderTxParams{
+		ID:    uuid.New(),
+		Items: make(map[uuid.UUID]int32),
+	}
 	if len(orderReq.Items) <= 0 {
 		errMessage.Items = map[string]string{"productId": "must be a valid product id", "quantity": "must be greater than zero"}
-		return db.Order{}, errMessage, http.StatusBadRequest, nil
+		return arg, errMessage
 	}
 	for _, item := range orderReq.Items {
 		if item.Quantity <= 0 {
 			errMessage.Items = map[string]string{"productId": "must be a product id", "quantity": "must be greater than zero"}
-			return db.Order{}, errMessage, http.StatusBadRequest, nil
+			return arg, errMessage
 		}
 	}
-	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	arg.ShippingRegion = strings.ToUpper(strings.TrimSpace(orderReq.ShippingRegion))
+	if msg := validators.ValidateRegion(arg.ShippingRegion); msg != "" {
+		errMessage.Items = map[string]string{"shippingRegion": msg}
+		return arg, errMessage
+	}
+	if orderReq.ShippingMethodId != "" {
+		methodId, err := uuid.Parse(orderReq.ShippingMethodId)
+		if err != nil {
+			errMessage.Items = map[string]string{"shippingMethodId": "must be a valid shipping method id"}
+			return arg, errMessage
+		}
+		arg.ShippingMethodId = methodId
+	}
+	arg.UserId, _ = ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
 	for _, item := range orderReq.Items {
 		productId := utils.ParseStringToUUID(item.ProductId)
-		productIds = append(productIds, productId)
-		items[productId] = item.Quantity
-	}
-	order, orderErrMessage, execErr, txErr := s.store.CreateOrderTx(ctx, db.CreateOrderTxParams{
-		ID:         uuid.New(),
-		UserId:     userId,
-		ProductIds: productIds,
-		Items:      items,
-	})
-	if len(orderErrMessage) > 0 {
-		errMessage.Items = orderErrMessage
-		return order, errMessage, http.StatusBadRequest, nil
+		arg.ProductIds = append(arg.ProductIds, productId)
+		arg.Items[productId] = item.Quantity
 	}
-	if execErr != nil || txErr != nil {
-		return order, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
-	}
-	return order, errMessage, http.StatusCreated, nil
+	arg.CouponCode = strings.ToUpper(strings.TrimSpace(orderReq.CouponCode))
+	return arg, errMessage
 }
 
-func (s *OrderService) GetUserOrders(ctx context.Context) ([]db.Order, int, error) {
+func (s *OrderService) GetUserOrders(ctx context.Context) ([]types.OrderOutput, int, error) {
 	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
 	userOrders, err := s.store.GetAllOrderByUserId(ctx, userId)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
-	return userOrders, http.StatusOK, nil
+	output, err := s.withBreakdown(ctx, userOrders)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return output, http.StatusOK, nil
+}
+
+// withBreakdown attaches the per rate tax breakdown and the applied promotions
+// to each of the orders.
+func (s *OrderService) withBreakdown(ctx context.Context, orders []db.Order) ([]types.OrderOutput, error) {
+	output := make([]types.OrderOutput, len(orders))
+	if len(orders) == 0 {
+		return output, nil
+	}
+	orderIds := make([]uuid.UUID, len(orders))
+	for i, order := range orders {
+		orderIds[i] = order.ID
+	}
+	orderTaxes, err := s.store.GetOrderTaxByOrderIds(ctx, orderIds)
+	if err != nil {
+		return nil, err
+	}
+	taxes := make(map[uuid.UUID][]db.OrderTax)
+	for _, orderTax := range orderTaxes {
+		taxes[orderTax.OrderId] = append(taxes[orderTax.OrderId], orderTax)
+	}
+	orderPromotions, err := s.store.GetOrderPromotionsByOrderIds(ctx, orderIds)
+	if err != nil {
+		return nil, err
+	}
+	promotions := make(map[uuid.UUID][]db.OrderPromotion)
+	for _, orderPromotion := range orderPromotions {
+		promotions[orderPromotion.OrderId] = append(promotions[orderPromotion.OrderId], orderPromotion)
+	}
+	for i, order := range orders {
+		output[i] = types.OrderOutput{Order: order, Taxes: taxes[order.ID], Promotions: promotions[order.ID]}
+		if output[i].Taxes == nil {
+			output[i].Taxes = []db.OrderTax{}
+		}
+		if output[i].Promotions == nil {
+			output[i].Promotions = []db.OrderPromotion{}
+		}
+	}
+	return output, nil
 }
 
 func (s *OrderService) CancelOrder(ctx context.Context, orderId uuid.UUID) (db.Order, types.OrderErrMessage, int, error) {
@@ -91,6 +179,10 @@ func (s *OrderService) CancelOrder(ctx context.Context, orderId uuid.UUID) (db.O
 		Status: db.OrderStatusCANCELLED,
 	})
 	if err != nil {
+		if errors.Is(err, fulfilment.ErrNotCancellable) {
+			errMessage.ID = "only PENDING or BACKORDERED orders that have not shipped can be cancelled"
+			return order, errMessage, http.StatusConflict, err
+		}
 		errMessage.ID = "order not found or has already been cancelled"
 		return order, errMessage, http.StatusBadRequest, err
 	}
@@ -118,6 +210,18 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderId uuid.UUID,
 			errMessage.ID = "order id not found"
 			return order, errMessage, http.StatusNotFound, err
 		}
+		if errors.Is(err, fulfilment.ErrBackordered) {
+			errMessage.Status = "order is backordered, it can only be cancelled until restocked"
+			return order, errMessage, http.StatusBadRequest, err
+		}
+		if errors.Is(err, fulfilment.ErrNotCancellable) {
+			errMessage.Status = "only PENDING, COMPLETED or BACKORDERED orders that have not shipped can be cancelled"
+			return order, errMessage, http.StatusConflict, err
+		}
+		if errors.Is(err, fulfilment.ErrStatusChange) {
+			errMessage.Status = "only PENDING orders can be moved to COMPLETED"
+			return order, errMessage, http.StatusConflict, err
+		}
 		return order, errMessage, http.StatusInternalServerError, err
 	}
 	return order, errMessage, http.StatusOK, nil
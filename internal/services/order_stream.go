@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/constants"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/orderstream"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"net/http"
+	"strconv"
+)
+
+// orderStreamPageSize is the number of missed changes read at a time on resume.
+const orderStreamPageSize = 100
+
+// OrderStreamService provides business logic for streaming order status changes.
+type OrderStreamService struct {
+	store  db.Store
+	broker *orderstream.Broker
+}
+
+// NewOrderStreamService creates a new OrderStreamService instance.
+func NewOrderStreamService(store db.Store, broker *orderstream.Broker) *OrderStreamService {
+	return &OrderStreamService{
+		store:  store,
+		broker: broker,
+	}
+}
+
+// Subscribe subscribes the user to the status changes of their orders. When
+// lastEventId is given, the changes after it are returned to be sent first,
+// starting orderstream.CatchUpOverlap ids earlier for the changes that
+// committed after it. They can overlap with the changes the client received
+// and with the first changes of the subscription.
+func (s *OrderStreamService) Subscribe(ctx context.Context, lastEventId string) (*orderstream.Subscription, []db.OrderStatusEvent, types.OrderErrMessage, int, error) {
+	var errMessage types.OrderErrMessage
+	var afterId int64
+	if lastEventId != "" {
+		var err error
+		afterId, err = strconv.ParseInt(lastEventId, 10, 64)
+		if err != nil || afterId < 0 {
+			errMessage.LastEventId = "Last-Event-ID must be the id of a received event"
+			return nil, nil, errMessage, http.StatusBadRequest, errors.New("invalid last event id")
+		}
+	}
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	sub, err := s.broker.Subscribe(ctx, userId)
+	if err != nil {
+		return nil, nil, errMessage, http.StatusServiceUnavailable, err
+	}
+	var missed []db.OrderStatusEvent
+	if lastEventId != "" {
+		afterId = max(afterId-orderstream.CatchUpOverlap, 0)
+		for {
+			events, err := s.store.GetOrderStatusEventsAfter(ctx, db.GetOrderStatusEventsAfterParams{
+				UserId:   userId,
+				AfterId:  afterId,
+				PageSize: orderStreamPageSize,
+			})
+			if err != nil {
+				sub.Close()
+				return nil, nil, errMessage, http.StatusInternalServerError, err
+			}
+			missed = append(missed, events...)
+			if len(events) < orderStreamPageSize {
+				break
+			}
+			afterId = events[len(events)-1].ID
+		}
+	}
+	return sub, missed, errMessage, http.StatusOK, nil
+}
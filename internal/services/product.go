@@ -6,86 +6,165 @@ import (
 	"errors"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/slamchillz/getinstashop-ecommerce-api/internal/constants"
 	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/fulfilment"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/storage"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/tax"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/thumbnail"
 	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
 	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
-	"log"
 	"math"
 	"net/http"
 	"strings"
 )
 
+// skuConstraint is the unique index keeping product skus unique.
+const skuConstraint = "idx_product_sku"
+
 // ProductService provides business logic for product operations.
 type ProductService struct {
-	store db.Store
+	store      db.Store
+	blobs      storage.BlobStore
+	thumbnails *thumbnail.Generator
 }
 
-// NewProductService creates a new ProductService instance.
-func NewProductService(store db.Store) *ProductService {
+// NewProductService creates a new ProductService instance. Product images are
+// kept in blobs and thumbnails of them made by thumbnails.
+func NewProductService(store db.Store, blobs storage.BlobStore, thumbnails *thumbnail.Generator) *ProductService {
 	return &ProductService{
-		store: store,
+		store:      store,
+		blobs:      blobs,
+		thumbnails: thumbnails,
 	}
 }
 
 func (s *ProductService) CreateProduct(ctx context.Context, product types.CreateProductInput) (types.ProductOutput, types.ProductErrMessage, int, error) {
+	if product.TaxClass == "" {
+		product.TaxClass = tax.DefaultClass
+	}
+	product.BackorderPolicy = strings.ToUpper(strings.TrimSpace(product.BackorderPolicy))
 	errMessage, err := validators.ValidateProduct(product)
 	if err != nil {
 		return types.ProductOutput{}, errMessage, http.StatusBadRequest, err
 	}
+	reorderThreshold := db.DefaultReorderThreshold
+	if product.ReorderThreshold != nil {
+		reorderThreshold = int32(*product.ReorderThreshold)
+	}
+	backorderPolicy := db.BackorderPolicyNONE
+	if product.BackorderPolicy != "" {
+		backorderPolicy = db.BackorderPolicy(product.BackorderPolicy)
+	}
+	var availableAt pgtype.Timestamp
+	if product.AvailableAt != nil && backorderPolicy != db.BackorderPolicyNONE {
+		availableAt = pgtype.Timestamp{Time: product.AvailableAt.UTC(), Valid: true}
+	}
 	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
-	newProduct, err := s.store.CreateProduct(ctx, db.CreateProductParams{
-		ID:          uuid.New(),
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       math.Round(product.Price*100) / 100,
-		Stock:       int32(product.Stock),
-		CreatedBy:   userId,
+	newProduct, execErr, txErr := s.store.CreateProductTx(ctx, db.CreateProductParams{
+		ID:               uuid.New(),
+		Name:             product.Name,
+		Description:      product.Description,
+		Price:            math.Round(product.Price*100) / 100,
+		Stock:            int32(product.Stock),
+		Category:         product.Category,
+		TaxClass:         product.TaxClass,
+		Weight:           product.Weight,
+		CreatedBy:        userId,
+		Sku:              product.Sku,
+		ReorderThreshold: reorderThreshold,
+		BackorderPolicy:  backorderPolicy,
+		AvailableAt:      availableAt,
 	})
-	log.Print(newProduct, userId, err)
-	if err != nil {
+	if execErr != nil || txErr != nil {
+		err = utils.ConcatenateErrors(execErr, txErr)
 		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
+		if errors.As(execErr, &pgErr) {
 			if pgErr.Code == "23505" {
-				errMessage.Name = "product already exists"
+				if pgErr.ConstraintName == skuConstraint {
+					errMessage.Sku = "sku already exists"
+				} else {
+					errMessage.Name = "product already exists"
+				}
 				return types.ProductOutput{}, errMessage, http.StatusBadRequest, err
 			}
 		}
+		if errors.Is(execErr, fulfilment.ErrNoWarehouse) {
+			errMessage.Stock = "no warehouse is active to hold the stock"
+			return types.ProductOutput{}, errMessage, http.StatusBadRequest, err
+		}
 		return types.ProductOutput{}, errMessage, http.StatusInternalServerError, err
 	}
 	return types.ProductOutput{
-		ID:          newProduct.ID,
-		Name:        newProduct.Name,
-		Description: newProduct.Description,
-		Price:       newProduct.Price,
-		Stock:       newProduct.Stock,
-		CreatedBy:   newProduct.CreatedBy,
-		CreatedAt:   newProduct.CreatedAt,
-		UpdatedAt:   newProduct.UpdatedAt,
+		GetAllProductRow: db.GetAllProductRow{
+			ID:               newProduct.ID,
+			Name:             newProduct.Name,
+			Description:      newProduct.Description,
+			Price:            newProduct.Price,
+			Stock:            newProduct.Stock,
+			Category:         newProduct.Category,
+			TaxClass:         newProduct.TaxClass,
+			Weight:           newProduct.Weight,
+			Sku:              newProduct.Sku,
+			ReorderThreshold: newProduct.ReorderThreshold,
+			BackorderPolicy:  newProduct.BackorderPolicy,
+			AvailableAt:      newProduct.AvailableAt,
+			DeletedAt:        newProduct.DeletedAt,
+			Version:          newProduct.Version,
+			CreatedBy:        newProduct.CreatedBy,
+			CreatedAt:        newProduct.CreatedAt,
+			UpdatedAt:        newProduct.UpdatedAt,
+		},
+		Images: []types.ProductImageOutput{},
 	}, errMessage, http.StatusCreated, nil
 }
 
-func (s *ProductService) GetAllProduct(ctx context.Context) ([]types.ProductOutput, types.ProductErrMessage, int, error) {
+// GetAllProduct lists the products in the catalog, the best rated first when
+// sort is rating.
+func (s *ProductService) GetAllProduct(ctx context.Context, sort string) ([]types.ProductOutput, types.ProductErrMessage, int, error) {
 	var errMessage types.ProductErrMessage
-	allProduct, err := s.store.GetAllProduct(ctx)
+	if msg := validators.ValidateProductSort(sort); msg != "" {
+		errMessage.Sort = msg
+		return nil, errMessage, http.StatusBadRequest, errors.New(msg)
+	}
+	allProduct, err := s.store.GetAllProduct(ctx, sort)
 	if err != nil {
 		return nil, errMessage, http.StatusInternalServerError, err
 	}
-	// Convert GetAllProductRow slice to CreateProductOutput slice
+	// Convert GetAllProductRow slice to ProductOutput slice
 	var allProductOutput []types.ProductOutput
 	for _, product := range allProduct {
 		productOutput := types.ProductOutput{
-			ID:          product.ID,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-			Stock:       product.Stock,
-			CreatedAt:   product.CreatedAt,
-			UpdatedAt:   product.UpdatedAt,
-			CreatedBy:   product.CreatedBy,
+			GetAllProductRow: db.GetAllProductRow{
+				ID:               product.ID,
+				Name:             product.Name,
+				Description:      product.Description,
+				Price:            product.Price,
+				Stock:            product.Stock,
+				Category:         product.Category,
+				TaxClass:         product.TaxClass,
+				Weight:           product.Weight,
+				Sku:              product.Sku,
+				ReorderThreshold: product.ReorderThreshold,
+				BackorderPolicy:  product.BackorderPolicy,
+				AvailableAt:      product.AvailableAt,
+				DeletedAt:        product.DeletedAt,
+				Version:          product.Version,
+				RatingAverage:    product.RatingAverage,
+				RatingCount:      product.RatingCount,
+				CreatedAt:        product.CreatedAt,
+				UpdatedAt:        product.UpdatedAt,
+				CreatedBy:        product.CreatedBy,
+			},
 		}
 		allProductOutput = append(allProductOutput, productOutput)
 	}
+	allProductOutput, err = s.withImages(ctx, allProductOutput)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
 	// Return the converted slice along with the status code
 	return allProductOutput, errMessage, http.StatusOK, nil
 }
@@ -96,39 +175,87 @@ func (s *ProductService) GetOneProduct(ctx context.Context, productId uuid.UUID)
 	if err != nil {
 		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
 			errMessage.ID = "product not found"
-			return types.ProductOutput(product), errMessage, http.StatusNotFound, err
+			return types.ProductOutput{}, errMessage, http.StatusNotFound, err
 		}
-		return types.ProductOutput(product), errMessage, http.StatusInternalServerError, err
+		return types.ProductOutput{}, errMessage, http.StatusInternalServerError, err
 	}
-	return types.ProductOutput(product), errMessage, http.StatusOK, nil
+	output, err := s.withImages(ctx, []types.ProductOutput{{GetAllProductRow: db.GetAllProductRow(product)}})
+	if err != nil {
+		return types.ProductOutput{}, errMessage, http.StatusInternalServerError, err
+	}
+	return output[0], errMessage, http.StatusOK, nil
 }
 
 func (s *ProductService) DeleteOneProduct(ctx context.Context, productId uuid.UUID) (types.ProductOutput, types.ProductErrMessage, int, error) {
 	var product types.ProductOutput
 	var errMessage types.ProductErrMessage
-	err := s.store.DeleteOneProduct(ctx, productId)
-	if err != nil {
-		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+	_, execErr, txErr := s.store.DeleteProductTx(ctx, productId)
+	if execErr != nil || txErr != nil {
+		if execErr != nil && strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
 			errMessage.ID = "product not found"
-			return product, errMessage, http.StatusNotFound, err
+			return product, errMessage, http.StatusNotFound, execErr
 		}
-		return product, errMessage, http.StatusInternalServerError, err
+		return product, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
 	}
 	return product, errMessage, http.StatusNoContent, nil
 }
 
-func (s *ProductService) UpdateOneProduct(ctx context.Context, productId uuid.UUID, product types.ProductUpdateInput) (db.Product, types.ProductErrMessage, int, error) {
+// RestoreProduct puts an archived product back in the catalog.
+func (s *ProductService) RestoreProduct(ctx context.Context, productId uuid.UUID) (db.Product, types.ProductErrMessage, int, error) {
+	var errMessage types.ProductErrMessage
+	product, execErr, txErr := s.store.RestoreProductTx(ctx, productId)
+	if execErr != nil || txErr != nil {
+		if execErr != nil && strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+			errMessage.ID = "product not found or not archived"
+			return product, errMessage, http.StatusNotFound, execErr
+		}
+		return product, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	return product, errMessage, http.StatusOK, nil
+}
+
+// UpdateOneProduct updates a product, provided it is still at version when
+// version is set.
+func (s *ProductService) UpdateOneProduct(ctx context.Context, productId uuid.UUID, product types.ProductUpdateInput, version *int32) (db.Product, types.ProductErrMessage, int, error) {
 	var updatedProduct db.Product
+	if product.BackorderPolicy != nil {
+		policy := strings.ToUpper(strings.TrimSpace(*product.BackorderPolicy))
+		product.BackorderPolicy = &policy
+	}
 	errMessage, err := validators.ValidateProductUpdateInput(product)
 	if err != nil {
 		return updatedProduct, errMessage, http.StatusBadRequest, err
 	}
+	var stockNote string
+	if product.StockNote != nil {
+		stockNote = *product.StockNote
+	}
+	var backorderPolicy *db.BackorderPolicy
+	if product.BackorderPolicy != nil {
+		policy := db.BackorderPolicy(*product.BackorderPolicy)
+		backorderPolicy = &policy
+	}
+	var availableAt *pgtype.Timestamp
+	if product.AvailableAt != nil {
+		availableAt = &pgtype.Timestamp{Time: product.AvailableAt.UTC(), Valid: true}
+	}
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
 	updatedProduct, execErr, txErr := s.store.UpdateProductTx(ctx, db.UpdateProductTxParams{
-		ID:          productId,
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-		Stock:       product.Stock,
+		ID:               productId,
+		Name:             product.Name,
+		Description:      product.Description,
+		Price:            product.Price,
+		Stock:            product.Stock,
+		Category:         product.Category,
+		TaxClass:         product.TaxClass,
+		Weight:           product.Weight,
+		Sku:              product.Sku,
+		ReorderThreshold: product.ReorderThreshold,
+		StockNote:        stockNote,
+		BackorderPolicy:  backorderPolicy,
+		AvailableAt:      availableAt,
+		UpdatedBy:        userId,
+		Version:          version,
 	})
 	if execErr != nil || txErr != nil {
 		if execErr != nil {
@@ -137,6 +264,29 @@ func (s *ProductService) UpdateOneProduct(ctx context.Context, productId uuid.UU
 					ID: "product not found",
 				}, http.StatusNotFound, err
 			}
+			var pgErr *pgconn.PgError
+			if errors.As(execErr, &pgErr) && pgErr.Code == "23505" {
+				if pgErr.ConstraintName == skuConstraint {
+					errMessage.Sku = "sku already exists"
+				} else {
+					errMessage.Name = "product already exists"
+				}
+				return updatedProduct, errMessage, http.StatusBadRequest, execErr
+			}
+			if errors.Is(execErr, db.ErrProductVersionMismatch) {
+				errMessage.Version = "product was changed since it was fetched, fetch it again"
+				return updatedProduct, errMessage, http.StatusPreconditionFailed, execErr
+			}
+			// Stock is added to the primary warehouse and taken off the
+			// active warehouses
+			switch {
+			case errors.Is(execErr, fulfilment.ErrNoWarehouse):
+				errMessage.Stock = "no warehouse is active to hold the stock"
+				return updatedProduct, errMessage, http.StatusBadRequest, execErr
+			case errors.Is(execErr, fulfilment.ErrNotEnoughStock):
+				errMessage.Stock = "stock cannot be lowered by more than the active warehouses hold"
+				return updatedProduct, errMessage, http.StatusBadRequest, execErr
+			}
 		}
 		return updatedProduct, errMessage, http.StatusInternalServerError, err
 	}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/constants"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/storage"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/thumbnail"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// UploadProductImage stores an image of a product in the blob store. The
+// content type is detected from the file itself rather than trusted from the
+// request.
+func (s *ProductService) UploadProductImage(ctx context.Context, productId uuid.UUID, file io.Reader, size int64, primary bool) (types.ProductImageOutput, types.ProductImageErrMessage, int, error) {
+	var errMessage types.ProductImageErrMessage
+	if msg := validators.ValidateImageSize(size); msg != "" {
+		errMessage.Image = msg
+		return types.ProductImageOutput{}, errMessage, http.StatusBadRequest, nil
+	}
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		errMessage.Image = "unable to read image"
+		return types.ProductImageOutput{}, errMessage, http.StatusBadRequest, err
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	if msg := validators.ValidateImageContentType(contentType); msg != "" {
+		errMessage.Image = msg
+		return types.ProductImageOutput{}, errMessage, http.StatusBadRequest, nil
+	}
+	imageId := uuid.New()
+	key := fmt.Sprintf("products/%s/%s%s", productId, imageId, validators.ImageExtensions[contentType])
+	// The file is stored first so that an image is never listed without it.
+	err = s.blobs.Put(ctx, key, contentType, io.MultiReader(bytes.NewReader(head), file), size)
+	if err != nil {
+		return types.ProductImageOutput{}, errMessage, http.StatusInternalServerError, err
+	}
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	image, invalidFields, execErr, txErr := s.store.CreateProductImageTx(ctx, db.CreateProductImageTxParams{
+		ID:          imageId,
+		ProductId:   productId,
+		Key:         key,
+		ContentType: contentType,
+		Size:        size,
+		Primary:     primary,
+		CreatedBy:   userId,
+	})
+	if len(invalidFields) > 0 || execErr != nil || txErr != nil {
+		if err = s.blobs.Delete(ctx, key); err != nil {
+			log.Printf("Error while deleting unused image %s: %v", key, err)
+		}
+	}
+	if len(invalidFields) > 0 {
+		errMessage.Image = invalidFields["image"]
+		return types.ProductImageOutput{}, errMessage, http.StatusBadRequest, nil
+	}
+	if execErr != nil || txErr != nil {
+		if execErr != nil {
+			if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+				errMessage.ID = "product not found"
+				return types.ProductImageOutput{}, errMessage, http.StatusNotFound, execErr
+			}
+		}
+		return types.ProductImageOutput{}, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	if s.thumbnails.OnUpload() {
+		// Thumbnails can still be made on the first request for them, so a
+		// failure here does not fail the upload.
+		if err = s.thumbnails.Generate(ctx, image.ID, image.Key, image.ContentType); err != nil {
+			log.Printf("Error while generating thumbnails of image %s: %v", image.ID, err)
+		}
+	}
+	return s.imageOutput(image), errMessage, http.StatusCreated, nil
+}
+
+// ReorderProductImages changes the display order and the primary image of a
+// product's images.
+func (s *ProductService) ReorderProductImages(ctx context.Context, productId uuid.UUID, input types.ProductImageOrderInput) ([]types.ProductImageOutput, types.ProductImageErrMessage, int, error) {
+	var errMessage types.ProductImageErrMessage
+	arg := db.ReorderProductImageTxParams{ProductId: productId}
+	for _, id := range input.ImageIds {
+		imageId, err := uuid.Parse(id)
+		if err != nil {
+			errMessage.ImageIds = "must list each image of the product once"
+			return nil, errMessage, http.StatusBadRequest, nil
+		}
+		arg.ImageIds = append(arg.ImageIds, imageId)
+	}
+	if input.PrimaryImageId != "" {
+		primaryImageId, err := uuid.Parse(input.PrimaryImageId)
+		if err != nil {
+			errMessage.PrimaryImageId = "image not found"
+			return nil, errMessage, http.StatusBadRequest, nil
+		}
+		arg.PrimaryImageId = primaryImageId
+	}
+	images, invalidFields, execErr, txErr := s.store.ReorderProductImageTx(ctx, arg)
+	if len(invalidFields) > 0 {
+		errMessage.ImageIds = invalidFields["imageIds"]
+		errMessage.PrimaryImageId = invalidFields["primaryImageId"]
+		return nil, errMessage, http.StatusBadRequest, nil
+	}
+	if execErr != nil || txErr != nil {
+		if execErr != nil {
+			if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+				errMessage.ID = "product not found"
+				return nil, errMessage, http.StatusNotFound, execErr
+			}
+		}
+		return nil, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	output := make([]types.ProductImageOutput, len(images))
+	for i, image := range images {
+		output[i] = s.imageOutput(image)
+	}
+	return output, errMessage, http.StatusOK, nil
+}
+
+// DeleteProductImage removes an image from a product and the blob store.
+func (s *ProductService) DeleteProductImage(ctx context.Context, productId uuid.UUID, imageId uuid.UUID) (types.ProductImageErrMessage, int, error) {
+	var errMessage types.ProductImageErrMessage
+	image, execErr, txErr := s.store.DeleteProductImageTx(ctx, productId, imageId)
+	if execErr != nil || txErr != nil {
+		if execErr != nil {
+			if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+				errMessage.ID = "image not found"
+				return errMessage, http.StatusNotFound, execErr
+			}
+		}
+		return errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	// The image is already gone from the product, a leftover file only wastes space.
+	if err := s.blobs.Delete(ctx, image.Key); err != nil {
+		log.Printf("Error while deleting image %s: %v", image.Key, err)
+	}
+	if err := s.thumbnails.Delete(ctx, image.ID, image.ContentType); err != nil {
+		log.Printf("Error while deleting thumbnails of image %s: %v", image.ID, err)
+	}
+	return errMessage, http.StatusNoContent, nil
+}
+
+// GetProductImageThumbnail opens a thumbnail of an image, generating it on the
+// first request for it.
+func (s *ProductService) GetProductImageThumbnail(ctx context.Context, imageId uuid.UUID, size string, format string) (io.ReadCloser, storage.Object, types.ProductImageErrMessage, int, error) {
+	var errMessage types.ProductImageErrMessage
+	image, err := s.store.GetOneProductImage(ctx, imageId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "image not found"
+			return nil, storage.Object{}, errMessage, http.StatusNotFound, err
+		}
+		return nil, storage.Object{}, errMessage, http.StatusInternalServerError, err
+	}
+	body, object, err := s.thumbnails.Get(ctx, image.ID, image.Key, image.ContentType, size, format)
+	if err != nil {
+		if errors.Is(err, thumbnail.ErrUnknownSize) || errors.Is(err, thumbnail.ErrUnknownFormat) {
+			errMessage.Image = "thumbnail not found"
+			return nil, storage.Object{}, errMessage, http.StatusNotFound, err
+		}
+		if errors.Is(err, storage.ErrNotFound) {
+			errMessage.Image = "image not found"
+			return nil, storage.Object{}, errMessage, http.StatusNotFound, err
+		}
+		return nil, storage.Object{}, errMessage, http.StatusInternalServerError, err
+	}
+	return body, object, errMessage, http.StatusOK, nil
+}
+
+// withImages attaches the images of each product, primary image first.
+func (s *ProductService) withImages(ctx context.Context, products []types.ProductOutput) ([]types.ProductOutput, error) {
+	if len(products) == 0 {
+		return products, nil
+	}
+	productIds := make([]uuid.UUID, len(products))
+	for i, product := range products {
+		productIds[i] = product.ID
+	}
+	images, err := s.store.GetProductImageByProductIds(ctx, productIds)
+	if err != nil {
+		return nil, err
+	}
+	byProduct := make(map[uuid.UUID][]types.ProductImageOutput)
+	for _, image := range images {
+		output := s.imageOutput(image)
+		if image.IsPrimary {
+			byProduct[image.ProductId] = append([]types.ProductImageOutput{output}, byProduct[image.ProductId]...)
+			continue
+		}
+		byProduct[image.ProductId] = append(byProduct[image.ProductId], output)
+	}
+	for i := range products {
+		products[i].Images = byProduct[products[i].ID]
+		if products[i].Images == nil {
+			products[i].Images = []types.ProductImageOutput{}
+		}
+	}
+	return products, nil
+}
+
+func (s *ProductService) imageOutput(image db.ProductImage) types.ProductImageOutput {
+	return types.ProductImageOutput{
+		ID:          image.ID,
+		ProductId:   image.ProductId,
+		URL:         s.blobs.URL(image.Key),
+		ContentType: image.ContentType,
+		Size:        image.Size,
+		Position:    image.Position,
+		IsPrimary:   image.IsPrimary,
+		Thumbnails:  s.thumbnailOutput(image),
+		CreatedAt:   image.CreatedAt,
+	}
+}
+
+// thumbnailOutput links to the thumbnails of an image. They are served by the
+// API rather than the blob store, as they may not have been generated yet.
+func (s *ProductService) thumbnailOutput(image db.ProductImage) []types.ThumbnailOutput {
+	format := thumbnail.Formats(image.ContentType)[0]
+	output := make([]types.ThumbnailOutput, 0, len(s.thumbnails.Sizes()))
+	for _, size := range s.thumbnails.Sizes() {
+		output = append(output, types.ThumbnailOutput{
+			Size:         size.Name,
+			MaxDimension: size.MaxDimension,
+			URL:          fmt.Sprintf("/api/v1/images/%s/%s.%s", image.ID, size.Name, format),
+			WebpURL:      fmt.Sprintf("/api/v1/images/%s/%s.%s", image.ID, size.Name, thumbnail.WebP),
+		})
+	}
+	return output
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/catalog"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/constants"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/jobs"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/tax"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+)
+
+// MaxImportRows is the number of products a single import can hold.
+const MaxImportRows = 5000
+
+// exportPageSize is the number of products fetched at a time while exporting.
+const exportPageSize = 500
+
+// ImportProducts creates or updates the products of a CSV or JSON Lines file.
+// Every row is validated before anything is saved, and the rows are saved in
+// a single transaction. A dry run reports what the import would do without
+// saving it, and an async import is saved by a background job.
+func (s *ProductService) ImportProducts(ctx context.Context, file io.Reader, format string, dryRun bool, async bool) (types.ProductImportOutput, types.ProductImportErrMessage, int, error) {
+	var errMessage types.ProductImportErrMessage
+	rows, err := catalog.Read(file, format, MaxImportRows)
+	if err != nil {
+		errMessage.File = err.Error()
+		if err == catalog.ErrTooManyRows {
+			errMessage.File = fmt.Sprintf("file has more than %d products", MaxImportRows)
+		}
+		return types.ProductImportOutput{}, errMessage, http.StatusBadRequest, err
+	}
+	if len(rows) == 0 {
+		errMessage.File = "file has no products"
+		return types.ProductImportOutput{}, errMessage, http.StatusBadRequest, errors.New("empty import")
+	}
+	arg := db.ImportProductTxParams{DryRun: dryRun}
+	arg.CreatedBy, _ = ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	names := make(map[string]int)
+	skus := make(map[string]int)
+	for _, row := range rows {
+		product := row.Product
+		if product.TaxClass == "" {
+			product.TaxClass = tax.DefaultClass
+		}
+		rowErr, _ := validators.ValidateProduct(product)
+		mergeProductErrMessage(&rowErr, row.Errors)
+		// A product may only appear once, later rows would silently overwrite it
+		if line, ok := names[product.Name]; ok && rowErr.Name == "" {
+			rowErr.Name = fmt.Sprintf("product already appears on line %d", line)
+		}
+		if line, ok := skus[product.Sku]; ok && product.Sku != "" && rowErr.Sku == "" {
+			rowErr.Sku = fmt.Sprintf("sku already appears on line %d", line)
+		}
+		names[product.Name] = row.Line
+		skus[product.Sku] = row.Line
+		if rowErr != (types.ProductErrMessage{}) {
+			errMessage.Rows = append(errMessage.Rows, types.ProductImportRowError{Line: row.Line, Error: rowErr})
+			continue
+		}
+		arg.Rows = append(arg.Rows, db.ImportProductRow{
+			Name:        product.Name,
+			Description: product.Description,
+			Price:       math.Round(product.Price*100) / 100,
+			Stock:       int32(product.Stock),
+			Category:    product.Category,
+			TaxClass:    product.TaxClass,
+			Weight:      product.Weight,
+			Sku:         product.Sku,
+		})
+	}
+	if len(errMessage.Rows) > 0 {
+		return types.ProductImportOutput{}, errMessage, http.StatusBadRequest, fmt.Errorf("%d invalid rows", len(errMessage.Rows))
+	}
+	if async && !dryRun {
+		job, err := jobs.Enqueue(ctx, s.store, jobs.EnqueueParams{Kind: ImportProductsJob, Payload: arg})
+		if err != nil {
+			return types.ProductImportOutput{}, errMessage, http.StatusInternalServerError, err
+		}
+		return types.ProductImportOutput{JobId: &job.ID, Products: []types.ProductImportResult{}}, errMessage, http.StatusAccepted, nil
+	}
+	products, invalidRows, execErr, txErr := s.store.ImportProductTx(ctx, arg)
+	if len(invalidRows) > 0 {
+		for i, row := range rows {
+			if fields, ok := invalidRows[i]; ok {
+				errMessage.Rows = append(errMessage.Rows, types.ProductImportRowError{
+					Line:  row.Line,
+					Error: types.ProductErrMessage{Name: fields["name"], Sku: fields["sku"], Stock: fields["stock"]},
+				})
+			}
+		}
+		return types.ProductImportOutput{}, errMessage, http.StatusBadRequest, fmt.Errorf("%d rejected rows", len(errMessage.Rows))
+	}
+	if execErr != nil || txErr != nil {
+		return types.ProductImportOutput{}, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	output := types.ProductImportOutput{DryRun: dryRun, Products: make([]types.ProductImportResult, len(products))}
+	for i, product := range products {
+		result := types.ProductImportResult{Line: rows[i].Line, Name: product.Name, Sku: product.Sku}
+		if product.Created {
+			output.Created++
+			result.Action = "created"
+		} else {
+			output.Updated++
+			result.Action = "updated"
+		}
+		if !dryRun || !product.Created {
+			id := product.ID
+			result.ID = &id
+		}
+		output.Products[i] = result
+	}
+	return output, errMessage, http.StatusOK, nil
+}
+
+// RunImportJob saves an import that was checked and queued by ImportProducts.
+func (s *ProductService) RunImportJob(ctx context.Context, payload json.RawMessage) error {
+	var arg db.ImportProductTxParams
+	if err := json.Unmarshal(payload, &arg); err != nil {
+		return jobs.Permanent(err)
+	}
+	_, invalidRows, execErr, txErr := s.store.ImportProductTx(ctx, arg)
+	if len(invalidRows) > 0 {
+		// The catalog changed after the file was checked, running the same
+		// import again cannot succeed.
+		var conflicts []string
+		for i, row := range arg.Rows {
+			for _, msg := range invalidRows[i] {
+				conflicts = append(conflicts, fmt.Sprintf("%s: %s", row.Name, msg))
+			}
+		}
+		return jobs.Permanent(fmt.Errorf("import rejected, %s", strings.Join(conflicts, "; ")))
+	}
+	return utils.ConcatenateErrors(execErr, txErr)
+}
+
+// ExportProducts writes the whole catalog to w, a page of products at a
+// time, so that large catalogs are never held in memory.
+func (s *ProductService) ExportProducts(ctx context.Context, w io.Writer, format string) error {
+	writer, err := catalog.NewWriter(w, format)
+	if err != nil {
+		return err
+	}
+	var afterId uuid.UUID
+	for {
+		products, err := s.store.GetProductPage(ctx, db.GetProductPageParams{AfterId: afterId, PageSize: exportPageSize})
+		if err != nil {
+			return err
+		}
+		for _, product := range products {
+			if err = writer.Write(db.GetAllProductRow(product)); err != nil {
+				return err
+			}
+		}
+		if err = writer.Flush(); err != nil {
+			return err
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		if len(products) < exportPageSize {
+			return nil
+		}
+		afterId = products[len(products)-1].ID
+	}
+}
+
+// mergeProductErrMessage keeps the fields that could not be read over the
+// validation messages about their zero value.
+func mergeProductErrMessage(errMessage *types.ProductErrMessage, readErr types.ProductErrMessage) {
+	if readErr.Price != "" {
+		errMessage.Price = readErr.Price
+	}
+	if readErr.Stock != "" {
+		errMessage.Stock = readErr.Stock
+	}
+	if readErr.Weight != "" {
+		errMessage.Weight = readErr.Weight
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/constants"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/jobs"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ScheduleProductPrice adds a price to the history of a product, in effect
+// from input.EffectiveFrom, or now, until input.EffectiveTo, or for good. The
+// latest started of the prices in effect is the price of the product.
+func (s *ProductService) ScheduleProductPrice(ctx context.Context, productId uuid.UUID, input types.ProductPriceInput) (db.ScheduleProductPriceTxResult, types.ProductPriceErrMessage, int, error) {
+	var result db.ScheduleProductPriceTxResult
+	now := time.Now().UTC()
+	if input.EffectiveFrom == nil {
+		input.EffectiveFrom = &now
+	}
+	errMessage, err := validators.ValidateProductPrice(input, now)
+	if err != nil {
+		return result, errMessage, http.StatusBadRequest, err
+	}
+	arg := db.CreateProductPriceParams{
+		ID:            uuid.New(),
+		ProductId:     productId,
+		Price:         math.Round(input.Price*100) / 100,
+		EffectiveFrom: pgtype.Timestamp{Time: input.EffectiveFrom.UTC(), Valid: true},
+	}
+	if input.CompareAtPrice != nil {
+		arg.CompareAtPrice = pgtype.Float8{Float64: math.Round(*input.CompareAtPrice*100) / 100, Valid: true}
+	}
+	if input.EffectiveTo != nil {
+		arg.EffectiveTo = pgtype.Timestamp{Time: input.EffectiveTo.UTC(), Valid: true}
+	}
+	if userId, ok := ctx.Value(constants.ContextUserIdKey).(uuid.UUID); ok {
+		arg.CreatedBy = pgtype.UUID{Bytes: userId, Valid: true}
+	}
+	result, execErr, txErr := s.store.ScheduleProductPriceTx(ctx, arg)
+	if execErr != nil || txErr != nil {
+		if execErr != nil && strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+			errMessage.ProductId = "product not found"
+			return result, errMessage, http.StatusNotFound, execErr
+		}
+		return result, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	return result, errMessage, http.StatusCreated, nil
+}
+
+// GetProductPrices lists the price history of a product, the latest to come
+// into effect first, along with its scheduled and cancelled prices.
+func (s *ProductService) GetProductPrices(ctx context.Context, productId uuid.UUID, limit int) ([]db.ProductPrice, types.ProductPriceErrMessage, int, error) {
+	var errMessage types.ProductPriceErrMessage
+	if limit < 1 || limit > 500 {
+		errMessage.Limit = "limit must be between 1 and 500"
+		return nil, errMessage, http.StatusBadRequest, nil
+	}
+	_, err := s.store.GetOneProduct(ctx, productId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ProductId = "product not found"
+			return nil, errMessage, http.StatusNotFound, err
+		}
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	prices, err := s.store.GetProductPrices(ctx, db.GetProductPricesParams{
+		ProductId: productId,
+		PageSize:  int32(limit),
+	})
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return prices, errMessage, http.StatusOK, nil
+}
+
+// CancelProductPrice cancels a price of a product that has not gone out of
+// effect. The product goes back to the price in effect without it.
+func (s *ProductService) CancelProductPrice(ctx context.Context, productId, priceId uuid.UUID) (db.ScheduleProductPriceTxResult, types.ProductPriceErrMessage, int, error) {
+	var errMessage types.ProductPriceErrMessage
+	result, execErr, txErr := s.store.CancelProductPriceTx(ctx, db.CancelProductPriceParams{
+		ID:        priceId,
+		ProductId: productId,
+	})
+	if execErr != nil || txErr != nil {
+		if execErr != nil && strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+			errMessage.ID = "price not found, cancelled or no longer in effect"
+			return result, errMessage, http.StatusNotFound, execErr
+		}
+		return result, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	return result, errMessage, http.StatusOK, nil
+}
+
+// RunApplyPriceJob brings the price of a product in line with its price
+// history when a scheduled price comes into or goes out of effect.
+func (s *ProductService) RunApplyPriceJob(ctx context.Context, payload json.RawMessage) error {
+	var args db.ApplyProductPriceArgs
+	if err := json.Unmarshal(payload, &args); err != nil {
+		return jobs.Permanent(err)
+	}
+	_, execErr, txErr := s.store.ApplyProductPriceTx(ctx, args.ProductId)
+	// The product was deleted along with its prices
+	if execErr != nil && strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+		return nil
+	}
+	return utils.ConcatenateErrors(execErr, txErr)
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/constants"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/promotion"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
+	"math"
+	"net/http"
+	"strings"
+)
+
+// PromotionService provides business logic for promotion operations.
+type PromotionService struct {
+	store db.Store
+}
+
+// NewPromotionService creates a new PromotionService instance.
+func NewPromotionService(store db.Store) *PromotionService {
+	return &PromotionService{
+		store: store,
+	}
+}
+
+func (s *PromotionService) CreatePromotion(ctx context.Context, input types.CreatePromotionInput) (db.Promotion, types.PromotionErrMessage, int, error) {
+	input = normalisePromotion(input)
+	errMessage, err := validators.ValidatePromotion(input)
+	if err != nil {
+		return db.Promotion{}, errMessage, http.StatusBadRequest, err
+	}
+	active := true
+	if input.Active != nil {
+		active = *input.Active
+	}
+	tiers, err := json.Marshal(input.Tiers)
+	if err != nil {
+		return db.Promotion{}, errMessage, http.StatusInternalServerError, err
+	}
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	newPromotion, err := s.store.CreatePromotion(ctx, db.CreatePromotionParams{
+		ID:          uuid.New(),
+		Name:        input.Name,
+		Kind:        db.PromotionKind(input.Kind),
+		Priority:    input.Priority,
+		Stackable:   input.Stackable,
+		ProductIds:  input.ProductIds,
+		Categories:  input.Categories,
+		BuyQuantity: input.BuyQuantity,
+		GetQuantity: input.GetQuantity,
+		Percent:     input.Percent,
+		BundlePrice: input.BundlePrice,
+		Tiers:       tiers,
+		StartsAt:    toTimestamp(input.StartsAt),
+		ExpiresAt:   toTimestamp(input.ExpiresAt),
+		Active:      active,
+		CreatedBy:   userId,
+	})
+	if err != nil {
+		return db.Promotion{}, errMessage, http.StatusInternalServerError, err
+	}
+	return newPromotion, errMessage, http.StatusCreated, nil
+}
+
+func (s *PromotionService) GetAllPromotion(ctx context.Context) ([]db.Promotion, types.PromotionErrMessage, int, error) {
+	var errMessage types.PromotionErrMessage
+	promotions, err := s.store.GetAllPromotion(ctx)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return promotions, errMessage, http.StatusOK, nil
+}
+
+func (s *PromotionService) GetOnePromotion(ctx context.Context, promotionId uuid.UUID) (db.Promotion, types.PromotionErrMessage, int, error) {
+	var errMessage types.PromotionErrMessage
+	current, err := s.store.GetOnePromotion(ctx, promotionId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "promotion not found"
+			return current, errMessage, http.StatusNotFound, err
+		}
+		return current, errMessage, http.StatusInternalServerError, err
+	}
+	return current, errMessage, http.StatusOK, nil
+}
+
+// UpdateOnePromotion changes the fields present in the input. The promotion
+// is validated as a whole afterwards, as the fields it uses depend on its kind.
+func (s *PromotionService) UpdateOnePromotion(ctx context.Context, promotionId uuid.UUID, input types.PromotionUpdateInput) (db.Promotion, types.PromotionErrMessage, int, error) {
+	current, errMessage, statusCode, err := s.GetOnePromotion(ctx, promotionId)
+	if err != nil {
+		return current, errMessage, statusCode, err
+	}
+	merged := types.CreatePromotionInput{
+		Name:        current.Name,
+		Kind:        string(current.Kind),
+		Priority:    current.Priority,
+		Stackable:   current.Stackable,
+		ProductIds:  current.ProductIds,
+		Categories:  current.Categories,
+		BuyQuantity: current.BuyQuantity,
+		GetQuantity: current.GetQuantity,
+		Percent:     current.Percent,
+		BundlePrice: current.BundlePrice,
+		Active:      &current.Active,
+	}
+	if err = json.Unmarshal(current.Tiers, &merged.Tiers); err != nil {
+		return current, errMessage, http.StatusInternalServerError, err
+	}
+	if current.StartsAt.Valid {
+		merged.StartsAt = &current.StartsAt.Time
+	}
+	if current.ExpiresAt.Valid {
+		merged.ExpiresAt = &current.ExpiresAt.Time
+	}
+	if input.Name != nil {
+		merged.Name = *input.Name
+	}
+	if input.Kind != nil {
+		merged.Kind = *input.Kind
+	}
+	if input.Priority != nil {
+		merged.Priority = *input.Priority
+	}
+	if input.Stackable != nil {
+		merged.Stackable = *input.Stackable
+	}
+	if input.ProductIds != nil {
+		merged.ProductIds = *input.ProductIds
+	}
+	if input.Categories != nil {
+		merged.Categories = *input.Categories
+	}
+	if input.BuyQuantity != nil {
+		merged.BuyQuantity = *input.BuyQuantity
+	}
+	if input.GetQuantity != nil {
+		merged.GetQuantity = *input.GetQuantity
+	}
+	if input.Percent != nil {
+		merged.Percent = *input.Percent
+	}
+	if input.BundlePrice != nil {
+		merged.BundlePrice = *input.BundlePrice
+	}
+	if input.Tiers != nil {
+		merged.Tiers = *input.Tiers
+	}
+	if input.StartsAt != nil {
+		merged.StartsAt = input.StartsAt
+	}
+	if input.ExpiresAt != nil {
+		merged.ExpiresAt = input.ExpiresAt
+	}
+	if input.Active != nil {
+		merged.Active = input.Active
+	}
+	merged = normalisePromotion(merged)
+	errMessage, err = validators.ValidatePromotion(merged)
+	if err != nil {
+		return current, errMessage, http.StatusBadRequest, err
+	}
+	tiers, err := json.Marshal(merged.Tiers)
+	if err != nil {
+		return current, errMessage, http.StatusInternalServerError, err
+	}
+	updatedPromotion, err := s.store.UpdateOnePromotion(ctx, db.UpdateOnePromotionParams{
+		ID:          promotionId,
+		Name:        merged.Name,
+		Kind:        db.PromotionKind(merged.Kind),
+		Priority:    merged.Priority,
+		Stackable:   merged.Stackable,
+		ProductIds:  merged.ProductIds,
+		Categories:  merged.Categories,
+		BuyQuantity: merged.BuyQuantity,
+		GetQuantity: merged.GetQuantity,
+		Percent:     merged.Percent,
+		BundlePrice: merged.BundlePrice,
+		Tiers:       tiers,
+		StartsAt:    toTimestamp(merged.StartsAt),
+		ExpiresAt:   toTimestamp(merged.ExpiresAt),
+		Active:      *merged.Active,
+	})
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "promotion not found"
+			return updatedPromotion, errMessage, http.StatusNotFound, err
+		}
+		return updatedPromotion, errMessage, http.StatusInternalServerError, err
+	}
+	return updatedPromotion, errMessage, http.StatusOK, nil
+}
+
+func (s *PromotionService) DeleteOnePromotion(ctx context.Context, promotionId uuid.UUID) (db.Promotion, types.PromotionErrMessage, int, error) {
+	var errMessage types.PromotionErrMessage
+	deleted, err := s.store.DeleteOnePromotion(ctx, promotionId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "promotion not found"
+			return deleted, errMessage, http.StatusNotFound, err
+		}
+		return deleted, errMessage, http.StatusInternalServerError, err
+	}
+	return deleted, errMessage, http.StatusNoContent, nil
+}
+
+// normalisePromotion upper cases the kind, rounds the amounts to cents and
+// gives a buy X get Y promotion without a percentage its Y units for free.
+func normalisePromotion(input types.CreatePromotionInput) types.CreatePromotionInput {
+	input.Name = strings.TrimSpace(input.Name)
+	input.Kind = strings.ToUpper(strings.TrimSpace(input.Kind))
+	if input.Kind == promotion.BuyXGetY && input.Percent == 0 {
+		input.Percent = 100
+	}
+	input.Percent = math.Round(input.Percent*100) / 100
+	input.BundlePrice = math.Round(input.BundlePrice*100) / 100
+	if input.ProductIds == nil {
+		input.ProductIds = []uuid.UUID{}
+	}
+	if input.Categories == nil {
+		input.Categories = []string{}
+	}
+	if input.Tiers == nil {
+		input.Tiers = []promotion.Tier{}
+	}
+	return input
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/constants"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/rma"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// refundClaimTimeout is how long a return stays claimed by a refund that was
+// interrupted before it can be refunded again.
+const refundClaimTimeout = 15 * time.Minute
+
+// ReturnService provides business logic for returns (RMA).
+type ReturnService struct {
+	store    db.Store
+	refunder rma.Refunder
+}
+
+// NewReturnService creates a new ReturnService instance. Received returns are
+// refunded through the refunder.
+func NewReturnService(store db.Store, refunder rma.Refunder) *ReturnService {
+	return &ReturnService{
+		store:    store,
+		refunder: refunder,
+	}
+}
+
+func (s *ReturnService) CreateReturn(ctx context.Context, orderId uuid.UUID, input types.CreateReturnInput) (types.ReturnOutput, types.ReturnErrMessage, int, error) {
+	var errMessage types.ReturnErrMessage
+	input.Note = strings.TrimSpace(input.Note)
+	if msg := validators.ValidateReturnNote(input.Note); msg != "" {
+		errMessage.Note = msg
+		return types.ReturnOutput{}, errMessage, http.StatusBadRequest, nil
+	}
+	if len(input.Items) <= 0 {
+		errMessage.Items = map[string]string{"productId": "must be a valid product id", "quantity": "must be greater than zero"}
+		return types.ReturnOutput{}, errMessage, http.StatusBadRequest, nil
+	}
+	items := make(map[uuid.UUID]db.ReturnItemTxParams)
+	for _, item := range input.Items {
+		productId, err := uuid.Parse(item.ProductId)
+		if err != nil || item.Quantity <= 0 {
+			errMessage.Items = map[string]string{"productId": "must be a valid product id", "quantity": "must be greater than zero"}
+			return types.ReturnOutput{}, errMessage, http.StatusBadRequest, nil
+		}
+		reason := strings.TrimSpace(item.Reason)
+		if msg := validators.ValidateReturnReason(reason); msg != "" {
+			errMessage.Items = map[string]string{productId.String(): msg}
+			return types.ReturnOutput{}, errMessage, http.StatusBadRequest, nil
+		}
+		if _, ok := items[productId]; ok {
+			errMessage.Items = map[string]string{productId.String(): "product listed more than once"}
+			return types.ReturnOutput{}, errMessage, http.StatusBadRequest, nil
+		}
+		items[productId] = db.ReturnItemTxParams{Quantity: item.Quantity, Reason: reason}
+	}
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	result, invalidItems, execErr, txErr := s.store.CreateReturnTx(ctx, db.CreateReturnTxParams{
+		ID:      uuid.New(),
+		OrderId: orderId,
+		UserId:  userId,
+		Items:   items,
+		Note:    input.Note,
+	})
+	if len(invalidItems) > 0 {
+		if msg, ok := invalidItems["status"]; ok {
+			errMessage.Status = msg
+		} else {
+			errMessage.Items = invalidItems
+		}
+		return types.ReturnOutput{}, errMessage, http.StatusBadRequest, nil
+	}
+	if execErr != nil || txErr != nil {
+		if execErr != nil {
+			if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+				errMessage.ID = "order not found"
+				return types.ReturnOutput{}, errMessage, http.StatusNotFound, execErr
+			}
+		}
+		return types.ReturnOutput{}, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	return returnOutput(result.Return, result.Items, result.History), errMessage, http.StatusCreated, nil
+}
+
+// GetOrderReturns lists the returns of an order. Customers can only see the
+// returns of their own orders.
+func (s *ReturnService) GetOrderReturns(ctx context.Context, orderId uuid.UUID) ([]types.ReturnOutput, types.ReturnErrMessage, int, error) {
+	var errMessage types.ReturnErrMessage
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	admin, _ := ctx.Value(constants.ContextUserAdminStatusKey).(bool)
+	order, err := s.store.GetOrderById(ctx, orderId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "order not found"
+			return nil, errMessage, http.StatusNotFound, err
+		}
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	if order.UserId != userId && !admin {
+		errMessage.ID = "order not found"
+		return nil, errMessage, http.StatusNotFound, sql.ErrNoRows
+	}
+	returns, err := s.store.GetReturnRequestByOrderId(ctx, orderId)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	output, err := s.withDetails(ctx, returns)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return output, errMessage, http.StatusOK, nil
+}
+
+func (s *ReturnService) GetAllReturn(ctx context.Context) ([]types.ReturnOutput, types.ReturnErrMessage, int, error) {
+	var errMessage types.ReturnErrMessage
+	returns, err := s.store.GetAllReturnRequest(ctx)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	output, err := s.withDetails(ctx, returns)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return output, errMessage, http.StatusOK, nil
+}
+
+// UpdateReturnStatus moves a return to the given state. Returns are refunded
+// as soon as they are received; when the refund fails the return stays
+// RECEIVED and the refund can be retried with RefundReturn.
+func (s *ReturnService) UpdateReturnStatus(ctx context.Context, returnId uuid.UUID, status db.ReturnStatus, input types.ReturnActionInput) (types.ReturnOutput, types.ReturnErrMessage, int, error) {
+	var errMessage types.ReturnErrMessage
+	input.Note = strings.TrimSpace(input.Note)
+	if msg := validators.ValidateReturnNote(input.Note); msg != "" {
+		errMessage.Note = msg
+		return types.ReturnOutput{}, errMessage, http.StatusBadRequest, errors.New(msg)
+	}
+	output, errMessage, statusCode, err := s.transition(ctx, db.UpdateReturnStatusTxParams{
+		ID:     returnId,
+		Status: status,
+		Note:   input.Note,
+	})
+	if err != nil || status != db.ReturnStatusRECEIVED {
+		return output, errMessage, statusCode, err
+	}
+	refunded, _, _, err := s.RefundReturn(ctx, returnId, types.ReturnActionInput{})
+	if err != nil {
+		log.Printf("Error while refunding return %s: %v", returnId, err)
+		return output, errMessage, http.StatusOK, nil
+	}
+	return refunded, errMessage, http.StatusOK, nil
+}
+
+// RefundReturn refunds a RECEIVED return through the refunder. The return is
+// claimed as REFUNDING first, so concurrent refunds of a return issue a single
+// refund, and released back to RECEIVED when the refund fails. A claim left
+// by an interrupted refund can be taken again after refundClaimTimeout.
+func (s *ReturnService) RefundReturn(ctx context.Context, returnId uuid.UUID, input types.ReturnActionInput) (types.ReturnOutput, types.ReturnErrMessage, int, error) {
+	claimed, errMessage, statusCode, err := s.transition(ctx, db.UpdateReturnStatusTxParams{
+		ID:            returnId,
+		Status:        db.ReturnStatusREFUNDING,
+		ReclaimBefore: time.Now().UTC().Add(-refundClaimTimeout),
+	})
+	if err != nil {
+		return claimed, errMessage, statusCode, err
+	}
+	reference, err := s.refunder.Refund(ctx, rma.Refund{
+		ReturnID:       claimed.ID,
+		OrderID:        claimed.OrderId,
+		UserID:         claimed.UserId,
+		Amount:         claimed.RefundAmount,
+		IdempotencyKey: claimed.ID.String(),
+	})
+	if err != nil {
+		_, _, _, releaseErr := s.transition(ctx, db.UpdateReturnStatusTxParams{
+			ID:      returnId,
+			Release: true,
+			Note:    "refund failed",
+		})
+		if releaseErr != nil {
+			log.Printf("Error while releasing return %s after a failed refund: %v", returnId, releaseErr)
+		}
+		errMessage.Refund = "refund failed, retry later"
+		return types.ReturnOutput{}, errMessage, http.StatusBadGateway, err
+	}
+	return s.transition(ctx, db.UpdateReturnStatusTxParams{
+		ID:              returnId,
+		Status:          db.ReturnStatusREFUNDED,
+		Note:            strings.TrimSpace(input.Note),
+		RefundReference: reference,
+	})
+}
+
+func (s *ReturnService) transition(ctx context.Context, arg db.UpdateReturnStatusTxParams) (types.ReturnOutput, types.ReturnErrMessage, int, error) {
+	var errMessage types.ReturnErrMessage
+	arg.ActorId, _ = ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	result, execErr, txErr := s.store.UpdateReturnStatusTx(ctx, arg)
+	if execErr != nil || txErr != nil {
+		if execErr != nil {
+			if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+				errMessage.ID = "return not found"
+				return types.ReturnOutput{}, errMessage, http.StatusNotFound, execErr
+			}
+			if errors.Is(execErr, rma.ErrInvalidTransition) {
+				errMessage.Status = execErr.Error()
+				return types.ReturnOutput{}, errMessage, http.StatusConflict, execErr
+			}
+		}
+		return types.ReturnOutput{}, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	return returnOutput(result.Return, result.Items, result.History), errMessage, http.StatusOK, nil
+}
+
+// withDetails attaches the items and history to each of the returns.
+func (s *ReturnService) withDetails(ctx context.Context, returns []db.ReturnRequest) ([]types.ReturnOutput, error) {
+	output := make([]types.ReturnOutput, len(returns))
+	if len(returns) == 0 {
+		return output, nil
+	}
+	returnIds := make([]uuid.UUID, len(returns))
+	for i, returnRequest := range returns {
+		returnIds[i] = returnRequest.ID
+	}
+	returnItems, err := s.store.GetReturnItemByReturnIds(ctx, returnIds)
+	if err != nil {
+		return nil, err
+	}
+	returnEvents, err := s.store.GetReturnEventByReturnIds(ctx, returnIds)
+	if err != nil {
+		return nil, err
+	}
+	items := make(map[uuid.UUID][]db.ReturnItem)
+	for _, item := range returnItems {
+		items[item.ReturnId] = append(items[item.ReturnId], item)
+	}
+	events := make(map[uuid.UUID][]db.ReturnEvent)
+	for _, event := range returnEvents {
+		events[event.ReturnId] = append(events[event.ReturnId], event)
+	}
+	for i, returnRequest := range returns {
+		output[i] = returnOutput(returnRequest, items[returnRequest.ID], events[returnRequest.ID])
+	}
+	return output, nil
+}
+
+func returnOutput(returnRequest db.ReturnRequest, items []db.ReturnItem, events []db.ReturnEvent) types.ReturnOutput {
+	if items == nil {
+		items = []db.ReturnItem{}
+	}
+	history := make([]types.ReturnEventOutput, len(events))
+	for i, event := range events {
+		history[i] = types.ReturnEventOutput{
+			ID:        event.ID,
+			ReturnId:  event.ReturnId,
+			ToStatus:  event.ToStatus,
+			Note:      event.Note,
+			ActorId:   event.ActorId,
+			CreatedAt: event.CreatedAt,
+		}
+		if event.FromStatus.Valid {
+			history[i].FromStatus = string(event.FromStatus.ReturnStatus)
+		}
+	}
+	return types.ReturnOutput{ReturnRequest: returnRequest, Items: items, History: history}
+}
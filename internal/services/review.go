@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/constants"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
+	"net/http"
+	"strings"
+)
+
+// ReviewService provides business logic for product reviews.
+type ReviewService struct {
+	store db.Store
+}
+
+// NewReviewService creates a new ReviewService instance.
+func NewReviewService(store db.Store) *ReviewService {
+	return &ReviewService{
+		store: store,
+	}
+}
+
+// CreateReview records the review of a product by the user. Only customers
+// who bought the product in a completed order can review it, once. The review
+// is shown after an admin approves it.
+func (s *ReviewService) CreateReview(ctx context.Context, productId uuid.UUID, input types.CreateReviewInput) (db.ProductReview, types.ReviewErrMessage, int, error) {
+	var review db.ProductReview
+	input.Title = strings.TrimSpace(input.Title)
+	input.Body = strings.TrimSpace(input.Body)
+	errMessage, err := validators.ValidateReview(input)
+	if err != nil {
+		return review, errMessage, http.StatusBadRequest, err
+	}
+	product, err := s.store.GetOneProduct(ctx, productId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ProductId = "product not found"
+			return review, errMessage, http.StatusNotFound, err
+		}
+		return review, errMessage, http.StatusInternalServerError, err
+	}
+	if product.DeletedAt.Valid {
+		errMessage.ProductId = "product not found"
+		return review, errMessage, http.StatusNotFound, errors.New(errMessage.ProductId)
+	}
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	purchased, err := s.store.HasPurchasedProduct(ctx, db.HasPurchasedProductParams{
+		UserId:    userId,
+		ProductId: productId,
+	})
+	if err != nil {
+		return review, errMessage, http.StatusInternalServerError, err
+	}
+	if !purchased {
+		errMessage.ProductId = "only customers with a completed order of the product can review it"
+		return review, errMessage, http.StatusForbidden, errors.New(errMessage.ProductId)
+	}
+	review, err = s.store.CreateProductReview(ctx, db.CreateProductReviewParams{
+		ID:        uuid.New(),
+		ProductId: productId,
+		UserId:    userId,
+		Rating:    input.Rating,
+		Title:     input.Title,
+		Body:      input.Body,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			errMessage.ProductId = "you have already reviewed this product"
+			return review, errMessage, http.StatusConflict, err
+		}
+		return review, errMessage, http.StatusInternalServerError, err
+	}
+	return review, errMessage, http.StatusCreated, nil
+}
+
+// GetProductReviews lists the approved reviews of a product, newest first.
+func (s *ReviewService) GetProductReviews(ctx context.Context, productId uuid.UUID, limit int) ([]db.ProductReview, types.ReviewErrMessage, int, error) {
+	var errMessage types.ReviewErrMessage
+	if limit < 1 || limit > 100 {
+		errMessage.Limit = "limit must be between 1 and 100"
+		return nil, errMessage, http.StatusBadRequest, errors.New(errMessage.Limit)
+	}
+	reviews, err := s.store.GetProductReviews(ctx, db.GetProductReviewsParams{
+		ProductId: productId,
+		PageSize:  int32(limit),
+	})
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return reviews, errMessage, http.StatusOK, nil
+}
+
+// GetAllReview lists the latest reviews in the given moderation state, or in
+// any state when status is empty.
+func (s *ReviewService) GetAllReview(ctx context.Context, status string, limit int) ([]db.ProductReview, types.ReviewErrMessage, int, error) {
+	var errMessage types.ReviewErrMessage
+	status = strings.ToUpper(strings.TrimSpace(status))
+	if status != "" {
+		errMessage.Status = validators.ValidateReviewStatus(status)
+	}
+	if limit < 1 || limit > 500 {
+		errMessage.Limit = "limit must be between 1 and 500"
+	}
+	if errMessage != (types.ReviewErrMessage{}) {
+		return nil, errMessage, http.StatusBadRequest, errors.New("invalid review query")
+	}
+	reviews, err := s.store.GetAllProductReview(ctx, db.GetAllProductReviewParams{
+		Status:   db.NullReviewStatus{ReviewStatus: db.ReviewStatus(status), Valid: status != ""},
+		PageSize: int32(limit),
+	})
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return reviews, errMessage, http.StatusOK, nil
+}
+
+// ModerateReview approves or rejects a review. Only approved reviews are
+// shown and counted in the rating of the product.
+func (s *ReviewService) ModerateReview(ctx context.Context, reviewId uuid.UUID, status db.ReviewStatus) (db.ProductReview, types.ReviewErrMessage, int, error) {
+	var errMessage types.ReviewErrMessage
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	review, err := s.store.UpdateProductReviewStatus(ctx, db.UpdateProductReviewStatusParams{
+		ID:          reviewId,
+		Status:      status,
+		ModeratedBy: pgtype.UUID{Bytes: userId, Valid: true},
+	})
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "review not found"
+			return review, errMessage, http.StatusNotFound, err
+		}
+		return review, errMessage, http.StatusInternalServerError, err
+	}
+	return review, errMessage, http.StatusOK, nil
+}
+
+func (s *ReviewService) DeleteReview(ctx context.Context, reviewId uuid.UUID) (db.ProductReview, types.ReviewErrMessage, int, error) {
+	var errMessage types.ReviewErrMessage
+	review, err := s.store.DeleteProductReview(ctx, reviewId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "review not found"
+			return review, errMessage, http.StatusNotFound, err
+		}
+		return review, errMessage, http.StatusInternalServerError, err
+	}
+	return review, errMessage, http.StatusNoContent, nil
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/constants"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
+	"net/http"
+	"strings"
+)
+
+// ShipmentService provides business logic for order fulfilment.
+type ShipmentService struct {
+	store db.Store
+}
+
+// NewShipmentService creates a new ShipmentService instance.
+func NewShipmentService(store db.Store) *ShipmentService {
+	return &ShipmentService{
+		store: store,
+	}
+}
+
+func (s *ShipmentService) CreateShipment(ctx context.Context, orderId uuid.UUID, input types.CreateShipmentInput) (types.ShipmentOutput, types.ShipmentErrMessage, int, error) {
+	input.Carrier = strings.TrimSpace(input.Carrier)
+	input.TrackingNumber = strings.TrimSpace(input.TrackingNumber)
+	errMessage, err := validators.ValidateShipment(input)
+	if err != nil {
+		return types.ShipmentOutput{}, errMessage, http.StatusBadRequest, err
+	}
+	items := make(map[uuid.UUID]int32)
+	for _, item := range input.Items {
+		productId, err := uuid.Parse(item.ProductId)
+		if err != nil || item.Quantity <= 0 {
+			errMessage.Items = map[string]string{"productId": "must be a valid product id", "quantity": "must be greater than zero"}
+			return types.ShipmentOutput{}, errMessage, http.StatusBadRequest, nil
+		}
+		items[productId] += item.Quantity
+	}
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	result, invalidItems, execErr, txErr := s.store.CreateShipmentTx(ctx, db.CreateShipmentTxParams{
+		ID:             uuid.New(),
+		OrderId:        orderId,
+		Carrier:        input.Carrier,
+		TrackingNumber: input.TrackingNumber,
+		Items:          items,
+		CreatedBy:      userId,
+	})
+	if len(invalidItems) > 0 {
+		if msg, ok := invalidItems["status"]; ok {
+			errMessage.Status = msg
+		} else {
+			errMessage.Items = invalidItems
+		}
+		return types.ShipmentOutput{}, errMessage, http.StatusBadRequest, nil
+	}
+	if execErr != nil || txErr != nil {
+		if execErr != nil {
+			if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+				errMessage.ID = "order not found"
+				return types.ShipmentOutput{}, errMessage, http.StatusNotFound, execErr
+			}
+		}
+		return types.ShipmentOutput{}, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	return shipmentOutput(result.Shipment, result.Items), errMessage, http.StatusCreated, nil
+}
+
+func (s *ShipmentService) UpdateOneShipment(ctx context.Context, shipmentId uuid.UUID, input types.ShipmentUpdateInput) (types.ShipmentOutput, types.ShipmentErrMessage, int, error) {
+	if input.Carrier != nil {
+		carrier := strings.TrimSpace(*input.Carrier)
+		input.Carrier = &carrier
+	}
+	if input.TrackingNumber != nil {
+		trackingNumber := strings.TrimSpace(*input.TrackingNumber)
+		input.TrackingNumber = &trackingNumber
+	}
+	errMessage, err := validators.ValidateShipmentUpdateInput(input)
+	if err != nil {
+		return types.ShipmentOutput{}, errMessage, http.StatusBadRequest, err
+	}
+	result, execErr, txErr := s.store.UpdateShipmentTx(ctx, db.UpdateShipmentTxParams{
+		ID:             shipmentId,
+		Carrier:        input.Carrier,
+		TrackingNumber: input.TrackingNumber,
+		Delivered:      input.Delivered,
+	})
+	if execErr != nil || txErr != nil {
+		if execErr != nil {
+			if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+				errMessage.ID = "shipment not found"
+				return types.ShipmentOutput{}, errMessage, http.StatusNotFound, execErr
+			}
+		}
+		return types.ShipmentOutput{}, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	return shipmentOutput(result.Shipment, result.Items), errMessage, http.StatusOK, nil
+}
+
+// GetOrderShipments lists the shipments of an order. Customers can only see
+// the shipments of their own orders.
+func (s *ShipmentService) GetOrderShipments(ctx context.Context, orderId uuid.UUID) ([]types.ShipmentOutput, types.ShipmentErrMessage, int, error) {
+	var errMessage types.ShipmentErrMessage
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	admin, _ := ctx.Value(constants.ContextUserAdminStatusKey).(bool)
+	order, err := s.store.GetOrderById(ctx, orderId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "order not found"
+			return nil, errMessage, http.StatusNotFound, err
+		}
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	if order.UserId != userId && !admin {
+		errMessage.ID = "order not found"
+		return nil, errMessage, http.StatusNotFound, sql.ErrNoRows
+	}
+	shipments, err := s.store.GetShipmentByOrderId(ctx, orderId)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	output := make([]types.ShipmentOutput, len(shipments))
+	if len(shipments) == 0 {
+		return output, errMessage, http.StatusOK, nil
+	}
+	shipmentIds := make([]uuid.UUID, len(shipments))
+	for i, shipment := range shipments {
+		shipmentIds[i] = shipment.ID
+	}
+	shipmentItems, err := s.store.GetShipmentItemByShipmentIds(ctx, shipmentIds)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	items := make(map[uuid.UUID][]db.ShipmentItem)
+	for _, item := range shipmentItems {
+		items[item.ShipmentId] = append(items[item.ShipmentId], item)
+	}
+	for i, shipment := range shipments {
+		output[i] = shipmentOutput(shipment, items[shipment.ID])
+	}
+	return output, errMessage, http.StatusOK, nil
+}
+
+func shipmentOutput(shipment db.Shipment, items []db.ShipmentItem) types.ShipmentOutput {
+	if items == nil {
+		items = []db.ShipmentItem{}
+	}
+	return types.ShipmentOutput{Shipment: shipment, Items: items}
+}
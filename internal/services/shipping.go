@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
+	"math"
+	"net/http"
+	"strings"
+)
+
+// ShippingService provides business logic for shipping zones, methods and quotes.
+type ShippingService struct {
+	store db.Store
+}
+
+// NewShippingService creates a new ShippingService instance.
+func NewShippingService(store db.Store) *ShippingService {
+	return &ShippingService{
+		store: store,
+	}
+}
+
+func (s *ShippingService) CreateShippingZone(ctx context.Context, input types.CreateShippingZoneInput) (db.ShippingZone, types.ShippingZoneErrMessage, int, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	input.Regions = normalizeRegions(input.Regions)
+	errMessage, err := validators.ValidateShippingZone(input)
+	if err != nil {
+		return db.ShippingZone{}, errMessage, http.StatusBadRequest, err
+	}
+	zone, err := s.store.CreateShippingZone(ctx, db.CreateShippingZoneParams{
+		ID:      uuid.New(),
+		Name:    input.Name,
+		Regions: input.Regions,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				errMessage.Name = "shipping zone already exists"
+				return db.ShippingZone{}, errMessage, http.StatusBadRequest, err
+			}
+		}
+		return db.ShippingZone{}, errMessage, http.StatusInternalServerError, err
+	}
+	return zone, errMessage, http.StatusCreated, nil
+}
+
+func (s *ShippingService) GetAllShippingZone(ctx context.Context) ([]db.ShippingZone, types.ShippingZoneErrMessage, int, error) {
+	var errMessage types.ShippingZoneErrMessage
+	zones, err := s.store.GetAllShippingZone(ctx)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return zones, errMessage, http.StatusOK, nil
+}
+
+func (s *ShippingService) UpdateOneShippingZone(ctx context.Context, zoneId uuid.UUID, input types.ShippingZoneUpdateInput) (db.ShippingZone, types.ShippingZoneErrMessage, int, error) {
+	var zone db.ShippingZone
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		input.Name = &name
+	}
+	if input.Regions != nil {
+		regions := normalizeRegions(*input.Regions)
+		input.Regions = &regions
+	}
+	errMessage, err := validators.ValidateShippingZoneUpdateInput(input)
+	if err != nil {
+		return zone, errMessage, http.StatusBadRequest, err
+	}
+	zone, execErr, txErr := s.store.UpdateShippingZoneTx(ctx, db.UpdateShippingZoneTxParams{
+		ID:      zoneId,
+		Name:    input.Name,
+		Regions: input.Regions,
+	})
+	if execErr != nil || txErr != nil {
+		if execErr != nil {
+			if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+				errMessage.ID = "shipping zone not found"
+				return zone, errMessage, http.StatusNotFound, execErr
+			}
+			var pgErr *pgconn.PgError
+			if errors.As(execErr, &pgErr) && pgErr.Code == "23505" {
+				errMessage.Name = "shipping zone already exists"
+				return zone, errMessage, http.StatusBadRequest, execErr
+			}
+		}
+		return zone, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	return zone, errMessage, http.StatusOK, nil
+}
+
+func (s *ShippingService) DeleteOneShippingZone(ctx context.Context, zoneId uuid.UUID) (db.ShippingZone, types.ShippingZoneErrMessage, int, error) {
+	var zone db.ShippingZone
+	var errMessage types.ShippingZoneErrMessage
+	err := s.store.DeleteOneShippingZone(ctx, zoneId)
+	if err != nil {
+		return zone, errMessage, http.StatusInternalServerError, err
+	}
+	return zone, errMessage, http.StatusNoContent, nil
+}
+
+func (s *ShippingService) CreateShippingMethod(ctx context.Context, input types.CreateShippingMethodInput) (db.ShippingMethod, types.ShippingMethodErrMessage, int, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	input.RateType = strings.ToUpper(strings.TrimSpace(input.RateType))
+	errMessage, err := validators.ValidateShippingMethod(input)
+	zoneId, parseErr := uuid.Parse(input.ZoneId)
+	if parseErr != nil {
+		errMessage.ZoneId = "must be a valid shipping zone id"
+		err = utils.ConcatenateErrors(err, parseErr)
+	}
+	if err != nil {
+		return db.ShippingMethod{}, errMessage, http.StatusBadRequest, err
+	}
+	active := true
+	if input.Active != nil {
+		active = *input.Active
+	}
+	method, err := s.store.CreateShippingMethod(ctx, db.CreateShippingMethodParams{
+		ID:        uuid.New(),
+		ZoneId:    zoneId,
+		Name:      input.Name,
+		RateType:  db.ShippingRateType(input.RateType),
+		Rate:      math.Round(input.Rate*100) / 100,
+		RatePerKg: math.Round(input.RatePerKg*100) / 100,
+		FreeOver:  math.Round(input.FreeOver*100) / 100,
+		Active:    active,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23503" {
+				errMessage.ZoneId = "shipping zone not found"
+				return db.ShippingMethod{}, errMessage, http.StatusBadRequest, err
+			}
+		}
+		return db.ShippingMethod{}, errMessage, http.StatusInternalServerError, err
+	}
+	return method, errMessage, http.StatusCreated, nil
+}
+
+func (s *ShippingService) GetAllShippingMethod(ctx context.Context) ([]db.ShippingMethod, types.ShippingMethodErrMessage, int, error) {
+	var errMessage types.ShippingMethodErrMessage
+	methods, err := s.store.GetAllShippingMethod(ctx)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return methods, errMessage, http.StatusOK, nil
+}
+
+func (s *ShippingService) UpdateOneShippingMethod(ctx context.Context, methodId uuid.UUID, input types.ShippingMethodUpdateInput) (db.ShippingMethod, types.ShippingMethodErrMessage, int, error) {
+	var method db.ShippingMethod
+	var rateType *db.ShippingRateType
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		input.Name = &name
+	}
+	if input.RateType != nil {
+		value := strings.ToUpper(strings.TrimSpace(*input.RateType))
+		input.RateType = &value
+		rateType = (*db.ShippingRateType)(&value)
+	}
+	errMessage, err := validators.ValidateShippingMethodUpdateInput(input)
+	if err != nil {
+		return method, errMessage, http.StatusBadRequest, err
+	}
+	method, execErr, txErr := s.store.UpdateShippingMethodTx(ctx, db.UpdateShippingMethodTxParams{
+		ID:        methodId,
+		Name:      input.Name,
+		RateType:  rateType,
+		Rate:      input.Rate,
+		RatePerKg: input.RatePerKg,
+		FreeOver:  input.FreeOver,
+		Active:    input.Active,
+	})
+	if execErr != nil || txErr != nil {
+		if execErr != nil {
+			if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+				errMessage.ID = "shipping method not found"
+				return method, errMessage, http.StatusNotFound, execErr
+			}
+		}
+		return method, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	return method, errMessage, http.StatusOK, nil
+}
+
+func (s *ShippingService) DeleteOneShippingMethod(ctx context.Context, methodId uuid.UUID) (db.ShippingMethod, types.ShippingMethodErrMessage, int, error) {
+	var method db.ShippingMethod
+	var errMessage types.ShippingMethodErrMessage
+	err := s.store.DeleteOneShippingMethod(ctx, methodId)
+	if err != nil {
+		return method, errMessage, http.StatusInternalServerError, err
+	}
+	return method, errMessage, http.StatusNoContent, nil
+}
+
+func (s *ShippingService) QuoteShipping(ctx context.Context, input types.ShippingQuoteInput) (db.ShippingQuote, types.ShippingQuoteErrMessage, int, error) {
+	var productIds []uuid.UUID
+	var items = make(map[uuid.UUID]int32)
+	var errMessage types.ShippingQuoteErrMessage
+	if len(input.Items) <= 0 {
+		errMessage.Items = map[string]string{"productId": "must be a valid product id", "quantity": "must be greater than zero"}
+		return db.ShippingQuote{}, errMessage, http.StatusBadRequest, nil
+	}
+	for _, item := range input.Items {
+		productId, err := uuid.Parse(item.ProductId)
+		if err != nil || item.Quantity <= 0 {
+			errMessage.Items = map[string]string{"productId": "must be a valid product id", "quantity": "must be greater than zero"}
+			return db.ShippingQuote{}, errMessage, http.StatusBadRequest, nil
+		}
+		if _, ok := items[productId]; !ok {
+			productIds = append(productIds, productId)
+		}
+		items[productId] += item.Quantity
+	}
+	input.Address.Country = strings.ToUpper(strings.TrimSpace(input.Address.Country))
+	input.Address.State = strings.ToUpper(strings.TrimSpace(input.Address.State))
+	if msg := validators.ValidateAddress(input.Address); msg != "" {
+		errMessage.Address = msg
+		return db.ShippingQuote{}, errMessage, http.StatusBadRequest, nil
+	}
+	region := input.Address.Country
+	if input.Address.State != "" {
+		region += "-" + input.Address.State
+	}
+	quote, invalid, err := s.store.QuoteShipping(ctx, db.QuoteShippingParams{
+		ProductIds: productIds,
+		Items:      items,
+		Region:     region,
+	})
+	if err != nil {
+		return quote, errMessage, http.StatusInternalServerError, err
+	}
+	if msg, ok := invalid["region"]; ok {
+		errMessage.Address = msg
+		return quote, errMessage, http.StatusBadRequest, nil
+	}
+	if len(invalid) > 0 {
+		errMessage.Items = invalid
+		return quote, errMessage, http.StatusBadRequest, nil
+	}
+	return quote, errMessage, http.StatusOK, nil
+}
+
+// normalizeRegions upper cases and trims the regions of a shipping zone.
+func normalizeRegions(regions []string) []string {
+	normalized := make([]string, len(regions))
+	for i, region := range regions {
+		normalized[i] = strings.ToUpper(strings.TrimSpace(region))
+	}
+	return normalized
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
+	"net/http"
+	"strings"
+)
+
+// TaxService provides business logic for tax rule operations.
+type TaxService struct {
+	store db.Store
+}
+
+// NewTaxService creates a new TaxService instance.
+func NewTaxService(store db.Store) *TaxService {
+	return &TaxService{
+		store: store,
+	}
+}
+
+func (s *TaxService) CreateTaxRule(ctx context.Context, input types.CreateTaxRuleInput) (db.TaxRule, types.TaxRuleErrMessage, int, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	input.TaxClass = strings.ToLower(strings.TrimSpace(input.TaxClass))
+	input.Region = strings.ToUpper(strings.TrimSpace(input.Region))
+	errMessage, err := validators.ValidateTaxRule(input)
+	if err != nil {
+		return db.TaxRule{}, errMessage, http.StatusBadRequest, err
+	}
+	rule, err := s.store.CreateTaxRule(ctx, db.CreateTaxRuleParams{
+		ID:        uuid.New(),
+		Name:      input.Name,
+		TaxClass:  input.TaxClass,
+		Region:    input.Region,
+		Rate:      input.Rate,
+		Inclusive: input.Inclusive,
+	})
+	if err != nil {
+		return db.TaxRule{}, errMessage, http.StatusInternalServerError, err
+	}
+	return rule, errMessage, http.StatusCreated, nil
+}
+
+func (s *TaxService) GetAllTaxRule(ctx context.Context) ([]db.TaxRule, types.TaxRuleErrMessage, int, error) {
+	var errMessage types.TaxRuleErrMessage
+	rules, err := s.store.GetAllTaxRule(ctx)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return rules, errMessage, http.StatusOK, nil
+}
+
+func (s *TaxService) UpdateOneTaxRule(ctx context.Context, ruleId uuid.UUID, input types.TaxRuleUpdateInput) (db.TaxRule, types.TaxRuleErrMessage, int, error) {
+	var rule db.TaxRule
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		input.Name = &name
+	}
+	if input.TaxClass != nil {
+		taxClass := strings.ToLower(strings.TrimSpace(*input.TaxClass))
+		input.TaxClass = &taxClass
+	}
+	if input.Region != nil {
+		region := strings.ToUpper(strings.TrimSpace(*input.Region))
+		input.Region = &region
+	}
+	errMessage, err := validators.ValidateTaxRuleUpdateInput(input)
+	if err != nil {
+		return rule, errMessage, http.StatusBadRequest, err
+	}
+	rule, execErr, txErr := s.store.UpdateTaxRuleTx(ctx, db.UpdateTaxRuleTxParams{
+		ID:        ruleId,
+		Name:      input.Name,
+		TaxClass:  input.TaxClass,
+		Region:    input.Region,
+		Rate:      input.Rate,
+		Inclusive: input.Inclusive,
+	})
+	if execErr != nil || txErr != nil {
+		if execErr != nil {
+			if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+				errMessage.ID = "tax rule not found"
+				return rule, errMessage, http.StatusNotFound, execErr
+			}
+		}
+		return rule, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	return rule, errMessage, http.StatusOK, nil
+}
+
+func (s *TaxService) DeleteOneTaxRule(ctx context.Context, ruleId uuid.UUID) (db.TaxRule, types.TaxRuleErrMessage, int, error) {
+	var rule db.TaxRule
+	var errMessage types.TaxRuleErrMessage
+	err := s.store.DeleteOneTaxRule(ctx, ruleId)
+	if err != nil {
+		return rule, errMessage, http.StatusInternalServerError, err
+	}
+	return rule, errMessage, http.StatusNoContent, nil
+}
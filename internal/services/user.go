@@ -52,6 +52,10 @@ func (s *UserService) CreateUser(ctx context.Context, user types.RegisterUserInp
 		if errors.As(err, &pgErr) {
 			if pgErr.Code == "23505" {
 				errMessage.Email = "email already taken"
+				// Guests prove they own the email with a claim token emailed to them
+				if existing, getErr := s.store.GetUserById(ctx, user.Email); getErr == nil && existing.Guest {
+					errMessage.Email = "email has guest orders, request a token to claim the account with"
+				}
 				return newUserOutput, errMessage, http.StatusBadRequest, err
 			}
 		}
@@ -83,6 +87,10 @@ func (s *UserService) LoginUser(ctx context.Context, user types.LoginUserInput)
 		}
 		return output, errMessage, http.StatusInternalServerError, err
 	}
+	if dbUser.Guest {
+		errMessage.Email = "email has guest orders only, request a token to claim the account with"
+		return output, errMessage, http.StatusBadRequest, errors.New("login to an unclaimed guest account")
+	}
 	err = utils.CheckPassword(dbUser.Password, user.Password)
 	if err != nil {
 		errMessage.Password = "invalid password"
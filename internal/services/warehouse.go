@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/constants"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/fulfilment"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
+	"net/http"
+	"strings"
+)
+
+// WarehouseService provides business logic for warehouses and the stock
+// they hold.
+type WarehouseService struct {
+	store db.Store
+}
+
+// NewWarehouseService creates a new WarehouseService instance.
+func NewWarehouseService(store db.Store) *WarehouseService {
+	return &WarehouseService{
+		store: store,
+	}
+}
+
+func (s *WarehouseService) CreateWarehouse(ctx context.Context, input types.CreateWarehouseInput) (db.Warehouse, types.WarehouseErrMessage, int, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	errMessage, err := validators.ValidateWarehouse(input)
+	if err != nil {
+		return db.Warehouse{}, errMessage, http.StatusBadRequest, err
+	}
+	active := true
+	if input.Active != nil {
+		active = *input.Active
+	}
+	warehouse, err := s.store.CreateWarehouse(ctx, db.CreateWarehouseParams{
+		ID:       uuid.New(),
+		Name:     input.Name,
+		Priority: input.Priority,
+		Active:   active,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			errMessage.Name = "warehouse already exists"
+			return db.Warehouse{}, errMessage, http.StatusBadRequest, err
+		}
+		return db.Warehouse{}, errMessage, http.StatusInternalServerError, err
+	}
+	return warehouse, errMessage, http.StatusCreated, nil
+}
+
+// GetAllWarehouse lists the warehouses in the order orders are shipped from
+// them.
+func (s *WarehouseService) GetAllWarehouse(ctx context.Context) ([]db.Warehouse, types.WarehouseErrMessage, int, error) {
+	var errMessage types.WarehouseErrMessage
+	warehouses, err := s.store.GetAllWarehouse(ctx)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return warehouses, errMessage, http.StatusOK, nil
+}
+
+func (s *WarehouseService) GetOneWarehouse(ctx context.Context, warehouseId uuid.UUID) (db.Warehouse, types.WarehouseErrMessage, int, error) {
+	var errMessage types.WarehouseErrMessage
+	warehouse, err := s.store.GetOneWarehouse(ctx, warehouseId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "warehouse not found"
+			return warehouse, errMessage, http.StatusNotFound, err
+		}
+		return warehouse, errMessage, http.StatusInternalServerError, err
+	}
+	return warehouse, errMessage, http.StatusOK, nil
+}
+
+func (s *WarehouseService) UpdateOneWarehouse(ctx context.Context, warehouseId uuid.UUID, input types.WarehouseUpdateInput) (db.Warehouse, types.WarehouseErrMessage, int, error) {
+	var warehouse db.Warehouse
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		input.Name = &name
+	}
+	errMessage, err := validators.ValidateWarehouseUpdateInput(input)
+	if err != nil {
+		return warehouse, errMessage, http.StatusBadRequest, err
+	}
+	warehouse, execErr, txErr := s.store.UpdateWarehouseTx(ctx, db.UpdateWarehouseTxParams{
+		ID:       warehouseId,
+		Name:     input.Name,
+		Priority: input.Priority,
+		Active:   input.Active,
+	})
+	if execErr != nil || txErr != nil {
+		if execErr != nil {
+			if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+				errMessage.ID = "warehouse not found"
+				return warehouse, errMessage, http.StatusNotFound, execErr
+			}
+			var pgErr *pgconn.PgError
+			if errors.As(execErr, &pgErr) && pgErr.Code == "23505" {
+				errMessage.Name = "warehouse already exists"
+				return warehouse, errMessage, http.StatusBadRequest, execErr
+			}
+		}
+		return warehouse, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	return warehouse, errMessage, http.StatusOK, nil
+}
+
+// GetWarehouseStock lists the products a warehouse holds.
+func (s *WarehouseService) GetWarehouseStock(ctx context.Context, warehouseId uuid.UUID) ([]db.GetWarehouseStockRow, types.WarehouseErrMessage, int, error) {
+	_, errMessage, statusCode, err := s.GetOneWarehouse(ctx, warehouseId)
+	if err != nil {
+		return nil, errMessage, statusCode, err
+	}
+	stock, err := s.store.GetWarehouseStock(ctx, warehouseId)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return stock, errMessage, http.StatusOK, nil
+}
+
+// GetProductWarehouseStock lists the warehouses holding a product, in the
+// order orders are shipped from them.
+func (s *WarehouseService) GetProductWarehouseStock(ctx context.Context, productId uuid.UUID) ([]db.GetProductWarehouseStockRow, types.WarehouseErrMessage, int, error) {
+	var errMessage types.WarehouseErrMessage
+	_, err := s.store.GetOneProduct(ctx, productId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ProductId = "product not found"
+			return nil, errMessage, http.StatusNotFound, err
+		}
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	stock, err := s.store.GetProductWarehouseStock(ctx, productId)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return stock, errMessage, http.StatusOK, nil
+}
+
+// SetWarehouseStock sets how many units of a product a warehouse holds, the
+// stock of the product going up or down by the difference.
+func (s *WarehouseService) SetWarehouseStock(ctx context.Context, warehouseId, productId uuid.UUID, input types.WarehouseStockInput) (db.Product, types.WarehouseErrMessage, int, error) {
+	var product db.Product
+	errMessage, err := validators.ValidateWarehouseStockInput(input)
+	if err != nil {
+		return product, errMessage, http.StatusBadRequest, err
+	}
+	if _, errMessage, statusCode, err := s.GetOneWarehouse(ctx, warehouseId); err != nil {
+		return product, errMessage, statusCode, err
+	}
+	var note string
+	if input.Note != nil {
+		note = strings.TrimSpace(*input.Note)
+	}
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	product, execErr, txErr := s.store.SetWarehouseStockTx(ctx, db.SetWarehouseStockTxParams{
+		WarehouseId: warehouseId,
+		ProductId:   productId,
+		Stock:       *input.Stock,
+		Note:        note,
+		ActorId:     userId,
+	})
+	if execErr != nil || txErr != nil {
+		if execErr != nil && strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+			errMessage.ProductId = "product not found"
+			return product, errMessage, http.StatusNotFound, execErr
+		}
+		return product, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	return product, errMessage, http.StatusOK, nil
+}
+
+// TransferStock moves units of a product from a warehouse to another.
+func (s *WarehouseService) TransferStock(ctx context.Context, input types.StockTransferInput) (db.StockTransfer, types.WarehouseErrMessage, int, error) {
+	var transfer db.StockTransfer
+	input.Note = strings.TrimSpace(input.Note)
+	errMessage, err := validators.ValidateStockTransfer(input)
+	if err != nil {
+		return transfer, errMessage, http.StatusBadRequest, err
+	}
+	if _, err = s.store.GetOneWarehouse(ctx, input.FromWarehouseId); err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.FromWarehouseId = "warehouse not found"
+			return transfer, errMessage, http.StatusNotFound, err
+		}
+		return transfer, errMessage, http.StatusInternalServerError, err
+	}
+	if _, err = s.store.GetOneWarehouse(ctx, input.ToWarehouseId); err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ToWarehouseId = "warehouse not found"
+			return transfer, errMessage, http.StatusNotFound, err
+		}
+		return transfer, errMessage, http.StatusInternalServerError, err
+	}
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	transfer, execErr, txErr := s.store.TransferStockTx(ctx, db.TransferStockTxParams{
+		ProductId:       input.ProductId,
+		FromWarehouseId: input.FromWarehouseId,
+		ToWarehouseId:   input.ToWarehouseId,
+		Quantity:        input.Quantity,
+		Note:            input.Note,
+		ActorId:         userId,
+	})
+	if execErr != nil || txErr != nil {
+		if execErr != nil {
+			if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+				errMessage.ProductId = "product not found"
+				return transfer, errMessage, http.StatusNotFound, execErr
+			}
+			if errors.Is(execErr, fulfilment.ErrNotEnoughStock) {
+				errMessage.Quantity = "quantity more than the warehouse holds"
+				return transfer, errMessage, http.StatusBadRequest, execErr
+			}
+		}
+		return transfer, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	return transfer, errMessage, http.StatusCreated, nil
+}
+
+// GetStockTransfers lists the latest stock transfers, of a product and from
+// or to a warehouse when they are set.
+func (s *WarehouseService) GetStockTransfers(ctx context.Context, productId, warehouseId uuid.UUID, limit int) ([]db.StockTransfer, types.WarehouseErrMessage, int, error) {
+	var errMessage types.WarehouseErrMessage
+	if limit < 1 || limit > 500 {
+		errMessage.Limit = "limit must be between 1 and 500"
+		return nil, errMessage, http.StatusBadRequest, nil
+	}
+	transfers, err := s.store.GetStockTransfers(ctx, db.GetStockTransfersParams{
+		ProductId:   pgtype.UUID{Bytes: productId, Valid: productId != uuid.Nil},
+		WarehouseId: pgtype.UUID{Bytes: warehouseId, Valid: warehouseId != uuid.Nil},
+		PageSize:    int32(limit),
+	})
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return transfers, errMessage, http.StatusOK, nil
+}
+
+// GetOrderAllocations lists the warehouses the products of an order are
+// shipped from.
+func (s *WarehouseService) GetOrderAllocations(ctx context.Context, orderId uuid.UUID) ([]db.GetOrderAllocationsRow, types.WarehouseErrMessage, int, error) {
+	var errMessage types.WarehouseErrMessage
+	_, err := s.store.GetOrderById(ctx, orderId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.OrderId = "order not found"
+			return nil, errMessage, http.StatusNotFound, err
+		}
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	allocations, err := s.store.GetOrderAllocations(ctx, orderId)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return allocations, errMessage, http.StatusOK, nil
+}
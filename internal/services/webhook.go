@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/constants"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/jobs"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/utils"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/webhook"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WebhookService provides business logic for webhook operations.
+type WebhookService struct {
+	store  db.Store
+	client *http.Client
+}
+
+// NewWebhookService creates a new WebhookService instance.
+func NewWebhookService(store db.Store) *WebhookService {
+	return &WebhookService{
+		store:  store,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, input types.CreateWebhookInput) (db.Webhook, types.WebhookErrMessage, int, error) {
+	input.URL = strings.TrimSpace(input.URL)
+	errMessage, err := validators.ValidateWebhook(input)
+	if err != nil {
+		return db.Webhook{}, errMessage, http.StatusBadRequest, err
+	}
+	if input.Secret == "" {
+		input.Secret, err = webhook.NewSecret()
+		if err != nil {
+			return db.Webhook{}, errMessage, http.StatusInternalServerError, err
+		}
+	}
+	active := true
+	if input.Active != nil {
+		active = *input.Active
+	}
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	newWebhook, err := s.store.CreateWebhook(ctx, db.CreateWebhookParams{
+		ID:        uuid.New(),
+		Url:       input.URL,
+		Secret:    input.Secret,
+		Events:    input.Events,
+		Active:    active,
+		CreatedBy: userId,
+	})
+	if err != nil {
+		return db.Webhook{}, errMessage, http.StatusInternalServerError, err
+	}
+	return newWebhook, errMessage, http.StatusCreated, nil
+}
+
+func (s *WebhookService) GetAllWebhook(ctx context.Context) ([]db.Webhook, types.WebhookErrMessage, int, error) {
+	var errMessage types.WebhookErrMessage
+	webhooks, err := s.store.GetAllWebhook(ctx)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return webhooks, errMessage, http.StatusOK, nil
+}
+
+func (s *WebhookService) GetOneWebhook(ctx context.Context, webhookId uuid.UUID) (db.Webhook, types.WebhookErrMessage, int, error) {
+	var errMessage types.WebhookErrMessage
+	hook, err := s.store.GetOneWebhook(ctx, webhookId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "webhook not found"
+			return hook, errMessage, http.StatusNotFound, err
+		}
+		return hook, errMessage, http.StatusInternalServerError, err
+	}
+	return hook, errMessage, http.StatusOK, nil
+}
+
+func (s *WebhookService) UpdateOneWebhook(ctx context.Context, webhookId uuid.UUID, input types.WebhookUpdateInput) (db.Webhook, types.WebhookErrMessage, int, error) {
+	if input.URL != nil {
+		url := strings.TrimSpace(*input.URL)
+		input.URL = &url
+	}
+	errMessage, err := validators.ValidateWebhookUpdateInput(input)
+	if err != nil {
+		return db.Webhook{}, errMessage, http.StatusBadRequest, err
+	}
+	updatedWebhook, execErr, txErr := s.store.UpdateWebhookTx(ctx, db.UpdateWebhookTxParams{
+		ID:     webhookId,
+		Url:    input.URL,
+		Secret: input.Secret,
+		Events: input.Events,
+		Active: input.Active,
+	})
+	if execErr != nil || txErr != nil {
+		if execErr != nil && strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+			errMessage.ID = "webhook not found"
+			return updatedWebhook, errMessage, http.StatusNotFound, execErr
+		}
+		return updatedWebhook, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	return updatedWebhook, errMessage, http.StatusOK, nil
+}
+
+func (s *WebhookService) DeleteOneWebhook(ctx context.Context, webhookId uuid.UUID) (db.Webhook, types.WebhookErrMessage, int, error) {
+	var errMessage types.WebhookErrMessage
+	hook, err := s.store.DeleteOneWebhook(ctx, webhookId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "webhook not found"
+			return hook, errMessage, http.StatusNotFound, err
+		}
+		return hook, errMessage, http.StatusInternalServerError, err
+	}
+	return hook, errMessage, http.StatusNoContent, nil
+}
+
+// GetWebhookDeliveries lists the latest deliveries to a webhook, newest first.
+func (s *WebhookService) GetWebhookDeliveries(ctx context.Context, webhookId uuid.UUID, limit int) ([]db.WebhookDelivery, types.WebhookErrMessage, int, error) {
+	var errMessage types.WebhookErrMessage
+	if limit < 1 || limit > 500 {
+		errMessage.Limit = "limit must be between 1 and 500"
+		return nil, errMessage, http.StatusBadRequest, nil
+	}
+	_, errMessage, statusCode, err := s.GetOneWebhook(ctx, webhookId)
+	if err != nil {
+		return nil, errMessage, statusCode, err
+	}
+	deliveries, err := s.store.GetWebhookDeliveries(ctx, db.GetWebhookDeliveriesParams{
+		WebhookId: webhookId,
+		PageSize:  int32(limit),
+	})
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return deliveries, errMessage, http.StatusOK, nil
+}
+
+// RedeliverWebhook sends the event of a delivery again, as a new delivery.
+func (s *WebhookService) RedeliverWebhook(ctx context.Context, deliveryId uuid.UUID) (db.WebhookDelivery, types.WebhookErrMessage, int, error) {
+	var errMessage types.WebhookErrMessage
+	delivery, execErr, txErr := s.store.RedeliverWebhookTx(ctx, deliveryId)
+	if execErr != nil || txErr != nil {
+		if execErr != nil && strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == execErr.Error() {
+			errMessage.ID = "webhook delivery not found"
+			return delivery, errMessage, http.StatusNotFound, execErr
+		}
+		return delivery, errMessage, http.StatusInternalServerError, utils.ConcatenateErrors(execErr, txErr)
+	}
+	return delivery, errMessage, http.StatusCreated, nil
+}
+
+// RunDeliverJob posts an event to a webhook and records the attempt. Failed
+// attempts are retried by the job queue until the last one, which leaves the
+// delivery FAILED.
+func (s *WebhookService) RunDeliverJob(ctx context.Context, payload json.RawMessage) error {
+	var args db.DeliverWebhookArgs
+	if err := json.Unmarshal(payload, &args); err != nil {
+		return jobs.Permanent(err)
+	}
+	target, err := s.store.GetUndeliveredWebhookDelivery(ctx, args.DeliveryId)
+	if err != nil {
+		// Already delivered, or the webhook was deleted along with its deliveries
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			return nil
+		}
+		return err
+	}
+	record := db.RecordWebhookDeliveryAttemptParams{ID: target.ID, Status: db.WebhookDeliveryStatusPENDING}
+	if !target.Active {
+		record.Status = db.WebhookDeliveryStatusFAILED
+		record.LastError = "webhook is disabled"
+		_, err = s.store.RecordWebhookDeliveryAttempt(ctx, record)
+		return err
+	}
+	response, sendErr := webhook.Send(ctx, s.client, webhook.Delivery{
+		ID:     target.ID.String(),
+		URL:    target.Url,
+		Secret: target.Secret,
+		Event: webhook.Event{
+			ID:        target.EventId.String(),
+			Type:      target.EventType,
+			CreatedAt: target.EventCreatedAt.Time,
+			Data:      target.Payload,
+		},
+	})
+	record.ResponseStatus = int32(response.StatusCode)
+	record.ResponseBody = response.Body
+	switch {
+	case sendErr == nil:
+		record.Status = db.WebhookDeliveryStatusSUCCEEDED
+		record.DeliveredAt = pgtype.Timestamp{Time: time.Now().UTC(), Valid: true}
+	case jobs.LastAttempt(ctx):
+		record.Status = db.WebhookDeliveryStatusFAILED
+		record.LastError = sendErr.Error()
+	default:
+		record.LastError = sendErr.Error()
+	}
+	if _, err = s.store.RecordWebhookDeliveryAttempt(context.WithoutCancel(ctx), record); err != nil {
+		return utils.ConcatenateErrors(sendErr, err)
+	}
+	return sendErr
+}
@@ -0,0 +1,404 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/constants"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/events"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/jobs"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/mailer"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/notification"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/validators"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// backInStockMaxAttempts gives a mail server a few minutes to recover.
+const backInStockMaxAttempts = 5
+
+// WishlistService provides business logic for the wishlists of customers.
+type WishlistService struct {
+	store  db.Store
+	mailer mailer.Mailer
+}
+
+// NewWishlistService creates a new WishlistService instance. mail is only
+// needed to run the back-in-stock jobs.
+func NewWishlistService(store db.Store, mail mailer.Mailer) *WishlistService {
+	return &WishlistService{
+		store:  store,
+		mailer: mail,
+	}
+}
+
+// newShareToken returns a random token for the share link of a wishlist.
+func newShareToken() (string, error) {
+	key := make([]byte, 24)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+// withItems attaches their products to each of the wishlists.
+func (s *WishlistService) withItems(ctx context.Context, wishlists []db.Wishlist) ([]types.WishlistOutput, error) {
+	output := make([]types.WishlistOutput, len(wishlists))
+	if len(wishlists) == 0 {
+		return output, nil
+	}
+	wishlistIds := make([]uuid.UUID, len(wishlists))
+	for i, wishlist := range wishlists {
+		wishlistIds[i] = wishlist.ID
+	}
+	rows, err := s.store.GetWishlistItems(ctx, wishlistIds)
+	if err != nil {
+		return nil, err
+	}
+	items := make(map[uuid.UUID][]db.GetWishlistItemsRow)
+	for _, row := range rows {
+		items[row.WishlistId] = append(items[row.WishlistId], row)
+	}
+	for i, wishlist := range wishlists {
+		output[i] = types.WishlistOutput{Wishlist: wishlist, Items: items[wishlist.ID]}
+		if output[i].Items == nil {
+			output[i].Items = []db.GetWishlistItemsRow{}
+		}
+	}
+	return output, nil
+}
+
+// getWishlist returns a wishlist of the signed in customer.
+func (s *WishlistService) getWishlist(ctx context.Context, wishlistId uuid.UUID) (db.Wishlist, types.WishlistErrMessage, int, error) {
+	var errMessage types.WishlistErrMessage
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	wishlist, err := s.store.GetUserWishlist(ctx, db.GetUserWishlistParams{
+		ID:     wishlistId,
+		UserId: userId,
+	})
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "wishlist not found"
+			return wishlist, errMessage, http.StatusNotFound, err
+		}
+		return wishlist, errMessage, http.StatusInternalServerError, err
+	}
+	return wishlist, errMessage, http.StatusOK, nil
+}
+
+func (s *WishlistService) CreateWishlist(ctx context.Context, input types.WishlistInput) (types.WishlistOutput, types.WishlistErrMessage, int, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	errMessage, err := validators.ValidateWishlist(input)
+	if err != nil {
+		return types.WishlistOutput{}, errMessage, http.StatusBadRequest, err
+	}
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	wishlist, err := s.store.CreateWishlist(ctx, db.CreateWishlistParams{
+		ID:     uuid.New(),
+		UserId: userId,
+		Name:   input.Name,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			errMessage.Name = "you already have a wishlist with this name"
+			return types.WishlistOutput{}, errMessage, http.StatusConflict, err
+		}
+		return types.WishlistOutput{}, errMessage, http.StatusInternalServerError, err
+	}
+	return types.WishlistOutput{Wishlist: wishlist, Items: []db.GetWishlistItemsRow{}}, errMessage, http.StatusCreated, nil
+}
+
+// GetUserWishlists lists the wishlists of the signed in customer, oldest
+// first.
+func (s *WishlistService) GetUserWishlists(ctx context.Context) ([]types.WishlistOutput, types.WishlistErrMessage, int, error) {
+	var errMessage types.WishlistErrMessage
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	wishlists, err := s.store.GetUserWishlists(ctx, userId)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	output, err := s.withItems(ctx, wishlists)
+	if err != nil {
+		return nil, errMessage, http.StatusInternalServerError, err
+	}
+	return output, errMessage, http.StatusOK, nil
+}
+
+func (s *WishlistService) GetUserWishlist(ctx context.Context, wishlistId uuid.UUID) (types.WishlistOutput, types.WishlistErrMessage, int, error) {
+	wishlist, errMessage, statusCode, err := s.getWishlist(ctx, wishlistId)
+	if err != nil {
+		return types.WishlistOutput{}, errMessage, statusCode, err
+	}
+	output, err := s.withItems(ctx, []db.Wishlist{wishlist})
+	if err != nil {
+		return types.WishlistOutput{}, errMessage, http.StatusInternalServerError, err
+	}
+	return output[0], errMessage, http.StatusOK, nil
+}
+
+func (s *WishlistService) UpdateWishlist(ctx context.Context, wishlistId uuid.UUID, input types.WishlistInput) (types.WishlistOutput, types.WishlistErrMessage, int, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	errMessage, err := validators.ValidateWishlist(input)
+	if err != nil {
+		return types.WishlistOutput{}, errMessage, http.StatusBadRequest, err
+	}
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	wishlist, err := s.store.UpdateWishlistName(ctx, db.UpdateWishlistNameParams{
+		Name:   input.Name,
+		ID:     wishlistId,
+		UserId: userId,
+	})
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "wishlist not found"
+			return types.WishlistOutput{}, errMessage, http.StatusNotFound, err
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			errMessage.Name = "you already have a wishlist with this name"
+			return types.WishlistOutput{}, errMessage, http.StatusConflict, err
+		}
+		return types.WishlistOutput{}, errMessage, http.StatusInternalServerError, err
+	}
+	output, err := s.withItems(ctx, []db.Wishlist{wishlist})
+	if err != nil {
+		return types.WishlistOutput{}, errMessage, http.StatusInternalServerError, err
+	}
+	return output[0], errMessage, http.StatusOK, nil
+}
+
+func (s *WishlistService) DeleteWishlist(ctx context.Context, wishlistId uuid.UUID) (db.Wishlist, types.WishlistErrMessage, int, error) {
+	var errMessage types.WishlistErrMessage
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	wishlist, err := s.store.DeleteWishlist(ctx, db.DeleteWishlistParams{
+		ID:     wishlistId,
+		UserId: userId,
+	})
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "wishlist not found"
+			return wishlist, errMessage, http.StatusNotFound, err
+		}
+		return wishlist, errMessage, http.StatusInternalServerError, err
+	}
+	return wishlist, errMessage, http.StatusOK, nil
+}
+
+// AddWishlistItem saves a product to a wishlist, setting its quantity when
+// it is already on it.
+func (s *WishlistService) AddWishlistItem(ctx context.Context, wishlistId uuid.UUID, input types.WishlistItemInput) (db.WishlistItem, types.WishlistErrMessage, int, error) {
+	if input.Quantity == 0 {
+		input.Quantity = 1
+	}
+	errMessage, err := validators.ValidateWishlistItem(input)
+	if err != nil {
+		return db.WishlistItem{}, errMessage, http.StatusBadRequest, err
+	}
+	if _, errMessage, statusCode, err := s.getWishlist(ctx, wishlistId); err != nil {
+		return db.WishlistItem{}, errMessage, statusCode, err
+	}
+	productId := uuid.MustParse(input.ProductId)
+	product, err := s.store.GetOneProduct(ctx, productId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ProductId = "product not found"
+			return db.WishlistItem{}, errMessage, http.StatusNotFound, err
+		}
+		return db.WishlistItem{}, errMessage, http.StatusInternalServerError, err
+	}
+	if product.DeletedAt.Valid {
+		errMessage.ProductId = "product not found"
+		return db.WishlistItem{}, errMessage, http.StatusNotFound, errors.New("product is archived")
+	}
+	item, err := s.store.AddWishlistItem(ctx, db.AddWishlistItemParams{
+		ID:         uuid.New(),
+		WishlistId: wishlistId,
+		ProductId:  productId,
+		Quantity:   input.Quantity,
+	})
+	if err != nil {
+		return item, errMessage, http.StatusInternalServerError, err
+	}
+	return item, errMessage, http.StatusCreated, nil
+}
+
+func (s *WishlistService) RemoveWishlistItem(ctx context.Context, wishlistId, productId uuid.UUID) (db.WishlistItem, types.WishlistErrMessage, int, error) {
+	if _, errMessage, statusCode, err := s.getWishlist(ctx, wishlistId); err != nil {
+		return db.WishlistItem{}, errMessage, statusCode, err
+	}
+	var errMessage types.WishlistErrMessage
+	item, err := s.store.DeleteWishlistItem(ctx, db.DeleteWishlistItemParams{
+		WishlistId: wishlistId,
+		ProductId:  productId,
+	})
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ProductId = "product not on the wishlist"
+			return item, errMessage, http.StatusNotFound, err
+		}
+		return item, errMessage, http.StatusInternalServerError, err
+	}
+	return item, errMessage, http.StatusOK, nil
+}
+
+// ShareWishlist gives a wishlist a new share link, which stops the link it
+// had before from working.
+func (s *WishlistService) ShareWishlist(ctx context.Context, wishlistId uuid.UUID) (db.Wishlist, types.WishlistErrMessage, int, error) {
+	var errMessage types.WishlistErrMessage
+	token, err := newShareToken()
+	if err != nil {
+		return db.Wishlist{}, errMessage, http.StatusInternalServerError, err
+	}
+	return s.setShareToken(ctx, wishlistId, pgtype.Text{String: token, Valid: true})
+}
+
+// UnshareWishlist stops the share link of a wishlist from working.
+func (s *WishlistService) UnshareWishlist(ctx context.Context, wishlistId uuid.UUID) (db.Wishlist, types.WishlistErrMessage, int, error) {
+	return s.setShareToken(ctx, wishlistId, pgtype.Text{})
+}
+
+func (s *WishlistService) setShareToken(ctx context.Context, wishlistId uuid.UUID, token pgtype.Text) (db.Wishlist, types.WishlistErrMessage, int, error) {
+	var errMessage types.WishlistErrMessage
+	userId, _ := ctx.Value(constants.ContextUserIdKey).(uuid.UUID)
+	wishlist, err := s.store.SetWishlistShareToken(ctx, db.SetWishlistShareTokenParams{
+		ShareToken: token,
+		ID:         wishlistId,
+		UserId:     userId,
+	})
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.ID = "wishlist not found"
+			return wishlist, errMessage, http.StatusNotFound, err
+		}
+		return wishlist, errMessage, http.StatusInternalServerError, err
+	}
+	return wishlist, errMessage, http.StatusOK, nil
+}
+
+// GetSharedWishlist returns the wishlist shared with token, without telling
+// whose wishlist it is.
+func (s *WishlistService) GetSharedWishlist(ctx context.Context, token string) (types.SharedWishlistOutput, types.WishlistErrMessage, int, error) {
+	var errMessage types.WishlistErrMessage
+	wishlist, err := s.store.GetWishlistByShareToken(ctx, pgtype.Text{String: token, Valid: true})
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			errMessage.Token = "wishlist not found"
+			return types.SharedWishlistOutput{}, errMessage, http.StatusNotFound, err
+		}
+		return types.SharedWishlistOutput{}, errMessage, http.StatusInternalServerError, err
+	}
+	output, err := s.withItems(ctx, []db.Wishlist{wishlist})
+	if err != nil {
+		return types.SharedWishlistOutput{}, errMessage, http.StatusInternalServerError, err
+	}
+	return types.SharedWishlistOutput{Name: wishlist.Name, Items: output[0].Items}, errMessage, http.StatusOK, nil
+}
+
+// OrderWishlist places an order for the products of a wishlist, or those of
+// them in input.ProductIds, in the quantities saved on it. The products
+// ordered are taken off the wishlist.
+func (s *WishlistService) OrderWishlist(ctx context.Context, wishlistId uuid.UUID, input types.OrderWishlistInput) (types.OrderOutput, types.WishlistErrMessage, int, error) {
+	output, errMessage, statusCode, err := s.GetUserWishlist(ctx, wishlistId)
+	if err != nil {
+		return types.OrderOutput{}, errMessage, statusCode, err
+	}
+	orderReq := types.CreateOrderInput{
+		CouponCode:       input.CouponCode,
+		ShippingRegion:   input.ShippingRegion,
+		ShippingMethodId: input.ShippingMethodId,
+	}
+	var productIds []uuid.UUID
+	for _, item := range output.Items {
+		if len(input.ProductIds) > 0 && !slices.Contains(input.ProductIds, item.ProductId.String()) {
+			continue
+		}
+		orderReq.Items = append(orderReq.Items, types.Item{ProductId: item.ProductId.String(), Quantity: item.Quantity})
+		productIds = append(productIds, item.ProductId)
+	}
+	if len(orderReq.Items) == 0 {
+		if len(input.ProductIds) > 0 {
+			errMessage.ProductIds = "none of the products are on the wishlist"
+		} else {
+			errMessage.ID = "wishlist has no products"
+		}
+		return types.OrderOutput{}, errMessage, http.StatusBadRequest, nil
+	}
+	order, orderErrMessage, statusCode, err := NewOrderService(s.store).CreateOrder(ctx, orderReq)
+	if err != nil || statusCode != http.StatusCreated {
+		errMessage.Items = orderErrMessage.Items
+		return order, errMessage, statusCode, err
+	}
+	// The order is placed whether or not the wishlist could be cleared
+	_, err = s.store.DeleteWishlistItems(context.WithoutCancel(ctx), db.DeleteWishlistItemsParams{
+		WishlistId: wishlistId,
+		ProductIds: productIds,
+	})
+	if err != nil {
+		log.Printf("Error while removing ordered products from wishlist %s: %v", wishlistId, err)
+	}
+	return order, errMessage, statusCode, nil
+}
+
+// OnProductBackInStock queues an email to each customer with the product
+// restocked on their wishlists. The jobs are keyed by the event and the
+// customer, so relaying the event again does not email anyone twice.
+func (s *WishlistService) OnProductBackInStock(ctx context.Context, event events.Envelope) error {
+	restocked, err := events.Decode[db.ProductBackInStock](event)
+	if err != nil {
+		return err
+	}
+	watchers, err := s.store.GetProductWatchers(ctx, restocked.Product.ID)
+	if err != nil {
+		return err
+	}
+	for _, watcher := range watchers {
+		_, err = jobs.Enqueue(ctx, s.store, jobs.EnqueueParams{
+			Kind:           SendBackInStockJob,
+			Payload:        types.BackInStockJobArgs{ProductId: restocked.Product.ID, Email: watcher.Email},
+			MaxAttempts:    backInStockMaxAttempts,
+			IdempotencyKey: fmt.Sprintf("%s:%s:%s", SendBackInStockJob, event.ID, watcher.ID),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunBackInStockJob emails a customer that a product on their wishlist is
+// back in stock. Nothing is sent when the product sold out or was archived
+// again before the job ran.
+func (s *WishlistService) RunBackInStockJob(ctx context.Context, payload json.RawMessage) error {
+	var args types.BackInStockJobArgs
+	if err := json.Unmarshal(payload, &args); err != nil {
+		return jobs.Permanent(err)
+	}
+	product, err := s.store.GetOneProduct(ctx, args.ProductId)
+	if err != nil {
+		if strings.Replace(sql.ErrNoRows.Error(), "sql: ", "", 1) == err.Error() {
+			return nil
+		}
+		return err
+	}
+	if product.Stock <= 0 || product.DeletedAt.Valid {
+		return nil
+	}
+	msg, err := notification.RenderBackInStock(args.Email, notification.BackInStockData{Product: product})
+	if err != nil {
+		// A template that fails to render fails every time
+		return jobs.Permanent(err)
+	}
+	return s.mailer.Send(ctx, msg)
+}
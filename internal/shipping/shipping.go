@@ -0,0 +1,92 @@
+package shipping
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"math"
+	"strings"
+)
+
+const (
+	Flat     = "FLAT"
+	Weight   = "WEIGHT"
+	FreeOver = "FREE_OVER"
+)
+
+var (
+	ErrNoZone            = errors.New("no shipping zone covers the shipping region")
+	ErrMethodUnavailable = errors.New("shipping method is not available for the shipping region")
+)
+
+// Zone is a set of regions that share the same shipping methods. A region such
+// as US also covers its subdivisions such as US-CA and a zone without regions
+// covers every region.
+type Zone struct {
+	ID      uuid.UUID
+	Name    string
+	Regions []string
+}
+
+// Method is a way of shipping to a zone together with how it is priced.
+// Flat methods always cost Rate, weight based methods cost Rate plus RatePerKg
+// for every kilogram and free over threshold methods cost Rate unless the
+// basket subtotal reaches FreeOver.
+type Method struct {
+	ID        uuid.UUID
+	ZoneID    uuid.UUID
+	Name      string
+	RateType  string
+	Rate      float64
+	RatePerKg float64
+	FreeOver  float64
+}
+
+// Basket is what is being shipped. Subtotal is the value of the products
+// before any discount and Weight their total weight in kilograms.
+type Basket struct {
+	Subtotal float64
+	Weight   float64
+}
+
+// MatchZone returns the zone that ships to the region. When several zones
+// cover the region the one with the most specific region wins, so a zone for
+// US-CA takes precedence over a zone for US which in turn takes precedence
+// over a zone without regions.
+func MatchZone(zones []Zone, region string) (Zone, error) {
+	var matched Zone
+	best := -1
+	for _, zone := range zones {
+		if len(zone.Regions) == 0 && best < 0 {
+			matched, best = zone, 0
+		}
+		for _, zoneRegion := range zone.Regions {
+			if inRegion(zoneRegion, region) && len(zoneRegion) > best {
+				matched, best = zone, len(zoneRegion)
+			}
+		}
+	}
+	if best < 0 {
+		return matched, ErrNoZone
+	}
+	return matched, nil
+}
+
+// Cost works out the price of shipping the basket with the method.
+func Cost(method Method, basket Basket) float64 {
+	var cost float64
+	switch method.RateType {
+	case Weight:
+		cost = method.Rate + method.RatePerKg*basket.Weight
+	case FreeOver:
+		if basket.Subtotal < method.FreeOver {
+			cost = method.Rate
+		}
+	default:
+		cost = method.Rate
+	}
+	return math.Round(cost*100) / 100
+}
+
+func inRegion(zoneRegion, region string) bool {
+	return zoneRegion == region || strings.HasPrefix(region, zoneRegion+"-")
+}
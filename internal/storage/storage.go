@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// Object describes a stored blob.
+type Object struct {
+	Key          string
+	ContentType  string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+}
+
+// BlobStore stores files such as product images. Keys are slash separated
+// paths relative to the root of the store, e.g. products/{id}/{imageId}.jpg.
+type BlobStore interface {
+	// Put stores size bytes read from body under key, replacing any blob
+	// already stored under it.
+	Put(ctx context.Context, key, contentType string, body io.Reader, size int64) error
+	// Get opens the blob stored under key. The caller closes the reader.
+	// ErrNotFound is returned when there is no such blob.
+	Get(ctx context.Context, key string) (io.ReadCloser, Object, error)
+	// Delete removes the blob stored under key. Deleting a missing blob is
+	// not an error.
+	Delete(ctx context.Context, key string) error
+	// URL is where clients can download the blob stored under key.
+	URL(key string) string
+}
+
+// cleanKey rejects keys that could escape the root of the store.
+func cleanKey(key string) (string, error) {
+	key = strings.TrimPrefix(key, "/")
+	if key == "" || path.Clean(key) != key || key == ".." || strings.HasPrefix(key, "../") {
+		return "", ErrInvalidKey
+	}
+	return key, nil
+}
+
+// joinURL appends key to the base URL of a store.
+func joinURL(base, key string) string {
+	return strings.TrimSuffix(base, "/") + "/" + strings.TrimPrefix(key, "/")
+}
@@ -0,0 +1,135 @@
+package tax
+
+import (
+	"github.com/google/uuid"
+	"math"
+	"strings"
+)
+
+// DefaultClass is the tax class given to products that do not set one.
+const DefaultClass = "standard"
+
+// Rule is a single tax rate for a product tax class in a shipping region.
+// An empty Region matches every region and a country Region such as US also
+// matches its subdivisions such as US-CA. Inclusive rules are already part of
+// the product price while exclusive rules are added on top of it.
+type Rule struct {
+	ID        uuid.UUID
+	Name      string
+	TaxClass  string
+	Region    string
+	Rate      float64
+	Inclusive bool
+}
+
+// Line is a single taxable order line. Amount is the price of the line after
+// any discount.
+type Line struct {
+	TaxClass string
+	Amount   float64
+}
+
+// Amount is the tax charged by a single rule.
+type Amount struct {
+	RuleID    uuid.UUID
+	Name      string
+	Rate      float64
+	Inclusive bool
+	Amount    float64
+}
+
+// LineResult is the tax on a single line.
+type LineResult struct {
+	Tax       float64
+	Exclusive float64
+	Amounts   []Amount
+}
+
+// Result is the tax on a set of lines. Exclusive is the part of Tax that has
+// to be added to the order total, Breakdown holds the tax per rule.
+type Result struct {
+	Lines     []LineResult
+	Tax       float64
+	Exclusive float64
+	Breakdown []Amount
+}
+
+// Match returns the rules for the tax class that apply to the region. When
+// rules exist for both a country and one of its subdivisions only the most
+// specific ones apply, rules at the same level are all applied.
+func Match(rules []Rule, taxClass, region string) []Rule {
+	var matched []Rule
+	best := -1
+	for _, rule := range rules {
+		if rule.TaxClass != taxClass || !inRegion(rule.Region, region) {
+			continue
+		}
+		if len(rule.Region) > best {
+			best = len(rule.Region)
+			matched = matched[:0]
+		}
+		if len(rule.Region) == best {
+			matched = append(matched, rule)
+		}
+	}
+	return matched
+}
+
+// Calculate works out the tax on every line for an order shipping to region.
+func Calculate(rules []Rule, region string, lines []Line) Result {
+	var result Result
+	breakdown := make(map[uuid.UUID]int)
+	for _, line := range lines {
+		lineResult := calculateLine(Match(rules, line.TaxClass, region), line.Amount)
+		for _, amount := range lineResult.Amounts {
+			i, ok := breakdown[amount.RuleID]
+			if !ok {
+				breakdown[amount.RuleID] = len(result.Breakdown)
+				result.Breakdown = append(result.Breakdown, amount)
+				continue
+			}
+			result.Breakdown[i].Amount = round(result.Breakdown[i].Amount + amount.Amount)
+		}
+		result.Tax = round(result.Tax + lineResult.Tax)
+		result.Exclusive = round(result.Exclusive + lineResult.Exclusive)
+		result.Lines = append(result.Lines, lineResult)
+	}
+	return result
+}
+
+// calculateLine applies the rules to a single line amount. Inclusive rates are
+// extracted from the amount together so that stacked inclusive rates share the
+// same net price.
+func calculateLine(rules []Rule, amount float64) LineResult {
+	var result LineResult
+	var inclusiveRate float64
+	for _, rule := range rules {
+		if rule.Inclusive {
+			inclusiveRate += rule.Rate
+		}
+	}
+	net := amount / (1 + inclusiveRate/100)
+	for _, rule := range rules {
+		taxAmount := round(net * rule.Rate / 100)
+		result.Amounts = append(result.Amounts, Amount{
+			RuleID:    rule.ID,
+			Name:      rule.Name,
+			Rate:      rule.Rate,
+			Inclusive: rule.Inclusive,
+			Amount:    taxAmount,
+		})
+		result.Tax = round(result.Tax + taxAmount)
+		if !rule.Inclusive {
+			result.Exclusive = round(result.Exclusive + taxAmount)
+		}
+	}
+	return result
+}
+
+func inRegion(ruleRegion, region string) bool {
+	return ruleRegion == "" || ruleRegion == region || strings.HasPrefix(region, ruleRegion+"-")
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
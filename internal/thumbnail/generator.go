@@ -0,0 +1,142 @@
+package thumbnail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/storage"
+	"golang.org/x/sync/singleflight"
+	"image"
+	"io"
+	"slices"
+)
+
+// Config configures a Generator.
+type Config struct {
+	Sizes []Size
+	// OnUpload makes thumbnails be generated as soon as an image is uploaded
+	// instead of on the first request for them.
+	OnUpload bool
+}
+
+// Generator creates thumbnails of images kept in a blob store and caches them
+// in the same store, next to the originals.
+type Generator struct {
+	blobs  storage.BlobStore
+	config Config
+	group  singleflight.Group
+}
+
+// NewGenerator creates a new Generator instance.
+func NewGenerator(blobs storage.BlobStore, config Config) *Generator {
+	if len(config.Sizes) == 0 {
+		config.Sizes = DefaultSizes
+	}
+	return &Generator{blobs: blobs, config: config}
+}
+
+// Sizes lists the configured sizes, smallest first.
+func (g *Generator) Sizes() []Size {
+	return g.config.Sizes
+}
+
+// OnUpload reports whether thumbnails are generated when an image is uploaded.
+func (g *Generator) OnUpload() bool {
+	return g.config.OnUpload
+}
+
+func (g *Generator) size(name string) (Size, bool) {
+	for _, size := range g.config.Sizes {
+		if size.Name == name {
+			return size, true
+		}
+	}
+	return Size{}, false
+}
+
+// Key is where the thumbnail of an image is cached. The dimension is part of
+// the key so that changing a size never serves thumbnails made for the old one.
+func Key(imageId uuid.UUID, size Size, format string) string {
+	return fmt.Sprintf("thumbnails/%s/%s-%d.%s", imageId, size.Name, size.MaxDimension, format)
+}
+
+// Get opens the thumbnail of an image, generating it from the original stored
+// under originalKey when it is not cached yet. Concurrent requests for the
+// same missing thumbnail generate it only once.
+func (g *Generator) Get(ctx context.Context, imageId uuid.UUID, originalKey, contentType, sizeName, format string) (io.ReadCloser, storage.Object, error) {
+	size, ok := g.size(sizeName)
+	if !ok {
+		return nil, storage.Object{}, ErrUnknownSize
+	}
+	if !slices.Contains(Formats(contentType), format) {
+		return nil, storage.Object{}, ErrUnknownFormat
+	}
+	key := Key(imageId, size, format)
+	body, object, err := g.blobs.Get(ctx, key)
+	if !errors.Is(err, storage.ErrNotFound) {
+		return body, object, err
+	}
+	_, err, _ = g.group.Do(key, func() (interface{}, error) {
+		img, err := g.original(ctx, originalKey)
+		if err != nil {
+			return nil, err
+		}
+		return nil, g.put(ctx, img, key, size, format)
+	})
+	if err != nil {
+		return nil, storage.Object{}, err
+	}
+	return g.blobs.Get(ctx, key)
+}
+
+// Generate creates and caches every thumbnail of an image.
+func (g *Generator) Generate(ctx context.Context, imageId uuid.UUID, originalKey, contentType string) error {
+	img, err := g.original(ctx, originalKey)
+	if err != nil {
+		return err
+	}
+	for _, size := range g.config.Sizes {
+		resized := Resize(img, size)
+		for _, format := range Formats(contentType) {
+			if err = g.put(ctx, resized, Key(imageId, size, format), size, format); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Delete removes the cached thumbnails of an image.
+func (g *Generator) Delete(ctx context.Context, imageId uuid.UUID, contentType string) error {
+	var errs []error
+	for _, size := range g.config.Sizes {
+		for _, format := range Formats(contentType) {
+			errs = append(errs, g.blobs.Delete(ctx, Key(imageId, size, format)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (g *Generator) original(ctx context.Context, key string) (image.Image, error) {
+	body, _, err := g.blobs.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return Decode(data)
+}
+
+// put resizes img unless it already fits size, encodes it and stores it.
+func (g *Generator) put(ctx context.Context, img image.Image, key string, size Size, format string) error {
+	var buf bytes.Buffer
+	if err := Encode(&buf, Resize(img, size), format); err != nil {
+		return err
+	}
+	return g.blobs.Put(ctx, key, ContentType(format), &buf, int64(buf.Len()))
+}
@@ -0,0 +1,143 @@
+// Package thumbnail resizes product images and caches the results in the
+// blob store. Thumbnails are made in the format of the original, or PNG for
+// formats browsers may not all display, and in WebP.
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/webp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	JPEG = "jpg"
+	PNG  = "png"
+	WebP = "webp"
+)
+
+// maxPixels bounds the size of the images that are decoded, so that a small
+// file claiming huge dimensions cannot exhaust memory.
+const maxPixels = 50_000_000
+
+const jpegQuality = 85
+
+var (
+	ErrUnknownSize   = errors.New("unknown thumbnail size")
+	ErrUnknownFormat = errors.New("unknown thumbnail format")
+	ErrTooLarge      = errors.New("image is too large to resize")
+)
+
+// Size is a named thumbnail size. Thumbnails fit in a square of MaxDimension
+// pixels and keep the aspect ratio of the original image.
+type Size struct {
+	Name         string
+	MaxDimension int
+}
+
+// DefaultSizes are used when no sizes are configured.
+var DefaultSizes = []Size{{Name: "small", MaxDimension: 160}, {Name: "medium", MaxDimension: 480}, {Name: "large", MaxDimension: 1024}}
+
+// ParseSizes parses sizes written as name:maxDimension pairs separated by
+// commas, e.g. small:160,medium:480. An empty spec gives DefaultSizes.
+func ParseSizes(spec string) ([]Size, error) {
+	if strings.TrimSpace(spec) == "" {
+		return DefaultSizes, nil
+	}
+	var sizes []Size
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(spec, ",") {
+		name, dimension, ok := strings.Cut(strings.TrimSpace(part), ":")
+		maxDimension, err := strconv.Atoi(dimension)
+		if !ok || name == "" || err != nil || maxDimension <= 0 || maxDimension > 4096 {
+			return nil, fmt.Errorf("invalid thumbnail size %q, expected name:maxDimension", part)
+		}
+		if seen[name] || strings.ContainsAny(name, "./") {
+			return nil, fmt.Errorf("invalid thumbnail size name %q", name)
+		}
+		seen[name] = true
+		sizes = append(sizes, Size{Name: name, MaxDimension: maxDimension})
+	}
+	sort.SliceStable(sizes, func(i, j int) bool {
+		return sizes[i].MaxDimension < sizes[j].MaxDimension
+	})
+	return sizes, nil
+}
+
+// Formats lists the formats thumbnails of an image with the given content type
+// are made in: the one closest to the original, which every browser supports,
+// and WebP.
+func Formats(contentType string) []string {
+	if contentType == "image/jpeg" {
+		return []string{JPEG, WebP}
+	}
+	return []string{PNG, WebP}
+}
+
+// ContentType is the MIME type of thumbnails in format.
+func ContentType(format string) string {
+	switch format {
+	case JPEG:
+		return "image/jpeg"
+	case PNG:
+		return "image/png"
+	case WebP:
+		return "image/webp"
+	}
+	return ""
+}
+
+// Decode decodes an original image, refusing images with too many pixels.
+func Decode(original []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(original))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, ErrTooLarge
+	}
+	img, _, err := image.Decode(bytes.NewReader(original))
+	return img, err
+}
+
+// Resize scales img down to fit size. Smaller images are left as they are.
+func Resize(img image.Image, size Size) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size.MaxDimension && height <= size.MaxDimension {
+		return img
+	}
+	if width >= height {
+		height = max(1, height*size.MaxDimension/width)
+		width = size.MaxDimension
+	} else {
+		width = max(1, width*size.MaxDimension/height)
+		height = size.MaxDimension
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// Encode writes img to w in format.
+func Encode(w io.Writer, img image.Image, format string) error {
+	switch format {
+	case JPEG:
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+	case PNG:
+		return png.Encode(w, img)
+	case WebP:
+		return webp.Encode(w, img)
+	}
+	return ErrUnknownFormat
+}
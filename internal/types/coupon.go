@@ -0,0 +1,73 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CreateCouponInput struct {
+	Code          string      `json:"code"`
+	DiscountType  string      `json:"discountType"`
+	Value         float64     `json:"value"`
+	MinOrderValue float64     `json:"minOrderValue"`
+	ProductIds    []uuid.UUID `json:"productIds"`
+	Categories    []string    `json:"categories"`
+	UsageLimit    int32       `json:"usageLimit"`
+	PerUserLimit  int32       `json:"perUserLimit"`
+	StartsAt      *time.Time  `json:"startsAt,omitempty"`
+	ExpiresAt     *time.Time  `json:"expiresAt,omitempty"`
+	Active        *bool       `json:"active,omitempty"`
+}
+
+type CouponUpdateInput struct {
+	Code          *string      `json:"code,omitempty"`
+	DiscountType  *string      `json:"discountType,omitempty"`
+	Value         *float64     `json:"value,omitempty"`
+	MinOrderValue *float64     `json:"minOrderValue,omitempty"`
+	ProductIds    *[]uuid.UUID `json:"productIds,omitempty"`
+	Categories    *[]string    `json:"categories,omitempty"`
+	UsageLimit    *int32       `json:"usageLimit,omitempty"`
+	PerUserLimit  *int32       `json:"perUserLimit,omitempty"`
+	StartsAt      *time.Time   `json:"startsAt,omitempty"`
+	ExpiresAt     *time.Time   `json:"expiresAt,omitempty"`
+	Active        *bool        `json:"active,omitempty"`
+}
+
+type CouponErrMessage struct {
+	ID            string `json:"id,omitempty"`
+	Code          string `json:"code,omitempty"`
+	DiscountType  string `json:"discountType,omitempty"`
+	Value         string `json:"value,omitempty"`
+	MinOrderValue string `json:"minOrderValue,omitempty"`
+	Categories    string `json:"categories,omitempty"`
+	UsageLimit    string `json:"usageLimit,omitempty"`
+	PerUserLimit  string `json:"perUserLimit,omitempty"`
+	ExpiresAt     string `json:"expiresAt,omitempty"`
+}
+
+// Coupon For Swagger Docs
+type Coupon struct {
+	ID            uuid.UUID   `json:"id"`
+	Code          string      `json:"code"`
+	DiscountType  string      `json:"discountType"`
+	Value         float64     `json:"value"`
+	MinOrderValue float64     `json:"minOrderValue"`
+	ProductIds    []uuid.UUID `json:"productIds"`
+	Categories    []string    `json:"categories"`
+	UsageLimit    int32       `json:"usageLimit"`
+	PerUserLimit  int32       `json:"perUserLimit"`
+	UsageCount    int32       `json:"usageCount"`
+	StartsAt      *time.Time  `json:"startsAt"`
+	ExpiresAt     *time.Time  `json:"expiresAt"`
+	Active        bool        `json:"active"`
+	CreatedBy     uuid.UUID   `json:"createdBy"`
+	CreatedAt     time.Time   `json:"createdAt"`
+	UpdatedAt     time.Time   `json:"updatedAt"`
+}
+
+// CouponError For Swagger Docs
+type CouponError struct {
+	Status  string           `json:"status"`
+	Message string           `json:"message"`
+	Error   CouponErrMessage `json:"error"`
+}
@@ -0,0 +1,114 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"time"
+)
+
+// GuestAddress is where a guest order is shipped to. Country is an ISO 3166
+// country code and State an optional subdivision code, e.g. US and CA.
+type GuestAddress struct {
+	Name       string `json:"name"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city"`
+	PostalCode string `json:"postalCode,omitempty"`
+	Country    string `json:"country"`
+	State      string `json:"state,omitempty"`
+}
+
+// GuestOrderInput places an order without an account. The shipping region is
+// that of the address.
+type GuestOrderInput struct {
+	Email            string       `json:"email"`
+	Address          GuestAddress `json:"address"`
+	Items            []Item       `json:"items"`
+	CouponCode       string       `json:"couponCode,omitempty"`
+	ShippingMethodId string       `json:"shippingMethodId,omitempty"`
+}
+
+type GuestOrderErrMessage struct {
+	Email   string            `json:"email,omitempty"`
+	Address string            `json:"address,omitempty"`
+	Token   string            `json:"token,omitempty"`
+	Items   map[string]string `json:"items,omitempty"`
+}
+
+// GuestOrderOutput is a guest order along with the address it is shipped to.
+type GuestOrderOutput struct {
+	OrderOutput
+	ShippingAddress db.GuestOrder `json:"shippingAddress"`
+}
+
+// GuestOrderLookupInput looks up a guest order with the token emailed to the
+// guest. The token is sent in the body to keep it out of the logged URLs.
+type GuestOrderLookupInput struct {
+	Token string `json:"token"`
+}
+
+// AccountClaimRequestInput asks for a token to claim the account of a guest
+// with, emailed to the guest as proof of their email.
+type AccountClaimRequestInput struct {
+	Email string `json:"email"`
+}
+
+// ClaimAccountInput turns the shadow user of a guest into an account, with
+// the account claim token emailed to the guest.
+type ClaimAccountInput struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type ClaimAccountErrMessage struct {
+	Email    string `json:"email,omitempty"`
+	Token    string `json:"token,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// OrderLookupJobArgs is the payload of the jobs emailing a guest the token to
+// look up their order with
+type OrderLookupJobArgs struct {
+	OrderId uuid.UUID `json:"orderId"`
+}
+
+// AccountClaimJobArgs is the payload of the jobs emailing a guest the token to
+// claim their account with
+type AccountClaimJobArgs struct {
+	UserId uuid.UUID `json:"userId"`
+	Email  string    `json:"email"`
+}
+
+// GuestOrderAddress For Swagger Docs
+type GuestOrderAddress struct {
+	OrderId    uuid.UUID `json:"orderId"`
+	Email      string    `json:"email"`
+	Name       string    `json:"name"`
+	Line1      string    `json:"line1"`
+	Line2      string    `json:"line2"`
+	City       string    `json:"city"`
+	PostalCode string    `json:"postalCode"`
+	Country    string    `json:"country"`
+	State      string    `json:"state"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// GuestOrder For Swagger Docs
+type GuestOrder struct {
+	Order
+	ShippingAddress GuestOrderAddress `json:"shippingAddress"`
+}
+
+// GuestOrderError For Swagger Docs
+type GuestOrderError struct {
+	Status  string               `json:"status"`
+	Message string               `json:"message"`
+	Error   GuestOrderErrMessage `json:"error"`
+}
+
+// ClaimAccountError For Swagger Docs
+type ClaimAccountError struct {
+	Status  string                 `json:"status"`
+	Message string                 `json:"message"`
+	Error   ClaimAccountErrMessage `json:"error"`
+}
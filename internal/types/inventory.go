@@ -0,0 +1,81 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"time"
+)
+
+type InventoryErrMessage struct {
+	ID       string `json:"id,omitempty"`
+	Category string `json:"category,omitempty"`
+	Before   string `json:"before,omitempty"`
+	Limit    string `json:"limit,omitempty"`
+}
+
+// StockMovementOutput is a page of the stock ledger of a product, newest
+// movement first, along with the stock the whole ledger adds up to
+type StockMovementOutput struct {
+	ProductId   uuid.UUID          `json:"productId"`
+	Stock       int32              `json:"stock"`
+	LedgerStock int32              `json:"ledgerStock"`
+	Movements   []db.StockMovement `json:"movements"`
+}
+
+// LowStockAlertJobArgs is the payload of the jobs emailing a low-stock alert
+type LowStockAlertJobArgs struct {
+	AlertId    uuid.UUID `json:"alertId"`
+	Recipients []string  `json:"recipients"`
+}
+
+// LowStockProduct For Swagger Docs
+type LowStockProduct struct {
+	ID               uuid.UUID  `json:"id"`
+	Name             string     `json:"name"`
+	Sku              string     `json:"sku"`
+	Category         string     `json:"category"`
+	Stock            int32      `json:"stock"`
+	ReorderThreshold int32      `json:"reorderThreshold"`
+	AlertId          *uuid.UUID `json:"alertId"`
+	LowSince         *time.Time `json:"lowSince"`
+	NotifiedAt       *time.Time `json:"notifiedAt"`
+}
+
+// StockMovement For Swagger Docs
+type StockMovement struct {
+	ID          int64      `json:"id"`
+	ProductId   uuid.UUID  `json:"productId"`
+	Quantity    int32      `json:"quantity"`
+	StockAfter  int32      `json:"stockAfter"`
+	Kind        string     `json:"kind"`
+	OrderId     *uuid.UUID `json:"orderId"`
+	ReturnId    *uuid.UUID `json:"returnId"`
+	CreatedBy   *uuid.UUID `json:"createdBy"`
+	WarehouseId *uuid.UUID `json:"warehouseId"`
+	Note        string     `json:"note"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// StockMovements For Swagger Docs
+type StockMovements struct {
+	ProductId   uuid.UUID       `json:"productId"`
+	Stock       int32           `json:"stock"`
+	LedgerStock int32           `json:"ledgerStock"`
+	Movements   []StockMovement `json:"movements"`
+}
+
+// StockDiscrepancy For Swagger Docs
+type StockDiscrepancy struct {
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	Sku            string    `json:"sku"`
+	Stock          int32     `json:"stock"`
+	LedgerStock    int32     `json:"ledgerStock"`
+	WarehouseStock int32     `json:"warehouseStock"`
+}
+
+type InventoryError struct {
+	Status  string              `json:"status"`
+	Message string              `json:"message"`
+	Error   InventoryErrMessage `json:"error"`
+}
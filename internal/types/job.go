@@ -0,0 +1,62 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"time"
+)
+
+type JobErrMessage struct {
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status,omitempty"`
+	Limit  string `json:"limit,omitempty"`
+}
+
+// JobOutput is a queued job, with its payload as JSON rather than bytes
+type JobOutput struct {
+	ID          uuid.UUID        `json:"id"`
+	Kind        string           `json:"kind"`
+	Payload     json.RawMessage  `json:"payload"`
+	Status      db.JobStatus     `json:"status"`
+	Attempts    int32            `json:"attempts"`
+	MaxAttempts int32            `json:"maxAttempts"`
+	RunAt       pgtype.Timestamp `json:"runAt"`
+	LockedBy    string           `json:"lockedBy"`
+	LastError   string           `json:"lastError"`
+	CompletedAt pgtype.Timestamp `json:"completedAt"`
+	CreatedAt   pgtype.Timestamp `json:"createdAt"`
+	UpdatedAt   pgtype.Timestamp `json:"updatedAt"`
+}
+
+// Job For Swagger Docs
+type Job struct {
+	ID          uuid.UUID  `json:"id"`
+	Kind        string     `json:"kind"`
+	Payload     any        `json:"payload"`
+	Status      string     `json:"status"`
+	Attempts    int32      `json:"attempts"`
+	MaxAttempts int32      `json:"maxAttempts"`
+	RunAt       time.Time  `json:"runAt"`
+	LockedBy    string     `json:"lockedBy"`
+	LastError   string     `json:"lastError"`
+	CompletedAt *time.Time `json:"completedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// JobStats For Swagger Docs
+type JobStats struct {
+	Pending   int64 `json:"PENDING"`
+	Running   int64 `json:"RUNNING"`
+	Succeeded int64 `json:"SUCCEEDED"`
+	Dead      int64 `json:"DEAD"`
+}
+
+// JobError For Swagger Docs
+type JobError struct {
+	Status  string        `json:"status"`
+	Message string        `json:"message"`
+	Error   JobErrMessage `json:"error"`
+}
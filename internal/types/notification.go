@@ -0,0 +1,26 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// NotificationPreferenceInput changes the emails a user wants. Only the
+// fields present in the body are changed.
+type NotificationPreferenceInput struct {
+	OrderPlaced    *bool `json:"orderPlaced"`
+	OrderCancelled *bool `json:"orderCancelled"`
+	OrderCompleted *bool `json:"orderCompleted"`
+	OrderShipped   *bool `json:"orderShipped"`
+}
+
+// NotificationPreference For Swagger Docs
+type NotificationPreference struct {
+	UserId         uuid.UUID `json:"userId"`
+	OrderPlaced    bool      `json:"orderPlaced"`
+	OrderCancelled bool      `json:"orderCancelled"`
+	OrderCompleted bool      `json:"orderCompleted"`
+	OrderShipped   bool      `json:"orderShipped"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
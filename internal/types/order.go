@@ -9,12 +9,29 @@ import (
 type OrderStatus db.OrderStatus
 
 type Order struct {
-	ID        uuid.UUID   `json:"id"`
-	UserId    uuid.UUID   `json:"userId"`
-	Total     float64     `json:"total"`
-	Status    OrderStatus `json:"status"`
-	CreatedAt time.Time   `json:"createdAt"`
-	UpdatedAt time.Time   `json:"updatedAt"`
+	ID               uuid.UUID        `json:"id"`
+	UserId           uuid.UUID        `json:"userId"`
+	Subtotal         float64          `json:"subtotal"`
+	Discount         float64          `json:"discount"`
+	Tax              float64          `json:"tax"`
+	Total            float64          `json:"total"`
+	CouponId         *uuid.UUID       `json:"couponId"`
+	ShippingRegion   string           `json:"shippingRegion"`
+	ShippingMethodId *uuid.UUID       `json:"shippingMethodId"`
+	ShippingMethod   string           `json:"shippingMethod"`
+	ShippingCost     float64          `json:"shippingCost"`
+	Status           OrderStatus      `json:"status"`
+	CreatedAt        time.Time        `json:"createdAt"`
+	UpdatedAt        time.Time        `json:"updatedAt"`
+	Taxes            []OrderTax       `json:"taxes"`
+	Promotions       []OrderPromotion `json:"promotions"`
+}
+
+// OrderOutput is an order together with its per rate tax breakdown and the promotions applied to it
+type OrderOutput struct {
+	db.Order
+	Taxes      []db.OrderTax       `json:"taxes"`
+	Promotions []db.OrderPromotion `json:"promotions"`
 }
 
 type Item struct {
@@ -23,13 +40,17 @@ type Item struct {
 }
 
 type CreateOrderInput struct {
-	Items []Item `json:"items"`
+	Items            []Item `json:"items"`
+	CouponCode       string `json:"couponCode,omitempty"`
+	ShippingRegion   string `json:"shippingRegion,omitempty"`
+	ShippingMethodId string `json:"shippingMethodId,omitempty"`
 }
 
 type OrderErrMessage struct {
-	Items  map[string]string `json:"items,omitempty"`
-	ID     string            `json:"id,omitempty"`
-	Status string            `json:"status,omitempty"`
+	Items       map[string]string `json:"items,omitempty"`
+	ID          string            `json:"id,omitempty"`
+	Status      string            `json:"status,omitempty"`
+	LastEventId string            `json:"lastEventId,omitempty"`
 }
 
 type ItemError struct {
@@ -52,3 +73,57 @@ type OrderCancelError struct {
 type UpdateOrderStatusInput struct {
 	Status db.OrderStatus `json:"status"`
 }
+
+// OrderPreviewItem For Swagger Docs
+type OrderPreviewItem struct {
+	ProductId   uuid.UUID `json:"productId"`
+	Quantity    int32     `json:"quantity"`
+	Price       float64   `json:"price"`
+	Discount    float64   `json:"discount"`
+	Tax         float64   `json:"tax"`
+	Backordered int32     `json:"backordered"`
+}
+
+// OrderPreviewTax For Swagger Docs
+type OrderPreviewTax struct {
+	TaxRuleId uuid.UUID `json:"taxRuleId"`
+	Name      string    `json:"name"`
+	Rate      float64   `json:"rate"`
+	Inclusive bool      `json:"inclusive"`
+	Amount    float64   `json:"amount"`
+}
+
+// OrderPreview For Swagger Docs
+type OrderPreview struct {
+	Status           OrderStatus        `json:"status"`
+	Subtotal         float64            `json:"subtotal"`
+	Discount         float64            `json:"discount"`
+	CouponDiscount   float64            `json:"couponDiscount"`
+	Tax              float64            `json:"tax"`
+	Total            float64            `json:"total"`
+	CouponId         *uuid.UUID         `json:"couponId"`
+	ShippingRegion   string             `json:"shippingRegion"`
+	ShippingMethodId *uuid.UUID         `json:"shippingMethodId"`
+	ShippingMethod   string             `json:"shippingMethod"`
+	ShippingCost     float64            `json:"shippingCost"`
+	Items            []OrderPreviewItem `json:"items"`
+	Taxes            []OrderPreviewTax  `json:"taxes"`
+	Promotions       []AppliedPromotion `json:"promotions"`
+}
+
+// OrderStatusEvent is the data of an order.status_changed event of the order stream. For Swagger Docs
+type OrderStatusEvent struct {
+	ID             int64          `json:"id"`
+	OrderId        uuid.UUID      `json:"orderId"`
+	UserId         uuid.UUID      `json:"userId"`
+	Status         db.OrderStatus `json:"status"`
+	PreviousStatus db.OrderStatus `json:"previousStatus"`
+	CreatedAt      time.Time      `json:"createdAt"`
+}
+
+// OrderStreamError For Swagger Docs
+type OrderStreamError struct {
+	Status  string          `json:"status,omitempty"`
+	Message string          `json:"message,omitempty"`
+	Error   OrderErrMessage `json:"error,omitempty"`
+}
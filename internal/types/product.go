@@ -7,40 +7,83 @@ import (
 )
 
 type CreateProductInput struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Stock       int     `json:"stock"`
+	Name             string  `json:"name"`
+	Description      string  `json:"description"`
+	Price            float64 `json:"price"`
+	Stock            int     `json:"stock"`
+	Category         string  `json:"category"`
+	TaxClass         string  `json:"taxClass"`
+	Weight           float64 `json:"weight"`
+	Sku              string  `json:"sku"`
+	ReorderThreshold *int    `json:"reorderThreshold,omitempty"`
+	// BackorderPolicy is NONE (default), BACKORDER or PREORDER
+	BackorderPolicy string     `json:"backorderPolicy,omitempty"`
+	AvailableAt     *time.Time `json:"availableAt,omitempty"`
 }
 
 type ProductErrMessage struct {
-	ID          string `json:"id,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
-	Price       string `json:"price,omitempty"`
-	Stock       string `json:"stock,omitempty"`
+	ID               string `json:"id,omitempty"`
+	Name             string `json:"name,omitempty"`
+	Description      string `json:"description,omitempty"`
+	Price            string `json:"price,omitempty"`
+	Stock            string `json:"stock,omitempty"`
+	Category         string `json:"category,omitempty"`
+	TaxClass         string `json:"taxClass,omitempty"`
+	Weight           string `json:"weight,omitempty"`
+	Sku              string `json:"sku,omitempty"`
+	ReorderThreshold string `json:"reorderThreshold,omitempty"`
+	StockNote        string `json:"stockNote,omitempty"`
+	BackorderPolicy  string `json:"backorderPolicy,omitempty"`
+	Version          string `json:"version,omitempty"`
+	Sort             string `json:"sort,omitempty"`
 }
 
 type CreateProductOutput db.GetAllProductRow
 
-type ProductOutput db.GetAllProductRow
+// ProductOutput is a product together with its images, primary image first
+type ProductOutput struct {
+	db.GetAllProductRow
+	Images []ProductImageOutput `json:"images"`
+}
 
 type ProductUpdateInput struct {
-	Name        *string  `json:"name,omitempty"`
-	Description *string  `json:"description,omitempty"`
-	Price       *float64 `json:"price,omitempty"`
-	Stock       *int32   `json:"stock,omitempty"`
+	Name             *string  `json:"name,omitempty"`
+	Description      *string  `json:"description,omitempty"`
+	Price            *float64 `json:"price,omitempty"`
+	Stock            *int32   `json:"stock,omitempty"`
+	Category         *string  `json:"category,omitempty"`
+	TaxClass         *string  `json:"taxClass,omitempty"`
+	Weight           *float64 `json:"weight,omitempty"`
+	Sku              *string  `json:"sku,omitempty"`
+	ReorderThreshold *int32   `json:"reorderThreshold,omitempty"`
+	StockNote        *string  `json:"stockNote,omitempty"`
+	// BackorderPolicy set to NONE clears AvailableAt
+	BackorderPolicy *string    `json:"backorderPolicy,omitempty"`
+	AvailableAt     *time.Time `json:"availableAt,omitempty"`
 }
 
 type Product struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	Stock       int32     `json:"stock"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
-	CreatedBy   uuid.UUID `json:"createdBy"`
+	ID               uuid.UUID      `json:"id"`
+	Name             string         `json:"name"`
+	Description      string         `json:"description"`
+	Price            float64        `json:"price"`
+	Stock            int32          `json:"stock"`
+	CreatedAt        time.Time      `json:"createdAt"`
+	UpdatedAt        time.Time      `json:"updatedAt"`
+	CreatedBy        uuid.UUID      `json:"createdBy"`
+	Category         string         `json:"category"`
+	TaxClass         string         `json:"taxClass"`
+	Weight           float64        `json:"weight"`
+	Sku              string         `json:"sku"`
+	ReorderThreshold int32          `json:"reorderThreshold"`
+	BackorderPolicy  string         `json:"backorderPolicy"`
+	AvailableAt      *time.Time     `json:"availableAt"`
+	DeletedAt        *time.Time     `json:"deletedAt"`
+	Version          int32          `json:"version"`
+	CompareAtPrice   *float64       `json:"compareAtPrice"`
+	RatingAverage    float64        `json:"ratingAverage"`
+	RatingCount      int32          `json:"ratingCount"`
+	Images           []ProductImage `json:"images"`
 }
 
 type ProductError struct {
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+type ProductImageOrderInput struct {
+	ImageIds       []string `json:"imageIds"`
+	PrimaryImageId string   `json:"primaryImageId,omitempty"`
+}
+
+type ProductImageErrMessage struct {
+	ID             string `json:"id,omitempty"`
+	Image          string `json:"image,omitempty"`
+	ImageIds       string `json:"imageIds,omitempty"`
+	PrimaryImageId string `json:"primaryImageId,omitempty"`
+}
+
+type ProductImageOutput struct {
+	ID          uuid.UUID         `json:"id"`
+	ProductId   uuid.UUID         `json:"productId"`
+	URL         string            `json:"url"`
+	ContentType string            `json:"contentType"`
+	Size        int64             `json:"size"`
+	Position    int32             `json:"position"`
+	IsPrimary   bool              `json:"isPrimary"`
+	Thumbnails  []ThumbnailOutput `json:"thumbnails"`
+	CreatedAt   pgtype.Timestamp  `json:"createdAt"`
+}
+
+// ThumbnailOutput links to a thumbnail of an image in the format of the
+// original and in WebP, so that clients can pick the one they support.
+type ThumbnailOutput struct {
+	Size         string `json:"size"`
+	MaxDimension int    `json:"maxDimension"`
+	URL          string `json:"url"`
+	WebpURL      string `json:"webpUrl"`
+}
+
+// ProductImage For Swagger Docs
+type ProductImage struct {
+	ID          uuid.UUID         `json:"id"`
+	ProductId   uuid.UUID         `json:"productId"`
+	URL         string            `json:"url"`
+	ContentType string            `json:"contentType"`
+	Size        int64             `json:"size"`
+	Position    int32             `json:"position"`
+	IsPrimary   bool              `json:"isPrimary"`
+	Thumbnails  []ThumbnailOutput `json:"thumbnails"`
+	CreatedAt   time.Time         `json:"createdAt"`
+}
+
+// ProductImageError For Swagger Docs
+type ProductImageError struct {
+	Status  string                 `json:"status"`
+	Message string                 `json:"message"`
+	Error   ProductImageErrMessage `json:"error"`
+}
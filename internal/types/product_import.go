@@ -0,0 +1,44 @@
+package types
+
+import (
+	"github.com/google/uuid"
+)
+
+type ProductImportErrMessage struct {
+	File string                  `json:"file,omitempty"`
+	Rows []ProductImportRowError `json:"rows,omitempty"`
+}
+
+// ProductImportRowError lists the invalid fields of the product on a line of
+// the imported file.
+type ProductImportRowError struct {
+	Line  int               `json:"line"`
+	Error ProductErrMessage `json:"error"`
+}
+
+// ProductImportOutput summarises an import. An async import only has the id
+// of the job saving it, see GET /admin/jobs/{jobId}.
+type ProductImportOutput struct {
+	JobId    *uuid.UUID            `json:"jobId,omitempty"`
+	DryRun   bool                  `json:"dryRun"`
+	Created  int                   `json:"created"`
+	Updated  int                   `json:"updated"`
+	Products []ProductImportResult `json:"products"`
+}
+
+// ProductImportResult tells what became of the product on a line of the
+// imported file. Products created by a dry run have no id.
+type ProductImportResult struct {
+	Line   int        `json:"line"`
+	Action string     `json:"action"`
+	ID     *uuid.UUID `json:"id,omitempty"`
+	Name   string     `json:"name"`
+	Sku    string     `json:"sku"`
+}
+
+// ProductImportError For Swagger Docs
+type ProductImportError struct {
+	Status  string                  `json:"status"`
+	Message string                  `json:"message"`
+	Error   ProductImportErrMessage `json:"error"`
+}
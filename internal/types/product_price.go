@@ -0,0 +1,51 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ProductPriceInput prices a product from EffectiveFrom, now when it is not
+// set, until EffectiveTo, for good when it is not set.
+type ProductPriceInput struct {
+	Price          float64    `json:"price"`
+	CompareAtPrice *float64   `json:"compareAtPrice,omitempty"`
+	EffectiveFrom  *time.Time `json:"effectiveFrom,omitempty"`
+	EffectiveTo    *time.Time `json:"effectiveTo,omitempty"`
+}
+
+type ProductPriceErrMessage struct {
+	ID             string `json:"id,omitempty"`
+	ProductId      string `json:"productId,omitempty"`
+	Price          string `json:"price,omitempty"`
+	CompareAtPrice string `json:"compareAtPrice,omitempty"`
+	EffectiveFrom  string `json:"effectiveFrom,omitempty"`
+	EffectiveTo    string `json:"effectiveTo,omitempty"`
+	Limit          string `json:"limit,omitempty"`
+}
+
+// ProductPrice For Swagger Docs
+type ProductPrice struct {
+	ID             uuid.UUID  `json:"id"`
+	ProductId      uuid.UUID  `json:"productId"`
+	Price          float64    `json:"price"`
+	CompareAtPrice *float64   `json:"compareAtPrice"`
+	EffectiveFrom  time.Time  `json:"effectiveFrom"`
+	EffectiveTo    *time.Time `json:"effectiveTo"`
+	CreatedBy      *uuid.UUID `json:"createdBy"`
+	CancelledAt    *time.Time `json:"cancelledAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// ScheduledProductPrice For Swagger Docs
+type ScheduledProductPrice struct {
+	Price   ProductPrice `json:"price"`
+	Product Product      `json:"product"`
+}
+
+// ProductPriceError For Swagger Docs
+type ProductPriceError struct {
+	Status  string                 `json:"status"`
+	Message string                 `json:"message"`
+	Error   ProductPriceErrMessage `json:"error"`
+}
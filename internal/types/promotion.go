@@ -0,0 +1,104 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/promotion"
+	"time"
+)
+
+type CreatePromotionInput struct {
+	Name        string           `json:"name"`
+	Kind        string           `json:"kind"`
+	Priority    int32            `json:"priority"`
+	Stackable   bool             `json:"stackable"`
+	ProductIds  []uuid.UUID      `json:"productIds"`
+	Categories  []string         `json:"categories"`
+	BuyQuantity int32            `json:"buyQuantity"`
+	GetQuantity int32            `json:"getQuantity"`
+	Percent     float64          `json:"percent"`
+	BundlePrice float64          `json:"bundlePrice"`
+	Tiers       []promotion.Tier `json:"tiers"`
+	StartsAt    *time.Time       `json:"startsAt,omitempty"`
+	ExpiresAt   *time.Time       `json:"expiresAt,omitempty"`
+	Active      *bool            `json:"active,omitempty"`
+}
+
+type PromotionUpdateInput struct {
+	Name        *string           `json:"name,omitempty"`
+	Kind        *string           `json:"kind,omitempty"`
+	Priority    *int32            `json:"priority,omitempty"`
+	Stackable   *bool             `json:"stackable,omitempty"`
+	ProductIds  *[]uuid.UUID      `json:"productIds,omitempty"`
+	Categories  *[]string         `json:"categories,omitempty"`
+	BuyQuantity *int32            `json:"buyQuantity,omitempty"`
+	GetQuantity *int32            `json:"getQuantity,omitempty"`
+	Percent     *float64          `json:"percent,omitempty"`
+	BundlePrice *float64          `json:"bundlePrice,omitempty"`
+	Tiers       *[]promotion.Tier `json:"tiers,omitempty"`
+	StartsAt    *time.Time        `json:"startsAt,omitempty"`
+	ExpiresAt   *time.Time        `json:"expiresAt,omitempty"`
+	Active      *bool             `json:"active,omitempty"`
+}
+
+type PromotionErrMessage struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Kind        string `json:"kind,omitempty"`
+	Priority    string `json:"priority,omitempty"`
+	ProductIds  string `json:"productIds,omitempty"`
+	Categories  string `json:"categories,omitempty"`
+	BuyQuantity string `json:"buyQuantity,omitempty"`
+	GetQuantity string `json:"getQuantity,omitempty"`
+	Percent     string `json:"percent,omitempty"`
+	BundlePrice string `json:"bundlePrice,omitempty"`
+	Tiers       string `json:"tiers,omitempty"`
+	ExpiresAt   string `json:"expiresAt,omitempty"`
+}
+
+// Promotion For Swagger Docs
+type Promotion struct {
+	ID          uuid.UUID        `json:"id"`
+	Name        string           `json:"name"`
+	Kind        string           `json:"kind"`
+	Priority    int32            `json:"priority"`
+	Stackable   bool             `json:"stackable"`
+	ProductIds  []uuid.UUID      `json:"productIds"`
+	Categories  []string         `json:"categories"`
+	BuyQuantity int32            `json:"buyQuantity"`
+	GetQuantity int32            `json:"getQuantity"`
+	Percent     float64          `json:"percent"`
+	BundlePrice float64          `json:"bundlePrice"`
+	Tiers       []promotion.Tier `json:"tiers"`
+	StartsAt    *time.Time       `json:"startsAt"`
+	ExpiresAt   *time.Time       `json:"expiresAt"`
+	Active      bool             `json:"active"`
+	CreatedBy   uuid.UUID        `json:"createdBy"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
+}
+
+// PromotionError For Swagger Docs
+type PromotionError struct {
+	Status  string              `json:"status"`
+	Message string              `json:"message"`
+	Error   PromotionErrMessage `json:"error"`
+}
+
+// OrderPromotion For Swagger Docs
+type OrderPromotion struct {
+	ID          uuid.UUID  `json:"id"`
+	OrderId     uuid.UUID  `json:"orderId"`
+	PromotionId *uuid.UUID `json:"promotionId"`
+	Name        string     `json:"name"`
+	Kind        string     `json:"kind"`
+	Discount    float64    `json:"discount"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// AppliedPromotion For Swagger Docs
+type AppliedPromotion struct {
+	PromotionId uuid.UUID `json:"promotionId"`
+	Name        string    `json:"name"`
+	Kind        string    `json:"kind"`
+	Discount    float64   `json:"discount"`
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"time"
+)
+
+type ReturnItemInput struct {
+	ProductId string `json:"productId"`
+	Quantity  int32  `json:"quantity"`
+	Reason    string `json:"reason"`
+}
+
+type CreateReturnInput struct {
+	Items []ReturnItemInput `json:"items"`
+	Note  string            `json:"note,omitempty"`
+}
+
+type ReturnActionInput struct {
+	Note string `json:"note,omitempty"`
+}
+
+type ReturnErrMessage struct {
+	ID     string            `json:"id,omitempty"`
+	Status string            `json:"status,omitempty"`
+	Note   string            `json:"note,omitempty"`
+	Refund string            `json:"refund,omitempty"`
+	Items  map[string]string `json:"items,omitempty"`
+}
+
+// ReturnOutput is a return request together with its items and state history
+type ReturnOutput struct {
+	db.ReturnRequest
+	Items   []db.ReturnItem     `json:"items"`
+	History []ReturnEventOutput `json:"history"`
+}
+
+// ReturnEventOutput is a single state transition of a return. FromStatus is
+// empty for the transition that opened the return
+type ReturnEventOutput struct {
+	ID         uuid.UUID        `json:"id"`
+	ReturnId   uuid.UUID        `json:"returnId"`
+	FromStatus string           `json:"fromStatus"`
+	ToStatus   db.ReturnStatus  `json:"toStatus"`
+	Note       string           `json:"note"`
+	ActorId    uuid.UUID        `json:"actorId"`
+	CreatedAt  pgtype.Timestamp `json:"createdAt"`
+}
+
+// Return For Swagger Docs
+type Return struct {
+	ID              uuid.UUID     `json:"id"`
+	OrderId         uuid.UUID     `json:"orderId"`
+	UserId          uuid.UUID     `json:"userId"`
+	Status          string        `json:"status"`
+	RefundAmount    float64       `json:"refundAmount"`
+	RefundReference string        `json:"refundReference"`
+	RefundedAt      *time.Time    `json:"refundedAt"`
+	CreatedAt       time.Time     `json:"createdAt"`
+	UpdatedAt       time.Time     `json:"updatedAt"`
+	Items           []ReturnItem  `json:"items"`
+	History         []ReturnEvent `json:"history"`
+}
+
+// ReturnItem For Swagger Docs
+type ReturnItem struct {
+	ID          uuid.UUID `json:"id"`
+	ReturnId    uuid.UUID `json:"returnId"`
+	OrderItemId uuid.UUID `json:"orderItemId"`
+	ProductId   uuid.UUID `json:"productId"`
+	Quantity    int32     `json:"quantity"`
+	Reason      string    `json:"reason"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// ReturnEvent For Swagger Docs
+type ReturnEvent struct {
+	ID         uuid.UUID `json:"id"`
+	ReturnId   uuid.UUID `json:"returnId"`
+	FromStatus string    `json:"fromStatus"`
+	ToStatus   string    `json:"toStatus"`
+	Note       string    `json:"note"`
+	ActorId    uuid.UUID `json:"actorId"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// ReturnError For Swagger Docs
+type ReturnError struct {
+	Status  string           `json:"status"`
+	Message string           `json:"message"`
+	Error   ReturnErrMessage `json:"error"`
+}
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CreateReviewInput struct {
+	Rating int32  `json:"rating"`
+	Title  string `json:"title"`
+	Body   string `json:"body,omitempty"`
+}
+
+type ReviewErrMessage struct {
+	ID        string `json:"id,omitempty"`
+	ProductId string `json:"productId,omitempty"`
+	Rating    string `json:"rating,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Body      string `json:"body,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Limit     string `json:"limit,omitempty"`
+}
+
+// Review For Swagger Docs
+type Review struct {
+	ID          uuid.UUID  `json:"id"`
+	ProductId   uuid.UUID  `json:"productId"`
+	UserId      uuid.UUID  `json:"userId"`
+	Rating      int32      `json:"rating"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	Status      string     `json:"status"`
+	ModeratedBy *uuid.UUID `json:"moderatedBy"`
+	ModeratedAt *time.Time `json:"moderatedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// ReviewError For Swagger Docs
+type ReviewError struct {
+	Status  string           `json:"status"`
+	Message string           `json:"message"`
+	Error   ReviewErrMessage `json:"error"`
+}
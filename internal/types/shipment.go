@@ -0,0 +1,64 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"time"
+)
+
+type CreateShipmentInput struct {
+	Carrier        string `json:"carrier"`
+	TrackingNumber string `json:"trackingNumber"`
+	Items          []Item `json:"items,omitempty"`
+}
+
+type ShipmentUpdateInput struct {
+	Carrier        *string `json:"carrier,omitempty"`
+	TrackingNumber *string `json:"trackingNumber,omitempty"`
+	Delivered      *bool   `json:"delivered,omitempty"`
+}
+
+type ShipmentErrMessage struct {
+	ID             string            `json:"id,omitempty"`
+	Carrier        string            `json:"carrier,omitempty"`
+	TrackingNumber string            `json:"trackingNumber,omitempty"`
+	Status         string            `json:"status,omitempty"`
+	Items          map[string]string `json:"items,omitempty"`
+}
+
+// ShipmentOutput is a shipment together with the order items it contains
+type ShipmentOutput struct {
+	db.Shipment
+	Items []db.ShipmentItem `json:"items"`
+}
+
+// Shipment For Swagger Docs
+type Shipment struct {
+	ID             uuid.UUID      `json:"id"`
+	OrderId        uuid.UUID      `json:"orderId"`
+	Carrier        string         `json:"carrier"`
+	TrackingNumber string         `json:"trackingNumber"`
+	ShippedAt      time.Time      `json:"shippedAt"`
+	DeliveredAt    *time.Time     `json:"deliveredAt"`
+	CreatedBy      uuid.UUID      `json:"createdBy"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
+	Items          []ShipmentItem `json:"items"`
+}
+
+// ShipmentItem For Swagger Docs
+type ShipmentItem struct {
+	ID          uuid.UUID `json:"id"`
+	ShipmentId  uuid.UUID `json:"shipmentId"`
+	OrderItemId uuid.UUID `json:"orderItemId"`
+	ProductId   uuid.UUID `json:"productId"`
+	Quantity    int32     `json:"quantity"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// ShipmentError For Swagger Docs
+type ShipmentError struct {
+	Status  string             `json:"status"`
+	Message string             `json:"message"`
+	Error   ShipmentErrMessage `json:"error"`
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CreateShippingZoneInput struct {
+	Name    string   `json:"name"`
+	Regions []string `json:"regions"`
+}
+
+type ShippingZoneUpdateInput struct {
+	Name    *string   `json:"name,omitempty"`
+	Regions *[]string `json:"regions,omitempty"`
+}
+
+type ShippingZoneErrMessage struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Regions string `json:"regions,omitempty"`
+}
+
+type CreateShippingMethodInput struct {
+	ZoneId    string  `json:"zoneId"`
+	Name      string  `json:"name"`
+	RateType  string  `json:"rateType"`
+	Rate      float64 `json:"rate"`
+	RatePerKg float64 `json:"ratePerKg"`
+	FreeOver  float64 `json:"freeOver"`
+	Active    *bool   `json:"active,omitempty"`
+}
+
+type ShippingMethodUpdateInput struct {
+	Name      *string  `json:"name,omitempty"`
+	RateType  *string  `json:"rateType,omitempty"`
+	Rate      *float64 `json:"rate,omitempty"`
+	RatePerKg *float64 `json:"ratePerKg,omitempty"`
+	FreeOver  *float64 `json:"freeOver,omitempty"`
+	Active    *bool    `json:"active,omitempty"`
+}
+
+type ShippingMethodErrMessage struct {
+	ID        string `json:"id,omitempty"`
+	ZoneId    string `json:"zoneId,omitempty"`
+	Name      string `json:"name,omitempty"`
+	RateType  string `json:"rateType,omitempty"`
+	Rate      string `json:"rate,omitempty"`
+	RatePerKg string `json:"ratePerKg,omitempty"`
+	FreeOver  string `json:"freeOver,omitempty"`
+}
+
+// Address is where a basket is shipped to. Country is an ISO 3166 country code
+// and State an optional subdivision code, e.g. US and CA.
+type Address struct {
+	Country string `json:"country"`
+	State   string `json:"state,omitempty"`
+}
+
+type ShippingQuoteInput struct {
+	Items   []Item  `json:"items"`
+	Address Address `json:"address"`
+}
+
+type ShippingQuoteErrMessage struct {
+	Items   map[string]string `json:"items,omitempty"`
+	Address string            `json:"address,omitempty"`
+}
+
+// ShippingZone For Swagger Docs
+type ShippingZone struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Regions   []string  `json:"regions"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ShippingZoneError For Swagger Docs
+type ShippingZoneError struct {
+	Status  string                 `json:"status"`
+	Message string                 `json:"message"`
+	Error   ShippingZoneErrMessage `json:"error"`
+}
+
+// ShippingMethod For Swagger Docs
+type ShippingMethod struct {
+	ID        uuid.UUID `json:"id"`
+	ZoneId    uuid.UUID `json:"zoneId"`
+	Name      string    `json:"name"`
+	RateType  string    `json:"rateType"`
+	Rate      float64   `json:"rate"`
+	RatePerKg float64   `json:"ratePerKg"`
+	FreeOver  float64   `json:"freeOver"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ShippingMethodError For Swagger Docs
+type ShippingMethodError struct {
+	Status  string                   `json:"status"`
+	Message string                   `json:"message"`
+	Error   ShippingMethodErrMessage `json:"error"`
+}
+
+// ShippingRate For Swagger Docs
+type ShippingRate struct {
+	ShippingMethod
+	Cost float64 `json:"cost"`
+}
+
+// ShippingQuote For Swagger Docs
+type ShippingQuote struct {
+	Region   string         `json:"region"`
+	Zone     ShippingZone   `json:"zone"`
+	Subtotal float64        `json:"subtotal"`
+	Weight   float64        `json:"weight"`
+	Rates    []ShippingRate `json:"rates"`
+}
+
+// ShippingQuoteError For Swagger Docs
+type ShippingQuoteError struct {
+	Status  string                  `json:"status"`
+	Message string                  `json:"message"`
+	Error   ShippingQuoteErrMessage `json:"error"`
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CreateTaxRuleInput struct {
+	Name      string  `json:"name"`
+	TaxClass  string  `json:"taxClass"`
+	Region    string  `json:"region"`
+	Rate      float64 `json:"rate"`
+	Inclusive bool    `json:"inclusive"`
+}
+
+type TaxRuleUpdateInput struct {
+	Name      *string  `json:"name,omitempty"`
+	TaxClass  *string  `json:"taxClass,omitempty"`
+	Region    *string  `json:"region,omitempty"`
+	Rate      *float64 `json:"rate,omitempty"`
+	Inclusive *bool    `json:"inclusive,omitempty"`
+}
+
+type TaxRuleErrMessage struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	TaxClass string `json:"taxClass,omitempty"`
+	Region   string `json:"region,omitempty"`
+	Rate     string `json:"rate,omitempty"`
+}
+
+// TaxRule For Swagger Docs
+type TaxRule struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	TaxClass  string    `json:"taxClass"`
+	Region    string    `json:"region"`
+	Rate      float64   `json:"rate"`
+	Inclusive bool      `json:"inclusive"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TaxRuleError For Swagger Docs
+type TaxRuleError struct {
+	Status  string            `json:"status"`
+	Message string            `json:"message"`
+	Error   TaxRuleErrMessage `json:"error"`
+}
+
+// OrderTax For Swagger Docs
+type OrderTax struct {
+	ID        uuid.UUID  `json:"id"`
+	OrderId   uuid.UUID  `json:"orderId"`
+	TaxRuleId *uuid.UUID `json:"taxRuleId"`
+	Name      string     `json:"name"`
+	Rate      float64    `json:"rate"`
+	Inclusive bool       `json:"inclusive"`
+	Amount    float64    `json:"amount"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CreateWarehouseInput struct {
+	Name     string `json:"name"`
+	Priority int32  `json:"priority"`
+	Active   *bool  `json:"active,omitempty"`
+}
+
+type WarehouseUpdateInput struct {
+	Name     *string `json:"name,omitempty"`
+	Priority *int32  `json:"priority,omitempty"`
+	Active   *bool   `json:"active,omitempty"`
+}
+
+type WarehouseStockInput struct {
+	Stock *int32  `json:"stock"`
+	Note  *string `json:"note,omitempty"`
+}
+
+type StockTransferInput struct {
+	ProductId       uuid.UUID `json:"productId"`
+	FromWarehouseId uuid.UUID `json:"fromWarehouseId"`
+	ToWarehouseId   uuid.UUID `json:"toWarehouseId"`
+	Quantity        int32     `json:"quantity"`
+	Note            string    `json:"note"`
+}
+
+type WarehouseErrMessage struct {
+	ID              string `json:"id,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Priority        string `json:"priority,omitempty"`
+	ProductId       string `json:"productId,omitempty"`
+	WarehouseId     string `json:"warehouseId,omitempty"`
+	OrderId         string `json:"orderId,omitempty"`
+	Stock           string `json:"stock,omitempty"`
+	Note            string `json:"note,omitempty"`
+	FromWarehouseId string `json:"fromWarehouseId,omitempty"`
+	ToWarehouseId   string `json:"toWarehouseId,omitempty"`
+	Quantity        string `json:"quantity,omitempty"`
+	Limit           string `json:"limit,omitempty"`
+}
+
+// Warehouse For Swagger Docs
+type Warehouse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Priority  int32     `json:"priority"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// WarehouseStock For Swagger Docs
+type WarehouseStock struct {
+	ProductId uuid.UUID `json:"productId"`
+	Name      string    `json:"name"`
+	Sku       string    `json:"sku"`
+	Stock     int32     `json:"stock"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ProductWarehouseStock For Swagger Docs
+type ProductWarehouseStock struct {
+	WarehouseId   uuid.UUID `json:"warehouseId"`
+	WarehouseName string    `json:"warehouseName"`
+	Priority      int32     `json:"priority"`
+	Active        bool      `json:"active"`
+	Stock         int32     `json:"stock"`
+}
+
+// OrderAllocation For Swagger Docs
+type OrderAllocation struct {
+	ID            uuid.UUID `json:"id"`
+	OrderItemId   uuid.UUID `json:"orderItemId"`
+	ProductId     uuid.UUID `json:"productId"`
+	WarehouseId   uuid.UUID `json:"warehouseId"`
+	WarehouseName string    `json:"warehouseName"`
+	Quantity      int32     `json:"quantity"`
+}
+
+// StockTransfer For Swagger Docs
+type StockTransfer struct {
+	ID              uuid.UUID `json:"id"`
+	ProductId       uuid.UUID `json:"productId"`
+	FromWarehouseId uuid.UUID `json:"fromWarehouseId"`
+	ToWarehouseId   uuid.UUID `json:"toWarehouseId"`
+	Quantity        int32     `json:"quantity"`
+	Note            string    `json:"note"`
+	CreatedBy       uuid.UUID `json:"createdBy"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// WarehouseError For Swagger Docs
+type WarehouseError struct {
+	Status  string              `json:"status"`
+	Message string              `json:"message"`
+	Error   WarehouseErrMessage `json:"error"`
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CreateWebhookInput struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret,omitempty"`
+	Events []string `json:"events"`
+	Active *bool    `json:"active,omitempty"`
+}
+
+type WebhookUpdateInput struct {
+	URL    *string   `json:"url,omitempty"`
+	Secret *string   `json:"secret,omitempty"`
+	Events *[]string `json:"events,omitempty"`
+	Active *bool     `json:"active,omitempty"`
+}
+
+type WebhookErrMessage struct {
+	ID     string `json:"id,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Secret string `json:"secret,omitempty"`
+	Events string `json:"events,omitempty"`
+	Limit  string `json:"limit,omitempty"`
+}
+
+// Webhook For Swagger Docs
+type Webhook struct {
+	ID        uuid.UUID `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedBy uuid.UUID `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// WebhookDelivery For Swagger Docs
+type WebhookDelivery struct {
+	ID             uuid.UUID  `json:"id"`
+	WebhookId      uuid.UUID  `json:"webhookId"`
+	EventId        uuid.UUID  `json:"eventId"`
+	EventType      string     `json:"eventType"`
+	Status         string     `json:"status"`
+	Attempts       int32      `json:"attempts"`
+	ResponseStatus int32      `json:"responseStatus"`
+	ResponseBody   string     `json:"responseBody"`
+	LastError      string     `json:"lastError"`
+	DeliveredAt    *time.Time `json:"deliveredAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// WebhookError For Swagger Docs
+type WebhookError struct {
+	Status  string            `json:"status"`
+	Message string            `json:"message"`
+	Error   WebhookErrMessage `json:"error"`
+}
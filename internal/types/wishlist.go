@@ -0,0 +1,92 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"time"
+)
+
+type WishlistInput struct {
+	Name string `json:"name"`
+}
+
+type WishlistItemInput struct {
+	ProductId string `json:"productId"`
+	Quantity  int32  `json:"quantity,omitempty"`
+}
+
+// OrderWishlistInput places an order for the products of a wishlist, only
+// those in ProductIds when it is set.
+type OrderWishlistInput struct {
+	ProductIds       []string `json:"productIds,omitempty"`
+	CouponCode       string   `json:"couponCode,omitempty"`
+	ShippingRegion   string   `json:"shippingRegion,omitempty"`
+	ShippingMethodId string   `json:"shippingMethodId,omitempty"`
+}
+
+type WishlistErrMessage struct {
+	ID         string            `json:"id,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	ProductId  string            `json:"productId,omitempty"`
+	Quantity   string            `json:"quantity,omitempty"`
+	ProductIds string            `json:"productIds,omitempty"`
+	Token      string            `json:"token,omitempty"`
+	Items      map[string]string `json:"items,omitempty"`
+}
+
+// WishlistOutput is a wishlist along with its products.
+type WishlistOutput struct {
+	db.Wishlist
+	Items []db.GetWishlistItemsRow `json:"items"`
+}
+
+// SharedWishlistOutput is the read-only view of a wishlist shared with a
+// link, which does not tell whose wishlist it is.
+type SharedWishlistOutput struct {
+	Name  string                   `json:"name"`
+	Items []db.GetWishlistItemsRow `json:"items"`
+}
+
+// BackInStockJobArgs is the payload of the jobs emailing a customer that a
+// product on their wishlist is back in stock
+type BackInStockJobArgs struct {
+	ProductId uuid.UUID `json:"productId"`
+	Email     string    `json:"email"`
+}
+
+// WishlistItem For Swagger Docs
+type WishlistItem struct {
+	ID         uuid.UUID `json:"id"`
+	WishlistId uuid.UUID `json:"wishlistId"`
+	ProductId  uuid.UUID `json:"productId"`
+	Quantity   int32     `json:"quantity"`
+	CreatedAt  time.Time `json:"createdAt"`
+	Name       string    `json:"name"`
+	Price      float64   `json:"price"`
+	Stock      int32     `json:"stock"`
+	Archived   bool      `json:"archived"`
+}
+
+// Wishlist For Swagger Docs
+type Wishlist struct {
+	ID         uuid.UUID      `json:"id"`
+	UserId     uuid.UUID      `json:"userId"`
+	Name       string         `json:"name"`
+	ShareToken *string        `json:"shareToken"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+	Items      []WishlistItem `json:"items"`
+}
+
+// SharedWishlist For Swagger Docs
+type SharedWishlist struct {
+	Name  string         `json:"name"`
+	Items []WishlistItem `json:"items"`
+}
+
+// WishlistError For Swagger Docs
+type WishlistError struct {
+	Status  string             `json:"status"`
+	Message string             `json:"message"`
+	Error   WishlistErrMessage `json:"error"`
+}
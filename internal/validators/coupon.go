@@ -0,0 +1,140 @@
+package validators
+
+import (
+	"errors"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/coupon"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"regexp"
+	"time"
+)
+
+var couponCodeRegex = regexp.MustCompile(`^[A-Z0-9_-]{3,50}$`)
+
+// ValidateCouponCode checks if the Code is made up of 3 to 50 upper case letters, digits, dashes or underscores
+func ValidateCouponCode(code string) string {
+	var msg string
+	if !couponCodeRegex.MatchString(code) {
+		msg = "code must be 3 to 50 upper case letters, digits, dashes or underscores"
+	}
+	return msg
+}
+
+// ValidateDiscountType checks if the DiscountType is one of PERCENTAGE or FIXED
+func ValidateDiscountType(discountType string) string {
+	var msg string
+	if discountType != coupon.Percentage && discountType != coupon.Fixed {
+		msg = "discountType must be one of PERCENTAGE or FIXED"
+	}
+	return msg
+}
+
+// ValidateDiscountValue checks if the Value is greater than 0 and a percentage is not more than 100
+func ValidateDiscountValue(discountType string, value float64) string {
+	var msg string
+	if value <= 0 {
+		msg = "value must be greater than 0"
+	} else if discountType == coupon.Percentage && value > 100 {
+		msg = "value must not be more than 100 for a percentage discount"
+	}
+	return msg
+}
+
+// ValidateNonNegative checks if a coupon amount or limit is not negative
+func ValidateNonNegative(field string, value float64) string {
+	var msg string
+	if value < 0 {
+		msg = field + " cannot be negative"
+	}
+	return msg
+}
+
+// ValidateValidityWindow checks if the coupon expires after it starts
+func ValidateValidityWindow(startsAt, expiresAt *time.Time) string {
+	var msg string
+	if startsAt != nil && expiresAt != nil && !expiresAt.After(*startsAt) {
+		msg = "expiresAt must be after startsAt"
+	}
+	return msg
+}
+
+// ValidateCategories checks if every category is within length constraints
+func ValidateCategories(categories []string) string {
+	for _, category := range categories {
+		if category == "" {
+			return "categories cannot contain an empty category"
+		}
+		if msg := ValidateCategory(category); msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+// ValidateCoupon validates the CreateCouponInput struct
+func ValidateCoupon(input types.CreateCouponInput) (types.CouponErrMessage, error) {
+	errMessage := types.CouponErrMessage{
+		Code:          ValidateCouponCode(input.Code),
+		DiscountType:  ValidateDiscountType(input.DiscountType),
+		Value:         ValidateDiscountValue(input.DiscountType, input.Value),
+		MinOrderValue: ValidateNonNegative("minOrderValue", input.MinOrderValue),
+		Categories:    ValidateCategories(input.Categories),
+		UsageLimit:    ValidateNonNegative("usageLimit", float64(input.UsageLimit)),
+		PerUserLimit:  ValidateNonNegative("perUserLimit", float64(input.PerUserLimit)),
+		ExpiresAt:     ValidateValidityWindow(input.StartsAt, input.ExpiresAt),
+	}
+	if errMessage == (types.CouponErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid create coupon input")
+}
+
+// ValidateCouponUpdateInput validates the fields present in the CouponUpdateInput struct against the current coupon.
+// The discount type and value are checked together as the type decides the range of the value.
+func ValidateCouponUpdateInput(input types.CouponUpdateInput, current db.Coupon) (types.CouponErrMessage, error) {
+	var errMessage types.CouponErrMessage
+	discountType, value := string(current.DiscountType), current.Value
+	var startsAt, expiresAt *time.Time
+	if current.StartsAt.Valid {
+		startsAt = &current.StartsAt.Time
+	}
+	if current.ExpiresAt.Valid {
+		expiresAt = &current.ExpiresAt.Time
+	}
+	if input.Code != nil {
+		errMessage.Code = ValidateCouponCode(*input.Code)
+	}
+	if input.DiscountType != nil {
+		errMessage.DiscountType = ValidateDiscountType(*input.DiscountType)
+		discountType = *input.DiscountType
+	}
+	if input.Value != nil {
+		value = *input.Value
+	}
+	if input.DiscountType != nil || input.Value != nil {
+		errMessage.Value = ValidateDiscountValue(discountType, value)
+	}
+	if input.MinOrderValue != nil {
+		errMessage.MinOrderValue = ValidateNonNegative("minOrderValue", *input.MinOrderValue)
+	}
+	if input.Categories != nil {
+		errMessage.Categories = ValidateCategories(*input.Categories)
+	}
+	if input.UsageLimit != nil {
+		errMessage.UsageLimit = ValidateNonNegative("usageLimit", float64(*input.UsageLimit))
+	}
+	if input.PerUserLimit != nil {
+		errMessage.PerUserLimit = ValidateNonNegative("perUserLimit", float64(*input.PerUserLimit))
+	}
+	if input.StartsAt != nil {
+		startsAt = input.StartsAt
+	}
+	if input.ExpiresAt != nil {
+		expiresAt = input.ExpiresAt
+	}
+	errMessage.ExpiresAt = ValidateValidityWindow(startsAt, expiresAt)
+	if errMessage == (types.CouponErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid coupon input")
+}
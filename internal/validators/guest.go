@@ -0,0 +1,37 @@
+package validators
+
+import (
+	"errors"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+)
+
+// ValidateGuestAddress checks if the name, first line and city of the GuestAddress are set, every line is within
+// length constraints and the Country and State are valid codes
+func ValidateGuestAddress(address types.GuestAddress) string {
+	switch {
+	case address.Name == "" || len(address.Name) > 255:
+		return "name must be between 1 and 255 characters"
+	case address.Line1 == "" || len(address.Line1) > 255:
+		return "line1 must be between 1 and 255 characters"
+	case len(address.Line2) > 255:
+		return "line2 cannot be longer than 255 characters"
+	case address.City == "" || len(address.City) > 100:
+		return "city must be between 1 and 100 characters"
+	case len(address.PostalCode) > 20:
+		return "postalCode cannot be longer than 20 characters"
+	}
+	return ValidateAddress(types.Address{Country: address.Country, State: address.State})
+}
+
+// ValidateGuestOrder validates the email and address of the GuestOrderInput struct. The items are validated as those
+// of any order
+func ValidateGuestOrder(input types.GuestOrderInput) (types.GuestOrderErrMessage, error) {
+	errMessage := types.GuestOrderErrMessage{
+		Email:   ValidateEmail(input.Email),
+		Address: ValidateGuestAddress(input.Address),
+	}
+	if errMessage.Email == "" && errMessage.Address == "" {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid guest order input")
+}
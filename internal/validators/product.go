@@ -47,6 +47,76 @@ func ValidateStock(stock int) string {
 	return msg
 }
 
+// ValidateReorderThreshold checks if the ReorderThreshold is a non-negative integer
+func ValidateReorderThreshold(threshold int) string {
+	var msg string
+	if threshold < 0 {
+		msg = "reorderThreshold cannot be negative"
+	}
+	return msg
+}
+
+// ValidateBackorderPolicy checks if the BackorderPolicy is NONE, BACKORDER or PREORDER
+func ValidateBackorderPolicy(policy string) string {
+	var msg string
+	if policy != "NONE" && policy != "BACKORDER" && policy != "PREORDER" {
+		msg = "backorderPolicy must be NONE, BACKORDER or PREORDER"
+	}
+	return msg
+}
+
+// ValidateProductSort checks if the products can be sorted by sort, which is
+// empty for the default order
+func ValidateProductSort(sort string) string {
+	var msg string
+	if sort != "" && sort != "rating" {
+		msg = "sort must be rating"
+	}
+	return msg
+}
+
+// ValidateStockNote checks if the StockNote is within length constraints. An empty note is allowed
+func ValidateStockNote(note string) string {
+	var msg string
+	if len(note) > 255 {
+		msg = "stockNote must not be more than 255 characters"
+	}
+	return msg
+}
+
+// ValidateCategory checks if the Category is within length constraints. An empty category is allowed
+func ValidateCategory(category string) string {
+	var msg string
+	if len(category) > 100 {
+		msg = "category must not be more than 100 characters"
+	}
+	return msg
+}
+
+// ValidateWeight checks if the Weight in kilograms is not negative
+func ValidateWeight(weight float64) string {
+	var msg string
+	if weight < 0 {
+		msg = "weight cannot be negative"
+	}
+	return msg
+}
+
+// ValidateSku checks if the Sku is made of letters, digits, dashes, dots and underscores. An empty sku is allowed
+func ValidateSku(sku string) string {
+	var msg string
+	if len(sku) > 64 {
+		return "sku must not be more than 64 characters"
+	}
+	for _, c := range sku {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			msg = "sku can only contain letters, digits, dashes, dots and underscores"
+			break
+		}
+	}
+	return msg
+}
+
 // ValidateProduct validates the CreateProductInput struct
 func ValidateProduct(product types.CreateProductInput) (types.ProductErrMessage, error) {
 	errMessage := types.ProductErrMessage{
@@ -54,8 +124,18 @@ func ValidateProduct(product types.CreateProductInput) (types.ProductErrMessage,
 		Description: ValidateDescription(product.Description),
 		Price:       ValidatePrice(product.Price),
 		Stock:       ValidateStock(product.Stock),
+		Category:    ValidateCategory(product.Category),
+		TaxClass:    ValidateTaxClass(product.TaxClass),
+		Weight:      ValidateWeight(product.Weight),
+		Sku:         ValidateSku(product.Sku),
 	}
-	if errMessage.Name == "" && errMessage.Description == "" && errMessage.Price == "" && errMessage.Stock == "" {
+	if product.ReorderThreshold != nil {
+		errMessage.ReorderThreshold = ValidateReorderThreshold(*product.ReorderThreshold)
+	}
+	if product.BackorderPolicy != "" {
+		errMessage.BackorderPolicy = ValidateBackorderPolicy(product.BackorderPolicy)
+	}
+	if errMessage.Name == "" && errMessage.Description == "" && errMessage.Price == "" && errMessage.Stock == "" && errMessage.Category == "" && errMessage.TaxClass == "" && errMessage.Weight == "" && errMessage.Sku == "" && errMessage.ReorderThreshold == "" && errMessage.BackorderPolicy == "" {
 		return errMessage, nil
 	}
 	return errMessage, errors.New("invalid create product input")
@@ -85,7 +165,42 @@ func ValidateProductUpdateInput(product types.ProductUpdateInput) (types.Product
 			errMessage.Stock = msg
 		}
 	}
-	if errMessage.Name == "" && errMessage.Description == "" && errMessage.Price == "" && errMessage.Stock == "" {
+	if product.Category != nil {
+		if msg := ValidateCategory(*product.Category); msg != "" {
+			errMessage.Category = msg
+		}
+	}
+	if product.TaxClass != nil {
+		if msg := ValidateTaxClass(*product.TaxClass); msg != "" {
+			errMessage.TaxClass = msg
+		}
+	}
+	if product.Weight != nil {
+		if msg := ValidateWeight(*product.Weight); msg != "" {
+			errMessage.Weight = msg
+		}
+	}
+	if product.Sku != nil {
+		if msg := ValidateSku(*product.Sku); msg != "" {
+			errMessage.Sku = msg
+		}
+	}
+	if product.ReorderThreshold != nil {
+		if msg := ValidateReorderThreshold(int(*product.ReorderThreshold)); msg != "" {
+			errMessage.ReorderThreshold = msg
+		}
+	}
+	if product.StockNote != nil {
+		if msg := ValidateStockNote(*product.StockNote); msg != "" {
+			errMessage.StockNote = msg
+		}
+	}
+	if product.BackorderPolicy != nil {
+		if msg := ValidateBackorderPolicy(*product.BackorderPolicy); msg != "" {
+			errMessage.BackorderPolicy = msg
+		}
+	}
+	if errMessage.Name == "" && errMessage.Description == "" && errMessage.Price == "" && errMessage.Stock == "" && errMessage.Category == "" && errMessage.TaxClass == "" && errMessage.Weight == "" && errMessage.Sku == "" && errMessage.ReorderThreshold == "" && errMessage.StockNote == "" && errMessage.BackorderPolicy == "" {
 		return errMessage, nil
 	}
 	return errMessage, errors.New("invalid product input")
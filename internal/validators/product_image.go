@@ -0,0 +1,32 @@
+package validators
+
+import "fmt"
+
+// MaxImageSize is the largest image file that can be uploaded, in bytes
+const MaxImageSize = 5 << 20
+
+// ImageExtensions maps the image types that can be uploaded to the file extension they are stored with
+var ImageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// ValidateImageSize checks if the image is non-empty and not larger than MaxImageSize
+func ValidateImageSize(size int64) string {
+	var msg string
+	if size <= 0 || size > MaxImageSize {
+		msg = fmt.Sprintf("image must be between 1 byte and %d MB", MaxImageSize>>20)
+	}
+	return msg
+}
+
+// ValidateImageContentType checks if the content type is one of the image types that can be uploaded
+func ValidateImageContentType(contentType string) string {
+	var msg string
+	if _, ok := ImageExtensions[contentType]; !ok {
+		msg = "image must be a JPEG, PNG, GIF or WebP file"
+	}
+	return msg
+}
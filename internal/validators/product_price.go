@@ -0,0 +1,37 @@
+package validators
+
+import (
+	"errors"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"time"
+)
+
+// ValidateCompareAtPrice checks if the CompareAtPrice is more than the Price it is shown reduced from
+func ValidateCompareAtPrice(compareAtPrice, price float64) string {
+	var msg string
+	if compareAtPrice <= price {
+		msg = "compareAtPrice must be greater than price"
+	}
+	return msg
+}
+
+// ValidateProductPrice validates the ProductPriceInput struct, EffectiveFrom being set
+func ValidateProductPrice(input types.ProductPriceInput, now time.Time) (types.ProductPriceErrMessage, error) {
+	errMessage := types.ProductPriceErrMessage{
+		Price: ValidatePrice(input.Price),
+	}
+	if input.CompareAtPrice != nil {
+		errMessage.CompareAtPrice = ValidateCompareAtPrice(*input.CompareAtPrice, input.Price)
+	}
+	if input.EffectiveTo != nil {
+		if !input.EffectiveTo.After(*input.EffectiveFrom) {
+			errMessage.EffectiveTo = "effectiveTo must be after effectiveFrom"
+		} else if !input.EffectiveTo.After(now) {
+			errMessage.EffectiveTo = "effectiveTo must be in the future"
+		}
+	}
+	if errMessage == (types.ProductPriceErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid product price input")
+}
@@ -0,0 +1,114 @@
+package validators
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/promotion"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+)
+
+// ValidatePromotionName checks if the Name is between 1 and 100 characters
+func ValidatePromotionName(name string) string {
+	var msg string
+	if name == "" || len(name) > 100 {
+		msg = "name must be between 1 and 100 characters"
+	}
+	return msg
+}
+
+// ValidatePromotionKind checks if the Kind is one of BUY_X_GET_Y, BUNDLE, QUANTITY_TIER or CATEGORY_SALE
+func ValidatePromotionKind(kind string) string {
+	var msg string
+	switch kind {
+	case promotion.BuyXGetY, promotion.Bundle, promotion.QuantityTier, promotion.CategorySale:
+	default:
+		msg = "kind must be one of BUY_X_GET_Y, BUNDLE, QUANTITY_TIER or CATEGORY_SALE"
+	}
+	return msg
+}
+
+// ValidatePercent checks if a percentage taken off is greater than 0 and not more than 100
+func ValidatePercent(field string, percent float64) string {
+	var msg string
+	if percent <= 0 || percent > 100 {
+		msg = field + " must be greater than 0 and not more than 100"
+	}
+	return msg
+}
+
+// ValidateBundleProducts checks if a bundle is made up of at least two distinct products
+func ValidateBundleProducts(productIds []uuid.UUID) string {
+	seen := make(map[uuid.UUID]bool, len(productIds))
+	for _, id := range productIds {
+		if seen[id] {
+			return "productIds cannot contain the same product twice"
+		}
+		seen[id] = true
+	}
+	if len(productIds) < 2 {
+		return "productIds must contain at least two products for a bundle"
+	}
+	return ""
+}
+
+// ValidateTiers checks if there is at least one tier and every tier has a distinct minimum quantity of at least 1
+func ValidateTiers(tiers []promotion.Tier) string {
+	if len(tiers) == 0 {
+		return "tiers must contain at least one tier"
+	}
+	seen := make(map[int32]bool, len(tiers))
+	for _, tier := range tiers {
+		if tier.MinQuantity < 1 {
+			return "minQuantity of every tier must be at least 1"
+		}
+		if seen[tier.MinQuantity] {
+			return "tiers cannot share the same minQuantity"
+		}
+		seen[tier.MinQuantity] = true
+		if msg := ValidatePercent("percent of every tier", tier.Percent); msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+// ValidatePromotion validates the CreatePromotionInput struct. The fields a
+// promotion uses depend on its kind, so they are checked against the kind.
+func ValidatePromotion(input types.CreatePromotionInput) (types.PromotionErrMessage, error) {
+	errMessage := types.PromotionErrMessage{
+		Name:       ValidatePromotionName(input.Name),
+		Kind:       ValidatePromotionKind(input.Kind),
+		Priority:   ValidateNonNegative("priority", float64(input.Priority)),
+		Categories: ValidateCategories(input.Categories),
+		ExpiresAt:  ValidateValidityWindow(input.StartsAt, input.ExpiresAt),
+	}
+	switch input.Kind {
+	case promotion.BuyXGetY:
+		if input.BuyQuantity < 1 {
+			errMessage.BuyQuantity = "buyQuantity must be at least 1"
+		}
+		if input.GetQuantity < 1 {
+			errMessage.GetQuantity = "getQuantity must be at least 1"
+		}
+		errMessage.Percent = ValidatePercent("percent", input.Percent)
+	case promotion.Bundle:
+		errMessage.ProductIds = ValidateBundleProducts(input.ProductIds)
+		if len(input.Categories) > 0 {
+			errMessage.Categories = "categories cannot be set for a bundle"
+		}
+		if input.BundlePrice <= 0 {
+			errMessage.BundlePrice = "bundlePrice must be greater than 0"
+		}
+	case promotion.QuantityTier:
+		errMessage.Tiers = ValidateTiers(input.Tiers)
+	case promotion.CategorySale:
+		if len(input.Categories) == 0 && errMessage.Categories == "" {
+			errMessage.Categories = "categories must contain at least one category for a category sale"
+		}
+		errMessage.Percent = ValidatePercent("percent", input.Percent)
+	}
+	if errMessage == (types.PromotionErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid promotion input")
+}
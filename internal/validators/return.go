@@ -0,0 +1,19 @@
+package validators
+
+// ValidateReturnReason checks if the Reason is non-empty and within length constraints
+func ValidateReturnReason(reason string) string {
+	var msg string
+	if reason == "" || len(reason) > 500 {
+		msg = "reason must be between 1 and 500 characters"
+	}
+	return msg
+}
+
+// ValidateReturnNote checks if the Note is within length constraints. An empty note is allowed
+func ValidateReturnNote(note string) string {
+	var msg string
+	if len(note) > 500 {
+		msg = "note must not be more than 500 characters"
+	}
+	return msg
+}
@@ -0,0 +1,55 @@
+package validators
+
+import (
+	"errors"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+)
+
+// ValidateReviewRating checks if the Rating is between 1 and 5 stars
+func ValidateReviewRating(rating int32) string {
+	var msg string
+	if rating < 1 || rating > 5 {
+		msg = "rating must be between 1 and 5"
+	}
+	return msg
+}
+
+// ValidateReviewTitle checks if the Title is non-empty and within length constraints
+func ValidateReviewTitle(title string) string {
+	var msg string
+	if title == "" || len(title) > 150 {
+		msg = "title must be between 1 and 150 characters"
+	}
+	return msg
+}
+
+// ValidateReviewBody checks if the Body is within length constraints. An empty body is allowed
+func ValidateReviewBody(body string) string {
+	var msg string
+	if len(body) > 5000 {
+		msg = "body must not be more than 5000 characters"
+	}
+	return msg
+}
+
+// ValidateReviewStatus checks if the Status is PENDING, APPROVED or REJECTED
+func ValidateReviewStatus(status string) string {
+	var msg string
+	if status != "PENDING" && status != "APPROVED" && status != "REJECTED" {
+		msg = "status must be PENDING, APPROVED or REJECTED"
+	}
+	return msg
+}
+
+// ValidateReview validates the CreateReviewInput struct
+func ValidateReview(input types.CreateReviewInput) (types.ReviewErrMessage, error) {
+	errMessage := types.ReviewErrMessage{
+		Rating: ValidateReviewRating(input.Rating),
+		Title:  ValidateReviewTitle(input.Title),
+		Body:   ValidateReviewBody(input.Body),
+	}
+	if errMessage == (types.ReviewErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid create review input")
+}
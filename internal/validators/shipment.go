@@ -0,0 +1,51 @@
+package validators
+
+import (
+	"errors"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+)
+
+// ValidateCarrier checks if the Carrier is non-empty and within length constraints
+func ValidateCarrier(carrier string) string {
+	var msg string
+	if carrier == "" || len(carrier) > 100 {
+		msg = "carrier must be between 1 and 100 characters"
+	}
+	return msg
+}
+
+// ValidateTrackingNumber checks if the TrackingNumber is within length constraints. An empty tracking number is allowed
+func ValidateTrackingNumber(trackingNumber string) string {
+	var msg string
+	if len(trackingNumber) > 100 {
+		msg = "trackingNumber must not be more than 100 characters"
+	}
+	return msg
+}
+
+// ValidateShipment validates the carrier and tracking number of the CreateShipmentInput struct
+func ValidateShipment(input types.CreateShipmentInput) (types.ShipmentErrMessage, error) {
+	errMessage := types.ShipmentErrMessage{
+		Carrier:        ValidateCarrier(input.Carrier),
+		TrackingNumber: ValidateTrackingNumber(input.TrackingNumber),
+	}
+	if errMessage.Carrier == "" && errMessage.TrackingNumber == "" {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid create shipment input")
+}
+
+// ValidateShipmentUpdateInput validates the fields present in the ShipmentUpdateInput struct
+func ValidateShipmentUpdateInput(input types.ShipmentUpdateInput) (types.ShipmentErrMessage, error) {
+	var errMessage types.ShipmentErrMessage
+	if input.Carrier != nil {
+		errMessage.Carrier = ValidateCarrier(*input.Carrier)
+	}
+	if input.TrackingNumber != nil {
+		errMessage.TrackingNumber = ValidateTrackingNumber(*input.TrackingNumber)
+	}
+	if errMessage.Carrier == "" && errMessage.TrackingNumber == "" {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid shipment input")
+}
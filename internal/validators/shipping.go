@@ -0,0 +1,131 @@
+package validators
+
+import (
+	"errors"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/shipping"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"regexp"
+)
+
+var (
+	countryRegex = regexp.MustCompile(`^[A-Z]{2}$`)
+	stateRegex   = regexp.MustCompile(`^[A-Z0-9]{1,3}$`)
+)
+
+// ValidateShippingName checks if the Name of a shipping zone or method is non-empty and within length constraints
+func ValidateShippingName(name string) string {
+	var msg string
+	if name == "" || len(name) > 100 {
+		msg = "name must be between 1 and 100 characters"
+	}
+	return msg
+}
+
+// ValidateRegions checks if every region of a shipping zone is a non-empty region code. An empty list is allowed
+func ValidateRegions(regions []string) string {
+	var msg string
+	for _, region := range regions {
+		if region == "" || ValidateRegion(region) != "" {
+			msg = "regions must be country codes optionally followed by a subdivision, e.g. GB or US-CA"
+			break
+		}
+	}
+	return msg
+}
+
+// ValidateShippingRateType checks if the RateType is FLAT, WEIGHT or FREE_OVER
+func ValidateShippingRateType(rateType string) string {
+	var msg string
+	if rateType != shipping.Flat && rateType != shipping.Weight && rateType != shipping.FreeOver {
+		msg = "rateType must be either FLAT, WEIGHT or FREE_OVER"
+	}
+	return msg
+}
+
+// ValidateFreeOver checks if the FreeOver threshold is set for free over threshold methods
+func ValidateFreeOver(rateType string, freeOver float64) string {
+	var msg string
+	if freeOver < 0 {
+		msg = "freeOver cannot be negative"
+	} else if rateType == shipping.FreeOver && freeOver == 0 {
+		msg = "freeOver must be greater than 0 for FREE_OVER methods"
+	}
+	return msg
+}
+
+// ValidateAddress checks if the Country is an ISO 3166 country code and the optional State a subdivision code
+func ValidateAddress(address types.Address) string {
+	var msg string
+	if !countryRegex.MatchString(address.Country) {
+		msg = "country must be a two letter country code, e.g. GB or US"
+	} else if address.State != "" && !stateRegex.MatchString(address.State) {
+		msg = "state must be a subdivision code of 1 to 3 letters or digits, e.g. CA"
+	}
+	return msg
+}
+
+// ValidateShippingZone validates the CreateShippingZoneInput struct
+func ValidateShippingZone(input types.CreateShippingZoneInput) (types.ShippingZoneErrMessage, error) {
+	errMessage := types.ShippingZoneErrMessage{
+		Name:    ValidateShippingName(input.Name),
+		Regions: ValidateRegions(input.Regions),
+	}
+	if errMessage == (types.ShippingZoneErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid create shipping zone input")
+}
+
+// ValidateShippingZoneUpdateInput validates the fields present in the ShippingZoneUpdateInput struct
+func ValidateShippingZoneUpdateInput(input types.ShippingZoneUpdateInput) (types.ShippingZoneErrMessage, error) {
+	var errMessage types.ShippingZoneErrMessage
+	if input.Name != nil {
+		errMessage.Name = ValidateShippingName(*input.Name)
+	}
+	if input.Regions != nil {
+		errMessage.Regions = ValidateRegions(*input.Regions)
+	}
+	if errMessage == (types.ShippingZoneErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid shipping zone input")
+}
+
+// ValidateShippingMethod validates the CreateShippingMethodInput struct
+func ValidateShippingMethod(input types.CreateShippingMethodInput) (types.ShippingMethodErrMessage, error) {
+	errMessage := types.ShippingMethodErrMessage{
+		Name:      ValidateShippingName(input.Name),
+		RateType:  ValidateShippingRateType(input.RateType),
+		Rate:      ValidateNonNegative("rate", input.Rate),
+		RatePerKg: ValidateNonNegative("ratePerKg", input.RatePerKg),
+		FreeOver:  ValidateFreeOver(input.RateType, input.FreeOver),
+	}
+	if errMessage == (types.ShippingMethodErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid create shipping method input")
+}
+
+// ValidateShippingMethodUpdateInput validates the fields present in the ShippingMethodUpdateInput struct
+func ValidateShippingMethodUpdateInput(input types.ShippingMethodUpdateInput) (types.ShippingMethodErrMessage, error) {
+	var errMessage types.ShippingMethodErrMessage
+	if input.Name != nil {
+		errMessage.Name = ValidateShippingName(*input.Name)
+	}
+	if input.RateType != nil {
+		errMessage.RateType = ValidateShippingRateType(*input.RateType)
+	}
+	if input.Rate != nil {
+		errMessage.Rate = ValidateNonNegative("rate", *input.Rate)
+	}
+	if input.RatePerKg != nil {
+		errMessage.RatePerKg = ValidateNonNegative("ratePerKg", *input.RatePerKg)
+	}
+	if input.FreeOver != nil {
+		errMessage.FreeOver = ValidateNonNegative("freeOver", *input.FreeOver)
+	}
+	if errMessage == (types.ShippingMethodErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid shipping method input")
+}
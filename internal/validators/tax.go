@@ -0,0 +1,84 @@
+package validators
+
+import (
+	"errors"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"regexp"
+)
+
+var (
+	taxClassRegex = regexp.MustCompile(`^[a-z0-9_-]{1,50}$`)
+	regionRegex   = regexp.MustCompile(`^[A-Z]{2}(-[A-Z0-9]{1,3})?$`)
+)
+
+// ValidateTaxClass checks if the TaxClass is made up of 1 to 50 lower case letters, digits, dashes or underscores
+func ValidateTaxClass(taxClass string) string {
+	var msg string
+	if !taxClassRegex.MatchString(taxClass) {
+		msg = "taxClass must be 1 to 50 lower case letters, digits, dashes or underscores"
+	}
+	return msg
+}
+
+// ValidateRegion checks if the Region is an ISO 3166 country code optionally followed by a subdivision, e.g. US or US-CA.
+// An empty region is allowed
+func ValidateRegion(region string) string {
+	var msg string
+	if region != "" && !regionRegex.MatchString(region) {
+		msg = "region must be a country code optionally followed by a subdivision, e.g. GB or US-CA"
+	}
+	return msg
+}
+
+// ValidateTaxRate checks if the Rate is a percentage between 0 and 100
+func ValidateTaxRate(rate float64) string {
+	var msg string
+	if rate < 0 || rate > 100 {
+		msg = "rate must be between 0 and 100"
+	}
+	return msg
+}
+
+// ValidateTaxRuleName checks if the Name is non-empty and within length constraints
+func ValidateTaxRuleName(name string) string {
+	var msg string
+	if name == "" || len(name) > 100 {
+		msg = "name must be between 1 and 100 characters"
+	}
+	return msg
+}
+
+// ValidateTaxRule validates the CreateTaxRuleInput struct
+func ValidateTaxRule(input types.CreateTaxRuleInput) (types.TaxRuleErrMessage, error) {
+	errMessage := types.TaxRuleErrMessage{
+		Name:     ValidateTaxRuleName(input.Name),
+		TaxClass: ValidateTaxClass(input.TaxClass),
+		Region:   ValidateRegion(input.Region),
+		Rate:     ValidateTaxRate(input.Rate),
+	}
+	if errMessage == (types.TaxRuleErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid create tax rule input")
+}
+
+// ValidateTaxRuleUpdateInput validates the fields present in the TaxRuleUpdateInput struct
+func ValidateTaxRuleUpdateInput(input types.TaxRuleUpdateInput) (types.TaxRuleErrMessage, error) {
+	var errMessage types.TaxRuleErrMessage
+	if input.Name != nil {
+		errMessage.Name = ValidateTaxRuleName(*input.Name)
+	}
+	if input.TaxClass != nil {
+		errMessage.TaxClass = ValidateTaxClass(*input.TaxClass)
+	}
+	if input.Region != nil {
+		errMessage.Region = ValidateRegion(*input.Region)
+	}
+	if input.Rate != nil {
+		errMessage.Rate = ValidateTaxRate(*input.Rate)
+	}
+	if errMessage == (types.TaxRuleErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid tax rule input")
+}
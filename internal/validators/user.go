@@ -7,6 +7,19 @@ import (
 	"regexp"
 )
 
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+// ValidateEmail checks if the Email is present and well formed
+func ValidateEmail(email string) string {
+	var msg string
+	if email == "" {
+		msg = "email is required"
+	} else if !emailRegex.MatchString(email) {
+		msg = "email is invalid"
+	}
+	return msg
+}
+
 func ValidateAuthPayload(input types.AuthPayload) (types.RegisterUserErrMessage, error) {
 	errMessage := types.RegisterUserErrMessage{}
 	var err error = nil
@@ -14,10 +27,8 @@ func ValidateAuthPayload(input types.AuthPayload) (types.RegisterUserErrMessage,
 		err = utils.ConcatenateErrors(err, errors.New("missing email"))
 		errMessage.Email = "email is required"
 	} else {
-		emailRegex := `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
-		re := regexp.MustCompile(emailRegex)
 		// Match email with regex
-		if !re.MatchString(input.Email) {
+		if !emailRegex.MatchString(input.Email) {
 			err = utils.ConcatenateErrors(err, errors.New("invalid email"))
 			errMessage.Email = "email is invalid"
 		}
@@ -0,0 +1,95 @@
+package validators
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+)
+
+// ValidateWarehouseName checks if the Name is non-empty and within length constraints
+func ValidateWarehouseName(name string) string {
+	var msg string
+	if name == "" || len(name) > 100 {
+		msg = "name must be between 1 and 100 characters"
+	}
+	return msg
+}
+
+// ValidateWarehousePriority checks if the Priority is not negative. Lower priorities are shipped from first
+func ValidateWarehousePriority(priority int32) string {
+	var msg string
+	if priority < 0 {
+		msg = "priority must not be negative"
+	}
+	return msg
+}
+
+// ValidateWarehouse validates the CreateWarehouseInput struct
+func ValidateWarehouse(input types.CreateWarehouseInput) (types.WarehouseErrMessage, error) {
+	errMessage := types.WarehouseErrMessage{
+		Name:     ValidateWarehouseName(input.Name),
+		Priority: ValidateWarehousePriority(input.Priority),
+	}
+	if errMessage == (types.WarehouseErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid create warehouse input")
+}
+
+// ValidateWarehouseUpdateInput validates the fields present in the WarehouseUpdateInput struct
+func ValidateWarehouseUpdateInput(input types.WarehouseUpdateInput) (types.WarehouseErrMessage, error) {
+	var errMessage types.WarehouseErrMessage
+	if input.Name != nil {
+		errMessage.Name = ValidateWarehouseName(*input.Name)
+	}
+	if input.Priority != nil {
+		errMessage.Priority = ValidateWarehousePriority(*input.Priority)
+	}
+	if errMessage == (types.WarehouseErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid warehouse input")
+}
+
+// ValidateWarehouseStockInput validates the WarehouseStockInput struct
+func ValidateWarehouseStockInput(input types.WarehouseStockInput) (types.WarehouseErrMessage, error) {
+	var errMessage types.WarehouseErrMessage
+	if input.Stock == nil {
+		errMessage.Stock = "stock is required"
+	} else if *input.Stock < 0 {
+		errMessage.Stock = "stock must not be negative"
+	}
+	if input.Note != nil && len(*input.Note) > 255 {
+		errMessage.Note = "note must not be more than 255 characters"
+	}
+	if errMessage == (types.WarehouseErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid warehouse stock input")
+}
+
+// ValidateStockTransfer validates the StockTransferInput struct
+func ValidateStockTransfer(input types.StockTransferInput) (types.WarehouseErrMessage, error) {
+	var errMessage types.WarehouseErrMessage
+	if input.ProductId == uuid.Nil {
+		errMessage.ProductId = "productId is required"
+	}
+	if input.FromWarehouseId == uuid.Nil {
+		errMessage.FromWarehouseId = "fromWarehouseId is required"
+	}
+	if input.ToWarehouseId == uuid.Nil {
+		errMessage.ToWarehouseId = "toWarehouseId is required"
+	} else if input.ToWarehouseId == input.FromWarehouseId {
+		errMessage.ToWarehouseId = "toWarehouseId must not be fromWarehouseId"
+	}
+	if input.Quantity < 1 {
+		errMessage.Quantity = "quantity must be at least 1"
+	}
+	if len(input.Note) > 255 {
+		errMessage.Note = "note must not be more than 255 characters"
+	}
+	if errMessage == (types.WarehouseErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid stock transfer input")
+}
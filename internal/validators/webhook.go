@@ -0,0 +1,81 @@
+package validators
+
+import (
+	"errors"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// ValidateWebhookURL checks if the URL is an absolute http or https URL
+func ValidateWebhookURL(rawURL string) string {
+	var msg string
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		msg = "url must be an absolute http or https URL"
+	} else if len(rawURL) > 2048 {
+		msg = "url must not be longer than 2048 characters"
+	}
+	return msg
+}
+
+// ValidateWebhookSecret checks if the Secret is between 16 and 255 characters
+func ValidateWebhookSecret(secret string) string {
+	var msg string
+	if len(secret) < 16 || len(secret) > 255 {
+		msg = "secret must be between 16 and 255 characters"
+	}
+	return msg
+}
+
+// ValidateWebhookEvents checks if at least one event is given and every event is known
+func ValidateWebhookEvents(events []string) string {
+	if len(events) == 0 {
+		return "events must contain at least one event"
+	}
+	for i, event := range events {
+		if !slices.Contains(db.EventTypes, event) {
+			return "events must be among " + strings.Join(db.EventTypes, ", ")
+		}
+		if slices.Contains(events[:i], event) {
+			return "events cannot contain " + event + " more than once"
+		}
+	}
+	return ""
+}
+
+// ValidateWebhook validates the CreateWebhookInput struct. An empty secret is
+// generated by the service.
+func ValidateWebhook(input types.CreateWebhookInput) (types.WebhookErrMessage, error) {
+	errMessage := types.WebhookErrMessage{
+		URL:    ValidateWebhookURL(input.URL),
+		Events: ValidateWebhookEvents(input.Events),
+	}
+	if input.Secret != "" {
+		errMessage.Secret = ValidateWebhookSecret(input.Secret)
+	}
+	if errMessage == (types.WebhookErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid create webhook input")
+}
+
+// ValidateWebhookUpdateInput validates the fields present in the WebhookUpdateInput struct
+func ValidateWebhookUpdateInput(input types.WebhookUpdateInput) (types.WebhookErrMessage, error) {
+	var errMessage types.WebhookErrMessage
+	if input.URL != nil {
+		errMessage.URL = ValidateWebhookURL(*input.URL)
+	}
+	if input.Secret != nil {
+		errMessage.Secret = ValidateWebhookSecret(*input.Secret)
+	}
+	if input.Events != nil {
+		errMessage.Events = ValidateWebhookEvents(*input.Events)
+	}
+	if errMessage == (types.WebhookErrMessage{}) {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid webhook input")
+}
@@ -0,0 +1,42 @@
+package validators
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+)
+
+// ValidateWishlistName checks if the Name is non-empty and within length constraints
+func ValidateWishlistName(name string) string {
+	var msg string
+	if name == "" || len(name) > 100 {
+		msg = "name must be between 1 and 100 characters"
+	}
+	return msg
+}
+
+// ValidateWishlist validates the WishlistInput struct
+func ValidateWishlist(input types.WishlistInput) (types.WishlistErrMessage, error) {
+	errMessage := types.WishlistErrMessage{
+		Name: ValidateWishlistName(input.Name),
+	}
+	if errMessage.Name == "" {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid wishlist input")
+}
+
+// ValidateWishlistItem validates the WishlistItemInput struct
+func ValidateWishlistItem(input types.WishlistItemInput) (types.WishlistErrMessage, error) {
+	var errMessage types.WishlistErrMessage
+	if _, err := uuid.Parse(input.ProductId); err != nil {
+		errMessage.ProductId = "must be a valid product id"
+	}
+	if input.Quantity <= 0 {
+		errMessage.Quantity = "quantity must be greater than zero"
+	}
+	if errMessage.ProductId == "" && errMessage.Quantity == "" {
+		return errMessage, nil
+	}
+	return errMessage, errors.New("invalid wishlist item input")
+}
@@ -0,0 +1,120 @@
+// Package webhook posts events to the URLs admins subscribe to them.
+//
+// Every delivery is signed with the secret of its webhook. Receivers check
+// the X-Webhook-Signature header, an HMAC-SHA256 of the X-Webhook-Timestamp
+// header, a dot and the raw body:
+//
+//	X-Webhook-Signature: sha256=hex(hmac_sha256(secret, timestamp + "." + body))
+//
+// and should drop deliveries with an old timestamp to guard against replays.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers sent with every delivery.
+const (
+	DeliveryHeader  = "X-Webhook-Delivery"
+	EventHeader     = "X-Webhook-Event"
+	TimestampHeader = "X-Webhook-Timestamp"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// maxResponseBody is how much of a response body is kept for the delivery log.
+const maxResponseBody = 2048
+
+// Event is the body of a delivery. The id is the same for every delivery of
+// an event, so that receivers can drop the ones they already handled.
+type Event struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Delivery is an event to post to a webhook.
+type Delivery struct {
+	ID     string
+	URL    string
+	Secret string
+	Event  Event
+}
+
+// Response is what a webhook answered to a delivery.
+type Response struct {
+	StatusCode int
+	Body       string
+}
+
+// NewSecret generates a random signing secret.
+func NewSecret() (string, error) {
+	key := make([]byte, 24)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(key), nil
+}
+
+// Sign computes the signature of body sent at timestamp, in Unix seconds.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of body sent at timestamp.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Send posts a delivery. An error is returned when the webhook could not be
+// reached or did not answer with a 2xx status, along with what it answered.
+func Send(ctx context.Context, client *http.Client, delivery Delivery) (Response, error) {
+	var response Response
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return response, err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return response, err
+	}
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "getinstashop-webhooks/1.0")
+	request.Header.Set(DeliveryHeader, delivery.ID)
+	request.Header.Set(EventHeader, delivery.Event.Type)
+	request.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(SignatureHeader, Sign(delivery.Secret, timestamp, body))
+	resp, err := client.Do(request)
+	if err != nil {
+		return response, err
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	// The body is kept in a text column, which only takes valid UTF-8
+	text := strings.ReplaceAll(strings.ToValidUTF8(string(content), ""), "\x00", "")
+	response = Response{StatusCode: resp.StatusCode, Body: text}
+	if err != nil {
+		return response, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return response, fmt.Errorf("webhook answered with status %d", resp.StatusCode)
+	}
+	return response, nil
+}
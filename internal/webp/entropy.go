@@ -0,0 +1,402 @@
+package webp
+
+import (
+	"container/heap"
+	"sort"
+)
+
+const (
+	numLiteralCodes  = 256
+	numLengthCodes   = 24
+	numDistanceCodes = 40
+	maxCodeLength    = 15
+	// maxCopyLength is the longest backward reference VP8L allows.
+	maxCopyLength = 4096
+	// minCopyLength is the shortest run worth coding as a backward reference.
+	minCopyLength = 3
+)
+
+// Distance codes of the pixel above and the pixel to the left, from the
+// distance map of the format.
+const (
+	distanceCodeTop  = 1
+	distanceCodeLeft = 2
+)
+
+var codeLengthCodeOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// token is either a literal pixel or a backward reference copying length
+// pixels from distanceCode.
+type token struct {
+	pixel        uint32
+	length       int
+	distanceCode int
+}
+
+// writeEntropyImage codes pixels with a single group of five prefix codes for
+// green (plus lengths), red, blue, alpha and distances. Only the main image
+// has the bit telling there is no meta prefix image.
+func writeEntropyImage(bw *bitWriter, pixels []uint32, width int, main bool) {
+	tokens := backwardReferences(pixels, width)
+	histograms := [5][]uint32{
+		make([]uint32, numLiteralCodes+numLengthCodes),
+		make([]uint32, numLiteralCodes),
+		make([]uint32, numLiteralCodes),
+		make([]uint32, numLiteralCodes),
+		make([]uint32, numDistanceCodes),
+	}
+	for _, t := range tokens {
+		if t.length == 0 {
+			histograms[0][(t.pixel>>8)&0xff]++
+			histograms[1][(t.pixel>>16)&0xff]++
+			histograms[2][t.pixel&0xff]++
+			histograms[3][t.pixel>>24]++
+			continue
+		}
+		lengthCode, _, _ := prefixEncode(t.length)
+		histograms[0][numLiteralCodes+lengthCode]++
+		distanceCode, _, _ := prefixEncode(t.distanceCode)
+		histograms[4][distanceCode]++
+	}
+
+	// No color cache.
+	bw.write(0, 1)
+	if main {
+		bw.write(0, 1)
+	}
+	var codes [5]prefixCode
+	for i, histogram := range histograms {
+		codes[i] = newPrefixCode(histogram, maxCodeLength)
+		codes[i].write(bw)
+	}
+
+	for _, t := range tokens {
+		if t.length == 0 {
+			codes[0].writeSymbol(bw, int((t.pixel>>8)&0xff))
+			codes[1].writeSymbol(bw, int((t.pixel>>16)&0xff))
+			codes[2].writeSymbol(bw, int(t.pixel&0xff))
+			codes[3].writeSymbol(bw, int(t.pixel>>24))
+			continue
+		}
+		lengthCode, extraBits, extraValue := prefixEncode(t.length)
+		codes[0].writeSymbol(bw, numLiteralCodes+lengthCode)
+		bw.write(extraValue, extraBits)
+		distanceCode, extraBits, extraValue := prefixEncode(t.distanceCode)
+		codes[4].writeSymbol(bw, distanceCode)
+		bw.write(extraValue, extraBits)
+	}
+}
+
+// backwardReferences turns runs of pixels equal to the pixel to their left or
+// above into backward references.
+func backwardReferences(pixels []uint32, width int) []token {
+	var tokens []token
+	for i := 0; i < len(pixels); {
+		bestLength, bestCode := 0, 0
+		for _, candidate := range [2]struct{ distance, code int }{{1, distanceCodeLeft}, {width, distanceCodeTop}} {
+			if i < candidate.distance {
+				continue
+			}
+			length := 0
+			for i+length < len(pixels) && length < maxCopyLength && pixels[i+length] == pixels[i+length-candidate.distance] {
+				length++
+			}
+			if length > bestLength {
+				bestLength, bestCode = length, candidate.code
+			}
+		}
+		if bestLength >= minCopyLength {
+			tokens = append(tokens, token{length: bestLength, distanceCode: bestCode})
+			i += bestLength
+			continue
+		}
+		tokens = append(tokens, token{pixel: pixels[i]})
+		i++
+	}
+	return tokens
+}
+
+// prefixEncode splits a length or distance code into its prefix symbol and
+// the extra bits following it.
+func prefixEncode(value int) (int, uint, uint32) {
+	v := value - 1
+	if v < 4 {
+		return v, 0, 0
+	}
+	highestBit := 0
+	for v>>(highestBit+1) != 0 {
+		highestBit++
+	}
+	secondHighestBit := (v >> (highestBit - 1)) & 1
+	extraBits := highestBit - 1
+	return 2*highestBit + secondHighestBit, uint(extraBits), uint32(v & (1<<extraBits - 1))
+}
+
+// prefixCode is a canonical Huffman code.
+type prefixCode struct {
+	lengths []uint8
+	// codes holds the bit reversed code of each symbol, ready to be written
+	// least significant bit first.
+	codes []uint32
+	// symbols lists the symbols in use.
+	symbols []int
+}
+
+func newPrefixCode(histogram []uint32, maxLength int) prefixCode {
+	code := prefixCode{lengths: codeLengths(histogram, maxLength)}
+	for symbol, length := range code.lengths {
+		if length > 0 {
+			code.symbols = append(code.symbols, symbol)
+		}
+	}
+	code.codes = canonicalCodes(code.lengths)
+	return code
+}
+
+// writeSymbol writes the code of symbol. Codes with a single symbol take no
+// bits at all.
+func (c *prefixCode) writeSymbol(bw *bitWriter, symbol int) {
+	if len(c.symbols) <= 1 {
+		return
+	}
+	bw.write(c.codes[symbol], uint(c.lengths[symbol]))
+}
+
+// write writes the code lengths so the decoder can rebuild the code.
+func (c *prefixCode) write(bw *bitWriter) {
+	if len(c.symbols) <= 2 && (len(c.symbols) == 0 || c.symbols[len(c.symbols)-1] < 256) {
+		c.writeSimple(bw)
+		return
+	}
+	c.writeNormal(bw)
+}
+
+func (c *prefixCode) writeSimple(bw *bitWriter) {
+	symbols := c.symbols
+	if len(symbols) == 0 {
+		// An unused code still has to be valid: a single symbol taking no bits.
+		symbols = []int{0}
+	}
+	bw.write(1, 1)
+	bw.write(uint32(len(symbols)-1), 1)
+	if symbols[0] < 2 {
+		bw.write(0, 1)
+		bw.write(uint32(symbols[0]), 1)
+	} else {
+		bw.write(1, 1)
+		bw.write(uint32(symbols[0]), 8)
+	}
+	if len(symbols) == 2 {
+		bw.write(uint32(symbols[1]), 8)
+	}
+}
+
+func (c *prefixCode) writeNormal(bw *bitWriter) {
+	// Code lengths are themselves coded, with runs of zeros shortened using
+	// symbols 17 and 18.
+	type lengthToken struct {
+		symbol, extra int
+	}
+	var tokens []lengthToken
+	for i := 0; i < len(c.lengths); {
+		if c.lengths[i] != 0 {
+			tokens = append(tokens, lengthToken{symbol: int(c.lengths[i])})
+			i++
+			continue
+		}
+		run := 0
+		for i+run < len(c.lengths) && c.lengths[i+run] == 0 && run < 138 {
+			run++
+		}
+		switch {
+		case run >= 11:
+			tokens = append(tokens, lengthToken{symbol: 18, extra: run - 11})
+		case run >= 3:
+			tokens = append(tokens, lengthToken{symbol: 17, extra: run - 3})
+		default:
+			for j := 0; j < run; j++ {
+				tokens = append(tokens, lengthToken{symbol: 0})
+			}
+		}
+		i += run
+	}
+	histogram := make([]uint32, len(codeLengthCodeOrder))
+	for _, t := range tokens {
+		histogram[t.symbol]++
+	}
+	lengthCode := newPrefixCode(histogram, 7)
+	if len(lengthCode.symbols) == 1 {
+		// A lone symbol still needs a non zero length to be transmitted.
+		lengthCode.lengths[lengthCode.symbols[0]] = 1
+	}
+	numCodes := len(codeLengthCodeOrder)
+	for numCodes > 4 && lengthCode.lengths[codeLengthCodeOrder[numCodes-1]] == 0 {
+		numCodes--
+	}
+
+	bw.write(0, 1)
+	bw.write(uint32(numCodes-4), 4)
+	for _, symbol := range codeLengthCodeOrder[:numCodes] {
+		bw.write(uint32(lengthCode.lengths[symbol]), 3)
+	}
+	// Every symbol of the alphabet has its length written.
+	bw.write(0, 1)
+	for _, t := range tokens {
+		lengthCode.writeSymbol(bw, t.symbol)
+		switch t.symbol {
+		case 17:
+			bw.write(uint32(t.extra), 3)
+		case 18:
+			bw.write(uint32(t.extra), 7)
+		}
+	}
+}
+
+// codeLengths computes Huffman code lengths no longer than maxLength. When the
+// tree gets too deep the counts are flattened and the tree rebuilt. A lone
+// symbol gets a length of one.
+func codeLengths(histogram []uint32, maxLength int) []uint8 {
+	lengths := make([]uint8, len(histogram))
+	counts := make([]uint32, len(histogram))
+	copy(counts, histogram)
+	for {
+		if huffmanLengths(counts, lengths) <= maxLength {
+			return lengths
+		}
+		for i, count := range counts {
+			if count > 0 {
+				counts[i] = count/2 + 1
+			}
+		}
+	}
+}
+
+type huffmanNode struct {
+	count       uint32
+	symbol      int
+	left, right *huffmanNode
+}
+
+type huffmanHeap []*huffmanNode
+
+func (h huffmanHeap) Len() int { return len(h) }
+func (h huffmanHeap) Less(i, j int) bool {
+	if h[i].count != h[j].count {
+		return h[i].count < h[j].count
+	}
+	return h[i].symbol < h[j].symbol
+}
+func (h huffmanHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *huffmanHeap) Push(x any)   { *h = append(*h, x.(*huffmanNode)) }
+func (h *huffmanHeap) Pop() any {
+	old := *h
+	node := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return node
+}
+
+// huffmanLengths fills lengths with the depth of each symbol in the Huffman
+// tree of counts and returns the deepest one.
+func huffmanLengths(counts []uint32, lengths []uint8) int {
+	for i := range lengths {
+		lengths[i] = 0
+	}
+	h := &huffmanHeap{}
+	for symbol, count := range counts {
+		if count > 0 {
+			*h = append(*h, &huffmanNode{count: count, symbol: symbol})
+		}
+	}
+	switch h.Len() {
+	case 0:
+		return 0
+	case 1:
+		lengths[(*h)[0].symbol] = 1
+		return 1
+	}
+	heap.Init(h)
+	// Internal nodes sort after the symbols with the same count.
+	next := len(counts)
+	for h.Len() > 1 {
+		a := heap.Pop(h).(*huffmanNode)
+		b := heap.Pop(h).(*huffmanNode)
+		heap.Push(h, &huffmanNode{count: a.count + b.count, symbol: next, left: a, right: b})
+		next++
+	}
+	deepest := 0
+	var walk func(node *huffmanNode, depth int)
+	walk = func(node *huffmanNode, depth int) {
+		if node.left == nil {
+			lengths[node.symbol] = uint8(depth)
+			if depth > deepest {
+				deepest = depth
+			}
+			return
+		}
+		walk(node.left, depth+1)
+		walk(node.right, depth+1)
+	}
+	walk(heap.Pop(h).(*huffmanNode), 0)
+	return deepest
+}
+
+// canonicalCodes assigns canonical Huffman codes to the code lengths, shorter
+// codes first and in symbol order within a length, and bit reverses them.
+func canonicalCodes(lengths []uint8) []uint32 {
+	codes := make([]uint32, len(lengths))
+	symbols := make([]int, 0, len(lengths))
+	for symbol, length := range lengths {
+		if length > 0 {
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.SliceStable(symbols, func(i, j int) bool {
+		return lengths[symbols[i]] < lengths[symbols[j]]
+	})
+	code, previousLength := uint32(0), uint8(0)
+	for i, symbol := range symbols {
+		length := lengths[symbol]
+		if i > 0 {
+			code = (code + 1) << (length - previousLength)
+		} else {
+			code <<= length
+		}
+		previousLength = length
+		codes[symbol] = reverseBits(code, length)
+	}
+	return codes
+}
+
+func reverseBits(code uint32, length uint8) uint32 {
+	var reversed uint32
+	for i := uint8(0); i < length; i++ {
+		reversed = reversed<<1 | code&1
+		code >>= 1
+	}
+	return reversed
+}
+
+// bitWriter packs bits least significant bit first.
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits uint
+}
+
+func (b *bitWriter) write(value uint32, nbits uint) {
+	b.acc |= uint64(value) << b.nbits
+	b.nbits += nbits
+	for b.nbits >= 8 {
+		b.buf = append(b.buf, byte(b.acc))
+		b.acc >>= 8
+		b.nbits -= 8
+	}
+}
+
+func (b *bitWriter) bytes() []byte {
+	if b.nbits > 0 {
+		b.buf = append(b.buf, byte(b.acc))
+		b.acc, b.nbits = 0, 0
+	}
+	return b.buf
+}
@@ -0,0 +1,169 @@
+package webp
+
+// numPredictors is the number of predictor modes defined by VP8L.
+const numPredictors = 14
+
+// choosePredictors picks for every tile the predictor mode leaving the
+// smallest residuals. The modes are returned as the predictor transform
+// image, the mode being stored in the green channel.
+func choosePredictors(pixels []uint32, width, height int) ([]uint32, int) {
+	tileSize := 1 << predictorBits
+	tilesPerRow := (width + tileSize - 1) >> predictorBits
+	tilesPerColumn := (height + tileSize - 1) >> predictorBits
+	modes := make([]uint32, tilesPerRow*tilesPerColumn)
+	for tileY := 0; tileY < tilesPerColumn; tileY++ {
+		for tileX := 0; tileX < tilesPerRow; tileX++ {
+			bestMode, bestCost := 0, -1
+			for mode := 0; mode < numPredictors; mode++ {
+				cost := 0
+				for y := tileY * tileSize; y < (tileY+1)*tileSize && y < height; y++ {
+					for x := tileX * tileSize; x < (tileX+1)*tileSize && x < width; x++ {
+						cost += residualCost(pixels[y*width+x], prediction(pixels, width, x, y, mode))
+					}
+				}
+				if bestCost < 0 || cost < bestCost {
+					bestMode, bestCost = mode, cost
+				}
+			}
+			modes[tileY*tilesPerRow+tileX] = 0xff000000 | uint32(bestMode)<<8
+		}
+	}
+	return modes, tilesPerRow
+}
+
+// predict replaces every pixel by its difference with its prediction.
+func predict(pixels []uint32, width, height int, modes []uint32, tilesPerRow int) []uint32 {
+	residuals := make([]uint32, len(pixels))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			mode := int(modes[(y>>predictorBits)*tilesPerRow+x>>predictorBits]>>8) & 0xf
+			residuals[y*width+x] = subPixels(pixels[y*width+x], prediction(pixels, width, x, y, mode))
+		}
+	}
+	return residuals
+}
+
+// prediction predicts the pixel at x, y from the pixels before it. The first
+// row and column are always predicted from the pixel to the left and above.
+func prediction(pixels []uint32, width, x, y, mode int) uint32 {
+	i := y*width + x
+	switch {
+	case x == 0 && y == 0:
+		return 0xff000000
+	case y == 0:
+		return pixels[i-1]
+	case x == 0:
+		return pixels[i-width]
+	}
+	left, top, topLeft := pixels[i-1], pixels[i-width], pixels[i-width-1]
+	// For the last column this wraps around to the first pixel of the current
+	// row, as the format specifies.
+	topRight := pixels[i-width+1]
+	switch mode {
+	case 0:
+		return 0xff000000
+	case 1:
+		return left
+	case 2:
+		return top
+	case 3:
+		return topRight
+	case 4:
+		return topLeft
+	case 5:
+		return average2(average2(left, topRight), top)
+	case 6:
+		return average2(left, topLeft)
+	case 7:
+		return average2(left, top)
+	case 8:
+		return average2(topLeft, top)
+	case 9:
+		return average2(top, topRight)
+	case 10:
+		return average2(average2(left, topLeft), average2(top, topRight))
+	case 11:
+		return selectPixel(left, top, topLeft)
+	case 12:
+		return clampAddSubtractFull(left, top, topLeft)
+	default:
+		return clampAddSubtractHalf(average2(left, top), topLeft)
+	}
+}
+
+func channel(p uint32, shift uint) int32 {
+	return int32(p>>shift) & 0xff
+}
+
+func abs(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func clamp(v int32) uint32 {
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint32(v)
+}
+
+func average2(a, b uint32) uint32 {
+	var p uint32
+	for shift := uint(0); shift < 32; shift += 8 {
+		p |= uint32((channel(a, shift)+channel(b, shift))/2) << shift
+	}
+	return p
+}
+
+func selectPixel(left, top, topLeft uint32) uint32 {
+	var distanceLeft, distanceTop int32
+	for shift := uint(0); shift < 32; shift += 8 {
+		distanceLeft += abs(channel(top, shift) - channel(topLeft, shift))
+		distanceTop += abs(channel(left, shift) - channel(topLeft, shift))
+	}
+	if distanceLeft < distanceTop {
+		return left
+	}
+	return top
+}
+
+func clampAddSubtractFull(a, b, c uint32) uint32 {
+	var p uint32
+	for shift := uint(0); shift < 32; shift += 8 {
+		p |= clamp(channel(a, shift)+channel(b, shift)-channel(c, shift)) << shift
+	}
+	return p
+}
+
+func clampAddSubtractHalf(a, b uint32) uint32 {
+	var p uint32
+	for shift := uint(0); shift < 32; shift += 8 {
+		p |= clamp(channel(a, shift)+(channel(a, shift)-channel(b, shift))/2) << shift
+	}
+	return p
+}
+
+// subPixels subtracts each channel of b from a, modulo 256.
+func subPixels(a, b uint32) uint32 {
+	var p uint32
+	for shift := uint(0); shift < 32; shift += 8 {
+		p |= uint32(channel(a, shift)-channel(b, shift)) & 0xff << shift
+	}
+	return p
+}
+
+// residualCost estimates how expensive a residual is to code: small
+// differences in either direction are cheap.
+func residualCost(pixel, predicted uint32) int {
+	residual := subPixels(pixel, predicted)
+	cost := 0
+	for shift := uint(0); shift < 32; shift += 8 {
+		cost += int(abs(int32(int8(residual >> shift))))
+	}
+	return cost
+}
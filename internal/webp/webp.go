@@ -0,0 +1,110 @@
+// Package webp encodes images in the lossless WebP (VP8L) format.
+//
+// The encoder applies the subtract green and predictor transforms and codes
+// the residuals with a single group of prefix codes, using backward references
+// only to repeat the pixel to the left or above. That keeps it small while
+// still compressing the flat backgrounds common in product photos well.
+package webp
+
+import (
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+)
+
+// maxDimension is the largest width or height a VP8L image can have.
+const maxDimension = 1 << 14
+
+// predictorBits is the log-2 size of the tiles that share a predictor.
+const predictorBits = 4
+
+var ErrTooLarge = errors.New("webp: image is too large")
+
+// Encode writes img to w as a lossless WebP image.
+func Encode(w io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= 0 || height <= 0 || width > maxDimension || height > maxDimension {
+		return ErrTooLarge
+	}
+	pixels, alpha := argbPixels(img)
+
+	bw := &bitWriter{}
+	bw.write(0x2f, 8)
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	if alpha {
+		bw.write(1, 1)
+	} else {
+		bw.write(0, 1)
+	}
+	bw.write(0, 3)
+
+	// Transforms are listed in the order they are applied, the decoder undoes
+	// them in reverse.
+	subtractGreen(pixels)
+	bw.write(1, 1)
+	bw.write(transformSubtractGreen, 2)
+
+	modes, tilesPerRow := choosePredictors(pixels, width, height)
+	bw.write(1, 1)
+	bw.write(transformPredictor, 2)
+	bw.write(predictorBits-2, 3)
+	writeEntropyImage(bw, modes, tilesPerRow, false)
+	residuals := predict(pixels, width, height, modes, tilesPerRow)
+
+	bw.write(0, 1)
+	writeEntropyImage(bw, residuals, width, true)
+
+	data := bw.bytes()
+	chunkSize := len(data)
+	padding := chunkSize & 1
+	header := make([]byte, 20)
+	copy(header[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(4+8+chunkSize+padding))
+	copy(header[8:12], "WEBP")
+	copy(header[12:16], "VP8L")
+	binary.LittleEndian.PutUint32(header[16:20], uint32(chunkSize))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if padding == 1 {
+		data = append(data, 0)
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+const (
+	transformPredictor     = 0
+	transformSubtractGreen = 2
+)
+
+// argbPixels returns the non premultiplied pixels of img packed as ARGB and
+// whether any of them is not fully opaque.
+func argbPixels(img image.Image) ([]uint32, bool) {
+	bounds := img.Bounds()
+	pixels := make([]uint32, 0, bounds.Dx()*bounds.Dy())
+	alpha := false
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A != 0xff {
+				alpha = true
+			}
+			pixels = append(pixels, uint32(c.A)<<24|uint32(c.R)<<16|uint32(c.G)<<8|uint32(c.B))
+		}
+	}
+	return pixels, alpha
+}
+
+func subtractGreen(pixels []uint32) {
+	for i, p := range pixels {
+		green := (p >> 8) & 0xff
+		red := ((p >> 16) - green) & 0xff
+		blue := (p - green) & 0xff
+		pixels[i] = p&0xff00ff00 | red<<16 | blue
+	}
+}
@@ -13,9 +13,19 @@ import (
 var (
 	MininumAllowedSecretKeySize = 32
 	TokenDuration               = time.Hour * 12
+	OrderLookupTokenDuration    = time.Hour * 24 * 30
+	AccountClaimTokenDuration   = time.Hour
 	ErrTokenIsInvalid           = errors.New("token is invalid")
 )
 
+// orderLookupAudience and accountClaimAudience set order lookup and account
+// claim tokens apart from access tokens and from each other, so that none is
+// accepted in place of another.
+const (
+	orderLookupAudience  = "order-lookup"
+	accountClaimAudience = "account-claim"
+)
+
 type Payload struct {
 	UserID               uuid.UUID `json:"userId"`
 	Admin                bool      `json:"admin"`
@@ -79,6 +89,109 @@ func (jwtToken *JWT) VerifyToken(tokenString string) (*Payload, error) {
 		return nil, ErrTokenIsInvalid
 	}
 	payload, ok := token.Claims.(*Payload)
+	if !ok || len(payload.Audience) > 0 {
+		return nil, ErrTokenIsInvalid
+	}
+	return payload, nil
+}
+
+// OrderLookupPayload is the payload of the token a guest looks up their order
+// with. UserID is the shadow user the order was placed under.
+type OrderLookupPayload struct {
+	OrderID              uuid.UUID `json:"orderId"`
+	UserID               uuid.UUID `json:"userId"`
+	Email                string    `json:"email"`
+	jwt.RegisteredClaims `json:"claims"`
+}
+
+// CreateOrderLookupToken signs a token giving access to a guest order.
+func (jwtToken *JWT) CreateOrderLookupToken(orderId uuid.UUID, userId uuid.UUID, email string) (string, error) {
+	tokenId, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+	payload := &OrderLookupPayload{
+		OrderID: orderId,
+		UserID:  userId,
+		Email:   email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenId.String(),
+			Audience:  jwt.ClaimStrings{orderLookupAudience},
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(OrderLookupTokenDuration)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	return token.SignedString([]byte(jwtToken.secretKey))
+}
+
+// VerifyOrderLookupToken checks a token created by CreateOrderLookupToken.
+// Access tokens are rejected.
+func (jwtToken *JWT) VerifyOrderLookupToken(tokenString string) (*OrderLookupPayload, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &OrderLookupPayload{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("invalid token signing method: %v", token.Header["alg"])
+		}
+		return []byte(jwtToken.secretKey), nil
+	}, jwt.WithAudience(orderLookupAudience))
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, jwt.ErrTokenExpired
+		}
+		return nil, ErrTokenIsInvalid
+	}
+	payload, ok := token.Claims.(*OrderLookupPayload)
+	if !ok {
+		return nil, ErrTokenIsInvalid
+	}
+	return payload, nil
+}
+
+// AccountClaimPayload is the payload of the token a guest claims their account
+// with. UserID is the shadow user of the guest.
+type AccountClaimPayload struct {
+	UserID               uuid.UUID `json:"userId"`
+	Email                string    `json:"email"`
+	jwt.RegisteredClaims `json:"claims"`
+}
+
+// CreateAccountClaimToken signs a short-lived token turning the shadow user of
+// a guest into an account.
+func (jwtToken *JWT) CreateAccountClaimToken(userId uuid.UUID, email string) (string, error) {
+	tokenId, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+	payload := &AccountClaimPayload{
+		UserID: userId,
+		Email:  email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenId.String(),
+			Audience:  jwt.ClaimStrings{accountClaimAudience},
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccountClaimTokenDuration)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	return token.SignedString([]byte(jwtToken.secretKey))
+}
+
+// VerifyAccountClaimToken checks a token created by CreateAccountClaimToken.
+// Access and order lookup tokens are rejected.
+func (jwtToken *JWT) VerifyAccountClaimToken(tokenString string) (*AccountClaimPayload, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &AccountClaimPayload{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("invalid token signing method: %v", token.Header["alg"])
+		}
+		return []byte(jwtToken.secretKey), nil
+	}, jwt.WithAudience(accountClaimAudience))
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, jwt.ErrTokenExpired
+		}
+		return nil, ErrTokenIsInvalid
+	}
+	payload, ok := token.Claims.(*AccountClaimPayload)
 	if !ok {
 		return nil, ErrTokenIsInvalid
 	}
@@ -0,0 +1,450 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/fulfilment"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCreateBackorderableProduct(t *testing.T) {
+	availableAt := time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		body     gin.H
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Pre-order",
+			body: gin.H{"name": "Console", "description": "Next gen console", "price": 500, "stock": 0, "backorderPolicy": "preorder", "availableAt": availableAt},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProductTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateProductParams) (db.Product, error, error) {
+						require.Equal(t, db.BackorderPolicyPREORDER, arg.BackorderPolicy)
+						require.True(t, arg.AvailableAt.Valid)
+						require.Equal(t, availableAt, arg.AvailableAt.Time)
+						return db.Product{ID: arg.ID, BackorderPolicy: arg.BackorderPolicy, AvailableAt: arg.AvailableAt}, nil, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "No Policy",
+			body: gin.H{"name": "Console", "description": "Next gen console", "price": 500, "stock": 3, "availableAt": availableAt},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProductTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateProductParams) (db.Product, error, error) {
+						// An availability date means nothing without backorders
+						require.Equal(t, db.BackorderPolicyNONE, arg.BackorderPolicy)
+						require.False(t, arg.AvailableAt.Valid)
+						return db.Product{ID: arg.ID, BackorderPolicy: arg.BackorderPolicy}, nil, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Unknown Policy",
+			body: gin.H{"name": "Console", "description": "Next gen console", "price": 500, "stock": 3, "backorderPolicy": "later"},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateProductTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "backorderPolicy must be NONE, BACKORDER or PREORDER")
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/v1/admin/products", bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestUpdateProductBackorderPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productId := uuid.New()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		UpdateProductTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.UpdateProductTxParams) (db.Product, error, error) {
+			require.Equal(t, db.BackorderPolicyBACKORDER, *arg.BackorderPolicy)
+			require.Nil(t, arg.AvailableAt)
+			return db.Product{ID: productId, BackorderPolicy: *arg.BackorderPolicy}, nil, nil
+		}).
+		Times(1)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/admin/products/%s", productId), bytes.NewReader([]byte(`{"backorderPolicy":"Backorder"}`)))
+	require.NoError(t, err)
+	request.Header.Set("If-Match", "*")
+	addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+	server.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestUpdateBackorderedOrderStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderId := uuid.New()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		UpdateOrderTx(gomock.Any(), gomock.Any()).
+		Return(db.Order{}, fmt.Errorf("update order: %w", fulfilment.ErrBackordered)).
+		Times(1)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/admin/orders/%s", orderId), bytes.NewReader([]byte(`{"status":"COMPLETED"}`)))
+	require.NoError(t, err)
+	addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+	server.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "order is backordered")
+}
+
+func TestCanCancel(t *testing.T) {
+	testCases := []struct {
+		status   db.OrderStatus
+		customer bool
+		admin    bool
+	}{
+		{status: db.OrderStatusPENDING, customer: true, admin: true},
+		{status: db.OrderStatusBACKORDERED, customer: true, admin: true},
+		{status: db.OrderStatusCOMPLETED, customer: false, admin: true},
+		// The units have left the warehouses
+		{status: db.OrderStatusPARTIALLYSHIPPED},
+		{status: db.OrderStatusSHIPPED},
+		{status: db.OrderStatusDELIVERED},
+		// The units are back in stock already
+		{status: db.OrderStatusCANCELLED},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.status), func(t *testing.T) {
+			require.Equal(t, tc.customer, db.CanCancel(tc.status, false))
+			require.Equal(t, tc.admin, db.CanCancel(tc.status, true))
+		})
+	}
+}
+
+func TestCancelShippedOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderId := uuid.New()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		UpdateOrderTx(gomock.Any(), gomock.Eq(db.UpdateOrderTxParams{ID: orderId, UserId: testUserId, Admin: true, Status: db.OrderStatusCANCELLED})).
+		Return(db.Order{}, fulfilment.ErrNotCancellable).
+		Times(1)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/admin/orders/%s", orderId), bytes.NewReader([]byte(`{"status":"CANCELLED"}`)))
+	require.NoError(t, err)
+	addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+	server.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusConflict, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "only PENDING, COMPLETED or BACKORDERED orders that have not shipped can be cancelled")
+}
+
+func TestCancelOrderTwice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderId := uuid.New()
+	store := mockdb.NewMockStore(ctrl)
+	cancel := store.EXPECT().
+		UpdateOrderTx(gomock.Any(), gomock.Eq(db.UpdateOrderTxParams{ID: orderId, UserId: testUserId, Status: db.OrderStatusCANCELLED})).
+		Return(db.Order{ID: orderId, UserId: testUserId, Status: db.OrderStatusCANCELLED}, nil).
+		Times(1)
+	store.EXPECT().
+		UpdateOrderTx(gomock.Any(), gomock.Any()).
+		Return(db.Order{}, fulfilment.ErrNotCancellable).
+		After(cancel).
+		Times(1)
+
+	server := newTestServer(t, store)
+	codes := make([]int, 2)
+	for i := range codes {
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/orders/%s", orderId), nil)
+		require.NoError(t, err)
+		addAuthorization(t, request, server.TokenCreator(), testUserId, false)
+		server.Router().ServeHTTP(recorder, request)
+		codes[i] = recorder.Code
+	}
+	require.Equal(t, []int{http.StatusOK, http.StatusConflict}, codes)
+}
+
+func TestCanChangeStatus(t *testing.T) {
+	statuses := []db.OrderStatus{
+		db.OrderStatusPENDING,
+		db.OrderStatusCOMPLETED,
+		db.OrderStatusBACKORDERED,
+		db.OrderStatusPARTIALLYSHIPPED,
+		db.OrderStatusSHIPPED,
+		db.OrderStatusDELIVERED,
+		db.OrderStatusCANCELLED,
+	}
+	for _, from := range statuses {
+		for _, to := range statuses {
+			allowed := from == db.OrderStatusPENDING && to == db.OrderStatusCOMPLETED
+			require.Equal(t, allowed, db.CanChangeStatus(from, to), "%s to %s", from, to)
+		}
+	}
+}
+
+func TestChangeOrderStatusConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderId := uuid.New()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		UpdateOrderTx(gomock.Any(), gomock.Eq(db.UpdateOrderTxParams{ID: orderId, UserId: testUserId, Admin: true, Status: db.OrderStatusPENDING})).
+		Return(db.Order{}, fulfilment.ErrStatusChange).
+		Times(1)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/admin/orders/%s", orderId), bytes.NewReader([]byte(`{"status":"PENDING"}`)))
+	require.NoError(t, err)
+	addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+	server.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusConflict, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "only PENDING orders can be moved to COMPLETED")
+}
+
+func TestUpdateOrderTxStatusChange(t *testing.T) {
+	testCases := []struct {
+		from db.OrderStatus
+		to   db.OrderStatus
+		err  error
+	}{
+		{from: db.OrderStatusPENDING, to: db.OrderStatusCOMPLETED},
+		{from: db.OrderStatusCOMPLETED, to: db.OrderStatusPENDING, err: fulfilment.ErrStatusChange},
+		{from: db.OrderStatusSHIPPED, to: db.OrderStatusPENDING, err: fulfilment.ErrStatusChange},
+		{from: db.OrderStatusPARTIALLYSHIPPED, to: db.OrderStatusCOMPLETED, err: fulfilment.ErrStatusChange},
+		{from: db.OrderStatusDELIVERED, to: db.OrderStatusCOMPLETED, err: fulfilment.ErrStatusChange},
+		{from: db.OrderStatusCANCELLED, to: db.OrderStatusPENDING, err: fulfilment.ErrStatusChange},
+		{from: db.OrderStatusBACKORDERED, to: db.OrderStatusCOMPLETED, err: fulfilment.ErrBackordered},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s to %s", tc.from, tc.to), func(t *testing.T) {
+			orderId := uuid.New()
+			fake := newFakeDB()
+			fake.on("GetOrderForUpdate", func(args []any) (any, error) {
+				return db.Order{ID: orderId, Status: tc.from}, nil
+			})
+			fake.on("UpdateOrderStatus", func(args []any) (any, error) {
+				return db.Order{ID: orderId, Status: args[0].(db.OrderStatus)}, nil
+			})
+			order, err := db.NewStore(fake).UpdateOrderTx(context.Background(), db.UpdateOrderTxParams{ID: orderId, Admin: true, Status: tc.to})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				require.Empty(t, fake.called("UpdateOrderStatus"))
+				require.True(t, fake.rolledBack)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.to, order.Status)
+			require.True(t, fake.committed)
+		})
+	}
+}
+
+func TestCancelOrderTxWithShipments(t *testing.T) {
+	orderId := uuid.New()
+	fake := newFakeDB()
+	fake.on("GetOrderForUpdate", func(args []any) (any, error) {
+		return db.Order{ID: orderId, Status: db.OrderStatusCOMPLETED}, nil
+	})
+	fake.on("GetShipmentByOrderId", func(args []any) (any, error) {
+		return []db.Shipment{{ID: uuid.New(), OrderId: orderId}}, nil
+	})
+	_, err := db.NewStore(fake).UpdateOrderTx(context.Background(), db.UpdateOrderTxParams{ID: orderId, Admin: true, Status: db.OrderStatusCANCELLED})
+	require.ErrorIs(t, err, fulfilment.ErrNotCancellable)
+	// Nothing is restocked
+	require.Equal(t, []string{"GetOrderForUpdate", "GetShipmentByOrderId"}, fake.names())
+	require.True(t, fake.rolledBack)
+}
+
+// waitForBackorders makes the orders of items wait on fake for their
+// backordered units, the orders holding the statuses given. Like the queries
+// it stands in for, only the items of BACKORDERED orders are handed units.
+func waitForBackorders(fake *fakeDB, statuses map[uuid.UUID]db.OrderStatus, items ...*db.OrderItem) {
+	fake.on("GetOrderForUpdate", func(args []any) (any, error) {
+		orderId := args[0].(uuid.UUID)
+		return db.Order{ID: orderId, UserId: testUserId, Status: statuses[orderId]}, nil
+	})
+	fake.on("CancelOrder", func(args []any) (any, error) {
+		orderId := args[0].(uuid.UUID)
+		statuses[orderId] = db.OrderStatusCANCELLED
+		return db.Order{ID: orderId, UserId: testUserId, Status: db.OrderStatusCANCELLED}, nil
+	})
+	fake.on("UpdateOrderStatus", func(args []any) (any, error) {
+		orderId := args[1].(uuid.UUID)
+		statuses[orderId] = args[0].(db.OrderStatus)
+		return db.Order{ID: orderId, UserId: testUserId, Status: statuses[orderId]}, nil
+	})
+	fake.on("GetBackorderedItemsForUpdate", func(args []any) (any, error) {
+		var rows []db.GetBackorderedItemsForUpdateRow
+		for _, item := range items {
+			if item.ProductId == args[0] && item.Backordered > 0 && statuses[item.OrderId] == db.OrderStatusBACKORDERED {
+				rows = append(rows, db.GetBackorderedItemsForUpdateRow{ID: item.ID, OrderId: item.OrderId, UserId: testUserId, Backordered: item.Backordered})
+			}
+		}
+		return rows, nil
+	})
+	fake.on("UpdateOrderItemBackordered", func(args []any) (any, error) {
+		for _, item := range items {
+			if item.ID == args[1] {
+				item.Backordered = args[0].(int32)
+				return *item, nil
+			}
+		}
+		return nil, nil
+	})
+	fake.on("CountBackorderedItems", func(args []any) (any, error) {
+		var remaining int64
+		for _, item := range items {
+			if item.OrderId == args[0] && item.Backordered > 0 {
+				remaining++
+			}
+		}
+		return remaining, nil
+	})
+}
+
+func TestCancelOrderTxAllocatesBackorders(t *testing.T) {
+	warehouseId := uuid.New()
+	product := db.Product{ID: uuid.New(), Price: 10, BackorderPolicy: db.BackorderPolicyBACKORDER}
+	cancel := func(t *testing.T, fake *fakeDB, orderId uuid.UUID) {
+		fake.on("GetOrderAllocations", func(args []any) (any, error) {
+			return []db.GetOrderAllocationsRow{{ProductId: product.ID, WarehouseId: warehouseId, Quantity: 2}}, nil
+		})
+		order, err := db.NewStore(fake).UpdateOrderTx(context.Background(), db.UpdateOrderTxParams{
+			ID:     orderId,
+			UserId: testUserId,
+			Status: db.OrderStatusCANCELLED,
+		})
+		require.NoError(t, err)
+		require.Equal(t, db.OrderStatusCANCELLED, order.Status)
+		require.True(t, fake.committed)
+	}
+
+	t.Run("Hands Units To Waiting Order", func(t *testing.T) {
+		cancelled, waiting := uuid.New(), uuid.New()
+		statuses := map[uuid.UUID]db.OrderStatus{cancelled: db.OrderStatusPENDING, waiting: db.OrderStatusBACKORDERED}
+		item := &db.OrderItem{ID: uuid.New(), OrderId: waiting, ProductId: product.ID, Quantity: 2, Backordered: 2}
+		fake := stockedFakeDB(warehouseId, product)
+		waitForBackorders(fake, statuses, item)
+		cancel(t, fake, cancelled)
+
+		// The units put back are allocated to the waiting order, which has
+		// nothing left backordered
+		allocations := fake.called("CreateOrderAllocation")
+		require.Len(t, allocations, 1)
+		require.Equal(t, waiting, allocations[0].Args[1])
+		require.Equal(t, int32(2), allocations[0].Args[5])
+		require.Zero(t, item.Backordered)
+		require.Equal(t, db.OrderStatusPENDING, statuses[waiting])
+		require.Equal(t, db.OrderStatusCANCELLED, statuses[cancelled])
+	})
+
+	t.Run("Not Its Own Units", func(t *testing.T) {
+		cancelled, waiting := uuid.New(), uuid.New()
+		statuses := map[uuid.UUID]db.OrderStatus{cancelled: db.OrderStatusBACKORDERED, waiting: db.OrderStatusBACKORDERED}
+		// Both orders wait on a unit, the one cancelled having 2 allocated
+		own := &db.OrderItem{ID: uuid.New(), OrderId: cancelled, ProductId: product.ID, Quantity: 3, Backordered: 1}
+		item := &db.OrderItem{ID: uuid.New(), OrderId: waiting, ProductId: product.ID, Quantity: 1, Backordered: 1}
+		fake := stockedFakeDB(warehouseId, product)
+		waitForBackorders(fake, statuses, own, item)
+		cancel(t, fake, cancelled)
+
+		// The backorders are allocated once the order is cancelled, so it is
+		// no longer waiting for the units it put back
+		names := fake.names()
+		require.Less(t, slices.Index(names, "CancelOrder"), slices.Index(names, "GetBackorderedItemsForUpdate"))
+		updates := fake.called("UpdateOrderItemBackordered")
+		require.Len(t, updates, 1)
+		require.Equal(t, []any{int32(0), item.ID}, updates[0].Args)
+		require.Equal(t, int32(1), own.Backordered)
+		require.Equal(t, db.OrderStatusPENDING, statuses[waiting])
+	})
+}
+
+func TestImportProductTxAllocatesBackorders(t *testing.T) {
+	warehouseId, waiting := uuid.New(), uuid.New()
+	product := db.Product{ID: uuid.New(), Name: "Mug", Sku: "MG-1", Price: 8, BackorderPolicy: db.BackorderPolicyBACKORDER}
+	statuses := map[uuid.UUID]db.OrderStatus{waiting: db.OrderStatusBACKORDERED}
+	item := &db.OrderItem{ID: uuid.New(), OrderId: waiting, ProductId: product.ID, Quantity: 2, Backordered: 2}
+	// The import brings the product up to 5 units, held by one warehouse
+	fake := importFakeDB(product, []db.GetAllocatableStockRow{{WarehouseId: warehouseId, ProductId: product.ID, Stock: 5}})
+	fake.on("UpdateProductStock", func(args []any) (any, error) {
+		restocked := product
+		restocked.Stock = 5 - args[1].(int32)
+		return restocked, nil
+	})
+	waitForBackorders(fake, statuses, item)
+
+	products, invalidRows, execErr, txErr := db.NewStore(fake).ImportProductTx(context.Background(), db.ImportProductTxParams{
+		Rows: []db.ImportProductRow{{Name: "Mug", Sku: "MG-1", Price: 8, Stock: 5}},
+	})
+	require.NoError(t, execErr)
+	require.NoError(t, txErr)
+	require.Empty(t, invalidRows)
+	require.Len(t, products, 1)
+	require.Equal(t, int32(3), products[0].Stock)
+
+	allocations := fake.called("CreateOrderAllocation")
+	require.Len(t, allocations, 1)
+	require.Equal(t, waiting, allocations[0].Args[1])
+	require.Equal(t, int32(2), allocations[0].Args[5])
+	require.Zero(t, item.Backordered)
+	require.Equal(t, db.OrderStatusPENDING, statuses[waiting])
+	require.True(t, fake.committed)
+}
@@ -0,0 +1,334 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/coupon"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/pkg/token"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreateCoupon(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     gin.H
+		auth     func(t *testing.T, req *http.Request, tokenCreator *token.JWT)
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success",
+			body: gin.H{
+				"code":         "welcome10",
+				"discountType": "percentage",
+				"value":        10,
+				"usageLimit":   100,
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, true)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCoupon(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateCouponParams) (db.Coupon, error) {
+						require.Equal(t, "WELCOME10", arg.Code)
+						require.Equal(t, db.DiscountTypePERCENTAGE, arg.DiscountType)
+						require.True(t, arg.Active)
+						return db.Coupon{ID: arg.ID, Code: arg.Code}, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Percentage Over 100",
+			body: gin.H{
+				"code":         "HALFPRICE",
+				"discountType": "PERCENTAGE",
+				"value":        150,
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, true)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCoupon(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Expires Before Start",
+			body: gin.H{
+				"code":         "SUMMER",
+				"discountType": "FIXED",
+				"value":        500,
+				"startsAt":     "2025-06-01T00:00:00Z",
+				"expiresAt":    "2025-05-01T00:00:00Z",
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, true)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCoupon(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			body: gin.H{
+				"code":         "WELCOME10",
+				"discountType": "PERCENTAGE",
+				"value":        10,
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, false)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCoupon(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/api/v1/admin/coupons"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			tc.auth(t, request, server.TokenCreator())
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestCreateOrderWithCoupon(t *testing.T) {
+	productId := uuid.New()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		CreateOrderTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.CreateOrderTxParams) (db.Order, map[string]string, error, error) {
+			require.Equal(t, "WELCOME10", arg.CouponCode)
+			return db.Order{}, map[string]string{"couponCode": coupon.ErrExpired.Error()}, nil, nil
+		}).
+		Times(1)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+	reqBody, err := json.Marshal(gin.H{
+		"items":      []gin.H{{"productId": productId, "quantity": 1}},
+		"couponCode": " welcome10 ",
+	})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/orders", bytes.NewReader(reqBody))
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.TokenCreator(), testUserId, false)
+	server.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Contains(t, recorder.Body.String(), coupon.ErrExpired.Error())
+}
+
+func TestCouponDiscount(t *testing.T) {
+	now := time.Now()
+	phone, cable := uuid.New(), uuid.New()
+	lines := []coupon.Line{
+		{ProductId: phone, Category: "phones", Amount: 1000},
+		{ProductId: cable, Category: "accessories", Amount: 50},
+	}
+	testCases := []struct {
+		name        string
+		rule        coupon.Rule
+		redemptions int64
+		discount    float64
+		err         error
+	}{
+		{
+			name:     "Percentage",
+			rule:     coupon.Rule{DiscountType: coupon.Percentage, Value: 10, Active: true},
+			discount: 105,
+		},
+		{
+			name:     "Fixed Capped At Eligible Subtotal",
+			rule:     coupon.Rule{DiscountType: coupon.Fixed, Value: 80, Categories: []string{"accessories"}, Active: true},
+			discount: 50,
+		},
+		{
+			name:     "Product Restriction",
+			rule:     coupon.Rule{DiscountType: coupon.Percentage, Value: 50, ProductIds: []uuid.UUID{phone}, Active: true},
+			discount: 500,
+		},
+		{
+			name: "Not Applicable",
+			rule: coupon.Rule{DiscountType: coupon.Percentage, Value: 50, Categories: []string{"laptops"}, Active: true},
+			err:  coupon.ErrNotApplicable,
+		},
+		{
+			name: "Below Minimum Order Value",
+			rule: coupon.Rule{DiscountType: coupon.Fixed, Value: 10, MinOrderValue: 2000, Active: true},
+			err:  coupon.ErrMinOrderValue,
+		},
+		{
+			name: "Expired",
+			rule: coupon.Rule{DiscountType: coupon.Fixed, Value: 10, ExpiresAt: now.Add(-time.Hour), Active: true},
+			err:  coupon.ErrExpired,
+		},
+		{
+			name: "Not Started",
+			rule: coupon.Rule{DiscountType: coupon.Fixed, Value: 10, StartsAt: now.Add(time.Hour), Active: true},
+			err:  coupon.ErrNotStarted,
+		},
+		{
+			name: "Usage Limit Reached",
+			rule: coupon.Rule{DiscountType: coupon.Fixed, Value: 10, UsageLimit: 5, UsageCount: 5, Active: true},
+			err:  coupon.ErrUsageLimitReached,
+		},
+		{
+			name:        "Per User Limit Reached",
+			rule:        coupon.Rule{DiscountType: coupon.Fixed, Value: 10, PerUserLimit: 1, Active: true},
+			redemptions: 1,
+			err:         coupon.ErrUserLimitReached,
+		},
+		{
+			name: "Inactive",
+			rule: coupon.Rule{DiscountType: coupon.Fixed, Value: 10},
+			err:  coupon.ErrInactive,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			discount, err := coupon.Discount(tc.rule, lines, tc.redemptions, now)
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.discount, discount)
+		})
+	}
+}
+
+func TestDeleteRedeemedCoupon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	couponId := uuid.New()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		DeleteOneCoupon(gomock.Any(), gomock.Eq(couponId)).
+		Return(&pgconn.PgError{Code: "23503", ConstraintName: "fk_coupon"}).
+		Times(1)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/admin/coupons/%s", couponId), nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+	server.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusConflict, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "coupon has been redeemed, deactivate it instead")
+}
+
+func TestCreateOrderTxCouponUserLimit(t *testing.T) {
+	product := db.Product{ID: uuid.New(), Price: 100, Stock: 10, BackorderPolicy: db.BackorderPolicyNONE}
+	welcome := db.Coupon{ID: uuid.New(), Code: "WELCOME10", DiscountType: db.DiscountTypePERCENTAGE, Value: 10, PerUserLimit: 1, Active: true}
+	fake := stockedFakeDB(uuid.New(), product)
+	fake.on("GetCouponByCode", func(args []any) (any, error) {
+		return welcome, nil
+	})
+	fake.on("IncrementCouponUsage", func(args []any) (any, error) {
+		redeemed := welcome
+		redeemed.UsageCount++
+		return redeemed, nil
+	})
+	// A concurrent order of the user redeemed the coupon once it was
+	// validated, before this order locked it
+	var counts int64
+	fake.on("CountUserCouponRedemption", func(args []any) (any, error) {
+		counts++
+		return counts - 1, nil
+	})
+
+	_, invalidProducts, execErr, txErr := db.NewStore(fake).CreateOrderTx(context.Background(), db.CreateOrderTxParams{
+		ID:         uuid.New(),
+		UserId:     testUserId,
+		ProductIds: []uuid.UUID{product.ID},
+		Items:      map[uuid.UUID]int32{product.ID: 1},
+		CouponCode: welcome.Code,
+	})
+	require.NoError(t, execErr)
+	require.NoError(t, txErr)
+	require.Equal(t, map[string]string{"couponCode": coupon.ErrUserLimitReached.Error()}, invalidProducts)
+	require.Equal(t, int64(2), counts)
+	require.Empty(t, fake.called("CreateCouponRedemption"))
+	require.True(t, fake.rolledBack)
+	require.False(t, fake.committed)
+}
+
+func TestCancelOrderTxReleasesCoupon(t *testing.T) {
+	orderId, couponId := uuid.New(), uuid.New()
+	product := db.Product{ID: uuid.New(), Price: 100, Stock: 9}
+	fake := stockedFakeDB(uuid.New(), product)
+	fake.on("GetOrderForUpdate", func(args []any) (any, error) {
+		return db.Order{ID: orderId, UserId: testUserId, Status: db.OrderStatusPENDING}, nil
+	})
+	fake.on("GetOrderAllocations", func(args []any) (any, error) {
+		return []db.GetOrderAllocationsRow{{ProductId: product.ID, WarehouseId: uuid.New(), Quantity: 1}}, nil
+	})
+	fake.on("DeleteCouponRedemptionByOrderId", func(args []any) (any, error) {
+		return []db.CouponRedemption{{ID: uuid.New(), CouponId: couponId, UserId: testUserId, OrderId: orderId}}, nil
+	})
+	fake.on("CancelOrder", func(args []any) (any, error) {
+		return db.Order{ID: orderId, UserId: testUserId, Status: db.OrderStatusCANCELLED}, nil
+	})
+
+	order, err := db.NewStore(fake).UpdateOrderTx(context.Background(), db.UpdateOrderTxParams{
+		ID:     orderId,
+		UserId: testUserId,
+		Status: db.OrderStatusCANCELLED,
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.OrderStatusCANCELLED, order.Status)
+	// The redemption is removed and no longer counts towards either limit
+	require.Equal(t, []any{orderId}, fake.called("DeleteCouponRedemptionByOrderId")[0].Args)
+	decrements := fake.called("DecrementCouponUsage")
+	require.Len(t, decrements, 1)
+	require.Equal(t, []any{couponId}, decrements[0].Args)
+	require.True(t, fake.committed)
+}
@@ -0,0 +1,340 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/events"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeSink struct {
+	sent []events.Envelope
+	fail func(event events.Envelope) error
+}
+
+func (s *fakeSink) Name() string {
+	return "fake"
+}
+
+func (s *fakeSink) Send(ctx context.Context, event events.Envelope) error {
+	s.sent = append(s.sent, event)
+	if s.fail != nil {
+		return s.fail(event)
+	}
+	return nil
+}
+
+func outboxEvent(t *testing.T, event db.Event) db.OutboxEvent {
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+	return db.OutboxEvent{
+		ID:        uuid.New(),
+		Type:      event.EventType(),
+		Payload:   payload,
+		Attempts:  1,
+		CreatedAt: pgtype.Timestamp{Time: time.Now().UTC(), Valid: true},
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	created := outboxEvent(t, db.OrderCreated{Order: db.Order{ID: uuid.New(), Total: 25}})
+	deleted := outboxEvent(t, db.ProductDeleted{Product: db.Product{ID: uuid.New(), Name: "Mug"}})
+	deleted.Attempts = 3
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ClaimOutboxEvents(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.ClaimOutboxEventsParams) ([]db.OutboxEvent, error) {
+			require.Equal(t, int32(10), arg.BatchSize)
+			require.True(t, arg.LeaseUntil.Time.After(arg.Now.Time))
+			return []db.OutboxEvent{created, deleted}, nil
+		}).
+		Times(1)
+	store.EXPECT().
+		MarkOutboxEventDispatched(gomock.Any(), gomock.Eq(created.ID)).
+		Times(1)
+	store.EXPECT().
+		RetryOutboxEvent(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.RetryOutboxEventParams) (db.OutboxEvent, error) {
+			require.Equal(t, deleted.ID, arg.ID)
+			require.Equal(t, "fake: broker unavailable", arg.LastError)
+			// The bus took the event and does not get it again
+			require.Equal(t, []string{"bus"}, arg.DeliveredSinks)
+			require.True(t, arg.NextAttemptAt.Time.After(time.Now().UTC()))
+			return deleted, nil
+		}).
+		Times(1)
+
+	bus := events.NewBus()
+	var orders []db.OrderCreated
+	events.On(bus, func(ctx context.Context, event db.OrderCreated) error {
+		orders = append(orders, event)
+		return nil
+	})
+	sink := &fakeSink{fail: func(event events.Envelope) error {
+		if event.Type == db.EventProductDeleted {
+			return errors.New("broker unavailable")
+		}
+		return nil
+	}}
+	dispatcher := events.NewDispatcher(store, events.Config{BatchSize: 10}, bus, sink)
+	count, err := dispatcher.Dispatch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	// Every sink sees every event, whatever the others did with it
+	require.Len(t, sink.sent, 2)
+	require.Equal(t, created.ID, sink.sent[0].ID)
+	require.Equal(t, db.EventOrderCreated, sink.sent[0].Type)
+	require.Len(t, orders, 1)
+	require.Equal(t, float64(25), orders[0].Order.Total)
+}
+
+func TestDispatcherRetriesFailedSinks(t *testing.T) {
+	created := outboxEvent(t, db.OrderCreated{Order: db.Order{ID: uuid.New(), Total: 25}})
+	created.Attempts = 2
+	created.DeliveredSinks = []string{"bus"}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ClaimOutboxEvents(gomock.Any(), gomock.Any()).
+		Return([]db.OutboxEvent{created}, nil).
+		Times(1)
+	store.EXPECT().
+		MarkOutboxEventDispatched(gomock.Any(), gomock.Eq(created.ID)).
+		Times(1)
+
+	bus := events.NewBus()
+	var orders []db.OrderCreated
+	events.On(bus, func(ctx context.Context, event db.OrderCreated) error {
+		orders = append(orders, event)
+		return nil
+	})
+	sink := &fakeSink{}
+	dispatcher := events.NewDispatcher(store, events.Config{}, bus, sink)
+	_, err := dispatcher.Dispatch(context.Background())
+	require.NoError(t, err)
+
+	// Only the sink that failed the last time gets the event
+	require.Empty(t, orders)
+	require.Len(t, sink.sent, 1)
+}
+
+func TestDispatcherGivesUp(t *testing.T) {
+	created := outboxEvent(t, db.OrderCreated{Order: db.Order{ID: uuid.New(), Total: 25}})
+	created.Attempts = 3
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ClaimOutboxEvents(gomock.Any(), gomock.Any()).
+		Return([]db.OutboxEvent{created}, nil).
+		Times(1)
+	store.EXPECT().
+		RetryOutboxEvent(gomock.Any(), gomock.Any()).
+		Times(0)
+	store.EXPECT().
+		FailOutboxEvent(gomock.Any(), gomock.Eq(db.FailOutboxEventParams{
+			ID:             created.ID,
+			LastError:      "fake: broker unavailable",
+			DeliveredSinks: []string{"bus"},
+		})).
+		Times(1)
+
+	sink := &fakeSink{fail: func(event events.Envelope) error {
+		return errors.New("broker unavailable")
+	}}
+	dispatcher := events.NewDispatcher(store, events.Config{MaxAttempts: 3}, events.NewBus(), sink)
+	_, err := dispatcher.Dispatch(context.Background())
+	require.NoError(t, err)
+}
+
+func TestDispatcherShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	claimed := make(chan struct{}, 1)
+	store.EXPECT().
+		ClaimOutboxEvents(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, _ db.ClaimOutboxEventsParams) ([]db.OutboxEvent, error) {
+			select {
+			case claimed <- struct{}{}:
+			default:
+			}
+			return nil, nil
+		}).
+		MinTimes(1)
+
+	dispatcher := events.NewDispatcher(store, events.Config{PollInterval: time.Hour})
+	dispatcher.Start()
+	<-claimed
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	// The dispatcher stops while waiting for the next poll
+	require.NoError(t, dispatcher.Shutdown(ctx))
+}
+
+func TestBus(t *testing.T) {
+	bus := events.NewBus()
+	var types []string
+	bus.Subscribe(events.All, func(ctx context.Context, event events.Envelope) error {
+		types = append(types, event.Type)
+		return nil
+	})
+	var lowStock []db.ProductStockLow
+	events.On(bus, func(ctx context.Context, event db.ProductStockLow) error {
+		lowStock = append(lowStock, event)
+		return nil
+	})
+	bus.Subscribe(db.EventOrderStatusChanged, func(ctx context.Context, event events.Envelope) error {
+		var changed map[string]any
+		changed["order"] = event.Data
+		return nil
+	})
+
+	low := outboxEvent(t, db.ProductStockLow{Product: db.Product{Name: "Mug", Stock: 2}, Threshold: 5})
+	require.NoError(t, bus.Send(context.Background(), events.Envelope{ID: low.ID, Type: low.Type, Data: low.Payload}))
+	require.Len(t, lowStock, 1)
+	require.Equal(t, int32(2), lowStock[0].Product.Stock)
+	require.Equal(t, int32(5), lowStock[0].Threshold)
+
+	// A handler that panics fails the event instead of the dispatcher
+	changed := outboxEvent(t, db.OrderStatusChanged{PreviousStatus: db.OrderStatusPENDING})
+	err := bus.Send(context.Background(), events.Envelope{ID: changed.ID, Type: changed.Type, Data: changed.Payload})
+	require.ErrorContains(t, err, "panicked")
+	require.Equal(t, []string{db.EventProductStockLow, db.EventOrderStatusChanged}, types)
+
+	_, err = events.Decode[db.OrderCreated](events.Envelope{Type: db.EventProductStockLow, Data: low.Payload})
+	require.Error(t, err)
+}
+
+// natsServer accepts one connection and answers like a NATS server. It
+// returns the published messages, or an -ERR to every publish with fail.
+func natsServer(t *testing.T, fail string) (string, chan map[string]string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	published := make(chan map[string]string, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		fmt.Fprint(conn, "INFO {\"server_id\":\"test\",\"headers\":true}\r\n")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			fields := strings.Fields(line)
+			switch fields[0] {
+			case "CONNECT":
+				published <- map[string]string{"connect": strings.TrimPrefix(strings.TrimSpace(line), "CONNECT ")}
+			case "PING":
+				fmt.Fprint(conn, "PONG\r\n")
+			case "HPUB":
+				headerSize, _ := strconv.Atoi(fields[2])
+				totalSize, _ := strconv.Atoi(fields[3])
+				message := make([]byte, totalSize+2)
+				if _, err = io.ReadFull(reader, message); err != nil {
+					return
+				}
+				if fail != "" {
+					fmt.Fprintf(conn, "-ERR '%s'\r\n", fail)
+					continue
+				}
+				published <- map[string]string{
+					"subject": fields[1],
+					"header":  string(message[:headerSize]),
+					"body":    string(message[headerSize:totalSize]),
+				}
+			}
+		}
+	}()
+	return listener.Addr().String(), published
+}
+
+func TestNATSSink(t *testing.T) {
+	address, published := natsServer(t, "")
+	sink := events.NewNATSSink(events.NATSConfig{URL: "nats://app:secret@" + address, SubjectPrefix: "instashop"})
+	defer sink.Close()
+	event := outboxEvent(t, db.OrderCreated{Order: db.Order{ID: uuid.New()}})
+	envelope := events.Envelope{ID: event.ID, Type: event.Type, CreatedAt: event.CreatedAt.Time, Data: event.Payload}
+
+	require.NoError(t, sink.Send(context.Background(), envelope))
+	var options map[string]any
+	require.NoError(t, json.Unmarshal([]byte((<-published)["connect"]), &options))
+	require.Equal(t, "app", options["user"])
+	require.Equal(t, "secret", options["pass"])
+	require.Equal(t, true, options["headers"])
+
+	message := <-published
+	require.Equal(t, "instashop.order.created", message["subject"])
+	require.Contains(t, message["header"], "Nats-Msg-Id: "+event.ID.String()+"\r\n")
+	var body events.Envelope
+	require.NoError(t, json.Unmarshal([]byte(message["body"]), &body))
+	require.Equal(t, event.ID, body.ID)
+	require.JSONEq(t, string(event.Payload), string(body.Data))
+
+	// The connection is reused
+	require.NoError(t, sink.Send(context.Background(), envelope))
+	require.Equal(t, "instashop.order.created", (<-published)["subject"])
+}
+
+func TestNATSSinkError(t *testing.T) {
+	address, _ := natsServer(t, "Permissions Violation for Publish")
+	sink := events.NewNATSSink(events.NATSConfig{URL: "nats://" + address, Timeout: time.Second})
+	defer sink.Close()
+	event := outboxEvent(t, db.ProductCreated{Product: db.Product{Name: "Mug"}})
+	err := sink.Send(context.Background(), events.Envelope{ID: event.ID, Type: event.Type, Data: event.Payload})
+	require.ErrorContains(t, err, "Permissions Violation")
+
+	sink = events.NewNATSSink(events.NATSConfig{URL: "http://" + address})
+	require.Error(t, sink.Send(context.Background(), events.Envelope{ID: event.ID, Type: event.Type, Data: event.Payload}))
+}
+
+type fakeProducer struct {
+	topic   string
+	key     []byte
+	value   []byte
+	headers map[string]string
+}
+
+func (p *fakeProducer) Produce(ctx context.Context, topic string, key, value []byte, headers map[string]string) error {
+	p.topic, p.key, p.value, p.headers = topic, key, value, headers
+	return nil
+}
+
+func TestKafkaSink(t *testing.T) {
+	producer := &fakeProducer{}
+	sink := events.NewKafkaSink(producer, "instashop.events")
+	event := outboxEvent(t, db.ProductUpdated{Product: db.Product{Name: "Mug"}})
+	require.NoError(t, sink.Send(context.Background(), events.Envelope{ID: event.ID, Type: event.Type, Data: event.Payload}))
+	require.Equal(t, "instashop.events", producer.topic)
+	require.Equal(t, event.ID.String(), string(producer.key))
+	require.Equal(t, db.EventProductUpdated, producer.headers["event-type"])
+	var body events.Envelope
+	require.NoError(t, json.Unmarshal(producer.value, &body))
+	require.Equal(t, db.EventProductUpdated, body.Type)
+}
@@ -0,0 +1,324 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// fakeCall is a query run on a fakeDB.
+type fakeCall struct {
+	Name string
+	Args []any
+}
+
+// fakeHandler answers a query run on a fakeDB. A slice is returned as the
+// rows of a :many query, anything else as the row of a :one query or the
+// rows affected by an :exec query. nil means no rows.
+type fakeHandler func(args []any) (any, error)
+
+// fakeDB stands in for the connection pool of a db.SQLStore, so that the
+// transactions of the store can be tested without a database. Queries are
+// recorded by their sqlc name and answered by the handler registered for
+// that name. Queries without a handler affect one row and return zero
+// values, or no rows for a :many query.
+type fakeDB struct {
+	mu         sync.Mutex
+	handlers   map[string]fakeHandler
+	calls      []fakeCall
+	committed  bool
+	rolledBack bool
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{handlers: make(map[string]fakeHandler)}
+}
+
+// on registers the handler answering the queries named name.
+func (f *fakeDB) on(name string, handler fakeHandler) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handlers[name] = handler
+}
+
+// names returns the names of the queries run so far, in order.
+func (f *fakeDB) names() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	names := make([]string, 0, len(f.calls))
+	for _, call := range f.calls {
+		names = append(names, call.Name)
+	}
+	return names
+}
+
+// called returns the calls of the query named name.
+func (f *fakeDB) called(name string) []fakeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var calls []fakeCall
+	for _, call := range f.calls {
+		if call.Name == name {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// queryName is the sqlc name of a query, or its first words for raw SQL.
+func queryName(sql string) string {
+	sql = strings.TrimSpace(sql)
+	if strings.HasPrefix(sql, "-- name: ") {
+		return strings.Fields(sql)[2]
+	}
+	words := strings.Fields(sql)
+	if len(words) > 3 {
+		words = words[:3]
+	}
+	return strings.Join(words, " ")
+}
+
+func (f *fakeDB) run(sql string, args []any) (any, error) {
+	name := queryName(sql)
+	f.mu.Lock()
+	f.calls = append(f.calls, fakeCall{Name: name, Args: args})
+	handler, ok := f.handlers[name]
+	f.mu.Unlock()
+	if !ok {
+		return nil, errNoHandler
+	}
+	return handler(args)
+}
+
+var errNoHandler = errors.New("no handler")
+
+func (f *fakeDB) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	result, err := f.run(sql, args)
+	rows := int64(1)
+	switch {
+	case errors.Is(err, errNoHandler):
+	case err != nil:
+		return pgconn.CommandTag{}, err
+	case result == nil:
+		rows = 0
+	default:
+		rows = reflect.ValueOf(result).Int()
+	}
+	verb := strings.ToUpper(strings.Fields(strings.TrimSpace(stripComment(sql)))[0])
+	if verb == "INSERT" {
+		return pgconn.NewCommandTag(fmt.Sprintf("INSERT 0 %d", rows)), nil
+	}
+	return pgconn.NewCommandTag(fmt.Sprintf("%s %d", verb, rows)), nil
+}
+
+func stripComment(sql string) string {
+	sql = strings.TrimSpace(sql)
+	if strings.HasPrefix(sql, "--") {
+		if i := strings.Index(sql, "\n"); i >= 0 {
+			return sql[i+1:]
+		}
+	}
+	return sql
+}
+
+func (f *fakeDB) Query(_ context.Context, sql string, args ...any) (pgx.Rows, error) {
+	result, err := f.run(sql, args)
+	if errors.Is(err, errNoHandler) {
+		return &fakeRows{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rows := &fakeRows{}
+	if result != nil {
+		value := reflect.ValueOf(result)
+		for i := 0; i < value.Len(); i++ {
+			rows.rows = append(rows.rows, value.Index(i).Interface())
+		}
+	}
+	return rows, nil
+}
+
+func (f *fakeDB) QueryRow(_ context.Context, sql string, args ...any) pgx.Row {
+	result, err := f.run(sql, args)
+	if errors.Is(err, errNoHandler) {
+		return fakeRow{}
+	}
+	if err == nil && result == nil {
+		err = pgx.ErrNoRows
+	}
+	return fakeRow{row: result, err: err}
+}
+
+func (f *fakeDB) Begin(context.Context) (pgx.Tx, error) {
+	return f, nil
+}
+
+func (f *fakeDB) Commit(context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.committed = true
+	return nil
+}
+
+func (f *fakeDB) Rollback(context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rolledBack = true
+	return nil
+}
+
+func (f *fakeDB) CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error) {
+	return 0, errors.New("fakeDB: CopyFrom is not supported")
+}
+
+func (f *fakeDB) SendBatch(context.Context, *pgx.Batch) pgx.BatchResults {
+	panic("fakeDB: SendBatch is not supported")
+}
+
+func (f *fakeDB) LargeObjects() pgx.LargeObjects {
+	panic("fakeDB: LargeObjects is not supported")
+}
+
+func (f *fakeDB) Prepare(context.Context, string, string) (*pgconn.StatementDescription, error) {
+	return nil, errors.New("fakeDB: Prepare is not supported")
+}
+
+func (f *fakeDB) Conn() *pgx.Conn {
+	return nil
+}
+
+// scanRow copies row into dest: the fields of a struct in order, or the
+// value itself.
+func scanRow(row any, dest []any) error {
+	if row == nil {
+		return nil
+	}
+	value := reflect.ValueOf(row)
+	var values []reflect.Value
+	if value.Kind() == reflect.Struct && len(dest) > 1 {
+		for i := 0; i < value.NumField(); i++ {
+			values = append(values, value.Field(i))
+		}
+	} else {
+		values = []reflect.Value{value}
+	}
+	if len(values) != len(dest) {
+		return fmt.Errorf("fakeDB: %d values scanned into %d destinations", len(values), len(dest))
+	}
+	for i, d := range dest {
+		target := reflect.ValueOf(d).Elem()
+		switch {
+		case values[i].Type().AssignableTo(target.Type()):
+			target.Set(values[i])
+		case values[i].Type().ConvertibleTo(target.Type()):
+			target.Set(values[i].Convert(target.Type()))
+		default:
+			return fmt.Errorf("fakeDB: cannot scan %s into %s", values[i].Type(), target.Type())
+		}
+	}
+	return nil
+}
+
+type fakeRow struct {
+	row any
+	err error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanRow(r.row, dest)
+}
+
+type fakeRows struct {
+	rows    []any
+	current int
+}
+
+func (r *fakeRows) Close()                                       {}
+func (r *fakeRows) Err() error                                   { return nil }
+func (r *fakeRows) CommandTag() pgconn.CommandTag                { return pgconn.CommandTag{} }
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (r *fakeRows) RawValues() [][]byte                          { return nil }
+func (r *fakeRows) Conn() *pgx.Conn                              { return nil }
+
+func (r *fakeRows) Next() bool {
+	if r.current >= len(r.rows) {
+		return false
+	}
+	r.current++
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	return scanRow(r.rows[r.current-1], dest)
+}
+
+func (r *fakeRows) Values() ([]any, error) {
+	return nil, errors.New("fakeDB: Values is not supported")
+}
+
+// stockedFakeDB is a fakeDB holding the stock of products in a warehouse, so
+// that placing and cancelling orders see the stock the other left. The stock
+// of the products and that of the warehouse change apart, as they do in the
+// database.
+func stockedFakeDB(warehouseId uuid.UUID, products ...db.Product) *fakeDB {
+	fake := newFakeDB()
+	catalog := make(map[uuid.UUID]db.Product, len(products))
+	held := make(map[uuid.UUID]int32, len(products))
+	for _, product := range products {
+		catalog[product.ID] = product
+		held[product.ID] = product.Stock
+	}
+	fake.on("GetMultipleProductById", func(args []any) (any, error) {
+		var rows []db.GetMultipleProductByIdRow
+		for _, id := range args[0].([]uuid.UUID) {
+			if product, ok := catalog[id]; ok {
+				rows = append(rows, db.GetMultipleProductByIdRow{
+					ID:              product.ID,
+					Price:           product.Price,
+					Stock:           product.Stock,
+					Category:        product.Category,
+					TaxClass:        product.TaxClass,
+					Weight:          product.Weight,
+					BackorderPolicy: product.BackorderPolicy,
+				})
+			}
+		}
+		return rows, nil
+	})
+	fake.on("GetAllocatableStock", func(args []any) (any, error) {
+		var rows []db.GetAllocatableStockRow
+		for _, id := range args[0].([]uuid.UUID) {
+			if held[id] > 0 {
+				rows = append(rows, db.GetAllocatableStockRow{WarehouseId: warehouseId, ProductId: id, Stock: held[id]})
+			}
+		}
+		return rows, nil
+	})
+	fake.on("GetWarehouseStockForUpdate", func(args []any) (any, error) {
+		productId := args[1].(uuid.UUID)
+		return db.WarehouseStock{WarehouseId: warehouseId, ProductId: productId, Stock: held[productId]}, nil
+	})
+	fake.on("AddWarehouseStock", func(args []any) (any, error) {
+		productId := args[1].(uuid.UUID)
+		held[productId] += args[2].(int32)
+		return db.WarehouseStock{WarehouseId: warehouseId, ProductId: productId, Stock: held[productId]}, nil
+	})
+	fake.on("UpdateProductStock", func(args []any) (any, error) {
+		product := catalog[args[0].(uuid.UUID)]
+		product.Stock -= args[1].(int32)
+		catalog[product.ID] = product
+		return product, nil
+	})
+	return fake
+}
@@ -0,0 +1,621 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/constants"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/events"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/mailer"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/slamchillz/getinstashop-ecommerce-api/pkg/token"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestCreateGuestOrder(t *testing.T) {
+	guestId := uuid.New()
+	productId := uuid.New()
+	address := gin.H{"name": "Ada Lovelace", "line1": "1 Infinite Loop", "city": "Cupertino", "postalCode": "95014", "country": "us", "state": "ca"}
+	validBody := gin.H{
+		"email":   "ada@example.com",
+		"address": address,
+		"items":   []gin.H{{"productId": productId, "quantity": 2}},
+	}
+	placeOrder := func(store *mockdb.MockStore) {
+		store.EXPECT().
+			CreateOrderTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, arg db.CreateOrderTxParams) (db.Order, map[string]string, error, error) {
+				require.Equal(t, guestId, arg.UserId)
+				require.Equal(t, "US-CA", arg.ShippingRegion)
+				require.Equal(t, map[uuid.UUID]int32{productId: 2}, arg.Items)
+				require.NotNil(t, arg.Guest)
+				require.Equal(t, "ada@example.com", arg.Guest.Email)
+				require.Equal(t, "Ada Lovelace", arg.Guest.Name)
+				require.Equal(t, "US", arg.Guest.Country)
+				require.Equal(t, "CA", arg.Guest.State)
+				return db.Order{ID: arg.ID, UserId: arg.UserId, Total: 25}, nil, nil, nil
+			}).
+			Times(1)
+		store.EXPECT().GetOrderTaxByOrderIds(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+		store.EXPECT().GetOrderPromotionsByOrderIds(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+	}
+
+	testCases := []struct {
+		name     string
+		body     gin.H
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "First Order",
+			body: validBody,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Eq("ada@example.com")).Return(db.GetUserByIdRow{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().
+					CreateGuestUser(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateGuestUserParams) (db.User, error) {
+						require.Equal(t, "ada@example.com", arg.Email)
+						return db.User{ID: guestId, Email: arg.Email, Guest: true}, nil
+					}).
+					Times(1)
+				placeOrder(store)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				// The lookup token proves the guest owns the email, so it is only emailed
+				require.NotContains(t, recorder.Body.String(), "eyJ")
+			},
+		},
+		{
+			name: "Returning Guest",
+			body: validBody,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserById(gomock.Any(), gomock.Eq("ada@example.com")).
+					Return(db.GetUserByIdRow{ID: guestId, Email: "ada@example.com", Guest: true}, nil).
+					Times(1)
+				store.EXPECT().CreateGuestUser(gomock.Any(), gomock.Any()).Times(0)
+				placeOrder(store)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Email Of An Account",
+			body: validBody,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserById(gomock.Any(), gomock.Eq("ada@example.com")).
+					Return(db.GetUserByIdRow{ID: uuid.New(), Email: "ada@example.com"}, nil).
+					Times(1)
+				store.EXPECT().CreateOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "log in to place the order")
+			},
+		},
+		{
+			name: "Invalid Address",
+			body: gin.H{
+				"email":   "ada@example.com",
+				"address": gin.H{"name": "Ada Lovelace", "line1": "1 Infinite Loop", "city": "Cupertino", "country": "USA"},
+				"items":   []gin.H{{"productId": productId, "quantity": 2}},
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "two letter country code")
+			},
+		},
+		{
+			name: "Invalid Email",
+			body: gin.H{
+				"email":   "ada",
+				"address": address,
+				"items":   []gin.H{{"productId": productId, "quantity": 2}},
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "email is invalid")
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/v1/guest/orders", bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestGetGuestOrder(t *testing.T) {
+	guestId := uuid.New()
+	order := db.Order{ID: uuid.New(), UserId: guestId, Total: 25, Status: db.OrderStatusPENDING}
+	address := db.GuestOrder{OrderId: order.ID, Email: "ada@example.com", Name: "Ada Lovelace", Line1: "1 Infinite Loop", City: "Cupertino", Country: "US", State: "CA"}
+
+	testCases := []struct {
+		name     string
+		token    func(t *testing.T, jwtToken *token.JWT) string
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Found",
+			token: func(t *testing.T, jwtToken *token.JWT) string {
+				lookupToken, err := jwtToken.CreateOrderLookupToken(order.ID, guestId, address.Email)
+				require.NoError(t, err)
+				return lookupToken
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrderById(gomock.Any(), gomock.Eq(order.ID)).Return(order, nil).Times(1)
+				store.EXPECT().GetGuestOrder(gomock.Any(), gomock.Eq(order.ID)).Return(address, nil).Times(1)
+				store.EXPECT().GetOrderTaxByOrderIds(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				store.EXPECT().GetOrderPromotionsByOrderIds(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "1 Infinite Loop")
+				require.Contains(t, recorder.Body.String(), order.ID.String())
+			},
+		},
+		{
+			name: "Access Token",
+			token: func(t *testing.T, jwtToken *token.JWT) string {
+				accessToken, err := jwtToken.CreateToken(guestId, false)
+				require.NoError(t, err)
+				return accessToken
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrderById(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Deleted Order",
+			token: func(t *testing.T, jwtToken *token.JWT) string {
+				lookupToken, err := jwtToken.CreateOrderLookupToken(order.ID, guestId, address.Email)
+				require.NoError(t, err)
+				return lookupToken
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrderById(gomock.Any(), gomock.Eq(order.ID)).Return(db.Order{}, pgx.ErrNoRows).Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(gin.H{"token": tc.token(t, server.TokenCreator())})
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/api/v1/guest/orders/lookup", bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestOrderLookupTokenIsNotAccessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAllOrderByUserId(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	lookupToken, err := server.TokenCreator().CreateOrderLookupToken(uuid.New(), testUserId, "ada@example.com")
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+	require.NoError(t, err)
+	request.Header.Set(constants.AuthenticationHeader, fmt.Sprintf("%s %s", constants.AuthenticationScheme, lookupToken))
+
+	server.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func TestClaimAccount(t *testing.T) {
+	guestId := uuid.New()
+	claimToken := func(t *testing.T, jwtToken *token.JWT) string {
+		claimToken, err := jwtToken.CreateAccountClaimToken(guestId, "ada@example.com")
+		require.NoError(t, err)
+		return claimToken
+	}
+
+	testCases := []struct {
+		name     string
+		token    func(t *testing.T, jwtToken *token.JWT) string
+		password string
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Claimed",
+			token:    claimToken,
+			password: "password123",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ClaimGuestUser(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.ClaimGuestUserParams) (db.User, error) {
+						require.Equal(t, guestId, arg.ID)
+						require.NotEqual(t, "password123", arg.Password)
+						return db.User{ID: guestId, Email: "ada@example.com"}, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "token")
+			},
+		},
+		{
+			name:     "Already Claimed",
+			token:    claimToken,
+			password: "password123",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ClaimGuestUser(gomock.Any(), gomock.Any()).Return(db.User{}, pgx.ErrNoRows).Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "account already claimed")
+			},
+		},
+		{
+			name: "Lookup Token",
+			token: func(t *testing.T, jwtToken *token.JWT) string {
+				lookupToken, err := jwtToken.CreateOrderLookupToken(uuid.New(), guestId, "ada@example.com")
+				require.NoError(t, err)
+				return lookupToken
+			},
+			password: "password123",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ClaimGuestUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "Short Password",
+			token:    claimToken,
+			password: "short",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ClaimGuestUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(gin.H{"token": tc.token(t, server.TokenCreator()), "password": tc.password})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/v1/auth/claim", bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestRequestAccountClaim(t *testing.T) {
+	guestId := uuid.New()
+
+	testCases := []struct {
+		name     string
+		email    string
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Guest",
+			email: " ada@example.com ",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserById(gomock.Any(), gomock.Eq("ada@example.com")).
+					Return(db.GetUserByIdRow{ID: guestId, Email: "ada@example.com", Guest: true}, nil).
+					Times(1)
+				store.EXPECT().
+					CreateJob(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateJobParams) (db.Job, error) {
+						require.Equal(t, services.SendAccountClaimJob, arg.Kind)
+						var args types.AccountClaimJobArgs
+						require.NoError(t, json.Unmarshal(arg.Payload, &args))
+						require.Equal(t, guestId, args.UserId)
+						require.Equal(t, "ada@example.com", args.Email)
+						return db.Job{ID: arg.ID, Kind: arg.Kind}, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+			},
+		},
+		{
+			name:  "Account",
+			email: "ada@example.com",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserById(gomock.Any(), gomock.Eq("ada@example.com")).
+					Return(db.GetUserByIdRow{ID: guestId, Email: "ada@example.com"}, nil).
+					Times(1)
+				store.EXPECT().CreateJob(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				// Not telling accounts and unknown emails apart from guests
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+			},
+		},
+		{
+			name:  "Unknown Email",
+			email: "ada@example.com",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Any()).Return(db.GetUserByIdRow{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().CreateJob(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+			},
+		},
+		{
+			name:  "Invalid Email",
+			email: "ada",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserById(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(gin.H{"email": tc.email})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/v1/auth/claim/request", bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestLoginUnclaimedGuest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUserById(gomock.Any(), gomock.Eq("ada@example.com")).
+		Return(db.GetUserByIdRow{ID: uuid.New(), Email: "ada@example.com", Guest: true}, nil).
+		Times(1)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+	reqBody, err := json.Marshal(gin.H{"email": "ada@example.com", "password": "password123"})
+	require.NoError(t, err)
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(reqBody))
+	require.NoError(t, err)
+
+	server.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "claim the account")
+}
+
+func TestGuestOrderEvents(t *testing.T) {
+	order := db.Order{ID: uuid.New(), UserId: uuid.New(), Total: 25}
+	data, err := json.Marshal(db.OrderCreated{Order: order})
+	require.NoError(t, err)
+	event := events.Envelope{ID: uuid.New(), Type: db.EventOrderCreated, Data: data}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	// The lookup email stands for the order placed email
+	store.EXPECT().GetNotificationPreference(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().CreateNotificationTx(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().
+		GetGuestOrder(gomock.Any(), gomock.Eq(order.ID)).
+		Return(db.GuestOrder{OrderId: order.ID, Email: "ada@example.com"}, nil).
+		Times(4)
+	enqueued := make(map[string]bool)
+	store.EXPECT().
+		CreateJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.CreateJobParams) (db.Job, error) {
+			require.Equal(t, services.SendOrderLookupJob, arg.Kind)
+			var args types.OrderLookupJobArgs
+			require.NoError(t, json.Unmarshal(arg.Payload, &args))
+			require.Equal(t, order.ID, args.OrderId)
+			require.Equal(t, fmt.Sprintf("%s:%s", services.SendOrderLookupJob, order.ID), arg.IdempotencyKey)
+			// The job table skips the keys it holds already
+			if enqueued[arg.IdempotencyKey] {
+				return db.Job{}, pgx.ErrNoRows
+			}
+			enqueued[arg.IdempotencyKey] = true
+			return db.Job{ID: arg.ID, Kind: arg.Kind}, nil
+		}).
+		Times(2)
+
+	bus := events.NewBus()
+	services.RegisterEventHandlers(bus, store, nil)
+	require.NoError(t, bus.Send(context.Background(), event))
+	// The event is relayed again, e.g. after another sink failed
+	require.NoError(t, bus.Send(context.Background(), event))
+	require.Len(t, enqueued, 1)
+}
+
+func TestRunOrderLookupJob(t *testing.T) {
+	order := db.Order{ID: uuid.New(), UserId: uuid.New(), Total: 25}
+	address := db.GuestOrder{OrderId: order.ID, Email: "ada@example.com", Name: "Ada Lovelace", Line1: "1 Infinite Loop", City: "Cupertino", Country: "US", State: "CA"}
+	payload, err := json.Marshal(types.OrderLookupJobArgs{OrderId: order.ID})
+	require.NoError(t, err)
+	jwtToken, err := token.NewJWT("a-secret-key-long-enough-for-the-tests")
+	require.NoError(t, err)
+
+	t.Run("Sent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetOrderById(gomock.Any(), gomock.Eq(order.ID)).Return(order, nil).Times(1)
+		store.EXPECT().GetGuestOrder(gomock.Any(), gomock.Eq(order.ID)).Return(address, nil).Times(1)
+
+		dir := t.TempDir()
+		guests := services.NewGuestService(store, jwtToken, mailer.NewFileMailer(dir, "InstaShop <no-reply@instashop.local>"))
+		require.NoError(t, guests.RunOrderLookupJob(context.Background(), payload))
+		files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		raw, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		msg, parts := readEmail(t, raw)
+		require.Equal(t, fmt.Sprintf("Look up your order %s", order.ID), msg.Header.Get("Subject"))
+		require.Contains(t, msg.Header.Get("To"), "ada@example.com")
+		require.Contains(t, parts["text/plain"], "1 Infinite Loop")
+		require.Contains(t, parts["text/html"], "Cupertino")
+
+		// The token in the email looks the order up
+		lookupToken := regexp.MustCompile(`eyJ[\w-]+\.[\w-]+\.[\w-]+`).FindString(parts["text/plain"])
+		lookup, err := jwtToken.VerifyOrderLookupToken(lookupToken)
+		require.NoError(t, err)
+		require.Equal(t, order.ID, lookup.OrderID)
+		require.Equal(t, order.UserId, lookup.UserID)
+		require.Equal(t, "ada@example.com", lookup.Email)
+	})
+
+	t.Run("Order Deleted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetOrderById(gomock.Any(), gomock.Eq(order.ID)).Return(db.Order{}, pgx.ErrNoRows).Times(1)
+
+		guests := services.NewGuestService(store, jwtToken, failingMailer{})
+		require.NoError(t, guests.RunOrderLookupJob(context.Background(), payload))
+	})
+
+	t.Run("Retried", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetOrderById(gomock.Any(), gomock.Eq(order.ID)).Return(order, nil).Times(1)
+		store.EXPECT().GetGuestOrder(gomock.Any(), gomock.Eq(order.ID)).Return(address, nil).Times(1)
+
+		guests := services.NewGuestService(store, jwtToken, failingMailer{})
+		require.ErrorContains(t, guests.RunOrderLookupJob(context.Background(), payload), "connection refused")
+	})
+}
+
+func TestRunAccountClaimJob(t *testing.T) {
+	guest := db.GetUserByIdRow{ID: uuid.New(), Email: "ada@example.com", Guest: true}
+	payload, err := json.Marshal(types.AccountClaimJobArgs{UserId: guest.ID, Email: guest.Email})
+	require.NoError(t, err)
+	jwtToken, err := token.NewJWT("a-secret-key-long-enough-for-the-tests")
+	require.NoError(t, err)
+
+	t.Run("Sent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(guest.Email)).Return(guest, nil).Times(1)
+
+		dir := t.TempDir()
+		guests := services.NewGuestService(store, jwtToken, mailer.NewFileMailer(dir, "InstaShop <no-reply@instashop.local>"))
+		require.NoError(t, guests.RunAccountClaimJob(context.Background(), payload))
+		files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		raw, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		msg, parts := readEmail(t, raw)
+		require.Equal(t, "Create your InstaShop account", msg.Header.Get("Subject"))
+		require.Contains(t, msg.Header.Get("To"), guest.Email)
+
+		// The token in the email claims the account, but does not look orders up
+		claimToken := regexp.MustCompile(`eyJ[\w-]+\.[\w-]+\.[\w-]+`).FindString(parts["text/plain"])
+		claim, err := jwtToken.VerifyAccountClaimToken(claimToken)
+		require.NoError(t, err)
+		require.Equal(t, guest.ID, claim.UserID)
+		require.Equal(t, guest.Email, claim.Email)
+		_, err = jwtToken.VerifyOrderLookupToken(claimToken)
+		require.ErrorIs(t, err, token.ErrTokenIsInvalid)
+	})
+
+	t.Run("Already Claimed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		claimed := guest
+		claimed.Guest = false
+		store.EXPECT().GetUserById(gomock.Any(), gomock.Eq(guest.Email)).Return(claimed, nil).Times(1)
+
+		guests := services.NewGuestService(store, jwtToken, failingMailer{})
+		require.NoError(t, guests.RunAccountClaimJob(context.Background(), payload))
+	})
+}
@@ -19,6 +19,9 @@ var testUserId = uuid.New()
 func newTestServer(t *testing.T, store db.Store) *server.Server {
 	cfg, err := config.LoadConfig("../")
 	require.NoError(t, err)
+	// Keep uploaded files out of the repository
+	cfg.BlobStore = "local"
+	cfg.BlobLocalDir = t.TempDir()
 	apiServer, err := server.NewServer(cfg, store)
 	require.NoError(t, err)
 	return apiServer
@@ -0,0 +1,415 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/events"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/fulfilment"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/mailer"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetLowStockProducts(t *testing.T) {
+	low := db.GetLowStockProductsRow{
+		ID:               uuid.New(),
+		Name:             "Mug",
+		Sku:              "MUG-1",
+		Category:         "kitchen",
+		Stock:            2,
+		ReorderThreshold: 5,
+		AlertId:          pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		LowSince:         pgtype.Timestamp{Time: time.Now().UTC(), Valid: true},
+	}
+	testCases := []struct {
+		name     string
+		url      string
+		admin    bool
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			url:   "/api/v1/admin/inventory/low-stock",
+			admin: true,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLowStockProducts(gomock.Any(), gomock.Eq(db.GetLowStockProductsParams{PageSize: 100})).
+					Return([]db.GetLowStockProductsRow{low}, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var body struct {
+					Data []types.LowStockProduct `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.Len(t, body.Data, 1)
+				require.Equal(t, low.ID, body.Data[0].ID)
+				require.Equal(t, int32(5), body.Data[0].ReorderThreshold)
+				require.Contains(t, recorder.Body.String(), `"notifiedAt":null`)
+			},
+		},
+		{
+			name:  "By Category",
+			url:   "/api/v1/admin/inventory/low-stock?category=kitchen&limit=10",
+			admin: true,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLowStockProducts(gomock.Any(), gomock.Eq(db.GetLowStockProductsParams{Category: "kitchen", PageSize: 10})).
+					Return([]db.GetLowStockProductsRow{}, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"data":[]`)
+			},
+		},
+		{
+			name:  "Invalid Limit",
+			url:   "/api/v1/admin/inventory/low-stock?limit=0",
+			admin: true,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLowStockProducts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "limit must be between 1 and 500")
+			},
+		},
+		{
+			name:  "Not Admin",
+			url:   "/api/v1/admin/inventory/low-stock",
+			admin: false,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLowStockProducts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, tc.admin)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestLowStockAlertEvents(t *testing.T) {
+	low := db.ProductStockLow{
+		Product:   db.Product{ID: uuid.New(), Name: "Mug", Stock: 2, ReorderThreshold: 5},
+		Threshold: 5,
+		AlertId:   uuid.New(),
+	}
+	data, err := json.Marshal(low)
+	require.NoError(t, err)
+	event := events.Envelope{ID: uuid.New(), Type: low.EventType(), Data: data}
+
+	t.Run("Queued", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			CreateJob(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, arg db.CreateJobParams) (db.Job, error) {
+				require.Equal(t, services.SendLowStockAlertJob, arg.Kind)
+				var args types.LowStockAlertJobArgs
+				require.NoError(t, json.Unmarshal(arg.Payload, &args))
+				require.Equal(t, low.AlertId, args.AlertId)
+				require.Equal(t, []string{"stock@example.com", "buyer@example.com"}, args.Recipients)
+				return db.Job{ID: arg.ID, Kind: arg.Kind}, nil
+			}).
+			Times(1)
+
+		bus := events.NewBus()
+		services.RegisterEventHandlers(bus, store, []string{"stock@example.com", "buyer@example.com"})
+		require.NoError(t, bus.Send(context.Background(), event))
+	})
+
+	t.Run("No Recipients", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().CreateJob(gomock.Any(), gomock.Any()).Times(0)
+
+		bus := events.NewBus()
+		services.RegisterEventHandlers(bus, store, nil)
+		require.NoError(t, bus.Send(context.Background(), event))
+	})
+}
+
+func TestRunLowStockAlertJob(t *testing.T) {
+	product := db.GetOneProductRow{ID: uuid.New(), Name: "Mug", Sku: "MUG-1", Stock: 2, ReorderThreshold: 5}
+	alert := db.LowStockAlert{ID: uuid.New(), ProductId: product.ID, Stock: 2, Threshold: 5}
+	payload, err := json.Marshal(types.LowStockAlertJobArgs{AlertId: alert.ID, Recipients: []string{"stock@example.com", "buyer@example.com"}})
+	require.NoError(t, err)
+
+	t.Run("Sent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetOneLowStockAlert(gomock.Any(), gomock.Eq(alert.ID)).Return(alert, nil).Times(1)
+		store.EXPECT().GetOneProduct(gomock.Any(), gomock.Eq(product.ID)).Return(product, nil).Times(1)
+		store.EXPECT().
+			MarkLowStockAlertNotified(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, arg db.MarkLowStockAlertNotifiedParams) (db.LowStockAlert, error) {
+				require.Equal(t, alert.ID, arg.ID)
+				require.True(t, arg.NotifiedAt.Valid)
+				return alert, nil
+			}).
+			Times(1)
+
+		dir := t.TempDir()
+		inventory := services.NewInventoryService(store, mailer.NewFileMailer(dir, "InstaShop <no-reply@instashop.local>"), nil)
+		require.NoError(t, inventory.RunLowStockAlertJob(context.Background(), payload))
+		files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+		raw, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		msg, parts := readEmail(t, raw)
+		require.Equal(t, "Low stock: Mug", msg.Header.Get("Subject"))
+		require.Contains(t, parts["text/plain"], "Stock left: 2")
+		require.Contains(t, parts["text/plain"], "Reorder threshold: 5")
+		require.Contains(t, parts["text/plain"], "low-stock alert list")
+		require.NotContains(t, parts["text/plain"], "your order")
+		require.Contains(t, parts["text/html"], "MUG-1")
+	})
+
+	t.Run("Retried", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetOneLowStockAlert(gomock.Any(), gomock.Eq(alert.ID)).Return(alert, nil).Times(1)
+		store.EXPECT().GetOneProduct(gomock.Any(), gomock.Eq(product.ID)).Return(product, nil).Times(1)
+		store.EXPECT().MarkLowStockAlertNotified(gomock.Any(), gomock.Any()).Times(0)
+
+		inventory := services.NewInventoryService(store, failingMailer{}, nil)
+		require.ErrorContains(t, inventory.RunLowStockAlertJob(context.Background(), payload), "connection refused")
+	})
+
+	t.Run("Resolved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		resolved := alert
+		resolved.ResolvedAt = pgtype.Timestamp{Time: time.Now().UTC(), Valid: true}
+		store.EXPECT().GetOneLowStockAlert(gomock.Any(), gomock.Eq(alert.ID)).Return(resolved, nil).Times(1)
+		store.EXPECT().GetOneProduct(gomock.Any(), gomock.Any()).Times(0)
+		store.EXPECT().MarkLowStockAlertNotified(gomock.Any(), gomock.Any()).Times(0)
+
+		inventory := services.NewInventoryService(store, failingMailer{}, nil)
+		require.NoError(t, inventory.RunLowStockAlertJob(context.Background(), payload))
+	})
+
+	t.Run("Product Deleted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetOneLowStockAlert(gomock.Any(), gomock.Eq(alert.ID)).Return(db.LowStockAlert{}, pgx.ErrNoRows).Times(1)
+		store.EXPECT().MarkLowStockAlertNotified(gomock.Any(), gomock.Any()).Times(0)
+
+		inventory := services.NewInventoryService(store, failingMailer{}, nil)
+		require.NoError(t, inventory.RunLowStockAlertJob(context.Background(), payload))
+	})
+}
+
+func TestGetStockMovements(t *testing.T) {
+	product := db.GetOneProductRow{ID: uuid.New(), Name: "Mug", Stock: 7}
+	movements := []db.StockMovement{
+		{ID: 12, ProductId: product.ID, Quantity: -3, StockAfter: 7, Kind: db.StockMovementKindORDERPLACED, OrderId: pgtype.UUID{Bytes: uuid.New(), Valid: true}},
+		{ID: 4, ProductId: product.ID, Quantity: 10, StockAfter: 10, Kind: db.StockMovementKindADJUSTMENT, Note: "Initial stock"},
+	}
+	testCases := []struct {
+		name     string
+		url      string
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			url:  fmt.Sprintf("/api/v1/admin/products/%s/stock-movements", product.ID),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneProduct(gomock.Any(), gomock.Eq(product.ID)).Return(product, nil).Times(1)
+				store.EXPECT().GetLedgerStock(gomock.Any(), gomock.Eq(product.ID)).Return(int32(7), nil).Times(1)
+				store.EXPECT().
+					GetStockMovements(gomock.Any(), gomock.Eq(db.GetStockMovementsParams{ProductId: product.ID, PageSize: 50})).
+					Return(movements, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var body struct {
+					Data types.StockMovements `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.Equal(t, int32(7), body.Data.Stock)
+				require.Equal(t, int32(7), body.Data.LedgerStock)
+				require.Len(t, body.Data.Movements, 2)
+				require.Equal(t, "ORDER_PLACED", body.Data.Movements[0].Kind)
+				require.NotNil(t, body.Data.Movements[0].OrderId)
+				require.Nil(t, body.Data.Movements[1].OrderId)
+			},
+		},
+		{
+			name: "Next Page",
+			url:  fmt.Sprintf("/api/v1/admin/products/%s/stock-movements?before=12&limit=1", product.ID),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneProduct(gomock.Any(), gomock.Eq(product.ID)).Return(product, nil).Times(1)
+				store.EXPECT().GetLedgerStock(gomock.Any(), gomock.Eq(product.ID)).Return(int32(7), nil).Times(1)
+				store.EXPECT().
+					GetStockMovements(gomock.Any(), gomock.Eq(db.GetStockMovementsParams{ProductId: product.ID, BeforeId: 12, PageSize: 1})).
+					Return(movements[1:], nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Before",
+			url:  fmt.Sprintf("/api/v1/admin/products/%s/stock-movements?before=abc", product.ID),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "before must be the id of a movement")
+			},
+		},
+		{
+			name: "Product Not Found",
+			url:  fmt.Sprintf("/api/v1/admin/products/%s/stock-movements", product.ID),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneProduct(gomock.Any(), gomock.Eq(product.ID)).Return(db.GetOneProductRow{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().GetStockMovements(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Reconciliation",
+			url:  "/api/v1/admin/inventory/reconciliation",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetStockDiscrepancies(gomock.Any()).
+					Return([]db.GetStockDiscrepanciesRow{{ID: product.ID, Name: "Mug", Stock: 7, LedgerStock: 9}}, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "Stock does not match the ledger")
+				require.Contains(t, recorder.Body.String(), `"ledgerStock":9`)
+			},
+		},
+		{
+			name: "Reconciled",
+			url:  "/api/v1/admin/inventory/reconciliation",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetStockDiscrepancies(gomock.Any()).Return([]db.GetStockDiscrepanciesRow{}, nil).Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "Stock matches the ledger")
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestAdjustStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productId := uuid.New()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		UpdateProductTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.UpdateProductTxParams) (db.Product, error, error) {
+			// The reason and the admin are recorded in the stock ledger
+			require.Equal(t, productId, arg.ID)
+			require.Equal(t, int32(40), *arg.Stock)
+			require.Equal(t, "Cycle count", arg.StockNote)
+			require.Equal(t, testUserId, arg.UpdatedBy)
+			return db.Product{ID: productId, Stock: 40}, nil, nil
+		}).
+		Times(1)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/admin/products/%s", productId), strings.NewReader(`{"stock":40,"stockNote":"Cycle count"}`))
+	require.NoError(t, err)
+	request.Header.Set("If-Match", `"1"`)
+	addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+	server.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestAdjustStockNotInWarehouses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productId := uuid.New()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		UpdateProductTx(gomock.Any(), gomock.Any()).
+		Return(db.Product{}, fmt.Errorf("take stock: %w", fulfilment.ErrNotEnoughStock), nil).
+		Times(1)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/admin/products/%s", productId), strings.NewReader(`{"stock":0,"stockNote":"Cycle count"}`))
+	require.NoError(t, err)
+	request.Header.Set("If-Match", `"1"`)
+	addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+	server.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "stock cannot be lowered by more than the active warehouses hold")
+}
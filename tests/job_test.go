@@ -0,0 +1,409 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/jobs"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	for attempt, want := range map[int32]time.Duration{
+		1:  10 * time.Second,
+		2:  20 * time.Second,
+		4:  80 * time.Second,
+		20: time.Hour,
+	} {
+		backoff := jobs.Backoff(attempt)
+		require.GreaterOrEqual(t, backoff, want)
+		require.LessOrEqual(t, backoff, want+want/10)
+	}
+}
+
+func TestJobPool(t *testing.T) {
+	failure := errors.New("gateway timeout")
+	testCases := []struct {
+		name    string
+		job     db.Job
+		handler jobs.Handler
+		outcome func(store *mockdb.MockStore, done chan struct{})
+	}{
+		{
+			name: "Succeeded",
+			job:  db.Job{Kind: "test", Payload: []byte(`{"n":1}`), Attempts: 1, MaxAttempts: 5},
+			handler: func(ctx context.Context, payload json.RawMessage) error {
+				if string(payload) != `{"n":1}` {
+					return fmt.Errorf("unexpected payload %s", payload)
+				}
+				return nil
+			},
+			outcome: func(store *mockdb.MockStore, done chan struct{}) {
+				store.EXPECT().
+					CompleteJob(gomock.Any(), gomock.Any()).
+					Do(func(_ any, _ uuid.UUID) { close(done) }).
+					Times(1)
+			},
+		},
+		{
+			name: "Retried",
+			job:  db.Job{Kind: "test", Attempts: 2, MaxAttempts: 5},
+			handler: func(ctx context.Context, payload json.RawMessage) error {
+				return failure
+			},
+			outcome: func(store *mockdb.MockStore, done chan struct{}) {
+				store.EXPECT().
+					RetryJob(gomock.Any(), gomock.Any()).
+					Do(func(_ any, arg db.RetryJobParams) {
+						require.Equal(t, failure.Error(), arg.LastError)
+						require.True(t, arg.RunAt.Time.After(time.Now().UTC().Add(19*time.Second)))
+						close(done)
+					}).
+					Times(1)
+			},
+		},
+		{
+			name: "Out Of Attempts",
+			job:  db.Job{Kind: "test", Attempts: 5, MaxAttempts: 5},
+			handler: func(ctx context.Context, payload json.RawMessage) error {
+				return failure
+			},
+			outcome: func(store *mockdb.MockStore, done chan struct{}) {
+				store.EXPECT().
+					KillJob(gomock.Any(), gomock.Any()).
+					Do(func(_ any, arg db.KillJobParams) { close(done) }).
+					Times(1)
+			},
+		},
+		{
+			name: "Permanent Failure",
+			job:  db.Job{Kind: "test", Attempts: 1, MaxAttempts: 5},
+			handler: func(ctx context.Context, payload json.RawMessage) error {
+				return jobs.Permanent(failure)
+			},
+			outcome: func(store *mockdb.MockStore, done chan struct{}) {
+				store.EXPECT().
+					KillJob(gomock.Any(), gomock.Any()).
+					Do(func(_ any, arg db.KillJobParams) { close(done) }).
+					Times(1)
+			},
+		},
+		{
+			name: "Panic",
+			job:  db.Job{Kind: "test", Attempts: 1, MaxAttempts: 5},
+			handler: func(ctx context.Context, payload json.RawMessage) error {
+				panic("nil map")
+			},
+			outcome: func(store *mockdb.MockStore, done chan struct{}) {
+				store.EXPECT().
+					RetryJob(gomock.Any(), gomock.Any()).
+					Do(func(_ any, arg db.RetryJobParams) {
+						require.Equal(t, "panic: nil map", arg.LastError)
+						close(done)
+					}).
+					Times(1)
+			},
+		},
+		{
+			name: "Unknown Kind",
+			job:  db.Job{Kind: "unknown", Attempts: 1, MaxAttempts: 5},
+			outcome: func(store *mockdb.MockStore, done chan struct{}) {
+				store.EXPECT().
+					KillJob(gomock.Any(), gomock.Any()).
+					Do(func(_ any, arg db.KillJobParams) {
+						require.ErrorContains(t, errors.New(arg.LastError), "unknown")
+						close(done)
+					}).
+					Times(1)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.job.ID = uuid.New()
+			done := make(chan struct{})
+			tc.outcome(store, done)
+			claimed := false
+			store.EXPECT().
+				ClaimJobs(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ any, arg db.ClaimJobsParams) ([]db.Job, error) {
+					if claimed {
+						return nil, nil
+					}
+					claimed = true
+					return []db.Job{tc.job}, nil
+				}).
+				MinTimes(1)
+			store.EXPECT().
+				RequeueStaleJobs(gomock.Any(), gomock.Any()).
+				Return(int64(0), nil).
+				AnyTimes()
+
+			pool := jobs.NewPool(store, jobs.Config{Workers: 1, PollInterval: 10 * time.Millisecond})
+			if tc.handler != nil {
+				pool.Register("test", tc.handler)
+			}
+			pool.Start()
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("job outcome was not recorded")
+			}
+			require.NoError(t, pool.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestJobPoolShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ClaimJobs(gomock.Any(), gomock.Any()).
+		Return([]db.Job{{ID: uuid.New(), Kind: "slow", Attempts: 1, MaxAttempts: 5}}, nil).
+		Times(1)
+	store.EXPECT().
+		RequeueStaleJobs(gomock.Any(), gomock.Any()).
+		Return(int64(0), nil).
+		AnyTimes()
+
+	started := make(chan struct{})
+	pool := jobs.NewPool(store, jobs.Config{Workers: 1})
+	pool.Register("slow", func(ctx context.Context, payload json.RawMessage) error {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	})
+
+	// A running job is drained before the shutdown returns
+	store.EXPECT().CompleteJob(gomock.Any(), gomock.Any()).Times(1)
+	pool.Start()
+	<-started
+	require.NoError(t, pool.Shutdown(context.Background()))
+
+	// A job outliving the shutdown deadline is cancelled, and retried
+	store.EXPECT().
+		ClaimJobs(gomock.Any(), gomock.Any()).
+		Return([]db.Job{{ID: uuid.New(), Kind: "stuck", Attempts: 1, MaxAttempts: 5}}, nil).
+		Times(1)
+	store.EXPECT().RetryJob(gomock.Any(), gomock.Any()).Times(1)
+	started = make(chan struct{})
+	pool = jobs.NewPool(store, jobs.Config{Workers: 1})
+	pool.Register("stuck", func(ctx context.Context, payload json.RawMessage) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	pool.Start()
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, pool.Shutdown(ctx), context.DeadlineExceeded)
+}
+
+func TestJobAPI(t *testing.T) {
+	job := db.Job{
+		ID:          uuid.New(),
+		Kind:        services.ImportProductsJob,
+		Payload:     []byte(`{"rows":[]}`),
+		Status:      db.JobStatusDEAD,
+		Attempts:    5,
+		MaxAttempts: 5,
+		LastError:   "connection refused",
+	}
+	testCases := []struct {
+		name     string
+		method   string
+		url      string
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "List By Status",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/jobs?status=DEAD&limit=10",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAllJob(gomock.Any(), gomock.Eq(db.GetAllJobParams{Status: "DEAD", PageSize: 10})).
+					Return([]db.Job{job}, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"payload":{"rows":[]}`)
+				require.Contains(t, recorder.Body.String(), `"lastError":"connection refused"`)
+			},
+		},
+		{
+			name:   "Invalid Status",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/jobs?status=FAILED",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAllJob(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Invalid Limit",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/jobs?limit=1000",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAllJob(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Stats",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/jobs/stats",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetJobStats(gomock.Any()).
+					Return([]db.GetJobStatsRow{{Status: db.JobStatusDEAD, Count: 2}, {Status: db.JobStatusSUCCEEDED, Count: 40}}, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var body struct {
+					Data json.RawMessage `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.JSONEq(t, `{"PENDING":0,"RUNNING":0,"SUCCEEDED":40,"DEAD":2}`, string(body.Data))
+			},
+		},
+		{
+			name:   "Get Missing",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/api/v1/admin/jobs/%s", uuid.New()),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneJob(gomock.Any(), gomock.Any()).Return(db.Job{}, pgx.ErrNoRows).Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "Retry",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/api/v1/admin/jobs/%s/retry", job.ID),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResurrectJob(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.ResurrectJobParams) (db.Job, error) {
+						require.Equal(t, job.ID, arg.ID)
+						retried := job
+						retried.Status, retried.Attempts = db.JobStatusPENDING, 0
+						return retried, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"status":"PENDING"`)
+			},
+		},
+		{
+			name:   "Retry Not Dead",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/api/v1/admin/jobs/%s/retry", job.ID),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ResurrectJob(gomock.Any(), gomock.Any()).Return(db.Job{}, pgx.ErrNoRows).Times(1)
+				running := job
+				running.Status = db.JobStatusRUNNING
+				store.EXPECT().GetOneJob(gomock.Any(), gomock.Eq(job.ID)).Return(running, nil).Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "Retry Missing",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/api/v1/admin/jobs/%s/retry", job.ID),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ResurrectJob(gomock.Any(), gomock.Any()).Return(db.Job{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().GetOneJob(gomock.Any(), gomock.Eq(job.ID)).Return(db.Job{}, pgx.ErrNoRows).Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(tc.method, tc.url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestImportProductsAsync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ImportProductTx(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().
+		CreateJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.CreateJobParams) (db.Job, error) {
+			require.Equal(t, services.ImportProductsJob, arg.Kind)
+			require.Equal(t, int32(jobs.DefaultMaxAttempts), arg.MaxAttempts)
+			var params db.ImportProductTxParams
+			require.NoError(t, json.Unmarshal(arg.Payload, &params))
+			require.Equal(t, testUserId, params.CreatedBy)
+			require.Len(t, params.Rows, 1)
+			return db.Job{ID: arg.ID, Kind: arg.Kind, Payload: arg.Payload, Status: db.JobStatusPENDING}, nil
+		}).
+		Times(1)
+	server := newTestServer(t, store)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/admin/products/import?async=true", bytes.NewBufferString("name,description,price\nMug,Ceramic mug,8\n"))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "text/csv")
+	addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+	server.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusAccepted, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `"jobId"`)
+
+	// The queued job saves the products
+	store.EXPECT().
+		ImportProductTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(importRows()).
+		Times(1)
+	products := services.NewProductService(store, nil, nil)
+	require.NoError(t, products.RunImportJob(context.Background(), []byte(fmt.Sprintf(`{"rows":[{"name":"Mug"}],"createdBy":%q}`, testUserId))))
+}
@@ -0,0 +1,425 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/events"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/mailer"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// readEmail parses an email and returns its subject and its text and HTML parts.
+func readEmail(t *testing.T, raw []byte) (*mail.Message, map[string]string) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+	parts := map[string]string{}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(part)
+		require.NoError(t, err)
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		parts[contentType] = string(content)
+	}
+	return msg, parts
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := t.TempDir()
+	sink := mailer.NewFileMailer(dir, "InstaShop <no-reply@instashop.local>")
+	err := sink.Send(context.Background(), mailer.Message{
+		To:      "jane@example.com",
+		Subject: "Your order is on its way ✈",
+		Text:    "Shipped",
+		HTML:    "<p>Shipped</p>",
+	})
+	require.NoError(t, err)
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	raw, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	msg, parts := readEmail(t, raw)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	require.Equal(t, "Your order is on its way ✈", subject)
+	require.Equal(t, "<jane@example.com>", msg.Header.Get("To"))
+	require.Equal(t, "Shipped", parts["text/plain"])
+	require.Equal(t, "<p>Shipped</p>", parts["text/html"])
+
+	require.Error(t, sink.Send(context.Background(), mailer.Message{To: "not an address"}))
+}
+
+// smtpServer accepts one SMTP session and returns the envelope and data it got.
+func smtpServer(t *testing.T) (string, int, chan map[string]string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	received := make(chan map[string]string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		session := map[string]string{}
+		fmt.Fprint(conn, "220 localhost ESMTP\r\n")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.Fields(line)[0])
+			switch command {
+			case "EHLO", "HELO":
+				fmt.Fprint(conn, "250 localhost\r\n")
+			case "MAIL":
+				session["from"] = strings.TrimSpace(line)
+				fmt.Fprint(conn, "250 OK\r\n")
+			case "RCPT":
+				session["to"] = strings.TrimSpace(line)
+				fmt.Fprint(conn, "250 OK\r\n")
+			case "DATA":
+				fmt.Fprint(conn, "354 Go ahead\r\n")
+				var data strings.Builder
+				for {
+					line, err = reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				session["data"] = data.String()
+				fmt.Fprint(conn, "250 Queued\r\n")
+			case "QUIT":
+				fmt.Fprint(conn, "221 Bye\r\n")
+				received <- session
+				return
+			default:
+				fmt.Fprint(conn, "502 Not implemented\r\n")
+			}
+		}
+	}()
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return host, portNumber, received
+}
+
+func TestSMTPMailer(t *testing.T) {
+	host, port, received := smtpServer(t)
+	smtpMailer := mailer.NewSMTPMailer(mailer.SMTPConfig{Host: host, Port: port, From: "InstaShop <no-reply@instashop.local>"})
+	err := smtpMailer.Send(context.Background(), mailer.Message{
+		To:      "Jane <jane@example.com>",
+		Subject: "We received your order",
+		Text:    "Thanks",
+		HTML:    "<p>Thanks</p>",
+	})
+	require.NoError(t, err)
+	session := <-received
+	require.Equal(t, "MAIL FROM:<no-reply@instashop.local>", session["from"])
+	require.Equal(t, "RCPT TO:<jane@example.com>", session["to"])
+	msg, parts := readEmail(t, []byte(session["data"]))
+	require.Equal(t, "We received your order", msg.Header.Get("Subject"))
+	require.Equal(t, "Thanks", parts["text/plain"])
+}
+
+func TestNotificationEvents(t *testing.T) {
+	order := db.Order{ID: uuid.New(), UserId: testUserId, Status: db.OrderStatusPENDING}
+	envelope := func(event db.Event) events.Envelope {
+		data, err := json.Marshal(event)
+		require.NoError(t, err)
+		return events.Envelope{ID: uuid.New(), Type: event.EventType(), Data: data}
+	}
+	withStatus := func(status db.OrderStatus) db.Order {
+		changed := order
+		changed.Status = status
+		return changed
+	}
+	testCases := []struct {
+		name  string
+		event db.Event
+		stubs func(store *mockdb.MockStore, eventId uuid.UUID)
+	}{
+		{
+			name:  "Placed",
+			event: db.OrderCreated{Order: order},
+			stubs: func(store *mockdb.MockStore, eventId uuid.UUID) {
+				// Users who never set preferences get every email
+				store.EXPECT().
+					GetNotificationPreference(gomock.Any(), gomock.Eq(testUserId)).
+					Return(db.NotificationPreference{}, pgx.ErrNoRows).
+					Times(1)
+				store.EXPECT().
+					CreateNotificationTx(gomock.Any(), gomock.Eq(db.CreateNotificationTxParams{
+						UserId:  testUserId,
+						OrderId: order.ID,
+						EventId: eventId,
+						Kind:    db.NotificationKindORDERPLACED,
+					})).
+					Times(1)
+				// Not a guest order, so the order placed email is sent and no
+				// lookup token
+				store.EXPECT().
+					GetGuestOrder(gomock.Any(), gomock.Eq(order.ID)).
+					Return(db.GuestOrder{}, pgx.ErrNoRows).
+					Times(2)
+			},
+		},
+		{
+			name:  "Shipped",
+			event: db.OrderStatusChanged{Order: withStatus(db.OrderStatusSHIPPED), PreviousStatus: db.OrderStatusPENDING},
+			stubs: func(store *mockdb.MockStore, eventId uuid.UUID) {
+				store.EXPECT().
+					GetNotificationPreference(gomock.Any(), gomock.Eq(testUserId)).
+					Return(db.NotificationPreference{UserId: testUserId, OrderShipped: true}, nil).
+					Times(1)
+				store.EXPECT().
+					CreateNotificationTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateNotificationTxParams) (db.Notification, error, error) {
+						require.Equal(t, db.NotificationKindORDERSHIPPED, arg.Kind)
+						// Relayed again: the email was already recorded
+						return db.Notification{}, pgx.ErrNoRows, nil
+					}).
+					Times(1)
+			},
+		},
+		{
+			name:  "OptedOut",
+			event: db.OrderStatusChanged{Order: withStatus(db.OrderStatusCANCELLED), PreviousStatus: db.OrderStatusPENDING},
+			stubs: func(store *mockdb.MockStore, eventId uuid.UUID) {
+				store.EXPECT().
+					GetNotificationPreference(gomock.Any(), gomock.Eq(testUserId)).
+					Return(db.NotificationPreference{UserId: testUserId, OrderPlaced: true, OrderShipped: true}, nil).
+					Times(1)
+				store.EXPECT().CreateNotificationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:  "NoEmail",
+			event: db.OrderStatusChanged{Order: withStatus(db.OrderStatusPARTIALLYSHIPPED), PreviousStatus: db.OrderStatusPENDING},
+			stubs: func(store *mockdb.MockStore, eventId uuid.UUID) {
+				store.EXPECT().GetNotificationPreference(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateNotificationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			event := envelope(tc.event)
+			tc.stubs(store, event.ID)
+
+			bus := events.NewBus()
+			services.RegisterEventHandlers(bus, store, nil)
+			require.NoError(t, bus.Send(context.Background(), event))
+		})
+	}
+}
+
+type failingMailer struct{}
+
+func (failingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	return errors.New("connection refused")
+}
+
+func TestSendNotification(t *testing.T) {
+	order := db.Order{ID: uuid.New(), UserId: testUserId, Subtotal: 20, Tax: 1.5, Total: 21.5, Status: db.OrderStatusCOMPLETED}
+	target := db.Notification{
+		ID:      uuid.New(),
+		UserId:  testUserId,
+		OrderId: order.ID,
+		Kind:    db.NotificationKindORDERCOMPLETED,
+		Email:   "jane@example.com",
+		Status:  db.NotificationStatusPENDING,
+	}
+	payload, err := json.Marshal(db.SendNotificationArgs{NotificationId: target.ID})
+	require.NoError(t, err)
+	stubOrder := func(store *mockdb.MockStore) {
+		store.EXPECT().GetOneNotification(gomock.Any(), gomock.Eq(target.ID)).Return(target, nil).Times(1)
+		store.EXPECT().GetOrderById(gomock.Any(), gomock.Eq(order.ID)).Return(order, nil).Times(1)
+		store.EXPECT().
+			GetOrderItemDetails(gomock.Any(), gomock.Eq(order.ID)).
+			Return([]db.GetOrderItemDetailsRow{{ProductId: uuid.New(), ProductName: "Mug <XL>", Quantity: 2, Price: 10}}, nil).
+			Times(1)
+	}
+
+	t.Run("Sent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		stubOrder(store)
+		store.EXPECT().
+			RecordNotificationAttempt(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, arg db.RecordNotificationAttemptParams) (db.Notification, error) {
+				require.Equal(t, db.NotificationStatusSENT, arg.Status)
+				require.True(t, arg.SentAt.Valid)
+				require.Empty(t, arg.LastError)
+				return target, nil
+			}).
+			Times(1)
+
+		dir := t.TempDir()
+		notifications := services.NewNotificationService(store, mailer.NewFileMailer(dir, "InstaShop <no-reply@instashop.local>"))
+		require.NoError(t, notifications.RunSendJob(context.Background(), payload))
+		files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		raw, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		msg, parts := readEmail(t, raw)
+		require.Equal(t, "Your order is complete", msg.Header.Get("Subject"))
+		require.Contains(t, parts["text/plain"], "Mug <XL> x 2: 10.00")
+		require.Contains(t, parts["text/plain"], "Total: 21.50")
+		require.Contains(t, parts["text/html"], "Mug &lt;XL&gt;")
+		require.Contains(t, parts["text/html"], order.ID.String())
+	})
+
+	t.Run("Retried", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		stubOrder(store)
+		store.EXPECT().
+			RecordNotificationAttempt(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, arg db.RecordNotificationAttemptParams) (db.Notification, error) {
+				require.Equal(t, db.NotificationStatusPENDING, arg.Status)
+				require.Equal(t, "connection refused", arg.LastError)
+				return target, nil
+			}).
+			Times(1)
+
+		notifications := services.NewNotificationService(store, failingMailer{})
+		require.ErrorContains(t, notifications.RunSendJob(context.Background(), payload), "connection refused")
+	})
+
+	t.Run("AlreadySent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		sent := target
+		sent.Status = db.NotificationStatusSENT
+		store.EXPECT().GetOneNotification(gomock.Any(), gomock.Eq(target.ID)).Return(sent, nil).Times(1)
+		store.EXPECT().RecordNotificationAttempt(gomock.Any(), gomock.Any()).Times(0)
+
+		notifications := services.NewNotificationService(store, failingMailer{})
+		require.NoError(t, notifications.RunSendJob(context.Background(), payload))
+	})
+}
+
+func TestNotificationPreferenceAPI(t *testing.T) {
+	testCases := []struct {
+		name     string
+		method   string
+		body     string
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "GetDefault",
+			method: http.MethodGet,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetNotificationPreference(gomock.Any(), gomock.Eq(testUserId)).
+					Return(db.NotificationPreference{}, pgx.ErrNoRows).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var body struct {
+					Data db.NotificationPreference `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.Equal(t, testUserId, body.Data.UserId)
+				require.True(t, body.Data.OrderPlaced && body.Data.OrderCancelled && body.Data.OrderCompleted && body.Data.OrderShipped)
+			},
+		},
+		{
+			name:   "Update",
+			method: http.MethodPut,
+			body:   `{"orderShipped":false}`,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetNotificationPreference(gomock.Any(), gomock.Eq(testUserId)).
+					Return(db.NotificationPreference{UserId: testUserId, OrderPlaced: true, OrderCancelled: false, OrderCompleted: true, OrderShipped: true}, nil).
+					Times(1)
+				store.EXPECT().
+					UpsertNotificationPreference(gomock.Any(), gomock.Eq(db.UpsertNotificationPreferenceParams{
+						UserId:         testUserId,
+						OrderPlaced:    true,
+						OrderCancelled: false,
+						OrderCompleted: true,
+						OrderShipped:   false,
+					})).
+					Return(db.NotificationPreference{UserId: testUserId, OrderPlaced: true, OrderCompleted: true}, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidJSON",
+			method: http.MethodPut,
+			body:   `{"orderShipped":"no"}`,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertNotificationPreference(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(tc.method, "/api/v1/me/notification-preferences", strings.NewReader(tc.body))
+			require.NoError(t, err)
+			addAuthorization(t, request, server.TokenCreator(), testUserId, false)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/slamchillz/getinstashop-ecommerce-api/cmd/server"
+	"github.com/slamchillz/getinstashop-ecommerce-api/config"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/orderstream"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func orderStatusEvent(id int64, userId uuid.UUID, status db.OrderStatus) db.OrderStatusEvent {
+	return db.OrderStatusEvent{
+		ID:             id,
+		OrderId:        uuid.New(),
+		UserId:         userId,
+		Status:         status,
+		PreviousStatus: db.OrderStatusPENDING,
+		CreatedAt:      pgtype.Timestamp{Time: time.Now().UTC().Truncate(time.Microsecond), Valid: true},
+	}
+}
+
+// triggerPayload encodes a change like row_to_json in the trigger does.
+func triggerPayload(t *testing.T, event db.OrderStatusEvent) string {
+	payload, err := json.Marshal(map[string]any{
+		"id":             event.ID,
+		"orderId":        event.OrderId,
+		"userId":         event.UserId,
+		"status":         event.Status,
+		"previousStatus": event.PreviousStatus,
+		"createdAt":      event.CreatedAt.Time.Format("2006-01-02T15:04:05.999999"),
+	})
+	require.NoError(t, err)
+	return string(payload)
+}
+
+// stubListen makes Listen deliver the payloads sent on the returned channel
+// the way the trigger notifies them, until the broker stops listening. The
+// changes made before the broker started are the last ones up to lastId.
+func stubListen(t *testing.T, store *mockdb.MockStore, lastId int64, made ...db.OrderStatusEvent) chan<- db.OrderStatusEvent {
+	notifications := make(chan db.OrderStatusEvent)
+	store.EXPECT().
+		GetLastOrderStatusEventId(gomock.Any()).
+		Return(lastId, nil).
+		Times(1)
+	store.EXPECT().
+		GetOrderStatusEventsSince(gomock.Any(), gomock.Eq(db.GetOrderStatusEventsSinceParams{
+			AfterId:  max(lastId-orderstream.CatchUpOverlap, 0),
+			PageSize: 100,
+		})).
+		Return(made, nil).
+		Times(1)
+	store.EXPECT().
+		Listen(gomock.Any(), gomock.Eq(orderstream.Channel), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ string, listening func(), notify func(string)) error {
+			listening()
+			for {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case event := <-notifications:
+					notify(triggerPayload(t, event))
+				}
+			}
+		}).
+		Times(1)
+	return notifications
+}
+
+func TestOrderStreamBroker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	notifications := stubListen(t, store, 10, orderStatusEvent(9, testUserId, db.OrderStatusSHIPPED))
+
+	broker := orderstream.NewBroker(store, orderstream.Config{Buffer: 1})
+	defer broker.Close()
+	otherUserId := uuid.New()
+	sub, err := broker.Subscribe(context.Background(), testUserId)
+	require.NoError(t, err)
+	other, err := broker.Subscribe(context.Background(), otherUserId)
+	require.NoError(t, err)
+
+	// Changes older than the start of the broker are not published
+	notifications <- orderStatusEvent(9, testUserId, db.OrderStatusSHIPPED)
+	notifications <- orderStatusEvent(11, testUserId, db.OrderStatusSHIPPED)
+	event := <-sub.Events()
+	require.Equal(t, int64(11), event.ID)
+	require.Equal(t, db.OrderStatusSHIPPED, event.Status)
+	require.Equal(t, db.OrderStatusPENDING, event.PreviousStatus)
+
+	// A change committed after one with a higher id is still published, but
+	// only once
+	notifications <- orderStatusEvent(10, testUserId, db.OrderStatusDELIVERED)
+	require.Equal(t, int64(10), (<-sub.Events()).ID)
+	notifications <- orderStatusEvent(11, testUserId, db.OrderStatusSHIPPED)
+	notifications <- orderStatusEvent(10, testUserId, db.OrderStatusDELIVERED)
+
+	// A subscription only gets the changes of the orders of its user, and is
+	// dropped once it falls behind
+	notifications <- orderStatusEvent(12, otherUserId, db.OrderStatusCANCELLED)
+	notifications <- orderStatusEvent(13, otherUserId, db.OrderStatusCANCELLED)
+	require.Equal(t, int64(12), (<-other.Events()).ID)
+	_, ok := <-other.Events()
+	require.False(t, ok)
+	select {
+	case event = <-sub.Events():
+		t.Fatalf("unexpected change %d", event.ID)
+	default:
+	}
+
+	broker.Close()
+	_, ok = <-sub.Events()
+	require.False(t, ok)
+	_, err = broker.Subscribe(context.Background(), testUserId)
+	require.ErrorIs(t, err, orderstream.ErrClosed)
+}
+
+func TestOrderStreamBrokerReconnect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	made := orderStatusEvent(5, testUserId, db.OrderStatusSHIPPED)
+	missed := orderStatusEvent(4, testUserId, db.OrderStatusDELIVERED)
+	store.EXPECT().
+		GetLastOrderStatusEventId(gomock.Any()).
+		Return(int64(5), nil).
+		Times(1)
+	since := db.GetOrderStatusEventsSinceParams{AfterId: 0, PageSize: 100}
+	gomock.InOrder(
+		store.EXPECT().
+			GetOrderStatusEventsSince(gomock.Any(), gomock.Eq(since)).
+			Return([]db.OrderStatusEvent{made}, nil),
+		// The change with the lower id committed while the broker was not
+		// listening
+		store.EXPECT().
+			GetOrderStatusEventsSince(gomock.Any(), gomock.Eq(since)).
+			Return([]db.OrderStatusEvent{missed, made}, nil),
+	)
+	gomock.InOrder(
+		store.EXPECT().
+			Listen(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, listening func(), _ func(string)) error {
+				listening()
+				return fmt.Errorf("connection reset by peer")
+			}),
+		store.EXPECT().
+			Listen(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ string, listening func(), _ func(string)) error {
+				// The changes sent while the broker was not listening are caught up on
+				listening()
+				<-ctx.Done()
+				return ctx.Err()
+			}),
+	)
+
+	broker := orderstream.NewBroker(store, orderstream.Config{RetryInterval: time.Millisecond})
+	defer broker.Close()
+	sub, err := broker.Subscribe(context.Background(), testUserId)
+	require.NoError(t, err)
+	select {
+	case event := <-sub.Events():
+		require.Equal(t, missed.ID, event.ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("missed change not published")
+	}
+	select {
+	case event := <-sub.Events():
+		t.Fatalf("unexpected change %d", event.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func newStreamTestServer(t *testing.T, store db.Store) *server.Server {
+	cfg, err := config.LoadConfig("../")
+	require.NoError(t, err)
+	cfg.BlobStore = "local"
+	cfg.BlobLocalDir = t.TempDir()
+	cfg.OrderStreamHeartbeat = 20 * time.Millisecond
+	apiServer, err := server.NewServer(cfg, store)
+	require.NoError(t, err)
+	return apiServer
+}
+
+// readStreamEvent reads the next event of an SSE stream, skipping heartbeats
+// when skipHeartbeats is set.
+func readStreamEvent(t *testing.T, reader *bufio.Reader, skipHeartbeats bool) map[string]string {
+	event := map[string]string{}
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			if len(event) > 0 && (!skipHeartbeats || event["comment"] == "") {
+				return event
+			}
+			event = map[string]string{}
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			event["comment"] = strings.TrimSpace(line[1:])
+			continue
+		}
+		field, value, _ := strings.Cut(line, ": ")
+		event[field] = value
+	}
+}
+
+func TestStreamOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	notifications := stubListen(t, store, 10)
+	store.EXPECT().
+		GetOrderStatusEventsAfter(gomock.Any(), gomock.Eq(db.GetOrderStatusEventsAfterParams{
+			UserId:   testUserId,
+			AfterId:  0,
+			PageSize: 100,
+		})).
+		Return([]db.OrderStatusEvent{
+			orderStatusEvent(8, testUserId, db.OrderStatusSHIPPED),
+			orderStatusEvent(10, testUserId, db.OrderStatusDELIVERED),
+		}, nil).
+		Times(1)
+
+	apiServer := newStreamTestServer(t, store)
+	httpServer := httptest.NewServer(apiServer.Router())
+	defer httpServer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL+"/api/v1/orders/stream", nil)
+	require.NoError(t, err)
+	request.Header.Set("Last-Event-ID", "7")
+	addAuthorization(t, request, apiServer.TokenCreator(), testUserId, false)
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+	reader := bufio.NewReader(response.Body)
+	require.Equal(t, "3000", readStreamEvent(t, reader, true)["retry"])
+
+	// The changes after Last-Event-ID come first, the ones just before it being
+	// read again for the changes that committed late
+	event := readStreamEvent(t, reader, true)
+	require.Equal(t, "8", event["id"])
+	require.Equal(t, db.EventOrderStatusChanged, event["event"])
+	var data types.OrderStatusEvent
+	require.NoError(t, json.Unmarshal([]byte(event["data"]), &data))
+	require.Equal(t, db.OrderStatusSHIPPED, data.Status)
+	require.Equal(t, "10", readStreamEvent(t, reader, true)["id"])
+
+	// Idle streams get heartbeats
+	require.Equal(t, "heartbeat", readStreamEvent(t, reader, false)["comment"])
+
+	// Then the live changes of the user's orders only
+	notifications <- orderStatusEvent(11, uuid.New(), db.OrderStatusCANCELLED)
+	notifications <- orderStatusEvent(10, testUserId, db.OrderStatusDELIVERED)
+	notifications <- orderStatusEvent(12, testUserId, db.OrderStatusCOMPLETED)
+	event = readStreamEvent(t, reader, true)
+	require.Equal(t, "12", event["id"])
+	require.NoError(t, json.Unmarshal([]byte(event["data"]), &data))
+	require.Equal(t, db.OrderStatusCOMPLETED, data.Status)
+	require.Equal(t, testUserId, data.UserId)
+}
+
+func TestStreamOrdersErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().Listen(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	apiServer := newStreamTestServer(t, store)
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/orders/stream?lastEventId=abc", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, apiServer.TokenCreator(), testUserId, false)
+	apiServer.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "lastEventId")
+
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodGet, "/api/v1/orders/stream", nil)
+	require.NoError(t, err)
+	apiServer.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
@@ -0,0 +1,219 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func testPNG(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))))
+	return buf.Bytes()
+}
+
+func multipartImage(t *testing.T, content []byte, primary bool) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("image", "photo.png")
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	if primary {
+		require.NoError(t, writer.WriteField("primary", "true"))
+	}
+	require.NoError(t, writer.Close())
+	return body, writer.FormDataContentType()
+}
+
+func TestUploadProductImage(t *testing.T) {
+	productId := uuid.New()
+	testCases := []struct {
+		name     string
+		content  []byte
+		primary  bool
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, server http.Handler, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			content: testPNG(t),
+			primary: true,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProductImageTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateProductImageTxParams) (db.ProductImage, map[string]string, error, error) {
+						require.Equal(t, productId, arg.ProductId)
+						require.Equal(t, "image/png", arg.ContentType)
+						require.Equal(t, fmt.Sprintf("products/%s/%s.png", productId, arg.ID), arg.Key)
+						require.True(t, arg.Primary)
+						return db.ProductImage{
+							ID:          arg.ID,
+							ProductId:   arg.ProductId,
+							Key:         arg.Key,
+							ContentType: arg.ContentType,
+							Size:        arg.Size,
+							IsPrimary:   true,
+						}, map[string]string{}, nil, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, server http.Handler, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				var body struct {
+					Data struct {
+						URL string `json:"url"`
+					} `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				// The uploaded file can be downloaded from its url
+				media := httptest.NewRecorder()
+				request, err := http.NewRequest(http.MethodGet, body.Data.URL, nil)
+				require.NoError(t, err)
+				server.ServeHTTP(media, request)
+				require.Equal(t, http.StatusOK, media.Code)
+				require.Equal(t, "image/png", media.Header().Get("Content-Type"))
+			},
+		},
+		{
+			name:    "Not An Image",
+			content: []byte("name,price\nshirt,10\n"),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProductImageTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, server http.Handler, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "JPEG, PNG, GIF or WebP")
+			},
+		},
+		{
+			name:    "Too Many Images",
+			content: testPNG(t),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProductImageTx(gomock.Any(), gomock.Any()).
+					Return(db.ProductImage{}, map[string]string{"image": "a product can have at most 10 images"}, nil, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, server http.Handler, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "Product Not Found",
+			content: testPNG(t),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProductImageTx(gomock.Any(), gomock.Any()).
+					Return(db.ProductImage{}, map[string]string{}, pgx.ErrNoRows, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, server http.Handler, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			body, contentType := multipartImage(t, tc.content, tc.primary)
+
+			url := fmt.Sprintf("/api/v1/admin/products/%s/images", productId)
+			request, err := http.NewRequest(http.MethodPost, url, body)
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", contentType)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, server.Router(), recorder)
+		})
+	}
+}
+
+func TestReorderProductImages(t *testing.T) {
+	productId := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	testCases := []struct {
+		name     string
+		body     gin.H
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"imageIds": []uuid.UUID{second, first}, "primaryImageId": second},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReorderProductImageTx(gomock.Any(), gomock.Eq(db.ReorderProductImageTxParams{
+						ProductId:      productId,
+						ImageIds:       []uuid.UUID{second, first},
+						PrimaryImageId: second,
+					})).
+					Return([]db.ProductImage{
+						{ID: second, ProductId: productId, Key: "products/b.png", Position: 0, IsPrimary: true},
+						{ID: first, ProductId: productId, Key: "products/a.png", Position: 1},
+					}, map[string]string{}, nil, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Image Id",
+			body: gin.H{"imageIds": []string{"first"}},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReorderProductImageTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/admin/products/%s/images", productId)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
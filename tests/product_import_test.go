@@ -0,0 +1,436 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// importRows answers ImportProductTx as if every product were new, except for
+// the ones named in existing.
+func importRows(existing ...string) func(_ any, arg db.ImportProductTxParams) ([]db.ImportedProduct, map[int]map[string]string, error, error) {
+	return func(_ any, arg db.ImportProductTxParams) ([]db.ImportedProduct, map[int]map[string]string, error, error) {
+		var products []db.ImportedProduct
+		for _, row := range arg.Rows {
+			products = append(products, db.ImportedProduct{
+				Product: db.Product{ID: uuid.New(), Name: row.Name, Sku: row.Sku, Price: row.Price},
+				Created: !strings.Contains(strings.Join(existing, ","), row.Name),
+			})
+		}
+		return products, map[int]map[string]string{}, nil, nil
+	}
+}
+
+func TestImportProducts(t *testing.T) {
+	validCSV := "sku,name,description,price,stock,category\n" +
+		"TS-1,T-shirt,\"Cotton, white\",19.999,10,clothing\n" +
+		",Mug,Ceramic mug,8,5,\n"
+	testCases := []struct {
+		name        string
+		query       string
+		contentType string
+		body        string
+		multipart   string
+		stubs       func(store *mockdb.MockStore)
+		response    func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "CSV",
+			contentType: "text/csv",
+			body:        validCSV,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportProductTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx any, arg db.ImportProductTxParams) ([]db.ImportedProduct, map[int]map[string]string, error, error) {
+						require.False(t, arg.DryRun)
+						require.Equal(t, testUserId, arg.CreatedBy)
+						require.Equal(t, []db.ImportProductRow{
+							{Name: "T-shirt", Description: "Cotton, white", Price: 20, Stock: 10, Category: "clothing", TaxClass: "standard", Sku: "TS-1"},
+							{Name: "Mug", Description: "Ceramic mug", Price: 8, Stock: 5, TaxClass: "standard"},
+						}, arg.Rows)
+						return importRows("Mug")(ctx, arg)
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var body struct {
+					Data types.ProductImportOutput `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.Equal(t, 1, body.Data.Created)
+				require.Equal(t, 1, body.Data.Updated)
+				require.Equal(t, 2, body.Data.Products[0].Line)
+				require.Equal(t, "created", body.Data.Products[0].Action)
+				require.NotNil(t, body.Data.Products[0].ID)
+				require.Equal(t, "updated", body.Data.Products[1].Action)
+			},
+		},
+		{
+			name:        "JSON Lines Dry Run",
+			query:       "?dryRun=true",
+			contentType: "application/x-ndjson",
+			body: `{"id":"ignored","sku":"TS-1","name":"T-shirt","description":"Cotton","price":19.99,"stock":10}` + "\n\n" +
+				`{"name":"Mug","description":"Ceramic mug","price":8}` + "\n",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportProductTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx any, arg db.ImportProductTxParams) ([]db.ImportedProduct, map[int]map[string]string, error, error) {
+						require.True(t, arg.DryRun)
+						require.Len(t, arg.Rows, 2)
+						return importRows("T-shirt")(ctx, arg)
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var body struct {
+					Data types.ProductImportOutput `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.True(t, body.Data.DryRun)
+				require.Equal(t, 3, body.Data.Products[1].Line)
+				// Products a dry run would create are not kept, so they have no id
+				require.NotNil(t, body.Data.Products[0].ID)
+				require.Nil(t, body.Data.Products[1].ID)
+			},
+		},
+		{
+			name:      "Multipart",
+			multipart: "catalog.csv",
+			body:      validCSV,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportProductTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(importRows()).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "Invalid Rows",
+			contentType: "text/csv",
+			body: "sku,name,description,price,stock\n" +
+				"TS-1,T-shirt,Cotton,abc,10\n" +
+				"TS-1,Mug,Ceramic mug,8,-1\n" +
+				"bad sku,T-shirt,Cotton,5,1\n",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportProductTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				var body struct {
+					Error types.ProductImportErrMessage `json:"error"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.Equal(t, []types.ProductImportRowError{
+					{Line: 2, Error: types.ProductErrMessage{Price: "price must be a number"}},
+					{Line: 3, Error: types.ProductErrMessage{Stock: "stock cannot be negative", Sku: "sku already appears on line 2"}},
+					{Line: 4, Error: types.ProductErrMessage{Name: "product already appears on line 2", Sku: "sku can only contain letters, digits, dashes, dots and underscores"}},
+				}, body.Error.Rows)
+			},
+		},
+		{
+			name:        "Conflicting Rows",
+			contentType: "text/csv",
+			body:        validCSV,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportProductTx(gomock.Any(), gomock.Any()).
+					Return(nil, map[int]map[string]string{1: {"name": "name is already used by the product with sku MG-1"}}, nil, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"line":3`)
+				require.Contains(t, recorder.Body.String(), "sku MG-1")
+			},
+		},
+		{
+			name:        "Not Enough Stock",
+			contentType: "text/csv",
+			body:        validCSV,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportProductTx(gomock.Any(), gomock.Any()).
+					Return(nil, map[int]map[string]string{0: {"stock": "stock cannot be lowered by more than the active warehouses hold"}}, nil, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				var body struct {
+					Error types.ProductImportErrMessage `json:"error"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.Equal(t, []types.ProductImportRowError{
+					{Line: 2, Error: types.ProductErrMessage{Stock: "stock cannot be lowered by more than the active warehouses hold"}},
+				}, body.Error.Rows)
+			},
+		},
+		{
+			name:        "Unknown Column",
+			contentType: "text/csv",
+			body:        "name,description,price,colour\nT-shirt,Cotton,10,white\n",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportProductTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `unknown column \"colour\"`)
+			},
+		},
+		{
+			name:        "Unsupported Format",
+			contentType: "application/json",
+			body:        `[]`,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportProductTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body, contentType := bytes.NewBufferString(tc.body), tc.contentType
+			if tc.multipart != "" {
+				body = &bytes.Buffer{}
+				writer := multipart.NewWriter(body)
+				part, err := writer.CreateFormFile("file", tc.multipart)
+				require.NoError(t, err)
+				_, err = part.Write([]byte(tc.body))
+				require.NoError(t, err)
+				require.NoError(t, writer.Close())
+				contentType = writer.FormDataContentType()
+			}
+			request, err := http.NewRequest(http.MethodPost, "/api/v1/admin/products/import"+tc.query, body)
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", contentType)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+// importFakeDB answers the queries of ImportProductTx for a catalog holding
+// product, spread over warehouses.
+func importFakeDB(product db.Product, warehouses []db.GetAllocatableStockRow) *fakeDB {
+	fake := newFakeDB()
+	fake.on("GetProductBySkuForUpdate", func(args []any) (any, error) {
+		if args[0] == product.Sku {
+			return product, nil
+		}
+		return nil, nil
+	})
+	fake.on("GetProductByNameForUpdate", func(args []any) (any, error) {
+		if args[0] == product.Name {
+			return product, nil
+		}
+		return nil, nil
+	})
+	fake.on("UpdateOneProduct", func(args []any) (any, error) {
+		updated := product
+		updated.Stock = args[3].(int32)
+		return updated, nil
+	})
+	fake.on("GetAllocatableStock", func(args []any) (any, error) {
+		return warehouses, nil
+	})
+	fake.on("GetWarehouseStockForUpdate", func(args []any) (any, error) {
+		for _, warehouse := range warehouses {
+			if warehouse.WarehouseId == args[0] {
+				return db.WarehouseStock{WarehouseId: warehouse.WarehouseId, ProductId: warehouse.ProductId, Stock: warehouse.Stock}, nil
+			}
+		}
+		return nil, nil
+	})
+	return fake
+}
+
+func TestImportProductTxLowersStock(t *testing.T) {
+	product := db.Product{ID: uuid.New(), Name: "Mug", Sku: "MG-1", Price: 8, Stock: 10}
+	primary, secondary := uuid.New(), uuid.New()
+	row := db.ImportProductRow{Name: "Mug", Sku: "MG-1", Description: "Ceramic mug", Price: 8, Stock: 3}
+
+	t.Run("Spread Over Warehouses", func(t *testing.T) {
+		// Neither warehouse holds the 7 units taken off on its own
+		fake := importFakeDB(product, []db.GetAllocatableStockRow{
+			{WarehouseId: primary, ProductId: product.ID, Stock: 4},
+			{WarehouseId: secondary, ProductId: product.ID, Stock: 6},
+		})
+		products, invalidRows, execErr, txErr := db.NewStore(fake).ImportProductTx(context.Background(), db.ImportProductTxParams{Rows: []db.ImportProductRow{row}})
+		require.NoError(t, execErr)
+		require.NoError(t, txErr)
+		require.Empty(t, invalidRows)
+		require.Len(t, products, 1)
+		require.Equal(t, int32(3), products[0].Stock)
+
+		// The units come off the warehouses in order of priority
+		added := fake.called("AddWarehouseStock")
+		require.Len(t, added, 2)
+		require.Equal(t, []any{primary, product.ID, int32(-4)}, added[0].Args)
+		require.Equal(t, []any{secondary, product.ID, int32(-3)}, added[1].Args)
+		movements := fake.called("CreateStockMovement")
+		require.Len(t, movements, 2)
+		require.Equal(t, []any{int32(-4), int32(6)}, movements[0].Args[1:3])
+		require.Equal(t, []any{int32(-3), int32(3)}, movements[1].Args[1:3])
+		require.True(t, fake.committed)
+	})
+
+	t.Run("Not Enough Stock", func(t *testing.T) {
+		fake := importFakeDB(product, []db.GetAllocatableStockRow{
+			{WarehouseId: primary, ProductId: product.ID, Stock: 2},
+			{WarehouseId: secondary, ProductId: product.ID, Stock: 3},
+		})
+		products, invalidRows, execErr, txErr := db.NewStore(fake).ImportProductTx(context.Background(), db.ImportProductTxParams{Rows: []db.ImportProductRow{row}})
+		require.NoError(t, execErr)
+		require.NoError(t, txErr)
+		require.Empty(t, products)
+		require.Equal(t, map[int]map[string]string{0: {"stock": "stock cannot be lowered by more than the active warehouses hold"}}, invalidRows)
+		require.Empty(t, fake.called("AddWarehouseStock"))
+		require.True(t, fake.rolledBack)
+	})
+}
+
+func TestImportProductTxArchivedMatch(t *testing.T) {
+	archived := db.Product{
+		ID:        uuid.New(),
+		Name:      "Mug",
+		Sku:       "MG-1",
+		Price:     8,
+		Stock:     4,
+		DeletedAt: pgtype.Timestamp{Time: time.Now().UTC(), Valid: true},
+	}
+	testCases := []struct {
+		name  string
+		row   db.ImportProductRow
+		error map[string]string
+	}{
+		{
+			name:  "By Sku",
+			row:   db.ImportProductRow{Name: "Large mug", Sku: "MG-1", Price: 9, Stock: 2},
+			error: map[string]string{"sku": "sku belongs to an archived product, restore it first"},
+		},
+		{
+			name:  "By Name",
+			row:   db.ImportProductRow{Name: "Mug", Price: 9, Stock: 2},
+			error: map[string]string{"name": "name belongs to an archived product, restore it first"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := importFakeDB(archived, nil)
+			products, invalidRows, execErr, txErr := db.NewStore(fake).ImportProductTx(context.Background(), db.ImportProductTxParams{
+				Rows: []db.ImportProductRow{
+					{Name: "T-shirt", Sku: "TS-1", Price: 10, Stock: 5},
+					tc.row,
+				},
+			})
+			require.NoError(t, execErr)
+			require.NoError(t, txErr)
+			require.Empty(t, products)
+			// Only the row matching the archived product is rejected, and it
+			// rejects the whole import
+			require.Equal(t, map[int]map[string]string{1: tc.error}, invalidRows)
+			require.Empty(t, fake.called("UpdateOneProduct"))
+			require.True(t, fake.rolledBack)
+			require.False(t, fake.committed)
+		})
+	}
+}
+
+func TestExportProducts(t *testing.T) {
+	product := db.GetProductPageRow{
+		ID:          uuid.New(),
+		Name:        "T-shirt",
+		Description: "Cotton, white",
+		Price:       19.99,
+		Stock:       10,
+		Category:    "clothing",
+		TaxClass:    "standard",
+		Sku:         "TS-1",
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetProductPage(gomock.Any(), gomock.Eq(db.GetProductPageParams{AfterId: uuid.Nil, PageSize: 500})).
+		Return([]db.GetProductPageRow{product}, nil).
+		Times(2)
+	server := newTestServer(t, store)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/admin/products/export", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+	server.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "text/csv", recorder.Header().Get("Content-Type"))
+	records, err := csv.NewReader(recorder.Body).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"id", "sku", "name", "description", "price", "stock", "category", "taxClass", "weight"},
+		{product.ID.String(), "TS-1", "T-shirt", "Cotton, white", "19.99", "10", "clothing", "standard", "0"},
+	}, records)
+
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodGet, "/api/v1/admin/products/export?format=jsonl", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+	server.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "application/x-ndjson", recorder.Header().Get("Content-Type"))
+	exported := recorder.Body.Bytes()
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(exported, &line))
+	require.Equal(t, "TS-1", line["sku"])
+	require.Equal(t, product.ID.String(), line["id"])
+
+	// The export can be imported back as it is
+	store.EXPECT().
+		ImportProductTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(importRows("T-shirt")).
+		Times(1)
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodPost, "/api/v1/admin/products/import?format=jsonl", bytes.NewReader(exported))
+	require.NoError(t, err)
+	addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+	server.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `"updated":1`)
+}
@@ -0,0 +1,293 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestScheduleProductPrice(t *testing.T) {
+	productId := uuid.New()
+	from := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Second)
+	to := from.Add(72 * time.Hour)
+
+	testCases := []struct {
+		name     string
+		body     gin.H
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Scheduled Sale",
+			body: gin.H{"price": 14.999, "compareAtPrice": 20, "effectiveFrom": from, "effectiveTo": to},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ScheduleProductPriceTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateProductPriceParams) (db.ScheduleProductPriceTxResult, error, error) {
+						require.Equal(t, productId, arg.ProductId)
+						require.Equal(t, 15.0, arg.Price)
+						require.Equal(t, pgtype.Float8{Float64: 20, Valid: true}, arg.CompareAtPrice)
+						require.True(t, from.Equal(arg.EffectiveFrom.Time))
+						require.True(t, to.Equal(arg.EffectiveTo.Time))
+						require.Equal(t, pgtype.UUID{Bytes: testUserId, Valid: true}, arg.CreatedBy)
+						return db.ScheduleProductPriceTxResult{
+							Price:   db.ProductPrice{ID: arg.ID, ProductId: arg.ProductId, Price: arg.Price},
+							Product: db.Product{ID: productId, Price: 20},
+						}, nil, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Effective Now",
+			body: gin.H{"price": 18},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ScheduleProductPriceTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateProductPriceParams) (db.ScheduleProductPriceTxResult, error, error) {
+						require.WithinDuration(t, time.Now().UTC(), arg.EffectiveFrom.Time, time.Minute)
+						require.False(t, arg.EffectiveTo.Valid)
+						require.False(t, arg.CompareAtPrice.Valid)
+						return db.ScheduleProductPriceTxResult{Product: db.Product{ID: productId, Price: 18}}, nil, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Compare-At Price Not Higher",
+			body: gin.H{"price": 15, "compareAtPrice": 15},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ScheduleProductPriceTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "compareAtPrice must be greater than price")
+			},
+		},
+		{
+			name: "Window Ends Before It Starts",
+			body: gin.H{"price": 15, "effectiveFrom": to, "effectiveTo": from},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ScheduleProductPriceTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "effectiveTo must be after effectiveFrom")
+			},
+		},
+		{
+			name: "Window Already Over",
+			body: gin.H{"price": 15, "effectiveFrom": time.Now().Add(-48 * time.Hour), "effectiveTo": time.Now().Add(-24 * time.Hour)},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ScheduleProductPriceTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "effectiveTo must be in the future")
+			},
+		},
+		{
+			name: "Product Not Found",
+			body: gin.H{"price": 15},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ScheduleProductPriceTx(gomock.Any(), gomock.Any()).
+					Return(db.ScheduleProductPriceTxResult{}, pgx.ErrNoRows, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/admin/products/%s/prices", productId)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestGetProductPrices(t *testing.T) {
+	product := db.GetOneProductRow{ID: uuid.New(), Name: "Mug", Price: 15}
+	prices := []db.ProductPrice{
+		{ID: uuid.New(), ProductId: product.ID, Price: 15, CompareAtPrice: pgtype.Float8{Float64: 20, Valid: true}},
+		{ID: uuid.New(), ProductId: product.ID, Price: 20},
+	}
+
+	testCases := []struct {
+		name     string
+		url      string
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			url:  fmt.Sprintf("/api/v1/admin/products/%s/prices", product.ID),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneProduct(gomock.Any(), gomock.Eq(product.ID)).Return(product, nil).Times(1)
+				store.EXPECT().
+					GetProductPrices(gomock.Any(), gomock.Eq(db.GetProductPricesParams{ProductId: product.ID, PageSize: 50})).
+					Return(prices, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"compareAtPrice":20`)
+				require.Contains(t, recorder.Body.String(), `"compareAtPrice":null`)
+			},
+		},
+		{
+			name: "Invalid Limit",
+			url:  fmt.Sprintf("/api/v1/admin/products/%s/prices?limit=0", product.ID),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProductPrices(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Product Not Found",
+			url:  fmt.Sprintf("/api/v1/admin/products/%s/prices", product.ID),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneProduct(gomock.Any(), gomock.Any()).Return(db.GetOneProductRow{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().GetProductPrices(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestCancelProductPrice(t *testing.T) {
+	productId, priceId := uuid.New(), uuid.New()
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			CancelProductPriceTx(gomock.Any(), gomock.Eq(db.CancelProductPriceParams{ID: priceId, ProductId: productId})).
+			Return(db.ScheduleProductPriceTxResult{Price: db.ProductPrice{ID: priceId}, Product: db.Product{ID: productId, Price: 20}}, nil, nil).
+			Times(1)
+
+		server := newTestServer(t, store)
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/admin/products/%s/prices/%s", productId, priceId), nil)
+		require.NoError(t, err)
+		addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+		server.Router().ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("No Longer In Effect", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			CancelProductPriceTx(gomock.Any(), gomock.Any()).
+			Return(db.ScheduleProductPriceTxResult{}, pgx.ErrNoRows, nil).
+			Times(1)
+
+		server := newTestServer(t, store)
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/admin/products/%s/prices/%s", productId, priceId), nil)
+		require.NoError(t, err)
+		addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+		server.Router().ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+		require.Contains(t, recorder.Body.String(), "no longer in effect")
+	})
+}
+
+func TestRunApplyPriceJob(t *testing.T) {
+	productId := uuid.New()
+	payload, err := json.Marshal(db.ApplyProductPriceArgs{ProductId: productId})
+	require.NoError(t, err)
+
+	t.Run("Applied", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().ApplyProductPriceTx(gomock.Any(), gomock.Eq(productId)).Return(db.Product{ID: productId}, nil, nil).Times(1)
+
+		products := services.NewProductService(store, nil, nil)
+		require.NoError(t, products.RunApplyPriceJob(context.Background(), payload))
+	})
+
+	t.Run("Product Deleted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().ApplyProductPriceTx(gomock.Any(), gomock.Eq(productId)).Return(db.Product{}, pgx.ErrNoRows, nil).Times(1)
+
+		products := services.NewProductService(store, nil, nil)
+		require.NoError(t, products.RunApplyPriceJob(context.Background(), payload))
+	})
+
+	t.Run("Invalid Payload", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().ApplyProductPriceTx(gomock.Any(), gomock.Any()).Times(0)
+
+		products := services.NewProductService(store, nil, nil)
+		require.Error(t, products.RunApplyPriceJob(context.Background(), json.RawMessage(`"oops"`)))
+	})
+}
@@ -38,7 +38,7 @@ func TestCreateProduct(t *testing.T) {
 			},
 			stubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					CreateProduct(gomock.Any(), gomock.Any()).
+					CreateProductTx(gomock.Any(), gomock.Any()).
 					Times(1)
 			},
 			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -58,7 +58,7 @@ func TestCreateProduct(t *testing.T) {
 			},
 			stubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					CreateProduct(gomock.Any(), gomock.Any()).
+					CreateProductTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -78,7 +78,7 @@ func TestCreateProduct(t *testing.T) {
 			},
 			stubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					CreateProduct(gomock.Any(), gomock.Any()).
+					CreateProductTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -126,7 +126,7 @@ func TestListAllProduct(t *testing.T) {
 			},
 			stubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetAllProduct(gomock.Any()).
+					GetAllProduct(gomock.Any(), gomock.Eq("")).
 					Times(1)
 			},
 			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -140,7 +140,7 @@ func TestListAllProduct(t *testing.T) {
 			},
 			stubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetAllProduct(gomock.Any()).
+					GetAllProduct(gomock.Any(), gomock.Eq("")).
 					Times(1)
 			},
 			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -154,7 +154,7 @@ func TestListAllProduct(t *testing.T) {
 			},
 			stubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetAllProduct(gomock.Any()).
+					GetAllProduct(gomock.Any(), gomock.Eq("")).
 					Times(0)
 			},
 			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -194,6 +194,7 @@ func TestGetSingleProduct(t *testing.T) {
 		CreatedBy:   testUserId,
 		CreatedAt:   pgtype.Timestamp{},
 		UpdatedAt:   pgtype.Timestamp{},
+		Version:     3,
 	}
 	testCases := []struct {
 		name      string
@@ -211,11 +212,16 @@ func TestGetSingleProduct(t *testing.T) {
 			stubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetOneProduct(gomock.Any(), gomock.Eq(product.ID)).
-					//Return(product, nil).
+					Return(product, nil).
+					Times(1)
+				store.EXPECT().
+					GetProductImageByProductIds(gomock.Any(), gomock.Eq([]uuid.UUID{product.ID})).
+					Return([]db.ProductImage{}, nil).
 					Times(1)
 			},
 			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, `"3"`, recorder.Header().Get("ETag"))
 			},
 		},
 		{
@@ -283,7 +289,7 @@ func TestDeleteProduct(t *testing.T) {
 			},
 			stubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					DeleteOneProduct(gomock.Any(), gomock.Eq(product.ID)).
+					DeleteProductTx(gomock.Any(), gomock.Eq(product.ID)).
 					//Return(product, nil).
 					Times(1)
 			},
@@ -299,7 +305,7 @@ func TestDeleteProduct(t *testing.T) {
 			},
 			stubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					DeleteOneProduct(gomock.Any(), gomock.Eq(product.ID)).
+					DeleteProductTx(gomock.Any(), gomock.Eq(product.ID)).
 					//Return(product, nil).
 					Times(0)
 			},
@@ -329,3 +335,178 @@ func TestDeleteProduct(t *testing.T) {
 		})
 	}
 }
+
+func TestRestoreProduct(t *testing.T) {
+	productId := uuid.New()
+	testCases := []struct {
+		name     string
+		auth     func(t *testing.T, req *http.Request, tokenCreator *token.JWT)
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Admin",
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, true)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RestoreProductTx(gomock.Any(), gomock.Eq(productId)).
+					Return(db.Product{ID: productId, Name: "test"}, nil, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Not Archived",
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, true)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RestoreProductTx(gomock.Any(), gomock.Eq(productId)).
+					Return(db.Product{}, fmt.Errorf("no rows in result set"), nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "product not found or not archived")
+			},
+		},
+		{
+			name: "Forbidden",
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, false)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RestoreProductTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/admin/products/%s/restore", productId)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			tc.auth(t, request, server.TokenCreator())
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestUpdateProductIfMatch(t *testing.T) {
+	productId := uuid.New()
+	testCases := []struct {
+		name     string
+		ifMatch  string
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "Matching Version",
+			ifMatch: `"3"`,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateProductTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.UpdateProductTxParams) (db.Product, error, error) {
+						require.Equal(t, int32(3), *arg.Version)
+						return db.Product{ID: productId, Name: *arg.Name, Version: 4}, nil, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, `"4"`, recorder.Header().Get("ETag"))
+			},
+		},
+		{
+			name:    "Any Version",
+			ifMatch: "*",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateProductTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.UpdateProductTxParams) (db.Product, error, error) {
+						require.Nil(t, arg.Version)
+						return db.Product{ID: productId, Name: *arg.Name, Version: 4}, nil, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:    "Stale Version",
+			ifMatch: `"2"`,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateProductTx(gomock.Any(), gomock.Any()).
+					Return(db.Product{}, db.ErrProductVersionMismatch, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+			},
+		},
+		{
+			name:    "Weak ETag",
+			ifMatch: `W/"3"`,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateProductTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+			},
+		},
+		{
+			name: "Missing If-Match",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateProductTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/admin/products/%s", productId)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader([]byte(`{"name":"iPhone8"}`)))
+			require.NoError(t, err)
+			if tc.ifMatch != "" {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
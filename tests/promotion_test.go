@@ -0,0 +1,356 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/promotion"
+	"github.com/slamchillz/getinstashop-ecommerce-api/pkg/token"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreatePromotion(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     gin.H
+		auth     func(t *testing.T, req *http.Request, tokenCreator *token.JWT)
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Buy X Get Y Free",
+			body: gin.H{
+				"name":        "Buy 2 get 1 free",
+				"kind":        "buy_x_get_y",
+				"categories":  []string{"apparel"},
+				"buyQuantity": 2,
+				"getQuantity": 1,
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, true)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePromotion(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreatePromotionParams) (db.Promotion, error) {
+						require.Equal(t, db.PromotionKindBUYXGETY, arg.Kind)
+						require.Equal(t, float64(100), arg.Percent)
+						require.Equal(t, []uuid.UUID{}, arg.ProductIds)
+						require.JSONEq(t, "[]", string(arg.Tiers))
+						require.True(t, arg.Active)
+						return db.Promotion{ID: arg.ID, Name: arg.Name, Kind: arg.Kind, Tiers: arg.Tiers}, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"tiers":[]`)
+			},
+		},
+		{
+			name: "Quantity Tiers",
+			body: gin.H{
+				"name":  "Bulk savings",
+				"kind":  "QUANTITY_TIER",
+				"tiers": []gin.H{{"minQuantity": 5, "percent": 5}, {"minQuantity": 10, "percent": 10}},
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, true)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePromotion(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreatePromotionParams) (db.Promotion, error) {
+						require.JSONEq(t, `[{"minQuantity":5,"percent":5},{"minQuantity":10,"percent":10}]`, string(arg.Tiers))
+						return db.Promotion{ID: arg.ID, Name: arg.Name, Kind: arg.Kind, Tiers: arg.Tiers}, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Bundle Of One Product",
+			body: gin.H{
+				"name":        "Desk set",
+				"kind":        "BUNDLE",
+				"productIds":  []uuid.UUID{uuid.New()},
+				"bundlePrice": 40,
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, true)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePromotion(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "at least two products")
+			},
+		},
+		{
+			name: "Category Sale Without Categories",
+			body: gin.H{
+				"name":    "Summer sale",
+				"kind":    "CATEGORY_SALE",
+				"percent": 20,
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, true)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePromotion(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			body: gin.H{
+				"name":       "Summer sale",
+				"kind":       "CATEGORY_SALE",
+				"categories": []string{"apparel"},
+				"percent":    20,
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, false)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePromotion(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/api/v1/admin/promotions"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			tc.auth(t, request, server.TokenCreator())
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestPreviewOrder(t *testing.T) {
+	productId := uuid.New()
+	testCases := []struct {
+		name     string
+		body     gin.H
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success",
+			body: gin.H{
+				"items":          []gin.H{{"productId": productId, "quantity": 3}},
+				"shippingRegion": "gb",
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					PreviewOrder(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateOrderTxParams) (db.OrderPreview, map[string]string, error) {
+						require.Equal(t, testUserId, arg.UserId)
+						require.Equal(t, map[uuid.UUID]int32{productId: 3}, arg.Items)
+						require.Equal(t, "GB", arg.ShippingRegion)
+						return db.OrderPreview{
+							Status:   db.OrderStatusBACKORDERED,
+							Subtotal: 60,
+							Discount: 20,
+							Total:    40,
+							Items: []db.OrderPreviewItem{
+								{ProductId: productId, Quantity: 3, Price: 60, Discount: 20, Backordered: 1},
+							},
+							Promotions: []db.AppliedPromotion{
+								{PromotionId: uuid.New(), Name: "Buy 2 get 1 free", Kind: db.PromotionKindBUYXGETY, Discount: 20},
+							},
+						}, nil, nil
+					}).
+					Times(1)
+				store.EXPECT().CreateOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "Buy 2 get 1 free")
+				require.Contains(t, recorder.Body.String(), `"total":40`)
+				require.Contains(t, recorder.Body.String(), `"backordered":1`)
+				require.Contains(t, recorder.Body.String(), string(db.OrderStatusBACKORDERED))
+			},
+		},
+		{
+			name: "Out Of Stock",
+			body: gin.H{
+				"items": []gin.H{{"productId": productId, "quantity": 3}},
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					PreviewOrder(gomock.Any(), gomock.Any()).
+					Return(db.OrderPreview{}, map[string]string{productId.String(): "quantity less than available stock"}, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "quantity less than available stock")
+			},
+		},
+		{
+			name: "Invalid Shipping Method",
+			body: gin.H{
+				"items":            []gin.H{{"productId": productId, "quantity": 3}},
+				"shippingMethodId": "express",
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().PreviewOrder(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "must be a valid shipping method id")
+			},
+		},
+		{
+			name: "No Items",
+			body: gin.H{
+				"items": []gin.H{},
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().PreviewOrder(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/v1/orders/preview", bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, false)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestPromotionApply(t *testing.T) {
+	shirt, socks, mug := uuid.New(), uuid.New(), uuid.New()
+	lines := []promotion.Line{
+		{ProductId: shirt, Category: "apparel", Quantity: 3, Amount: 60},
+		{ProductId: socks, Category: "apparel", Quantity: 2, Amount: 10},
+		{ProductId: mug, Category: "kitchen", Quantity: 1, Amount: 15},
+	}
+	testCases := []struct {
+		name      string
+		rules     []promotion.Rule
+		discounts []float64
+		applied   []float64
+	}{
+		{
+			name:      "Buy X Get Y Discounts The Cheapest Units",
+			rules:     []promotion.Rule{{Kind: promotion.BuyXGetY, Categories: []string{"apparel"}, BuyQuantity: 2, GetQuantity: 1, Percent: 100}},
+			discounts: []float64{0, 5, 0},
+			applied:   []float64{5},
+		},
+		{
+			name:      "Bundle",
+			rules:     []promotion.Rule{{Kind: promotion.Bundle, ProductIds: []uuid.UUID{shirt, mug}, BundlePrice: 30}},
+			discounts: []float64{2.86, 0, 2.14},
+			applied:   []float64{5},
+		},
+		{
+			name:      "Bundle Missing A Product",
+			rules:     []promotion.Rule{{Kind: promotion.Bundle, ProductIds: []uuid.UUID{shirt, uuid.New()}, BundlePrice: 10}},
+			discounts: []float64{0, 0, 0},
+		},
+		{
+			name:      "Quantity Tier Takes The Highest Tier Reached",
+			rules:     []promotion.Rule{{Kind: promotion.QuantityTier, Tiers: []promotion.Tier{{MinQuantity: 2, Percent: 5}, {MinQuantity: 3, Percent: 10}}}},
+			discounts: []float64{6, 0.5, 0},
+			applied:   []float64{6.5},
+		},
+		{
+			name:      "Category Sale",
+			rules:     []promotion.Rule{{Kind: promotion.CategorySale, Categories: []string{"kitchen"}, Percent: 20}},
+			discounts: []float64{0, 0, 3},
+			applied:   []float64{3},
+		},
+		{
+			name: "Exclusive Promotion Keeps Its Lines",
+			rules: []promotion.Rule{
+				{Kind: promotion.QuantityTier, Priority: 1, Stackable: true, Tiers: []promotion.Tier{{MinQuantity: 1, Percent: 10}}},
+				{Kind: promotion.CategorySale, Priority: 10, Categories: []string{"apparel"}, Percent: 50},
+			},
+			discounts: []float64{30, 5, 1.5},
+			applied:   []float64{35, 1.5},
+		},
+		{
+			name: "Stackable Promotions Combine",
+			rules: []promotion.Rule{
+				{Kind: promotion.CategorySale, Priority: 10, Stackable: true, Categories: []string{"apparel"}, Percent: 50},
+				{Kind: promotion.QuantityTier, Priority: 1, Stackable: true, Tiers: []promotion.Tier{{MinQuantity: 1, Percent: 10}}},
+			},
+			discounts: []float64{33, 5.5, 1.5},
+			applied:   []float64{35, 5},
+		},
+		{
+			name: "Exclusive Promotion Skips Discounted Lines",
+			rules: []promotion.Rule{
+				{Kind: promotion.CategorySale, Priority: 10, Stackable: true, Categories: []string{"apparel"}, Percent: 50},
+				{Kind: promotion.QuantityTier, Priority: 1, Tiers: []promotion.Tier{{MinQuantity: 1, Percent: 10}}},
+			},
+			discounts: []float64{30, 5, 1.5},
+			applied:   []float64{35, 1.5},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			applied, discounts := promotion.Apply(tc.rules, lines)
+			require.Equal(t, tc.discounts, discounts)
+			require.Len(t, applied, len(tc.applied))
+			for i, discount := range tc.applied {
+				require.Equal(t, discount, applied[i].Discount)
+			}
+		})
+	}
+}
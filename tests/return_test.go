@@ -0,0 +1,290 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/rma"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateReturn(t *testing.T) {
+	orderId := uuid.New()
+	productId := uuid.New()
+	testCases := []struct {
+		name     string
+		body     gin.H
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"items": []gin.H{{"productId": productId, "quantity": 1, "reason": " Arrived damaged "}},
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateReturnTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateReturnTxParams) (db.ReturnTxResult, map[string]string, error, error) {
+						require.Equal(t, orderId, arg.OrderId)
+						require.Equal(t, testUserId, arg.UserId)
+						require.Equal(t, db.ReturnItemTxParams{Quantity: 1, Reason: "Arrived damaged"}, arg.Items[productId])
+						return db.ReturnTxResult{
+							Return: db.ReturnRequest{ID: arg.ID, OrderId: arg.OrderId, Status: db.ReturnStatusREQUESTED},
+							History: []db.ReturnEvent{
+								{ID: uuid.New(), ReturnId: arg.ID, ToStatus: db.ReturnStatusREQUESTED},
+							},
+						}, map[string]string{}, nil, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"fromStatus":""`)
+			},
+		},
+		{
+			name: "Order Not Shipped",
+			body: gin.H{
+				"items": []gin.H{{"productId": productId, "quantity": 1, "reason": "Wrong size"}},
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateReturnTx(gomock.Any(), gomock.Any()).
+					Return(db.ReturnTxResult{}, map[string]string{"status": "only shipped orders can be returned"}, nil, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "only shipped orders can be returned")
+			},
+		},
+		{
+			name: "Missing Reason",
+			body: gin.H{
+				"items": []gin.H{{"productId": productId, "quantity": 1, "reason": "  "}},
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateReturnTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/orders/%s/returns", orderId)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, false)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestUpdateReturnStatus(t *testing.T) {
+	returnId := uuid.New()
+	testCases := []struct {
+		name     string
+		action   string
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Approve",
+			action: "approve",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateReturnStatusTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.UpdateReturnStatusTxParams) (db.ReturnTxResult, error, error) {
+						require.Equal(t, db.ReturnStatusAPPROVED, arg.Status)
+						require.Equal(t, testUserId, arg.ActorId)
+						return db.ReturnTxResult{Return: db.ReturnRequest{ID: arg.ID, Status: arg.Status}}, nil, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Receive Refunds Return",
+			action: "receive",
+			stubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().
+						UpdateReturnStatusTx(gomock.Any(), gomock.Any()).
+						Return(db.ReturnTxResult{Return: db.ReturnRequest{ID: returnId, Status: db.ReturnStatusRECEIVED}}, nil, nil).
+						Times(1),
+					store.EXPECT().
+						UpdateReturnStatusTx(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ any, arg db.UpdateReturnStatusTxParams) (db.ReturnTxResult, error, error) {
+							// The return is claimed before the refund is issued
+							require.Equal(t, db.ReturnStatusREFUNDING, arg.Status)
+							require.False(t, arg.Release)
+							return db.ReturnTxResult{Return: db.ReturnRequest{ID: arg.ID, Status: arg.Status, RefundAmount: 45.5}}, nil, nil
+						}).
+						Times(1),
+					store.EXPECT().
+						UpdateReturnStatusTx(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ any, arg db.UpdateReturnStatusTxParams) (db.ReturnTxResult, error, error) {
+							require.Equal(t, db.ReturnStatusREFUNDED, arg.Status)
+							require.Equal(t, "manual-"+returnId.String(), arg.RefundReference)
+							return db.ReturnTxResult{Return: db.ReturnRequest{ID: arg.ID, Status: arg.Status}}, nil, nil
+						}).
+						Times(1),
+				)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), string(db.ReturnStatusREFUNDED))
+			},
+		},
+		{
+			name:   "Refund Already Claimed",
+			action: "refund",
+			stubs: func(store *mockdb.MockStore) {
+				// A concurrent refund claimed the return first, no refund is issued
+				store.EXPECT().
+					UpdateReturnStatusTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.UpdateReturnStatusTxParams) (db.ReturnTxResult, error, error) {
+						require.Equal(t, db.ReturnStatusREFUNDING, arg.Status)
+						return db.ReturnTxResult{}, rma.Transition(rma.Refunding, rma.Refunding), nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "Invalid Transition",
+			action: "approve",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateReturnStatusTx(gomock.Any(), gomock.Any()).
+					Return(db.ReturnTxResult{}, rma.Transition(rma.Refunded, rma.Approved), nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/admin/returns/%s/%s", returnId, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+type failingRefunder struct {
+	refunds []rma.Refund
+}
+
+func (r *failingRefunder) Refund(_ context.Context, refund rma.Refund) (string, error) {
+	r.refunds = append(r.refunds, refund)
+	return "", errors.New("payment provider unavailable")
+}
+
+func TestRefundReturnFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	returnId := uuid.New()
+	gomock.InOrder(
+		store.EXPECT().
+			UpdateReturnStatusTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, arg db.UpdateReturnStatusTxParams) (db.ReturnTxResult, error, error) {
+				require.Equal(t, db.ReturnStatusREFUNDING, arg.Status)
+				return db.ReturnTxResult{Return: db.ReturnRequest{ID: arg.ID, Status: arg.Status, RefundAmount: 20}}, nil, nil
+			}).
+			Times(1),
+		// The claim is released so the refund can be retried
+		store.EXPECT().
+			UpdateReturnStatusTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, arg db.UpdateReturnStatusTxParams) (db.ReturnTxResult, error, error) {
+				require.True(t, arg.Release)
+				return db.ReturnTxResult{Return: db.ReturnRequest{ID: arg.ID, Status: db.ReturnStatusRECEIVED}}, nil, nil
+			}).
+			Times(1),
+	)
+
+	refunder := &failingRefunder{}
+	returns := services.NewReturnService(store, refunder)
+	_, errMessage, statusCode, err := returns.RefundReturn(context.Background(), returnId, types.ReturnActionInput{})
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadGateway, statusCode)
+	require.NotEmpty(t, errMessage.Refund)
+	require.Len(t, refunder.refunds, 1)
+	require.Equal(t, returnId.String(), refunder.refunds[0].IdempotencyKey)
+	require.Equal(t, 20.0, refunder.refunds[0].Amount)
+}
+
+func TestReturnTransition(t *testing.T) {
+	require.NoError(t, rma.Transition(rma.Requested, rma.Approved))
+	require.NoError(t, rma.Transition(rma.Approved, rma.Rejected))
+	require.NoError(t, rma.Transition(rma.Received, rma.Refunding))
+	require.NoError(t, rma.Transition(rma.Refunding, rma.Refunded))
+	require.NoError(t, rma.Release(rma.Refunding))
+	require.True(t, errors.Is(rma.Transition(rma.Received, rma.Refunded), rma.ErrInvalidTransition))
+	require.True(t, errors.Is(rma.Transition(rma.Refunding, rma.Refunding), rma.ErrInvalidTransition))
+	require.True(t, errors.Is(rma.Release(rma.Refunded), rma.ErrInvalidTransition))
+	require.True(t, errors.Is(rma.Transition(rma.Requested, rma.Received), rma.ErrInvalidTransition))
+	require.True(t, errors.Is(rma.Transition(rma.Rejected, rma.Approved), rma.ErrInvalidTransition))
+	require.True(t, errors.Is(rma.Transition(rma.Refunded, rma.Refunded), rma.ErrInvalidTransition))
+}
+
+func TestReturnRefundAmount(t *testing.T) {
+	lines := []rma.Line{
+		{Price: 30, Quantity: 3, Returned: 1},
+		{Price: 50, Quantity: 1, Returned: 1},
+	}
+	require.Equal(t, 60.0, rma.RefundAmount(lines, 1))
+	// A 10% discount on the order is taken off the refund.
+	require.Equal(t, 54.0, rma.RefundAmount(lines, 0.9))
+	require.Equal(t, 0.0, rma.RefundAmount(nil, 1))
+}
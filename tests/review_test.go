@@ -0,0 +1,284 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreateReview(t *testing.T) {
+	productId := uuid.New()
+	product := db.GetOneProductRow{ID: productId, Name: "iPhone7"}
+
+	testCases := []struct {
+		name     string
+		body     gin.H
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success",
+			body: gin.H{"rating": 4, "title": " Solid phone ", "body": "Battery could be better"},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneProduct(gomock.Any(), productId).Return(product, nil).Times(1)
+				store.EXPECT().
+					HasPurchasedProduct(gomock.Any(), db.HasPurchasedProductParams{UserId: testUserId, ProductId: productId}).
+					Return(true, nil).
+					Times(1)
+				store.EXPECT().
+					CreateProductReview(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateProductReviewParams) (db.ProductReview, error) {
+						require.Equal(t, int32(4), arg.Rating)
+						require.Equal(t, "Solid phone", arg.Title)
+						require.Equal(t, testUserId, arg.UserId)
+						return db.ProductReview{ID: arg.ID, ProductId: arg.ProductId, Rating: arg.Rating, Status: db.ReviewStatusPENDING}, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Not Purchased",
+			body: gin.H{"rating": 4, "title": "Solid phone"},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneProduct(gomock.Any(), productId).Return(product, nil).Times(1)
+				store.EXPECT().HasPurchasedProduct(gomock.Any(), gomock.Any()).Return(false, nil).Times(1)
+				store.EXPECT().CreateProductReview(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "only customers with a completed order of the product can review it")
+			},
+		},
+		{
+			name: "Already Reviewed",
+			body: gin.H{"rating": 2, "title": "Changed my mind"},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneProduct(gomock.Any(), productId).Return(product, nil).Times(1)
+				store.EXPECT().HasPurchasedProduct(gomock.Any(), gomock.Any()).Return(true, nil).Times(1)
+				store.EXPECT().
+					CreateProductReview(gomock.Any(), gomock.Any()).
+					Return(db.ProductReview{}, &pgconn.PgError{Code: "23505"}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "Archived Product",
+			body: gin.H{"rating": 5, "title": "Great"},
+			stubs: func(store *mockdb.MockStore) {
+				archived := product
+				archived.DeletedAt = pgtype.Timestamp{Time: time.Now(), Valid: true}
+				store.EXPECT().GetOneProduct(gomock.Any(), productId).Return(archived, nil).Times(1)
+				store.EXPECT().HasPurchasedProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Rating",
+			body: gin.H{"rating": 6, "title": ""},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "rating must be between 1 and 5")
+				require.Contains(t, recorder.Body.String(), "title must be between 1 and 150 characters")
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/products/%s/reviews", productId)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, false)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestModerateReview(t *testing.T) {
+	reviewId := uuid.New()
+
+	testCases := []struct {
+		name     string
+		action   string
+		admin    bool
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Approve",
+			action: "approve",
+			admin:  true,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateProductReviewStatus(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.UpdateProductReviewStatusParams) (db.ProductReview, error) {
+						require.Equal(t, reviewId, arg.ID)
+						require.Equal(t, db.ReviewStatusAPPROVED, arg.Status)
+						require.Equal(t, pgtype.UUID{Bytes: testUserId, Valid: true}, arg.ModeratedBy)
+						return db.ProductReview{ID: reviewId, Status: arg.Status}, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Reject Unknown Review",
+			action: "reject",
+			admin:  true,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateProductReviewStatus(gomock.Any(), gomock.Any()).
+					Return(db.ProductReview{}, fmt.Errorf("no rows in result set")).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "Forbidden",
+			action: "approve",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateProductReviewStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/admin/reviews/%s/%s", reviewId, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, tc.admin)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestGetAllReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetAllProductReview(gomock.Any(), db.GetAllProductReviewParams{
+			Status:   db.NullReviewStatus{ReviewStatus: db.ReviewStatusPENDING, Valid: true},
+			PageSize: 50,
+		}).
+		Return([]db.ProductReview{}, nil).
+		Times(1)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/admin/reviews?status=pending", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+	server.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestListProductsByRating(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sort     string
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Rating",
+			sort: "rating",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAllProduct(gomock.Any(), gomock.Eq("rating")).
+					Return([]db.GetAllProductRow{{ID: uuid.New(), RatingAverage: 4.5, RatingCount: 2}}, nil).
+					Times(1)
+				store.EXPECT().GetProductImageByProductIds(gomock.Any(), gomock.Any()).Return([]db.ProductImage{}, nil).Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"ratingAverage":4.5`)
+				require.Contains(t, recorder.Body.String(), `"ratingCount":2`)
+			},
+		},
+		{
+			name: "Unknown Sort",
+			sort: "price",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAllProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "sort must be rating")
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/api/v1/products?sort="+tc.sort, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, false)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/pkg/token"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateShipment(t *testing.T) {
+	orderId := uuid.New()
+	productId := uuid.New()
+	testCases := []struct {
+		name     string
+		body     gin.H
+		auth     func(t *testing.T, req *http.Request, tokenCreator *token.JWT)
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Partial Shipment",
+			body: gin.H{
+				"carrier":        " DHL ",
+				"trackingNumber": "JD014600003828",
+				"items":          []gin.H{{"productId": productId, "quantity": 1}},
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, true)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateShipmentTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateShipmentTxParams) (db.ShipmentTxResult, map[string]string, error, error) {
+						require.Equal(t, orderId, arg.OrderId)
+						require.Equal(t, "DHL", arg.Carrier)
+						require.Equal(t, int32(1), arg.Items[productId])
+						return db.ShipmentTxResult{
+							Shipment: db.Shipment{ID: arg.ID, OrderId: arg.OrderId},
+							Order:    db.Order{ID: arg.OrderId, Status: db.OrderStatusPARTIALLYSHIPPED},
+						}, map[string]string{}, nil, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Order Not Completed",
+			body: gin.H{
+				"carrier": "UPS",
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, true)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateShipmentTx(gomock.Any(), gomock.Any()).
+					Return(db.ShipmentTxResult{}, map[string]string{"status": "only COMPLETED or PARTIALLY_SHIPPED orders can be shipped"}, nil, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "PARTIALLY_SHIPPED")
+			},
+		},
+		{
+			name: "Missing Carrier",
+			body: gin.H{
+				"trackingNumber": "1Z999AA10123456784",
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, true)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateShipmentTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			body: gin.H{
+				"carrier": "UPS",
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, false)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateShipmentTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/admin/orders/%s/shipments", orderId)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			tc.auth(t, request, server.TokenCreator())
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestGetOrderShipments(t *testing.T) {
+	orderId := uuid.New()
+	shipmentId := uuid.New()
+	testCases := []struct {
+		name     string
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Own Order",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrderById(gomock.Any(), gomock.Eq(orderId)).
+					Return(db.Order{ID: orderId, UserId: testUserId, Status: db.OrderStatusSHIPPED}, nil).
+					Times(1)
+				store.EXPECT().
+					GetShipmentByOrderId(gomock.Any(), gomock.Eq(orderId)).
+					Return([]db.Shipment{{ID: shipmentId, OrderId: orderId, Carrier: "DHL"}}, nil).
+					Times(1)
+				store.EXPECT().
+					GetShipmentItemByShipmentIds(gomock.Any(), gomock.Eq([]uuid.UUID{shipmentId})).
+					Return([]db.ShipmentItem{{ID: uuid.New(), ShipmentId: shipmentId, Quantity: 2}}, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var body struct {
+					Data []struct {
+						ID    uuid.UUID         `json:"id"`
+						Items []db.ShipmentItem `json:"items"`
+					} `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.Len(t, body.Data, 1)
+				require.Equal(t, shipmentId, body.Data[0].ID)
+				require.Len(t, body.Data[0].Items, 1)
+			},
+		},
+		{
+			name: "Another User's Order",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetOrderById(gomock.Any(), gomock.Eq(orderId)).
+					Return(db.Order{ID: orderId, UserId: uuid.New()}, nil).
+					Times(1)
+				store.EXPECT().
+					GetShipmentByOrderId(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/orders/%s/shipments", orderId)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, false)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
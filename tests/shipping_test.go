@@ -0,0 +1,190 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/shipping"
+	"github.com/slamchillz/getinstashop-ecommerce-api/pkg/token"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestQuoteShipping(t *testing.T) {
+	productId := uuid.New()
+	testCases := []struct {
+		name     string
+		body     gin.H
+		auth     func(t *testing.T, req *http.Request, tokenCreator *token.JWT)
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success",
+			body: gin.H{
+				"items":   []gin.H{{"productId": productId, "quantity": 2}},
+				"address": gin.H{"country": "us", "state": "ca"},
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, false)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					QuoteShipping(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.QuoteShippingParams) (db.ShippingQuote, map[string]string, error) {
+						require.Equal(t, "US-CA", arg.Region)
+						require.Equal(t, int32(2), arg.Items[productId])
+						return db.ShippingQuote{Region: arg.Region, Rates: []db.ShippingRate{}}, map[string]string{}, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Address",
+			body: gin.H{
+				"items":   []gin.H{{"productId": productId, "quantity": 1}},
+				"address": gin.H{"country": "United States"},
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, false)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					QuoteShipping(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "No Zone For Region",
+			body: gin.H{
+				"items":   []gin.H{{"productId": productId, "quantity": 1}},
+				"address": gin.H{"country": "FR"},
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, false)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					QuoteShipping(gomock.Any(), gomock.Any()).
+					Return(db.ShippingQuote{}, map[string]string{"region": shipping.ErrNoZone.Error()}, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), shipping.ErrNoZone.Error())
+			},
+		},
+		{
+			name: "Unauthorized",
+			body: gin.H{
+				"items":   []gin.H{{"productId": productId, "quantity": 1}},
+				"address": gin.H{"country": "US"},
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					QuoteShipping(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/api/v1/shipping/quote"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			tc.auth(t, request, server.TokenCreator())
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestShippingCost(t *testing.T) {
+	basket := shipping.Basket{Subtotal: 80, Weight: 2.5}
+	testCases := []struct {
+		name   string
+		method shipping.Method
+		basket shipping.Basket
+		cost   float64
+	}{
+		{
+			name:   "Flat",
+			method: shipping.Method{RateType: shipping.Flat, Rate: 4.99},
+			basket: basket,
+			cost:   4.99,
+		},
+		{
+			name:   "Weight Based",
+			method: shipping.Method{RateType: shipping.Weight, Rate: 3, RatePerKg: 1.5},
+			basket: basket,
+			cost:   6.75,
+		},
+		{
+			name:   "Below Free Threshold",
+			method: shipping.Method{RateType: shipping.FreeOver, Rate: 5, FreeOver: 100},
+			basket: basket,
+			cost:   5,
+		},
+		{
+			name:   "Free Over Threshold",
+			method: shipping.Method{RateType: shipping.FreeOver, Rate: 5, FreeOver: 100},
+			basket: shipping.Basket{Subtotal: 100, Weight: 2.5},
+			cost:   0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.cost, shipping.Cost(tc.method, tc.basket))
+		})
+	}
+}
+
+func TestMatchShippingZone(t *testing.T) {
+	world := shipping.Zone{ID: uuid.New(), Name: "Rest of World"}
+	us := shipping.Zone{ID: uuid.New(), Name: "United States", Regions: []string{"US"}}
+	california := shipping.Zone{ID: uuid.New(), Name: "California", Regions: []string{"US-CA"}}
+
+	zone, err := shipping.MatchZone([]shipping.Zone{world, us, california}, "US-CA")
+	require.NoError(t, err)
+	require.Equal(t, california.ID, zone.ID)
+
+	zone, err = shipping.MatchZone([]shipping.Zone{world, us, california}, "US-NY")
+	require.NoError(t, err)
+	require.Equal(t, us.ID, zone.ID)
+
+	zone, err = shipping.MatchZone([]shipping.Zone{world, us, california}, "GB")
+	require.NoError(t, err)
+	require.Equal(t, world.ID, zone.ID)
+
+	_, err = shipping.MatchZone([]shipping.Zone{us, california}, "GB")
+	require.ErrorIs(t, err, shipping.ErrNoZone)
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/storage"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	ctx := context.Background()
+	blobs := storage.NewLocalStore(t.TempDir(), "/api/v1/media")
+	content := []byte("image bytes")
+
+	require.NoError(t, blobs.Put(ctx, "products/a/b.png", "image/png", bytes.NewReader(content), int64(len(content))))
+	body, object, err := blobs.Get(ctx, "products/a/b.png")
+	require.NoError(t, err)
+	stored, err := io.ReadAll(body)
+	require.NoError(t, body.Close())
+	require.NoError(t, err)
+	require.Equal(t, content, stored)
+	require.Equal(t, "image/png", object.ContentType)
+	require.Equal(t, int64(len(content)), object.Size)
+	require.Equal(t, "/api/v1/media/products/a/b.png", blobs.URL("products/a/b.png"))
+
+	require.NoError(t, blobs.Delete(ctx, "products/a/b.png"))
+	require.NoError(t, blobs.Delete(ctx, "products/a/b.png"))
+	_, _, err = blobs.Get(ctx, "products/a/b.png")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	_, _, err = blobs.Get(ctx, "../etc/passwd")
+	require.ErrorIs(t, err, storage.ErrInvalidKey)
+	require.ErrorIs(t, blobs.Put(ctx, "products/../../x", "image/png", bytes.NewReader(content), int64(len(content))), storage.ErrInvalidKey)
+}
+
+func TestS3BlobStore(t *testing.T) {
+	ctx := context.Background()
+	objects := map[string][]byte{}
+	s3 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		require.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=minio/"))
+		require.Contains(t, authorization, "/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=")
+		require.Equal(t, "UNSIGNED-PAYLOAD", r.Header.Get("X-Amz-Content-Sha256"))
+		switch r.Method {
+		case http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			objects[r.URL.Path] = body
+		case http.MethodGet:
+			body, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "image/png")
+			w.Header().Set("ETag", `"abc"`)
+			_, _ = w.Write(body)
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer s3.Close()
+
+	blobs, err := storage.NewS3Store(storage.S3Config{
+		Endpoint:        s3.URL,
+		Bucket:          "media",
+		AccessKeyID:     "minio",
+		SecretAccessKey: "minio123",
+		PathStyle:       true,
+	})
+	require.NoError(t, err)
+	content := []byte("image bytes")
+
+	require.NoError(t, blobs.Put(ctx, "products/a/b.png", "image/png", bytes.NewReader(content), int64(len(content))))
+	require.Equal(t, content, objects["/media/products/a/b.png"])
+	body, object, err := blobs.Get(ctx, "products/a/b.png")
+	require.NoError(t, err)
+	stored, err := io.ReadAll(body)
+	require.NoError(t, body.Close())
+	require.NoError(t, err)
+	require.Equal(t, content, stored)
+	require.Equal(t, `"abc"`, object.ETag)
+	require.Equal(t, s3.URL+"/media/products/a/b.png", blobs.URL("products/a/b.png"))
+
+	require.NoError(t, blobs.Delete(ctx, "products/a/b.png"))
+	_, _, err = blobs.Get(ctx, "products/a/b.png")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
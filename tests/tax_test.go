@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/tax"
+	"github.com/slamchillz/getinstashop-ecommerce-api/pkg/token"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateTaxRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     gin.H
+		auth     func(t *testing.T, req *http.Request, tokenCreator *token.JWT)
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success",
+			body: gin.H{
+				"name":      "California Sales Tax",
+				"taxClass":  "standard",
+				"region":    "us-ca",
+				"rate":      7.25,
+				"inclusive": false,
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, true)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateTaxRule(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateTaxRuleParams) (db.TaxRule, error) {
+						require.Equal(t, "US-CA", arg.Region)
+						require.Equal(t, 7.25, arg.Rate)
+						return db.TaxRule{ID: arg.ID, Name: arg.Name}, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Rate",
+			body: gin.H{
+				"name":     "VAT",
+				"taxClass": "standard",
+				"rate":     120,
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, true)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateTaxRule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			body: gin.H{
+				"name":     "VAT",
+				"taxClass": "standard",
+				"rate":     20,
+			},
+			auth: func(t *testing.T, req *http.Request, tokenCreator *token.JWT) {
+				addAuthorization(t, req, tokenCreator, testUserId, false)
+			},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateTaxRule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/api/v1/admin/tax-rules"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			tc.auth(t, request, server.TokenCreator())
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestTaxCalculate(t *testing.T) {
+	vat := tax.Rule{ID: uuid.New(), Name: "VAT", TaxClass: tax.DefaultClass, Region: "GB", Rate: 20, Inclusive: true}
+	federal := tax.Rule{ID: uuid.New(), Name: "Federal", TaxClass: tax.DefaultClass, Rate: 5}
+	state := tax.Rule{ID: uuid.New(), Name: "State", TaxClass: tax.DefaultClass, Region: "US-CA", Rate: 7.5}
+	reduced := tax.Rule{ID: uuid.New(), Name: "Reduced", TaxClass: "reduced", Region: "US-CA", Rate: 2}
+	rules := []tax.Rule{vat, federal, state, reduced}
+
+	testCases := []struct {
+		name      string
+		region    string
+		lines     []tax.Line
+		tax       float64
+		exclusive float64
+	}{
+		{
+			name:      "Inclusive",
+			region:    "GB",
+			lines:     []tax.Line{{TaxClass: tax.DefaultClass, Amount: 120}},
+			tax:       20,
+			exclusive: 0,
+		},
+		{
+			name:      "Most Specific Region Wins",
+			region:    "US-CA",
+			lines:     []tax.Line{{TaxClass: tax.DefaultClass, Amount: 100}},
+			tax:       7.5,
+			exclusive: 7.5,
+		},
+		{
+			name:      "Fallback To Global Rule",
+			region:    "US-NY",
+			lines:     []tax.Line{{TaxClass: tax.DefaultClass, Amount: 100}},
+			tax:       5,
+			exclusive: 5,
+		},
+		{
+			name:   "Per Tax Class",
+			region: "US-CA",
+			lines: []tax.Line{
+				{TaxClass: tax.DefaultClass, Amount: 100},
+				{TaxClass: "reduced", Amount: 50},
+			},
+			tax:       8.5,
+			exclusive: 8.5,
+		},
+		{
+			name:   "Untaxed Class",
+			region: "US-CA",
+			lines:  []tax.Line{{TaxClass: "exempt", Amount: 100}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := tax.Calculate(rules, tc.region, tc.lines)
+			require.Equal(t, tc.tax, result.Tax)
+			require.Equal(t, tc.exclusive, result.Exclusive)
+			require.Len(t, result.Lines, len(tc.lines))
+		})
+	}
+}
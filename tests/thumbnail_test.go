@@ -0,0 +1,167 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/thumbnail"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/webp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	xwebp "golang.org/x/image/webp"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func gradient(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x * 7), G: uint8(y * 3), B: uint8(x ^ y), A: uint8(255 - x%64)})
+		}
+	}
+	return img
+}
+
+func TestWebpEncode(t *testing.T) {
+	for _, size := range []image.Point{{1, 1}, {17, 9}, {64, 48}, {300, 7}} {
+		t.Run(fmt.Sprintf("%dx%d", size.X, size.Y), func(t *testing.T) {
+			img := gradient(size.X, size.Y)
+			var buf bytes.Buffer
+			require.NoError(t, webp.Encode(&buf, img))
+
+			decoded, err := xwebp.Decode(&buf)
+			require.NoError(t, err)
+			require.Equal(t, img.Bounds(), decoded.Bounds())
+			// Lossless, so every pixel comes back unchanged
+			for y := 0; y < size.Y; y++ {
+				for x := 0; x < size.X; x++ {
+					require.Equal(t, img.NRGBAAt(x, y), color.NRGBAModel.Convert(decoded.At(x, y)))
+				}
+			}
+		})
+	}
+}
+
+func TestThumbnailSizes(t *testing.T) {
+	sizes, err := thumbnail.ParseSizes("large:1024, small:160")
+	require.NoError(t, err)
+	require.Equal(t, []thumbnail.Size{{Name: "small", MaxDimension: 160}, {Name: "large", MaxDimension: 1024}}, sizes)
+
+	sizes, err = thumbnail.ParseSizes("")
+	require.NoError(t, err)
+	require.Equal(t, thumbnail.DefaultSizes, sizes)
+
+	for _, spec := range []string{"small", "small:0", "small:abc", "small:160,small:320", "../x:10"} {
+		_, err = thumbnail.ParseSizes(spec)
+		require.Error(t, err, spec)
+	}
+
+	medium := thumbnail.Size{Name: "medium", MaxDimension: 480}
+	require.Equal(t, image.Rect(0, 0, 480, 240), thumbnail.Resize(gradient(800, 400), medium).Bounds())
+	require.Equal(t, image.Rect(0, 0, 120, 480), thumbnail.Resize(gradient(300, 1200), medium).Bounds())
+	// Images are never enlarged
+	require.Equal(t, image.Rect(0, 0, 100, 50), thumbnail.Resize(gradient(100, 50), medium).Bounds())
+}
+
+func TestGetProductImageThumbnail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	// Upload an original through the API so it lands in the server's blob store
+	var original db.ProductImage
+	var content bytes.Buffer
+	require.NoError(t, png.Encode(&content, gradient(800, 400)))
+	store.EXPECT().
+		CreateProductImageTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.CreateProductImageTxParams) (db.ProductImage, map[string]string, error, error) {
+			original = db.ProductImage{
+				ID:          arg.ID,
+				ProductId:   arg.ProductId,
+				Key:         arg.Key,
+				ContentType: arg.ContentType,
+				Size:        arg.Size,
+				IsPrimary:   true,
+			}
+			return original, map[string]string{}, nil, nil
+		}).
+		Times(1)
+	body, contentType := multipartImage(t, content.Bytes(), true)
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/admin/products/%s/images", uuid.New()), body)
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", contentType)
+	addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+	recorder := httptest.NewRecorder()
+	server.Router().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusCreated, recorder.Code)
+	require.Contains(t, recorder.Body.String(), fmt.Sprintf("/api/v1/images/%s/medium.webp", original.ID))
+
+	missing := uuid.New()
+	store.EXPECT().
+		GetOneProductImage(gomock.Any(), gomock.Eq(original.ID)).
+		Return(original, nil).
+		AnyTimes()
+	store.EXPECT().
+		GetOneProductImage(gomock.Any(), gomock.Eq(missing)).
+		Return(db.ProductImage{}, pgx.ErrNoRows).
+		AnyTimes()
+
+	get := func(url, ifNoneMatch string) *httptest.ResponseRecorder {
+		request, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		if ifNoneMatch != "" {
+			request.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		recorder := httptest.NewRecorder()
+		server.Router().ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	for _, tc := range []struct {
+		variant     string
+		contentType string
+		decode      func(r *bytes.Buffer) (image.Image, error)
+	}{
+		{variant: "medium.webp", contentType: "image/webp", decode: func(r *bytes.Buffer) (image.Image, error) { return xwebp.Decode(r) }},
+		{variant: "small.png", contentType: "image/png", decode: func(r *bytes.Buffer) (image.Image, error) { return png.Decode(r) }},
+	} {
+		t.Run(tc.variant, func(t *testing.T) {
+			url := fmt.Sprintf("/api/v1/images/%s/%s", original.ID, tc.variant)
+			recorder := get(url, "")
+			require.Equal(t, http.StatusOK, recorder.Code)
+			require.Equal(t, tc.contentType, recorder.Header().Get("Content-Type"))
+			require.NotEmpty(t, recorder.Header().Get("Cache-Control"))
+			etag := recorder.Header().Get("ETag")
+			require.NotEmpty(t, etag)
+			img, err := tc.decode(recorder.Body)
+			require.NoError(t, err)
+			require.LessOrEqual(t, img.Bounds().Dx(), 480)
+			require.Equal(t, img.Bounds().Dx(), 2*img.Bounds().Dy())
+
+			// The cached thumbnail is served again and can be revalidated
+			recorder = get(url, etag)
+			require.Equal(t, http.StatusNotModified, recorder.Code)
+			require.Empty(t, recorder.Body.Bytes())
+			require.Equal(t, etag, recorder.Header().Get("ETag"))
+		})
+	}
+
+	for name, url := range map[string]string{
+		"Unknown Size":   fmt.Sprintf("/api/v1/images/%s/huge.webp", original.ID),
+		"Unknown Format": fmt.Sprintf("/api/v1/images/%s/small.jpg", original.ID),
+		"Unknown Image":  fmt.Sprintf("/api/v1/images/%s/small.webp", missing),
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, http.StatusNotFound, get(url, "").Code)
+		})
+	}
+}
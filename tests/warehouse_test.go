@@ -0,0 +1,326 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/fulfilment"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestAllocate(t *testing.T) {
+	north, south, east := uuid.New(), uuid.New(), uuid.New()
+	shirt, mug := uuid.New(), uuid.New()
+
+	testCases := []struct {
+		name        string
+		lines       []fulfilment.Line
+		stock       []fulfilment.Stock
+		allocations []fulfilment.Allocation
+		err         bool
+	}{
+		{
+			name:  "First Warehouse Holding The Whole Order",
+			lines: []fulfilment.Line{{ProductId: shirt, Quantity: 2}, {ProductId: mug, Quantity: 1}},
+			stock: []fulfilment.Stock{
+				{WarehouseId: north, ProductId: shirt, Stock: 5},
+				{WarehouseId: south, ProductId: shirt, Stock: 5},
+				{WarehouseId: south, ProductId: mug, Stock: 5},
+				{WarehouseId: east, ProductId: shirt, Stock: 5},
+				{WarehouseId: east, ProductId: mug, Stock: 5},
+			},
+			allocations: []fulfilment.Allocation{
+				{WarehouseId: south, ProductId: shirt, Quantity: 2},
+				{WarehouseId: south, ProductId: mug, Quantity: 1},
+			},
+		},
+		{
+			name:  "Lines From Different Warehouses",
+			lines: []fulfilment.Line{{ProductId: shirt, Quantity: 2}, {ProductId: mug, Quantity: 1}},
+			stock: []fulfilment.Stock{
+				{WarehouseId: north, ProductId: shirt, Stock: 5},
+				{WarehouseId: south, ProductId: mug, Stock: 5},
+			},
+			allocations: []fulfilment.Allocation{
+				{WarehouseId: north, ProductId: shirt, Quantity: 2},
+				{WarehouseId: south, ProductId: mug, Quantity: 1},
+			},
+		},
+		{
+			name:  "Split Line",
+			lines: []fulfilment.Line{{ProductId: shirt, Quantity: 7}},
+			stock: []fulfilment.Stock{
+				{WarehouseId: north, ProductId: shirt, Stock: 3},
+				{WarehouseId: south, ProductId: shirt, Stock: 2},
+				{WarehouseId: east, ProductId: shirt, Stock: 4},
+			},
+			allocations: []fulfilment.Allocation{
+				{WarehouseId: north, ProductId: shirt, Quantity: 3},
+				{WarehouseId: south, ProductId: shirt, Quantity: 2},
+				{WarehouseId: east, ProductId: shirt, Quantity: 2},
+			},
+		},
+		{
+			name:  "Not Enough Stock",
+			lines: []fulfilment.Line{{ProductId: shirt, Quantity: 6}},
+			stock: []fulfilment.Stock{
+				{WarehouseId: north, ProductId: shirt, Stock: 3},
+				{WarehouseId: south, ProductId: shirt, Stock: 2},
+			},
+			err: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			allocations, err := fulfilment.Allocate(tc.lines, tc.stock)
+			if tc.err {
+				var insufficient *fulfilment.InsufficientStockError
+				require.ErrorAs(t, err, &insufficient)
+				require.Equal(t, shirt, insufficient.ProductId)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.allocations, allocations)
+		})
+	}
+}
+
+func TestTransferStock(t *testing.T) {
+	productId, fromId, toId := uuid.New(), uuid.New(), uuid.New()
+
+	testCases := []struct {
+		name     string
+		body     gin.H
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success",
+			body: gin.H{"productId": productId, "fromWarehouseId": fromId, "toWarehouseId": toId, "quantity": 4, "note": " Rebalance "},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneWarehouse(gomock.Any(), fromId).Return(db.Warehouse{ID: fromId}, nil).Times(1)
+				store.EXPECT().GetOneWarehouse(gomock.Any(), toId).Return(db.Warehouse{ID: toId}, nil).Times(1)
+				store.EXPECT().
+					TransferStockTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.TransferStockTxParams) (db.StockTransfer, error, error) {
+						require.Equal(t, int32(4), arg.Quantity)
+						require.Equal(t, "Rebalance", arg.Note)
+						require.Equal(t, testUserId, arg.ActorId)
+						return db.StockTransfer{ID: uuid.New(), ProductId: arg.ProductId, Quantity: arg.Quantity}, nil, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Same Warehouse",
+			body: gin.H{"productId": productId, "fromWarehouseId": fromId, "toWarehouseId": fromId, "quantity": 4},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().TransferStockTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "toWarehouseId must not be fromWarehouseId")
+			},
+		},
+		{
+			name: "Not Enough Stock",
+			body: gin.H{"productId": productId, "fromWarehouseId": fromId, "toWarehouseId": toId, "quantity": 40},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneWarehouse(gomock.Any(), gomock.Any()).Return(db.Warehouse{}, nil).Times(2)
+				store.EXPECT().
+					TransferStockTx(gomock.Any(), gomock.Any()).
+					Return(db.StockTransfer{}, fmt.Errorf("take stock: %w", fulfilment.ErrNotEnoughStock), nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "quantity more than the warehouse holds")
+			},
+		},
+		{
+			name: "Warehouse Not Found",
+			body: gin.H{"productId": productId, "fromWarehouseId": fromId, "toWarehouseId": toId, "quantity": 4},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneWarehouse(gomock.Any(), fromId).Return(db.Warehouse{}, fmt.Errorf("no rows in result set")).Times(1)
+				store.EXPECT().TransferStockTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/v1/admin/stock-transfers", bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestSetWarehouseStock(t *testing.T) {
+	warehouseId, productId := uuid.New(), uuid.New()
+
+	testCases := []struct {
+		name     string
+		body     gin.H
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Success",
+			body: gin.H{"stock": 12, "note": "Cycle count"},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneWarehouse(gomock.Any(), warehouseId).Return(db.Warehouse{ID: warehouseId}, nil).Times(1)
+				store.EXPECT().
+					SetWarehouseStockTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.SetWarehouseStockTxParams) (db.Product, error, error) {
+						require.Equal(t, warehouseId, arg.WarehouseId)
+						require.Equal(t, productId, arg.ProductId)
+						require.Equal(t, int32(12), arg.Stock)
+						require.Equal(t, "Cycle count", arg.Note)
+						return db.Product{ID: productId, Stock: 20}, nil, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Missing Stock",
+			body: gin.H{"note": "Cycle count"},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetWarehouseStockTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "stock is required")
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/admin/warehouses/%s/stock/%s", warehouseId, productId)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+// TestStockLockOrder checks that placing and cancelling orders lock the
+// products before any of their warehouse stock, in the lock order of execTx.
+func TestStockLockOrder(t *testing.T) {
+	orderId := uuid.New()
+	shirt := db.Product{ID: uuid.New(), Price: 10, Stock: 5}
+	mug := db.Product{ID: uuid.New(), Price: 8, Stock: 5}
+	testCases := []struct {
+		name string
+		run  func(t *testing.T, store db.Store)
+	}{
+		{
+			name: "Place",
+			run: func(t *testing.T, store db.Store) {
+				_, _, execErr, txErr := store.CreateOrderTx(context.Background(), db.CreateOrderTxParams{
+					ID:         orderId,
+					UserId:     testUserId,
+					ProductIds: []uuid.UUID{shirt.ID, mug.ID},
+					Items:      map[uuid.UUID]int32{shirt.ID: 1, mug.ID: 2},
+				})
+				require.NoError(t, execErr)
+				require.NoError(t, txErr)
+			},
+		},
+		{
+			name: "Cancel",
+			run: func(t *testing.T, store db.Store) {
+				_, err := store.UpdateOrderTx(context.Background(), db.UpdateOrderTxParams{
+					ID:     orderId,
+					UserId: testUserId,
+					Status: db.OrderStatusCANCELLED,
+				})
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			warehouseId := uuid.New()
+			fake := stockedFakeDB(warehouseId, shirt, mug)
+			fake.on("GetOrderForUpdate", func(args []any) (any, error) {
+				return db.Order{ID: orderId, UserId: testUserId, Status: db.OrderStatusPENDING}, nil
+			})
+			fake.on("GetOrderAllocations", func(args []any) (any, error) {
+				return []db.GetOrderAllocationsRow{
+					{ProductId: shirt.ID, WarehouseId: warehouseId, Quantity: 1},
+					{ProductId: mug.ID, WarehouseId: warehouseId, Quantity: 2},
+				}, nil
+			})
+			fake.on("CancelOrder", func(args []any) (any, error) {
+				return db.Order{ID: orderId, UserId: testUserId, Status: db.OrderStatusCANCELLED}, nil
+			})
+			tc.run(t, db.NewStore(fake))
+			require.True(t, fake.committed)
+
+			names := fake.names()
+			locks := fake.called("GetProductIdsForUpdate")
+			require.Len(t, locks, 1)
+			require.ElementsMatch(t, []uuid.UUID{shirt.ID, mug.ID}, locks[0].Args[0])
+			locked := slices.Index(names, "GetProductIdsForUpdate")
+			if order := slices.Index(names, "GetOrderForUpdate"); order >= 0 {
+				require.Less(t, order, locked)
+			}
+			stockCalls := 0
+			for i, name := range names {
+				switch name {
+				case "GetAllocatableStock", "GetWarehouseStockForUpdate", "UpdateProductStock":
+					stockCalls++
+					require.Greater(t, i, locked, "%s ran before the products were locked", name)
+				}
+			}
+			require.NotZero(t, stockCalls)
+		})
+	}
+}
@@ -0,0 +1,359 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/jobs"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/webhook"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookSignature(t *testing.T) {
+	secret, err := webhook.NewSecret()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(secret, "whsec_"))
+
+	body := []byte(`{"type":"order.created"}`)
+	signature := webhook.Sign(secret, 1700000000, body)
+	require.True(t, strings.HasPrefix(signature, "sha256="))
+	require.True(t, webhook.Verify(secret, 1700000000, body, signature))
+	require.False(t, webhook.Verify(secret, 1700000001, body, signature))
+	require.False(t, webhook.Verify(secret, 1700000000, []byte(`{"type":"order.updated"}`), signature))
+	require.False(t, webhook.Verify("another secret", 1700000000, body, signature))
+}
+
+func TestWebhookAPI(t *testing.T) {
+	hook := db.Webhook{
+		ID:     uuid.New(),
+		Url:    "https://example.com/hooks",
+		Secret: "whsec_0123456789abcdef",
+		Events: []string{db.EventOrderCreated},
+		Active: true,
+	}
+	delivery := db.WebhookDelivery{
+		ID:        uuid.New(),
+		WebhookId: hook.ID,
+		EventId:   uuid.New(),
+		EventType: db.EventOrderCreated,
+		Status:    db.WebhookDeliveryStatusFAILED,
+	}
+	testCases := []struct {
+		name     string
+		method   string
+		url      string
+		body     string
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Create",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/webhooks",
+			body:   `{"url":" https://example.com/hooks ","events":["order.created","product.stock_low"]}`,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWebhook(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateWebhookParams) (db.Webhook, error) {
+						require.Equal(t, "https://example.com/hooks", arg.Url)
+						require.Equal(t, []string{db.EventOrderCreated, db.EventProductStockLow}, arg.Events)
+						require.True(t, arg.Active)
+						require.Equal(t, testUserId, arg.CreatedBy)
+						// A secret is generated when none is given
+						require.True(t, strings.HasPrefix(arg.Secret, "whsec_"))
+						return db.Webhook{ID: arg.ID, Url: arg.Url, Secret: arg.Secret, Events: arg.Events, Active: arg.Active}, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"secret":"whsec_`)
+			},
+		},
+		{
+			name:   "Create Invalid",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/webhooks",
+			body:   `{"url":"ftp://example.com","secret":"short","events":["order.created","order.created"]}`,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "url must be an absolute http or https URL")
+				require.Contains(t, recorder.Body.String(), "secret must be between 16 and 255 characters")
+				require.Contains(t, recorder.Body.String(), "events cannot contain order.created more than once")
+			},
+		},
+		{
+			name:   "Create Unknown Event",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/webhooks",
+			body:   `{"url":"https://example.com/hooks","events":["order.deleted"]}`,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "events must be among")
+			},
+		},
+		{
+			name:   "Update",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/api/v1/admin/webhooks/%s", hook.ID),
+			body:   `{"active":false}`,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateWebhookTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.UpdateWebhookTxParams) (db.Webhook, error, error) {
+						require.Nil(t, arg.Url)
+						require.Nil(t, arg.Events)
+						require.False(t, *arg.Active)
+						updated := hook
+						updated.Active = false
+						return updated, nil, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"active":false`)
+			},
+		},
+		{
+			name:   "Update Missing",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/api/v1/admin/webhooks/%s", uuid.New()),
+			body:   `{"events":["order.status_changed"]}`,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateWebhookTx(gomock.Any(), gomock.Any()).Return(db.Webhook{}, pgx.ErrNoRows, nil).Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "Delete Missing",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/api/v1/admin/webhooks/%s", uuid.New()),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteOneWebhook(gomock.Any(), gomock.Any()).Return(db.Webhook{}, pgx.ErrNoRows).Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "Deliveries",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/api/v1/admin/webhooks/%s/deliveries?limit=20", hook.ID),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneWebhook(gomock.Any(), gomock.Eq(hook.ID)).Return(hook, nil).Times(1)
+				store.EXPECT().
+					GetWebhookDeliveries(gomock.Any(), gomock.Eq(db.GetWebhookDeliveriesParams{WebhookId: hook.ID, PageSize: 20})).
+					Return([]db.WebhookDelivery{delivery}, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), delivery.ID.String())
+			},
+		},
+		{
+			name:   "Deliveries Of Missing Webhook",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/api/v1/admin/webhooks/%s/deliveries", hook.ID),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOneWebhook(gomock.Any(), gomock.Eq(hook.ID)).Return(db.Webhook{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().GetWebhookDeliveries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "Redeliver",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/api/v1/admin/webhook-deliveries/%s/redeliver", delivery.ID),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RedeliverWebhookTx(gomock.Any(), gomock.Eq(delivery.ID)).
+					Return(db.WebhookDelivery{ID: uuid.New(), WebhookId: hook.ID, EventId: delivery.EventId, Status: db.WebhookDeliveryStatusPENDING}, nil, nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), delivery.EventId.String())
+			},
+		},
+		{
+			name:   "Redeliver Missing",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/api/v1/admin/webhook-deliveries/%s/redeliver", uuid.New()),
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RedeliverWebhookTx(gomock.Any(), gomock.Any()).Return(db.WebhookDelivery{}, pgx.ErrNoRows, nil).Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, true)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestDeliverWebhook(t *testing.T) {
+	const secret = "whsec_0123456789abcdef"
+	var status int
+	var received webhook.Event
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhook.TimestampHeader), 10, 64)
+		if !webhook.Verify(secret, timestamp, body, r.Header.Get(webhook.SignatureHeader)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		require.NoError(t, json.Unmarshal(body, &received))
+		require.Equal(t, received.Type, r.Header.Get(webhook.EventHeader))
+		w.WriteHeader(status)
+		w.Write([]byte("thanks"))
+	}))
+	defer receiver.Close()
+
+	target := db.GetUndeliveredWebhookDeliveryRow{
+		ID:             uuid.New(),
+		EventId:        uuid.New(),
+		EventType:      db.EventOrderCreated,
+		Url:            receiver.URL,
+		Secret:         secret,
+		Active:         true,
+		Payload:        []byte(`{"order":{"total":20}}`),
+		EventCreatedAt: pgtype.Timestamp{Time: time.Now().UTC(), Valid: true},
+	}
+	payload := []byte(fmt.Sprintf(`{"deliveryId":%q}`, target.ID))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUndeliveredWebhookDelivery(gomock.Any(), gomock.Eq(target.ID)).Return(target, nil).AnyTimes()
+	webhooks := services.NewWebhookService(store)
+
+	// Delivered
+	status = http.StatusOK
+	store.EXPECT().
+		RecordWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.RecordWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+			require.Equal(t, db.WebhookDeliveryStatusSUCCEEDED, arg.Status)
+			require.Equal(t, int32(http.StatusOK), arg.ResponseStatus)
+			require.Equal(t, "thanks", arg.ResponseBody)
+			require.True(t, arg.DeliveredAt.Valid)
+			return db.WebhookDelivery{}, nil
+		}).
+		Times(1)
+	require.NoError(t, webhooks.RunDeliverJob(context.Background(), payload))
+	require.Equal(t, target.EventId.String(), received.ID)
+	require.JSONEq(t, `{"order":{"total":20}}`, string(received.Data))
+
+	// Rejected, so retried
+	status = http.StatusServiceUnavailable
+	store.EXPECT().
+		RecordWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.RecordWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+			require.Equal(t, db.WebhookDeliveryStatusPENDING, arg.Status)
+			require.Equal(t, int32(http.StatusServiceUnavailable), arg.ResponseStatus)
+			require.Equal(t, "webhook answered with status 503", arg.LastError)
+			return db.WebhookDelivery{}, nil
+		}).
+		Times(1)
+	require.Error(t, webhooks.RunDeliverJob(context.Background(), payload))
+
+	// Rejected on the last attempt, so failed for good
+	done := make(chan struct{})
+	store.EXPECT().
+		ClaimJobs(gomock.Any(), gomock.Any()).
+		Return([]db.Job{{ID: uuid.New(), Kind: db.DeliverWebhookJob, Payload: payload, Attempts: 8, MaxAttempts: 8}}, nil).
+		Times(1)
+	store.EXPECT().ClaimJobs(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	store.EXPECT().RequeueStaleJobs(gomock.Any(), gomock.Any()).Return(int64(0), nil).AnyTimes()
+	store.EXPECT().
+		RecordWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.RecordWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+			require.Equal(t, db.WebhookDeliveryStatusFAILED, arg.Status)
+			return db.WebhookDelivery{}, nil
+		}).
+		Times(1)
+	store.EXPECT().
+		KillJob(gomock.Any(), gomock.Any()).
+		Do(func(_ any, _ db.KillJobParams) { close(done) }).
+		Times(1)
+	pool := jobs.NewPool(store, jobs.Config{Workers: 1, PollInterval: 10 * time.Millisecond})
+	services.RegisterJobHandlers(pool, store, nil, nil, nil, nil)
+	pool.Start()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("delivery job was not run")
+	}
+	require.NoError(t, pool.Shutdown(context.Background()))
+}
+
+func TestDeliverWebhookDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	deliveryId := uuid.New()
+	store.EXPECT().
+		GetUndeliveredWebhookDelivery(gomock.Any(), gomock.Eq(deliveryId)).
+		Return(db.GetUndeliveredWebhookDeliveryRow{ID: deliveryId, Url: "http://127.0.0.1:1", Active: false}, nil).
+		Times(1)
+	store.EXPECT().
+		RecordWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.RecordWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+			require.Equal(t, db.WebhookDeliveryStatusFAILED, arg.Status)
+			require.Equal(t, "webhook is disabled", arg.LastError)
+			return db.WebhookDelivery{}, nil
+		}).
+		Times(1)
+	webhooks := services.NewWebhookService(store)
+	require.NoError(t, webhooks.RunDeliverJob(context.Background(), []byte(fmt.Sprintf(`{"deliveryId":%q}`, deliveryId))))
+
+	// A delivery already made, or whose webhook was deleted, is not sent
+	store.EXPECT().
+		GetUndeliveredWebhookDelivery(gomock.Any(), gomock.Any()).
+		Return(db.GetUndeliveredWebhookDeliveryRow{}, pgx.ErrNoRows).
+		Times(1)
+	require.NoError(t, webhooks.RunDeliverJob(context.Background(), []byte(fmt.Sprintf(`{"deliveryId":%q}`, uuid.New()))))
+}
@@ -0,0 +1,499 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/mock"
+	db "github.com/slamchillz/getinstashop-ecommerce-api/internal/db/sqlc"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/events"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/mailer"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/services"
+	"github.com/slamchillz/getinstashop-ecommerce-api/internal/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCreateWishlist(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     gin.H
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Created",
+			body: gin.H{"name": " Birthday "},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWishlist(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateWishlistParams) (db.Wishlist, error) {
+						require.Equal(t, testUserId, arg.UserId)
+						require.Equal(t, "Birthday", arg.Name)
+						return db.Wishlist{ID: arg.ID, UserId: arg.UserId, Name: arg.Name}, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"items":[]`)
+			},
+		},
+		{
+			name: "Missing Name",
+			body: gin.H{"name": "  "},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWishlist(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "name must be between 1 and 100 characters")
+			},
+		},
+		{
+			name: "Duplicate Name",
+			body: gin.H{"name": "Birthday"},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWishlist(gomock.Any(), gomock.Any()).
+					Return(db.Wishlist{}, &pgconn.PgError{Code: "23505"}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/v1/me/wishlists", bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, false)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestAddWishlistItem(t *testing.T) {
+	wishlist := db.Wishlist{ID: uuid.New(), UserId: testUserId, Name: "Birthday"}
+	product := db.GetOneProductRow{ID: uuid.New(), Name: "Mug", Price: 12.5}
+
+	testCases := []struct {
+		name     string
+		body     gin.H
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Saved",
+			body: gin.H{"productId": product.ID},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserWishlist(gomock.Any(), gomock.Eq(db.GetUserWishlistParams{ID: wishlist.ID, UserId: testUserId})).
+					Return(wishlist, nil).
+					Times(1)
+				store.EXPECT().GetOneProduct(gomock.Any(), gomock.Eq(product.ID)).Return(product, nil).Times(1)
+				store.EXPECT().
+					AddWishlistItem(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.AddWishlistItemParams) (db.WishlistItem, error) {
+						require.Equal(t, wishlist.ID, arg.WishlistId)
+						require.Equal(t, product.ID, arg.ProductId)
+						require.Equal(t, int32(1), arg.Quantity)
+						return db.WishlistItem{ID: arg.ID, WishlistId: arg.WishlistId, ProductId: arg.ProductId, Quantity: arg.Quantity}, nil
+					}).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Product Id",
+			body: gin.H{"productId": "mug", "quantity": -1},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AddWishlistItem(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "must be a valid product id")
+				require.Contains(t, recorder.Body.String(), "quantity must be greater than zero")
+			},
+		},
+		{
+			name: "Not My Wishlist",
+			body: gin.H{"productId": product.ID},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserWishlist(gomock.Any(), gomock.Any()).Return(db.Wishlist{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().AddWishlistItem(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "wishlist not found")
+			},
+		},
+		{
+			name: "Archived Product",
+			body: gin.H{"productId": product.ID, "quantity": 2},
+			stubs: func(store *mockdb.MockStore) {
+				archived := product
+				archived.DeletedAt = pgtype.Timestamp{Time: time.Now().UTC(), Valid: true}
+				store.EXPECT().GetUserWishlist(gomock.Any(), gomock.Any()).Return(wishlist, nil).Times(1)
+				store.EXPECT().GetOneProduct(gomock.Any(), gomock.Eq(product.ID)).Return(archived, nil).Times(1)
+				store.EXPECT().AddWishlistItem(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "product not found")
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			reqBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/me/wishlists/%s/items", wishlist.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(reqBody))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, false)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestShareWishlist(t *testing.T) {
+	wishlist := db.Wishlist{ID: uuid.New(), UserId: testUserId, Name: "Birthday"}
+
+	t.Run("Shared", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			SetWishlistShareToken(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, arg db.SetWishlistShareTokenParams) (db.Wishlist, error) {
+				require.Equal(t, wishlist.ID, arg.ID)
+				require.Equal(t, testUserId, arg.UserId)
+				require.True(t, arg.ShareToken.Valid)
+				require.Len(t, arg.ShareToken.String, 32)
+				shared := wishlist
+				shared.ShareToken = arg.ShareToken
+				return shared, nil
+			}).
+			Times(1)
+
+		server := newTestServer(t, store)
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/me/wishlists/%s/share", wishlist.ID), nil)
+		require.NoError(t, err)
+		addAuthorization(t, request, server.TokenCreator(), testUserId, false)
+		server.Router().ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("Unshared", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			SetWishlistShareToken(gomock.Any(), gomock.Eq(db.SetWishlistShareTokenParams{ID: wishlist.ID, UserId: testUserId})).
+			Return(wishlist, nil).
+			Times(1)
+
+		server := newTestServer(t, store)
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/me/wishlists/%s/share", wishlist.ID), nil)
+		require.NoError(t, err)
+		addAuthorization(t, request, server.TokenCreator(), testUserId, false)
+		server.Router().ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusOK, recorder.Code)
+	})
+}
+
+func TestGetSharedWishlist(t *testing.T) {
+	wishlist := db.Wishlist{
+		ID:         uuid.New(),
+		UserId:     testUserId,
+		Name:       "Birthday",
+		ShareToken: pgtype.Text{String: "s3cr3t", Valid: true},
+	}
+	items := []db.GetWishlistItemsRow{{ID: uuid.New(), WishlistId: wishlist.ID, ProductId: uuid.New(), Quantity: 1, Name: "Mug", Price: 12.5, Stock: 3}}
+
+	testCases := []struct {
+		name     string
+		token    string
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Found Without Signing In",
+			token: "s3cr3t",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWishlistByShareToken(gomock.Any(), gomock.Eq(wishlist.ShareToken)).Return(wishlist, nil).Times(1)
+				store.EXPECT().GetWishlistItems(gomock.Any(), gomock.Eq([]uuid.UUID{wishlist.ID})).Return(items, nil).Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"name":"Birthday"`)
+				require.Contains(t, recorder.Body.String(), `"name":"Mug"`)
+				require.NotContains(t, recorder.Body.String(), testUserId.String())
+				require.NotContains(t, recorder.Body.String(), "shareToken")
+			},
+		},
+		{
+			name:  "Unknown Token",
+			token: "stale",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWishlistByShareToken(gomock.Any(), gomock.Any()).Return(db.Wishlist{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().GetWishlistItems(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/api/v1/wishlists/shared/"+tc.token, nil)
+			require.NoError(t, err)
+
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestOrderWishlist(t *testing.T) {
+	wishlist := db.Wishlist{ID: uuid.New(), UserId: testUserId, Name: "Birthday"}
+	mug, shirt := uuid.New(), uuid.New()
+	items := []db.GetWishlistItemsRow{
+		{ID: uuid.New(), WishlistId: wishlist.ID, ProductId: mug, Quantity: 2, Name: "Mug", Price: 12.5, Stock: 3},
+		{ID: uuid.New(), WishlistId: wishlist.ID, ProductId: shirt, Quantity: 1, Name: "Shirt", Price: 20, Stock: 0},
+	}
+
+	testCases := []struct {
+		name     string
+		body     gin.H
+		stubs    func(store *mockdb.MockStore)
+		response func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Ordered Selected Products",
+			body: gin.H{"productIds": []uuid.UUID{mug}},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserWishlist(gomock.Any(), gomock.Any()).Return(wishlist, nil).Times(1)
+				store.EXPECT().GetWishlistItems(gomock.Any(), gomock.Any()).Return(items, nil).Times(1)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateOrderTxParams) (db.Order, map[string]string, error, error) {
+						require.Equal(t, testUserId, arg.UserId)
+						require.Equal(t, []uuid.UUID{mug}, arg.ProductIds)
+						require.Equal(t, map[uuid.UUID]int32{mug: 2}, arg.Items)
+						return db.Order{ID: arg.ID, UserId: arg.UserId, Total: 25}, nil, nil, nil
+					}).
+					Times(1)
+				store.EXPECT().GetOrderTaxByOrderIds(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				store.EXPECT().GetOrderPromotionsByOrderIds(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				store.EXPECT().
+					DeleteWishlistItems(gomock.Any(), gomock.Eq(db.DeleteWishlistItemsParams{WishlistId: wishlist.ID, ProductIds: []uuid.UUID{mug}})).
+					Return(int64(1), nil).
+					Times(1)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Out Of Stock",
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserWishlist(gomock.Any(), gomock.Any()).Return(wishlist, nil).Times(1)
+				store.EXPECT().GetWishlistItems(gomock.Any(), gomock.Any()).Return(items, nil).Times(1)
+				store.EXPECT().
+					CreateOrderTx(gomock.Any(), gomock.Any()).
+					Return(db.Order{}, map[string]string{shirt.String(): "out of stock"}, nil, nil).
+					Times(1)
+				store.EXPECT().DeleteWishlistItems(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "out of stock")
+			},
+		},
+		{
+			name: "No Products On The Wishlist",
+			body: gin.H{"productIds": []uuid.UUID{uuid.New()}},
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserWishlist(gomock.Any(), gomock.Any()).Return(wishlist, nil).Times(1)
+				store.EXPECT().GetWishlistItems(gomock.Any(), gomock.Any()).Return(items, nil).Times(1)
+				store.EXPECT().CreateOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			response: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "none of the products are on the wishlist")
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			body := bytes.NewReader(nil)
+			if tc.body != nil {
+				reqBody, err := json.Marshal(tc.body)
+				require.NoError(t, err)
+				body = bytes.NewReader(reqBody)
+			}
+
+			url := fmt.Sprintf("/api/v1/me/wishlists/%s/order", wishlist.ID)
+			request, err := http.NewRequest(http.MethodPost, url, body)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenCreator(), testUserId, false)
+			server.Router().ServeHTTP(recorder, request)
+			tc.response(t, recorder)
+		})
+	}
+}
+
+func TestBackInStockEvents(t *testing.T) {
+	restocked := db.ProductBackInStock{Product: db.Product{ID: uuid.New(), Name: "Mug", Stock: 4}}
+	data, err := json.Marshal(restocked)
+	require.NoError(t, err)
+	event := events.Envelope{ID: uuid.New(), Type: restocked.EventType(), Data: data}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	watchers := []db.GetProductWatchersRow{{ID: uuid.New(), Email: "ada@example.com"}, {ID: uuid.New(), Email: "grace@example.com"}}
+	store.EXPECT().
+		GetProductWatchers(gomock.Any(), gomock.Eq(restocked.Product.ID)).
+		Return(watchers, nil).
+		Times(2)
+	var emails []string
+	enqueued := make(map[string]bool)
+	store.EXPECT().
+		CreateJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.CreateJobParams) (db.Job, error) {
+			require.Equal(t, services.SendBackInStockJob, arg.Kind)
+			var args types.BackInStockJobArgs
+			require.NoError(t, json.Unmarshal(arg.Payload, &args))
+			require.Equal(t, restocked.Product.ID, args.ProductId)
+			require.Contains(t, arg.IdempotencyKey, event.ID.String())
+			// The job table skips the keys it holds already
+			if enqueued[arg.IdempotencyKey] {
+				return db.Job{}, pgx.ErrNoRows
+			}
+			enqueued[arg.IdempotencyKey] = true
+			emails = append(emails, args.Email)
+			return db.Job{ID: arg.ID, Kind: arg.Kind}, nil
+		}).
+		Times(4)
+
+	bus := events.NewBus()
+	services.RegisterEventHandlers(bus, store, nil)
+	require.NoError(t, bus.Send(context.Background(), event))
+	// The event is relayed again, e.g. after another sink failed
+	require.NoError(t, bus.Send(context.Background(), event))
+	require.Equal(t, []string{"ada@example.com", "grace@example.com"}, emails)
+	require.Len(t, enqueued, 2)
+	for _, watcher := range watchers {
+		require.True(t, enqueued[fmt.Sprintf("%s:%s:%s", services.SendBackInStockJob, event.ID, watcher.ID)])
+	}
+}
+
+func TestRunBackInStockJob(t *testing.T) {
+	product := db.GetOneProductRow{ID: uuid.New(), Name: "Mug", Price: 12.5, Stock: 4}
+	payload, err := json.Marshal(types.BackInStockJobArgs{ProductId: product.ID, Email: "ada@example.com"})
+	require.NoError(t, err)
+
+	t.Run("Sent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetOneProduct(gomock.Any(), gomock.Eq(product.ID)).Return(product, nil).Times(1)
+
+		dir := t.TempDir()
+		wishlists := services.NewWishlistService(store, mailer.NewFileMailer(dir, "InstaShop <no-reply@instashop.local>"))
+		require.NoError(t, wishlists.RunBackInStockJob(context.Background(), payload))
+		files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		raw, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		msg, parts := readEmail(t, raw)
+		require.Equal(t, "Back in stock: Mug", msg.Header.Get("Subject"))
+		require.Contains(t, msg.Header.Get("To"), "ada@example.com")
+		require.Contains(t, parts["text/plain"], "Price: 12.50")
+		require.Contains(t, parts["text/html"], "on your wishlist")
+	})
+
+	t.Run("Sold Out Again", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		soldOut := product
+		soldOut.Stock = 0
+		store.EXPECT().GetOneProduct(gomock.Any(), gomock.Eq(product.ID)).Return(soldOut, nil).Times(1)
+
+		wishlists := services.NewWishlistService(store, failingMailer{})
+		require.NoError(t, wishlists.RunBackInStockJob(context.Background(), payload))
+	})
+
+	t.Run("Retried", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetOneProduct(gomock.Any(), gomock.Eq(product.ID)).Return(product, nil).Times(1)
+
+		wishlists := services.NewWishlistService(store, failingMailer{})
+		require.ErrorContains(t, wishlists.RunBackInStockJob(context.Background(), payload), "connection refused")
+	})
+}